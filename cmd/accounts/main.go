@@ -1,7 +1,7 @@
 // File: cmd/accounts/main.go
 // Project: Terminal Velocity
 // Description: Account management CLI tool
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -15,11 +15,15 @@
 //   create      Create a new player account (with password or SSH key)
 //   add-key     Add SSH public key to existing account
 //   list        List player accounts (currently shows online players only)
+//   grant-admin Grant an admin role (or revoke with -role player)
+//   reset-2fa   Remove two-factor authentication from a locked-out account
 //
 // Command-Line Usage:
 //   accounts create -username <name> [-email <email>] [-password | -ssh-key <file>]
 //   accounts add-key -username <name> -key <file>
 //   accounts list [-v]
+//   accounts grant-admin -username <name> -role <moderator|admin|superadmin|player>
+//   accounts reset-2fa -username <name>
 //
 // Example Usage:
 //   # Create account with password (prompts for password)
@@ -37,6 +41,9 @@
 //   # List online players with details (verbose)
 //   ./accounts list -v
 //
+//   # Make alice a moderator (takes effect on the next server start)
+//   ./accounts grant-admin -username alice -role moderator
+//
 // Authentication Methods:
 //   1. Password-based: User provides password (prompted securely, not echoed)
 //   2. SSH key only: User uploads public key, authenticates via SSH protocol
@@ -76,7 +83,9 @@ import (
 	"syscall"
 
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/validation"
+	"github.com/google/uuid"
 	"golang.org/x/term"
 )
 
@@ -112,6 +121,13 @@ func main() {
 	listCmd := flag.NewFlagSet("list", flag.ExitOnError)
 	listVerbose := listCmd.Bool("v", false, "Verbose output")
 
+	grantCmd := flag.NewFlagSet("grant-admin", flag.ExitOnError)
+	grantUsername := grantCmd.String("username", "", "Username to grant the role to")
+	grantRole := grantCmd.String("role", string(models.RoleModerator), "Admin role (moderator, admin, superadmin, or player to revoke)")
+
+	reset2FACmd := flag.NewFlagSet("reset-2fa", flag.ExitOnError)
+	reset2FAUsername := reset2FACmd.String("username", "", "Username to remove 2FA from")
+
 	// Check for subcommand
 	if len(os.Args) < 2 {
 		printUsage()
@@ -193,6 +209,41 @@ func main() {
 			os.Exit(1)
 		}
 
+	case "grant-admin":
+		if err := grantCmd.Parse(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to parse flags: %v\n", err)
+			os.Exit(1)
+		}
+		if *grantUsername == "" {
+			fmt.Fprintln(os.Stderr, "Error: -username is required")
+			grantCmd.Usage()
+			os.Exit(1)
+		}
+
+		adminRepo := database.NewAdminRepository(db)
+		err := grantAdminRole(ctx, playerRepo, adminRepo, *grantUsername, models.AdminRole(*grantRole))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to grant admin role: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "reset-2fa":
+		if err := reset2FACmd.Parse(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to parse flags: %v\n", err)
+			os.Exit(1)
+		}
+		if *reset2FAUsername == "" {
+			fmt.Fprintln(os.Stderr, "Error: -username is required")
+			reset2FACmd.Usage()
+			os.Exit(1)
+		}
+
+		securityRepo := database.NewSecurityRepository(db)
+		if err := resetTwoFactor(ctx, playerRepo, securityRepo, *reset2FAUsername); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to reset 2FA: %v\n", err)
+			os.Exit(1)
+		}
+
 	default:
 		printUsage()
 		os.Exit(1)
@@ -216,6 +267,8 @@ func printUsage() {
 	fmt.Println("  accounts create -username <name> [-email <email>] [-password | -ssh-key <file>]")
 	fmt.Println("  accounts add-key -username <name> -key <file>")
 	fmt.Println("  accounts list [-v]")
+	fmt.Println("  accounts grant-admin -username <name> -role <moderator|admin|superadmin|player>")
+	fmt.Println("  accounts reset-2fa -username <name>")
 	fmt.Println("\nExamples:")
 	fmt.Println("  # Create account with password (prompts securely)")
 	fmt.Println("  accounts create -username alice -email alice@example.com")
@@ -228,6 +281,9 @@ func printUsage() {
 	fmt.Println("")
 	fmt.Println("  # List all online accounts with details")
 	fmt.Println("  accounts list -v")
+	fmt.Println("")
+	fmt.Println("  # Make alice a moderator")
+	fmt.Println("  accounts grant-admin -username alice -role moderator")
 }
 
 // createAccountWithPassword creates a new player account with password authentication.
@@ -497,3 +553,52 @@ func listAccounts(ctx context.Context, repo *database.PlayerRepository, verbose
 
 	return nil
 }
+
+// grantAdminRole stores an admin role for an existing account.
+//
+// This is how the first admin is created: roles granted here are recorded
+// with no granting admin, and the server loads them on startup. Passing
+// the player role revokes admin access instead.
+func grantAdminRole(ctx context.Context, playerRepo *database.PlayerRepository, adminRepo *database.AdminRepository, username string, role models.AdminRole) error {
+	switch role {
+	case models.RolePlayer, models.RoleModerator, models.RoleAdmin, models.RoleSuperAdmin:
+	default:
+		return fmt.Errorf("unknown role %q", role)
+	}
+
+	player, err := playerRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("player not found: %w", err)
+	}
+
+	if role == models.RolePlayer {
+		if err := adminRepo.DeactivateAdmin(ctx, player.ID); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Revoked admin access for %s\n", player.Username)
+		return nil
+	}
+
+	admin := models.NewAdminUser(player.ID, player.Username, role, uuid.Nil)
+	if err := adminRepo.UpsertAdmin(ctx, admin); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Granted %s role to %s\n", role, player.Username)
+	return nil
+}
+
+// resetTwoFactor removes a player's two-factor configuration so they can
+// log in with their password or key alone and enroll again. Use it for
+// players who lost both their authenticator and their backup codes.
+func resetTwoFactor(ctx context.Context, playerRepo *database.PlayerRepository, securityRepo *database.SecurityRepository, username string) error {
+	player, err := playerRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("player not found: %w", err)
+	}
+
+	if err := securityRepo.DeleteTwoFactor(ctx, player.ID); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Removed two-factor authentication from %s\n", player.Username)
+	return nil
+}
@@ -1,21 +1,24 @@
 // File: cmd/genmap/main.go
 // Project: Terminal Velocity
 // Description: Universe generation and database population tool
-// Version: 1.2.0
+// Version: 1.3.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
 // Package main provides the universe generation CLI tool for Terminal Velocity.
 //
 // Tool Overview:
-// This utility generates procedural star system universes and optionally saves
-// them to the database. It's used for initial server setup and universe resets.
+// This utility generates procedural star system universes, writes them to a
+// versioned JSON/YAML universe file and optionally saves them to the database.
+// It's used for initial server setup and universe resets.
 //
 // Features:
 //   - Generate N star systems with realistic distribution
 //   - Create jump routes using Minimum Spanning Tree algorithm
 //   - Assign tech levels, governments, and planets
 //   - Display detailed statistics and visualizations
+//   - Export to a universe file that can be reviewed and checked in
+//   - Load a universe file instead of generating one
 //   - Save directly to PostgreSQL database
 //   - Preview before saving with confirmation prompt
 //
@@ -25,6 +28,8 @@
 //   -stats              Show detailed statistics after generation
 //   -systems-list       List all generated systems
 //   -faction <id>       Filter system list by faction
+//   -in <file>          Load universe from a .json/.yaml file instead of generating
+//   -out <file>         Write universe to a .json/.yaml file
 //   -save               Save universe to database (interactive)
 //   -db-host <host>     Database host (default: localhost)
 //   -db-port <port>     Database port (default: 5432)
@@ -42,6 +47,12 @@
 //   # List all systems filtered by faction
 //   ./genmap -systems 100 -systems-list -faction united_earth_federation
 //
+//   # Generate and export a universe file for review
+//   ./genmap -systems 100 -seed 12345 -out galaxy.json
+//
+//   # Load a checked-in universe file into the database
+//   ./genmap -in galaxy.json -save -db-password mypassword
+//
 //   # Generate and save to database (with confirmation)
 //   ./genmap -systems 100 -save -db-password mypassword
 //
@@ -60,6 +71,13 @@
 //   4. Assign tech levels (higher in core, lower at edges)
 //   5. Distribute 6 NPC factions across systems
 //   6. Generate planets for each system with services
+//   7. Seed initial markets from the same seed
+//
+// Universe Files:
+// -out writes the format chosen by the file extension (.json, .yaml, .yml).
+// Files are sorted so an unchanged universe always produces the same bytes,
+// and carry the system and planet IDs, so loading one reproduces the galaxy
+// exactly on any environment. See internal/game/universe/file.go.
 //
 // Database Integration:
 // When -save flag is used:
@@ -67,14 +85,13 @@
 //   2. Checks for existing universe data
 //   3. Prompts for confirmation if data exists
 //   4. Clears old data (systems, planets, connections)
-//   5. Inserts new universe data with progress updates
-//   6. Commits transaction
+//   5. Loads the universe with DB.ImportUniverse (systems, planets, routes,
+//      markets), which is idempotent and safe to re-run after a failure
 //
 // Safety Features:
 //   - Confirmation prompt before overwriting existing universe
 //   - Transaction rollback on error
 //   - Foreign key constraint handling
-//   - Progress indicators for large datasets
 //
 // Output Format:
 // The tool produces beautiful ASCII art visualizations:
@@ -96,6 +113,7 @@ import (
 	"sort"
 
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/game/trading"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/game/universe"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 )
@@ -105,9 +123,10 @@ import (
 // Execution Flow:
 //   1. Parse command-line flags
 //   2. Display banner
-//   3. Create universe generator with configuration
-//   4. Generate universe (systems, planets, connections)
-//   5. Display statistics and visualizations
+//   3. Generate universe (systems, planets, connections, markets) or load
+//      it from a universe file
+//   4. Display statistics and visualizations
+//   5. Optionally write a universe file
 //   6. Optionally save to database (with confirmation)
 //
 // Error Handling:
@@ -122,6 +141,8 @@ func main() {
 		showStats     = flag.Bool("stats", false, "Show detailed statistics")
 		showSystems   = flag.Bool("systems-list", false, "List all systems")
 		factionFilter = flag.String("faction", "", "Filter systems by faction")
+		inFile        = flag.String("in", "", "Load universe from a .json/.yaml file")
+		outFile       = flag.String("out", "", "Write universe to a .json/.yaml file")
 		save          = flag.Bool("save", false, "Save universe to database")
 		dbHost        = flag.String("db-host", "localhost", "Database host")
 		dbPort        = flag.Int("db-port", 5432, "Database port")
@@ -136,27 +157,45 @@ func main() {
 	fmt.Println("═══════════════════════════════════════════════════════════")
 	fmt.Println()
 
-	// Create generator
-	config := universe.DefaultConfig()
-	config.NumSystems = *numSystems
-	config.Seed = *seed
+	var file *universe.File
+	if *inFile != "" {
+		fmt.Printf("Loading universe from %s\n", *inFile)
+		fmt.Println()
 
-	fmt.Printf("Generating universe with %d systems", *numSystems)
-	if *seed != 0 {
-		fmt.Printf(" (seed: %d)", *seed)
-	}
-	fmt.Println()
-	fmt.Println()
+		var err error
+		file, err = universe.LoadFile(*inFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading universe: %v\n", err)
+			os.Exit(1)
+		}
 
-	gen := universe.NewGenerator(config)
-	univ, err := gen.Generate()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error generating universe: %v\n", err)
-		os.Exit(1)
-	}
+		fmt.Println("✓ Universe loaded successfully!")
+		fmt.Println()
+	} else {
+		// Create generator
+		config := universe.DefaultConfig()
+		config.NumSystems = *numSystems
+		config.Seed = *seed
+
+		fmt.Printf("Generating universe with %d systems", *numSystems)
+		if *seed != 0 {
+			fmt.Printf(" (seed: %d)", *seed)
+		}
+		fmt.Println()
+		fmt.Println()
 
-	fmt.Println("✓ Universe generated successfully!")
-	fmt.Println()
+		gen := universe.NewGenerator(config)
+		generated, err := gen.Generate()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating universe: %v\n", err)
+			os.Exit(1)
+		}
+		file = universe.NewFile(generated, gen.Seed(), generateMarkets(generated, gen.Seed()))
+
+		fmt.Println("✓ Universe generated successfully!")
+		fmt.Println()
+	}
+	univ := file.Universe()
 
 	// Show statistics
 	showUniverseStats(univ)
@@ -171,6 +210,16 @@ func main() {
 		showSystemsList(univ, *factionFilter)
 	}
 
+	// Write universe file if requested
+	if *outFile != "" {
+		if err := file.SaveFile(*outFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing universe file: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println()
+		fmt.Printf("✓ Universe written to %s\n", *outFile)
+	}
+
 	// Save to database if requested
 	if *save {
 		fmt.Println()
@@ -179,7 +228,7 @@ func main() {
 		fmt.Println("═══════════════════════════════════════════════════════════")
 		fmt.Println()
 
-		if err := saveToDatabase(univ, *dbHost, *dbPort, *dbUser, *dbPassword, *dbName); err != nil {
+		if err := saveToDatabase(file, *dbHost, *dbPort, *dbUser, *dbPassword, *dbName); err != nil {
 			fmt.Fprintf(os.Stderr, "Error saving universe to database: %v\n", err)
 			os.Exit(1)
 		}
@@ -429,19 +478,45 @@ func showSystemsList(univ *universe.Universe, factionFilter string) {
 	fmt.Println()
 }
 
-// saveToDatabase saves the generated universe to PostgreSQL database.
+// generateMarkets seeds initial market prices for every planet.
+//
+// Planets are visited in name order with a pricing engine seeded from the
+// universe seed, so the same universe always gets the same markets.
+func generateMarkets(univ *universe.Universe, seed int64) []models.MarketPrice {
+	systems := make([]*models.StarSystem, 0, len(univ.Systems))
+	for _, system := range univ.Systems {
+		systems = append(systems, system)
+	}
+	sort.Slice(systems, func(i, j int) bool {
+		return systems[i].Name < systems[j].Name
+	})
+
+	engine := trading.NewSeededPricingEngine(seed)
+	var markets []models.MarketPrice
+	for _, system := range systems {
+		planets := append([]models.Planet(nil), system.Planets...)
+		sort.Slice(planets, func(i, j int) bool {
+			return planets[i].Name < planets[j].Name
+		})
+		for i := range planets {
+			markets = append(markets, engine.GenerateInitialMarket(&planets[i], system.GovernmentID)...)
+		}
+	}
+
+	return markets
+}
+
+// saveToDatabase saves the universe to PostgreSQL database.
 //
 // Process:
 //   1. Connect to database with provided credentials
 //   2. Check for existing universe data
 //   3. Prompt user for confirmation if data exists
 //   4. Clear old universe data (foreign key aware)
-//   5. Insert star systems with progress updates
-//   6. Insert planets with progress updates
-//   7. Insert system connections (jump routes)
+//   5. Load systems, planets, jump routes and markets via DB.ImportUniverse
 //
 // Parameters:
-//   - univ: Generated universe with systems, planets, connections
+//   - file: Universe file (generated or loaded with -in)
 //   - host: Database hostname
 //   - port: Database port number
 //   - user: Database username
@@ -454,17 +529,9 @@ func showSystemsList(univ *universe.Universe, factionFilter string) {
 // Safety Features:
 //   - Interactive confirmation before overwriting
 //   - Foreign key constraint handling (delete order: connections, planets, systems)
-//   - Progress indicators for large datasets (every 20 systems, 50 planets)
-//   - Connection bidirectionality handling (only insert one direction)
-//
-// Transaction Handling:
-// Currently uses individual queries (not in transaction).
-// Future enhancement: Wrap in transaction for atomicity.
-//
-// Error Handling:
-// Any database error during insert causes immediate return with error.
-// Partial inserts may remain in database (no rollback currently).
-func saveToDatabase(univ *universe.Universe, host string, port int, user, password, dbName string) error {
+//   - Each bulk step runs in its own transaction; the import is idempotent,
+//     so re-running after a failure completes a partial load
+func saveToDatabase(file *universe.File, host string, port int, user, password, dbName string) error {
 	ctx := context.Background()
 
 	// Connect to database
@@ -490,13 +557,13 @@ func saveToDatabase(univ *universe.Universe, host string, port int, user, passwo
 	fmt.Println()
 
 	// Check if universe already exists
-	existingSystems, err := systemRepo.ListSystems(ctx)
+	existingSystems, err := systemRepo.CountSystems(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to check existing systems: %w", err)
 	}
 
-	if len(existingSystems) > 0 {
-		fmt.Printf("⚠️  WARNING: Database already contains %d systems.\n", len(existingSystems))
+	if existingSystems > 0 {
+		fmt.Printf("⚠️  WARNING: Database already contains %d systems.\n", existingSystems)
 		fmt.Print("Continue and replace all systems? [y/N]: ")
 		var response string
 		fmt.Scanln(&response)
@@ -515,51 +582,11 @@ func saveToDatabase(univ *universe.Universe, host string, port int, user, passwo
 		fmt.Println()
 	}
 
-	// Insert star systems
-	fmt.Printf("Inserting %d star systems...\n", len(univ.Systems))
-	systemCount := 0
-	for _, system := range univ.Systems {
-		if err := systemRepo.CreateSystem(ctx, system); err != nil {
-			return fmt.Errorf("failed to insert system %s: %w", system.Name, err)
-		}
-		systemCount++
-		if systemCount%20 == 0 {
-			fmt.Printf("  %d/%d systems inserted...\n", systemCount, len(univ.Systems))
-		}
-	}
-	fmt.Printf("✓ Inserted %d systems\n", systemCount)
-	fmt.Println()
-
-	// Insert planets
-	fmt.Printf("Inserting %d planets...\n", len(univ.Planets))
-	planetCount := 0
-	for _, planet := range univ.Planets {
-		if err := systemRepo.CreatePlanet(ctx, planet); err != nil {
-			return fmt.Errorf("failed to insert planet %s: %w", planet.Name, err)
-		}
-		planetCount++
-		if planetCount%50 == 0 {
-			fmt.Printf("  %d/%d planets inserted...\n", planetCount, len(univ.Planets))
-		}
-	}
-	fmt.Printf("✓ Inserted %d planets\n", planetCount)
-	fmt.Println()
-
-	// Insert system connections
-	fmt.Println("Inserting system connections...")
-	connectionCount := 0
-	for _, system := range univ.Systems {
-		for _, connectedID := range system.ConnectedSystems {
-			// Only insert one direction to avoid duplicates
-			if system.ID.String() < connectedID.String() {
-				if err := systemRepo.CreateJumpRoute(ctx, system.ID, connectedID); err != nil {
-					return fmt.Errorf("failed to insert connection: %w", err)
-				}
-				connectionCount++
-			}
-		}
+	fmt.Printf("Loading %d systems and %d jump routes...\n", len(file.Systems), len(file.Routes))
+	if err := db.ImportUniverse(ctx, file); err != nil {
+		return err
 	}
-	fmt.Printf("✓ Inserted %d connections\n", connectionCount)
+	fmt.Println("✓ Loaded systems, planets, jump routes and markets")
 
 	return nil
 }
@@ -0,0 +1,211 @@
+// File: cmd/migrate/main.go
+// Project: Terminal Velocity
+// Description: Database schema migration CLI tool
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+// Package main provides the schema migration CLI tool for Terminal Velocity.
+//
+// Subcommands:
+//   status   List migrations and whether each is applied
+//   up       Apply all pending migrations
+//   down     Revert the most recent migration(s)
+//   verify   Report drift between the live schema and the applied migrations
+//
+// Command-Line Usage:
+//   migrate status
+//   migrate up
+//   migrate down [-steps <n>]
+//   migrate verify
+//
+// Migrations are embedded in the binary (internal/database/migrations). The
+// game server also applies pending migrations on startup; an advisory lock
+// keeps concurrent runs from colliding.
+//
+// Database Connection:
+// Uses database.DefaultConfig(), which reads DB_HOST, DB_PORT, DB_USER,
+// DB_PASSWORD and DB_NAME from the environment.
+//
+// Exit Codes:
+//   0 - Success (verify: no drift)
+//   1 - Argument, database or migration error (verify: drift detected)
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+)
+
+func main() {
+	downCmd := flag.NewFlagSet("down", flag.ExitOnError)
+	downSteps := downCmd.Int("steps", 1, "Number of migrations to revert")
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	command := os.Args[1]
+	switch command {
+	case "status", "up", "verify":
+	case "down":
+		if err := downCmd.Parse(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to parse flags: %v\n", err)
+			os.Exit(1)
+		}
+		if *downSteps < 1 {
+			fmt.Fprintln(os.Stderr, "Error: -steps must be at least 1")
+			os.Exit(1)
+		}
+	default:
+		printUsage()
+		os.Exit(1)
+	}
+
+	db, err := database.NewDB(database.DefaultConfig())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load migrations: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+
+	switch command {
+	case "status":
+		err = showStatus(ctx, migrator)
+	case "up":
+		err = migrateUp(ctx, migrator)
+	case "down":
+		err = migrateDown(ctx, migrator, *downSteps)
+	case "verify":
+		var clean bool
+		clean, err = verifySchema(ctx, migrator)
+		if err == nil && !clean {
+			db.Close()
+			os.Exit(1)
+		}
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		db.Close()
+		os.Exit(1)
+	}
+}
+
+// printUsage displays help information for the migration tool
+func printUsage() {
+	fmt.Println("Terminal Velocity - Schema Migrations")
+	fmt.Println("\nUsage:")
+	fmt.Println("  migrate status             Show applied and pending migrations")
+	fmt.Println("  migrate up                 Apply all pending migrations")
+	fmt.Println("  migrate down [-steps <n>]  Revert the last n migrations (default 1)")
+	fmt.Println("  migrate verify             Compare the live schema with the applied migrations")
+}
+
+// showStatus prints every migration with its state
+func showStatus(ctx context.Context, migrator *database.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-8s %-32s %-10s %s\n", "VERSION", "NAME", "STATE", "APPLIED AT")
+	fmt.Println(strings.Repeat("-", 72))
+	for _, s := range statuses {
+		state, appliedAt := "pending", ""
+		switch {
+		case s.Unknown:
+			state = "unknown"
+		case s.Modified:
+			state = "modified"
+		case s.Applied:
+			state = "applied"
+		}
+		if s.Applied {
+			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("%04d     %-32s %-10s %s\n", s.Version, s.Name, state, appliedAt)
+	}
+
+	return nil
+}
+
+// migrateUp applies pending migrations
+func migrateUp(ctx context.Context, migrator *database.Migrator) error {
+	applied, err := migrator.Up(ctx)
+	for _, m := range applied {
+		fmt.Printf("✓ Applied %04d_%s\n", m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		fmt.Println("Schema is up to date")
+	}
+	return nil
+}
+
+// migrateDown reverts the most recent migrations
+func migrateDown(ctx context.Context, migrator *database.Migrator, steps int) error {
+	reverted, err := migrator.Down(ctx, steps)
+	for _, m := range reverted {
+		fmt.Printf("✓ Reverted %04d_%s\n", m.Version, m.Name)
+	}
+	return err
+}
+
+// verifySchema prints schema drift and returns true if there is none
+func verifySchema(ctx context.Context, migrator *database.Migrator) (bool, error) {
+	drift, err := migrator.Verify(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	printVersions := func(label string, versions []int) {
+		for _, v := range versions {
+			fmt.Printf("  %s migration %04d\n", label, v)
+		}
+	}
+	printItems := func(label string, items []string) {
+		for _, item := range items {
+			fmt.Printf("  %s %s\n", label, item)
+		}
+	}
+
+	if len(drift.PendingMigrations) > 0 {
+		fmt.Println("Pending:")
+		printVersions("pending", drift.PendingMigrations)
+	}
+
+	if !drift.HasDrift() {
+		fmt.Println("✓ Live schema matches the applied migrations")
+		return true, nil
+	}
+
+	fmt.Println("✗ Schema drift detected:")
+	printVersions("modified", drift.ModifiedMigrations)
+	printVersions("unknown", drift.UnknownMigrations)
+	printItems("missing table", drift.MissingTables)
+	printItems("extra table", drift.ExtraTables)
+	printItems("missing column", drift.MissingColumns)
+	printItems("extra column", drift.ExtraColumns)
+	printItems("changed column", drift.ChangedColumns)
+	printItems("missing index", drift.MissingIndexes)
+	printItems("extra index", drift.ExtraIndexes)
+
+	return false, nil
+}
@@ -1,7 +1,7 @@
 // File: cmd/server/main.go
 // Project: Terminal Velocity
 // Description: Main SSH game server entry point
-// Version: 1.1.0
+// Version: 1.3.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -10,6 +10,7 @@
 // Server Overview:
 // This binary starts the Terminal Velocity multiplayer space trading game server.
 // Players connect via SSH and play through a terminal user interface (TUI).
+// With -headless, only the game API is served (over gRPC) for remote gateways.
 //
 // Execution Flow:
 //   1. Parse command-line flags (config file, port, logging)
@@ -25,6 +26,14 @@
 //   -log-level <level> Logging verbosity: debug, info, warn, error (default: info)
 //   -log-file <path>   Log file path, empty for stdout only (default: stdout)
 //   -version           Show version information and exit
+//   -headless          Run the game server without SSH, serving the API over gRPC
+//   -grpc-addr <addr>  gRPC listen address in headless mode (default: 127.0.0.1:50051;
+//                      other addresses require mutual TLS)
+//   -tls-cert <file>   TLS certificate for the gRPC server (headless mode)
+//   -tls-key <file>    TLS private key for the gRPC server (headless mode)
+//   -tls-ca <file>     CA bundle; when set, gRPC clients must present a certificate
+//   -export-content <file>  Write the built-in content pack (.yaml/.json) and exit
+//   -check-content <file>   Validate a content pack file and exit
 //
 // Example Usage:
 //   # Start with defaults (port 2222, stdout logging)
@@ -39,6 +48,13 @@
 //   # Check version
 //   ./server -version
 //
+//   # Run the game server headless with TLS (SSH gateways connect via gRPC)
+//   ./server -headless -grpc-addr :50051 -tls-cert server.crt -tls-key server.key -tls-ca ca.crt
+//
+//   # Start a content pack from the built-in definitions, then check edits
+//   ./server -export-content configs/content.yaml
+//   ./server -check-content configs/content.yaml
+//
 // Configuration:
 // Server reads configuration from YAML file with fallback to defaults:
 //   - Database connection (host, port, credentials)
@@ -80,6 +96,8 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/api"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/content"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/server"
 )
@@ -135,6 +153,13 @@ func main() {
 		port        = flag.Int("port", 2222, "SSH server port")
 		logLevel    = flag.String("log-level", "info", "Log level (debug, info, warn, error)")
 		logFile     = flag.String("log-file", "", "Log file path (empty for stdout only)")
+		headless    = flag.Bool("headless", false, "Run the game server without SSH, serving the API over gRPC")
+		grpcAddr    = flag.String("grpc-addr", "127.0.0.1:50051", "gRPC listen address (headless mode; non-loopback needs mutual TLS)")
+		tlsCert     = flag.String("tls-cert", "", "TLS certificate file for gRPC (headless mode)")
+		tlsKey      = flag.String("tls-key", "", "TLS private key file for gRPC (headless mode)")
+		tlsCA       = flag.String("tls-ca", "", "CA file for verifying gRPC client certificates (headless mode)")
+		exportPack  = flag.String("export-content", "", "Write the built-in content pack to a .yaml/.json file and exit")
+		checkPack   = flag.String("check-content", "", "Validate a content pack file and exit")
 	)
 	flag.Parse()
 
@@ -160,6 +185,24 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Content pack tools
+	if *exportPack != "" {
+		if err := content.ExportDefault(*exportPack); err != nil {
+			log.Fatal("Failed to export content pack: %v", err)
+		}
+		fmt.Printf("Wrote built-in content pack to %s\n", *exportPack)
+		os.Exit(0)
+	}
+	if *checkPack != "" {
+		pack, err := content.LoadFile(*checkPack)
+		if err != nil {
+			log.Fatal("Content pack check failed: %v", err)
+		}
+		fmt.Printf("Content pack %s %s is valid: %d ship types, %d commodities, %d weapons, %d outfits, %d equipment\n",
+			pack.Name, pack.Version, len(pack.ShipTypes), len(pack.Commodities), len(pack.Weapons), len(pack.Outfits), len(pack.Equipment))
+		os.Exit(0)
+	}
+
 	// Create context that listens for termination signals
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -176,6 +219,26 @@ func main() {
 	// Initialize and start server
 	log.Info("Starting Terminal Velocity server v%s (commit: %s, built: %s)", version, commit, date)
 
+	if *headless {
+		var tlsConfig *api.TLSConfig
+		if *tlsCert != "" || *tlsKey != "" || *tlsCA != "" {
+			tlsConfig = &api.TLSConfig{CertFile: *tlsCert, KeyFile: *tlsKey, CAFile: *tlsCA}
+		}
+
+		srv, err := server.NewHeadlessServer(*configFile, *grpcAddr, tlsConfig)
+		if err != nil {
+			log.Fatal("Failed to create headless server: %v", err)
+		}
+
+		log.Info("Headless server initialized, serving gRPC on %s", *grpcAddr)
+		if err := srv.Start(ctx); err != nil {
+			log.Fatal("Server error: %v", err)
+		}
+
+		log.Info("Server shutdown complete")
+		return
+	}
+
 	srv, err := server.NewServer(*configFile, *port)
 	if err != nil {
 		log.Fatal("Failed to create server: %v", err)
@@ -1,7 +1,7 @@
 // File: internal/admin/manager.go
 // Project: Terminal Velocity
 // Description: Server administration and monitoring
-// Version: 1.0.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -10,16 +10,30 @@ package admin
 import (
 	"context"
 	"errors"
+	"fmt"
 	"runtime"
 	"sync"
 	"time"
 
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/content"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	"github.com/google/uuid"
 )
 
-// Manager handles server administration
+var log = logger.WithComponent("Admin")
+
+// actionHistoryLimit is how many audit entries Load brings back into memory
+const actionHistoryLimit = 1000
+
+// Manager handles server administration.
+//
+// Admins, bans, mutes and the audit log are persisted through the admin
+// repository and cached in memory; Load restores the cache on startup.
+// Every change is written to the database before the cache is updated, so a
+// failed write leaves both unchanged. With a nil repository the manager
+// works purely in memory.
 
 type Manager struct {
 	mu sync.RWMutex
@@ -42,6 +56,10 @@ type Manager struct {
 
 	// Repositories
 	playerRepo *database.PlayerRepository
+	adminRepo  *database.AdminRepository
+
+	// Content pack loader (nil = content reload unavailable)
+	contentLoader *content.Loader
 
 	// Metrics collection
 	metricsInterval time.Duration
@@ -51,7 +69,7 @@ type Manager struct {
 }
 
 // NewManager creates a new admin manager
-func NewManager(playerRepo *database.PlayerRepository) *Manager {
+func NewManager(playerRepo *database.PlayerRepository, adminRepo *database.AdminRepository) *Manager {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	m := &Manager{
@@ -62,6 +80,7 @@ func NewManager(playerRepo *database.PlayerRepository) *Manager {
 		settings:        models.GetDefaultServerSettings(),
 		metrics:         &models.ServerMetrics{},
 		playerRepo:      playerRepo,
+		adminRepo:       adminRepo,
 		metricsInterval: 10 * time.Second,
 		ctx:             ctx,
 		cancel:          cancel,
@@ -74,6 +93,55 @@ func NewManager(playerRepo *database.PlayerRepository) *Manager {
 	return m
 }
 
+// Load restores admins, active bans and mutes, and recent audit entries
+// from the database
+func (m *Manager) Load(ctx context.Context) error {
+	if m.adminRepo == nil {
+		return nil
+	}
+
+	admins, err := m.adminRepo.ListActiveAdmins(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load admins: %w", err)
+	}
+	bans, err := m.adminRepo.ListActiveBans(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load bans: %w", err)
+	}
+	mutes, err := m.adminRepo.ListActiveMutes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load mutes: %w", err)
+	}
+	actions, err := m.adminRepo.ListRecentActions(ctx, actionHistoryLimit)
+	if err != nil {
+		return fmt.Errorf("failed to load admin actions: %w", err)
+	}
+	settings, err := m.adminRepo.GetServerSettings(ctx)
+	if err != nil && !errors.Is(err, database.ErrServerSettingsNotFound) {
+		return fmt.Errorf("failed to load server settings: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, admin := range admins {
+		m.admins[admin.PlayerID] = admin
+	}
+	for _, ban := range bans {
+		m.bans[ban.PlayerID] = ban
+	}
+	for _, mute := range mutes {
+		m.mutes[mute.PlayerID] = mute
+	}
+	m.actionLog = append(actions, m.actionLog...)
+	if settings != nil {
+		m.settings = settings
+	}
+
+	log.Info("Loaded moderation state: admins=%d, bans=%d, mutes=%d", len(admins), len(bans), len(mutes))
+	return nil
+}
+
 // AddAdmin adds an admin user
 func (m *Manager) AddAdmin(playerID uuid.UUID, username string, role models.AdminRole, createdBy uuid.UUID) (*models.AdminUser, error) {
 	m.mu.Lock()
@@ -85,6 +153,11 @@ func (m *Manager) AddAdmin(playerID uuid.UUID, username string, role models.Admi
 	}
 
 	admin := models.NewAdminUser(playerID, username, role, createdBy)
+	if m.adminRepo != nil {
+		if err := m.adminRepo.UpsertAdmin(m.ctx, admin); err != nil {
+			return nil, err
+		}
+	}
 	m.admins[playerID] = admin
 
 	// Log action
@@ -113,6 +186,11 @@ func (m *Manager) RemoveAdmin(adminID uuid.UUID, targetID uuid.UUID) error {
 		return errors.New("cannot remove superadmin")
 	}
 
+	if m.adminRepo != nil {
+		if err := m.adminRepo.DeactivateAdmin(m.ctx, targetID); err != nil {
+			return err
+		}
+	}
 	delete(m.admins, targetID)
 
 	// Log action
@@ -168,6 +246,11 @@ func (m *Manager) BanPlayer(
 
 	// Create ban
 	ban := models.NewPlayerBan(targetID, username, ipAddress, reason, adminID, duration)
+	if m.adminRepo != nil {
+		if err := m.adminRepo.CreateBan(m.ctx, ban); err != nil {
+			return err
+		}
+	}
 	m.bans[targetID] = ban
 
 	// Log action
@@ -192,6 +275,11 @@ func (m *Manager) UnbanPlayer(adminID uuid.UUID, targetID uuid.UUID) error {
 		return errors.New("player not banned")
 	}
 
+	if m.adminRepo != nil {
+		if err := m.adminRepo.DeactivateBans(m.ctx, targetID); err != nil {
+			return err
+		}
+	}
 	ban.IsActive = false
 
 	// Log action
@@ -218,6 +306,41 @@ func (m *Manager) IsBanned(playerID uuid.UUID) bool {
 	return true
 }
 
+// CheckBan returns the player's active ban, or nil if they may log in.
+//
+// With a repository the database is consulted, so bans placed by another
+// server process are honored; otherwise the in-memory cache is used.
+func (m *Manager) CheckBan(ctx context.Context, playerID uuid.UUID) (*models.PlayerBan, error) {
+	if m.adminRepo == nil {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+
+		ban, exists := m.bans[playerID]
+		if !exists || !ban.IsActive || ban.IsExpired() {
+			return nil, nil
+		}
+		return ban, nil
+	}
+
+	ban, err := m.adminRepo.GetActiveBan(ctx, playerID)
+	if errors.Is(err, database.ErrBanNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ban, nil
+}
+
+// BanMessage is the rejection shown to a banned player
+func BanMessage(ban *models.PlayerBan) string {
+	if ban.IsPermanent || ban.ExpiresAt == nil {
+		return fmt.Sprintf("This account is permanently banned. Reason: %s", ban.Reason)
+	}
+	return fmt.Sprintf("This account is banned until %s. Reason: %s",
+		ban.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"), ban.Reason)
+}
+
 // MutePlayer mutes a player
 func (m *Manager) MutePlayer(
 	adminID uuid.UUID,
@@ -237,6 +360,11 @@ func (m *Manager) MutePlayer(
 
 	// Create mute
 	mute := models.NewPlayerMute(targetID, username, reason, adminID, duration)
+	if m.adminRepo != nil {
+		if err := m.adminRepo.CreateMute(m.ctx, mute); err != nil {
+			return err
+		}
+	}
 	m.mutes[targetID] = mute
 
 	// Log action
@@ -261,6 +389,11 @@ func (m *Manager) UnmutePlayer(adminID uuid.UUID, targetID uuid.UUID) error {
 		return errors.New("player not muted")
 	}
 
+	if m.adminRepo != nil {
+		if err := m.adminRepo.DeactivateMutes(m.ctx, targetID); err != nil {
+			return err
+		}
+	}
 	mute.IsActive = false
 
 	// Log action
@@ -287,6 +420,20 @@ func (m *Manager) IsMuted(playerID uuid.UUID) bool {
 	return true
 }
 
+// GetMute returns a copy of the player's active mute, or nil
+func (m *Manager) GetMute(playerID uuid.UUID) *models.PlayerMute {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	mute, exists := m.mutes[playerID]
+	if !exists || !mute.IsActive || mute.IsExpired() {
+		return nil
+	}
+
+	result := *mute
+	return &result
+}
+
 // UpdateSettings updates server settings
 func (m *Manager) UpdateSettings(adminID uuid.UUID, settings *models.ServerSettings) error {
 	m.mu.Lock()
@@ -298,6 +445,11 @@ func (m *Manager) UpdateSettings(adminID uuid.UUID, settings *models.ServerSetti
 		return errors.New("not authorized")
 	}
 
+	if m.adminRepo != nil {
+		if err := m.adminRepo.SaveServerSettings(m.ctx, settings, adminID); err != nil {
+			return err
+		}
+	}
 	m.settings = settings
 
 	// Log action
@@ -306,6 +458,71 @@ func (m *Manager) UpdateSettings(adminID uuid.UUID, settings *models.ServerSetti
 	return nil
 }
 
+// SetContentLoader sets the loader used to hot-reload the content pack
+func (m *Manager) SetContentLoader(loader *content.Loader) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.contentLoader = loader
+}
+
+// ContentStatus describes the active content pack. ok is false when no
+// content loader is set.
+func (m *Manager) ContentStatus() (status content.Status, ok bool) {
+	m.mu.RLock()
+	loader := m.contentLoader
+	m.mu.RUnlock()
+
+	if loader == nil {
+		return content.Status{}, false
+	}
+	return loader.Status(), true
+}
+
+// ReloadContent re-reads the content pack file and installs it. A pack that
+// fails validation is not installed; the attempt is audited either way.
+func (m *Manager) ReloadContent(adminID uuid.UUID) (content.Status, error) {
+	m.mu.RLock()
+	admin, exists := m.admins[adminID]
+	authorized := exists && admin.HasPermission(models.PermServerSettings)
+	loader := m.contentLoader
+	m.mu.RUnlock()
+
+	if !authorized {
+		return content.Status{}, errors.New("not authorized")
+	}
+	if loader == nil {
+		return content.Status{}, errors.New("content reload not available")
+	}
+
+	// Loading can take a while for a large pack; don't hold the lock
+	status, err := loader.Load()
+
+	action := models.NewAdminAction(adminID, admin.Username, "reload_content", "")
+	if err != nil {
+		action.Details = "Failed to reload content pack"
+		action.SetError(err)
+	} else {
+		action.Details = fmt.Sprintf("Loaded content pack %s %s", status.Name, status.Version)
+	}
+	m.LogAction(action)
+
+	return status, err
+}
+
+// RequiresTwoFactor reports whether server policy requires the player to
+// use two-factor authentication (RequireAdmin2FA applies to every admin role)
+func (m *Manager) RequiresTwoFactor(playerID uuid.UUID) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if !m.settings.RequireAdmin2FA {
+		return false
+	}
+	admin, exists := m.admins[playerID]
+	return exists && admin.IsActive && admin.Role != models.RolePlayer
+}
+
 // GetSettings returns current server settings
 func (m *Manager) GetSettings() *models.ServerSettings {
 	m.mu.RLock()
@@ -356,6 +573,7 @@ func (m *Manager) LogAction(action *models.AdminAction) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.persistActionUnsafe(action)
 	m.actionLog = append(m.actionLog, action)
 
 	// Trim log if too large
@@ -377,6 +595,7 @@ func (m *Manager) logActionUnsafe(adminID uuid.UUID, action string, targetID uui
 	}
 	logEntry.Details = details
 
+	m.persistActionUnsafe(logEntry)
 	m.actionLog = append(m.actionLog, logEntry)
 
 	// Trim log if too large
@@ -385,6 +604,17 @@ func (m *Manager) logActionUnsafe(adminID uuid.UUID, action string, targetID uui
 	}
 }
 
+// persistActionUnsafe writes an action to the audit table. The action has
+// already happened, so a failed write is logged rather than returned.
+func (m *Manager) persistActionUnsafe(action *models.AdminAction) {
+	if m.adminRepo == nil {
+		return
+	}
+	if err := m.adminRepo.LogAction(m.ctx, action); err != nil {
+		log.Error("Failed to persist admin action %s by %s: %v", action.Action, action.AdminName, err)
+	}
+}
+
 // metricsWorker collects server metrics periodically
 func (m *Manager) metricsWorker() {
 	defer m.wg.Done()
@@ -0,0 +1,122 @@
+// File: internal/admin/manager_test.go
+// Project: Terminal Velocity
+// Description: Tests for in-memory ban, mute and audit handling
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package admin
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+func newTestManager(t *testing.T) (*Manager, uuid.UUID) {
+	t.Helper()
+
+	m := NewManager(nil, nil)
+	t.Cleanup(m.Shutdown)
+
+	adminID := uuid.New()
+	if _, err := m.AddAdmin(adminID, "root", models.RoleSuperAdmin, uuid.Nil); err != nil {
+		t.Fatalf("AddAdmin failed: %v", err)
+	}
+	return m, adminID
+}
+
+func TestBanLifecycle(t *testing.T) {
+	m, adminID := newTestManager(t)
+	ctx := context.Background()
+	target := uuid.New()
+
+	if ban, err := m.CheckBan(ctx, target); err != nil || ban != nil {
+		t.Fatalf("Expected no ban, got %+v (%v)", ban, err)
+	}
+
+	duration := time.Hour
+	if err := m.BanPlayer(adminID, target, "griefer", "", "spawn camping", &duration); err != nil {
+		t.Fatalf("BanPlayer failed: %v", err)
+	}
+
+	ban, err := m.CheckBan(ctx, target)
+	if err != nil || ban == nil {
+		t.Fatalf("Expected active ban, got %+v (%v)", ban, err)
+	}
+	if msg := BanMessage(ban); !strings.Contains(msg, "banned until") || !strings.Contains(msg, "spawn camping") {
+		t.Errorf("Unexpected ban message: %q", msg)
+	}
+
+	if err := m.UnbanPlayer(adminID, target); err != nil {
+		t.Fatalf("UnbanPlayer failed: %v", err)
+	}
+	if ban, _ := m.CheckBan(ctx, target); ban != nil {
+		t.Error("Expected ban to be lifted")
+	}
+
+	// Non-admins can't ban
+	if err := m.BanPlayer(uuid.New(), target, "griefer", "", "nope", nil); err == nil {
+		t.Error("Expected non-admin ban to fail")
+	}
+
+	actions := m.GetActionLog(10)
+	if len(actions) != 3 || actions[1].Action != "ban_player" || actions[2].Action != "unban_player" {
+		t.Errorf("Unexpected audit log: %+v", actions)
+	}
+}
+
+func TestPermanentBanMessage(t *testing.T) {
+	ban := models.NewPlayerBan(uuid.New(), "griefer", "", "botting", uuid.Nil, nil)
+	if msg := BanMessage(ban); !strings.Contains(msg, "permanently banned") {
+		t.Errorf("Unexpected ban message: %q", msg)
+	}
+}
+
+func TestMuteExpires(t *testing.T) {
+	m, adminID := newTestManager(t)
+	target := uuid.New()
+
+	if err := m.MutePlayer(adminID, target, "spammer", "caps lock", time.Hour); err != nil {
+		t.Fatalf("MutePlayer failed: %v", err)
+	}
+	if !m.IsMuted(target) {
+		t.Fatal("Expected player to be muted")
+	}
+	if mute := m.GetMute(target); mute == nil || mute.Reason != "caps lock" {
+		t.Errorf("Unexpected mute: %+v", mute)
+	}
+
+	// An expired mute no longer applies
+	m.mu.Lock()
+	m.mutes[target].ExpiresAt = time.Now().Add(-time.Minute)
+	m.mu.Unlock()
+	if m.IsMuted(target) {
+		t.Error("Expected expired mute to be ignored")
+	}
+}
+
+func TestRequiresTwoFactor(t *testing.T) {
+	m, adminID := newTestManager(t)
+	player := uuid.New()
+
+	if m.RequiresTwoFactor(adminID) {
+		t.Error("2FA should not be required by default")
+	}
+
+	settings := m.GetSettings()
+	settings.RequireAdmin2FA = true
+	if err := m.UpdateSettings(adminID, settings); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	if !m.RequiresTwoFactor(adminID) {
+		t.Error("Expected 2FA to be required for admins")
+	}
+	if m.RequiresTwoFactor(player) {
+		t.Error("2FA policy should not apply to regular players")
+	}
+}
@@ -15,8 +15,11 @@ import (
 )
 
 var (
-	// ErrGRPCNotImplemented is returned when gRPC mode is requested but not yet implemented
-	ErrGRPCNotImplemented = errors.New("gRPC mode not yet implemented (Phase 2+)")
+	// ErrUnknownClientMode is returned when the client mode is not recognized
+	ErrUnknownClientMode = errors.New("unknown client mode")
+
+	// ErrNoServerAddress is returned when gRPC mode is requested without an address
+	ErrNoServerAddress = errors.New("server address is required for gRPC mode")
 )
 
 // Client provides a unified interface for communicating with the game server.
@@ -76,6 +79,9 @@ type PlayerClient interface {
 	// GetPlayerReputation retrieves faction reputation
 	GetPlayerReputation(ctx context.Context, playerID uuid.UUID) (*ReputationInfo, error)
 
+	// GetUnreadMail retrieves the player's unread mail (newest first)
+	GetUnreadMail(ctx context.Context, playerID uuid.UUID) (*MailList, error)
+
 	// StreamPlayerUpdates subscribes to real-time player state changes
 	StreamPlayerUpdates(ctx context.Context, playerID uuid.UUID) (PlayerUpdateStream, error)
 }
@@ -123,11 +129,14 @@ type GameClient interface {
 // In Phase 1 (monolithic), this returns an in-process client
 // In Phase 2+, this can return a gRPC client based on configuration
 func NewClient(config *ClientConfig) (Client, error) {
-	if config.Mode == ClientModeInProcess {
+	switch config.Mode {
+	case ClientModeInProcess:
 		return newInProcessClient(config)
+	case ClientModeGRPC:
+		return newGRPCClient(config)
+	default:
+		return nil, ErrUnknownClientMode
 	}
-	// Phase 2+: return newGRPCClient(config)
-	return nil, ErrGRPCNotImplemented
 }
 
 // ClientConfig configures the API client
@@ -138,6 +147,7 @@ type ClientConfig struct {
 	InProcessServer Server
 
 	// For gRPC mode (Phase 2+)
+	// TLSConfig nil means an insecure (plaintext) connection
 	ServerAddress string
 	TLSConfig     *TLSConfig
 }
@@ -154,6 +164,8 @@ const (
 )
 
 // TLSConfig for secure gRPC connections (Phase 2+)
+// On the client, CAFile verifies the server and CertFile/KeyFile enable mutual TLS.
+// On the server, CertFile/KeyFile are required and CAFile requires client certificates.
 type TLSConfig struct {
 	CertFile string
 	KeyFile  string
@@ -187,6 +199,7 @@ type PlayerService interface {
 	GetPlayerInventory(ctx context.Context, playerID uuid.UUID) (*Inventory, error)
 	GetPlayerStats(ctx context.Context, playerID uuid.UUID) (*PlayerStats, error)
 	GetPlayerReputation(ctx context.Context, playerID uuid.UUID) (*ReputationInfo, error)
+	GetUnreadMail(ctx context.Context, playerID uuid.UUID) (*MailList, error)
 	StreamPlayerUpdates(ctx context.Context, playerID uuid.UUID) (PlayerUpdateStream, error)
 }
 
@@ -291,6 +304,10 @@ func (c *inProcessClient) GetPlayerReputation(ctx context.Context, playerID uuid
 	return c.server.GetPlayerReputation(ctx, playerID)
 }
 
+func (c *inProcessClient) GetUnreadMail(ctx context.Context, playerID uuid.UUID) (*MailList, error) {
+	return c.server.GetUnreadMail(ctx, playerID)
+}
+
 func (c *inProcessClient) StreamPlayerUpdates(ctx context.Context, playerID uuid.UUID) (PlayerUpdateStream, error) {
 	return c.server.StreamPlayerUpdates(ctx, playerID)
 }
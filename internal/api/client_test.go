@@ -0,0 +1,429 @@
+// File: internal/api/client_test.go
+// Project: Terminal Velocity
+// Description: Behaviour tests run against the in-process and gRPC clients
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var (
+	testPlayerID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	testSystemID = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	testIssuedAt = time.Date(2025, 11, 16, 12, 0, 0, 0, time.UTC)
+)
+
+// fakeServer implements the subset of Server exercised by the behaviour tests.
+// Unimplemented methods panic via the nil embedded interface.
+type fakeServer struct {
+	Server
+}
+
+func (f *fakeServer) Authenticate(ctx context.Context, req *AuthRequest) (*AuthResponse, error) {
+	if req.Username != "pilot" || req.Password != "secret" {
+		return nil, ErrUnauthorized
+	}
+	return &AuthResponse{
+		PlayerID:  testPlayerID,
+		Token:     "token-" + req.Username,
+		IssuedAt:  testIssuedAt,
+		ExpiresAt: testIssuedAt.Add(time.Hour),
+		PlayerInfo: &PlayerInfo{
+			PlayerID: testPlayerID,
+			Username: req.Username,
+		},
+	}, nil
+}
+
+func (f *fakeServer) EndSession(ctx context.Context, req *EndSessionRequest) error {
+	return nil
+}
+
+func (f *fakeServer) GetMarket(ctx context.Context, systemID uuid.UUID) (*Market, error) {
+	if systemID != testSystemID {
+		return nil, ErrNotFound
+	}
+	return &Market{
+		SystemID: systemID,
+		Commodities: []*CommodityListing{
+			{CommodityID: "food", Name: "Food", BuyPrice: 40, SellPrice: 50, Stock: 100},
+		},
+		LastUpdated: testIssuedAt,
+	}, nil
+}
+
+func (f *fakeServer) BuyCommodity(ctx context.Context, req *TradeRequest) (*TradeResponse, error) {
+	if req.Quantity <= 0 {
+		return nil, ErrInvalidRequest
+	}
+	return &TradeResponse{
+		Success:        true,
+		QuantityTraded: req.Quantity,
+		PricePerUnit:   50,
+		TotalCost:      int64(req.Quantity) * 50,
+		NewState:       &PlayerState{PlayerID: req.PlayerID, Credits: 1000 - int64(req.Quantity)*50},
+	}, nil
+}
+
+func (f *fakeServer) AbandonMission(ctx context.Context, missionID uuid.UUID) error {
+	return ErrForbidden
+}
+
+func (f *fakeServer) StreamPlayerUpdates(ctx context.Context, playerID uuid.UUID) (PlayerUpdateStream, error) {
+	if playerID != testPlayerID {
+		return nil, ErrNotFound
+	}
+	return &fakeStream{updates: []*PlayerUpdate{
+		{PlayerID: playerID, Type: UpdateTypeCredits, Timestamp: testIssuedAt,
+			CreditsUpdate: &CreditsUpdate{OldCredits: 100, NewCredits: 150, Delta: 50}},
+		{PlayerID: playerID, Type: UpdateTypeStatus, Timestamp: testIssuedAt,
+			StatusUpdate: &StatusUpdate{NewStatus: PlayerStatus("docked")}},
+	}}, nil
+}
+
+// fakeStream replays a fixed list of updates then ends
+type fakeStream struct {
+	updates []*PlayerUpdate
+}
+
+func (s *fakeStream) Recv() (*PlayerUpdate, error) {
+	if len(s.updates) == 0 {
+		return nil, io.EOF
+	}
+	update := s.updates[0]
+	s.updates = s.updates[1:]
+	return update, nil
+}
+
+func (s *fakeStream) Close() error {
+	return nil
+}
+
+// clientFactories returns each client transport under test
+func clientFactories() map[string]func(t *testing.T, srv Server) Client {
+	return map[string]func(t *testing.T, srv Server) Client{
+		"in_process": func(t *testing.T, srv Server) Client {
+			client, err := NewClient(&ClientConfig{Mode: ClientModeInProcess, InProcessServer: srv})
+			if err != nil {
+				t.Fatalf("NewClient failed: %v", err)
+			}
+			return client
+		},
+		"grpc": func(t *testing.T, srv Server) Client {
+			addr := startGRPCServer(t, srv, nil)
+			return dialGRPC(t, addr, nil)
+		},
+		"grpc_mtls": func(t *testing.T, srv Server) Client {
+			serverTLS, clientTLS := writeTestCerts(t)
+			addr := startGRPCServer(t, srv, serverTLS)
+			return dialGRPC(t, addr, clientTLS)
+		},
+	}
+}
+
+// startGRPCServer serves srv on a loopback listener for the duration of the test
+func startGRPCServer(t *testing.T, srv Server, tlsConfig *TLSConfig) string {
+	t.Helper()
+
+	var opts []grpc.ServerOption
+	if tlsConfig != nil {
+		cfg, err := ServerTLSConfig(tlsConfig)
+		if err != nil {
+			t.Fatalf("ServerTLSConfig failed: %v", err)
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(cfg)))
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	s := grpc.NewServer(opts...)
+	RegisterGRPCServices(s, srv)
+	go func() {
+		_ = s.Serve(listener)
+	}()
+	t.Cleanup(s.Stop)
+
+	return listener.Addr().String()
+}
+
+func dialGRPC(t *testing.T, addr string, tlsConfig *TLSConfig) Client {
+	t.Helper()
+
+	client, err := NewClient(&ClientConfig{Mode: ClientModeGRPC, ServerAddress: addr, TLSConfig: tlsConfig})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	return client
+}
+
+// TestClientBehaviour runs the same calls against every transport
+func TestClientBehaviour(t *testing.T) {
+	for name, newClient := range clientFactories() {
+		t.Run(name, func(t *testing.T) {
+			client := newClient(t, &fakeServer{})
+			defer client.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			t.Run("authenticate", func(t *testing.T) {
+				resp, err := client.Authenticate(ctx, &AuthRequest{Username: "pilot", Password: "secret"})
+				if err != nil {
+					t.Fatalf("Authenticate failed: %v", err)
+				}
+				if resp.PlayerID != testPlayerID || resp.Token != "token-pilot" {
+					t.Errorf("Unexpected response: %+v", resp)
+				}
+				if !resp.IssuedAt.Equal(testIssuedAt) || resp.PlayerInfo == nil || resp.PlayerInfo.Username != "pilot" {
+					t.Errorf("Response fields not preserved: %+v", resp)
+				}
+
+				if _, err := client.Authenticate(ctx, &AuthRequest{Username: "pilot", Password: "wrong"}); !errors.Is(err, ErrUnauthorized) {
+					t.Errorf("Expected ErrUnauthorized, got %v", err)
+				}
+			})
+
+			t.Run("error-only methods", func(t *testing.T) {
+				if err := client.EndSession(ctx, &EndSessionRequest{}); err != nil {
+					t.Errorf("EndSession failed: %v", err)
+				}
+				if err := client.AbandonMission(ctx, uuid.New()); !errors.Is(err, ErrForbidden) {
+					t.Errorf("Expected ErrForbidden, got %v", err)
+				}
+			})
+
+			t.Run("market and trade", func(t *testing.T) {
+				market, err := client.GetMarket(ctx, testSystemID)
+				if err != nil {
+					t.Fatalf("GetMarket failed: %v", err)
+				}
+				if len(market.Commodities) != 1 || market.Commodities[0].SellPrice != 50 {
+					t.Errorf("Unexpected market: %+v", market)
+				}
+
+				if _, err := client.GetMarket(ctx, uuid.New()); !errors.Is(err, ErrNotFound) {
+					t.Errorf("Expected ErrNotFound, got %v", err)
+				}
+
+				trade, err := client.BuyCommodity(ctx, &TradeRequest{PlayerID: testPlayerID, CommodityID: "food", Quantity: 3})
+				if err != nil {
+					t.Fatalf("BuyCommodity failed: %v", err)
+				}
+				if trade.TotalCost != 150 || trade.NewState == nil || trade.NewState.Credits != 850 {
+					t.Errorf("Unexpected trade: %+v", trade)
+				}
+
+				if _, err := client.BuyCommodity(ctx, &TradeRequest{PlayerID: testPlayerID, Quantity: 0}); !errors.Is(err, ErrInvalidRequest) {
+					t.Errorf("Expected ErrInvalidRequest, got %v", err)
+				}
+			})
+
+			t.Run("stream player updates", func(t *testing.T) {
+				if _, err := client.StreamPlayerUpdates(ctx, uuid.New()); !errors.Is(err, ErrNotFound) {
+					t.Errorf("Expected ErrNotFound for unknown player, got %v", err)
+				}
+
+				stream, err := client.StreamPlayerUpdates(ctx, testPlayerID)
+				if err != nil {
+					t.Fatalf("StreamPlayerUpdates failed: %v", err)
+				}
+				defer stream.Close()
+
+				var received []*PlayerUpdate
+				for {
+					update, err := stream.Recv()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						t.Fatalf("Recv failed: %v", err)
+					}
+					received = append(received, update)
+				}
+
+				if len(received) != 2 {
+					t.Fatalf("Expected 2 updates, got %d", len(received))
+				}
+				if received[0].CreditsUpdate == nil || received[0].CreditsUpdate.Delta != 50 {
+					t.Errorf("Unexpected credits update: %+v", received[0])
+				}
+				if received[1].Type != UpdateTypeStatus || received[1].StatusUpdate == nil {
+					t.Errorf("Unexpected status update: %+v", received[1])
+				}
+			})
+		})
+	}
+}
+
+// TestGRPCClientRejectsUntrustedServer verifies TLS clients verify the server certificate
+func TestGRPCClientRejectsUntrustedServer(t *testing.T) {
+	serverTLS, _ := writeTestCerts(t)
+	addr := startGRPCServer(t, &fakeServer{}, serverTLS)
+
+	// A client trusting a different CA must fail the handshake
+	_, otherClientTLS := writeTestCerts(t)
+	client := dialGRPC(t, addr, otherClientTLS)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := client.GetMarket(ctx, testSystemID); err == nil {
+		t.Fatal("Expected TLS verification failure")
+	}
+}
+
+// TestGRPCFullMethod verifies interceptors see "/<package>.<Service>/<Method>"
+func TestGRPCFullMethod(t *testing.T) {
+	var methods []string
+	interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		methods = append(methods, info.FullMethod)
+		return handler(ctx, req)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	s := grpc.NewServer(grpc.UnaryInterceptor(interceptor))
+	RegisterGRPCServices(s, &fakeServer{})
+	go func() {
+		_ = s.Serve(listener)
+	}()
+	t.Cleanup(s.Stop)
+
+	client := dialGRPC(t, listener.Addr().String(), nil)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := client.Authenticate(ctx, &AuthRequest{Username: "pilot", Password: "secret"}); err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+	if _, err := client.GetMarket(ctx, testSystemID); err != nil {
+		t.Fatalf("GetMarket failed: %v", err)
+	}
+
+	want := []string{
+		"/terminalvelocity.v1.AuthService/Authenticate",
+		"/terminalvelocity.v1.GameService/GetMarket",
+	}
+	if len(methods) != len(want) {
+		t.Fatalf("Expected methods %v, got %v", want, methods)
+	}
+	for i := range want {
+		if methods[i] != want[i] {
+			t.Errorf("Expected FullMethod %q, got %q", want[i], methods[i])
+		}
+	}
+}
+
+func TestNewClientValidation(t *testing.T) {
+	if _, err := NewClient(&ClientConfig{Mode: ClientModeGRPC}); !errors.Is(err, ErrNoServerAddress) {
+		t.Errorf("Expected ErrNoServerAddress, got %v", err)
+	}
+	if _, err := NewClient(&ClientConfig{Mode: "carrier_pigeon"}); !errors.Is(err, ErrUnknownClientMode) {
+		t.Errorf("Expected ErrUnknownClientMode, got %v", err)
+	}
+}
+
+// writeTestCerts creates a CA plus server and client certificates signed by it.
+// It returns the mutual TLS configuration for each side.
+func writeTestCerts(t *testing.T) (serverTLS, clientTLS *TLSConfig) {
+	t.Helper()
+	dir := t.TempDir()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate CA key: %v", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Terminal Velocity Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("Failed to create CA certificate: %v", err)
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatalf("Failed to parse CA certificate: %v", err)
+	}
+	caFile := filepath.Join(dir, "ca.crt")
+	writePEM(t, caFile, "CERTIFICATE", caDER)
+
+	issue := func(name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("Failed to generate %s key: %v", name, err)
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		if err != nil {
+			t.Fatalf("Failed to create %s certificate: %v", name, err)
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatalf("Failed to marshal %s key: %v", name, err)
+		}
+
+		certFile := filepath.Join(dir, name+".crt")
+		keyFile := filepath.Join(dir, name+".key")
+		writePEM(t, certFile, "CERTIFICATE", der)
+		writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+		return certFile, keyFile
+	}
+
+	serverCert, serverKey := issue("server", 2, x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := issue("client", 3, x509.ExtKeyUsageClientAuth)
+
+	return &TLSConfig{CertFile: serverCert, KeyFile: serverKey, CAFile: caFile},
+		&TLSConfig{CertFile: clientCert, KeyFile: clientKey, CAFile: caFile}
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
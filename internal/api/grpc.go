@@ -0,0 +1,393 @@
+// File: internal/api/grpc.go
+// Project: Terminal Velocity
+// Description: gRPC service descriptors, codec and error mapping shared by client and server
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC Transport (Phase 2)
+//
+// Service and method names follow api/proto/*.proto
+// (e.g. "/terminalvelocity.v1.GameService/BuyCommodity"), so the wire paths
+// will not change when generated protobuf bindings replace this layer.
+//
+// Until then, messages are the plain Go API types encoded with a JSON codec
+// registered under the "json" content-subtype (application/grpc+json). Both
+// ends of the connection must be built from this package.
+
+const (
+	// GRPCCodecName is the content-subtype used for API messages
+	GRPCCodecName = "json"
+
+	grpcPackage     = "terminalvelocity.v1"
+	authServiceName = grpcPackage + ".AuthService"
+	playerService   = grpcPackage + ".PlayerService"
+	gameServiceName = grpcPackage + ".GameService"
+)
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec encodes API messages as JSON
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return GRPCCodecName
+}
+
+// idMessage carries a single UUID (PlayerID, SystemID, MissionID in the protos)
+type idMessage struct {
+	ID uuid.UUID
+}
+
+// emptyMessage is the Empty message in the protos
+type emptyMessage struct{}
+
+// RegisterGRPCServices registers the Auth, Player and Game services on a gRPC
+// server, dispatching every call to srv.
+func RegisterGRPCServices(s *grpc.Server, srv Server) {
+	s.RegisterService(&authServiceDesc, srv)
+	s.RegisterService(&playerServiceDesc, srv)
+	s.RegisterService(&gameServiceDesc, srv)
+}
+
+// unary builds a MethodDesc for a method of service that decodes Req, calls
+// the Server and returns Resp
+func unary[Req any, Resp any](service, name string, call func(Server, context.Context, *Req) (*Resp, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			req := new(Req)
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				resp, err := call(srv.(Server), ctx, req.(*Req))
+				if err != nil {
+					return nil, toGRPCError(err)
+				}
+				if resp == nil {
+					resp = new(Resp)
+				}
+				return resp, nil
+			}
+
+			if interceptor == nil {
+				return handler(ctx, req)
+			}
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + service + "/" + name}
+			return interceptor(ctx, req, info, handler)
+		},
+	}
+}
+
+var authServiceDesc = grpc.ServiceDesc{
+	ServiceName: authServiceName,
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		unary(authServiceName, "Authenticate", func(s Server, ctx context.Context, req *AuthRequest) (*AuthResponse, error) {
+			return s.Authenticate(ctx, req)
+		}),
+		unary(authServiceName, "AuthenticateSSH", func(s Server, ctx context.Context, req *SSHAuthRequest) (*AuthResponse, error) {
+			return s.AuthenticateSSH(ctx, req)
+		}),
+		unary(authServiceName, "CreateSession", func(s Server, ctx context.Context, req *CreateSessionRequest) (*Session, error) {
+			return s.CreateSession(ctx, req)
+		}),
+		unary(authServiceName, "ValidateSession", func(s Server, ctx context.Context, req *ValidateSessionRequest) (*Session, error) {
+			return s.ValidateSession(ctx, req)
+		}),
+		unary(authServiceName, "EndSession", func(s Server, ctx context.Context, req *EndSessionRequest) (*emptyMessage, error) {
+			return &emptyMessage{}, s.EndSession(ctx, req)
+		}),
+		unary(authServiceName, "RefreshSession", func(s Server, ctx context.Context, req *RefreshSessionRequest) (*AuthResponse, error) {
+			return s.RefreshSession(ctx, req)
+		}),
+		unary(authServiceName, "Register", func(s Server, ctx context.Context, req *RegisterRequest) (*RegisterResponse, error) {
+			return s.Register(ctx, req)
+		}),
+	},
+	Metadata: "api/proto/auth.proto",
+}
+
+var playerServiceDesc = grpc.ServiceDesc{
+	ServiceName: playerService,
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		unary(playerService, "GetPlayerState", func(s Server, ctx context.Context, req *idMessage) (*PlayerState, error) {
+			return s.GetPlayerState(ctx, req.ID)
+		}),
+		unary(playerService, "UpdatePlayerLocation", func(s Server, ctx context.Context, req *LocationUpdate) (*PlayerState, error) {
+			return s.UpdatePlayerLocation(ctx, req)
+		}),
+		unary(playerService, "GetPlayerShip", func(s Server, ctx context.Context, req *idMessage) (*Ship, error) {
+			return s.GetPlayerShip(ctx, req.ID)
+		}),
+		unary(playerService, "GetPlayerInventory", func(s Server, ctx context.Context, req *idMessage) (*Inventory, error) {
+			return s.GetPlayerInventory(ctx, req.ID)
+		}),
+		unary(playerService, "GetPlayerStats", func(s Server, ctx context.Context, req *idMessage) (*PlayerStats, error) {
+			return s.GetPlayerStats(ctx, req.ID)
+		}),
+		unary(playerService, "GetPlayerReputation", func(s Server, ctx context.Context, req *idMessage) (*ReputationInfo, error) {
+			return s.GetPlayerReputation(ctx, req.ID)
+		}),
+		unary(playerService, "GetUnreadMail", func(s Server, ctx context.Context, req *idMessage) (*MailList, error) {
+			return s.GetUnreadMail(ctx, req.ID)
+		}),
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPlayerUpdates",
+			Handler:       streamPlayerUpdatesHandler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/proto/player.proto",
+}
+
+var gameServiceDesc = grpc.ServiceDesc{
+	ServiceName: gameServiceName,
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		unary(gameServiceName, "Jump", func(s Server, ctx context.Context, req *JumpRequest) (*JumpResponse, error) {
+			return s.Jump(ctx, req)
+		}),
+		unary(gameServiceName, "Land", func(s Server, ctx context.Context, req *LandRequest) (*LandResponse, error) {
+			return s.Land(ctx, req)
+		}),
+		unary(gameServiceName, "Takeoff", func(s Server, ctx context.Context, req *TakeoffRequest) (*TakeoffResponse, error) {
+			return s.Takeoff(ctx, req)
+		}),
+		unary(gameServiceName, "GetMarket", func(s Server, ctx context.Context, req *idMessage) (*Market, error) {
+			return s.GetMarket(ctx, req.ID)
+		}),
+		unary(gameServiceName, "BuyCommodity", func(s Server, ctx context.Context, req *TradeRequest) (*TradeResponse, error) {
+			return s.BuyCommodity(ctx, req)
+		}),
+		unary(gameServiceName, "SellCommodity", func(s Server, ctx context.Context, req *TradeRequest) (*TradeResponse, error) {
+			return s.SellCommodity(ctx, req)
+		}),
+		unary(gameServiceName, "BuyShip", func(s Server, ctx context.Context, req *ShipPurchaseRequest) (*ShipPurchaseResponse, error) {
+			return s.BuyShip(ctx, req)
+		}),
+		unary(gameServiceName, "SellShip", func(s Server, ctx context.Context, req *ShipSaleRequest) (*ShipSaleResponse, error) {
+			return s.SellShip(ctx, req)
+		}),
+		unary(gameServiceName, "BuyOutfit", func(s Server, ctx context.Context, req *OutfitPurchaseRequest) (*OutfitPurchaseResponse, error) {
+			return s.BuyOutfit(ctx, req)
+		}),
+		unary(gameServiceName, "SellOutfit", func(s Server, ctx context.Context, req *OutfitSaleRequest) (*OutfitSaleResponse, error) {
+			return s.SellOutfit(ctx, req)
+		}),
+		unary(gameServiceName, "GetAvailableMissions", func(s Server, ctx context.Context, req *idMessage) (*MissionList, error) {
+			return s.GetAvailableMissions(ctx, req.ID)
+		}),
+		unary(gameServiceName, "AcceptMission", func(s Server, ctx context.Context, req *MissionAcceptRequest) (*Mission, error) {
+			return s.AcceptMission(ctx, req)
+		}),
+		unary(gameServiceName, "AbandonMission", func(s Server, ctx context.Context, req *idMessage) (*emptyMessage, error) {
+			return &emptyMessage{}, s.AbandonMission(ctx, req.ID)
+		}),
+		unary(gameServiceName, "GetActiveMissions", func(s Server, ctx context.Context, req *idMessage) (*MissionList, error) {
+			return s.GetActiveMissions(ctx, req.ID)
+		}),
+		unary(gameServiceName, "GetAvailableQuests", func(s Server, ctx context.Context, req *idMessage) (*QuestList, error) {
+			return s.GetAvailableQuests(ctx, req.ID)
+		}),
+		unary(gameServiceName, "AcceptQuest", func(s Server, ctx context.Context, req *QuestAcceptRequest) (*Quest, error) {
+			return s.AcceptQuest(ctx, req)
+		}),
+		unary(gameServiceName, "GetActiveQuests", func(s Server, ctx context.Context, req *idMessage) (*QuestList, error) {
+			return s.GetActiveQuests(ctx, req.ID)
+		}),
+	},
+	Metadata: "api/proto/game.proto",
+}
+
+// streamAcceptedKey is sent in the response headers once a stream is open.
+// A rejected stream ends without headers (trailers only).
+const streamAcceptedKey = "tv-stream-accepted"
+
+// streamPlayerUpdatesHandler relays a server-side PlayerUpdateStream to the client.
+// The stream is closed when the client cancels or the server stream ends.
+func streamPlayerUpdatesHandler(srv interface{}, ss grpc.ServerStream) error {
+	req := new(idMessage)
+	if err := ss.RecvMsg(req); err != nil {
+		return err
+	}
+
+	updates, err := srv.(Server).StreamPlayerUpdates(ss.Context(), req.ID)
+	if err != nil {
+		return toGRPCError(err)
+	}
+	defer updates.Close()
+
+	// Headers tell the client the subscription was accepted
+	if err := ss.SendHeader(metadata.Pairs(streamAcceptedKey, "true")); err != nil {
+		return err
+	}
+
+	for {
+		update, err := updates.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return toGRPCError(err)
+		}
+		if err := ss.SendMsg(update); err != nil {
+			return err
+		}
+	}
+}
+
+// grpcErrorCodes maps API sentinel errors to gRPC status codes
+var grpcErrorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{ErrInvalidRequest, codes.InvalidArgument},
+	{ErrUnauthorized, codes.Unauthenticated},
+	{ErrNotFound, codes.NotFound},
+	{ErrForbidden, codes.PermissionDenied},
+	{context.Canceled, codes.Canceled},
+	{context.DeadlineExceeded, codes.DeadlineExceeded},
+}
+
+// toGRPCError converts a Server error into a gRPC status error
+func toGRPCError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	for _, mapping := range grpcErrorCodes {
+		if errors.Is(err, mapping.err) {
+			return status.Error(mapping.code, err.Error())
+		}
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+// fromGRPCError converts a gRPC status error back into an API error so that
+// callers can use errors.Is with the same sentinels as the in-process client
+func fromGRPCError(err error) error {
+	if err == nil || err == io.EOF {
+		return err
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	for _, mapping := range grpcErrorCodes {
+		if st.Code() == mapping.code {
+			if st.Message() == mapping.err.Error() {
+				return mapping.err
+			}
+			return fmt.Errorf("%w: %s", mapping.err, st.Message())
+		}
+	}
+	return errors.New(st.Message())
+}
+
+// ServerTLSConfig builds a server-side TLS configuration.
+// CertFile and KeyFile are required; if CAFile is set, clients must present
+// a certificate signed by that CA (mutual TLS).
+func ServerTLSConfig(cfg *TLSConfig) (*tls.Config, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, fmt.Errorf("TLS requires both a certificate and a key file")
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.CAFile != "" {
+		pool, err := loadCertPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// ClientTLSConfig builds a client-side TLS configuration.
+// CAFile verifies the server (system roots are used if empty); CertFile and
+// KeyFile, if both set, present a client certificate for mutual TLS.
+func ClientTLSConfig(cfg *TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if cfg.CAFile != "" {
+		pool, err := loadCertPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// loadCertPool reads a PEM CA bundle
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA file %s", caFile)
+	}
+	return pool, nil
+}
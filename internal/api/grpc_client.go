@@ -0,0 +1,258 @@
+// File: internal/api/grpc_client.go
+// Project: Terminal Velocity
+// Description: gRPC client implementation of the API client interface
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// grpcClient implements Client over a gRPC connection (Phase 2+)
+type grpcClient struct {
+	conn *grpc.ClientConn
+}
+
+// newGRPCClient dials the configured server address.
+// The connection is established lazily on the first call.
+func newGRPCClient(config *ClientConfig) (Client, error) {
+	if config.ServerAddress == "" {
+		return nil, ErrNoServerAddress
+	}
+
+	creds := insecure.NewCredentials()
+	if config.TLSConfig != nil {
+		tlsConfig, err := ClientTLSConfig(config.TLSConfig)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(config.ServerAddress,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(GRPCCodecName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %w", config.ServerAddress, err)
+	}
+
+	return &grpcClient{conn: conn}, nil
+}
+
+// invoke performs a unary call and converts status errors back to API errors
+func invoke[Resp any](ctx context.Context, c *grpcClient, method string, req interface{}) (*Resp, error) {
+	resp := new(Resp)
+	if err := c.conn.Invoke(ctx, method, req, resp); err != nil {
+		return nil, fromGRPCError(err)
+	}
+	return resp, nil
+}
+
+func authMethod(name string) string   { return "/" + authServiceName + "/" + name }
+func playerMethod(name string) string { return "/" + playerService + "/" + name }
+func gameMethod(name string) string   { return "/" + gameServiceName + "/" + name }
+
+// Close closes the underlying connection
+func (c *grpcClient) Close() error {
+	return c.conn.Close()
+}
+
+// Auth methods
+func (c *grpcClient) Authenticate(ctx context.Context, req *AuthRequest) (*AuthResponse, error) {
+	return invoke[AuthResponse](ctx, c, authMethod("Authenticate"), req)
+}
+
+func (c *grpcClient) AuthenticateSSH(ctx context.Context, req *SSHAuthRequest) (*AuthResponse, error) {
+	return invoke[AuthResponse](ctx, c, authMethod("AuthenticateSSH"), req)
+}
+
+func (c *grpcClient) CreateSession(ctx context.Context, req *CreateSessionRequest) (*Session, error) {
+	return invoke[Session](ctx, c, authMethod("CreateSession"), req)
+}
+
+func (c *grpcClient) ValidateSession(ctx context.Context, req *ValidateSessionRequest) (*Session, error) {
+	return invoke[Session](ctx, c, authMethod("ValidateSession"), req)
+}
+
+func (c *grpcClient) EndSession(ctx context.Context, req *EndSessionRequest) error {
+	_, err := invoke[emptyMessage](ctx, c, authMethod("EndSession"), req)
+	return err
+}
+
+func (c *grpcClient) RefreshSession(ctx context.Context, req *RefreshSessionRequest) (*AuthResponse, error) {
+	return invoke[AuthResponse](ctx, c, authMethod("RefreshSession"), req)
+}
+
+func (c *grpcClient) Register(ctx context.Context, req *RegisterRequest) (*RegisterResponse, error) {
+	return invoke[RegisterResponse](ctx, c, authMethod("Register"), req)
+}
+
+// Player methods
+func (c *grpcClient) GetPlayerState(ctx context.Context, playerID uuid.UUID) (*PlayerState, error) {
+	return invoke[PlayerState](ctx, c, playerMethod("GetPlayerState"), &idMessage{ID: playerID})
+}
+
+func (c *grpcClient) UpdatePlayerLocation(ctx context.Context, req *LocationUpdate) (*PlayerState, error) {
+	return invoke[PlayerState](ctx, c, playerMethod("UpdatePlayerLocation"), req)
+}
+
+func (c *grpcClient) GetPlayerShip(ctx context.Context, playerID uuid.UUID) (*Ship, error) {
+	return invoke[Ship](ctx, c, playerMethod("GetPlayerShip"), &idMessage{ID: playerID})
+}
+
+func (c *grpcClient) GetPlayerInventory(ctx context.Context, playerID uuid.UUID) (*Inventory, error) {
+	return invoke[Inventory](ctx, c, playerMethod("GetPlayerInventory"), &idMessage{ID: playerID})
+}
+
+func (c *grpcClient) GetPlayerStats(ctx context.Context, playerID uuid.UUID) (*PlayerStats, error) {
+	return invoke[PlayerStats](ctx, c, playerMethod("GetPlayerStats"), &idMessage{ID: playerID})
+}
+
+func (c *grpcClient) GetPlayerReputation(ctx context.Context, playerID uuid.UUID) (*ReputationInfo, error) {
+	return invoke[ReputationInfo](ctx, c, playerMethod("GetPlayerReputation"), &idMessage{ID: playerID})
+}
+
+func (c *grpcClient) GetUnreadMail(ctx context.Context, playerID uuid.UUID) (*MailList, error) {
+	return invoke[MailList](ctx, c, playerMethod("GetUnreadMail"), &idMessage{ID: playerID})
+}
+
+// StreamPlayerUpdates opens a server stream. It waits for the server to accept
+// the subscription so that errors surface here, as with the in-process client.
+func (c *grpcClient) StreamPlayerUpdates(ctx context.Context, playerID uuid.UUID) (PlayerUpdateStream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	desc := &playerServiceDesc.Streams[0]
+	cs, err := c.conn.NewStream(ctx, desc, playerMethod(desc.StreamName))
+	if err != nil {
+		cancel()
+		return nil, fromGRPCError(err)
+	}
+
+	if err := cs.SendMsg(&idMessage{ID: playerID}); err != nil {
+		cancel()
+		return nil, fromGRPCError(err)
+	}
+	if err := cs.CloseSend(); err != nil {
+		cancel()
+		return nil, fromGRPCError(err)
+	}
+
+	header, err := cs.Header()
+	if err != nil {
+		cancel()
+		return nil, fromGRPCError(err)
+	}
+	if len(header.Get(streamAcceptedKey)) == 0 {
+		// Rejected: the status error is returned by the first receive
+		err := cs.RecvMsg(new(PlayerUpdate))
+		cancel()
+		if err == nil || err == io.EOF {
+			err = ErrNotFound
+		}
+		return nil, fromGRPCError(err)
+	}
+
+	return &grpcPlayerUpdateStream{stream: cs, cancel: cancel}, nil
+}
+
+// grpcPlayerUpdateStream adapts a gRPC client stream to PlayerUpdateStream
+type grpcPlayerUpdateStream struct {
+	stream grpc.ClientStream
+	cancel context.CancelFunc
+}
+
+// Recv returns the next update, or io.EOF when the server ends the stream
+func (s *grpcPlayerUpdateStream) Recv() (*PlayerUpdate, error) {
+	update := new(PlayerUpdate)
+	if err := s.stream.RecvMsg(update); err != nil {
+		return nil, fromGRPCError(err)
+	}
+	return update, nil
+}
+
+// Close cancels the stream
+func (s *grpcPlayerUpdateStream) Close() error {
+	s.cancel()
+	return nil
+}
+
+// Game methods
+func (c *grpcClient) Jump(ctx context.Context, req *JumpRequest) (*JumpResponse, error) {
+	return invoke[JumpResponse](ctx, c, gameMethod("Jump"), req)
+}
+
+func (c *grpcClient) Land(ctx context.Context, req *LandRequest) (*LandResponse, error) {
+	return invoke[LandResponse](ctx, c, gameMethod("Land"), req)
+}
+
+func (c *grpcClient) Takeoff(ctx context.Context, req *TakeoffRequest) (*TakeoffResponse, error) {
+	return invoke[TakeoffResponse](ctx, c, gameMethod("Takeoff"), req)
+}
+
+func (c *grpcClient) GetMarket(ctx context.Context, systemID uuid.UUID) (*Market, error) {
+	return invoke[Market](ctx, c, gameMethod("GetMarket"), &idMessage{ID: systemID})
+}
+
+func (c *grpcClient) BuyCommodity(ctx context.Context, req *TradeRequest) (*TradeResponse, error) {
+	return invoke[TradeResponse](ctx, c, gameMethod("BuyCommodity"), req)
+}
+
+func (c *grpcClient) SellCommodity(ctx context.Context, req *TradeRequest) (*TradeResponse, error) {
+	return invoke[TradeResponse](ctx, c, gameMethod("SellCommodity"), req)
+}
+
+func (c *grpcClient) BuyShip(ctx context.Context, req *ShipPurchaseRequest) (*ShipPurchaseResponse, error) {
+	return invoke[ShipPurchaseResponse](ctx, c, gameMethod("BuyShip"), req)
+}
+
+func (c *grpcClient) SellShip(ctx context.Context, req *ShipSaleRequest) (*ShipSaleResponse, error) {
+	return invoke[ShipSaleResponse](ctx, c, gameMethod("SellShip"), req)
+}
+
+func (c *grpcClient) BuyOutfit(ctx context.Context, req *OutfitPurchaseRequest) (*OutfitPurchaseResponse, error) {
+	return invoke[OutfitPurchaseResponse](ctx, c, gameMethod("BuyOutfit"), req)
+}
+
+func (c *grpcClient) SellOutfit(ctx context.Context, req *OutfitSaleRequest) (*OutfitSaleResponse, error) {
+	return invoke[OutfitSaleResponse](ctx, c, gameMethod("SellOutfit"), req)
+}
+
+func (c *grpcClient) GetAvailableMissions(ctx context.Context, playerID uuid.UUID) (*MissionList, error) {
+	return invoke[MissionList](ctx, c, gameMethod("GetAvailableMissions"), &idMessage{ID: playerID})
+}
+
+func (c *grpcClient) AcceptMission(ctx context.Context, req *MissionAcceptRequest) (*Mission, error) {
+	return invoke[Mission](ctx, c, gameMethod("AcceptMission"), req)
+}
+
+func (c *grpcClient) AbandonMission(ctx context.Context, missionID uuid.UUID) error {
+	_, err := invoke[emptyMessage](ctx, c, gameMethod("AbandonMission"), &idMessage{ID: missionID})
+	return err
+}
+
+func (c *grpcClient) GetActiveMissions(ctx context.Context, playerID uuid.UUID) (*MissionList, error) {
+	return invoke[MissionList](ctx, c, gameMethod("GetActiveMissions"), &idMessage{ID: playerID})
+}
+
+func (c *grpcClient) GetAvailableQuests(ctx context.Context, playerID uuid.UUID) (*QuestList, error) {
+	return invoke[QuestList](ctx, c, gameMethod("GetAvailableQuests"), &idMessage{ID: playerID})
+}
+
+func (c *grpcClient) AcceptQuest(ctx context.Context, req *QuestAcceptRequest) (*Quest, error) {
+	return invoke[Quest](ctx, c, gameMethod("AcceptQuest"), req)
+}
+
+func (c *grpcClient) GetActiveQuests(ctx context.Context, playerID uuid.UUID) (*QuestList, error) {
+	return invoke[QuestList](ctx, c, gameMethod("GetActiveQuests"), &idMessage{ID: playerID})
+}
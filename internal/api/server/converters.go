@@ -1,7 +1,7 @@
 // File: internal/api/server/converters.go
 // Project: Terminal Velocity
 // Description: Converters between database models and API types
-// Version: 1.4.0
+// Version: 1.5.0
 // Author: Joshua Ferguson
 // Created: 2025-01-14
 
@@ -9,7 +9,6 @@ package server
 
 import (
 	"context"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -174,6 +173,12 @@ func convertOutfitModifiers(outfit *models.Outfit) map[string]int32 {
 	if outfit.SpeedBonus > 0 {
 		modifiers["speed_bonus"] = int32(outfit.SpeedBonus)
 	}
+	if outfit.MiningBonus > 0 {
+		modifiers["mining_bonus"] = int32(outfit.MiningBonus)
+	}
+	if outfit.ScannerBonus > 0 {
+		modifiers["scanner_bonus"] = int32(outfit.ScannerBonus)
+	}
 	return modifiers
 }
 
@@ -230,6 +235,20 @@ func convertPlayerStatsToAPI(player *models.Player) *api.PlayerStats {
 	}
 }
 
+// convertMailToAPI converts a mail message to an API summary (without the body)
+func convertMailToAPI(mail *models.Mail) *api.MailMessage {
+	if mail == nil {
+		return nil
+	}
+
+	return &api.MailMessage{
+		MailID:     mail.ID,
+		SenderName: mail.SenderName,
+		Subject:    mail.Subject,
+		SentAt:     mail.SentAt,
+	}
+}
+
 // convertReputationToAPI converts player reputation data to API ReputationInfo
 func convertReputationToAPI(player *models.Player) *api.ReputationInfo {
 	if player == nil {
@@ -0,0 +1,127 @@
+// File: internal/api/server/grpc.go
+// Project: Terminal Velocity
+// Description: gRPC transport for the game server
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/api"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var log = logger.WithComponent("APIServer")
+
+// ErrMutualTLSRequired indicates a gRPC listen address other than loopback
+// was configured without client certificate verification
+var ErrMutualTLSRequired = errors.New("gRPC outside loopback requires mutual TLS")
+
+// GRPCServer exposes an api.Server over gRPC.
+//
+// Requests act for whichever player they name, so callers must be trusted
+// frontends such as SSH gateways. Every call is refused unless the caller
+// presented a client certificate verified against the configured CA
+// (mutual TLS) or connected from the loopback interface.
+type GRPCServer struct {
+	server *grpc.Server
+}
+
+// NewGRPCServer wraps a game server for network access.
+// If tlsConfig is nil the server accepts plaintext connections, which are
+// only served from loopback.
+func NewGRPCServer(gameServer api.Server, tlsConfig *api.TLSConfig) (*GRPCServer, error) {
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(authorizeUnary),
+		grpc.StreamInterceptor(authorizeStream),
+	}
+	if tlsConfig != nil {
+		cfg, err := api.ServerTLSConfig(tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure TLS: %w", err)
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(cfg)))
+	}
+
+	s := grpc.NewServer(opts...)
+	api.RegisterGRPCServices(s, gameServer)
+
+	return &GRPCServer{server: s}, nil
+}
+
+// CheckListenAddress refuses to serve on a non-loopback address unless
+// clients must present a certificate signed by tlsConfig's CA
+func CheckListenAddress(addr string, tlsConfig *api.TLSConfig) error {
+	if tlsConfig != nil && tlsConfig.CAFile != "" {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid gRPC address %q: %w", addr, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("%w: set -tls-cert, -tls-key and -tls-ca or listen on 127.0.0.1 (got %q)", ErrMutualTLSRequired, addr)
+}
+
+// authorizePeer allows callers with a verified client certificate or a
+// loopback connection
+func authorizePeer(ctx context.Context) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "unknown caller")
+	}
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 {
+		return nil
+	}
+	if tcp, ok := p.Addr.(*net.TCPAddr); ok && tcp.IP.IsLoopback() {
+		return nil
+	}
+	return status.Error(codes.Unauthenticated, "client certificate required")
+}
+
+func authorizeUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := authorizePeer(ctx); err != nil {
+		log.Warn("Refused gRPC call %s: %v", info.FullMethod, err)
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func authorizeStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := authorizePeer(stream.Context()); err != nil {
+		log.Warn("Refused gRPC stream %s: %v", info.FullMethod, err)
+		return err
+	}
+	return handler(srv, stream)
+}
+
+// Serve accepts connections on the listener until Stop is called
+func (s *GRPCServer) Serve(listener net.Listener) error {
+	log.Info("gRPC API listening on %s", listener.Addr())
+	if err := s.server.Serve(listener); err != nil {
+		return fmt.Errorf("gRPC server failed: %w", err)
+	}
+	return nil
+}
+
+// Stop finishes in-flight calls and closes all listeners.
+// It waits for open streams, so close the UpdateBus first.
+func (s *GRPCServer) Stop() {
+	s.server.GracefulStop()
+}
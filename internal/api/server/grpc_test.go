@@ -0,0 +1,88 @@
+// File: internal/api/server/grpc_test.go
+// Project: Terminal Velocity
+// Description: Tests for gRPC caller authorization and listen address checks
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorizePeer(t *testing.T) {
+	remote := &net.TCPAddr{IP: net.ParseIP("203.0.113.7"), Port: 40000}
+	verified := credentials.TLSInfo{State: tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{&x509.Certificate{}}},
+	}}
+
+	tests := []struct {
+		name string
+		peer *peer.Peer
+		ok   bool
+	}{
+		{"loopback", &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 40000}}, true},
+		{"loopback ipv6", &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("::1"), Port: 40000}}, true},
+		{"remote plaintext", &peer.Peer{Addr: remote}, false},
+		{"remote tls without client certificate", &peer.Peer{Addr: remote, AuthInfo: credentials.TLSInfo{}}, false},
+		{"remote mutual tls", &peer.Peer{Addr: remote, AuthInfo: verified}, true},
+		{"no peer", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.peer != nil {
+				ctx = peer.NewContext(ctx, tt.peer)
+			}
+			err := authorizePeer(ctx)
+			if tt.ok && err != nil {
+				t.Errorf("authorizePeer failed: %v", err)
+			}
+			if !tt.ok && status.Code(err) != codes.Unauthenticated {
+				t.Errorf("authorizePeer error = %v, want Unauthenticated", err)
+			}
+		})
+	}
+}
+
+func TestCheckListenAddress(t *testing.T) {
+	mutualTLS := &api.TLSConfig{CertFile: "server.crt", KeyFile: "server.key", CAFile: "ca.crt"}
+	serverTLS := &api.TLSConfig{CertFile: "server.crt", KeyFile: "server.key"}
+
+	tests := []struct {
+		addr string
+		tls  *api.TLSConfig
+		ok   bool
+	}{
+		{"127.0.0.1:50051", nil, true},
+		{"[::1]:50051", nil, true},
+		{"localhost:50051", nil, true},
+		{":50051", nil, false},
+		{"0.0.0.0:50051", serverTLS, false},
+		{"0.0.0.0:50051", mutualTLS, true},
+		{":50051", mutualTLS, true},
+	}
+
+	for _, tt := range tests {
+		err := CheckListenAddress(tt.addr, tt.tls)
+		if tt.ok && err != nil {
+			t.Errorf("CheckListenAddress(%q) failed: %v", tt.addr, err)
+		}
+		if !tt.ok && !errors.Is(err, ErrMutualTLSRequired) {
+			t.Errorf("CheckListenAddress(%q) error = %v, want ErrMutualTLSRequired", tt.addr, err)
+		}
+	}
+}
@@ -1,7 +1,7 @@
 // File: internal/api/server/server.go
 // Project: Terminal Velocity
 // Description: In-process API server implementation
-// Version: 1.1.0
+// Version: 1.3.0
 // Author: Joshua Ferguson
 // Created: 2025-01-14
 
@@ -11,12 +11,14 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"time"
 
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/api"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/game/trading"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/missions"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/quests"
@@ -33,6 +35,7 @@ type GameServer struct {
 	shipRepo   *database.ShipRepository
 	marketRepo *database.MarketRepository
 	sshKeyRepo *database.SSHKeyRepository
+	mailRepo   *database.MailRepository
 
 	// Manager packages (existing game logic)
 	// NOTE: These are in-memory managers designed for single-user TUI sessions.
@@ -46,6 +49,12 @@ type GameServer struct {
 
 	// Database connection for transactions
 	db *database.DB
+
+	// Atomic commodity trading
+	tradingService *trading.Service
+
+	// Real-time player updates (StreamPlayerUpdates)
+	updates *UpdateBus
 }
 
 // NewGameServer creates a new in-process game server
@@ -56,15 +65,27 @@ func NewGameServer(config *Config) (*GameServer, error) {
 		shipRepo:   config.ShipRepo,
 		marketRepo: config.MarketRepo,
 		sshKeyRepo: config.SSHKeyRepo,
+		mailRepo:   config.MailRepo,
 		missionMgr: config.MissionMgr,
 		questMgr:   config.QuestMgr,
 		sessions:   NewSessionManager(),
 		db:         config.DB,
 	}
+	server.tradingService = trading.NewService(config.DB, config.SystemRepo)
+
+	server.updates = config.Updates
+	if server.updates == nil {
+		server.updates = NewUpdateBus()
+	}
 
 	return server, nil
 }
 
+// Updates returns the bus that feeds StreamPlayerUpdates
+func (s *GameServer) Updates() *UpdateBus {
+	return s.updates
+}
+
 // Config for the game server
 type Config struct {
 	// Database connection
@@ -76,11 +97,16 @@ type Config struct {
 	ShipRepo   *database.ShipRepository
 	MarketRepo *database.MarketRepository
 	SSHKeyRepo *database.SSHKeyRepository
+	MailRepo   *database.MailRepository
 
 	// Manager packages
 	// NOTE: In Phase 2, these should be replaced with database-backed state
 	MissionMgr *missions.Manager
 	QuestMgr   *quests.Manager
+
+	// Updates is the player update bus; a new bus is created if nil.
+	// Share one bus to let other publishers (mail, marketplace) reach streams.
+	Updates *UpdateBus
 }
 
 // Compile-time check that GameServer implements api.Server
@@ -424,11 +450,50 @@ func (s *GameServer) GetPlayerReputation(ctx context.Context, playerID uuid.UUID
 	return convertReputationToAPI(player), nil
 }
 
-// StreamPlayerUpdates subscribes to real-time player state changes
+// unreadMailLimit caps the messages returned by GetUnreadMail
+const unreadMailLimit = 50
+
+// GetUnreadMail retrieves the player's unread mail, newest first
+func (s *GameServer) GetUnreadMail(ctx context.Context, playerID uuid.UUID) (*api.MailList, error) {
+	if playerID == uuid.Nil {
+		return nil, api.ErrInvalidRequest
+	}
+	if s.mailRepo == nil {
+		return nil, api.ErrNotFound
+	}
+
+	unread, err := s.mailRepo.GetUnreadCount(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &api.MailList{UnreadCount: int32(unread)}
+	if unread == 0 {
+		return list, nil
+	}
+
+	// The inbox is newest first; collect unread messages from its first page
+	inbox, err := s.mailRepo.GetInbox(ctx, playerID, unreadMailLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, mail := range inbox {
+		if !mail.IsRead {
+			list.Messages = append(list.Messages, convertMailToAPI(mail))
+		}
+	}
+
+	return list, nil
+}
+
+// StreamPlayerUpdates subscribes to real-time player state changes.
+// The stream stays open until ctx is cancelled or the stream is closed.
 func (s *GameServer) StreamPlayerUpdates(ctx context.Context, playerID uuid.UUID) (api.PlayerUpdateStream, error) {
-	// TODO: Implement streaming
-	// Will use channels to push updates to client
-	return nil, api.ErrNotFound
+	if playerID == uuid.Nil {
+		return nil, api.ErrInvalidRequest
+	}
+
+	return s.updates.Subscribe(ctx, playerID), nil
 }
 
 // ============================================================================
@@ -548,6 +613,9 @@ func (s *GameServer) Jump(ctx context.Context, req *api.JumpRequest) (*api.JumpR
 	player.X = 0
 	player.Y = 0
 
+	s.updates.PublishLocation(player)
+	s.updates.PublishShip(player.ID, ship)
+
 	// Return success with new state
 	return &api.JumpResponse{
 		Success:      true,
@@ -639,6 +707,9 @@ func (s *GameServer) Land(ctx context.Context, req *api.LandRequest) (*api.LandR
 		system = &models.StarSystem{GovernmentID: ""}
 	}
 
+	s.updates.PublishLocation(player)
+	s.updates.PublishStatus(player.ID, api.PlayerStatusInSpace, api.PlayerStatusDocked)
+
 	// Return success with updated state
 	return &api.LandResponse{
 		Success:  true,
@@ -686,6 +757,9 @@ func (s *GameServer) Takeoff(ctx context.Context, req *api.TakeoffRequest) (*api
 	// Update local player state
 	player.CurrentPlanet = nil
 
+	s.updates.PublishLocation(player)
+	s.updates.PublishStatus(player.ID, api.PlayerStatusDocked, api.PlayerStatusInSpace)
+
 	// Load ship
 	ship, err := s.shipRepo.GetByID(ctx, player.ShipID)
 	if err != nil {
@@ -721,10 +795,11 @@ func (s *GameServer) GetMarket(ctx context.Context, systemID uuid.UUID) (*api.Ma
 		return nil, err
 	}
 
-	// Build commodity map from standard commodities
+	// Build commodity map from the active content pack
 	commodities := make(map[string]*models.Commodity)
-	for i := range models.StandardCommodities {
-		commodity := &models.StandardCommodities[i]
+	commodityList := models.AllCommodities()
+	for i := range commodityList {
+		commodity := &commodityList[i]
 		commodities[commodity.ID] = commodity
 	}
 
@@ -746,134 +821,15 @@ func (s *GameServer) BuyCommodity(ctx context.Context, req *api.TradeRequest) (*
 		}, nil
 	}
 
-	// Load player
-	player, err := s.playerRepo.GetByID(ctx, req.PlayerID)
+	// Credit check, cargo change and market update happen in one transaction
+	result, err := s.tradingService.Buy(ctx, req.PlayerID, req.CommodityID, int(req.Quantity))
 	if err != nil {
-		return &api.TradeResponse{
-			Success: false,
-			Message: "player not found",
-		}, nil
-	}
-
-	// Must be docked to trade
-	if !player.IsDocked() {
-		return &api.TradeResponse{
-			Success: false,
-			Message: "must be docked at a planet to trade",
-		}, nil
+		return tradeFailure(err), nil
 	}
 
-	// Load ship
-	ship, err := s.shipRepo.GetByID(ctx, player.ShipID)
-	if err != nil {
-		return &api.TradeResponse{
-			Success: false,
-			Message: "ship not found",
-		}, nil
-	}
-
-	// Get market price
-	price, err := s.marketRepo.GetMarketPrice(ctx, *player.CurrentPlanet, req.CommodityID)
-	if err != nil {
-		return &api.TradeResponse{
-			Success: false,
-			Message: "commodity not available at this planet",
-		}, nil
-	}
-
-	// Check stock availability
-	if price.Stock < int(req.Quantity) {
-		return &api.TradeResponse{
-			Success: false,
-			Message: fmt.Sprintf("insufficient stock (available: %d)", price.Stock),
-		}, nil
-	}
-
-	// Calculate cost (player buys at sell price)
-	totalCost := price.SellPrice * int64(req.Quantity)
-
-	// Check credits
-	if !player.CanAfford(totalCost) {
-		return &api.TradeResponse{
-			Success: false,
-			Message: fmt.Sprintf("insufficient credits (need: %d, have: %d)", totalCost, player.Credits),
-		}, nil
-	}
-
-	// Check cargo space
-	shipType := models.GetShipTypeByID(ship.TypeID)
-	if shipType == nil {
-		return &api.TradeResponse{
-			Success: false,
-			Message: "invalid ship type",
-		}, nil
-	}
-
-	if !ship.CanAddCargo(int(req.Quantity), shipType) {
-		cargoUsed := ship.GetCargoUsed()
-		return &api.TradeResponse{
-			Success: false,
-			Message: fmt.Sprintf("insufficient cargo space (used: %d/%d, need: %d more)",
-				cargoUsed, shipType.CargoSpace, req.Quantity),
-		}, nil
-	}
-
-	// Perform the trade atomically within a transaction
-	err = s.db.WithTransaction(ctx, func(tx *sql.Tx) error {
-		// 1. Deduct credits from player
-		_, err := tx.ExecContext(ctx,
-			"UPDATE players SET credits = credits - $1 WHERE id = $2",
-			totalCost, req.PlayerID)
-		if err != nil {
-			return fmt.Errorf("failed to update player credits: %w", err)
-		}
-
-		// 2. Add cargo to ship
-		ship.AddCargo(req.CommodityID, int(req.Quantity))
-		cargoJSON, err := json.Marshal(ship.Cargo)
-		if err != nil {
-			return fmt.Errorf("failed to serialize cargo: %w", err)
-		}
-		_, err = tx.ExecContext(ctx,
-			"UPDATE ships SET cargo = $1 WHERE id = $2",
-			cargoJSON, player.ShipID)
-		if err != nil {
-			return fmt.Errorf("failed to update ship cargo: %w", err)
-		}
-
-		// 3. Update market stock
-		_, err = tx.ExecContext(ctx,
-			"UPDATE market_prices SET stock = stock - $1 WHERE planet_id = $2 AND commodity_id = $3",
-			req.Quantity, *player.CurrentPlanet, req.CommodityID)
-		if err != nil {
-			return fmt.Errorf("failed to update market stock: %w", err)
-		}
-
-		// Update local state
-		player.AddCredits(-totalCost)
-
-		return nil
-	})
-
-	if err != nil {
-		return &api.TradeResponse{
-			Success: false,
-			Message: fmt.Sprintf("trade failed: %v", err),
-		}, nil
-	}
-
-	// Return success
-	return &api.TradeResponse{
-		Success:        true,
-		Message:        "purchase successful",
-		QuantityTraded: req.Quantity,
-		TotalCost:      totalCost,
-		PricePerUnit:   int32(price.SellPrice),
-		NewState:       convertPlayerToAPI(player, ship),
-	}, nil
+	return s.tradeSuccess(ctx, req.PlayerID, "purchase successful", -result.Total, result), nil
 }
 
-
 // SellCommodity sells a commodity to the market
 func (s *GameServer) SellCommodity(ctx context.Context, req *api.TradeRequest) (*api.TradeResponse, error) {
 	if req.PlayerID == uuid.Nil || req.CommodityID == "" || req.Quantity <= 0 {
@@ -883,119 +839,81 @@ func (s *GameServer) SellCommodity(ctx context.Context, req *api.TradeRequest) (
 		}, nil
 	}
 
-	// Load player
-	player, err := s.playerRepo.GetByID(ctx, req.PlayerID)
+	// Cargo removal, credit payout and market update happen in one transaction
+	result, err := s.tradingService.Sell(ctx, req.PlayerID, req.CommodityID, int(req.Quantity))
 	if err != nil {
-		return &api.TradeResponse{
-			Success: false,
-			Message: "player not found",
-		}, nil
+		return tradeFailure(err), nil
 	}
 
-	// Must be docked to trade
-	if !player.IsDocked() {
-		return &api.TradeResponse{
-			Success: false,
-			Message: "must be docked at a planet to trade",
-		}, nil
-	}
+	return s.tradeSuccess(ctx, req.PlayerID, "sale successful", result.Total, result), nil
+}
 
-	// Load ship
-	ship, err := s.shipRepo.GetByID(ctx, player.ShipID)
-	if err != nil {
-		return &api.TradeResponse{
-			Success: false,
-			Message: "ship not found",
-		}, nil
+// tradeFailure converts a trading service error into a failed TradeResponse.
+// Game-rule failures (insufficient credits, no stock, ...) are reported as-is;
+// anything else is an internal error.
+func tradeFailure(err error) *api.TradeResponse {
+	message := err.Error()
+	if !isTradeRuleError(err) {
+		message = fmt.Sprintf("trade failed: %v", err)
 	}
-
-	// Check if player has the commodity
-	currentQuantity := ship.GetCommodityQuantity(req.CommodityID)
-	if currentQuantity < int(req.Quantity) {
-		return &api.TradeResponse{
-			Success: false,
-			Message: fmt.Sprintf("insufficient cargo (have: %d, trying to sell: %d)", currentQuantity, req.Quantity),
-		}, nil
+	return &api.TradeResponse{
+		Success: false,
+		Message: message,
 	}
+}
 
-	// Get market price
-	price, err := s.marketRepo.GetMarketPrice(ctx, *player.CurrentPlanet, req.CommodityID)
-	if err != nil {
-		return &api.TradeResponse{
-			Success: false,
-			Message: "commodity not traded at this planet",
-		}, nil
+// isTradeRuleError reports whether err is one of the trading service's typed errors
+func isTradeRuleError(err error) bool {
+	for _, target := range []error{
+		trading.ErrInvalidQuantity,
+		trading.ErrUnknownCommodity,
+		trading.ErrPlayerNotFound,
+		trading.ErrNoShip,
+		trading.ErrNotDocked,
+		trading.ErrCommodityNotTraded,
+		trading.ErrInsufficientCredits,
+		trading.ErrInsufficientStock,
+		trading.ErrInsufficientCargoSpace,
+		trading.ErrInsufficientCargo,
+		trading.ErrInsufficientDemand,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
 	}
+	return false
+}
 
-	// Check demand (can't sell more than demand)
-	if price.Demand < int(req.Quantity) {
-		return &api.TradeResponse{
-			Success: false,
-			Message: fmt.Sprintf("insufficient demand (available: %d)", price.Demand),
-		}, nil
+// tradeSuccess builds a successful TradeResponse with the player's fresh state
+// and publishes the credit (creditDelta) and cargo changes
+func (s *GameServer) tradeSuccess(ctx context.Context, playerID uuid.UUID, message string, creditDelta int64, result *trading.TradeResult) *api.TradeResponse {
+	resp := &api.TradeResponse{
+		Success:        true,
+		Message:        message,
+		QuantityTraded: int32(result.Quantity),
+		TotalCost:      result.Total,
+		PricePerUnit:   int32(result.PricePerUnit),
 	}
 
-	// Calculate payment (player sells at buy price)
-	totalPayment := price.BuyPrice * int64(req.Quantity)
-
-	// Perform the trade atomically within a transaction
-	err = s.db.WithTransaction(ctx, func(tx *sql.Tx) error {
-		// 1. Add credits to player
-		_, err := tx.ExecContext(ctx,
-			"UPDATE players SET credits = credits + $1 WHERE id = $2",
-			totalPayment, req.PlayerID)
-		if err != nil {
-			return fmt.Errorf("failed to update player credits: %w", err)
-		}
-
-		// 2. Remove cargo from ship
-		if !ship.RemoveCargo(req.CommodityID, int(req.Quantity)) {
-			return fmt.Errorf("failed to remove cargo from ship")
-		}
-		cargoJSON, err := json.Marshal(ship.Cargo)
-		if err != nil {
-			return fmt.Errorf("failed to serialize cargo: %w", err)
-		}
-		_, err = tx.ExecContext(ctx,
-			"UPDATE ships SET cargo = $1 WHERE id = $2",
-			cargoJSON, player.ShipID)
-		if err != nil {
-			return fmt.Errorf("failed to update ship cargo: %w", err)
-		}
-
-		// 3. Update market stock (increase stock, decrease demand)
-		_, err = tx.ExecContext(ctx,
-			"UPDATE market_prices SET stock = stock + $1 WHERE planet_id = $2 AND commodity_id = $3",
-			req.Quantity, *player.CurrentPlanet, req.CommodityID)
-		if err != nil {
-			return fmt.Errorf("failed to update market stock: %w", err)
-		}
-
-		// Update local state
-		player.AddCredits(totalPayment)
-
-		return nil
-	})
+	s.updates.PublishCredits(playerID, result.NewCredits-creditDelta, result.NewCredits, "trade")
 
+	// The trade is committed; a failed reload only omits NewState
+	player, err := s.playerRepo.GetByID(ctx, playerID)
 	if err != nil {
-		return &api.TradeResponse{
-			Success: false,
-			Message: fmt.Sprintf("trade failed: %v", err),
-		}, nil
+		s.updates.PublishInventory(playerID, nil)
+		return resp
 	}
+	ship, err := s.shipRepo.GetByID(ctx, player.ShipID)
+	if err != nil {
+		s.updates.PublishInventory(playerID, nil)
+		return resp
+	}
+	resp.NewState = convertPlayerToAPI(player, ship)
+	s.updates.PublishInventory(playerID, ship)
 
-	// Return success
-	return &api.TradeResponse{
-		Success:        true,
-		Message:        "sale successful",
-		QuantityTraded: req.Quantity,
-		TotalCost:      totalPayment,
-		PricePerUnit:   int32(price.BuyPrice),
-		NewState:       convertPlayerToAPI(player, ship),
-	}, nil
+	return resp
 }
 
-
 // BuyShip purchases a new ship
 func (s *GameServer) BuyShip(ctx context.Context, req *api.ShipPurchaseRequest) (*api.ShipPurchaseResponse, error) {
 	if req.PlayerID == uuid.Nil || req.ShipType == "" {
@@ -1140,6 +1058,8 @@ func (s *GameServer) BuyShip(ctx context.Context, req *api.ShipPurchaseRequest)
 		}, nil
 	}
 
+	s.updates.PublishCredits(player.ID, player.Credits+totalCost, player.Credits, "ship purchase")
+
 	// Return success
 	return &api.ShipPurchaseResponse{
 		Success:      true,
@@ -1246,6 +1166,8 @@ func (s *GameServer) SellShip(ctx context.Context, req *api.ShipSaleRequest) (*a
 		}, nil
 	}
 
+	s.updates.PublishCredits(player.ID, player.Credits-saleValue, player.Credits, "ship sale")
+
 	// Load current ship for state
 	currentShip, _ := s.shipRepo.GetByID(ctx, player.ShipID)
 
@@ -1376,6 +1298,9 @@ func (s *GameServer) BuyOutfit(ctx context.Context, req *api.OutfitPurchaseReque
 		}, nil
 	}
 
+	s.updates.PublishCredits(player.ID, player.Credits+totalCost, player.Credits, "outfit purchase")
+	s.updates.PublishShip(player.ID, ship)
+
 	// Convert purchased outfits to API format
 	purchasedOutfits := make([]*api.Outfit, req.Quantity)
 	for i := int32(0); i < req.Quantity; i++ {
@@ -1509,6 +1434,9 @@ func (s *GameServer) SellOutfit(ctx context.Context, req *api.OutfitSaleRequest)
 		}, nil
 	}
 
+	s.updates.PublishCredits(player.ID, player.Credits-saleValue, player.Credits, "outfit sale")
+	s.updates.PublishShip(player.ID, ship)
+
 	// Return success
 	return &api.OutfitSaleResponse{
 		Success:     true,
@@ -1597,6 +1525,7 @@ func (s *GameServer) AcceptMission(ctx context.Context, req *api.MissionAcceptRe
 	if err != nil {
 		return nil, fmt.Errorf("failed to update ship: %w", err)
 	}
+	s.updates.PublishInventory(player.ID, ship)
 
 	// Get the accepted mission
 	mission := s.missionMgr.GetMissionByID(req.MissionID)
@@ -0,0 +1,283 @@
+// File: internal/api/server/updates.go
+// Project: Terminal Velocity
+// Description: Player update event bus backing StreamPlayerUpdates
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package server
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/api"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// updateStreamBuffer is the number of pending updates held per subscriber
+const updateStreamBuffer = 32
+
+// UpdateBus fans player state changes out to StreamPlayerUpdates subscribers.
+//
+// Publishers (GameServer methods, or managers wired by the server) call
+// Publish after a change is committed. Each subscriber receives only updates
+// for its own player.
+//
+// Backpressure:
+// Every stream has a bounded buffer. When a subscriber falls behind, the
+// oldest pending update is discarded to make room, so a slow reader always
+// ends up with the latest state (updates carry absolute values such as
+// NewCredits) and publishers never block.
+//
+// Thread Safety:
+// All methods are safe for concurrent use.
+type UpdateBus struct {
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[*updateStream]struct{}
+	dropped     uint64
+	closed      bool
+}
+
+// NewUpdateBus creates an empty update bus
+func NewUpdateBus() *UpdateBus {
+	return &UpdateBus{
+		subscribers: make(map[uuid.UUID]map[*updateStream]struct{}),
+	}
+}
+
+// Subscribe opens a stream of updates for the player.
+// The stream ends when ctx is cancelled, Close is called, or the bus closes.
+func (b *UpdateBus) Subscribe(ctx context.Context, playerID uuid.UUID) api.PlayerUpdateStream {
+	stream := &updateStream{
+		bus:      b,
+		playerID: playerID,
+		ctx:      ctx,
+		updates:  make(chan *api.PlayerUpdate, updateStreamBuffer),
+		done:     make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		close(stream.done)
+		b.mu.Unlock()
+		return stream
+	}
+	if b.subscribers[playerID] == nil {
+		b.subscribers[playerID] = make(map[*updateStream]struct{})
+	}
+	b.subscribers[playerID][stream] = struct{}{}
+	b.mu.Unlock()
+
+	// Release the subscription as soon as the caller's context ends
+	go func() {
+		select {
+		case <-ctx.Done():
+			stream.Close()
+		case <-stream.done:
+		}
+	}()
+
+	return stream
+}
+
+// Publish delivers an update to every stream subscribed to update.PlayerID.
+// It never blocks; see the type documentation for overflow behaviour.
+func (b *UpdateBus) Publish(update *api.PlayerUpdate) {
+	if update == nil || update.PlayerID == uuid.Nil {
+		return
+	}
+	if update.Timestamp.IsZero() {
+		update.Timestamp = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for stream := range b.subscribers[update.PlayerID] {
+		for {
+			select {
+			case stream.updates <- update:
+			default:
+				// Full: discard the oldest pending update and retry
+				select {
+				case <-stream.updates:
+					b.dropped++
+				default:
+				}
+				continue
+			}
+			break
+		}
+	}
+}
+
+// SubscriberCount returns the number of open streams
+func (b *UpdateBus) SubscriberCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	count := 0
+	for _, streams := range b.subscribers {
+		count += len(streams)
+	}
+	return count
+}
+
+// DroppedUpdates returns how many updates were discarded for slow subscribers
+func (b *UpdateBus) DroppedUpdates() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+// Close ends every open stream and rejects new subscriptions
+func (b *UpdateBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for playerID, streams := range b.subscribers {
+		for stream := range streams {
+			stream.closeDone()
+		}
+		delete(b.subscribers, playerID)
+	}
+}
+
+// unsubscribe removes a stream from the bus
+func (b *UpdateBus) unsubscribe(stream *updateStream) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if streams, ok := b.subscribers[stream.playerID]; ok {
+		delete(streams, stream)
+		if len(streams) == 0 {
+			delete(b.subscribers, stream.playerID)
+		}
+	}
+	stream.closeDone()
+}
+
+// PublishCredits publishes a credits change
+func (b *UpdateBus) PublishCredits(playerID uuid.UUID, oldCredits, newCredits int64, reason string) {
+	b.Publish(&api.PlayerUpdate{
+		PlayerID: playerID,
+		Type:     api.UpdateTypeCredits,
+		CreditsUpdate: &api.CreditsUpdate{
+			OldCredits: oldCredits,
+			NewCredits: newCredits,
+			Delta:      newCredits - oldCredits,
+			Reason:     reason,
+		},
+	})
+}
+
+// PublishInventory publishes a cargo/item change.
+// A nil ship publishes a change signal without contents; subscribers re-read.
+func (b *UpdateBus) PublishInventory(playerID uuid.UUID, ship *models.Ship) {
+	update := &api.PlayerUpdate{
+		PlayerID:        playerID,
+		Type:            api.UpdateTypeInventory,
+		InventoryUpdate: &api.InventoryUpdate{},
+	}
+	if ship != nil {
+		update.InventoryUpdate.Inventory = convertInventoryToAPI(ship)
+	}
+	b.Publish(update)
+}
+
+// PublishShip publishes a change to the player's current ship
+func (b *UpdateBus) PublishShip(playerID uuid.UUID, ship *models.Ship) {
+	if ship == nil {
+		return
+	}
+	b.Publish(&api.PlayerUpdate{
+		PlayerID:   playerID,
+		Type:       api.UpdateTypeShip,
+		ShipUpdate: &api.ShipUpdate{Ship: convertShipToAPI(ship)},
+	})
+}
+
+// PublishLocation publishes the player's new system, planet and position
+func (b *UpdateBus) PublishLocation(player *models.Player) {
+	b.Publish(&api.PlayerUpdate{
+		PlayerID: player.ID,
+		Type:     api.UpdateTypeLocation,
+		LocationUpdate: &api.LocationUpdate{
+			PlayerID: player.ID,
+			SystemID: player.CurrentSystem,
+			PlanetID: player.CurrentPlanet,
+			Position: api.Coordinates{X: player.X, Y: player.Y},
+		},
+	})
+}
+
+// PublishStatus publishes a docked/in-space (etc.) status change
+func (b *UpdateBus) PublishStatus(playerID uuid.UUID, oldStatus, newStatus api.PlayerStatus) {
+	b.Publish(&api.PlayerUpdate{
+		PlayerID:     playerID,
+		Type:         api.UpdateTypeStatus,
+		StatusUpdate: &api.StatusUpdate{OldStatus: oldStatus, NewStatus: newStatus},
+	})
+}
+
+// PublishProgress publishes the player's crafting skill, crafts and research points
+func (b *UpdateBus) PublishProgress(player *models.Player) {
+	b.Publish(&api.PlayerUpdate{
+		PlayerID: player.ID,
+		Type:     api.UpdateTypeProgress,
+		ProgressUpdate: &api.ProgressUpdate{
+			CraftingSkill:  int32(player.CraftingSkill),
+			TotalCrafts:    int32(player.TotalCrafts),
+			ResearchPoints: int32(player.ResearchPoints),
+		},
+	})
+}
+
+// updateStream is a single subscriber's view of the bus
+type updateStream struct {
+	bus      *UpdateBus
+	playerID uuid.UUID
+	ctx      context.Context
+	updates  chan *api.PlayerUpdate
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// Recv blocks until the next update.
+// It returns the context error if the subscriber's context ends, and io.EOF
+// once the stream or bus is closed.
+func (s *updateStream) Recv() (*api.PlayerUpdate, error) {
+	// Deliver anything already buffered before reporting closure
+	select {
+	case update := <-s.updates:
+		return update, nil
+	default:
+	}
+
+	select {
+	case update := <-s.updates:
+		return update, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case <-s.done:
+		return nil, io.EOF
+	}
+}
+
+// Close unsubscribes the stream. Safe to call more than once.
+func (s *updateStream) Close() error {
+	s.bus.unsubscribe(s)
+	return nil
+}
+
+// closeDone marks the stream finished exactly once
+func (s *updateStream) closeDone() {
+	s.doneOnce.Do(func() {
+		close(s.done)
+	})
+}
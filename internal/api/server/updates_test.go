@@ -0,0 +1,165 @@
+// File: internal/api/server/updates_test.go
+// Project: Terminal Velocity
+// Description: Tests for the player update bus
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/api"
+	"github.com/google/uuid"
+)
+
+// recvWithin receives from a stream or fails after a timeout
+func recvWithin(t *testing.T, stream api.PlayerUpdateStream) (*api.PlayerUpdate, error) {
+	t.Helper()
+
+	type result struct {
+		update *api.PlayerUpdate
+		err    error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		update, err := stream.Recv()
+		ch <- result{update, err}
+	}()
+
+	select {
+	case r := <-ch:
+		return r.update, r.err
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for update")
+		return nil, nil
+	}
+}
+
+func TestUpdateBusDeliversOnlyToOwnPlayer(t *testing.T) {
+	bus := NewUpdateBus()
+	defer bus.Close()
+
+	alice, bob := uuid.New(), uuid.New()
+	aliceStream := bus.Subscribe(context.Background(), alice)
+	bobStream := bus.Subscribe(context.Background(), bob)
+
+	bus.PublishCredits(bob, 100, 250, "auction sale")
+	bus.PublishCredits(alice, 500, 400, "auction bid")
+
+	update, err := recvWithin(t, aliceStream)
+	if err != nil {
+		t.Fatalf("Recv failed: %v", err)
+	}
+	if update.PlayerID != alice || update.CreditsUpdate.NewCredits != 400 || update.CreditsUpdate.Delta != -100 {
+		t.Errorf("Unexpected update for alice: %+v", update.CreditsUpdate)
+	}
+
+	update, err = recvWithin(t, bobStream)
+	if err != nil {
+		t.Fatalf("Recv failed: %v", err)
+	}
+	if update.PlayerID != bob || update.CreditsUpdate.NewCredits != 250 {
+		t.Errorf("Unexpected update for bob: %+v", update.CreditsUpdate)
+	}
+}
+
+// TestUpdateBusSlowSubscriberKeepsLatest verifies overflow drops the oldest updates
+func TestUpdateBusSlowSubscriberKeepsLatest(t *testing.T) {
+	bus := NewUpdateBus()
+	defer bus.Close()
+
+	playerID := uuid.New()
+	stream := bus.Subscribe(context.Background(), playerID)
+
+	total := updateStreamBuffer + 10
+	for i := 1; i <= total; i++ {
+		bus.PublishCredits(playerID, int64(i-1), int64(i), "test")
+	}
+
+	if dropped := bus.DroppedUpdates(); dropped != 10 {
+		t.Errorf("Expected 10 dropped updates, got %d", dropped)
+	}
+
+	var last int64
+	for i := 0; i < updateStreamBuffer; i++ {
+		update, err := recvWithin(t, stream)
+		if err != nil {
+			t.Fatalf("Recv failed: %v", err)
+		}
+		last = update.CreditsUpdate.NewCredits
+	}
+	if last != int64(total) {
+		t.Errorf("Expected latest balance %d, got %d", total, last)
+	}
+}
+
+// TestUpdateBusContextCancellation verifies cancelling the context ends the stream
+func TestUpdateBusContextCancellation(t *testing.T) {
+	bus := NewUpdateBus()
+	defer bus.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := bus.Subscribe(ctx, uuid.New())
+	cancel()
+
+	if _, err := recvWithin(t, stream); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for bus.SubscriberCount() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Cancelled stream was not unsubscribed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestUpdateBusClose(t *testing.T) {
+	bus := NewUpdateBus()
+	stream := bus.Subscribe(context.Background(), uuid.New())
+	bus.Close()
+
+	if _, err := recvWithin(t, stream); err != io.EOF {
+		t.Errorf("Expected io.EOF after bus close, got %v", err)
+	}
+
+	// Subscribing after close returns an already-ended stream
+	late := bus.Subscribe(context.Background(), uuid.New())
+	if _, err := recvWithin(t, late); err != io.EOF {
+		t.Errorf("Expected io.EOF for late subscriber, got %v", err)
+	}
+}
+
+func TestStreamPlayerUpdatesRequiresPlayer(t *testing.T) {
+	gameServer, err := NewGameServer(&Config{})
+	if err != nil {
+		t.Fatalf("NewGameServer failed: %v", err)
+	}
+
+	if _, err := gameServer.StreamPlayerUpdates(context.Background(), uuid.Nil); !errors.Is(err, api.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest, got %v", err)
+	}
+
+	playerID := uuid.New()
+	stream, err := gameServer.StreamPlayerUpdates(context.Background(), playerID)
+	if err != nil {
+		t.Fatalf("StreamPlayerUpdates failed: %v", err)
+	}
+	defer stream.Close()
+
+	gameServer.Updates().PublishStatus(playerID, api.PlayerStatusDocked, api.PlayerStatusInSpace)
+	update, err := recvWithin(t, stream)
+	if err != nil {
+		t.Fatalf("Recv failed: %v", err)
+	}
+	if update.StatusUpdate == nil || update.StatusUpdate.NewStatus != api.PlayerStatusInSpace {
+		t.Errorf("Unexpected update: %+v", update)
+	}
+}
@@ -1,7 +1,7 @@
 // File: internal/api/types.go
 // Project: Terminal Velocity
 // Description: API types for client-server communication
-// Version: 1.0.0
+// Version: 1.1.0
 // Author: Joshua Ferguson
 // Created: 2025-01-14
 
@@ -216,6 +216,18 @@ type ReputationInfo struct {
 	Bounty            int64
 }
 
+type MailList struct {
+	Messages    []*MailMessage
+	UnreadCount int32
+}
+
+type MailMessage struct {
+	MailID     uuid.UUID
+	SenderName string
+	Subject    string
+	SentAt     time.Time
+}
+
 type PlayerStatus string
 
 const (
@@ -244,6 +256,7 @@ type PlayerUpdate struct {
 	InventoryUpdate  *InventoryUpdate
 	StatusUpdate     *StatusUpdate
 	ReputationUpdate *ReputationUpdate
+	ProgressUpdate   *ProgressUpdate
 }
 
 type UpdateType string
@@ -255,6 +268,7 @@ const (
 	UpdateTypeInventory  UpdateType = "inventory"
 	UpdateTypeStatus     UpdateType = "status"
 	UpdateTypeReputation UpdateType = "reputation"
+	UpdateTypeProgress   UpdateType = "progress"
 )
 
 type CreditsUpdate struct {
@@ -285,6 +299,13 @@ type ReputationUpdate struct {
 	Reason        string
 }
 
+// ProgressUpdate carries the player's crafting and research progression
+type ProgressUpdate struct {
+	CraftingSkill  int32
+	TotalCrafts    int32
+	ResearchPoints int32
+}
+
 // Game Types
 
 type JumpRequest struct {
@@ -0,0 +1,357 @@
+// File: internal/arena/fight.go
+// Project: Terminal Velocity
+// Description: Server-side arena battles fought by the combat engine
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package arena
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/combat"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// maxFightLog is the number of battle log lines kept for viewers
+const maxFightLog = 50
+
+// fight is a match being fought. Every player flies a copy of their own
+// ship, repaired to full, under engine AI control; the red team fights on
+// the engine's player side and the blue team on its enemy side.
+type fight struct {
+	engine  *combat.Engine
+	players map[string]uuid.UUID // combatant ID -> player ID
+	names   map[uuid.UUID]string // player ID -> player name
+	log     []string
+}
+
+// Fighter is one player's ship in a live match view
+type Fighter struct {
+	PlayerID   uuid.UUID
+	PlayerName string
+	ShipName   string
+	Team       string
+	Hull       int
+	MaxHull    int
+	Shields    int
+	MaxShields int
+	Active     bool // Still fighting (not destroyed or retreated)
+}
+
+// MatchView is a snapshot of a live match for participants and spectators
+type MatchView struct {
+	Match     *Match
+	ArenaName string
+	Turn      int
+	Fighters  []Fighter
+	Log       []string // Most recent battle log lines, oldest first
+}
+
+// loadShip returns a repaired copy of the player's current ship and its
+// type, or ErrNoShip if they have no ship with weapons
+func (m *Manager) loadShip(ctx context.Context, player *models.Player) (*models.Ship, *models.ShipType, error) {
+	if player.ShipID == uuid.Nil {
+		return nil, nil, ErrNoShip
+	}
+	ship, err := m.ships.GetByID(ctx, player.ShipID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get ship: %w", err)
+	}
+	shipType := models.GetShipTypeByID(ship.TypeID)
+	if shipType == nil || len(ship.Weapons) == 0 {
+		return nil, nil, ErrNoShip
+	}
+
+	arenaShip := *ship
+	arenaShip.Hull = shipType.MaxHull
+	arenaShip.Shields = shipType.MaxShields
+	return &arenaShip, shipType, nil
+}
+
+// launchMatch starts a waiting match in a free arena. Players and ships
+// are read before m.mu is taken; the match then starts only if it is still
+// waiting and an arena is still free. Caller must not hold m.mu.
+func (m *Manager) launchMatch(ctx context.Context, matchID uuid.UUID) error {
+	m.mu.RLock()
+	match, exists := m.matches[matchID]
+	var teams map[string][]uuid.UUID
+	var err error
+	switch {
+	case !exists:
+		err = ErrMatchNotFound
+	case match.Status != models.MatchWaiting:
+		err = ErrMatchStarted
+	case m.availableArena() == nil:
+		err = ErrNoArena
+	default:
+		teams = copyMatch(match).Teams
+	}
+	m.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	f, fielded := m.prepareFight(ctx, teams)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if match.Status != models.MatchWaiting {
+		return ErrMatchStarted
+	}
+	arena := m.availableArena()
+	if arena == nil {
+		return ErrNoArena
+	}
+	return m.startMatch(match, arena, f, fielded)
+}
+
+// prepareFight loads each team's ships and sets up their battle. Players
+// without a usable ship forfeit and are left out of fielded. Caller must
+// not hold m.mu.
+func (m *Manager) prepareFight(ctx context.Context, teams map[string][]uuid.UUID) (*fight, map[string][]uuid.UUID) {
+	f := &fight{
+		engine:  combat.NewEngine(time.Now().UnixNano()),
+		players: make(map[string]uuid.UUID),
+		names:   make(map[uuid.UUID]string),
+	}
+
+	fielded := map[string][]uuid.UUID{}
+	for _, team := range []string{"red", "blue"} {
+		side := combat.SidePlayer
+		if team == "blue" {
+			side = combat.SideEnemy
+		}
+		for _, playerID := range teams[team] {
+			player, err := m.players.GetByID(ctx, playerID)
+			if err != nil {
+				log.Warn("Arena player %s forfeits: %v", playerID, err)
+				continue
+			}
+			ship, shipType, err := m.loadShip(ctx, player)
+			if err != nil {
+				log.Warn("Arena player %s forfeits: %v", playerID, err)
+				continue
+			}
+			combatant, err := f.engine.AddCombatant(ship, shipType, side, combat.NewAIState(combat.AILevelHard))
+			if err != nil {
+				log.Warn("Arena player %s forfeits: %v", playerID, err)
+				continue
+			}
+			combatant.Name = fmt.Sprintf("%s (%s)", player.Username, combatant.Name)
+			f.players[combatant.ID] = playerID
+			f.names[playerID] = player.Username
+			fielded[team] = append(fielded[team], playerID)
+		}
+	}
+	return f, fielded
+}
+
+// startMatch puts a waiting match into an arena with the battle
+// prepareFight set up. A side that fielded no ship forfeits. The match
+// stays waiting if its start cannot be saved. Caller must hold m.mu.
+func (m *Manager) startMatch(match *Match, arena *Arena, f *fight, fielded map[string][]uuid.UUID) error {
+	// A side that cannot field a ship loses without a fight
+	if len(fielded["red"]) == 0 || len(fielded["blue"]) == 0 {
+		switch {
+		case len(fielded["red"]) > 0:
+			return m.endMatch(match, fielded["red"][0])
+		case len(fielded["blue"]) > 0:
+			return m.endMatch(match, fielded["blue"][0])
+		case match.TournamentID != nil:
+			// The bracket must go on; the higher seed advances
+			return m.endMatch(match, match.Players[0])
+		default:
+			return m.cancelMatch(match, "no player could field a ship")
+		}
+	}
+
+	started := *match
+	started.ArenaID = arena.ID
+	started.Status = models.MatchInProgress
+	started.StartTime = time.Now()
+	if err := m.saveMatch(&started); err != nil {
+		return err
+	}
+	*match = started
+	arena.Status = "occupied"
+	f.addLog(fmt.Sprintf("Match begins in %s", arena.Name))
+	m.fights[match.ID] = f
+
+	log.Info("Match started: match=%s, arena=%s, type=%s", match.ID, arena.Name, match.Type)
+
+	if m.onMatchStart != nil {
+		go m.onMatchStart(copyMatch(match))
+	}
+	return nil
+}
+
+// addLog appends lines to the battle log, keeping the most recent ones
+func (f *fight) addLog(lines ...string) {
+	f.log = append(f.log, lines...)
+	if len(f.log) > maxFightLog {
+		f.log = append([]string(nil), f.log[len(f.log)-maxFightLog:]...)
+	}
+}
+
+// matchWorker fights live matches one turn per TurnInterval
+func (m *Manager) matchWorker() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.config.TurnInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.advanceFights()
+		case <-m.stopChan:
+			return
+		}
+	}
+}
+
+// advanceFights plays one turn of every live match and ends those that
+// are decided
+func (m *Manager) advanceFights() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for matchID, f := range m.fights {
+		match, exists := m.matches[matchID]
+		if !exists || match.Status != models.MatchInProgress {
+			delete(m.fights, matchID)
+			continue
+		}
+
+		for _, event := range f.engine.EndTurn() {
+			m.recordEvent(match, f, event)
+		}
+
+		var err error
+		switch f.engine.Outcome() {
+		case combat.OutcomeVictory:
+			err = m.endMatch(match, m.topScorer(match, f, "red"))
+		case combat.OutcomeDefeat:
+			err = m.endMatch(match, m.topScorer(match, f, "blue"))
+		default:
+			if f.engine.Turn() > m.config.MaxMatchTurns {
+				f.addLog("Time limit reached - decided on damage dealt")
+				err = m.endMatch(match, m.decideOnPoints(match, f))
+			}
+		}
+		if err != nil {
+			log.Error("Failed to end match %s: %v", matchID, err)
+		}
+	}
+}
+
+// recordEvent adds an engine event to the match statistics and battle log.
+// Caller must hold m.mu.
+func (m *Manager) recordEvent(match *Match, f *fight, event combat.Event) {
+	switch event.Type {
+	case combat.EventFire:
+		attacker, target := f.players[event.Actor], f.players[event.Target]
+		if event.Hit {
+			match.MatchData.DamageDealt[attacker] += float64(event.Damage)
+			match.MatchData.DamageTaken[target] += float64(event.Damage)
+			match.Scores[attacker] += event.Damage
+		}
+	case combat.EventDestroyed:
+		attacker, target := f.players[event.Actor], f.players[event.Target]
+		match.MatchData.Kills[attacker]++
+		match.MatchData.Deaths[target]++
+	case combat.EventShieldRegen, combat.EventTurnStart:
+		// Too frequent to be worth showing viewers
+		return
+	}
+	f.addLog(event.Message)
+}
+
+// topScorer returns the player of the winning team who dealt the most
+// damage. Caller must hold m.mu.
+func (m *Manager) topScorer(match *Match, f *fight, team string) uuid.UUID {
+	best := uuid.Nil
+	for _, playerID := range match.Teams[team] {
+		if _, fielded := f.names[playerID]; !fielded {
+			continue
+		}
+		if best == uuid.Nil || match.Scores[playerID] > match.Scores[best] {
+			best = playerID
+		}
+	}
+	return best
+}
+
+// decideOnPoints picks the winner of a match that ran out of turns: the
+// team that dealt more damage. A tie is a draw, except in tournaments
+// where the higher seed advances. Caller must hold m.mu.
+func (m *Manager) decideOnPoints(match *Match, f *fight) uuid.UUID {
+	totals := map[string]int{}
+	for team, members := range match.Teams {
+		for _, playerID := range members {
+			totals[team] += match.Scores[playerID]
+		}
+	}
+
+	switch {
+	case totals["red"] > totals["blue"]:
+		return m.topScorer(match, f, "red")
+	case totals["blue"] > totals["red"]:
+		return m.topScorer(match, f, "blue")
+	case match.TournamentID != nil:
+		return match.Players[0]
+	default:
+		return uuid.Nil
+	}
+}
+
+// GetMatchView returns a snapshot of a match with its fighters' condition
+// and recent battle log
+func (m *Manager) GetMatchView(matchID uuid.UUID) (*MatchView, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	match, exists := m.matches[matchID]
+	if !exists {
+		return nil, ErrMatchNotFound
+	}
+
+	view := &MatchView{Match: copyMatch(match)}
+	if arena, exists := m.arenas[match.ArenaID]; exists {
+		view.ArenaName = arena.Name
+	}
+
+	f, live := m.fights[matchID]
+	if !live {
+		return view, nil
+	}
+	view.Turn = f.engine.Turn()
+	view.Log = append([]string(nil), f.log...)
+	for _, side := range []combat.Side{combat.SidePlayer, combat.SideEnemy} {
+		for _, c := range f.engine.Combatants(side) {
+			playerID := f.players[c.ID]
+			shipName := c.Ship.Name
+			if shipName == "" {
+				shipName = c.Type.Name
+			}
+			view.Fighters = append(view.Fighters, Fighter{
+				PlayerID:   playerID,
+				PlayerName: f.names[playerID],
+				ShipName:   shipName,
+				Team:       match.Team(playerID),
+				Hull:       c.Ship.Hull,
+				MaxHull:    c.Type.MaxHull,
+				Shields:    c.Ship.Shields,
+				MaxShields: c.Type.MaxShields,
+				Active:     c.Active(),
+			})
+		}
+	}
+	return view, nil
+}
@@ -1,25 +1,81 @@
 // File: internal/arena/manager.go
 // Project: Terminal Velocity
 // Description: Enhanced PvP system with arenas, tournaments, and spectator mode
-// Version: 1.0.0
+// Version: 1.3.0
 // Author: Claude Code
 // Created: 2025-11-15
+//
+// Players queue for a ranked match type and are matched by ELO. A match
+// waits for a free arena, then is fought turn by turn by the combat engine
+// with each player's own ship (see fight.go); participants and spectators
+// watch it live, and EndMatch is called with whoever is left flying.
+// Rankings use the ELO expected-score formula and run in seasons that end
+// with a soft reset and mailed rewards (see season.go). Tournaments are
+// single-elimination brackets of the same matches, seeded by ELO and paid
+// from entry fees.
+//
+// Rankings, match history, tournaments and the current season are saved
+// through the Store and restored by Load.
 
 package arena
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"sync"
 	"time"
 
-	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	"github.com/google/uuid"
 )
 
 var log = logger.WithComponent("Arena")
 
+var (
+	// ErrMatchNotFound is returned for an unknown match
+	ErrMatchNotFound = errors.New("match not found")
+
+	// ErrMatchNotInProgress is returned when acting on a finished match
+	ErrMatchNotInProgress = errors.New("match not in progress")
+
+	// ErrMatchStarted is returned when starting a match that is not waiting
+	ErrMatchStarted = errors.New("match already started or completed")
+
+	// ErrNoArena is returned when every arena is occupied
+	ErrNoArena = errors.New("no available arenas")
+
+	// ErrAlreadyInMatch is returned when queueing while a match is pending
+	ErrAlreadyInMatch = errors.New("already in a match")
+
+	// ErrAlreadyQueued is returned when queueing twice
+	ErrAlreadyQueued = errors.New("already in the matchmaking queue")
+
+	// ErrNotQueued is returned when leaving a queue the player is not in
+	ErrNotQueued = errors.New("not in queue")
+
+	// ErrUnsupportedMatchType is returned for match types the arena cannot run
+	ErrUnsupportedMatchType = errors.New("match type is not available for ranked play")
+
+	// ErrNoShip is returned when a player has no armed ship to fight with
+	ErrNoShip = errors.New("you need an armed ship to fight in the arena")
+
+	// ErrTournamentNotFound is returned for an unknown tournament
+	ErrTournamentNotFound = errors.New("tournament not found")
+
+	// ErrRegistrationClosed is returned when entering a tournament that started
+	ErrRegistrationClosed = errors.New("registration closed")
+
+	// ErrTournamentFull is returned when a tournament has no places left
+	ErrTournamentFull = errors.New("tournament full")
+
+	// ErrAlreadyRegistered is returned when entering a tournament twice
+	ErrAlreadyRegistered = errors.New("already registered")
+)
+
 // Manager handles arena battles, tournaments, and spectating
 type Manager struct {
 	mu sync.RWMutex
@@ -28,22 +84,31 @@ type Manager struct {
 	arenas      map[uuid.UUID]*Arena
 	matches     map[uuid.UUID]*Match
 	tournaments map[uuid.UUID]*Tournament
-	spectators  map[uuid.UUID][]uuid.UUID // match_id -> spectator player IDs
-	rankings    map[string]*PlayerRanking  // player_id -> ranking
-	matchQueue  map[MatchType][]*QueueEntry // matchmaking queue by match type
+	spectators  map[uuid.UUID][]uuid.UUID    // match_id -> spectator player IDs
+	rankings    map[uuid.UUID]*PlayerRanking // player_id -> ranking
+	matchQueue  map[MatchType][]*QueueEntry  // matchmaking queue by match type
+	fights      map[uuid.UUID]*fight         // match_id -> battle being fought
+	season      *Season                      // Current ranked season
+	lastDecay   time.Time                    // When inactive rankings last decayed
 
 	// Configuration
 	config ArenaConfig
 
-	// Repositories
-	playerRepo *database.PlayerRepository
+	// Player accounts, ships and mail
+	players Players
+	ships   Ships
+	mailer  Mailer
+
+	// Persistence (nil keeps everything in memory)
+	store Store
 
 	// Callbacks
-	onMatchStart    func(match *Match)
-	onMatchEnd      func(match *Match)
+	onMatchStart      func(match *Match)
+	onMatchEnd        func(match *Match)
 	onTournamentStart func(tournament *Tournament)
 	onTournamentEnd   func(tournament *Tournament)
 	onSpectatorJoin   func(matchID, spectatorID uuid.UUID)
+	onCreditsChanged  func(playerID uuid.UUID, delta int64, reason string)
 
 	// Background workers
 	stopChan chan struct{}
@@ -53,69 +118,96 @@ type Manager struct {
 // ArenaConfig defines arena system parameters
 type ArenaConfig struct {
 	// Arena settings
-	ArenaCount              int           // Number of available arenas
-	MatchQueueTimeout       time.Duration // Max time in matchmaking queue
-	RankingDecayRate        float64       // Daily ranking decay
+	ArenaCount        int           // Number of available arenas
+	MatchQueueTimeout time.Duration // Max time in matchmaking queue
+	RankingDecayRate  float64       // Daily ranking decay
+	RankedMatchTypes  []MatchType   // Match types players can queue for
 
 	// Match settings
-	MatchDuration           time.Duration // Maximum match duration
-	RoundDuration           time.Duration // Duration per round
-	RespawnTime             time.Duration // Time before respawn in match
-	SpectatorDelay          time.Duration // Spectator view delay
-	MaxSpectatorsPerMatch   int           // Max spectators per match
+	TurnInterval          time.Duration // Time between combat turns in a live match
+	MaxMatchTurns         int           // Turns before a match is decided on damage dealt
+	MaxSpectatorsPerMatch int           // Max spectators per match
+	HistoryRetention      time.Duration // How long finished matches and tournaments stay loaded
 
 	// Tournament settings
-	MinTournamentPlayers    int           // Minimum players to start
-	MaxTournamentPlayers    int           // Maximum tournament size
-	TournamentEntryFee      int64         // Entry fee in credits
-	TournamentPrizePool     float64       // % of entry fees as prizes
-	TournamentBracketType   string        // "single_elimination", "double_elimination"
+	MinTournamentPlayers   int           // Minimum players to start
+	MaxTournamentPlayers   int           // Maximum tournament size
+	TournamentEntryFee     int64         // Entry fee in credits
+	TournamentPrizePool    float64       // % of entry fees as prizes
+	TournamentPrizeShares  []float64     // Share of the prize pool for 1st, 2nd, 3rd
+	TournamentRegistration time.Duration // How long a scheduled tournament takes entries
+	TournamentBracketType  string        // "single_elimination", "double_elimination"
 
 	// Ranking settings
-	StartingELO             int           // Starting ELO rating
-	ELOKFactor              int           // ELO calculation K-factor
-	WinStreakBonus          int           // Bonus per win streak
-	RankTiers               []string      // Rank tier names
+	StartingELO    int      // Starting ELO rating
+	ELOKFactor     int      // ELO calculation K-factor
+	WinStreakBonus int      // Bonus per win streak
+	RankTiers      []string // Rank tier names
+	TierFloors     []int    // Lowest ELO of each tier after the first
+
+	// Season settings
+	SeasonLength    time.Duration    // Length of a ranked season
+	SeasonSoftReset float64          // Share of distance from StartingELO kept at a reset
+	SeasonRewards   map[string]int64 // Credits paid per final tier
 }
 
 // DefaultArenaConfig returns sensible defaults
 func DefaultArenaConfig() ArenaConfig {
 	return ArenaConfig{
-		ArenaCount:            10,
-		MatchQueueTimeout:     5 * time.Minute,
-		RankingDecayRate:      0.02, // 2% per day
-		MatchDuration:         15 * time.Minute,
-		RoundDuration:         5 * time.Minute,
-		RespawnTime:           10 * time.Second,
-		SpectatorDelay:        5 * time.Second,
-		MaxSpectatorsPerMatch: 50,
-		MinTournamentPlayers:  4,
-		MaxTournamentPlayers:  32,
-		TournamentEntryFee:    10000,
-		TournamentPrizePool:   0.90, // 90% of fees
-		TournamentBracketType: "single_elimination",
-		StartingELO:           1000,
-		ELOKFactor:            32,
-		WinStreakBonus:        50,
+		ArenaCount:             5,
+		MatchQueueTimeout:      5 * time.Minute,
+		RankingDecayRate:       0.02, // 2% per day
+		RankedMatchTypes:       []MatchType{MatchTypeDuel, MatchTypeTeamDeathmatch},
+		TurnInterval:           2 * time.Second,
+		MaxMatchTurns:          120,
+		MaxSpectatorsPerMatch:  50,
+		HistoryRetention:       30 * 24 * time.Hour,
+		MinTournamentPlayers:   4,
+		MaxTournamentPlayers:   32,
+		TournamentEntryFee:     10000,
+		TournamentPrizePool:    0.90, // 90% of fees
+		TournamentPrizeShares:  []float64{0.60, 0.30, 0.10},
+		TournamentRegistration: 6 * time.Hour,
+		TournamentBracketType:  "single_elimination",
+		StartingELO:            1000,
+		ELOKFactor:             32,
+		WinStreakBonus:         50,
 		RankTiers: []string{
 			"Bronze", "Silver", "Gold", "Platinum", "Diamond", "Master", "Grandmaster",
 		},
+		TierFloors:      []int{800, 1100, 1400, 1700, 2000, 2300},
+		SeasonLength:    28 * 24 * time.Hour,
+		SeasonSoftReset: 0.5,
+		SeasonRewards: map[string]int64{
+			"Bronze":      5000,
+			"Silver":      10000,
+			"Gold":        25000,
+			"Platinum":    50000,
+			"Diamond":     100000,
+			"Master":      200000,
+			"Grandmaster": 400000,
+		},
 	}
 }
 
-// NewManager creates a new arena manager
-func NewManager(playerRepo *database.PlayerRepository) *Manager {
+// NewManager creates a new arena manager that fights matches with players'
+// ships and moves credits through the given repositories
+func NewManager(players Players, ships Ships) *Manager {
 	m := &Manager{
 		arenas:      make(map[uuid.UUID]*Arena),
 		matches:     make(map[uuid.UUID]*Match),
 		tournaments: make(map[uuid.UUID]*Tournament),
 		spectators:  make(map[uuid.UUID][]uuid.UUID),
-		rankings:    make(map[string]*PlayerRanking),
+		rankings:    make(map[uuid.UUID]*PlayerRanking),
 		matchQueue:  make(map[MatchType][]*QueueEntry),
+		fights:      make(map[uuid.UUID]*fight),
 		config:      DefaultArenaConfig(),
-		playerRepo:  playerRepo,
+		players:     players,
+		ships:       ships,
+		lastDecay:   time.Now(),
 		stopChan:    make(chan struct{}),
 	}
+	m.season = m.newSeason(1, time.Now())
 
 	// Initialize arenas
 	m.initializeArenas()
@@ -125,9 +217,10 @@ func NewManager(playerRepo *database.PlayerRepository) *Manager {
 
 // Start begins background workers
 func (m *Manager) Start() {
-	m.wg.Add(2)
+	m.wg.Add(3)
 	go m.maintenanceWorker()
 	go m.matchmakingWorker()
+	go m.matchWorker()
 	log.Info("Arena manager started")
 }
 
@@ -146,6 +239,8 @@ func (m *Manager) SetCallbacks(
 	onTournamentEnd func(tournament *Tournament),
 	onSpectatorJoin func(matchID, spectatorID uuid.UUID),
 ) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.onMatchStart = onMatchStart
 	m.onMatchEnd = onMatchEnd
 	m.onTournamentStart = onTournamentStart
@@ -153,113 +248,65 @@ func (m *Manager) SetCallbacks(
 	m.onSpectatorJoin = onSpectatorJoin
 }
 
-// ============================================================================
-// DATA STRUCTURES
-// ============================================================================
-
-// Arena represents a PvP battleground
-type Arena struct {
-	ID          uuid.UUID
-	Name        string
-	Description string
-	MapType     string // "asteroid_field", "nebula", "space_station", "debris_field"
-	Size        string // "small", "medium", "large"
-	Features    []string // "cover", "hazards", "power_ups", "objectives"
-	Capacity    int    // Max players in match
-	Status      string // "available", "occupied", "maintenance"
+// SetCreditsChangedCallback sets the callback invoked after entry fees,
+// refunds, prizes or season rewards change a player's stored credits
+func (m *Manager) SetCreditsChangedCallback(callback func(playerID uuid.UUID, delta int64, reason string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onCreditsChanged = callback
 }
 
-// Match represents an active PvP match
-type Match struct {
-	ID            uuid.UUID
-	ArenaID       uuid.UUID
-	Type          MatchType
-	Players       []uuid.UUID
-	Teams         map[string][]uuid.UUID // "red" -> player IDs, "blue" -> player IDs
-	Scores        map[uuid.UUID]int      // player_id -> score
-	StartTime     time.Time
-	EndTime       time.Time
-	Status        string // "waiting", "in_progress", "completed", "cancelled"
-	Winner        uuid.UUID
-	MatchData     *MatchData
-	TournamentID  *uuid.UUID // If part of tournament
+// creditsChanged reports a saved balance change. Caller must hold m.mu.
+func (m *Manager) creditsChanged(playerID uuid.UUID, delta int64, reason string) {
+	if m.onCreditsChanged != nil {
+		go m.onCreditsChanged(playerID, delta, reason)
+	}
 }
 
-// MatchType defines the type of PvP match
-type MatchType string
+// ============================================================================
+// DATA STRUCTURES
+// ============================================================================
 
-const (
-	MatchTypeDuel          MatchType = "duel"           // 1v1
-	MatchTypeTeamDeathmatch MatchType = "team_deathmatch" // Team vs Team
-	MatchTypeFreeForAll    MatchType = "free_for_all"   // Everyone vs Everyone
-	MatchTypeCaptureFlag   MatchType = "capture_flag"   // CTF mode
-	MatchTypeKingOfHill    MatchType = "king_of_hill"   // Control point
-	MatchTypeElimination   MatchType = "elimination"    // Last standing wins
+// Persisted arena records live in models so the database package can store
+// them; they are aliased here for callers of this package.
+type (
+	Match             = models.ArenaMatch        // Arena match, live or finished
+	MatchData         = models.ArenaMatchData    // Match statistics
+	MatchType         = models.ArenaMatchType    // Type of arena match
+	Tournament        = models.Tournament        // Competitive tournament
+	TournamentType    = models.TournamentType    // Tournament format
+	TournamentBracket = models.TournamentBracket // Tournament structure
+	TournamentRound   = models.TournamentRound   // Round in the bracket
+	PlayerRanking     = models.ArenaRanking      // Player's competitive ranking
+	Season            = models.ArenaSeason       // Ranked season
 )
 
-// MatchData contains detailed match information
-type MatchData struct {
-	Kills         map[uuid.UUID]int
-	Deaths        map[uuid.UUID]int
-	Assists       map[uuid.UUID]int
-	DamageDealt   map[uuid.UUID]float64
-	DamageTaken   map[uuid.UUID]float64
-	Objectives    map[uuid.UUID]int // Flags captured, points controlled, etc.
-}
-
-// Tournament represents a competitive tournament
-type Tournament struct {
-	ID            uuid.UUID
-	Name          string
-	Type          TournamentType
-	EntryFee      int64
-	PrizePool     int64
-	MaxPlayers    int
-	Participants  []uuid.UUID
-	Bracket       *TournamentBracket
-	CurrentRound  int
-	StartTime     time.Time
-	EndTime       time.Time
-	Status        string // "registration", "in_progress", "completed"
-	Winners       []uuid.UUID // 1st, 2nd, 3rd place
-	BracketData   map[string]interface{} // Additional bracket metadata (e.g., bye advancers)
-}
-
-// TournamentType defines tournament format
-type TournamentType string
-
 const (
-	TournamentSingleElimination TournamentType = "single_elimination"
-	TournamentDoubleElimination TournamentType = "double_elimination"
-	TournamentRoundRobin        TournamentType = "round_robin"
-	TournamentSwiss             TournamentType = "swiss"
+	MatchTypeDuel           = models.MatchTypeDuel           // 1v1
+	MatchTypeTeamDeathmatch = models.MatchTypeTeamDeathmatch // Team vs Team
+	MatchTypeFreeForAll     = models.MatchTypeFreeForAll     // Everyone vs Everyone
+	MatchTypeCaptureFlag    = models.MatchTypeCaptureFlag    // CTF mode
+	MatchTypeKingOfHill     = models.MatchTypeKingOfHill     // Control point
+	MatchTypeElimination    = models.MatchTypeElimination    // Last standing wins
 )
 
-// TournamentBracket represents the tournament structure
-type TournamentBracket struct {
-	Rounds   []*TournamentRound
-	Matches  map[uuid.UUID]*Match
-}
-
-// TournamentRound represents a round in the bracket
-type TournamentRound struct {
-	RoundNumber int
-	Matches     []uuid.UUID // Match IDs
-	Status      string      // "pending", "in_progress", "completed"
-}
+const (
+	TournamentSingleElimination = models.TournamentSingleElimination
+	TournamentDoubleElimination = models.TournamentDoubleElimination
+	TournamentRoundRobin        = models.TournamentRoundRobin
+	TournamentSwiss             = models.TournamentSwiss
+)
 
-// PlayerRanking tracks a player's competitive ranking
-type PlayerRanking struct {
-	PlayerID      uuid.UUID
-	ELO           int
-	Tier          string
-	Division      int       // 1-5 within tier
-	Wins          int
-	Losses        int
-	WinStreak     int
-	HighestELO    int
-	TournamentsWon int
-	LastMatchTime time.Time
+// Arena represents a PvP battleground
+type Arena struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	MapType     string   // "asteroid_field", "nebula", "space_station", "debris_field"
+	Size        string   // "small", "medium", "large"
+	Features    []string // "cover", "hazards", "power_ups", "objectives"
+	Capacity    int      // Max players in match
+	Status      string   // "available", "occupied", "maintenance"
 }
 
 // QueueEntry represents a player in the matchmaking queue
@@ -274,33 +321,39 @@ type QueueEntry struct {
 // MATCHMAKING
 // ============================================================================
 
-// QueueForMatch adds a player to matchmaking queue
+// QueueForMatch adds a player to the matchmaking queue for a ranked match type
 func (m *Manager) QueueForMatch(ctx context.Context, playerID uuid.UUID, matchType MatchType) error {
+	if !m.isRankedType(matchType) {
+		return ErrUnsupportedMatchType
+	}
+
+	// The player must have a ship that can fight
+	player, err := m.players.GetByID(ctx, playerID)
+	if err != nil {
+		return fmt.Errorf("failed to get player: %w", err)
+	}
+	if _, _, err := m.loadShip(ctx, player); err != nil {
+		return err
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Check if player already in a match
-	for _, match := range m.matches {
-		if match.Status == "in_progress" || match.Status == "waiting" {
-			for _, pid := range match.Players {
-				if pid == playerID {
-					return fmt.Errorf("already in a match")
-				}
-			}
-		}
+	// Check if player already has a match pending or being fought
+	if m.activeMatch(playerID) != nil {
+		return ErrAlreadyInMatch
 	}
 
-	// Check if player already in queue
-	if queue, exists := m.matchQueue[matchType]; exists {
-		for _, entry := range queue {
-			if entry.PlayerID == playerID {
-				return fmt.Errorf("already in queue for %s", matchType)
-			}
-		}
+	// Check if player already in a queue
+	if entry := m.queueEntry(playerID); entry != nil {
+		return fmt.Errorf("%w for %s", ErrAlreadyQueued, entry.MatchType)
 	}
 
 	// Get player's ranking (or create default)
-	ranking := m.getOrCreateRanking(playerID)
+	ranking, err := m.getOrCreateRanking(playerID, player.Username)
+	if err != nil {
+		return err
+	}
 
 	// Add to queue
 	entry := &QueueEntry{
@@ -318,124 +371,253 @@ func (m *Manager) QueueForMatch(ctx context.Context, playerID uuid.UUID, matchTy
 	return nil
 }
 
-// CreateMatch creates a new PvP match
-func (m *Manager) CreateMatch(ctx context.Context, matchType MatchType, players []uuid.UUID) (*Match, error) {
+// LeaveQueue removes a player from the matchmaking queue
+func (m *Manager) LeaveQueue(playerID uuid.UUID, matchType MatchType) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Find available arena
-	var arena *Arena
-	for _, a := range m.arenas {
-		if a.Status == "available" {
-			arena = a
-			break
-		}
+	queue, exists := m.matchQueue[matchType]
+	if !exists {
+		return ErrNotQueued
 	}
 
-	if arena == nil {
-		return nil, fmt.Errorf("no available arenas")
+	// Find and remove player
+	newQueue := make([]*QueueEntry, 0, len(queue))
+	found := false
+	for _, entry := range queue {
+		if entry.PlayerID != playerID {
+			newQueue = append(newQueue, entry)
+		} else {
+			found = true
+		}
 	}
 
-	// Create match
-	match := &Match{
-		ID:        uuid.New(),
-		ArenaID:   arena.ID,
-		Type:      matchType,
-		Players:   players,
-		Teams:     make(map[string][]uuid.UUID),
-		Scores:    make(map[uuid.UUID]int),
-		StartTime: time.Now(),
-		Status:    "waiting",
-		MatchData: &MatchData{
-			Kills:       make(map[uuid.UUID]int),
-			Deaths:      make(map[uuid.UUID]int),
-			Assists:     make(map[uuid.UUID]int),
-			DamageDealt: make(map[uuid.UUID]float64),
-			DamageTaken: make(map[uuid.UUID]float64),
-			Objectives:  make(map[uuid.UUID]int),
-		},
+	if !found {
+		return ErrNotQueued
 	}
 
-	// Assign teams for team-based modes
-	if matchType == MatchTypeTeamDeathmatch || matchType == MatchTypeCaptureFlag {
-		mid := len(players) / 2
-		match.Teams["red"] = players[:mid]
-		match.Teams["blue"] = players[mid:]
-	}
+	m.matchQueue[matchType] = newQueue
+	log.Info("Player left queue: player=%s, type=%s", playerID, matchType)
 
-	m.matches[match.ID] = match
-	arena.Status = "occupied"
+	return nil
+}
 
-	log.Info("Match created: type=%s, players=%d, arena=%s", matchType, len(players), arena.Name)
+// GetQueueEntry returns a copy of the player's queue entry, or nil if they
+// are not queued
+func (m *Manager) GetQueueEntry(playerID uuid.UUID) *QueueEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 
-	if m.onMatchStart != nil {
-		go m.onMatchStart(match)
+	if entry := m.queueEntry(playerID); entry != nil {
+		entryCopy := *entry
+		return &entryCopy
 	}
+	return nil
+}
 
-	return match, nil
+// RankedMatchTypes returns the match types players can queue for
+func (m *Manager) RankedMatchTypes() []MatchType {
+	return append([]MatchType(nil), m.config.RankedMatchTypes...)
 }
 
-// StartMatch begins a match
-func (m *Manager) StartMatch(ctx context.Context, matchID uuid.UUID) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+// isRankedType reports whether players can queue for a match type
+func (m *Manager) isRankedType(matchType MatchType) bool {
+	for _, t := range m.config.RankedMatchTypes {
+		if t == matchType {
+			return true
+		}
+	}
+	return false
+}
 
-	match, exists := m.matches[matchID]
-	if !exists {
-		return fmt.Errorf("match not found")
+// queueEntry finds a player's queue entry. Caller must hold m.mu.
+func (m *Manager) queueEntry(playerID uuid.UUID) *QueueEntry {
+	for _, queue := range m.matchQueue {
+		for _, entry := range queue {
+			if entry.PlayerID == playerID {
+				return entry
+			}
+		}
 	}
+	return nil
+}
 
-	if match.Status != "waiting" {
-		return fmt.Errorf("match already started or completed")
+// activeMatch returns the player's waiting or in-progress match, or nil.
+// Caller must hold m.mu.
+func (m *Manager) activeMatch(playerID uuid.UUID) *Match {
+	for _, match := range m.matches {
+		if (match.Status == models.MatchInProgress || match.Status == models.MatchWaiting) && match.HasPlayer(playerID) {
+			return match
+		}
 	}
+	return nil
+}
 
-	match.Status = "in_progress"
-	match.StartTime = time.Now()
+// GetActiveMatch returns a copy of the player's waiting or in-progress
+// match, or nil if they have none
+func (m *Manager) GetActiveMatch(playerID uuid.UUID) *Match {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 
-	log.Info("Match started: match=%s, type=%s", matchID, match.Type)
+	if match := m.activeMatch(playerID); match != nil {
+		return copyMatch(match)
+	}
+	return nil
+}
 
-	// Start match timer
-	go m.matchTimerWorker(matchID)
+// CreateMatch creates a new PvP match. It waits for a free arena and is
+// then fought by the match worker.
+func (m *Manager) CreateMatch(ctx context.Context, matchType MatchType, players []uuid.UUID) (*Match, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	return nil
+	if len(players) < 2 {
+		return nil, fmt.Errorf("a match needs at least 2 players")
+	}
+	match, err := m.createMatchInternal(matchType, players, nil)
+	if err != nil {
+		return nil, err
+	}
+	return copyMatch(match), nil
 }
 
-// EndMatch ends a match and calculates results
+// StartMatch starts fighting a waiting match if an arena is free
+func (m *Manager) StartMatch(ctx context.Context, matchID uuid.UUID) error {
+	return m.launchMatch(ctx, matchID)
+}
+
+// EndMatch ends a match with the given winner (uuid.Nil for a draw) and
+// updates rankings. A waiting match can be ended as a forfeit.
 func (m *Manager) EndMatch(ctx context.Context, matchID uuid.UUID, winnerID uuid.UUID) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	match, exists := m.matches[matchID]
 	if !exists {
-		return fmt.Errorf("match not found")
+		return ErrMatchNotFound
+	}
+
+	if match.Status != models.MatchInProgress && match.Status != models.MatchWaiting {
+		return ErrMatchNotInProgress
 	}
 
-	if match.Status != "in_progress" {
-		return fmt.Errorf("match not in progress")
+	if winnerID != uuid.Nil && !match.HasPlayer(winnerID) {
+		return fmt.Errorf("winner is not in the match")
 	}
 
-	match.Status = "completed"
+	return m.endMatch(match, winnerID)
+}
+
+// endMatch completes a match: rankings, arena, spectators, the tournament
+// it belongs to and the store are all updated. The result stands even if a
+// write fails; the failures are returned together. Caller must hold m.mu.
+func (m *Manager) endMatch(match *Match, winnerID uuid.UUID) error {
+	match.Status = models.MatchCompleted
 	match.EndTime = time.Now()
 	match.Winner = winnerID
 
 	// Update rankings
-	m.updateRankingsAfterMatch(match)
+	rankingErr := m.updateRankingsAfterMatch(match)
 
 	// Free arena
 	if arena, exists := m.arenas[match.ArenaID]; exists {
 		arena.Status = "available"
 	}
+	delete(m.fights, match.ID)
+	delete(m.spectators, match.ID)
+	matchErr := m.saveMatch(match)
 
 	log.Info("Match ended: match=%s, winner=%s, duration=%v",
-		matchID, winnerID, match.EndTime.Sub(match.StartTime))
+		match.ID, winnerID, match.EndTime.Sub(match.StartTime))
+
+	var tournamentErr error
+	if match.TournamentID != nil {
+		if tournament, exists := m.tournaments[*match.TournamentID]; exists {
+			tournamentErr = m.advanceTournament(tournament)
+		}
+	}
 
 	if m.onMatchEnd != nil {
-		go m.onMatchEnd(match)
+		go m.onMatchEnd(copyMatch(match))
 	}
+	return errors.Join(rankingErr, matchErr, tournamentErr)
+}
 
+// cancelMatch abandons a match without a result. Caller must hold m.mu.
+func (m *Manager) cancelMatch(match *Match, reason string) error {
+	cancelled := *match
+	cancelled.Status = models.MatchCancelled
+	cancelled.EndTime = time.Now()
+	if err := m.saveMatch(&cancelled); err != nil {
+		return err
+	}
+	*match = cancelled
+	if arena, exists := m.arenas[match.ArenaID]; exists {
+		arena.Status = "available"
+	}
+	delete(m.fights, match.ID)
+	delete(m.spectators, match.ID)
+
+	log.Warn("Match cancelled: match=%s, reason=%s", match.ID, reason)
 	return nil
 }
 
+// GetMatch returns a copy of a match
+func (m *Manager) GetMatch(matchID uuid.UUID) (*Match, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	match, exists := m.matches[matchID]
+	if !exists {
+		return nil, ErrMatchNotFound
+	}
+	return copyMatch(match), nil
+}
+
+// GetMatchHistory returns the player's finished matches, newest first
+func (m *Manager) GetMatchHistory(playerID uuid.UUID, limit int) []*Match {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var history []*Match
+	for _, match := range m.matches {
+		if match.Status == models.MatchCompleted && match.HasPlayer(playerID) {
+			history = append(history, copyMatch(match))
+		}
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].EndTime.After(history[j].EndTime)
+	})
+
+	if limit > 0 && limit < len(history) {
+		return history[:limit]
+	}
+	return history
+}
+
+// copyMatch returns a copy of a match that is safe to read without the lock
+func copyMatch(match *Match) *Match {
+	c := *match
+	c.Players = append([]uuid.UUID(nil), match.Players...)
+	c.Teams = make(map[string][]uuid.UUID, len(match.Teams))
+	for team, members := range match.Teams {
+		c.Teams[team] = append([]uuid.UUID(nil), members...)
+	}
+	c.Scores = make(map[uuid.UUID]int, len(match.Scores))
+	for id, score := range match.Scores {
+		c.Scores[id] = score
+	}
+	c.RatingChanges = make(map[uuid.UUID]int, len(match.RatingChanges))
+	for id, change := range match.RatingChanges {
+		c.RatingChanges[id] = change
+	}
+	if match.MatchData != nil {
+		data := *match.MatchData
+		c.MatchData = &data
+	}
+	return &c
+}
+
 // ============================================================================
 // SPECTATOR SYSTEM
 // ============================================================================
@@ -448,11 +630,11 @@ func (m *Manager) JoinAsSpectator(ctx context.Context, matchID, spectatorID uuid
 	// Check match exists and is in progress
 	match, exists := m.matches[matchID]
 	if !exists {
-		return fmt.Errorf("match not found")
+		return ErrMatchNotFound
 	}
 
-	if match.Status != "in_progress" {
-		return fmt.Errorf("match not in progress")
+	if match.Status != models.MatchInProgress {
+		return ErrMatchNotInProgress
 	}
 
 	// Check spectator limit
@@ -509,17 +691,20 @@ func (m *Manager) GetSpectators(matchID uuid.UUID) []uuid.UUID {
 	return result
 }
 
-// GetActiveMatches retrieves all active matches for spectating
+// GetActiveMatches retrieves all matches being fought, oldest first
 func (m *Manager) GetActiveMatches() []*Match {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	var matches []*Match
 	for _, match := range m.matches {
-		if match.Status == "in_progress" {
-			matches = append(matches, match)
+		if match.Status == models.MatchInProgress {
+			matches = append(matches, copyMatch(match))
 		}
 	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].StartTime.Before(matches[j].StartTime)
+	})
 	return matches
 }
 
@@ -527,93 +712,119 @@ func (m *Manager) GetActiveMatches() []*Match {
 // TOURNAMENT SYSTEM
 // ============================================================================
 
-// CreateTournament creates a new tournament
-func (m *Manager) CreateTournament(ctx context.Context, name string, tournamentType TournamentType, maxPlayers int) (*Tournament, error) {
+// CreateTournament creates a new tournament that takes entries until
+// startTime
+func (m *Manager) CreateTournament(ctx context.Context, name string, tournamentType TournamentType, maxPlayers int, startTime time.Time) (*Tournament, error) {
+	if tournamentType != TournamentSingleElimination {
+		return nil, fmt.Errorf("only %s tournaments are supported", TournamentSingleElimination)
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	tournament, err := m.createTournament(name, tournamentType, maxPlayers, startTime)
+	if err != nil {
+		return nil, err
+	}
+	return copyTournament(tournament), nil
+}
+
+// createTournament creates and saves a tournament. Caller must hold m.mu.
+func (m *Manager) createTournament(name string, tournamentType TournamentType, maxPlayers int, startTime time.Time) (*Tournament, error) {
 	tournament := &Tournament{
 		ID:           uuid.New(),
 		Name:         name,
 		Type:         tournamentType,
+		MatchType:    MatchTypeDuel,
 		EntryFee:     m.config.TournamentEntryFee,
 		PrizePool:    0,
 		MaxPlayers:   maxPlayers,
 		Participants: []uuid.UUID{},
-		Status:       "registration",
+		StartTime:    startTime,
+		Status:       models.TournamentRegistration,
 	}
 
+	if err := m.saveTournament(tournament); err != nil {
+		return nil, err
+	}
 	m.tournaments[tournament.ID] = tournament
 
 	log.Info("Tournament created: name=%s, type=%s, max_players=%d", name, tournamentType, maxPlayers)
 	return tournament, nil
 }
 
-// RegisterForTournament adds a player to a tournament
+// RegisterForTournament adds a player to a tournament and charges the
+// entry fee
 func (m *Manager) RegisterForTournament(ctx context.Context, tournamentID, playerID uuid.UUID) error {
+	player, err := m.players.GetByID(ctx, playerID)
+	if err != nil {
+		return fmt.Errorf("failed to get player: %w", err)
+	}
+	if _, _, err := m.loadShip(ctx, player); err != nil {
+		return err
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	tournament, exists := m.tournaments[tournamentID]
 	if !exists {
-		return fmt.Errorf("tournament not found")
+		return ErrTournamentNotFound
 	}
 
-	if tournament.Status != "registration" {
-		return fmt.Errorf("registration closed")
+	if tournament.Status != models.TournamentRegistration {
+		return ErrRegistrationClosed
 	}
 
 	if len(tournament.Participants) >= tournament.MaxPlayers {
-		return fmt.Errorf("tournament full")
+		return ErrTournamentFull
 	}
 
 	// Check if already registered
-	for _, pid := range tournament.Participants {
-		if pid == playerID {
-			return fmt.Errorf("already registered")
-		}
+	if tournament.HasParticipant(playerID) {
+		return ErrAlreadyRegistered
 	}
 
-	// Check entry fee
-	player, err := m.playerRepo.GetByID(ctx, playerID)
-	if err != nil {
-		return fmt.Errorf("failed to get player: %v", err)
-	}
+	// Deduct entry fee
 	if player.Credits < tournament.EntryFee {
 		return fmt.Errorf("insufficient credits (need %d)", tournament.EntryFee)
 	}
-
-	// Deduct entry fee
-	player.Credits -= tournament.EntryFee
-	if err := m.playerRepo.Update(ctx, player); err != nil {
-		return fmt.Errorf("failed to deduct credits: %v", err)
+	if _, err := m.getOrCreateRanking(playerID, player.Username); err != nil {
+		return err
+	}
+	if err := m.players.ModifyCredits(ctx, playerID, -tournament.EntryFee); err != nil {
+		return fmt.Errorf("failed to deduct credits: %w", err)
 	}
 
-	// Add to tournament
-	tournament.Participants = append(tournament.Participants, playerID)
-	tournament.PrizePool += int64(float64(tournament.EntryFee) * m.config.TournamentPrizePool)
+	// Add to tournament; the fee is refunded if the entry cannot be saved
+	entered := *tournament
+	entered.Participants = append(append([]uuid.UUID(nil), tournament.Participants...), playerID)
+	entered.PrizePool += int64(float64(tournament.EntryFee) * m.config.TournamentPrizePool)
+	if err := m.saveTournament(&entered); err != nil {
+		if refundErr := m.players.ModifyCredits(ctx, playerID, tournament.EntryFee); refundErr != nil {
+			log.Error("Failed to refund %s entry fee to %s: %v", tournament.Name, playerID, refundErr)
+		}
+		return err
+	}
+	*tournament = entered
+	m.creditsChanged(playerID, -tournament.EntryFee, "entry to "+tournament.Name)
 
 	log.Info("Player registered for tournament: tournament=%s, player=%s", tournament.Name, playerID)
 
-	// Auto-start if minimum reached
-	if len(tournament.Participants) >= m.config.MinTournamentPlayers {
-		// Could auto-start or wait for more players
-	}
-
 	return nil
 }
 
-// StartTournament begins a tournament
+// StartTournament closes registration and plays the first round
 func (m *Manager) StartTournament(ctx context.Context, tournamentID uuid.UUID) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	tournament, exists := m.tournaments[tournamentID]
 	if !exists {
-		return fmt.Errorf("tournament not found")
+		return ErrTournamentNotFound
 	}
 
-	if tournament.Status != "registration" {
+	if tournament.Status != models.TournamentRegistration {
 		return fmt.Errorf("tournament already started or completed")
 	}
 
@@ -621,43 +832,62 @@ func (m *Manager) StartTournament(ctx context.Context, tournamentID uuid.UUID) e
 		return fmt.Errorf("not enough participants (need %d)", m.config.MinTournamentPlayers)
 	}
 
-	// Generate bracket
-	tournament.Bracket = m.generateBracket(tournament)
-	tournament.Status = "in_progress"
+	return m.startTournament(tournament)
+}
+
+// startTournament generates the bracket. Caller must hold m.mu.
+func (m *Manager) startTournament(tournament *Tournament) error {
+	bracket, err := m.generateBracket(tournament)
+	if err != nil {
+		return err
+	}
+	tournament.Bracket = bracket
+	tournament.Status = models.TournamentInProgress
 	tournament.StartTime = time.Now()
 	tournament.CurrentRound = 1
+	if err := m.saveTournament(tournament); err != nil {
+		return err
+	}
 
 	log.Info("Tournament started: name=%s, participants=%d", tournament.Name, len(tournament.Participants))
 
 	if m.onTournamentStart != nil {
-		go m.onTournamentStart(tournament)
+		go m.onTournamentStart(copyTournament(tournament))
 	}
-
 	return nil
 }
 
-// generateBracket creates a tournament bracket
-func (m *Manager) generateBracket(tournament *Tournament) *TournamentBracket {
-	bracket := &TournamentBracket{
-		Rounds:  []*TournamentRound{},
-		Matches: make(map[uuid.UUID]*Match),
+// cancelTournament calls off a tournament and refunds its entry fees once
+// the cancellation is saved. Caller must hold m.mu.
+func (m *Manager) cancelTournament(tournament *Tournament, reason string) error {
+	cancelled := *tournament
+	cancelled.Status = models.TournamentCancelled
+	cancelled.EndTime = time.Now()
+	if err := m.saveTournament(&cancelled); err != nil {
+		return err
 	}
+	*tournament = cancelled
 
-	// For single elimination, calculate number of rounds
-	numRounds := 0
-	players := len(tournament.Participants)
-	for players > 1 {
-		players /= 2
-		numRounds++
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	for _, playerID := range tournament.Participants {
+		if err := m.players.ModifyCredits(ctx, playerID, tournament.EntryFee); err != nil {
+			log.Error("Failed to refund %s entry fee to %s: %v", tournament.Name, playerID, err)
+			continue
+		}
+		m.creditsChanged(playerID, tournament.EntryFee, "refund for "+tournament.Name)
 	}
 
-	// Initialize rounds
-	for i := 0; i < numRounds; i++ {
-		bracket.Rounds = append(bracket.Rounds, &TournamentRound{
-			RoundNumber: i + 1,
-			Matches:     []uuid.UUID{},
-			Status:      "pending",
-		})
+	log.Info("Tournament cancelled: name=%s, reason=%s", tournament.Name, reason)
+	return nil
+}
+
+// generateBracket creates a single-elimination bracket and the first
+// round's matches. Caller must hold m.mu.
+func (m *Manager) generateBracket(tournament *Tournament) (*TournamentBracket, error) {
+	bracket := &TournamentBracket{
+		Rounds: []*TournamentRound{},
 	}
 
 	// Seed participants by ELO (highest to lowest)
@@ -668,156 +898,411 @@ func (m *Manager) generateBracket(tournament *Tournament) *TournamentBracket {
 	// Calculate if we need byes (when not a power of 2)
 	numParticipants := len(participants)
 	nextPowerOf2 := 1
+	numRounds := 0
 	for nextPowerOf2 < numParticipants {
 		nextPowerOf2 *= 2
+		numRounds++
 	}
 	numByes := nextPowerOf2 - numParticipants
 
-	// Create first round matches
+	// Initialize rounds
+	for i := 0; i < numRounds; i++ {
+		bracket.Rounds = append(bracket.Rounds, &TournamentRound{
+			RoundNumber: i + 1,
+			Matches:     []uuid.UUID{},
+			Status:      "pending",
+		})
+	}
+
+	// Top seeds get byes into the second round
+	tournament.ByeAdvancers = append([]uuid.UUID(nil), participants[:numByes]...)
+	for _, playerID := range tournament.ByeAdvancers {
+		log.Info("Tournament bye: player=%s", playerID)
+	}
+
 	firstRound := bracket.Rounds[0]
-	playerIndex := 0
-
-	// Handle byes first - top seeds get byes
-	byeAdvancers := make([]uuid.UUID, 0, numByes)
-	if numByes > 0 {
-		// Top seeds get byes
-		for i := 0; i < numByes; i++ {
-			byeAdvancers = append(byeAdvancers, participants[playerIndex])
-			log.Info("Tournament bye: player=%s", participants[playerIndex])
-			playerIndex++
+	firstRound.Status = "in_progress"
+	matches, err := m.createRoundMatches(tournament, participants[numByes:])
+	if err != nil {
+		return nil, err
+	}
+	firstRound.Matches = matches
+
+	log.Info("Bracket generated: tournament=%s, rounds=%d, first_round_matches=%d, byes=%d",
+		tournament.ID, numRounds, len(firstRound.Matches), numByes)
+
+	return bracket, nil
+}
+
+// createRoundMatches pairs the highest remaining seed with the lowest and
+// creates their matches. If one cannot be saved, those already created
+// for the round are dropped. Caller must hold m.mu.
+func (m *Manager) createRoundMatches(tournament *Tournament, players []uuid.UUID) ([]uuid.UUID, error) {
+	var matchIDs []uuid.UUID
+	for i := 0; i < len(players)/2; i++ {
+		player1 := players[i]
+		player2 := players[len(players)-1-i]
+
+		match, err := m.createMatchInternal(tournament.MatchType, []uuid.UUID{player1, player2}, &tournament.ID)
+		if err != nil {
+			for _, matchID := range matchIDs {
+				delete(m.matches, matchID)
+			}
+			return nil, err
 		}
+		matchIDs = append(matchIDs, match.ID)
+
+		log.Info("Tournament match created: match=%s, p1=%s, p2=%s", match.ID, player1, player2)
 	}
+	return matchIDs, nil
+}
 
-	// Create matches for remaining players
-	// Use bracket seeding: 1 vs lowest, 2 vs second-lowest, etc.
-	remainingPlayers := participants[playerIndex:]
-	numMatches := len(remainingPlayers) / 2
+// advanceTournament starts the next round once every match of the current
+// one is finished, and completes the tournament after the final. Caller
+// must hold m.mu.
+func (m *Manager) advanceTournament(tournament *Tournament) error {
+	if tournament.Status != models.TournamentInProgress || tournament.Bracket == nil {
+		return nil
+	}
+	round := tournament.Bracket.Rounds[tournament.CurrentRound-1]
 
-	for i := 0; i < numMatches; i++ {
-		// Pair highest seed with lowest remaining seed
-		player1 := remainingPlayers[i]
-		player2 := remainingPlayers[len(remainingPlayers)-1-i]
+	var advancers []uuid.UUID
+	if tournament.CurrentRound == 1 {
+		advancers = append(advancers, tournament.ByeAdvancers...)
+	}
+	for _, matchID := range round.Matches {
+		match, exists := m.matches[matchID]
+		if !exists || match.Status != models.MatchCompleted {
+			return nil
+		}
+		advancers = append(advancers, match.Winner)
+	}
 
-		// Create match
-		match, err := m.createMatchInternal(tournament.MatchType, []uuid.UUID{player1, player2})
+	if tournament.CurrentRound < len(tournament.Bracket.Rounds) {
+		matches, err := m.createRoundMatches(tournament, advancers)
 		if err != nil {
-			log.Error("Failed to create tournament match: %v", err)
-			continue
+			return err
+		}
+		round.Status = "completed"
+		tournament.CurrentRound++
+		next := tournament.Bracket.Rounds[tournament.CurrentRound-1]
+		next.Status = "in_progress"
+		next.Matches = matches
+		if err := m.saveTournament(tournament); err != nil {
+			return err
 		}
 
-		// Link match to tournament
-		match.TournamentID = &tournament.ID
-		bracket.Matches[match.ID] = match
-		firstRound.Matches = append(firstRound.Matches, match.ID)
+		log.Info("Tournament advanced: name=%s, round=%d, matches=%d",
+			tournament.Name, tournament.CurrentRound, len(next.Matches))
+		return nil
+	}
+
+	round.Status = "completed"
+	return m.completeTournament(tournament, round)
+}
 
-		log.Info("Tournament match created: round=1, match=%s, p1=%s, p2=%s",
-			match.ID, player1, player2)
+// completeTournament records the placings of a finished tournament and
+// pays its prizes once the result is saved. Caller must hold m.mu.
+func (m *Manager) completeTournament(tournament *Tournament, final *TournamentRound) error {
+	finalMatch := m.matches[final.Matches[0]]
+	champion := finalMatch.Winner
+	completed := *tournament
+	completed.Winners = []uuid.UUID{champion}
+	for _, playerID := range finalMatch.Players {
+		if playerID != champion {
+			completed.Winners = append(completed.Winners, playerID)
+		}
 	}
 
-	// Store bye advancers for next round (if second round exists)
-	if len(bracket.Rounds) > 1 && len(byeAdvancers) > 0 {
-		// We'll handle these when advancing to round 2
-		tournament.BracketData = map[string]interface{}{
-			"bye_advancers": byeAdvancers,
+	// Third place goes to the better-ranked losing semifinalist
+	if len(tournament.Bracket.Rounds) >= 2 {
+		semifinal := tournament.Bracket.Rounds[len(tournament.Bracket.Rounds)-2]
+		third := uuid.Nil
+		for _, matchID := range semifinal.Matches {
+			match := m.matches[matchID]
+			for _, playerID := range match.Players {
+				if playerID == match.Winner {
+					continue
+				}
+				if third == uuid.Nil || m.rankingELO(playerID) > m.rankingELO(third) {
+					third = playerID
+				}
+			}
+		}
+		if third != uuid.Nil {
+			completed.Winners = append(completed.Winners, third)
 		}
 	}
 
-	log.Info("Bracket generated: tournament=%s, rounds=%d, first_round_matches=%d, byes=%d",
-		tournament.ID, numRounds, len(firstRound.Matches), numByes)
+	completed.Status = models.TournamentCompleted
+	completed.EndTime = time.Now()
+	if err := m.saveTournament(&completed); err != nil {
+		return err
+	}
+	*tournament = completed
 
-	return bracket
+	var rankingErr error
+	if ranking, exists := m.rankings[champion]; exists {
+		ranking.TournamentsWon++
+		rankingErr = m.saveRanking(ranking)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	for place, playerID := range tournament.Winners {
+		if place >= len(m.config.TournamentPrizeShares) {
+			break
+		}
+		prize := int64(float64(tournament.PrizePool) * m.config.TournamentPrizeShares[place])
+		if prize <= 0 {
+			continue
+		}
+		if err := m.players.ModifyCredits(ctx, playerID, prize); err != nil {
+			log.Error("Failed to pay %s prize to %s: %v", tournament.Name, playerID, err)
+			continue
+		}
+		m.creditsChanged(playerID, prize, fmt.Sprintf("%s place %d", tournament.Name, place+1))
+	}
+
+	log.Info("Tournament completed: name=%s, champion=%s, prize_pool=%d",
+		tournament.Name, champion, tournament.PrizePool)
+
+	if m.onTournamentEnd != nil {
+		go m.onTournamentEnd(copyTournament(tournament))
+	}
+	return rankingErr
 }
 
 // seedParticipants sorts participants by ELO rating (highest first)
 func (m *Manager) seedParticipants(participants []uuid.UUID) {
-	// Simple bubble sort by ELO (good enough for tournament sizes)
-	for i := 0; i < len(participants); i++ {
-		for j := i + 1; j < len(participants); j++ {
-			rank1 := m.getOrCreateRanking(participants[i])
-			rank2 := m.getOrCreateRanking(participants[j])
-
-			// Higher ELO goes first
-			if rank2.ELO > rank1.ELO {
-				participants[i], participants[j] = participants[j], participants[i]
-			}
+	sort.SliceStable(participants, func(i, j int) bool {
+		return m.rankingELO(participants[i]) > m.rankingELO(participants[j])
+	})
+}
+
+// GetTournaments returns tournaments taking entries or being played,
+// followed by recently finished ones, soonest first
+func (m *Manager) GetTournaments() []*Tournament {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tournaments := make([]*Tournament, 0, len(m.tournaments))
+	for _, tournament := range m.tournaments {
+		tournaments = append(tournaments, copyTournament(tournament))
+	}
+	finished := func(t *Tournament) bool {
+		return t.Status == models.TournamentCompleted || t.Status == models.TournamentCancelled
+	}
+	sort.Slice(tournaments, func(i, j int) bool {
+		if finished(tournaments[i]) != finished(tournaments[j]) {
+			return !finished(tournaments[i])
+		}
+		if finished(tournaments[i]) {
+			return tournaments[i].EndTime.After(tournaments[j].EndTime)
 		}
+		return tournaments[i].StartTime.Before(tournaments[j].StartTime)
+	})
+	return tournaments
+}
+
+// GetTournament returns a copy of a tournament
+func (m *Manager) GetTournament(tournamentID uuid.UUID) (*Tournament, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tournament, exists := m.tournaments[tournamentID]
+	if !exists {
+		return nil, ErrTournamentNotFound
 	}
+	return copyTournament(tournament), nil
+}
+
+// copyTournament returns a copy of a tournament that is safe to read
+// without the lock
+func copyTournament(tournament *Tournament) *Tournament {
+	c := *tournament
+	c.Participants = append([]uuid.UUID(nil), tournament.Participants...)
+	c.Winners = append([]uuid.UUID(nil), tournament.Winners...)
+	c.ByeAdvancers = append([]uuid.UUID(nil), tournament.ByeAdvancers...)
+	if tournament.Bracket != nil {
+		bracket := &TournamentBracket{}
+		for _, round := range tournament.Bracket.Rounds {
+			roundCopy := *round
+			roundCopy.Matches = append([]uuid.UUID(nil), round.Matches...)
+			bracket.Rounds = append(bracket.Rounds, &roundCopy)
+		}
+		c.Bracket = bracket
+	}
+	return &c
 }
 
 // ============================================================================
 // RANKING SYSTEM
 // ============================================================================
 
-// updateRankingsAfterMatch updates player rankings based on match results
-func (m *Manager) updateRankingsAfterMatch(match *Match) {
-	// Simple ELO update for now
+// updateRankingsAfterMatch moves each player's ELO towards the result
+// using the expected score against the average rating of the other side.
+// Caller must hold m.mu.
+func (m *Manager) updateRankingsAfterMatch(match *Match) error {
+	match.RatingChanges = make(map[uuid.UUID]int, len(match.Players))
+
+	// Average rating of each side before the match
+	sideELO := func(playerID uuid.UUID, own bool) float64 {
+		team := match.Team(playerID)
+		total, count := 0, 0
+		for _, other := range match.Players {
+			sameSide := other == playerID || (team != "" && match.Team(other) == team)
+			if sameSide == own {
+				total += m.rankingELO(other)
+				count++
+			}
+		}
+		if count == 0 {
+			return float64(m.config.StartingELO)
+		}
+		return float64(total) / float64(count)
+	}
+
+	changes := make(map[uuid.UUID]int, len(match.Players))
+	for _, playerID := range match.Players {
+		expected := 1 / (1 + math.Pow(10, (sideELO(playerID, false)-sideELO(playerID, true))/400))
+		score := 0.0
+		switch {
+		case match.Winner == uuid.Nil:
+			score = 0.5
+		case match.Won(playerID):
+			score = 1
+		}
+		changes[playerID] = int(math.Round(float64(m.config.ELOKFactor) * (score - expected)))
+	}
+
+	var errs []error
 	for _, playerID := range match.Players {
-		ranking := m.getOrCreateRanking(playerID)
+		ranking, err := m.getOrCreateRanking(playerID, "")
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		change := changes[playerID]
 
-		if playerID == match.Winner {
+		switch {
+		case match.Winner == uuid.Nil:
+			ranking.Draws++
+			ranking.WinStreak = 0
+		case match.Won(playerID):
 			ranking.Wins++
 			ranking.WinStreak++
-			ranking.ELO += m.config.ELOKFactor + (ranking.WinStreak * m.config.WinStreakBonus / 10)
-		} else {
+			// Streaks of three or more earn a growing bonus
+			if ranking.WinStreak >= 3 {
+				bonus := ranking.WinStreak - 2
+				if bonus > 5 {
+					bonus = 5
+				}
+				change += bonus * m.config.WinStreakBonus / 10
+			}
+		default:
 			ranking.Losses++
 			ranking.WinStreak = 0
-			ranking.ELO -= m.config.ELOKFactor
 		}
 
+		ranking.ELO += change
+		match.RatingChanges[playerID] = change
+
 		// Update highest ELO
 		if ranking.ELO > ranking.HighestELO {
 			ranking.HighestELO = ranking.ELO
 		}
 
 		// Update tier
-		ranking.Tier = m.calculateTier(ranking.ELO)
+		ranking.Tier, ranking.Division = m.calculateTier(ranking.ELO)
+		ranking.SeasonMatches++
 		ranking.LastMatchTime = time.Now()
+		if err := m.saveRanking(ranking); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	return errors.Join(errs...)
 }
 
-// getOrCreateRanking gets or creates a player ranking
-func (m *Manager) getOrCreateRanking(playerID uuid.UUID) *PlayerRanking {
-	key := playerID.String()
-	if ranking, exists := m.rankings[key]; exists {
-		return ranking
+// getOrCreateRanking gets or creates a player ranking. A non-empty name
+// refreshes the stored player name. A new ranking is only kept once it is
+// saved. Caller must hold m.mu.
+func (m *Manager) getOrCreateRanking(playerID uuid.UUID, name string) (*PlayerRanking, error) {
+	if ranking, exists := m.rankings[playerID]; exists {
+		if name != "" && ranking.PlayerName != name {
+			renamed := *ranking
+			renamed.PlayerName = name
+			if err := m.saveRanking(&renamed); err != nil {
+				return nil, err
+			}
+			*ranking = renamed
+		}
+		return ranking, nil
 	}
 
 	ranking := &PlayerRanking{
 		PlayerID:   playerID,
+		PlayerName: name,
 		ELO:        m.config.StartingELO,
-		Tier:       m.config.RankTiers[0],
-		Division:   5,
 		HighestELO: m.config.StartingELO,
 	}
-	m.rankings[key] = ranking
-	return ranking
+	ranking.Tier, ranking.Division = m.calculateTier(ranking.ELO)
+	if err := m.saveRanking(ranking); err != nil {
+		return nil, err
+	}
+	m.rankings[playerID] = ranking
+	return ranking, nil
 }
 
-// calculateTier determines rank tier based on ELO
-func (m *Manager) calculateTier(elo int) string {
-	// Simple tier calculation
-	if elo < 800 {
-		return m.config.RankTiers[0] // Bronze
-	} else if elo < 1100 {
-		return m.config.RankTiers[1] // Silver
-	} else if elo < 1400 {
-		return m.config.RankTiers[2] // Gold
-	} else if elo < 1700 {
-		return m.config.RankTiers[3] // Platinum
-	} else if elo < 2000 {
-		return m.config.RankTiers[4] // Diamond
-	} else if elo < 2300 {
-		return m.config.RankTiers[5] // Master
+// rankingELO returns a player's rating without creating a ranking. Caller
+// must hold m.mu.
+func (m *Manager) rankingELO(playerID uuid.UUID) int {
+	if ranking, exists := m.rankings[playerID]; exists {
+		return ranking.ELO
 	}
-	return m.config.RankTiers[6] // Grandmaster
+	return m.config.StartingELO
 }
 
-// GetPlayerRanking retrieves a player's ranking
+// calculateTier determines rank tier and division (5 lowest, 1 highest)
+// from ELO. Each tier spans 300 ELO; Grandmaster has a single division.
+func (m *Manager) calculateTier(elo int) (string, int) {
+	tier := 0
+	for tier < len(m.config.TierFloors) && elo >= m.config.TierFloors[tier] {
+		tier++
+	}
+	if tier == len(m.config.RankTiers)-1 {
+		return m.config.RankTiers[tier], 1
+	}
+
+	// Position within the tier's 300-point band
+	ceiling := m.config.TierFloors[tier]
+	division := 1 + (ceiling-1-elo)*5/300
+	if division < 1 {
+		division = 1
+	}
+	if division > 5 {
+		division = 5
+	}
+	return m.config.RankTiers[tier], division
+}
+
+// GetPlayerRanking returns a copy of a player's ranking, or a fresh
+// ranking if they have never played
 func (m *Manager) GetPlayerRanking(playerID uuid.UUID) *PlayerRanking {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	return m.getOrCreateRanking(playerID)
+	if ranking, exists := m.rankings[playerID]; exists {
+		rankingCopy := *ranking
+		return &rankingCopy
+	}
+	ranking := &PlayerRanking{
+		PlayerID:   playerID,
+		ELO:        m.config.StartingELO,
+		HighestELO: m.config.StartingELO,
+	}
+	ranking.Tier, ranking.Division = m.calculateTier(ranking.ELO)
+	return ranking
 }
 
 // GetLeaderboard retrieves top ranked players
@@ -825,20 +1310,27 @@ func (m *Manager) GetLeaderboard(limit int) []*PlayerRanking {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	// Collect all rankings
+	return m.leaderboard(limit)
+}
+
+// leaderboard returns copies of the top rankings by ELO, ignoring players
+// who have not played. Caller must hold m.mu.
+func (m *Manager) leaderboard(limit int) []*PlayerRanking {
 	rankings := make([]*PlayerRanking, 0, len(m.rankings))
 	for _, ranking := range m.rankings {
-		rankings = append(rankings, ranking)
+		if ranking.Wins+ranking.Losses+ranking.Draws == 0 {
+			continue
+		}
+		rankingCopy := *ranking
+		rankings = append(rankings, &rankingCopy)
 	}
 
-	// Sort by ELO (simple bubble sort for now)
-	for i := 0; i < len(rankings); i++ {
-		for j := i + 1; j < len(rankings); j++ {
-			if rankings[j].ELO > rankings[i].ELO {
-				rankings[i], rankings[j] = rankings[j], rankings[i]
-			}
+	sort.Slice(rankings, func(i, j int) bool {
+		if rankings[i].ELO != rankings[j].ELO {
+			return rankings[i].ELO > rankings[j].ELO
 		}
-	}
+		return rankings[i].PlayerName < rankings[j].PlayerName
+	})
 
 	// Return top N
 	if limit > 0 && limit < len(rankings) {
@@ -868,10 +1360,19 @@ func (m *Manager) matchmakingWorker() {
 	}
 }
 
-// processMatchmakingQueue attempts to create matches from queued players
+// processMatchmakingQueue creates matches from queued players and starts
+// waiting matches in free arenas
 func (m *Manager) processMatchmakingQueue() {
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	m.matchQueuedPlayers()
+	m.mu.Unlock()
+
+	m.startWaitingMatches()
+}
+
+// matchQueuedPlayers creates matches from queued players of similar
+// rating. Caller must hold m.mu.
+func (m *Manager) matchQueuedPlayers() {
 
 	// Process each match type queue
 	for matchType, queue := range m.matchQueue {
@@ -902,6 +1403,25 @@ func (m *Manager) processMatchmakingQueue() {
 				break
 			}
 
+			// Balance teams: strongest and weakest against the middle
+			sort.SliceStable(matched, func(i, j int) bool { return matched[i].ELO > matched[j].ELO })
+			playerIDs := make([]uuid.UUID, 0, len(matched))
+			for i := 0; i < len(matched); i += 2 {
+				playerIDs = append(playerIDs, matched[i].PlayerID)
+			}
+			for i := len(matched) - 1 - len(matched)%2; i > 0; i -= 2 {
+				playerIDs = append(playerIDs, matched[i].PlayerID)
+			}
+
+			// Players stay queued for the next pass if the match cannot be saved
+			match, err := m.createMatchInternal(matchType, playerIDs, nil)
+			if err != nil {
+				log.Error("Failed to create match from queue: %v", err)
+				break
+			}
+			log.Info("Match created from queue: match=%s, type=%s, players=%d",
+				match.ID, matchType, len(playerIDs))
+
 			// Remove matched players from queue
 			remainingQueue := make([]*QueueEntry, 0, len(queue))
 			matchedIDs := make(map[uuid.UUID]bool)
@@ -914,22 +1434,6 @@ func (m *Manager) processMatchmakingQueue() {
 				}
 			}
 			queue = remainingQueue
-
-			// Create match
-			playerIDs := make([]uuid.UUID, len(matched))
-			for i, entry := range matched {
-				playerIDs[i] = entry.PlayerID
-			}
-
-			match, err := m.createMatchInternal(matchType, playerIDs)
-			if err != nil {
-				log.Error("Failed to create match from queue: %v", err)
-				// Re-add players to queue
-				queue = append(queue, matched...)
-			} else {
-				log.Info("Match created from queue: match=%s, type=%s, players=%d",
-					match.ID, matchType, len(playerIDs))
-			}
 		}
 
 		// Update queue
@@ -992,96 +1496,84 @@ func (m *Manager) findBestMatch(queue []*QueueEntry, count int) []*QueueEntry {
 	return matched[:count]
 }
 
-// createMatchInternal creates a match without locking (caller must hold lock)
-func (m *Manager) createMatchInternal(matchType MatchType, players []uuid.UUID) (*Match, error) {
-	// Find available arena
-	var arena *Arena
-	for _, a := range m.arenas {
-		if a.Status == "available" {
-			arena = a
-			break
-		}
-	}
-
-	if arena == nil {
-		return nil, fmt.Errorf("no available arenas")
-	}
-
-	// Create match
+// createMatchInternal creates a match waiting for an arena. Players are
+// split into a red and a blue side: the first half and the second half.
+// The match is saved before it is kept. Caller must hold m.mu.
+func (m *Manager) createMatchInternal(matchType MatchType, players []uuid.UUID, tournamentID *uuid.UUID) (*Match, error) {
+	half := len(players) / 2
 	match := &Match{
 		ID:        uuid.New(),
-		ArenaID:   arena.ID,
 		Type:      matchType,
-		Players:   players,
-		Teams:     make(map[string][]uuid.UUID),
+		Players:   append([]uuid.UUID(nil), players...),
+		Teams:     map[string][]uuid.UUID{"red": append([]uuid.UUID(nil), players[:half]...), "blue": append([]uuid.UUID(nil), players[half:]...)},
 		Scores:    make(map[uuid.UUID]int),
 		StartTime: time.Now(),
-		Status:    "waiting",
-		MatchData: &MatchData{
-			Kills:   make(map[uuid.UUID]int),
-			Deaths:  make(map[uuid.UUID]int),
-			Assists: make(map[uuid.UUID]int),
-			Damage:  make(map[uuid.UUID]int),
-		},
+		Status:    models.MatchWaiting,
+		MatchData: models.NewArenaMatchData(),
+		Season:    m.season.Number,
 	}
-
-	// Assign teams for team-based modes
-	if matchType == MatchTypeTeamDeathmatch || matchType == MatchTypeCaptureFlag {
-		half := len(players) / 2
-		match.Teams["red"] = players[:half]
-		match.Teams["blue"] = players[half:]
+	if tournamentID != nil {
+		id := *tournamentID
+		match.TournamentID = &id
 	}
 
-	// Mark arena as occupied
-	arena.Status = "occupied"
-
-	// Store match
-	m.matches[match.ID] = match
-
-	// Trigger callback
-	if m.onMatchStart != nil {
-		go m.onMatchStart(match)
+	if err := m.saveMatch(match); err != nil {
+		return nil, err
 	}
-
+	m.matches[match.ID] = match
 	return match, nil
 }
 
-// LeaveQueue removes a player from the matchmaking queue
-func (m *Manager) LeaveQueue(playerID uuid.UUID, matchType MatchType) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	queue, exists := m.matchQueue[matchType]
-	if !exists {
-		return fmt.Errorf("not in queue")
+// availableArena returns a free arena, or nil. Caller must hold m.mu.
+func (m *Manager) availableArena() *Arena {
+	arenas := make([]*Arena, 0, len(m.arenas))
+	for _, arena := range m.arenas {
+		if arena.Status == "available" {
+			arenas = append(arenas, arena)
+		}
 	}
+	if len(arenas) == 0 {
+		return nil
+	}
+	sort.Slice(arenas, func(i, j int) bool { return arenas[i].Name < arenas[j].Name })
+	return arenas[0]
+}
 
-	// Find and remove player
-	newQueue := make([]*QueueEntry, 0, len(queue))
-	found := false
-	for _, entry := range queue {
-		if entry.PlayerID != playerID {
-			newQueue = append(newQueue, entry)
-		} else {
-			found = true
+// startWaitingMatches starts waiting matches, oldest first, in the free
+// arenas. Caller must not hold m.mu; each match's players and ships are
+// read outside it.
+func (m *Manager) startWaitingMatches() {
+	m.mu.RLock()
+	var waiting []*Match
+	for _, match := range m.matches {
+		if match.Status == models.MatchWaiting {
+			waiting = append(waiting, copyMatch(match))
 		}
 	}
-
-	if !found {
-		return fmt.Errorf("not in queue")
+	m.mu.RUnlock()
+	sort.Slice(waiting, func(i, j int) bool {
+		return waiting[i].StartTime.Before(waiting[j].StartTime)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	for _, match := range waiting {
+		err := m.launchMatch(ctx, match.ID)
+		switch {
+		case errors.Is(err, ErrNoArena):
+			return
+		case err != nil && !errors.Is(err, ErrMatchStarted) && !errors.Is(err, ErrMatchNotFound):
+			log.Error("Failed to start match %s: %v", match.ID, err)
+		}
 	}
-
-	m.matchQueue[matchType] = newQueue
-	log.Info("Player left queue: player=%s, type=%s", playerID, matchType)
-
-	return nil
 }
 
-// maintenanceWorker handles daily maintenance
+// maintenanceWorker runs the season and tournament schedules and daily
+// ranking decay
 func (m *Manager) maintenanceWorker() {
 	defer m.wg.Done()
 
-	ticker := time.NewTicker(24 * time.Hour)
+	ticker := time.NewTicker(time.Minute)
 	defer ticker.Stop()
 
 	for {
@@ -1094,56 +1586,83 @@ func (m *Manager) maintenanceWorker() {
 	}
 }
 
-// processMaintenance processes daily arena maintenance
+// processMaintenance ends the season when it is due, starts or cancels
+// scheduled tournaments, keeps one tournament open for entries, decays
+// inactive rankings once a day and forgets old history
 func (m *Manager) processMaintenance() {
+	m.checkSeason()
+
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	m.runSchedules()
+	m.mu.Unlock()
 
-	// Ranking decay for inactive players
-	for _, ranking := range m.rankings {
-		if time.Since(ranking.LastMatchTime) > 7*24*time.Hour {
-			decay := int(float64(ranking.ELO) * m.config.RankingDecayRate)
-			ranking.ELO -= decay
-			if ranking.ELO < m.config.StartingELO/2 {
-				ranking.ELO = m.config.StartingELO / 2
+	m.startWaitingMatches()
+}
+
+// runSchedules starts or cancels scheduled tournaments, keeps one
+// tournament open for entries, forgets old history and decays inactive
+// rankings once a day. Caller must hold m.mu.
+func (m *Manager) runSchedules() {
+
+	now := time.Now()
+	open := false
+	for id, tournament := range m.tournaments {
+		switch tournament.Status {
+		case models.TournamentRegistration:
+			if now.Before(tournament.StartTime) {
+				open = true
+				continue
+			}
+			var err error
+			if len(tournament.Participants) >= m.config.MinTournamentPlayers {
+				err = m.startTournament(tournament)
+			} else {
+				err = m.cancelTournament(tournament, "not enough participants")
+			}
+			if err != nil {
+				log.Error("Failed to close registration for %s: %v", tournament.Name, err)
+			}
+		case models.TournamentCompleted, models.TournamentCancelled:
+			if now.Sub(tournament.EndTime) > m.config.HistoryRetention {
+				delete(m.tournaments, id)
 			}
 		}
 	}
-}
-
-// matchTimerWorker handles match timeout
-func (m *Manager) matchTimerWorker(matchID uuid.UUID) {
-	time.Sleep(m.config.MatchDuration)
-
-	m.mu.Lock()
-	match, exists := m.matches[matchID]
-	if !exists || match.Status != "in_progress" {
-		m.mu.Unlock()
-		return
+	if !open {
+		if _, err := m.createTournament(fmt.Sprintf("Season %d Cup %s", m.season.Number, now.Format("Jan 2 15:04")),
+			TournamentSingleElimination, m.config.MaxTournamentPlayers, now.Add(m.config.TournamentRegistration)); err != nil {
+			log.Error("Failed to open a tournament: %v", err)
+		}
 	}
 
-	// Determine winner by score
-	var winnerID uuid.UUID
-	maxScore := -1
-	for playerID, score := range match.Scores {
-		if score > maxScore {
-			maxScore = score
-			winnerID = playerID
+	for id, match := range m.matches {
+		if (match.Status == models.MatchCompleted || match.Status == models.MatchCancelled) &&
+			now.Sub(match.EndTime) > m.config.HistoryRetention {
+			delete(m.matches, id)
 		}
 	}
 
-	match.Status = "completed"
-	match.EndTime = time.Now()
-	match.Winner = winnerID
-
-	// Free arena
-	if arena, exists := m.arenas[match.ArenaID]; exists {
-		arena.Status = "available"
+	// Ranking decay for inactive players
+	if now.Sub(m.lastDecay) < 24*time.Hour {
+		return
+	}
+	m.lastDecay = now
+	for _, ranking := range m.rankings {
+		if ranking.LastMatchTime.IsZero() || now.Sub(ranking.LastMatchTime) <= 7*24*time.Hour {
+			continue
+		}
+		decayed := *ranking
+		decayed.ELO -= int(float64(ranking.ELO) * m.config.RankingDecayRate)
+		if decayed.ELO < m.config.StartingELO/2 {
+			decayed.ELO = m.config.StartingELO / 2
+		}
+		decayed.Tier, decayed.Division = m.calculateTier(decayed.ELO)
+		if err := m.saveRanking(&decayed); err != nil {
+			log.Error("Ranking decay: %v", err)
+			continue
+		}
+		*ranking = decayed
 	}
-
-	m.mu.Unlock()
-
-	log.Info("Match timed out: match=%s, winner=%s", matchID, winnerID)
 }
 
 // ============================================================================
@@ -1166,7 +1685,10 @@ func (m *Manager) initializeArenas() {
 		{"Crimson Arena", "Open space combat arena", "open_space", "medium", []string{"power_ups"}},
 	}
 
-	for _, template := range arenaTemplates {
+	for i, template := range arenaTemplates {
+		if i >= m.config.ArenaCount {
+			break
+		}
 		arena := &Arena{
 			ID:          uuid.New(),
 			Name:        template.name,
@@ -1189,9 +1711,9 @@ func (m *Manager) GetStats() ArenaStats {
 	defer m.mu.RUnlock()
 
 	stats := ArenaStats{
-		AvailableArenas: 0,
-		ActiveMatches:   0,
-		TotalSpectators: 0,
+		AvailableArenas:   0,
+		ActiveMatches:     0,
+		TotalSpectators:   0,
 		ActiveTournaments: 0,
 	}
 
@@ -1202,7 +1724,7 @@ func (m *Manager) GetStats() ArenaStats {
 	}
 
 	for _, match := range m.matches {
-		if match.Status == "in_progress" {
+		if match.Status == models.MatchInProgress {
 			stats.ActiveMatches++
 		}
 	}
@@ -1212,7 +1734,7 @@ func (m *Manager) GetStats() ArenaStats {
 	}
 
 	for _, tournament := range m.tournaments {
-		if tournament.Status == "in_progress" || tournament.Status == "registration" {
+		if tournament.Status == models.TournamentInProgress || tournament.Status == models.TournamentRegistration {
 			stats.ActiveTournaments++
 		}
 	}
@@ -0,0 +1,451 @@
+// File: internal/arena/manager_test.go
+// Project: Terminal Velocity
+// Description: Tests for the arena - fought matches, ELO, seasons, tournaments and persistence
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package arena
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// memoryPlayers is an in-memory Players for tests
+type memoryPlayers struct {
+	players map[uuid.UUID]*models.Player
+	onGet   func() // run before every read
+}
+
+func (p *memoryPlayers) GetByID(ctx context.Context, id uuid.UUID) (*models.Player, error) {
+	if p.onGet != nil {
+		p.onGet()
+	}
+	player, exists := p.players[id]
+	if !exists {
+		return nil, fmt.Errorf("player not found")
+	}
+	copied := *player
+	return &copied, nil
+}
+
+func (p *memoryPlayers) ModifyCredits(ctx context.Context, id uuid.UUID, amount int64) error {
+	player := p.players[id]
+	if player.Credits+amount < 0 {
+		return fmt.Errorf("insufficient credits")
+	}
+	player.Credits += amount
+	return nil
+}
+
+// memoryShips is an in-memory Ships for tests
+type memoryShips struct {
+	ships map[uuid.UUID]*models.Ship
+}
+
+func (s *memoryShips) GetByID(ctx context.Context, id uuid.UUID) (*models.Ship, error) {
+	ship, exists := s.ships[id]
+	if !exists {
+		return nil, fmt.Errorf("ship not found")
+	}
+	copied := *ship
+	return &copied, nil
+}
+
+// memoryStore is an in-memory Store for tests
+type memoryStore struct {
+	rankings    map[uuid.UUID]models.ArenaRanking
+	matches     map[uuid.UUID]models.ArenaMatch
+	tournaments map[uuid.UUID]models.Tournament
+	season      *models.ArenaSeason
+	failWith    error // returned by every write while set
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		rankings:    make(map[uuid.UUID]models.ArenaRanking),
+		matches:     make(map[uuid.UUID]models.ArenaMatch),
+		tournaments: make(map[uuid.UUID]models.Tournament),
+	}
+}
+
+func (s *memoryStore) SaveRanking(ctx context.Context, ranking *models.ArenaRanking) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	s.rankings[ranking.PlayerID] = *ranking
+	return nil
+}
+
+func (s *memoryStore) ListRankings(ctx context.Context) ([]*models.ArenaRanking, error) {
+	var rankings []*models.ArenaRanking
+	for _, ranking := range s.rankings {
+		r := ranking
+		rankings = append(rankings, &r)
+	}
+	return rankings, nil
+}
+
+func (s *memoryStore) SaveMatch(ctx context.Context, match *models.ArenaMatch) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	s.matches[match.ID] = *copyMatch(match)
+	return nil
+}
+
+func (s *memoryStore) ListMatches(ctx context.Context, since time.Time) ([]*models.ArenaMatch, error) {
+	var matches []*models.ArenaMatch
+	for _, match := range s.matches {
+		if match.EndTime.IsZero() || match.EndTime.After(since) {
+			matches = append(matches, copyMatch(&match))
+		}
+	}
+	return matches, nil
+}
+
+func (s *memoryStore) SaveTournament(ctx context.Context, tournament *models.Tournament) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	s.tournaments[tournament.ID] = *copyTournament(tournament)
+	return nil
+}
+
+func (s *memoryStore) ListTournaments(ctx context.Context, since time.Time) ([]*models.Tournament, error) {
+	var tournaments []*models.Tournament
+	for _, tournament := range s.tournaments {
+		if tournament.EndTime.IsZero() || tournament.EndTime.After(since) {
+			tournaments = append(tournaments, copyTournament(&tournament))
+		}
+	}
+	return tournaments, nil
+}
+
+func (s *memoryStore) SaveSeason(ctx context.Context, season *models.ArenaSeason) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	saved := *season
+	s.season = &saved
+	return nil
+}
+
+func (s *memoryStore) GetCurrentSeason(ctx context.Context) (*models.ArenaSeason, error) {
+	if s.season == nil {
+		return nil, nil
+	}
+	season := *s.season
+	return &season, nil
+}
+
+// memoryMailer records system mail for tests
+type memoryMailer struct {
+	sent map[uuid.UUID][]string // receiver -> subjects
+}
+
+func (m *memoryMailer) SendSystemMail(ctx context.Context, receiverID uuid.UUID, subject, body string) error {
+	m.sent[receiverID] = append(m.sent[receiverID], subject)
+	return nil
+}
+
+// testArena is a manager with in-memory players, ships, store and mail
+type testArena struct {
+	*Manager
+	players *memoryPlayers
+	ships   *memoryShips
+	store   *memoryStore
+	mailer  *memoryMailer
+}
+
+func newTestArena() *testArena {
+	ta := &testArena{
+		players: &memoryPlayers{players: make(map[uuid.UUID]*models.Player)},
+		ships:   &memoryShips{ships: make(map[uuid.UUID]*models.Ship)},
+		store:   newMemoryStore(),
+		mailer:  &memoryMailer{sent: make(map[uuid.UUID][]string)},
+	}
+	ta.Manager = NewManager(ta.players, ta.ships)
+	ta.SetStore(ta.store)
+	ta.SetMailer(ta.mailer)
+	return ta
+}
+
+// addPilot creates a player flying a ship of the given type and weapons
+func (ta *testArena) addPilot(name, shipType string, weapons ...string) uuid.UUID {
+	ship := &models.Ship{ID: uuid.New(), TypeID: shipType, Name: name + "'s ship", Weapons: weapons}
+	player := &models.Player{ID: uuid.New(), Username: name, Credits: 50000, ShipID: ship.ID}
+	ship.OwnerID = player.ID
+	ta.ships.ships[ship.ID] = ship
+	ta.players.players[player.ID] = player
+	return player.ID
+}
+
+func TestDuelIsFoughtAndRated(t *testing.T) {
+	ta := newTestArena()
+	ctx := context.Background()
+	strong := ta.addPilot("ace", "viper", "heavy_laser", "heavy_laser", "heavy_laser", "heavy_laser")
+	weak := ta.addPilot("rookie", "shuttle", "pulse_laser")
+
+	if err := ta.QueueForMatch(ctx, strong, MatchTypeDuel); err != nil {
+		t.Fatalf("QueueForMatch failed: %v", err)
+	}
+	if err := ta.QueueForMatch(ctx, strong, MatchTypeDuel); !errors.Is(err, ErrAlreadyQueued) {
+		t.Fatalf("second QueueForMatch error = %v, want ErrAlreadyQueued", err)
+	}
+	if err := ta.QueueForMatch(ctx, weak, MatchTypeDuel); err != nil {
+		t.Fatalf("QueueForMatch failed: %v", err)
+	}
+
+	// Fighters are loaded without holding the manager's lock
+	ta.players.onGet = func() { ta.GetActiveMatch(strong) }
+	ta.processMatchmakingQueue()
+	ta.players.onGet = nil
+	match := ta.GetActiveMatch(strong)
+	if match == nil || match.Status != models.MatchInProgress {
+		t.Fatalf("expected a duel in progress, got %+v", match)
+	}
+	if view, err := ta.GetMatchView(match.ID); err != nil || len(view.Fighters) != 2 {
+		t.Fatalf("GetMatchView = %+v, %v; want two fighters", view, err)
+	}
+
+	for turn := 0; turn <= ta.config.MaxMatchTurns && ta.GetActiveMatch(strong) != nil; turn++ {
+		ta.advanceFights()
+	}
+
+	match, err := ta.GetMatch(match.ID)
+	if err != nil {
+		t.Fatalf("GetMatch failed: %v", err)
+	}
+	if match.Status != models.MatchCompleted || match.Winner != strong {
+		t.Fatalf("match status=%s winner=%s, want completed won by %s", match.Status, match.Winner, strong)
+	}
+	if match.Scores[strong] <= match.Scores[weak] {
+		t.Errorf("winner scored %d, loser %d", match.Scores[strong], match.Scores[weak])
+	}
+
+	// Equal ratings: the winner gains half the K-factor and the loser drops as much
+	winner, loser := ta.GetPlayerRanking(strong), ta.GetPlayerRanking(weak)
+	if winner.ELO != 1016 || loser.ELO != 984 {
+		t.Errorf("ELO after duel = %d/%d, want 1016/984", winner.ELO, loser.ELO)
+	}
+	if winner.Wins != 1 || loser.Losses != 1 || match.RatingChanges[strong] != 16 {
+		t.Errorf("record = %+v / %+v, changes %v", winner, loser, match.RatingChanges)
+	}
+	if saved := ta.store.matches[match.ID]; saved.Status != models.MatchCompleted {
+		t.Errorf("stored match status = %s, want completed", saved.Status)
+	}
+	if saved := ta.store.rankings[strong]; saved.ELO != 1016 {
+		t.Errorf("stored ranking ELO = %d, want 1016", saved.ELO)
+	}
+	if history := ta.GetMatchHistory(weak, 10); len(history) != 1 || history[0].ID != match.ID {
+		t.Errorf("history = %v, want the duel", history)
+	}
+}
+
+func TestQueueRequiresArmedShip(t *testing.T) {
+	ta := newTestArena()
+	unarmed := ta.addPilot("trader", "shuttle")
+
+	if err := ta.QueueForMatch(context.Background(), unarmed, MatchTypeDuel); !errors.Is(err, ErrNoShip) {
+		t.Fatalf("QueueForMatch error = %v, want ErrNoShip", err)
+	}
+	armed := ta.addPilot("pilot", "shuttle", "pulse_laser")
+	if err := ta.QueueForMatch(context.Background(), armed, MatchTypeCaptureFlag); !errors.Is(err, ErrUnsupportedMatchType) {
+		t.Fatalf("QueueForMatch error = %v, want ErrUnsupportedMatchType", err)
+	}
+}
+
+func TestSeasonEndRewardsAndSoftResets(t *testing.T) {
+	ta := newTestArena()
+	veteran := ta.addPilot("veteran", "viper", "pulse_laser")
+	idle := ta.addPilot("idle", "viper", "pulse_laser")
+
+	ta.mu.Lock()
+	ranking, err := ta.getOrCreateRanking(veteran, "veteran")
+	if err != nil {
+		t.Fatalf("getOrCreateRanking failed: %v", err)
+	}
+	ranking.ELO, ranking.Wins, ranking.SeasonMatches, ranking.WinStreak = 1500, 10, 12, 4
+	ranking.Tier, ranking.Division = ta.calculateTier(ranking.ELO)
+	if _, err := ta.getOrCreateRanking(idle, "idle"); err != nil {
+		t.Fatalf("getOrCreateRanking failed: %v", err)
+	}
+	ta.season.EndTime = time.Now().Add(-time.Minute)
+	ta.mu.Unlock()
+
+	if ranking.Tier != "Platinum" || ranking.Division != 4 {
+		t.Fatalf("1500 ELO rank = %s %d, want Platinum 4", ranking.Tier, ranking.Division)
+	}
+
+	ta.checkSeason()
+
+	if got := ta.players.players[veteran].Credits; got != 50000+50000 {
+		t.Errorf("veteran credits = %d, want Platinum reward paid", got)
+	}
+	if got := ta.players.players[idle].Credits; got != 50000 {
+		t.Errorf("idle credits = %d, want unchanged", got)
+	}
+	if len(ta.mailer.sent[veteran]) != 1 || len(ta.mailer.sent[idle]) != 0 {
+		t.Errorf("mail sent = %v, want one summary to the veteran", ta.mailer.sent)
+	}
+
+	reset := ta.GetPlayerRanking(veteran)
+	if reset.ELO != 1250 || reset.Tier != "Gold" || reset.SeasonMatches != 0 || reset.WinStreak != 0 || reset.Wins != 10 {
+		t.Errorf("ranking after reset = %+v, want ELO 1250 with record kept", reset)
+	}
+	if season := ta.GetCurrentSeason(); season.Number != 2 || ta.store.season.Number != 2 {
+		t.Errorf("season = %d (stored %d), want 2", season.Number, ta.store.season.Number)
+	}
+}
+
+func TestTournamentBracketAndPrizes(t *testing.T) {
+	ta := newTestArena()
+	ctx := context.Background()
+
+	tournament, err := ta.CreateTournament(ctx, "Test Cup", TournamentSingleElimination, 8, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("CreateTournament failed: %v", err)
+	}
+
+	// Five entrants seeded by ELO: three byes, then a full bracket of four
+	seeds := make([]uuid.UUID, 5)
+	for i := range seeds {
+		seeds[i] = ta.addPilot(fmt.Sprintf("seed%d", i+1), "viper", "pulse_laser")
+		if err := ta.RegisterForTournament(ctx, tournament.ID, seeds[i]); err != nil {
+			t.Fatalf("RegisterForTournament failed: %v", err)
+		}
+		ta.mu.Lock()
+		ta.rankings[seeds[i]].ELO = 1500 - 100*i
+		ta.mu.Unlock()
+	}
+	if err := ta.RegisterForTournament(ctx, tournament.ID, seeds[0]); !errors.Is(err, ErrAlreadyRegistered) {
+		t.Fatalf("second registration error = %v, want ErrAlreadyRegistered", err)
+	}
+	if got := ta.players.players[seeds[0]].Credits; got != 40000 {
+		t.Fatalf("credits after entry = %d, want fee deducted", got)
+	}
+
+	if err := ta.StartTournament(ctx, tournament.ID); err != nil {
+		t.Fatalf("StartTournament failed: %v", err)
+	}
+
+	// Play every match in favour of the higher seed
+	for round := 0; round < 5; round++ {
+		current, _ := ta.GetTournament(tournament.ID)
+		if current.Status == models.TournamentCompleted {
+			break
+		}
+		for _, matchID := range current.Bracket.Rounds[current.CurrentRound-1].Matches {
+			match, _ := ta.GetMatch(matchID)
+			if match.Status == models.MatchWaiting {
+				if err := ta.EndMatch(ctx, matchID, match.Players[0]); err != nil {
+					t.Fatalf("EndMatch failed: %v", err)
+				}
+			}
+		}
+	}
+
+	final, _ := ta.GetTournament(tournament.ID)
+	if final.Status != models.TournamentCompleted || len(final.Bracket.Rounds) != 3 {
+		t.Fatalf("tournament status=%s rounds=%d, want completed after 3 rounds", final.Status, len(final.Bracket.Rounds))
+	}
+	want := []uuid.UUID{seeds[0], seeds[1], seeds[2]}
+	for place, playerID := range want {
+		if place >= len(final.Winners) || final.Winners[place] != playerID {
+			t.Fatalf("winners = %v, want seeds 1, 2, 3", final.Winners)
+		}
+	}
+
+	// 5 x 10,000 fee x 90% = 45,000 pool split 60/30/10
+	for place, prize := range []int64{27000, 13500, 4500} {
+		if got := ta.players.players[want[place]].Credits; got != 40000+prize {
+			t.Errorf("place %d credits = %d, want %d", place+1, got, 40000+prize)
+		}
+	}
+	if ranking := ta.GetPlayerRanking(seeds[0]); ranking.TournamentsWon != 1 {
+		t.Errorf("champion tournaments won = %d, want 1", ranking.TournamentsWon)
+	}
+}
+
+func TestFailedSavesLeaveArenaUnchanged(t *testing.T) {
+	ta := newTestArena()
+	ctx := context.Background()
+	pilot := ta.addPilot("pilot", "viper", "pulse_laser")
+
+	tournament, err := ta.CreateTournament(ctx, "Test Cup", TournamentSingleElimination, 8, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("CreateTournament failed: %v", err)
+	}
+
+	ta.store.failWith = errors.New("database unavailable")
+	if err := ta.RegisterForTournament(ctx, tournament.ID, pilot); err == nil {
+		t.Fatal("RegisterForTournament succeeded with a failing store")
+	}
+	if _, err := ta.CreateTournament(ctx, "Lost Cup", TournamentSingleElimination, 8, time.Now().Add(time.Hour)); err == nil {
+		t.Fatal("CreateTournament succeeded with a failing store")
+	}
+
+	if got := ta.players.players[pilot].Credits; got != 50000 {
+		t.Errorf("credits after failed entry = %d, want 50000", got)
+	}
+	if current, _ := ta.GetTournament(tournament.ID); len(current.Participants) != 0 || current.PrizePool != 0 {
+		t.Errorf("tournament after failed entry has %d entrants and a %d pool", len(current.Participants), current.PrizePool)
+	}
+	if got := len(ta.GetTournaments()); got != 1 {
+		t.Errorf("tournaments = %d, want only the saved one", got)
+	}
+}
+
+func TestLoadRestoresArena(t *testing.T) {
+	ta := newTestArena()
+	red := ta.addPilot("red", "viper", "pulse_laser")
+	blue := ta.addPilot("blue", "viper", "pulse_laser")
+
+	ta.store.rankings[red] = models.ArenaRanking{PlayerID: red, PlayerName: "red", ELO: 1234, Wins: 3}
+	live := models.ArenaMatch{
+		ID:        uuid.New(),
+		ArenaID:   uuid.New(),
+		Type:      MatchTypeDuel,
+		Players:   []uuid.UUID{red, blue},
+		Teams:     map[string][]uuid.UUID{"red": {red}, "blue": {blue}},
+		Scores:    map[uuid.UUID]int{red: 40},
+		StartTime: time.Now().Add(-time.Minute),
+		Status:    models.MatchInProgress,
+		MatchData: models.NewArenaMatchData(),
+		Season:    4,
+	}
+	ta.store.matches[live.ID] = live
+	ta.store.season = &models.ArenaSeason{Number: 4, StartTime: time.Now(), EndTime: time.Now().Add(time.Hour)}
+
+	if err := ta.Load(context.Background()); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if ranking := ta.GetPlayerRanking(red); ranking.ELO != 1234 || ranking.Wins != 3 {
+		t.Errorf("loaded ranking = %+v", ranking)
+	}
+	if season := ta.GetCurrentSeason(); season.Number != 4 {
+		t.Errorf("season = %d, want 4", season.Number)
+	}
+
+	// The interrupted match is refought from the start
+	match, err := ta.GetMatch(live.ID)
+	if err != nil {
+		t.Fatalf("GetMatch failed: %v", err)
+	}
+	if match.Status != models.MatchWaiting || match.ArenaID != uuid.Nil || len(match.Scores) != 0 {
+		t.Errorf("loaded match = %+v, want waiting with scores cleared", match)
+	}
+	ta.processMatchmakingQueue()
+	if match, _ := ta.GetMatch(live.ID); match.Status != models.MatchInProgress {
+		t.Errorf("match status after matchmaking = %s, want in_progress", match.Status)
+	}
+}
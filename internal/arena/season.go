@@ -0,0 +1,151 @@
+// File: internal/arena/season.go
+// Project: Terminal Velocity
+// Description: Ranked arena seasons - soft resets and end-of-season rewards
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package arena
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// seasonReward is a player's end-of-season payout and summary mail
+type seasonReward struct {
+	playerID uuid.UUID
+	credits  int64
+	subject  string
+	body     string
+}
+
+// newSeason creates a season of the configured length
+func (m *Manager) newSeason(number int, start time.Time) *Season {
+	return &Season{
+		Number:    number,
+		StartTime: start,
+		EndTime:   start.Add(m.config.SeasonLength),
+	}
+}
+
+// GetCurrentSeason returns a copy of the current ranked season
+func (m *Manager) GetCurrentSeason() Season {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return *m.season
+}
+
+// checkSeason ends the current season once it is over. Every player who
+// played in it is paid the reward for their final tier and mailed a
+// summary, then rankings are softly reset towards the starting ELO and a
+// new season begins.
+func (m *Manager) checkSeason() {
+	m.mu.Lock()
+	if time.Now().Before(m.season.EndTime) {
+		m.mu.Unlock()
+		return
+	}
+	rewards, err := m.endSeason()
+	mailer := m.mailer
+	m.mu.Unlock()
+	if err != nil {
+		log.Error("Failed to end arena season: %v", err)
+	}
+
+	// Pay and notify outside the lock; these go to the database
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout*time.Duration(len(rewards)+1))
+	defer cancel()
+
+	for _, reward := range rewards {
+		if reward.credits > 0 {
+			if err := m.players.ModifyCredits(ctx, reward.playerID, reward.credits); err != nil {
+				log.Error("Failed to pay season reward to %s: %v", reward.playerID, err)
+			} else {
+				m.mu.RLock()
+				m.creditsChanged(reward.playerID, reward.credits, reward.subject)
+				m.mu.RUnlock()
+			}
+		}
+		if mailer != nil {
+			if err := mailer.SendSystemMail(ctx, reward.playerID, reward.subject, reward.body); err != nil {
+				log.Error("Failed to mail season results to %s: %v", reward.playerID, err)
+			}
+		}
+	}
+}
+
+// endSeason closes the current season, resets rankings and returns the
+// rewards owed. The next season is saved first: until it is, the season is
+// not over and nothing is owed, so a restart cannot pay the rewards twice.
+// Ranking and match writes that fail after that are returned with the
+// rewards. Caller must hold m.mu.
+func (m *Manager) endSeason() ([]seasonReward, error) {
+	ended := m.season
+	next := m.newSeason(ended.Number+1, time.Now())
+	if err := m.saveSeason(next); err != nil {
+		return nil, err
+	}
+
+	standings := m.leaderboard(0)
+	placement := make(map[uuid.UUID]int, len(standings))
+	for i, ranking := range standings {
+		placement[ranking.PlayerID] = i + 1
+	}
+
+	var rewards []seasonReward
+	var errs []error
+	for _, ranking := range m.rankings {
+		if ranking.SeasonMatches > 0 {
+			credits := m.config.SeasonRewards[ranking.Tier]
+			subject := fmt.Sprintf("Arena Season %d results", ended.Number)
+
+			var body strings.Builder
+			fmt.Fprintf(&body, "Arena Season %d has ended.\n\n", ended.Number)
+			fmt.Fprintf(&body, "Final rank: %s %d (%d ELO)\n", ranking.Tier, ranking.Division, ranking.ELO)
+			fmt.Fprintf(&body, "Leaderboard placement: #%d of %d\n", placement[ranking.PlayerID], len(standings))
+			fmt.Fprintf(&body, "Season matches: %d\n", ranking.SeasonMatches)
+			if credits > 0 {
+				fmt.Fprintf(&body, "\nReward: %d credits have been added to your account.\n", credits)
+			}
+
+			rewards = append(rewards, seasonReward{
+				playerID: ranking.PlayerID,
+				credits:  credits,
+				subject:  subject,
+				body:     body.String(),
+			})
+		}
+
+		// Soft reset: keep part of the distance from the starting rating
+		start := m.config.StartingELO
+		ranking.ELO = start + int(float64(ranking.ELO-start)*m.config.SeasonSoftReset)
+		ranking.Tier, ranking.Division = m.calculateTier(ranking.ELO)
+		ranking.WinStreak = 0
+		ranking.SeasonMatches = 0
+		if err := m.saveRanking(ranking); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Matches not yet fought count towards the new season
+	m.season = next
+	for _, match := range m.matches {
+		if match.Status == models.MatchWaiting && match.TournamentID == nil {
+			match.Season = m.season.Number
+			if err := m.saveMatch(match); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	log.Info("Arena season %d ended: rewarded=%d, next season ends %s",
+		ended.Number, len(rewards), m.season.EndTime.Format(time.RFC3339))
+	return rewards, errors.Join(errs...)
+}
@@ -0,0 +1,194 @@
+// File: internal/arena/store.go
+// Project: Terminal Velocity
+// Description: Persistence, player account and mail interfaces for the arena
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package arena
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// dbTimeout bounds each write to the arena store
+const dbTimeout = 5 * time.Second
+
+// Store persists rankings, match history, tournaments and seasons so they
+// survive restarts. The database package's ArenaRepository implements it.
+type Store interface {
+	SaveRanking(ctx context.Context, ranking *models.ArenaRanking) error
+	ListRankings(ctx context.Context) ([]*models.ArenaRanking, error)
+
+	SaveMatch(ctx context.Context, match *models.ArenaMatch) error
+	// ListMatches returns unfinished matches and those that ended after since
+	ListMatches(ctx context.Context, since time.Time) ([]*models.ArenaMatch, error)
+
+	SaveTournament(ctx context.Context, tournament *models.Tournament) error
+	// ListTournaments returns unfinished tournaments and those that ended after since
+	ListTournaments(ctx context.Context, since time.Time) ([]*models.Tournament, error)
+
+	SaveSeason(ctx context.Context, season *models.ArenaSeason) error
+	// GetCurrentSeason returns the latest season, or nil if none was saved
+	GetCurrentSeason(ctx context.Context) (*models.ArenaSeason, error)
+}
+
+// Players reads players and moves credits for entry fees, prizes and
+// season rewards. The database package's PlayerRepository implements it.
+type Players interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Player, error)
+
+	// ModifyCredits adds amount (negative to deduct) unless the balance
+	// would go below zero
+	ModifyCredits(ctx context.Context, id uuid.UUID, amount int64) error
+}
+
+// Ships loads the ships players fight with. The database package's
+// ShipRepository implements it.
+type Ships interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Ship, error)
+}
+
+// Mailer sends season results. mail.Manager implements it.
+type Mailer interface {
+	SendSystemMail(ctx context.Context, receiverID uuid.UUID, subject, body string) error
+}
+
+// SetStore sets where arena state is persisted. Without a store the
+// manager keeps everything in memory.
+func (m *Manager) SetStore(store Store) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store = store
+}
+
+// SetMailer sets how season rewards are announced
+func (m *Manager) SetMailer(mailer Mailer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mailer = mailer
+}
+
+// Load replaces the in-memory rankings, recent matches, tournaments and
+// season with the store's. Matches that were being fought when the server
+// stopped go back to waiting and are refought from the start.
+func (m *Manager) Load(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.store == nil {
+		return nil
+	}
+
+	since := time.Now().Add(-m.config.HistoryRetention)
+	rankings, err := m.store.ListRankings(ctx)
+	if err != nil {
+		return err
+	}
+	matches, err := m.store.ListMatches(ctx, since)
+	if err != nil {
+		return err
+	}
+	tournaments, err := m.store.ListTournaments(ctx, since)
+	if err != nil {
+		return err
+	}
+	season, err := m.store.GetCurrentSeason(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.rankings = make(map[uuid.UUID]*PlayerRanking, len(rankings))
+	for _, ranking := range rankings {
+		m.rankings[ranking.PlayerID] = ranking
+	}
+
+	m.matches = make(map[uuid.UUID]*Match, len(matches))
+	m.fights = make(map[uuid.UUID]*fight)
+	for _, match := range matches {
+		if match.MatchData == nil {
+			match.MatchData = models.NewArenaMatchData()
+		}
+		if match.Scores == nil {
+			match.Scores = make(map[uuid.UUID]int)
+		}
+		if match.Status == models.MatchInProgress {
+			match.Status = models.MatchWaiting
+			match.ArenaID = uuid.Nil
+			match.Scores = make(map[uuid.UUID]int)
+			match.MatchData = models.NewArenaMatchData()
+			if err := m.saveMatch(match); err != nil {
+				return err
+			}
+		}
+		m.matches[match.ID] = match
+	}
+	for _, arena := range m.arenas {
+		arena.Status = "available"
+	}
+
+	m.tournaments = make(map[uuid.UUID]*Tournament, len(tournaments))
+	for _, tournament := range tournaments {
+		m.tournaments[tournament.ID] = tournament
+	}
+
+	if season != nil {
+		m.season = season
+	} else if err := m.saveSeason(m.season); err != nil {
+		return err
+	}
+
+	log.Info("Loaded arena: rankings=%d, matches=%d, tournaments=%d, season=%d",
+		len(m.rankings), len(m.matches), len(m.tournaments), m.season.Number)
+	return nil
+}
+
+// persist runs a store write with a timeout and returns its error. Money is
+// only paid or refunded once the record accounting for it is saved; other
+// failures go back to the caller, and since records are saved whole the
+// next save of the same record catches the store up. Caller must hold m.mu.
+func (m *Manager) persist(what string, write func(ctx context.Context, store Store) error) error {
+	if m.store == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	if err := write(ctx, m.store); err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+	return nil
+}
+
+// saveRanking writes a ranking to the store. Caller must hold m.mu.
+func (m *Manager) saveRanking(ranking *PlayerRanking) error {
+	return m.persist("ranking of "+ranking.PlayerID.String(), func(ctx context.Context, store Store) error {
+		return store.SaveRanking(ctx, ranking)
+	})
+}
+
+// saveMatch writes a match to the store. Caller must hold m.mu.
+func (m *Manager) saveMatch(match *Match) error {
+	return m.persist("match "+match.ID.String(), func(ctx context.Context, store Store) error {
+		return store.SaveMatch(ctx, match)
+	})
+}
+
+// saveTournament writes a tournament to the store. Caller must hold m.mu.
+func (m *Manager) saveTournament(tournament *Tournament) error {
+	return m.persist("tournament "+tournament.Name, func(ctx context.Context, store Store) error {
+		return store.SaveTournament(ctx, tournament)
+	})
+}
+
+// saveSeason writes a season to the store. Caller must hold m.mu.
+func (m *Manager) saveSeason(season *Season) error {
+	return m.persist("arena season", func(ctx context.Context, store Store) error {
+		return store.SaveSeason(ctx, season)
+	})
+}
@@ -1,7 +1,7 @@
 // File: internal/capture/manager.go
 // Project: Terminal Velocity
 // Description: Ship capture and boarding system manager
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Claude Code
 // Created: 2025-11-15
 
@@ -9,12 +9,12 @@ package capture
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"sync"
 	"time"
 
-	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	"github.com/google/uuid"
@@ -22,11 +22,21 @@ import (
 
 var log = logger.WithComponent("Capture")
 
+// Capture errors
+var (
+	ErrNotDisabled     = errors.New("target is not disabled")
+	ErrNoBoardingParty = errors.New("no crew to form a boarding party")
+	ErrPvPNotAllowed   = errors.New("boarding another player's ship is not allowed here")
+)
+
 // Manager handles ship capture and boarding operations
 type Manager struct {
 	mu         sync.RWMutex
-	shipRepo   *database.ShipRepository
-	playerRepo *database.PlayerRepository
+	shipRepo   Ships
+	playerRepo Players
+
+	// pvpPolicy decides whether one player may board another's ship
+	pvpPolicy func(attackerID, defenderID uuid.UUID) error
 
 	// Active boarding attempts
 	activeBoardings map[uuid.UUID]*BoardingAttempt
@@ -103,8 +113,9 @@ type BoardingOutcome struct {
 	Message        string
 }
 
-// NewManager creates a new capture manager
-func NewManager(shipRepo *database.ShipRepository, playerRepo *database.PlayerRepository) *Manager {
+// NewManager creates a new capture manager. Either repository may be nil,
+// in which case captured ships and capture statistics are not saved.
+func NewManager(shipRepo Ships, playerRepo Players) *Manager {
 	return &Manager{
 		shipRepo:        shipRepo,
 		playerRepo:      playerRepo,
@@ -113,6 +124,35 @@ func NewManager(shipRepo *database.ShipRepository, playerRepo *database.PlayerRe
 	}
 }
 
+// SetPvPPolicy sets the PvP rules for boarding another player's ship: the
+// policy returns nil if attackerID may board defenderID's ship. Without a
+// policy, player ships cannot be boarded.
+func (m *Manager) SetPvPPolicy(policy func(attackerID, defenderID uuid.UUID) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pvpPolicy = policy
+}
+
+// checkPvP applies the PvP rules when the defender's ship belongs to a
+// player. NPC ships (no owner) can always be boarded.
+func (m *Manager) checkPvP(attackerShip, defenderShip *models.Ship) error {
+	if defenderShip.OwnerID == uuid.Nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	policy := m.pvpPolicy
+	m.mu.RUnlock()
+
+	if policy == nil {
+		return ErrPvPNotAllowed
+	}
+	if err := policy(attackerShip.OwnerID, defenderShip.OwnerID); err != nil {
+		return fmt.Errorf("%w: %v", ErrPvPNotAllowed, err)
+	}
+	return nil
+}
+
 // CanDisable checks if a ship can be disabled (pre-boarding check)
 func (m *Manager) CanDisable(target *models.Ship) (bool, string) {
 	// Get ship type for max values
@@ -145,6 +185,9 @@ func (m *Manager) AttemptBoarding(ctx context.Context, attackerShip, defenderShi
 	if !canDisable {
 		return nil, fmt.Errorf("cannot board: %s", reason)
 	}
+	if err := m.checkPvP(attackerShip, defenderShip); err != nil {
+		return nil, err
+	}
 
 	// Check for existing boarding attempt
 	m.mu.RLock()
@@ -169,14 +212,7 @@ func (m *Manager) AttemptBoarding(ctx context.Context, attackerShip, defenderShi
 	m.mu.Unlock()
 
 	// Update player stats for boarding attempt
-	if m.playerRepo != nil {
-		if player, err := m.playerRepo.GetByID(ctx, attackerShip.OwnerID); err == nil {
-			player.RecordCaptureAttempt()
-			if err := m.playerRepo.Update(ctx, player); err != nil {
-				log.Error("Failed to update player stats for boarding attempt: %v", err)
-			}
-		}
-	}
+	m.recordStats(ctx, attackerShip.OwnerID, 1, 0, 0)
 
 	log.Info("Boarding initiated: attacker=%s, defender=%s, crew=%d vs %d",
 		attackerShip.ID, defenderShip.ID, attackerCrew, defenderCrew)
@@ -202,14 +238,7 @@ func (m *Manager) resolveBoardingAfterDelay(ctx context.Context, attempt *Boardi
 			attempt.AttackerID, outcome.AttackerLosses, attackerCrew)
 
 		// Update player stats for successful boarding
-		if m.playerRepo != nil {
-			if player, err := m.playerRepo.GetByID(ctx, attempt.AttackerID); err == nil {
-				player.RecordSuccessfulBoard()
-				if err := m.playerRepo.Update(ctx, player); err != nil {
-					log.Error("Failed to update player stats for successful boarding: %v", err)
-				}
-			}
-		}
+		m.recordStats(ctx, attempt.AttackerID, 0, 1, 0)
 
 		// Attempt to capture ship
 		if outcome.CaptureSuccess {
@@ -329,6 +358,9 @@ func (m *Manager) captureShip(ctx context.Context, attempt *BoardingAttempt) err
 	}
 
 	// Update ship in database
+	if m.shipRepo == nil {
+		return nil
+	}
 	err := m.shipRepo.Update(ctx, attempt.DefenderShip)
 	if err != nil {
 		log.Error("Failed to capture ship: %v", err)
@@ -336,14 +368,7 @@ func (m *Manager) captureShip(ctx context.Context, attempt *BoardingAttempt) err
 	}
 
 	// Update player stats for successful capture
-	if m.playerRepo != nil {
-		if player, err := m.playerRepo.GetByID(ctx, attempt.AttackerID); err == nil {
-			player.RecordSuccessfulCapture()
-			if err := m.playerRepo.Update(ctx, player); err != nil {
-				log.Error("Failed to update player stats for successful capture: %v", err)
-			}
-		}
-	}
+	m.recordStats(ctx, attempt.AttackerID, 0, 0, 1)
 
 	log.Info("Ship captured: ship=%s, new_owner=%s",
 		attempt.DefenderShip.ID, attempt.AttackerID)
@@ -351,6 +376,100 @@ func (m *Manager) captureShip(ctx context.Context, attempt *BoardingAttempt) err
 	return nil
 }
 
+// Board resolves a boarding action fought in battle at once, rather than
+// after BoardingDuration as AttemptBoarding does: the boarding roll,
+// casualties on both sides and, if the boarders get aboard, the capture
+// roll. Boarding parties are drawn from the ships' crews.
+//
+// The attacker's capture statistics are recorded. A captured ship is not
+// handed over; call ClaimShip for that.
+//
+// Returns ErrNotDisabled if the target's hull and shields are too strong,
+// ErrNoBoardingParty if the attacker has no crew, and ErrPvPNotAllowed if
+// the target is another player's ship and the PvP rules forbid it.
+func (m *Manager) Board(ctx context.Context, attackerShip, defenderShip *models.Ship) (*BoardingOutcome, error) {
+	if canDisable, reason := m.CanDisable(defenderShip); !canDisable {
+		return nil, fmt.Errorf("%w: %s", ErrNotDisabled, reason)
+	}
+	if attackerShip.Crew < 1 {
+		return nil, ErrNoBoardingParty
+	}
+	if err := m.checkPvP(attackerShip, defenderShip); err != nil {
+		return nil, err
+	}
+
+	attempt := &BoardingAttempt{
+		AttackerID:   attackerShip.OwnerID,
+		DefenderID:   defenderShip.OwnerID,
+		AttackerShip: attackerShip,
+		DefenderShip: defenderShip,
+		StartTime:    time.Now(),
+	}
+	outcome := m.resolveBoarding(attempt, attackerShip.Crew, defenderShip.Crew)
+
+	boards, captures := 0, 0
+	if outcome.Success {
+		boards = 1
+		if outcome.CaptureSuccess {
+			captures = 1
+		}
+	}
+	m.recordStats(ctx, attackerShip.OwnerID, 1, boards, captures)
+
+	log.Info("Boarding resolved: attacker=%s, defender=%s, boarded=%v, captured=%v",
+		attackerShip.ID, defenderShip.ID, outcome.Success, outcome.CaptureSuccess)
+
+	return outcome, nil
+}
+
+// ClaimShip hands a ship captured with Board to its captor. The ship is
+// left at half hull with shields down and at least a prize crew aboard,
+// and saved as owned by the captor; an NPC ship has no record yet, so it
+// is saved as a new ship.
+func (m *Manager) ClaimShip(ctx context.Context, captorID uuid.UUID, ship *models.Ship) error {
+	npc := ship.OwnerID == uuid.Nil
+
+	ship.OwnerID = captorID
+	ship.Shields = 0
+	if ship.Crew < 1 {
+		ship.Crew = 1 // The prize crew
+	}
+	if shipType := models.GetShipTypeByID(ship.TypeID); shipType != nil {
+		ship.Hull = int(float64(shipType.MaxHull) * 0.5)
+	}
+
+	if m.shipRepo == nil {
+		return nil
+	}
+
+	var err error
+	if npc {
+		err = m.shipRepo.Create(ctx, ship)
+	} else {
+		err = m.shipRepo.Update(ctx, ship)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save captured ship: %w", err)
+	}
+
+	log.Info("Ship captured: ship=%s, new_owner=%s", ship.ID, captorID)
+	return nil
+}
+
+// recordStats adds to a player's capture statistics, logging any failure
+func (m *Manager) recordStats(ctx context.Context, playerID uuid.UUID, attempts, boards, captures int) {
+	if m.playerRepo == nil || playerID == uuid.Nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dbTimeout)
+	defer cancel()
+
+	if err := m.playerRepo.RecordCapture(ctx, playerID, attempts, boards, captures); err != nil {
+		log.Error("Failed to update capture stats for %s: %v", playerID, err)
+	}
+}
+
 // GetActiveBoardingAttempt retrieves an active boarding attempt
 func (m *Manager) GetActiveBoardingAttempt(shipID uuid.UUID) (*BoardingAttempt, bool) {
 	m.mu.RLock()
@@ -461,6 +580,9 @@ func (m *Manager) GetStats(ctx context.Context, playerID uuid.UUID) CaptureStats
 	m.mu.RUnlock()
 
 	// Get player from database to retrieve stats
+	if m.playerRepo == nil {
+		return CaptureStats{ActiveBoardings: activeBoardings}
+	}
 	player, err := m.playerRepo.GetByID(ctx, playerID)
 	if err != nil {
 		log.Error("Failed to get player stats: %v", err)
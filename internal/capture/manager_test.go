@@ -0,0 +1,170 @@
+// File: internal/capture/manager_test.go
+// Project: Terminal Velocity
+// Description: Tests for boarding in battle - disabling, PvP rules, stats and captured ships
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package capture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// memoryPlayers is an in-memory Players that tallies capture statistics
+type memoryPlayers struct {
+	attempts, boards, captures int
+}
+
+func (p *memoryPlayers) GetByID(ctx context.Context, id uuid.UUID) (*models.Player, error) {
+	return &models.Player{
+		ID:                   id,
+		TotalCaptureAttempts: p.attempts,
+		SuccessfulBoards:     p.boards,
+		SuccessfulCaptures:   p.captures,
+	}, nil
+}
+
+func (p *memoryPlayers) RecordCapture(ctx context.Context, id uuid.UUID, attempts, boards, captures int) error {
+	p.attempts += attempts
+	p.boards += boards
+	p.captures += captures
+	return nil
+}
+
+// memoryShips is an in-memory Ships
+type memoryShips struct {
+	created, updated []*models.Ship
+}
+
+func (s *memoryShips) Create(ctx context.Context, ship *models.Ship) error {
+	copied := *ship
+	s.created = append(s.created, &copied)
+	return nil
+}
+
+func (s *memoryShips) Update(ctx context.Context, ship *models.Ship) error {
+	copied := *ship
+	s.updated = append(s.updated, &copied)
+	return nil
+}
+
+// newShip returns a ship of the given type at the given hull and shield
+// fractions
+func newShip(owner uuid.UUID, typeID string, crew int, condition float64) *models.Ship {
+	shipType := models.GetShipTypeByID(typeID)
+	return &models.Ship{
+		ID:      uuid.New(),
+		OwnerID: owner,
+		TypeID:  typeID,
+		Name:    typeID,
+		Hull:    int(float64(shipType.MaxHull) * condition),
+		Shields: int(float64(shipType.MaxShields) * condition),
+		Crew:    crew,
+	}
+}
+
+func TestBoardRequiresDisabledTarget(t *testing.T) {
+	m := NewManager(nil, nil)
+	attacker := newShip(uuid.New(), "frigate", 10, 1)
+
+	if _, err := m.Board(context.Background(), attacker, newShip(uuid.Nil, "viper", 2, 1)); !errors.Is(err, ErrNotDisabled) {
+		t.Fatalf("Board on an intact ship error = %v, want ErrNotDisabled", err)
+	}
+
+	attacker.Crew = 0
+	if _, err := m.Board(context.Background(), attacker, newShip(uuid.Nil, "viper", 2, 0.05)); !errors.Is(err, ErrNoBoardingParty) {
+		t.Fatalf("Board without crew error = %v, want ErrNoBoardingParty", err)
+	}
+}
+
+func TestBoardPlayerShipFollowsPvPRules(t *testing.T) {
+	m := NewManager(nil, nil)
+	attacker := newShip(uuid.New(), "frigate", 10, 1)
+	defender := newShip(uuid.New(), "viper", 2, 0.05)
+
+	if _, err := m.Board(context.Background(), attacker, defender); !errors.Is(err, ErrPvPNotAllowed) {
+		t.Fatalf("Board without a PvP policy error = %v, want ErrPvPNotAllowed", err)
+	}
+
+	allowed := false
+	m.SetPvPPolicy(func(attackerID, defenderID uuid.UUID) error {
+		if attackerID != attacker.OwnerID || defenderID != defender.OwnerID {
+			t.Errorf("policy called with %s, %s", attackerID, defenderID)
+		}
+		if !allowed {
+			return fmt.Errorf("not in battle")
+		}
+		return nil
+	})
+	if _, err := m.Board(context.Background(), attacker, defender); !errors.Is(err, ErrPvPNotAllowed) {
+		t.Fatalf("Board refused by the policy error = %v, want ErrPvPNotAllowed", err)
+	}
+
+	allowed = true
+	if _, err := m.Board(context.Background(), attacker, defender); err != nil {
+		t.Fatalf("Board allowed by the policy failed: %v", err)
+	}
+}
+
+func TestBoardRecordsStatsAndClaimsShip(t *testing.T) {
+	players, ships := &memoryPlayers{}, &memoryShips{}
+	m := NewManager(ships, players)
+	captorID := uuid.New()
+	ctx := context.Background()
+
+	boards, captures := 0, 0
+	var prize *models.Ship
+	for i := 0; i < 50; i++ {
+		attacker := newShip(captorID, "frigate", 20, 1)
+		defender := newShip(uuid.Nil, "viper", 2, 0.05)
+
+		outcome, err := m.Board(ctx, attacker, defender)
+		if err != nil {
+			t.Fatalf("Board failed: %v", err)
+		}
+		if outcome.CaptureSuccess && !outcome.Success {
+			t.Fatal("a ship can only be captured by boarders who got aboard")
+		}
+		if outcome.AttackerLosses >= attacker.Crew || outcome.DefenderLosses > defender.Crew {
+			t.Fatalf("losses exceed crews: %+v", outcome)
+		}
+		if outcome.Success {
+			boards++
+		}
+		if outcome.CaptureSuccess {
+			captures++
+			prize = defender
+		}
+	}
+
+	if players.attempts != 50 || players.boards != boards || players.captures != captures {
+		t.Fatalf("stats = %d/%d/%d, want 50/%d/%d", players.attempts, players.boards, players.captures, boards, captures)
+	}
+	stats := m.GetStats(ctx, captorID)
+	if stats.TotalAttempts != 50 || stats.SuccessfulCaptures != captures {
+		t.Errorf("GetStats = %+v", stats)
+	}
+	if prize == nil {
+		t.Fatal("expected at least one capture in 50 boardings of a crippled ship")
+	}
+
+	prize.Crew = 0
+	if err := m.ClaimShip(ctx, captorID, prize); err != nil {
+		t.Fatalf("ClaimShip failed: %v", err)
+	}
+	shipType := models.GetShipTypeByID(prize.TypeID)
+	if len(ships.created) != 1 || ships.created[0].OwnerID != captorID {
+		t.Fatalf("expected the NPC ship to be saved as the captor's, got %+v", ships.created)
+	}
+	if claimed := ships.created[0]; claimed.Hull != shipType.MaxHull/2 || claimed.Shields != 0 || claimed.Crew != 1 {
+		t.Errorf("claimed ship = hull %d, shields %d, crew %d; want half hull, no shields, a prize crew",
+			claimed.Hull, claimed.Shields, claimed.Crew)
+	}
+}
@@ -0,0 +1,36 @@
+// File: internal/capture/store.go
+// Project: Terminal Velocity
+// Description: Player and ship interfaces for boarding and capture
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package capture
+
+import (
+	"context"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// dbTimeout bounds the writes made when a boarding attempt resolves
+const dbTimeout = 5 * time.Second
+
+// Players reads players and records their capture statistics. The database
+// package's PlayerRepository implements it.
+type Players interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Player, error)
+
+	// RecordCapture adds boarding attempts, successful boardings and
+	// captured ships to the player's statistics
+	RecordCapture(ctx context.Context, id uuid.UUID, attempts, boards, captures int) error
+}
+
+// Ships saves captured ships. The database package's ShipRepository
+// implements it.
+type Ships interface {
+	Create(ctx context.Context, ship *models.Ship) error
+	Update(ctx context.Context, ship *models.Ship) error
+}
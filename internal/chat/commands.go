@@ -232,7 +232,12 @@ func (h *CommandHandler) handleWhisper(ctx context.Context, senderID uuid.UUID,
 	}
 
 	// Send direct message
-	h.chatManager.SendDirectMessage(senderID, senderName, recipient.ID, recipient.Username, message)
+	if h.chatManager.SendDirectMessage(senderID, senderName, recipient.ID, recipient.Username, message) == nil {
+		return &CommandResult{
+			Success:      false,
+			SystemOutput: "You are muted and cannot send messages",
+		}
+	}
 
 	return &CommandResult{
 		Success:      true,
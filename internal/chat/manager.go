@@ -1,7 +1,7 @@
 // File: internal/chat/manager.go
 // Project: Terminal Velocity
 // Description: Chat manager for multiplayer communication and message routing
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -33,7 +33,7 @@
 // All Manager methods are thread-safe using sync.RWMutex. Read operations
 // use RLock, write operations use Lock.
 //
-// Version: 1.1.0
+// Version: 1.2.0
 // Last Updated: 2025-11-16
 package chat
 
@@ -54,6 +54,12 @@ type Manager struct {
 	// Message retention for late-joining players
 	globalHistory    []*models.ChatMessage // Recent global messages (circular buffer)
 	maxGlobalHistory int                   // Maximum global messages to retain (default: 200)
+
+	// Callback for real-time message delivery (nil recipients means all players)
+	onNewMessage func(msg *models.ChatMessage, recipientIDs []uuid.UUID)
+
+	// Reports whether a player is muted (nil means nobody is)
+	muteChecker func(playerID uuid.UUID) bool
 }
 
 // NewManager creates a new chat manager.
@@ -71,6 +77,43 @@ func NewManager() *Manager {
 	}
 }
 
+// SetMessageCallback sets the callback for real-time message delivery.
+//
+// The callback is invoked while the manager lock is held, so it must not
+// call back into the Manager. A nil recipient list means the message was
+// broadcast to every player.
+func (m *Manager) SetMessageCallback(callback func(msg *models.ChatMessage, recipientIDs []uuid.UUID)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onNewMessage = callback
+}
+
+// SetMuteChecker sets the function used to reject messages from muted players.
+//
+// The checker is called without the manager lock held.
+func (m *Manager) SetMuteChecker(checker func(playerID uuid.UUID) bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.muteChecker = checker
+}
+
+// IsMuted reports whether a player is currently muted
+func (m *Manager) IsMuted(playerID uuid.UUID) bool {
+	m.mu.RLock()
+	checker := m.muteChecker
+	m.mu.RUnlock()
+
+	return checker != nil && checker(playerID)
+}
+
+// notify delivers a message to the registered callback.
+// Caller must hold m.mu.
+func (m *Manager) notify(msg *models.ChatMessage, recipientIDs []uuid.UUID) {
+	if m.onNewMessage != nil {
+		m.onNewMessage(msg, recipientIDs)
+	}
+}
+
 // GetOrCreateHistory gets or creates a chat history for a player.
 //
 // Creates a new history if player doesn't have one yet. Used when
@@ -109,11 +152,14 @@ func (m *Manager) GetOrCreateHistory(playerID uuid.UUID) *models.ChatHistory {
 //   - content: Message text content
 //
 // Returns:
-//   - Pointer to created ChatMessage
+//   - Pointer to created ChatMessage, or nil if the sender is muted
 //
 // Thread Safety:
 // Thread-safe. Acquires write lock.
 func (m *Manager) SendGlobalMessage(senderID uuid.UUID, sender string, content string) *models.ChatMessage {
+	if m.IsMuted(senderID) {
+		return nil
+	}
 	msg := models.NewChatMessage(models.ChatChannelGlobal, senderID, sender, content)
 
 	m.mu.Lock()
@@ -130,6 +176,8 @@ func (m *Manager) SendGlobalMessage(senderID uuid.UUID, sender string, content s
 		history.AddMessage(msg)
 	}
 
+	m.notify(msg, nil)
+
 	return msg
 }
 
@@ -145,11 +193,14 @@ func (m *Manager) SendGlobalMessage(senderID uuid.UUID, sender string, content s
 //   - recipientIDs: List of player UUIDs to receive message
 //
 // Returns:
-//   - Pointer to created ChatMessage
+//   - Pointer to created ChatMessage, or nil if the sender is muted
 //
 // Thread Safety:
 // Thread-safe. Acquires write lock.
 func (m *Manager) SendSystemMessage(systemID uuid.UUID, senderID uuid.UUID, sender string, content string, recipientIDs []uuid.UUID) *models.ChatMessage {
+	if m.IsMuted(senderID) {
+		return nil
+	}
 	msg := models.NewChatMessage(models.ChatChannelSystem, senderID, sender, content)
 	msg.SystemID = systemID
 
@@ -163,6 +214,8 @@ func (m *Manager) SendSystemMessage(systemID uuid.UUID, senderID uuid.UUID, send
 		}
 	}
 
+	m.notify(msg, recipientIDs)
+
 	return msg
 }
 
@@ -178,11 +231,14 @@ func (m *Manager) SendSystemMessage(systemID uuid.UUID, senderID uuid.UUID, send
 //   - memberIDs: List of faction member UUIDs
 //
 // Returns:
-//   - Pointer to created ChatMessage
+//   - Pointer to created ChatMessage, or nil if the sender is muted
 //
 // Thread Safety:
 // Thread-safe. Acquires write lock.
 func (m *Manager) SendFactionMessage(factionID string, senderID uuid.UUID, sender string, content string, memberIDs []uuid.UUID) *models.ChatMessage {
+	if m.IsMuted(senderID) {
+		return nil
+	}
 	msg := models.NewChatMessage(models.ChatChannelFaction, senderID, sender, content)
 	msg.FactionID = factionID
 
@@ -196,6 +252,8 @@ func (m *Manager) SendFactionMessage(factionID string, senderID uuid.UUID, sende
 		}
 	}
 
+	m.notify(msg, memberIDs)
+
 	return msg
 }
 
@@ -212,11 +270,14 @@ func (m *Manager) SendFactionMessage(factionID string, senderID uuid.UUID, sende
 //   - content: Message text
 //
 // Returns:
-//   - Pointer to created ChatMessage (sender's copy)
+//   - Pointer to created ChatMessage (sender's copy), or nil if the sender is muted
 //
 // Thread Safety:
 // Thread-safe. Acquires write lock.
 func (m *Manager) SendDirectMessage(senderID uuid.UUID, sender string, recipientID uuid.UUID, recipient string, content string) *models.ChatMessage {
+	if m.IsMuted(senderID) {
+		return nil
+	}
 	msg := models.NewDirectMessage(senderID, sender, recipient, content)
 
 	m.mu.Lock()
@@ -236,6 +297,8 @@ func (m *Manager) SendDirectMessage(senderID uuid.UUID, sender string, recipient
 		history.AddMessage(recipientMsg)
 	}
 
+	m.notify(msg, []uuid.UUID{senderID, recipientID})
+
 	return msg
 }
 
@@ -249,11 +312,14 @@ func (m *Manager) SendDirectMessage(senderID uuid.UUID, sender string, recipient
 //   - content: Message text
 //
 // Returns:
-//   - Pointer to created ChatMessage
+//   - Pointer to created ChatMessage, or nil if the sender is muted
 //
 // Thread Safety:
 // Thread-safe. Acquires write lock.
 func (m *Manager) SendTradeMessage(senderID uuid.UUID, sender string, content string) *models.ChatMessage {
+	if m.IsMuted(senderID) {
+		return nil
+	}
 	msg := models.NewChatMessage(models.ChatChannelTrade, senderID, sender, content)
 
 	m.mu.Lock()
@@ -264,6 +330,8 @@ func (m *Manager) SendTradeMessage(senderID uuid.UUID, sender string, content st
 		history.AddMessage(msg)
 	}
 
+	m.notify(msg, nil)
+
 	return msg
 }
 
@@ -289,6 +357,8 @@ func (m *Manager) SendCombatNotification(playerIDs []uuid.UUID, content string)
 			history.AddMessage(msg)
 		}
 	}
+
+	m.notify(msg, playerIDs)
 }
 
 // BroadcastSystemMessage broadcasts a system message to all players.
@@ -311,6 +381,8 @@ func (m *Manager) BroadcastSystemMessage(channel models.ChatChannel, content str
 	for _, history := range m.histories {
 		history.AddMessage(msg)
 	}
+
+	m.notify(msg, nil)
 }
 
 // GetMessages retrieves messages for a specific player and channel.
@@ -34,8 +34,9 @@ type AIState struct {
 	CurrentTarget   string  // Ship ID of current target
 	LastTargetCheck float64 // time since last target evaluation
 	IsRetreating    bool
-	FormationPos    *Position // Position in formation, if any
-	Morale          float64   // 0.0-1.0, affects retreat decision
+	FormationPos    *Position  // Position in formation, if any
+	Morale          float64    // 0.0-1.0, affects retreat decision
+	Rand            *rand.Rand // Random source for decisions (nil = global source)
 }
 
 // Position represents a 2D position in space
@@ -277,9 +278,9 @@ func (ai *AIState) calculateTargetScore(self *models.Ship, target *models.Ship,
 
 	// Random factor to prevent too predictable behavior
 	if ai.Level <= AILevelMedium {
-		score += rand.Float64() * 15.0
+		score += randFloat(ai.Rand) * 15.0
 	} else {
-		score += rand.Float64() * 5.0
+		score += randFloat(ai.Rand) * 5.0
 	}
 
 	return score
@@ -411,12 +412,12 @@ func (ai *AIState) shouldEvade(self *models.Ship, selfType *models.ShipType,
 	// Random evasion based on AI level
 	// Higher level AIs evade more tactically
 	if ai.Level >= AILevelHard {
-		return rand.Float64() < 0.3
+		return randFloat(ai.Rand) < 0.3
 	} else if ai.Level >= AILevelMedium {
-		return rand.Float64() < 0.2
+		return randFloat(ai.Rand) < 0.2
 	}
 
-	return rand.Float64() < 0.1
+	return randFloat(ai.Rand) < 0.1
 }
 
 // calculateEvasion determines the best evasion maneuver
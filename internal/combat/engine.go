@@ -0,0 +1,621 @@
+// File: internal/combat/engine.go
+// Project: Terminal Velocity
+// Description: Headless, deterministic turn-based combat engine
+// Version: 1.3.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package combat
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+)
+
+// Package combat - Battle engine
+//
+// The Engine owns the state of a single battle and resolves it with the same
+// rules everywhere: player actions go through Fire, AI actions come from
+// DecideAction, and every shot uses the weapon firing rules in weapons.go
+// (CalculateHitChance, critical hits, shield-then-hull damage).
+//
+// All randomness is drawn from one RNG seeded at construction, and
+// combatants are always processed in the order they were added, so two
+// engines built with the same seed, combatants and actions produce the same
+// event log. This makes battles replayable and testable without a UI: the
+// engine keeps a Record of its inputs, and Replay rebuilds an identical
+// engine from one (see record.go).
+//
+// Thread-safety: An Engine is not safe for concurrent use. Callers that share
+// one between goroutines must provide their own locking.
+
+// DefaultEngagementDistance is the range at which all combatants engage
+// until positional combat is implemented
+const DefaultEngagementDistance = 500
+
+// Engine errors
+var (
+	ErrUnknownCombatant = errors.New("unknown combatant")
+	ErrUnknownWeapon    = errors.New("unknown weapon slot")
+	ErrWeaponNotReady   = errors.New("weapon not ready")
+	ErrNotActive        = errors.New("combatant is no longer in the battle")
+	ErrInvalidTarget    = errors.New("invalid target")
+	ErrNoBoardingParty  = errors.New("no crew left to board with")
+	ErrCombatOver       = errors.New("combat is over")
+)
+
+// Side identifies which side of a battle a combatant fights on
+type Side int
+
+const (
+	SidePlayer Side = iota // The player and their allies
+	SideEnemy              // Hostile ships
+)
+
+// Outcome is the result of a battle
+type Outcome string
+
+const (
+	OutcomeNone    Outcome = ""        // Battle still in progress
+	OutcomeVictory Outcome = "victory" // No enemies left in the battle
+	OutcomeDefeat  Outcome = "defeat"  // No player-side ships left in the battle
+)
+
+// EventType categorizes entries in the battle event log
+type EventType string
+
+const (
+	EventTurnStart   EventType = "turn_start"
+	EventFire        EventType = "fire"
+	EventDestroyed   EventType = "destroyed"
+	EventRetreat     EventType = "retreat"
+	EventBoard       EventType = "board"
+	EventCapture     EventType = "capture"
+	EventEvade       EventType = "evade"
+	EventShieldRegen EventType = "shield_regen"
+	EventVictory     EventType = "victory"
+	EventDefeat      EventType = "defeat"
+)
+
+// Event is a single entry in the battle event log
+type Event struct {
+	Turn         int       `json:"turn"`
+	Type         EventType `json:"type"`
+	Actor        string    `json:"actor,omitempty"`  // Combatant ID performing the action
+	Target       string    `json:"target,omitempty"` // Combatant ID affected by the action
+	WeaponID     string    `json:"weapon_id,omitempty"`
+	Hit          bool      `json:"hit,omitempty"`
+	Critical     bool      `json:"critical,omitempty"`
+	HitChance    float64   `json:"hit_chance,omitempty"` // Percentage (5-95) used for the shot
+	Damage       int       `json:"damage,omitempty"`
+	ShieldDamage int       `json:"shield_damage,omitempty"`
+	HullDamage   int       `json:"hull_damage,omitempty"`
+	Amount       int       `json:"amount,omitempty"` // Shield points regenerated
+	Message      string    `json:"message"`
+}
+
+// Stance controls when an AI combatant opens fire
+type Stance string
+
+const (
+	StanceEngage Stance = ""       // Attack any enemy (default)
+	StanceDefend Stance = "defend" // Attack only enemies that have opened fire
+	StanceHold   Stance = "hold"   // Hold fire
+)
+
+// Combatant is a ship taking part in a battle
+type Combatant struct {
+	ID        string           // Ship ID (matches AIAction.TargetID)
+	Name      string           // Display name
+	Side      Side             // Side the ship fights on
+	Ship      *models.Ship     // Ship state, modified in place by the engine
+	Type      *models.ShipType // Ship type for stats
+	AI        *AIState         // AI controller (nil = controlled by Fire calls)
+	Stance    Stance           // When the AI opens fire (ignored without AI)
+	Weapons   []*WeaponState   // Weapon states, one per slot in Ship.Weapons
+	Retreated bool             // True once the ship has left the battle
+	Captured  bool             // True once the ship has been taken by boarders
+	Hostile   bool             // True once the ship has fired on the other side
+}
+
+// Destroyed returns true if the combatant's hull has been depleted
+func (c *Combatant) Destroyed() bool {
+	return c.Ship.Hull <= 0
+}
+
+// Active returns true if the combatant is still fighting
+func (c *Combatant) Active() bool {
+	return !c.Destroyed() && !c.Retreated && !c.Captured
+}
+
+// Engine resolves a single battle
+type Engine struct {
+	seed       int64
+	rng        *rand.Rand
+	turn       int
+	outcome    Outcome
+	combatants []*Combatant
+	byID       map[string]*Combatant
+	events     []Event
+
+	// Inputs, for Record
+	initial []RecordedCombatant
+	actions []Action
+}
+
+// NewEngine creates an empty battle whose randomness is fully determined by seed
+func NewEngine(seed int64) *Engine {
+	return &Engine{
+		seed: seed,
+		rng:  rand.New(rand.NewSource(seed)),
+		turn: 1,
+		byID: make(map[string]*Combatant),
+	}
+}
+
+// AddCombatant adds a ship to the battle. If ai is non-nil the ship is
+// controlled by the engine during EndTurn; its random source is replaced with
+// the engine's so its decisions are reproducible.
+func (e *Engine) AddCombatant(ship *models.Ship, shipType *models.ShipType, side Side, ai *AIState) (*Combatant, error) {
+	if ship == nil || shipType == nil {
+		return nil, fmt.Errorf("ship and ship type are required")
+	}
+
+	id := ship.ID.String()
+	if _, exists := e.byID[id]; exists {
+		return nil, fmt.Errorf("combatant %s already in battle", id)
+	}
+
+	name := ship.Name
+	if name == "" {
+		name = shipType.Name
+	}
+
+	if ai != nil {
+		ai.Rand = e.rng
+	}
+
+	c := &Combatant{
+		ID:      id,
+		Name:    name,
+		Side:    side,
+		Ship:    ship,
+		Type:    shipType,
+		AI:      ai,
+		Weapons: make([]*WeaponState, len(ship.Weapons)),
+	}
+	for i, weaponID := range ship.Weapons {
+		if weapon := models.GetWeaponByID(weaponID); weapon != nil {
+			c.Weapons[i] = InitializeWeaponState(weapon)
+		} else {
+			c.Weapons[i] = &WeaponState{WeaponID: weaponID}
+		}
+	}
+
+	e.combatants = append(e.combatants, c)
+	e.byID[id] = c
+	e.initial = append(e.initial, newRecordedCombatant(ship, side, ai))
+	return c, nil
+}
+
+// Fire fires the weapon in the given slot of attacker at target and returns
+// the resulting events (the shot, plus destruction and outcome if any).
+func (e *Engine) Fire(attackerID string, slot int, targetID string) ([]Event, error) {
+	if e.outcome != OutcomeNone {
+		return nil, ErrCombatOver
+	}
+
+	attacker, ok := e.byID[attackerID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCombatant, attackerID)
+	}
+	target, ok := e.byID[targetID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCombatant, targetID)
+	}
+	if !attacker.Active() {
+		return nil, fmt.Errorf("%w: %s", ErrNotActive, attacker.Name)
+	}
+	if !target.Active() || target.Side == attacker.Side {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTarget, target.Name)
+	}
+	if slot < 0 || slot >= len(attacker.Weapons) {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownWeapon, slot)
+	}
+
+	weapon := models.GetWeaponByID(attacker.Ship.Weapons[slot])
+	if weapon == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownWeapon, attacker.Ship.Weapons[slot])
+	}
+	if ok, msg := CanFire(weapon, attacker.Weapons[slot], attacker.Ship, attacker.Type); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrWeaponNotReady, msg)
+	}
+
+	e.actions = append(e.actions, Action{Type: ActionFire, Attacker: attackerID, Slot: slot, Target: targetID})
+
+	start := len(e.events)
+	e.fire(attacker, slot, weapon, target, 1.0)
+	e.checkOutcome()
+	return e.eventsSince(start), nil
+}
+
+// BoardingResult is the outcome of a boarding action, resolved outside the
+// engine (see the capture package) and applied to the battle by Board
+type BoardingResult struct {
+	Boarded        bool   `json:"boarded"`                   // Boarders got aboard
+	Captured       bool   `json:"captured"`                  // The target was taken
+	AttackerLosses int    `json:"attacker_losses,omitempty"` // Boarders lost
+	DefenderLosses int    `json:"defender_losses,omitempty"` // Defending crew lost
+	Message        string `json:"message,omitempty"`
+}
+
+// Board applies a boarding action by attacker against target and returns
+// the resulting events (the boarding, plus the capture and outcome if any).
+// Crew losses come off both ships. A captured target leaves the battle as
+// if it had been destroyed, but with its hull intact. Boarding is the
+// attacker's action for the turn; the engine does not check whether the
+// target was disabled, since that is decided with the result.
+func (e *Engine) Board(attackerID, targetID string, result BoardingResult) ([]Event, error) {
+	if e.outcome != OutcomeNone {
+		return nil, ErrCombatOver
+	}
+
+	attacker, ok := e.byID[attackerID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCombatant, attackerID)
+	}
+	target, ok := e.byID[targetID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCombatant, targetID)
+	}
+	if !attacker.Active() {
+		return nil, fmt.Errorf("%w: %s", ErrNotActive, attacker.Name)
+	}
+	if !target.Active() || target.Side == attacker.Side {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTarget, target.Name)
+	}
+	if attacker.Ship.Crew < 1 {
+		return nil, fmt.Errorf("%w: %s", ErrNoBoardingParty, attacker.Name)
+	}
+
+	e.actions = append(e.actions, Action{Type: ActionBoard, Attacker: attackerID, Target: targetID, Boarding: &result})
+
+	start := len(e.events)
+	attacker.Hostile = true
+	attacker.Ship.Crew = max(attacker.Ship.Crew-result.AttackerLosses, 0)
+	target.Ship.Crew = max(target.Ship.Crew-result.DefenderLosses, 0)
+
+	msg := result.Message
+	if msg == "" {
+		msg = fmt.Sprintf("%s attempts to board %s", attacker.Name, target.Name)
+	}
+	e.record(Event{
+		Type:    EventBoard,
+		Actor:   attacker.ID,
+		Target:  target.ID,
+		Hit:     result.Boarded,
+		Damage:  result.DefenderLosses,
+		Message: msg,
+	})
+
+	if result.Boarded && result.Captured {
+		target.Captured = true
+		e.record(Event{
+			Type:    EventCapture,
+			Actor:   attacker.ID,
+			Target:  target.ID,
+			Message: fmt.Sprintf("%s has been captured by %s!", target.Name, attacker.Name),
+		})
+	}
+
+	e.checkOutcome()
+	return e.eventsSince(start), nil
+}
+
+// SetStance changes when an AI combatant opens fire, for example when a
+// player orders their escorts to hold fire. It takes effect from the next
+// EndTurn.
+func (e *Engine) SetStance(id string, stance Stance) error {
+	if e.outcome != OutcomeNone {
+		return ErrCombatOver
+	}
+
+	c, ok := e.byID[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownCombatant, id)
+	}
+	if c.Stance == stance {
+		return nil
+	}
+
+	e.actions = append(e.actions, Action{Type: ActionStance, Attacker: id, Stance: stance})
+	c.Stance = stance
+	return nil
+}
+
+// Retreat withdraws a combatant from the battle and returns the resulting
+// events (the retreat, plus the outcome if it ends the battle).
+func (e *Engine) Retreat(id string) ([]Event, error) {
+	if e.outcome != OutcomeNone {
+		return nil, ErrCombatOver
+	}
+
+	c, ok := e.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCombatant, id)
+	}
+	if !c.Active() {
+		return nil, fmt.Errorf("%w: %s", ErrNotActive, c.Name)
+	}
+
+	e.actions = append(e.actions, Action{Type: ActionRetreat, Attacker: id})
+
+	start := len(e.events)
+	e.retreat(c)
+	e.checkOutcome()
+	return e.eventsSince(start), nil
+}
+
+// EndTurn resolves the AI phase and the end of the current turn: every
+// active AI-controlled combatant acts, shields regenerate, weapon cooldowns
+// advance and the turn counter increments. Returns the resulting events.
+func (e *Engine) EndTurn() []Event {
+	if e.outcome != OutcomeNone {
+		return nil
+	}
+
+	e.actions = append(e.actions, Action{Type: ActionEndTurn})
+
+	start := len(e.events)
+
+	for _, c := range e.combatants {
+		if c.AI == nil || !c.Active() {
+			continue
+		}
+		e.runAI(c)
+		if e.checkOutcome() {
+			return e.eventsSince(start)
+		}
+	}
+
+	// Shield regeneration
+	for _, c := range e.combatants {
+		if !c.Active() || c.Ship.Shields >= c.Type.MaxShields || c.Type.ShieldRegen <= 0 {
+			continue
+		}
+		regen := c.Type.ShieldRegen
+		if c.Ship.Shields+regen > c.Type.MaxShields {
+			regen = c.Type.MaxShields - c.Ship.Shields
+		}
+		c.Ship.Shields += regen
+		e.record(Event{
+			Type:    EventShieldRegen,
+			Actor:   c.ID,
+			Amount:  regen,
+			Message: fmt.Sprintf("%s shields recharged +%d", c.Name, regen),
+		})
+	}
+
+	// Weapon cooldowns (one turn = one second)
+	for _, c := range e.combatants {
+		UpdateCooldowns(c.Weapons, 1.0)
+	}
+
+	e.turn++
+	e.record(Event{
+		Type:    EventTurnStart,
+		Message: fmt.Sprintf("--- Turn %d ---", e.turn),
+	})
+
+	return e.eventsSince(start)
+}
+
+// runAI executes the actions DecideAction chooses for an AI combatant.
+// Its stance limits which enemies it may engage: none when holding fire,
+// and only those that have opened fire when defending.
+func (e *Engine) runAI(c *Combatant) {
+	if c.Stance == StanceHold {
+		return
+	}
+
+	var enemies, allies []*models.Ship
+	enemyTypes := make(map[string]*models.ShipType)
+	for _, other := range e.combatants {
+		if other == c || !other.Active() {
+			continue
+		}
+		if other.Side == c.Side {
+			allies = append(allies, other.Ship)
+		} else if c.Stance != StanceDefend || other.Hostile {
+			enemies = append(enemies, other.Ship)
+			enemyTypes[other.Ship.TypeID] = other.Type
+		}
+	}
+	if len(enemies) == 0 {
+		return
+	}
+
+	actions := DecideAction(c.AI, c.Ship, c.Type, enemies, enemyTypes, allies, 1.0)
+	for _, action := range actions {
+		if !c.Active() {
+			return
+		}
+
+		switch action.Type {
+		case "fire":
+			target, ok := e.byID[action.TargetID]
+			if !ok || !target.Active() || target.Side == c.Side {
+				continue
+			}
+			slot := e.readySlot(c, action.WeaponID)
+			if slot < 0 {
+				continue
+			}
+			e.fire(c, slot, models.GetWeaponByID(action.WeaponID), target, c.AI.Accuracy)
+
+		case "retreat":
+			e.retreat(c)
+
+		case "evade":
+			e.record(Event{
+				Type:    EventEvade,
+				Actor:   c.ID,
+				Message: fmt.Sprintf("%s takes evasive maneuvers", c.Name),
+			})
+		}
+	}
+}
+
+// readySlot returns the first slot holding weaponID that can fire, or -1.
+// AI actions name weapons by ID, so ships with several copies of a weapon
+// fire each copy in turn.
+func (e *Engine) readySlot(c *Combatant, weaponID string) int {
+	weapon := models.GetWeaponByID(weaponID)
+	if weapon == nil {
+		return -1
+	}
+	for i, id := range c.Ship.Weapons {
+		if id != weaponID {
+			continue
+		}
+		if ok, _ := CanFire(weapon, c.Weapons[i], c.Ship, c.Type); ok {
+			return i
+		}
+	}
+	return -1
+}
+
+// retreat removes a combatant from the battle and records it
+func (e *Engine) retreat(c *Combatant) {
+	c.Retreated = true
+	e.record(Event{
+		Type:    EventRetreat,
+		Actor:   c.ID,
+		Message: fmt.Sprintf("%s retreats from the battle!", c.Name),
+	})
+}
+
+// fire resolves one shot and records its events
+func (e *Engine) fire(attacker *Combatant, slot int, weapon *models.Weapon, target *Combatant, accuracy float64) {
+	attacker.Hostile = true
+	result := fire(e.rng, weapon, attacker.Weapons[slot], attacker.Ship, target.Ship,
+		attacker.Type, target.Type, DefaultEngagementDistance, accuracy)
+
+	e.record(Event{
+		Type:         EventFire,
+		Actor:        attacker.ID,
+		Target:       target.ID,
+		WeaponID:     weapon.ID,
+		Hit:          result.Hit,
+		Critical:     result.CriticalHit,
+		HitChance:    result.HitChance,
+		Damage:       result.Damage,
+		ShieldDamage: result.ShieldDamage,
+		HullDamage:   result.HullDamage,
+		Message:      fmt.Sprintf("%s: %s", attacker.Name, result.Message),
+	})
+
+	if result.Hit && target.Destroyed() {
+		e.record(Event{
+			Type:    EventDestroyed,
+			Actor:   attacker.ID,
+			Target:  target.ID,
+			Message: fmt.Sprintf("%s DESTROYED!", target.Name),
+		})
+	}
+}
+
+// checkOutcome ends the battle once a side has no active ships left.
+// Returns true if the battle is over.
+func (e *Engine) checkOutcome() bool {
+	if e.outcome != OutcomeNone {
+		return true
+	}
+
+	playerActive, enemyActive := false, false
+	enemyDestroyed, enemyCaptured := true, false
+	for _, c := range e.combatants {
+		if c.Side == SideEnemy && c.Retreated && !c.Destroyed() {
+			enemyDestroyed = false
+		}
+		if c.Side == SideEnemy && c.Captured {
+			enemyCaptured = true
+		}
+		if !c.Active() {
+			continue
+		}
+		if c.Side == SidePlayer {
+			playerActive = true
+		} else {
+			enemyActive = true
+		}
+	}
+
+	switch {
+	case !playerActive:
+		e.outcome = OutcomeDefeat
+		e.record(Event{Type: EventDefeat, Message: "DEFEAT! All friendly ships lost!"})
+	case !enemyActive:
+		e.outcome = OutcomeVictory
+		msg := "VICTORY! All enemies destroyed!"
+		if !enemyDestroyed {
+			msg = "VICTORY! The enemy has fled!"
+		} else if enemyCaptured {
+			msg = "VICTORY! Enemy ship captured!"
+		}
+		e.record(Event{Type: EventVictory, Message: msg})
+	default:
+		return false
+	}
+	return true
+}
+
+// record appends an event to the log, stamped with the current turn
+func (e *Engine) record(event Event) {
+	event.Turn = e.turn
+	e.events = append(e.events, event)
+}
+
+// eventsSince returns a copy of the events recorded after index start
+func (e *Engine) eventsSince(start int) []Event {
+	return append([]Event(nil), e.events[start:]...)
+}
+
+// Events returns a copy of the full battle event log
+func (e *Engine) Events() []Event {
+	return e.eventsSince(0)
+}
+
+// Outcome returns the battle outcome (OutcomeNone while in progress)
+func (e *Engine) Outcome() Outcome {
+	return e.outcome
+}
+
+// Turn returns the current turn number (starting at 1)
+func (e *Engine) Turn() int {
+	return e.turn
+}
+
+// Seed returns the seed the engine was created with
+func (e *Engine) Seed() int64 {
+	return e.seed
+}
+
+// Combatant returns the combatant with the given ID, or nil
+func (e *Engine) Combatant(id string) *Combatant {
+	return e.byID[id]
+}
+
+// Combatants returns the combatants on a side in the order they were added
+func (e *Engine) Combatants(side Side) []*Combatant {
+	var result []*Combatant
+	for _, c := range e.combatants {
+		if c.Side == side {
+			result = append(result, c)
+		}
+	}
+	return result
+}
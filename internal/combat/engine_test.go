@@ -0,0 +1,238 @@
+// File: internal/combat/engine_test.go
+// Project: Terminal Velocity
+// Description: Tests for the deterministic combat engine
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package combat
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+func newTestShip(name, typeID string, weapons ...string) (*models.Ship, *models.ShipType) {
+	shipType := models.GetShipTypeByID(typeID)
+	return &models.Ship{
+		ID:      uuid.New(),
+		TypeID:  typeID,
+		Name:    name,
+		Hull:    shipType.MaxHull,
+		Shields: shipType.MaxShields,
+		Weapons: weapons,
+	}, shipType
+}
+
+// runBattle fights a scripted battle: the player fires every ready weapon at
+// the first active enemy each turn until the battle ends or 200 turns pass.
+func runBattle(t *testing.T, seed int64) *Engine {
+	t.Helper()
+
+	engine := NewEngine(seed)
+	playerShip, playerType := newTestShip("Player", "frigate", "heavy_laser", "pulse_laser")
+	player, err := engine.AddCombatant(playerShip, playerType, SidePlayer, nil)
+	if err != nil {
+		t.Fatalf("AddCombatant failed: %v", err)
+	}
+	for _, name := range []string{"Raider 1", "Raider 2"} {
+		ship, shipType := newTestShip(name, "viper", "pulse_laser")
+		if _, err := engine.AddCombatant(ship, shipType, SideEnemy, NewAIState(AILevelMedium)); err != nil {
+			t.Fatalf("AddCombatant failed: %v", err)
+		}
+	}
+
+	for engine.Outcome() == OutcomeNone && engine.Turn() <= 200 {
+		for slot := range player.Weapons {
+			var target *Combatant
+			for _, enemy := range engine.Combatants(SideEnemy) {
+				if enemy.Active() {
+					target = enemy
+					break
+				}
+			}
+			if target == nil {
+				break
+			}
+			if _, err := engine.Fire(player.ID, slot, target.ID); err != nil && !errors.Is(err, ErrWeaponNotReady) {
+				t.Fatalf("Fire failed: %v", err)
+			}
+		}
+		engine.EndTurn()
+	}
+
+	return engine
+}
+
+func TestEngineReplaysWithSameSeed(t *testing.T) {
+	first := runBattle(t, 42)
+	second := runBattle(t, 42)
+
+	if first.Outcome() == OutcomeNone {
+		t.Fatal("Expected the battle to finish")
+	}
+
+	// Ship IDs differ between runs; compare everything else
+	strip := func(events []Event) []Event {
+		for i := range events {
+			events[i].Actor, events[i].Target = "", ""
+		}
+		return events
+	}
+	if !reflect.DeepEqual(strip(first.Events()), strip(second.Events())) {
+		t.Error("Expected identical event logs for the same seed")
+	}
+}
+
+func TestEngineFireRules(t *testing.T) {
+	engine := NewEngine(1)
+	playerShip, playerType := newTestShip("Player", "frigate", "railgun")
+	enemyShip, enemyType := newTestShip("Target", "shuttle")
+	engine.AddCombatant(playerShip, playerType, SidePlayer, nil)
+	engine.AddCombatant(enemyShip, enemyType, SideEnemy, nil)
+
+	player, enemy := playerShip.ID.String(), enemyShip.ID.String()
+
+	if _, err := engine.Fire(player, 3, enemy); !errors.Is(err, ErrUnknownWeapon) {
+		t.Errorf("Expected ErrUnknownWeapon, got %v", err)
+	}
+	if _, err := engine.Fire(enemy, 0, player); !errors.Is(err, ErrUnknownWeapon) {
+		t.Errorf("Expected ErrUnknownWeapon for unarmed ship, got %v", err)
+	}
+
+	events, err := engine.Fire(player, 0, enemy)
+	if err != nil {
+		t.Fatalf("Fire failed: %v", err)
+	}
+	if len(events) == 0 || events[0].Type != EventFire || events[0].HitChance < 5 || events[0].HitChance > 95 {
+		t.Errorf("Unexpected fire events: %+v", events)
+	}
+
+	// The weapon is cooling down until the turn ends
+	if _, err := engine.Fire(player, 0, enemy); !errors.Is(err, ErrWeaponNotReady) {
+		t.Errorf("Expected ErrWeaponNotReady, got %v", err)
+	}
+}
+
+func TestEngineVictoryAndDefeat(t *testing.T) {
+	engine := NewEngine(7)
+	playerShip, playerType := newTestShip("Player", "frigate", "railgun")
+	enemyShip, enemyType := newTestShip("Target", "shuttle")
+	engine.AddCombatant(playerShip, playerType, SidePlayer, nil)
+	engine.AddCombatant(enemyShip, enemyType, SideEnemy, nil)
+
+	enemyShip.Hull, enemyShip.Shields = 1, 0
+	for engine.Outcome() == OutcomeNone && engine.Turn() < 100 {
+		engine.Fire(playerShip.ID.String(), 0, enemyShip.ID.String())
+		engine.EndTurn()
+	}
+	if engine.Outcome() != OutcomeVictory {
+		t.Fatalf("Expected victory, got %q", engine.Outcome())
+	}
+	if _, err := engine.Fire(playerShip.ID.String(), 0, enemyShip.ID.String()); !errors.Is(err, ErrCombatOver) {
+		t.Errorf("Expected ErrCombatOver, got %v", err)
+	}
+
+	// An AI enemy that outguns a crippled player wins
+	engine = NewEngine(7)
+	playerShip, playerType = newTestShip("Player", "shuttle")
+	enemyShip, enemyType = newTestShip("Hunter", "frigate", "railgun")
+	playerShip.Hull, playerShip.Shields = 1, 0
+	engine.AddCombatant(playerShip, playerType, SidePlayer, nil)
+	engine.AddCombatant(enemyShip, enemyType, SideEnemy, NewAIState(AILevelAce))
+
+	for engine.Outcome() == OutcomeNone && engine.Turn() < 100 {
+		engine.EndTurn()
+	}
+	if engine.Outcome() != OutcomeDefeat {
+		t.Fatalf("Expected defeat, got %q", engine.Outcome())
+	}
+}
+
+func TestEngineAllyStances(t *testing.T) {
+	engine := NewEngine(11)
+	playerShip, playerType := newTestShip("Player", "frigate", "railgun")
+	escortShip, escortType := newTestShip("Escort", "frigate", "heavy_laser", "heavy_laser")
+	enemyShip, enemyType := newTestShip("Raider", "viper")
+	engine.AddCombatant(playerShip, playerType, SidePlayer, nil)
+	escort, _ := engine.AddCombatant(escortShip, escortType, SidePlayer, NewAIState(AILevelAce))
+	engine.AddCombatant(enemyShip, enemyType, SideEnemy, nil)
+
+	fired := func() bool {
+		for _, event := range engine.Events() {
+			if event.Type == EventFire && event.Actor == escort.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	// A holding escort never fires, and a defending one waits to be shot at
+	engine.SetStance(escort.ID, StanceHold)
+	engine.EndTurn()
+	engine.SetStance(escort.ID, StanceDefend)
+	engine.EndTurn()
+	if fired() {
+		t.Fatal("Expected the escort to hold fire against an enemy that has not attacked")
+	}
+
+	engine.SetStance(escort.ID, StanceEngage)
+	engine.EndTurn()
+	if !fired() {
+		t.Fatal("Expected an engaging escort to open fire")
+	}
+
+	// The escort withdrawing with the player gone ends the battle
+	playerShip.Hull = 0
+	events, err := engine.Retreat(escort.ID)
+	if err != nil {
+		t.Fatalf("Retreat failed: %v", err)
+	}
+	if engine.Outcome() != OutcomeDefeat || len(events) != 2 || events[0].Type != EventRetreat {
+		t.Fatalf("Expected the retreat to end the battle in defeat, got %q %+v", engine.Outcome(), events)
+	}
+	if err := engine.SetStance(escort.ID, StanceHold); !errors.Is(err, ErrCombatOver) {
+		t.Errorf("Expected ErrCombatOver, got %v", err)
+	}
+}
+
+func TestEngineBoarding(t *testing.T) {
+	engine := NewEngine(5)
+	playerShip, playerType := newTestShip("Player", "frigate", "heavy_laser")
+	playerShip.Crew = 10
+	enemyShip, enemyType := newTestShip("Raider", "viper", "pulse_laser")
+	enemyShip.Crew = 4
+	player, _ := engine.AddCombatant(playerShip, playerType, SidePlayer, nil)
+	enemy, _ := engine.AddCombatant(enemyShip, enemyType, SideEnemy, NewAIState(AILevelMedium))
+
+	// A failed boarding costs crew but leaves the target fighting
+	events, err := engine.Board(player.ID, enemy.ID, BoardingResult{AttackerLosses: 3})
+	if err != nil {
+		t.Fatalf("Board failed: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != EventBoard || events[0].Hit {
+		t.Fatalf("Expected a single failed boarding event, got %+v", events)
+	}
+	if playerShip.Crew != 7 || !enemy.Active() || engine.Outcome() != OutcomeNone {
+		t.Fatalf("Expected crew 7 and the battle to go on, got crew %d, outcome %q", playerShip.Crew, engine.Outcome())
+	}
+
+	events, err = engine.Board(player.ID, enemy.ID, BoardingResult{Boarded: true, Captured: true, AttackerLosses: 1, DefenderLosses: 9})
+	if err != nil {
+		t.Fatalf("Board failed: %v", err)
+	}
+	if len(events) != 3 || events[1].Type != EventCapture || events[2].Type != EventVictory {
+		t.Fatalf("Expected boarding, capture and victory events, got %+v", events)
+	}
+	if !enemy.Captured || enemy.Destroyed() || enemyShip.Crew != 0 || engine.Outcome() != OutcomeVictory {
+		t.Errorf("Expected the raider to be captured intact, got %+v", enemy)
+	}
+
+	if _, err := engine.Board(player.ID, enemy.ID, BoardingResult{}); !errors.Is(err, ErrCombatOver) {
+		t.Errorf("Expected ErrCombatOver after the capture, got %v", err)
+	}
+}
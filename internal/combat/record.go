@@ -0,0 +1,154 @@
+// File: internal/combat/record.go
+// Project: Terminal Velocity
+// Description: Battle records - Serializable engine inputs and deterministic replay
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package combat
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+)
+
+// Package combat - Battle records
+//
+// A Record holds everything that went into a battle: the seed, each
+// combatant as it was when added, and every Fire, Board, SetStance, Retreat
+// and EndTurn call that succeeded. Because the engine is deterministic, Replay turns a Record back
+// into an engine in exactly the same state, including ship damage, weapon
+// cooldowns, AI morale and the event log. Records are small and encode to
+// JSON, so a battle in progress can be saved and resumed after a restart or
+// a dropped connection.
+//
+// A Record only describes the battle correctly if ships are changed solely
+// by the engine between AddCombatant and Record.
+
+// ActionType identifies a recorded engine call
+type ActionType string
+
+const (
+	ActionFire    ActionType = "fire"     // Engine.Fire
+	ActionBoard   ActionType = "board"    // Engine.Board
+	ActionStance  ActionType = "stance"   // Engine.SetStance
+	ActionRetreat ActionType = "retreat"  // Engine.Retreat
+	ActionEndTurn ActionType = "end_turn" // Engine.EndTurn
+)
+
+// Action is one recorded engine call
+type Action struct {
+	Type     ActionType      `json:"type"`
+	Attacker string          `json:"attacker,omitempty"` // Fire, Board: attacking combatant ID; SetStance, Retreat: combatant ID
+	Slot     int             `json:"slot,omitempty"`     // Fire: weapon slot
+	Target   string          `json:"target,omitempty"`   // Fire, Board: target combatant ID
+	Stance   Stance          `json:"stance,omitempty"`   // SetStance: new stance
+	Boarding *BoardingResult `json:"boarding,omitempty"` // Board: boarding outcome
+}
+
+// RecordedCombatant is a combatant as it was when added to the battle
+type RecordedCombatant struct {
+	Ship    models.Ship `json:"ship"`
+	Side    Side        `json:"side"`
+	AILevel *AILevel    `json:"ai_level,omitempty"` // nil = controlled by Fire calls
+}
+
+// Record is the serializable input of a battle
+type Record struct {
+	Seed       int64               `json:"seed"`
+	Combatants []RecordedCombatant `json:"combatants"`
+	Actions    []Action            `json:"actions"`
+}
+
+// newRecordedCombatant snapshots a ship being added to the battle
+func newRecordedCombatant(ship *models.Ship, side Side, ai *AIState) RecordedCombatant {
+	snapshot := *ship
+	snapshot.Cargo = append([]models.CargoItem(nil), ship.Cargo...)
+	snapshot.Weapons = append([]string(nil), ship.Weapons...)
+	snapshot.Outfits = append([]string(nil), ship.Outfits...)
+	snapshot.WeaponAmmo = maps.Clone(ship.WeaponAmmo)
+
+	rc := RecordedCombatant{Ship: snapshot, Side: side}
+	if ai != nil {
+		level := ai.Level
+		rc.AILevel = &level
+	}
+	return rc
+}
+
+// Record returns the battle's inputs so far. The record shares no state
+// with the engine.
+func (e *Engine) Record() *Record {
+	return &Record{
+		Seed:       e.seed,
+		Combatants: append([]RecordedCombatant(nil), e.initial...),
+		Actions:    append([]Action(nil), e.actions...),
+	}
+}
+
+// ActionCount returns the number of recorded engine calls
+func (e *Engine) ActionCount() int {
+	return len(e.actions)
+}
+
+// Replay rebuilds the engine a Record was taken from. Ship types are looked
+// up by the ships' type IDs, and AI combatants get a fresh AIState at their
+// recorded level. Returns an error if a ship type is unknown or a recorded
+// action no longer applies.
+func Replay(record *Record) (*Engine, error) {
+	e := NewEngine(record.Seed)
+
+	for i := range record.Combatants {
+		rc := &record.Combatants[i]
+
+		shipType := models.GetShipTypeByID(rc.Ship.TypeID)
+		if shipType == nil {
+			return nil, fmt.Errorf("unknown ship type %q", rc.Ship.TypeID)
+		}
+
+		var ai *AIState
+		if rc.AILevel != nil {
+			ai = NewAIState(*rc.AILevel)
+		}
+
+		ship := newRecordedCombatant(&rc.Ship, rc.Side, nil).Ship
+		if _, err := e.AddCombatant(&ship, shipType, rc.Side, ai); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, action := range record.Actions {
+		switch action.Type {
+		case ActionFire:
+			if _, err := e.Fire(action.Attacker, action.Slot, action.Target); err != nil {
+				return nil, fmt.Errorf("replay action %d: %w", i, err)
+			}
+		case ActionBoard:
+			if action.Boarding == nil {
+				return nil, fmt.Errorf("replay action %d: board action has no result", i)
+			}
+			if _, err := e.Board(action.Attacker, action.Target, *action.Boarding); err != nil {
+				return nil, fmt.Errorf("replay action %d: %w", i, err)
+			}
+		case ActionStance:
+			if err := e.SetStance(action.Attacker, action.Stance); err != nil {
+				return nil, fmt.Errorf("replay action %d: %w", i, err)
+			}
+		case ActionRetreat:
+			if _, err := e.Retreat(action.Attacker); err != nil {
+				return nil, fmt.Errorf("replay action %d: %w", i, err)
+			}
+		case ActionEndTurn:
+			if e.outcome != OutcomeNone {
+				return nil, fmt.Errorf("replay action %d: %w", i, ErrCombatOver)
+			}
+			e.EndTurn()
+		default:
+			return nil, fmt.Errorf("replay action %d: unknown action %q", i, action.Type)
+		}
+	}
+
+	return e, nil
+}
@@ -0,0 +1,135 @@
+// File: internal/combat/record_test.go
+// Project: Terminal Velocity
+// Description: Tests for battle records and replay
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package combat
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestReplayRestoresBattle(t *testing.T) {
+	original := runBattle(t, 99)
+
+	// Records survive a JSON round trip
+	data, err := json.Marshal(original.Record())
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	replayed, err := Replay(&record)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	if replayed.Outcome() != original.Outcome() || replayed.Turn() != original.Turn() {
+		t.Errorf("Expected outcome %q at turn %d, got %q at turn %d",
+			original.Outcome(), original.Turn(), replayed.Outcome(), replayed.Turn())
+	}
+	if !reflect.DeepEqual(replayed.Events(), original.Events()) {
+		t.Error("Expected identical event logs after replay")
+	}
+	for _, side := range []Side{SidePlayer, SideEnemy} {
+		for i, c := range original.Combatants(side) {
+			r := replayed.Combatants(side)[i]
+			if r.ID != c.ID || r.Ship.Hull != c.Ship.Hull || r.Ship.Shields != c.Ship.Shields {
+				t.Errorf("Combatant %s: expected hull %d shields %d, got %s hull %d shields %d",
+					c.ID, c.Ship.Hull, c.Ship.Shields, r.ID, r.Ship.Hull, r.Ship.Shields)
+			}
+		}
+	}
+}
+
+func TestReplayMidBattle(t *testing.T) {
+	engine := NewEngine(5)
+	playerShip, playerType := newTestShip("Player", "frigate", "heavy_laser")
+	enemyShip, enemyType := newTestShip("Raider", "viper", "pulse_laser")
+	engine.AddCombatant(playerShip, playerType, SidePlayer, nil)
+	engine.AddCombatant(enemyShip, enemyType, SideEnemy, NewAIState(AILevelHard))
+
+	engine.Fire(playerShip.ID.String(), 0, enemyShip.ID.String())
+	engine.EndTurn()
+
+	replayed, err := Replay(engine.Record())
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	// Both engines continue identically from here
+	engine.EndTurn()
+	replayed.EndTurn()
+	if !reflect.DeepEqual(replayed.Events(), engine.Events()) {
+		t.Error("Expected replayed battle to continue identically")
+	}
+	if replayed.ActionCount() != 3 {
+		t.Errorf("Expected 3 recorded actions, got %d", replayed.ActionCount())
+	}
+}
+
+func TestReplayStanceAndRetreat(t *testing.T) {
+	engine := NewEngine(3)
+	playerShip, playerType := newTestShip("Player", "frigate", "heavy_laser")
+	escortShip, escortType := newTestShip("Escort", "viper", "pulse_laser")
+	enemyShip, enemyType := newTestShip("Raider", "viper", "pulse_laser")
+	engine.AddCombatant(playerShip, playerType, SidePlayer, nil)
+	engine.AddCombatant(escortShip, escortType, SidePlayer, NewAIState(AILevelMedium))
+	engine.AddCombatant(enemyShip, enemyType, SideEnemy, NewAIState(AILevelMedium))
+
+	engine.SetStance(escortShip.ID.String(), StanceDefend)
+	engine.EndTurn()
+	engine.Retreat(escortShip.ID.String())
+	engine.EndTurn()
+
+	replayed, err := Replay(engine.Record())
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if !reflect.DeepEqual(replayed.Events(), engine.Events()) {
+		t.Error("Expected replayed events to match the original")
+	}
+	escort := replayed.Combatant(escortShip.ID.String())
+	if escort.Stance != StanceDefend || !escort.Retreated {
+		t.Errorf("Expected the escort's stance and retreat to be replayed, got %+v", escort)
+	}
+}
+
+func TestReplayBoarding(t *testing.T) {
+	engine := NewEngine(8)
+	playerShip, playerType := newTestShip("Player", "frigate", "heavy_laser")
+	playerShip.Crew = 10
+	enemyShip, enemyType := newTestShip("Raider", "viper", "pulse_laser")
+	engine.AddCombatant(playerShip, playerType, SidePlayer, nil)
+	engine.AddCombatant(enemyShip, enemyType, SideEnemy, NewAIState(AILevelMedium))
+
+	engine.Board(playerShip.ID.String(), enemyShip.ID.String(), BoardingResult{AttackerLosses: 2, Message: "Repelled"})
+	engine.EndTurn()
+	engine.Board(playerShip.ID.String(), enemyShip.ID.String(), BoardingResult{Boarded: true, Captured: true})
+
+	data, err := json.Marshal(engine.Record())
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	replayed, err := Replay(&record)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if !reflect.DeepEqual(replayed.Events(), engine.Events()) {
+		t.Error("Expected replayed events to match the original")
+	}
+	if !replayed.Combatant(enemyShip.ID.String()).Captured || replayed.Outcome() != OutcomeVictory {
+		t.Error("Expected the capture to be replayed")
+	}
+}
@@ -64,6 +64,7 @@ type WeaponState struct {
 //   - ShieldDamage: Damage absorbed by shields
 //   - HullDamage: Damage applied to hull (penetrating or shield overflow)
 //   - CriticalHit: Whether this was a critical hit (1.5x damage)
+//   - HitChance: Hit chance percentage (5-95) the shot was rolled against
 //   - AmmoRemaining: Remaining ammo after firing (for missile weapons)
 //   - Message: Human-readable combat log message
 type FireResult struct {
@@ -72,6 +73,7 @@ type FireResult struct {
 	ShieldDamage  int
 	HullDamage    int
 	CriticalHit   bool
+	HitChance     float64
 	AmmoRemaining int
 	Message       string
 }
@@ -162,6 +164,15 @@ func CanFire(weapon *models.Weapon, state *WeaponState, ship *models.Ship, shipT
 func Fire(weapon *models.Weapon, state *WeaponState, attacker *models.Ship, target *models.Ship,
 	attackerType *models.ShipType, targetType *models.ShipType, distance int) FireResult {
 
+	return fire(nil, weapon, state, attacker, target, attackerType, targetType, distance, 1.0)
+}
+
+// fire implements Fire with an explicit random source (nil = global source)
+// and an accuracy modifier applied to the hit chance (1.0 = none, see
+// ApplyAIAccuracyModifier). The Engine uses it to make battles reproducible.
+func fire(rng *rand.Rand, weapon *models.Weapon, state *WeaponState, attacker *models.Ship, target *models.Ship,
+	attackerType *models.ShipType, targetType *models.ShipType, distance int, accuracy float64) FireResult {
+
 	result := FireResult{}
 
 	// Check if can fire
@@ -172,8 +183,9 @@ func Fire(weapon *models.Weapon, state *WeaponState, attacker *models.Ship, targ
 	}
 
 	// Calculate hit chance
-	hitChance := CalculateHitChance(weapon, attackerType, targetType, distance)
-	roll := rand.Float64() * 100
+	hitChance := clampHitChance(CalculateHitChance(weapon, attackerType, targetType, distance) * accuracy)
+	roll := randFloat(rng) * 100
+	result.HitChance = hitChance
 
 	if roll > hitChance {
 		// Miss
@@ -187,7 +199,7 @@ func Fire(weapon *models.Weapon, state *WeaponState, attacker *models.Ship, targ
 		baseDamage := weapon.Damage
 
 		// Critical hit check (10% chance)
-		if rand.Float64() < 0.1 {
+		if randFloat(rng) < 0.1 {
 			result.CriticalHit = true
 			baseDamage = int(float64(baseDamage) * 1.5)
 		}
@@ -310,17 +322,29 @@ func CalculateHitChance(weapon *models.Weapon, attackerType *models.ShipType,
 	// Final hit chance calculation
 	hitChance := baseAccuracy - rangePenalty - evasionBonus + attackerBonus
 
-	// Clamp between 5% and 95%
+	return clampHitChance(hitChance)
+}
+
+// clampHitChance clamps a hit chance between 5% and 95%
+func clampHitChance(hitChance float64) float64 {
 	if hitChance < 5.0 {
-		hitChance = 5.0
+		return 5.0
 	}
 	if hitChance > 95.0 {
-		hitChance = 95.0
+		return 95.0
 	}
-
 	return hitChance
 }
 
+// randFloat returns a float in [0.0, 1.0) from rng, or from the global
+// source if rng is nil
+func randFloat(rng *rand.Rand) float64 {
+	if rng == nil {
+		return rand.Float64()
+	}
+	return rng.Float64()
+}
+
 // UpdateCooldowns decrements all weapon cooldowns based on elapsed time.
 //
 // This function should be called each turn or frame to advance weapon cooldown timers.
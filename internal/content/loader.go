@@ -0,0 +1,103 @@
+// File: internal/content/loader.go
+// Project: Terminal Velocity
+// Description: Loads the configured content pack at startup and on admin reload
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package content
+
+import (
+	"sync"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+)
+
+var log = logger.WithComponent("Content")
+
+// Status describes the active content pack
+type Status struct {
+	Path        string // Pack file ("" = built-in pack)
+	Name        string
+	Version     string
+	ShipTypes   int
+	Commodities int
+	Weapons     int
+	Outfits     int
+	Equipment   int
+	LoadedAt    time.Time
+}
+
+// Loader installs the server's content pack.
+//
+// Load reads the configured file, validates it and makes it the active
+// pack; calling it again hot-reloads the file. A pack that fails to load or
+// validate is never installed, so a bad edit leaves the running pack in
+// place. With no file configured the built-in pack is used.
+//
+// Ships, markets and loadouts reference content by ID, so a reload that
+// removes an ID still in use leaves those references unresolved (lookups
+// return nil, as for any unknown ID). Rebalancing existing IDs is safe.
+//
+// Thread-safety:
+//   - All methods are thread-safe
+type Loader struct {
+	mu       sync.Mutex
+	path     string
+	loadedAt time.Time
+}
+
+// NewLoader creates a loader for the pack file at path ("" for the built-in pack)
+func NewLoader(path string) *Loader {
+	return &Loader{path: path}
+}
+
+// Load reads, validates and installs the configured content pack
+func (l *Loader) Load() (Status, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	pack := models.DefaultContentPack()
+	if l.path != "" {
+		loaded, err := LoadFile(l.path)
+		if err != nil {
+			log.Error("Failed to load content pack %s: %v", l.path, err)
+			return Status{}, err
+		}
+		pack = loaded
+	}
+
+	models.SetContentPack(pack)
+	l.loadedAt = time.Now()
+
+	status := l.statusLocked()
+	log.Info("Loaded content pack %s %s: %d ship types, %d commodities, %d weapons, %d outfits, %d equipment",
+		status.Name, status.Version, status.ShipTypes, status.Commodities, status.Weapons, status.Outfits, status.Equipment)
+	return status, nil
+}
+
+// Status describes the active content pack
+func (l *Loader) Status() Status {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.statusLocked()
+}
+
+// statusLocked builds the status (must be called with lock held)
+func (l *Loader) statusLocked() Status {
+	pack := models.ActiveContentPack()
+	return Status{
+		Path:        l.path,
+		Name:        pack.Name,
+		Version:     pack.Version,
+		ShipTypes:   len(pack.ShipTypes),
+		Commodities: len(pack.Commodities),
+		Weapons:     len(pack.Weapons),
+		Outfits:     len(pack.Outfits),
+		Equipment:   len(pack.Equipment),
+		LoadedAt:    l.loadedAt,
+	}
+}
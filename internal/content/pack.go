@@ -0,0 +1,197 @@
+// File: internal/content/pack.go
+// Project: Terminal Velocity
+// Description: Content pack files - JSON/YAML ship, commodity, weapon, outfit and equipment definitions
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// A content pack file holds the game's balance data so it can be tuned
+// without recompiling. The format mirrors models.ContentPack, using the
+// models' JSON field names in both JSON and YAML files:
+//
+//	name: spring-balance
+//	version: "2"
+//	commodities:
+//	  - id: food
+//	    name: Food
+//	    base_price: 110
+//	    category: food
+//	    tech_level: 1
+//	    ...
+//
+// Sections left out of a file keep the built-in definitions, so a pack that
+// only rebalances commodities needs only a commodities section. A section
+// that is present replaces the built-in one entirely. Unknown fields are
+// rejected, and every pack is validated (see validate.go) before it can be
+// installed. ExportDefault writes the built-in pack as a starting point.
+
+package content
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Content pack errors.
+var (
+	// ErrUnknownFileFormat indicates a file extension that is neither JSON nor YAML.
+	ErrUnknownFileFormat = errors.New("unknown content pack format")
+
+	// ErrInvalidPack indicates a pack with missing or out-of-range values,
+	// duplicate IDs or references to definitions that do not exist.
+	ErrInvalidPack = errors.New("invalid content pack")
+)
+
+// FileFormat selects the encoding of a content pack file
+type FileFormat string
+
+const (
+	FormatJSON FileFormat = "json"
+	FormatYAML FileFormat = "yaml"
+)
+
+// FormatForPath picks the file format from a path's extension
+func FormatForPath(path string) (FileFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownFileFormat, path)
+	}
+}
+
+// Decode reads a content pack in either format, fills sections the file
+// leaves out from the built-in pack and validates the result.
+//
+// JSON is recognised by its leading brace; anything else is parsed as YAML.
+// YAML is converted to JSON before decoding so both formats share the
+// models' field names and the unknown field check.
+func Decode(r io.Reader) (*models.ContentPack, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content pack: %w", err)
+	}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var doc interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse content pack: %w", err)
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("failed to parse content pack: %w", err)
+		}
+	}
+
+	var pack models.ContentPack
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&pack); err != nil {
+		return nil, fmt.Errorf("failed to parse content pack: %w", err)
+	}
+
+	fillDefaults(&pack)
+	if err := Validate(&pack); err != nil {
+		return nil, err
+	}
+	return &pack, nil
+}
+
+// fillDefaults copies the built-in definitions into sections the file left out
+func fillDefaults(pack *models.ContentPack) {
+	builtin := models.DefaultContentPack()
+	if pack.ShipTypes == nil {
+		pack.ShipTypes = builtin.ShipTypes
+	}
+	if pack.Commodities == nil {
+		pack.Commodities = builtin.Commodities
+	}
+	if pack.Weapons == nil {
+		pack.Weapons = builtin.Weapons
+	}
+	if pack.Outfits == nil {
+		pack.Outfits = builtin.Outfits
+	}
+	if pack.Equipment == nil {
+		pack.Equipment = builtin.Equipment
+	}
+}
+
+// LoadFile reads and validates a content pack from path
+func LoadFile(path string) (*models.ContentPack, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open content pack: %w", err)
+	}
+	defer in.Close()
+
+	return Decode(in)
+}
+
+// Encode writes a content pack in the given format
+func Encode(w io.Writer, pack *models.ContentPack, format FileFormat) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(pack)
+	case FormatYAML:
+		// Round trip through JSON so YAML uses the same field names. JSON
+		// is valid YAML, so parsing it into a node keeps the field order.
+		data, err := json.Marshal(pack)
+		if err != nil {
+			return err
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		blockStyle(&doc)
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(&doc); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFileFormat, format)
+	}
+}
+
+// blockStyle clears the JSON flow style from a parsed node tree so it
+// encodes as ordinary block YAML
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// ExportDefault writes the built-in content pack to path, choosing the
+// format from the extension
+func ExportDefault(path string) error {
+	format, err := FormatForPath(path)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create content pack: %w", err)
+	}
+	if err := Encode(out, models.DefaultContentPack(), format); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to write content pack: %w", err)
+	}
+	return out.Close()
+}
@@ -0,0 +1,147 @@
+// File: internal/content/pack_test.go
+// Project: Terminal Velocity
+// Description: Tests for content pack files, validation and reloading
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package content
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+)
+
+func TestDefaultPackRoundTrip(t *testing.T) {
+	builtin := models.DefaultContentPack()
+	if err := Validate(builtin); err != nil {
+		t.Fatalf("Built-in pack failed validation: %v", err)
+	}
+
+	for _, format := range []FileFormat{FormatJSON, FormatYAML} {
+		var buf bytes.Buffer
+		if err := Encode(&buf, builtin, format); err != nil {
+			t.Fatalf("Encode %s failed: %v", format, err)
+		}
+		decoded, err := Decode(&buf)
+		if err != nil {
+			t.Fatalf("Decode %s failed: %v", format, err)
+		}
+		if !reflect.DeepEqual(decoded, builtin) {
+			t.Errorf("Expected %s round trip to reproduce the built-in pack", format)
+		}
+	}
+}
+
+func TestDecodePartialPack(t *testing.T) {
+	pack, err := Decode(strings.NewReader(`
+name: rebalance
+version: "2"
+commodities:
+  - id: food
+    name: Food
+    base_price: 999
+    category: food
+    tech_level: 1
+`))
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	if pack.Version != "2" || len(pack.Commodities) != 1 || pack.Commodities[0].BasePrice != 999 {
+		t.Errorf("Expected the file's commodities, got %+v", pack.Commodities)
+	}
+	if len(pack.ShipTypes) != len(models.StandardShipTypes) || len(pack.Weapons) != len(models.StandardWeapons) {
+		t.Error("Expected omitted sections to keep the built-in definitions")
+	}
+}
+
+func TestDecodeRejectsInvalidPacks(t *testing.T) {
+	tests := []struct {
+		name string
+		pack string
+	}{
+		{"unknown field", `{"name": "x", "colour": "red"}`},
+		{"tech level out of range", `
+name: x
+commodities:
+  - {id: food, name: Food, base_price: 100, category: food, tech_level: 11}
+`},
+		{"duplicate id", `
+name: x
+commodities:
+  - {id: food, name: Food, base_price: 100, category: food, tech_level: 1}
+  - {id: food, name: Food, base_price: 120, category: food, tech_level: 1}
+`},
+		{"unknown weapon", `
+name: x
+ship_types:
+  - {id: raider, name: Raider, price: 1000, max_hull: 100, max_fuel: 100, max_crew: 1,
+     speed: 5, weapon_slots: 2, class: fighter, npc_weapons: [pulse_laser, death_ray]}
+`},
+		{"missing name", `{"weapons": []}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(strings.NewReader(tt.pack)); err == nil {
+				t.Error("Expected the pack to be rejected")
+			}
+		})
+	}
+
+	_, err := Decode(strings.NewReader(`{"name": "x", "weapons": [{"id": "laser", "name": "Laser", "type": "phaser"}]}`))
+	if !errors.Is(err, ErrInvalidPack) {
+		t.Errorf("Expected ErrInvalidPack, got %v", err)
+	}
+}
+
+func TestLoaderReload(t *testing.T) {
+	t.Cleanup(func() { models.SetContentPack(models.DefaultContentPack()) })
+
+	path := filepath.Join(t.TempDir(), "content.yaml")
+	writePack := func(basePrice string) {
+		t.Helper()
+		pack := "name: test\ncommodities:\n  - {id: food, name: Food, base_price: " + basePrice + ", category: food, tech_level: 1}\n"
+		if err := os.WriteFile(path, []byte(pack), 0o644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	loader := NewLoader(path)
+	writePack("150")
+	if _, err := loader.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if food := models.GetCommodityByID("food"); food == nil || food.BasePrice != 150 {
+		t.Fatalf("Expected the loaded food price, got %+v", food)
+	}
+	if models.GetCommodityByID("water") != nil {
+		t.Error("Expected commodities missing from the pack to be gone")
+	}
+
+	// A bad edit is rejected and the running pack stays in place
+	writePack("-5")
+	if _, err := loader.Load(); !errors.Is(err, ErrInvalidPack) {
+		t.Fatalf("Expected ErrInvalidPack, got %v", err)
+	}
+	if food := models.GetCommodityByID("food"); food == nil || food.BasePrice != 150 {
+		t.Errorf("Expected the previous pack to stay active, got %+v", food)
+	}
+
+	writePack("175")
+	status, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if status.Name != "test" || status.Commodities != 1 || models.GetCommodityByID("food").BasePrice != 175 {
+		t.Errorf("Expected the reloaded pack, got %+v", status)
+	}
+}
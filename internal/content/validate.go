@@ -0,0 +1,194 @@
+// File: internal/content/validate.go
+// Project: Terminal Velocity
+// Description: Content pack validation - required fields, value ranges and cross-references
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package content
+
+import (
+	"fmt"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+)
+
+// Tech level range for commodities and equipment
+const (
+	MinTechLevel = 1
+	MaxTechLevel = 10
+)
+
+// Values the game code gives meaning to. Packs may only use these.
+var (
+	weaponTypes  = stringSet("laser", "missile", "plasma", "railgun")
+	weaponRanges = stringSet("short", "medium", "long")
+	outfitTypes  = stringSet("shield_booster", "hull_reinforcement", "cargo_pod", "fuel_tank", "engine", "mining_laser", "scanner")
+	rarities     = stringSet("common", "uncommon", "rare", "military", "experimental")
+
+	commodityCategories = stringSet(
+		models.CategoryFood, models.CategoryElectronics, models.CategoryWeapons, models.CategoryLuxuries,
+		models.CategoryIndustrial, models.CategoryMedical, models.CategoryOre, models.CategoryContraband,
+	)
+	equipmentCategories = stringSet(
+		string(models.CategoryWeapon), string(models.CategoryDefense), string(models.CategoryPower),
+		string(models.CategoryPropulsion), string(models.CategoryUtility), string(models.CategorySpecial),
+	)
+	slotTypes = stringSet(
+		string(models.SlotWeapon), string(models.SlotShield), string(models.SlotEngine),
+		string(models.SlotReactor), string(models.SlotUtility), string(models.SlotSpecial),
+	)
+)
+
+func stringSet(values ...string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// Validate checks that a content pack is complete and consistent: every
+// section has entries with unique IDs, values are in range, enumerations
+// use values the game understands and references resolve within the pack.
+// It reports the first problem found, wrapped in ErrInvalidPack.
+func Validate(pack *models.ContentPack) error {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrInvalidPack, fmt.Sprintf(format, args...))
+	}
+
+	if pack.Name == "" {
+		return invalid("missing pack name")
+	}
+	if len(pack.ShipTypes) == 0 || len(pack.Commodities) == 0 || len(pack.Weapons) == 0 {
+		return invalid("ship_types, commodities and weapons must not be empty")
+	}
+
+	weapons := make(map[string]bool)
+	for _, w := range pack.Weapons {
+		if w.ID == "" || weapons[w.ID] {
+			return invalid("empty or duplicate weapon id %q", w.ID)
+		}
+		if w.Name == "" {
+			return invalid("weapon %s has no name", w.ID)
+		}
+		if !weaponTypes[w.Type] {
+			return invalid("weapon %s has unknown type %q", w.ID, w.Type)
+		}
+		if !weaponRanges[w.Range] {
+			return invalid("weapon %s has unknown range %q", w.ID, w.Range)
+		}
+		if w.Damage <= 0 || w.RangeValue <= 0 {
+			return invalid("weapon %s needs positive damage and range_value", w.ID)
+		}
+		if w.Accuracy < 1 || w.Accuracy > 100 {
+			return invalid("weapon %s accuracy %d out of range 1-100", w.ID, w.Accuracy)
+		}
+		if w.ShieldPenetration < 0 || w.ShieldPenetration > 1 {
+			return invalid("weapon %s shield_penetration %.2f out of range 0-1", w.ID, w.ShieldPenetration)
+		}
+		if w.Type == "missile" && w.AmmoCapacity <= 0 {
+			return invalid("missile weapon %s needs ammo_capacity", w.ID)
+		}
+		if w.Price < 0 || w.OutfitSpace < 0 || w.Cooldown < 0 || w.EnergyCost < 0 ||
+			w.AmmoCapacity < 0 || w.AmmoConsumption < 0 || w.ProjectileSpeed < 0 {
+			return invalid("weapon %s has negative values", w.ID)
+		}
+		weapons[w.ID] = true
+	}
+
+	outfits := make(map[string]bool)
+	for _, o := range pack.Outfits {
+		if o.ID == "" || outfits[o.ID] {
+			return invalid("empty or duplicate outfit id %q", o.ID)
+		}
+		if o.Name == "" {
+			return invalid("outfit %s has no name", o.ID)
+		}
+		if !outfitTypes[o.Type] {
+			return invalid("outfit %s has unknown type %q", o.ID, o.Type)
+		}
+		if o.Price < 0 || o.OutfitSpace < 0 || o.ShieldBonus < 0 || o.HullBonus < 0 ||
+			o.CargoBonus < 0 || o.FuelBonus < 0 || o.SpeedBonus < 0 || o.MiningBonus < 0 || o.ScannerBonus < 0 {
+			return invalid("outfit %s has negative values", o.ID)
+		}
+		outfits[o.ID] = true
+	}
+
+	shipTypes := make(map[string]bool)
+	for _, s := range pack.ShipTypes {
+		if s.ID == "" || shipTypes[s.ID] {
+			return invalid("empty or duplicate ship type id %q", s.ID)
+		}
+		if s.Name == "" || s.Class == "" {
+			return invalid("ship type %s needs a name and class", s.ID)
+		}
+		if s.Price <= 0 || s.MaxHull <= 0 || s.MaxFuel <= 0 || s.MaxCrew <= 0 || s.Speed <= 0 {
+			return invalid("ship type %s needs positive price, max_hull, max_fuel, max_crew and speed", s.ID)
+		}
+		if s.MaxShields < 0 || s.ShieldRegen < 0 || s.CargoSpace < 0 || s.Maneuverability < 0 ||
+			s.WeaponSlots < 0 || s.OutfitSpace < 0 || s.MinCombatRating < 0 {
+			return invalid("ship type %s has negative values", s.ID)
+		}
+		if len(s.NPCWeapons) > s.WeaponSlots {
+			return invalid("ship type %s has %d npc_weapons but %d weapon slots", s.ID, len(s.NPCWeapons), s.WeaponSlots)
+		}
+		for _, weaponID := range s.NPCWeapons {
+			if !weapons[weaponID] {
+				return invalid("ship type %s references unknown weapon %q", s.ID, weaponID)
+			}
+		}
+		shipTypes[s.ID] = true
+	}
+
+	commodities := make(map[string]bool)
+	for _, c := range pack.Commodities {
+		if c.ID == "" || commodities[c.ID] {
+			return invalid("empty or duplicate commodity id %q", c.ID)
+		}
+		if c.Name == "" {
+			return invalid("commodity %s has no name", c.ID)
+		}
+		if !commodityCategories[c.Category] {
+			return invalid("commodity %s has unknown category %q", c.ID, c.Category)
+		}
+		if c.BasePrice <= 0 {
+			return invalid("commodity %s needs a positive base_price", c.ID)
+		}
+		if c.TechLevel < MinTechLevel || c.TechLevel > MaxTechLevel {
+			return invalid("commodity %s tech level %d out of range %d-%d", c.ID, c.TechLevel, MinTechLevel, MaxTechLevel)
+		}
+		commodities[c.ID] = true
+	}
+
+	equipment := make(map[string]bool)
+	for _, e := range pack.Equipment {
+		if e.ID == "" || equipment[e.ID] {
+			return invalid("empty or duplicate equipment id %q", e.ID)
+		}
+		if e.Name == "" {
+			return invalid("equipment %s has no name", e.ID)
+		}
+		if !equipmentCategories[string(e.Category)] {
+			return invalid("equipment %s has unknown category %q", e.ID, e.Category)
+		}
+		if !slotTypes[string(e.SlotType)] {
+			return invalid("equipment %s has unknown slot type %q", e.ID, e.SlotType)
+		}
+		if e.SlotSize < 1 || e.SlotSize > 4 {
+			return invalid("equipment %s slot size %d out of range 1-4", e.ID, e.SlotSize)
+		}
+		if e.MinTechLevel < MinTechLevel || e.MinTechLevel > MaxTechLevel {
+			return invalid("equipment %s tech level %d out of range %d-%d", e.ID, e.MinTechLevel, MinTechLevel, MaxTechLevel)
+		}
+		if e.Rarity != "" && !rarities[e.Rarity] {
+			return invalid("equipment %s has unknown rarity %q", e.ID, e.Rarity)
+		}
+		if e.Price < 0 || e.OutfitSpace < 0 {
+			return invalid("equipment %s has negative values", e.ID)
+		}
+		equipment[e.ID] = true
+	}
+
+	return nil
+}
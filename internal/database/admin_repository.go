@@ -0,0 +1,501 @@
+// File: internal/database/admin_repository.go
+// Project: Terminal Velocity
+// Description: Repository for admin users, player bans, mutes, the admin audit log and server settings
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// Moderation errors.
+var (
+	// ErrBanNotFound indicates the player has no active, unexpired ban.
+	ErrBanNotFound = errors.New("ban not found")
+
+	// ErrServerSettingsNotFound indicates no settings have been saved yet.
+	ErrServerSettingsNotFound = errors.New("server settings not found")
+)
+
+// AdminRepository handles all database operations for server moderation.
+//
+// Tables:
+//   - admin_users: players with an admin role (one row per player)
+//   - player_bans: bans with optional expiry (NULL expires_at = permanent)
+//   - player_mutes: chat mutes, always with an expiry
+//   - admin_actions: append-only audit log
+//   - server_settings: settings edited in the admin panel (single JSON row)
+//
+// Bans and mutes are never deleted; lifting one clears is_active so the
+// history stays available. A uuid.Nil admin is stored as NULL and stands for
+// actions taken by the server itself.
+//
+// Thread-safety:
+//   - All methods are thread-safe
+type AdminRepository struct {
+	db *DB // Database connection pool
+}
+
+// NewAdminRepository creates a new admin repository
+func NewAdminRepository(db *DB) *AdminRepository {
+	return &AdminRepository{db: db}
+}
+
+// ============================================================================
+// ADMIN USERS
+// ============================================================================
+
+// UpsertAdmin creates or updates the admin record for a player
+func (r *AdminRepository) UpsertAdmin(ctx context.Context, admin *models.AdminUser) error {
+	query := `
+		INSERT INTO admin_users (id, player_id, username, role, permissions, created_at, created_by, last_active, is_active)
+		VALUES ($1, $2, $3, $4, string_to_array($5, ','), $6, $7, $8, $9)
+		ON CONFLICT (player_id) DO UPDATE SET
+			username = EXCLUDED.username,
+			role = EXCLUDED.role,
+			permissions = EXCLUDED.permissions,
+			is_active = EXCLUDED.is_active
+	`
+
+	perms := make([]string, len(admin.Permissions))
+	for i, p := range admin.Permissions {
+		perms[i] = string(p)
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		admin.ID,
+		admin.PlayerID,
+		admin.Username,
+		string(admin.Role),
+		strings.Join(perms, ","),
+		admin.CreatedAt,
+		nullableUUID(admin.CreatedBy),
+		admin.LastActive,
+		admin.IsActive,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save admin: %w", err)
+	}
+
+	return nil
+}
+
+// ListActiveAdmins returns every active admin
+func (r *AdminRepository) ListActiveAdmins(ctx context.Context) ([]*models.AdminUser, error) {
+	query := `
+		SELECT id, player_id, username, role, array_to_string(permissions, ','),
+		       created_at, created_by, last_active, is_active
+		FROM admin_users
+		WHERE is_active = true
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query admins: %w", err)
+	}
+	defer rows.Close()
+
+	var admins []*models.AdminUser
+	for rows.Next() {
+		var admin models.AdminUser
+		var role, perms string
+		var createdBy sql.NullString
+
+		if err := rows.Scan(
+			&admin.ID,
+			&admin.PlayerID,
+			&admin.Username,
+			&role,
+			&perms,
+			&admin.CreatedAt,
+			&createdBy,
+			&admin.LastActive,
+			&admin.IsActive,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan admin: %w", err)
+		}
+
+		admin.Role = models.AdminRole(role)
+		admin.CreatedBy = uuidFromNull(createdBy)
+		for _, p := range strings.Split(perms, ",") {
+			if p != "" {
+				admin.Permissions = append(admin.Permissions, models.AdminPermission(p))
+			}
+		}
+		admins = append(admins, &admin)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating admins: %w", err)
+	}
+
+	return admins, nil
+}
+
+// DeactivateAdmin revokes a player's admin access
+func (r *AdminRepository) DeactivateAdmin(ctx context.Context, playerID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE admin_users SET is_active = false WHERE player_id = $1`, playerID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate admin: %w", err)
+	}
+	return nil
+}
+
+// ============================================================================
+// BANS
+// ============================================================================
+
+// CreateBan stores a ban, replacing any ban already active for the player
+func (r *AdminRepository) CreateBan(ctx context.Context, ban *models.PlayerBan) error {
+	return r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE player_bans SET is_active = false WHERE player_id = $1 AND is_active = true`,
+			ban.PlayerID,
+		); err != nil {
+			return fmt.Errorf("failed to replace existing ban: %w", err)
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO player_bans (id, player_id, username, ip_address, reason, banned_by, banned_at, expires_at, is_permanent, is_active)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`,
+			ban.ID,
+			ban.PlayerID,
+			ban.Username,
+			ban.IPAddress,
+			ban.Reason,
+			nullableUUID(ban.BannedBy),
+			ban.BannedAt,
+			ban.ExpiresAt,
+			ban.IsPermanent,
+			ban.IsActive,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create ban: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeactivateBans lifts every active ban on a player
+func (r *AdminRepository) DeactivateBans(ctx context.Context, playerID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE player_bans SET is_active = false WHERE player_id = $1 AND is_active = true`,
+		playerID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to lift ban: %w", err)
+	}
+	return nil
+}
+
+// GetActiveBan returns the player's current ban, or ErrBanNotFound
+func (r *AdminRepository) GetActiveBan(ctx context.Context, playerID uuid.UUID) (*models.PlayerBan, error) {
+	query := `
+		SELECT id, player_id, username, COALESCE(ip_address, ''), reason, banned_by,
+		       banned_at, expires_at, is_permanent, is_active
+		FROM player_bans
+		WHERE player_id = $1 AND is_active = true
+		  AND (is_permanent = true OR expires_at > $2)
+		ORDER BY banned_at DESC
+		LIMIT 1
+	`
+
+	ban, err := scanBan(r.db.QueryRowContext(ctx, query, playerID, time.Now()))
+	if err == sql.ErrNoRows {
+		return nil, ErrBanNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ban: %w", err)
+	}
+	return ban, nil
+}
+
+// ListActiveBans returns every active, unexpired ban
+func (r *AdminRepository) ListActiveBans(ctx context.Context) ([]*models.PlayerBan, error) {
+	query := `
+		SELECT id, player_id, username, COALESCE(ip_address, ''), reason, banned_by,
+		       banned_at, expires_at, is_permanent, is_active
+		FROM player_bans
+		WHERE is_active = true AND (is_permanent = true OR expires_at > $1)
+		ORDER BY banned_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bans: %w", err)
+	}
+	defer rows.Close()
+
+	var bans []*models.PlayerBan
+	for rows.Next() {
+		ban, err := scanBan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ban: %w", err)
+		}
+		bans = append(bans, ban)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bans: %w", err)
+	}
+
+	return bans, nil
+}
+
+// scanBan scans one player_bans row
+func scanBan(row interface{ Scan(...interface{}) error }) (*models.PlayerBan, error) {
+	var ban models.PlayerBan
+	var bannedBy sql.NullString
+	var expiresAt sql.NullTime
+
+	if err := row.Scan(
+		&ban.ID,
+		&ban.PlayerID,
+		&ban.Username,
+		&ban.IPAddress,
+		&ban.Reason,
+		&bannedBy,
+		&ban.BannedAt,
+		&expiresAt,
+		&ban.IsPermanent,
+		&ban.IsActive,
+	); err != nil {
+		return nil, err
+	}
+
+	ban.BannedBy = uuidFromNull(bannedBy)
+	if expiresAt.Valid {
+		ban.ExpiresAt = &expiresAt.Time
+	}
+	return &ban, nil
+}
+
+// ============================================================================
+// MUTES
+// ============================================================================
+
+// CreateMute stores a mute, replacing any mute already active for the player
+func (r *AdminRepository) CreateMute(ctx context.Context, mute *models.PlayerMute) error {
+	return r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE player_mutes SET is_active = false WHERE player_id = $1 AND is_active = true`,
+			mute.PlayerID,
+		); err != nil {
+			return fmt.Errorf("failed to replace existing mute: %w", err)
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO player_mutes (id, player_id, username, reason, muted_by, muted_at, expires_at, is_active)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`,
+			mute.ID,
+			mute.PlayerID,
+			mute.Username,
+			mute.Reason,
+			nullableUUID(mute.MutedBy),
+			mute.MutedAt,
+			mute.ExpiresAt,
+			mute.IsActive,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create mute: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeactivateMutes lifts every active mute on a player
+func (r *AdminRepository) DeactivateMutes(ctx context.Context, playerID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE player_mutes SET is_active = false WHERE player_id = $1 AND is_active = true`,
+		playerID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to lift mute: %w", err)
+	}
+	return nil
+}
+
+// ListActiveMutes returns every active, unexpired mute
+func (r *AdminRepository) ListActiveMutes(ctx context.Context) ([]*models.PlayerMute, error) {
+	query := `
+		SELECT id, player_id, username, reason, muted_by, muted_at, expires_at, is_active
+		FROM player_mutes
+		WHERE is_active = true AND expires_at > $1
+		ORDER BY muted_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query mutes: %w", err)
+	}
+	defer rows.Close()
+
+	var mutes []*models.PlayerMute
+	for rows.Next() {
+		var mute models.PlayerMute
+		var mutedBy sql.NullString
+		if err := rows.Scan(
+			&mute.ID,
+			&mute.PlayerID,
+			&mute.Username,
+			&mute.Reason,
+			&mutedBy,
+			&mute.MutedAt,
+			&mute.ExpiresAt,
+			&mute.IsActive,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan mute: %w", err)
+		}
+		mute.MutedBy = uuidFromNull(mutedBy)
+		mutes = append(mutes, &mute)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating mutes: %w", err)
+	}
+
+	return mutes, nil
+}
+
+// ============================================================================
+// AUDIT LOG
+// ============================================================================
+
+// LogAction appends an entry to the admin audit log
+func (r *AdminRepository) LogAction(ctx context.Context, action *models.AdminAction) error {
+	query := `
+		INSERT INTO admin_actions (id, admin_id, admin_name, action, target_id, target_name,
+		                           details, timestamp, ip_address, success, error_msg)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		action.ID,
+		nullableUUID(action.AdminID),
+		action.AdminName,
+		action.Action,
+		nullableUUID(action.TargetID),
+		action.TargetName,
+		action.Details,
+		action.Timestamp,
+		action.IPAddress,
+		action.Success,
+		action.ErrorMsg,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to log admin action: %w", err)
+	}
+
+	return nil
+}
+
+// ListRecentActions returns up to limit audit entries, oldest first
+func (r *AdminRepository) ListRecentActions(ctx context.Context, limit int) ([]*models.AdminAction, error) {
+	query := `
+		SELECT id, admin_id, admin_name, action, target_id, COALESCE(target_name, ''),
+		       COALESCE(details, ''), timestamp, COALESCE(ip_address, ''), success, COALESCE(error_msg, '')
+		FROM (
+			SELECT * FROM admin_actions ORDER BY timestamp DESC LIMIT $1
+		) recent
+		ORDER BY timestamp ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query admin actions: %w", err)
+	}
+	defer rows.Close()
+
+	var actions []*models.AdminAction
+	for rows.Next() {
+		var action models.AdminAction
+		var adminID, targetID sql.NullString
+		if err := rows.Scan(
+			&action.ID,
+			&adminID,
+			&action.AdminName,
+			&action.Action,
+			&targetID,
+			&action.TargetName,
+			&action.Details,
+			&action.Timestamp,
+			&action.IPAddress,
+			&action.Success,
+			&action.ErrorMsg,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan admin action: %w", err)
+		}
+		action.AdminID = uuidFromNull(adminID)
+		action.TargetID = uuidFromNull(targetID)
+		actions = append(actions, &action)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating admin actions: %w", err)
+	}
+
+	return actions, nil
+}
+
+// ============================================================================
+// SERVER SETTINGS
+// ============================================================================
+
+// GetServerSettings returns the settings saved from the admin panel.
+// Fields that were never saved keep their defaults. Returns
+// ErrServerSettingsNotFound if nothing has been saved yet.
+func (r *AdminRepository) GetServerSettings(ctx context.Context) (*models.ServerSettings, error) {
+	var data []byte
+	err := r.db.QueryRowContext(ctx, `SELECT settings FROM server_settings WHERE id = 1`).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, ErrServerSettingsNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server settings: %w", err)
+	}
+
+	settings := models.GetDefaultServerSettings()
+	if err := json.Unmarshal(data, settings); err != nil {
+		return nil, fmt.Errorf("failed to decode server settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SaveServerSettings stores the server settings
+func (r *AdminRepository) SaveServerSettings(ctx context.Context, settings *models.ServerSettings, updatedBy uuid.UUID) error {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to encode server settings: %w", err)
+	}
+
+	query := `
+		INSERT INTO server_settings (id, settings, updated_at, updated_by)
+		VALUES (1, $1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET
+			settings = EXCLUDED.settings,
+			updated_at = EXCLUDED.updated_at,
+			updated_by = EXCLUDED.updated_by
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, data, time.Now(), nullableUUID(updatedBy)); err != nil {
+		return fmt.Errorf("failed to save server settings: %w", err)
+	}
+
+	return nil
+}
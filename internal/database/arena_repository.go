@@ -0,0 +1,236 @@
+// File: internal/database/arena_repository.go
+// Project: Terminal Velocity
+// Description: Repository for arena rankings, match history, tournaments and seasons
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+)
+
+// ArenaRepository handles database operations for the ranked arena.
+//
+// Tables:
+//   - arena_rankings: one ranking per player (JSONB), indexed by ELO
+//   - arena_matches: live and finished matches (JSONB)
+//   - arena_tournaments: tournaments with their brackets (JSONB)
+//   - arena_seasons: ranked seasons; the highest number is current
+//
+// The arena manager keeps everything in memory and saves a record after
+// every change, so the Save methods are upserts.
+//
+// Thread-safety:
+//   - All methods are thread-safe
+type ArenaRepository struct {
+	db *DB // Database connection pool
+}
+
+// NewArenaRepository creates a new arena repository
+func NewArenaRepository(db *DB) *ArenaRepository {
+	return &ArenaRepository{db: db}
+}
+
+// SaveRanking inserts or replaces a player's ranking
+func (r *ArenaRepository) SaveRanking(ctx context.Context, ranking *models.ArenaRanking) error {
+	data, err := json.Marshal(ranking)
+	if err != nil {
+		return fmt.Errorf("failed to encode ranking: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO arena_rankings (player_id, elo, data)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (player_id) DO UPDATE SET
+			elo = EXCLUDED.elo,
+			data = EXCLUDED.data
+	`, ranking.PlayerID, ranking.ELO, data)
+	if err != nil {
+		return fmt.Errorf("failed to save ranking: %w", err)
+	}
+	return nil
+}
+
+// ListRankings returns every ranking, highest ELO first
+func (r *ArenaRepository) ListRankings(ctx context.Context) ([]*models.ArenaRanking, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT data FROM arena_rankings ORDER BY elo DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rankings: %w", err)
+	}
+	defer rows.Close()
+
+	var rankings []*models.ArenaRanking
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan ranking: %w", err)
+		}
+		var ranking models.ArenaRanking
+		if err := json.Unmarshal(data, &ranking); err != nil {
+			return nil, fmt.Errorf("failed to decode ranking: %w", err)
+		}
+		rankings = append(rankings, &ranking)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rankings: %w", err)
+	}
+
+	return rankings, nil
+}
+
+// SaveMatch inserts or replaces an arena match
+func (r *ArenaRepository) SaveMatch(ctx context.Context, match *models.ArenaMatch) error {
+	data, err := json.Marshal(match)
+	if err != nil {
+		return fmt.Errorf("failed to encode match: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO arena_matches (id, status, season, start_time, end_time, data)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			season = EXCLUDED.season,
+			start_time = EXCLUDED.start_time,
+			end_time = EXCLUDED.end_time,
+			data = EXCLUDED.data
+	`, match.ID, match.Status, match.Season, match.StartTime, nullableTime(match.EndTime), data)
+	if err != nil {
+		return fmt.Errorf("failed to save match: %w", err)
+	}
+	return nil
+}
+
+// ListMatches returns unfinished matches and those that ended after since,
+// oldest first
+func (r *ArenaRepository) ListMatches(ctx context.Context, since time.Time) ([]*models.ArenaMatch, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT data FROM arena_matches
+		WHERE end_time IS NULL OR end_time > $1
+		ORDER BY start_time
+	`, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query matches: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []*models.ArenaMatch
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan match: %w", err)
+		}
+		var match models.ArenaMatch
+		if err := json.Unmarshal(data, &match); err != nil {
+			return nil, fmt.Errorf("failed to decode match: %w", err)
+		}
+		matches = append(matches, &match)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating matches: %w", err)
+	}
+
+	return matches, nil
+}
+
+// SaveTournament inserts or replaces a tournament
+func (r *ArenaRepository) SaveTournament(ctx context.Context, tournament *models.Tournament) error {
+	data, err := json.Marshal(tournament)
+	if err != nil {
+		return fmt.Errorf("failed to encode tournament: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO arena_tournaments (id, status, start_time, end_time, data)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			start_time = EXCLUDED.start_time,
+			end_time = EXCLUDED.end_time,
+			data = EXCLUDED.data
+	`, tournament.ID, tournament.Status, tournament.StartTime, nullableTime(tournament.EndTime), data)
+	if err != nil {
+		return fmt.Errorf("failed to save tournament: %w", err)
+	}
+	return nil
+}
+
+// ListTournaments returns unfinished tournaments and those that ended
+// after since, soonest first
+func (r *ArenaRepository) ListTournaments(ctx context.Context, since time.Time) ([]*models.Tournament, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT data FROM arena_tournaments
+		WHERE end_time IS NULL OR end_time > $1
+		ORDER BY start_time
+	`, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tournaments: %w", err)
+	}
+	defer rows.Close()
+
+	var tournaments []*models.Tournament
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan tournament: %w", err)
+		}
+		var tournament models.Tournament
+		if err := json.Unmarshal(data, &tournament); err != nil {
+			return nil, fmt.Errorf("failed to decode tournament: %w", err)
+		}
+		tournaments = append(tournaments, &tournament)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tournaments: %w", err)
+	}
+
+	return tournaments, nil
+}
+
+// SaveSeason inserts or replaces a ranked season
+func (r *ArenaRepository) SaveSeason(ctx context.Context, season *models.ArenaSeason) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO arena_seasons (number, start_time, end_time)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (number) DO UPDATE SET
+			start_time = EXCLUDED.start_time,
+			end_time = EXCLUDED.end_time
+	`, season.Number, season.StartTime, season.EndTime)
+	if err != nil {
+		return fmt.Errorf("failed to save arena season: %w", err)
+	}
+	return nil
+}
+
+// GetCurrentSeason returns the latest season, or nil if none was saved
+func (r *ArenaRepository) GetCurrentSeason(ctx context.Context) (*models.ArenaSeason, error) {
+	var season models.ArenaSeason
+	err := r.db.QueryRowContext(ctx, `
+		SELECT number, start_time, end_time FROM arena_seasons
+		ORDER BY number DESC
+		LIMIT 1
+	`).Scan(&season.Number, &season.StartTime, &season.EndTime)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get arena season: %w", err)
+	}
+	return &season, nil
+}
+
+// nullableTime converts the zero time to a SQL NULL
+func nullableTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
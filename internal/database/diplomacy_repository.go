@@ -0,0 +1,321 @@
+// File: internal/database/diplomacy_repository.go
+// Project: Terminal Velocity
+// Description: Repository for faction alliances, wars, treaties, relations and proposals
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// DiplomacyRepository handles database operations for faction diplomacy.
+//
+// Tables:
+//   - faction_alliances: alliances with their member factions (JSONB)
+//   - faction_wars: wars with both sides (JSONB) and war scores (JSONB)
+//   - faction_treaties: signed treaties and their status
+//   - faction_relations: relation scores keyed by faction pair
+//   - diplomatic_proposals: alliance and treaty offers awaiting an answer
+//
+// The diplomacy manager keeps everything in memory and saves a record after
+// every change, so the Save methods are upserts keyed by ID.
+//
+// Thread-safety:
+//   - All methods are thread-safe
+type DiplomacyRepository struct {
+	db *DB // Database connection pool
+}
+
+// NewDiplomacyRepository creates a new diplomacy repository
+func NewDiplomacyRepository(db *DB) *DiplomacyRepository {
+	return &DiplomacyRepository{db: db}
+}
+
+// optionalFaction maps uuid.Nil, which the models use for "no faction", to NULL
+func optionalFaction(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
+
+// SaveAlliance inserts or replaces an alliance
+func (r *DiplomacyRepository) SaveAlliance(ctx context.Context, a *models.Alliance) error {
+	members, err := json.Marshal(a.MemberFactions)
+	if err != nil {
+		return fmt.Errorf("failed to encode alliance members: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO faction_alliances (id, name, description, leader_faction_id, member_factions,
+		                               created_at, status, treasury, last_maintenance)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			description = EXCLUDED.description,
+			leader_faction_id = EXCLUDED.leader_faction_id,
+			member_factions = EXCLUDED.member_factions,
+			status = EXCLUDED.status,
+			treasury = EXCLUDED.treasury,
+			last_maintenance = EXCLUDED.last_maintenance
+	`, a.ID, a.Name, a.Description, a.LeaderFactionID, members,
+		a.CreatedAt, a.Status, a.Treasury, a.LastMaintenance)
+	if err != nil {
+		return fmt.Errorf("failed to save alliance: %w", err)
+	}
+	return nil
+}
+
+// ListAlliances returns every alliance, including dissolved ones
+func (r *DiplomacyRepository) ListAlliances(ctx context.Context) ([]*models.Alliance, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, description, leader_faction_id, member_factions,
+		       created_at, status, treasury, last_maintenance
+		FROM faction_alliances
+		ORDER BY created_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alliances: %w", err)
+	}
+	defer rows.Close()
+
+	var alliances []*models.Alliance
+	for rows.Next() {
+		var a models.Alliance
+		var members []byte
+		if err := rows.Scan(&a.ID, &a.Name, &a.Description, &a.LeaderFactionID, &members,
+			&a.CreatedAt, &a.Status, &a.Treasury, &a.LastMaintenance); err != nil {
+			return nil, fmt.Errorf("failed to scan alliance: %w", err)
+		}
+		if err := json.Unmarshal(members, &a.MemberFactions); err != nil {
+			return nil, fmt.Errorf("failed to decode members of alliance %s: %w", a.ID, err)
+		}
+		alliances = append(alliances, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating alliances: %w", err)
+	}
+
+	return alliances, nil
+}
+
+// SaveWar inserts or replaces a war
+func (r *DiplomacyRepository) SaveWar(ctx context.Context, w *models.War) error {
+	aggressors, err := json.Marshal(w.AggressorFactions)
+	if err != nil {
+		return fmt.Errorf("failed to encode war aggressors: %w", err)
+	}
+	defenders, err := json.Marshal(w.DefenderFactions)
+	if err != nil {
+		return fmt.Errorf("failed to encode war defenders: %w", err)
+	}
+	score, err := json.Marshal(w.WarScore)
+	if err != nil {
+		return fmt.Errorf("failed to encode war score: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO faction_wars (id, name, aggressor_factions, defender_factions, start_time, end_time,
+		                          status, war_score, exhaustion, truce_proposed_by, truce_proposed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (id) DO UPDATE SET
+			aggressor_factions = EXCLUDED.aggressor_factions,
+			defender_factions = EXCLUDED.defender_factions,
+			end_time = EXCLUDED.end_time,
+			status = EXCLUDED.status,
+			war_score = EXCLUDED.war_score,
+			exhaustion = EXCLUDED.exhaustion,
+			truce_proposed_by = EXCLUDED.truce_proposed_by,
+			truce_proposed_at = EXCLUDED.truce_proposed_at
+	`, w.ID, w.Name, aggressors, defenders, w.StartTime, w.EndTime,
+		w.Status, score, w.Exhaustion, optionalFaction(w.TruceProposedBy), w.TruceProposedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save war: %w", err)
+	}
+	return nil
+}
+
+// ListWars returns every war, including ended ones
+func (r *DiplomacyRepository) ListWars(ctx context.Context) ([]*models.War, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, aggressor_factions, defender_factions, start_time, end_time,
+		       status, war_score, exhaustion, truce_proposed_by, truce_proposed_at
+		FROM faction_wars
+		ORDER BY start_time
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query wars: %w", err)
+	}
+	defer rows.Close()
+
+	var wars []*models.War
+	for rows.Next() {
+		var w models.War
+		var aggressors, defenders, score []byte
+		var truceProposedBy uuid.NullUUID
+		if err := rows.Scan(&w.ID, &w.Name, &aggressors, &defenders, &w.StartTime, &w.EndTime,
+			&w.Status, &score, &w.Exhaustion, &truceProposedBy, &w.TruceProposedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan war: %w", err)
+		}
+		w.TruceProposedBy = truceProposedBy.UUID
+		if err := json.Unmarshal(aggressors, &w.AggressorFactions); err != nil {
+			return nil, fmt.Errorf("failed to decode aggressors of war %s: %w", w.ID, err)
+		}
+		if err := json.Unmarshal(defenders, &w.DefenderFactions); err != nil {
+			return nil, fmt.Errorf("failed to decode defenders of war %s: %w", w.ID, err)
+		}
+		if err := json.Unmarshal(score, &w.WarScore); err != nil {
+			return nil, fmt.Errorf("failed to decode score of war %s: %w", w.ID, err)
+		}
+		wars = append(wars, &w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating wars: %w", err)
+	}
+
+	return wars, nil
+}
+
+// SaveTreaty inserts or replaces a treaty
+func (r *DiplomacyRepository) SaveTreaty(ctx context.Context, t *models.Treaty) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO faction_treaties (id, type, faction1, faction2, terms, signed_at, expires_at, status, violated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET
+			terms = EXCLUDED.terms,
+			expires_at = EXCLUDED.expires_at,
+			status = EXCLUDED.status,
+			violated_by = EXCLUDED.violated_by
+	`, t.ID, t.Type, t.Faction1, t.Faction2, t.Terms, t.SignedAt, t.ExpiresAt, t.Status, optionalFaction(t.ViolatedBy))
+	if err != nil {
+		return fmt.Errorf("failed to save treaty: %w", err)
+	}
+	return nil
+}
+
+// ListTreaties returns every treaty, including expired and violated ones
+func (r *DiplomacyRepository) ListTreaties(ctx context.Context) ([]*models.Treaty, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, type, faction1, faction2, terms, signed_at, expires_at, status, violated_by
+		FROM faction_treaties
+		ORDER BY signed_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query treaties: %w", err)
+	}
+	defer rows.Close()
+
+	var treaties []*models.Treaty
+	for rows.Next() {
+		var t models.Treaty
+		var violatedBy uuid.NullUUID
+		if err := rows.Scan(&t.ID, &t.Type, &t.Faction1, &t.Faction2, &t.Terms,
+			&t.SignedAt, &t.ExpiresAt, &t.Status, &violatedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan treaty: %w", err)
+		}
+		t.ViolatedBy = violatedBy.UUID
+		treaties = append(treaties, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating treaties: %w", err)
+	}
+
+	return treaties, nil
+}
+
+// SaveRelation inserts or replaces the relation between two factions
+func (r *DiplomacyRepository) SaveRelation(ctx context.Context, rel *models.Relation) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO faction_relations (faction1, faction2, value, status, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (faction1, faction2) DO UPDATE SET
+			value = EXCLUDED.value,
+			status = EXCLUDED.status,
+			updated_at = EXCLUDED.updated_at
+	`, rel.Faction1, rel.Faction2, rel.Value, rel.Status, rel.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save relation: %w", err)
+	}
+	return nil
+}
+
+// ListRelations returns every stored faction relation
+func (r *DiplomacyRepository) ListRelations(ctx context.Context) ([]*models.Relation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT faction1, faction2, value, status, updated_at
+		FROM faction_relations
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query relations: %w", err)
+	}
+	defer rows.Close()
+
+	var relations []*models.Relation
+	for rows.Next() {
+		var rel models.Relation
+		if err := rows.Scan(&rel.Faction1, &rel.Faction2, &rel.Value, &rel.Status, &rel.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan relation: %w", err)
+		}
+		relations = append(relations, &rel)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating relations: %w", err)
+	}
+
+	return relations, nil
+}
+
+// SaveProposal inserts a diplomatic proposal
+func (r *DiplomacyRepository) SaveProposal(ctx context.Context, p *models.DiplomaticProposal) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO diplomatic_proposals (id, kind, treaty_type, from_faction_id, to_faction_id,
+		                                  proposed_by, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO NOTHING
+	`, p.ID, p.Kind, p.TreatyType, p.FromFactionID, p.ToFactionID, p.ProposedBy, p.CreatedAt, p.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to save proposal: %w", err)
+	}
+	return nil
+}
+
+// DeleteProposal removes an answered or expired proposal
+func (r *DiplomacyRepository) DeleteProposal(ctx context.Context, proposalID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM diplomatic_proposals WHERE id = $1`, proposalID); err != nil {
+		return fmt.Errorf("failed to delete proposal: %w", err)
+	}
+	return nil
+}
+
+// ListProposals returns every open proposal in the order they were made
+func (r *DiplomacyRepository) ListProposals(ctx context.Context) ([]*models.DiplomaticProposal, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, kind, treaty_type, from_faction_id, to_faction_id, proposed_by, created_at, expires_at
+		FROM diplomatic_proposals
+		ORDER BY created_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query proposals: %w", err)
+	}
+	defer rows.Close()
+
+	var proposals []*models.DiplomaticProposal
+	for rows.Next() {
+		var p models.DiplomaticProposal
+		if err := rows.Scan(&p.ID, &p.Kind, &p.TreatyType, &p.FromFactionID, &p.ToFactionID,
+			&p.ProposedBy, &p.CreatedAt, &p.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan proposal: %w", err)
+		}
+		proposals = append(proposals, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating proposals: %w", err)
+	}
+
+	return proposals, nil
+}
@@ -0,0 +1,533 @@
+// File: internal/database/faction_repository.go
+// Project: Terminal Velocity
+// Description: Repository for player factions, membership, ranks and treasury
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// Faction-related errors returned by repository methods.
+var (
+	// ErrFactionNotFound indicates the requested faction does not exist.
+	ErrFactionNotFound = errors.New("faction not found")
+
+	// ErrFactionNameExists indicates another faction already uses the name.
+	// Enforced by the UNIQUE constraint on player_factions.name.
+	ErrFactionNameExists = errors.New("faction name already exists")
+
+	// ErrFactionTagExists indicates another faction already uses the tag.
+	// Enforced by the UNIQUE constraint on player_factions.tag.
+	ErrFactionTagExists = errors.New("faction tag already exists")
+
+	// ErrInsufficientTreasury indicates a withdrawal larger than the treasury.
+	ErrInsufficientTreasury = errors.New("insufficient faction treasury funds")
+
+	// ErrInsufficientCredits indicates a deposit larger than the player's credits.
+	ErrInsufficientCredits = errors.New("insufficient credits")
+)
+
+// FactionRepository handles all database operations for player factions.
+//
+// Manages the faction tables:
+//   - player_factions: Faction record, treasury and settings
+//   - faction_members: Membership, rank and total contribution
+//   - faction_officers: Officer appointments
+//   - faction_reputation: Faction standing with NPC governments
+//
+// faction_members is the authoritative membership record. Treasury
+// transfers move credits between players.credits and
+// player_factions.treasury inside a single transaction.
+//
+// Thread-safety:
+//   - All methods are thread-safe
+//   - Treasury updates use conditional UPDATEs so balances never go negative
+type FactionRepository struct {
+	db *DB // Database connection pool
+}
+
+// NewFactionRepository creates a new faction repository
+func NewFactionRepository(db *DB) *FactionRepository {
+	return &FactionRepository{db: db}
+}
+
+// Create inserts a new faction and its leader's membership row.
+//
+// Returns ErrFactionNameExists or ErrFactionTagExists if the database
+// rejects the name or tag as a duplicate.
+func (r *FactionRepository) Create(ctx context.Context, faction *models.PlayerFaction) error {
+	settings, err := json.Marshal(faction.Settings)
+	if err != nil {
+		return fmt.Errorf("failed to encode faction settings: %w", err)
+	}
+
+	return r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO player_factions (id, name, tag, founder_id, leader_id, created_at,
+			                             treasury, home_system, level, experience, alignment,
+			                             is_recruiting, tax_rate, member_limit, settings)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		`,
+			faction.ID,
+			faction.Name,
+			faction.Tag,
+			faction.FounderID,
+			faction.LeaderID,
+			faction.CreatedAt,
+			faction.Treasury,
+			faction.HomeSystem,
+			faction.Level,
+			faction.Experience,
+			faction.Alignment,
+			faction.IsRecruiting,
+			faction.TaxRate,
+			faction.MemberLimit,
+			settings,
+		)
+		if err != nil {
+			if isDuplicateKeyError(err) {
+				if strings.Contains(err.Error(), "player_factions_tag_key") {
+					return ErrFactionTagExists
+				}
+				return ErrFactionNameExists
+			}
+			return fmt.Errorf("failed to create faction: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO faction_members (faction_id, player_id, rank, joined_at)
+			VALUES ($1, $2, $3, $4)
+		`, faction.ID, faction.LeaderID, models.RankLeader, faction.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to add faction leader: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetAll loads every faction with its members, officers and reputation
+func (r *FactionRepository) GetAll(ctx context.Context) ([]*models.PlayerFaction, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, tag, founder_id, leader_id, created_at, treasury, home_system,
+		       level, experience, alignment, is_recruiting, tax_rate, member_limit, settings
+		FROM player_factions
+		ORDER BY created_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query factions: %w", err)
+	}
+	defer rows.Close()
+
+	var factions []*models.PlayerFaction
+	byID := make(map[uuid.UUID]*models.PlayerFaction)
+
+	for rows.Next() {
+		var faction models.PlayerFaction
+		var founderID, leaderID, homeSystem sql.NullString
+		var settings []byte
+
+		err := rows.Scan(
+			&faction.ID,
+			&faction.Name,
+			&faction.Tag,
+			&founderID,
+			&leaderID,
+			&faction.CreatedAt,
+			&faction.Treasury,
+			&homeSystem,
+			&faction.Level,
+			&faction.Experience,
+			&faction.Alignment,
+			&faction.IsRecruiting,
+			&faction.TaxRate,
+			&faction.MemberLimit,
+			&settings,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan faction: %w", err)
+		}
+
+		if founderID.Valid {
+			faction.FounderID, _ = uuid.Parse(founderID.String)
+		}
+		if leaderID.Valid {
+			faction.LeaderID, _ = uuid.Parse(leaderID.String)
+		}
+		if homeSystem.Valid {
+			if id, err := uuid.Parse(homeSystem.String); err == nil {
+				faction.HomeSystem = &id
+			}
+		}
+		if len(settings) > 0 {
+			if err := json.Unmarshal(settings, &faction.Settings); err != nil {
+				return nil, fmt.Errorf("failed to decode settings for faction %s: %w", faction.ID, err)
+			}
+		}
+
+		faction.Officers = []uuid.UUID{}
+		faction.Members = []uuid.UUID{}
+		faction.ControlledSystems = []uuid.UUID{}
+		faction.Reputation = make(map[string]int)
+
+		factions = append(factions, &faction)
+		byID[faction.ID] = &faction
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating factions: %w", err)
+	}
+
+	if err := r.loadMembers(ctx, byID); err != nil {
+		return nil, err
+	}
+	if err := r.loadOfficers(ctx, byID); err != nil {
+		return nil, err
+	}
+	if err := r.loadReputation(ctx, byID); err != nil {
+		return nil, err
+	}
+
+	return factions, nil
+}
+
+// loadMembers fills in faction members in join order
+func (r *FactionRepository) loadMembers(ctx context.Context, byID map[uuid.UUID]*models.PlayerFaction) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT faction_id, player_id FROM faction_members ORDER BY joined_at
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to query faction members: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var factionID, playerID uuid.UUID
+		if err := rows.Scan(&factionID, &playerID); err != nil {
+			return fmt.Errorf("failed to scan faction member: %w", err)
+		}
+		if faction, ok := byID[factionID]; ok {
+			faction.Members = append(faction.Members, playerID)
+		}
+	}
+
+	return rows.Err()
+}
+
+// loadOfficers fills in faction officer appointments
+func (r *FactionRepository) loadOfficers(ctx context.Context, byID map[uuid.UUID]*models.PlayerFaction) error {
+	rows, err := r.db.QueryContext(ctx, `SELECT faction_id, player_id FROM faction_officers`)
+	if err != nil {
+		return fmt.Errorf("failed to query faction officers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var factionID, playerID uuid.UUID
+		if err := rows.Scan(&factionID, &playerID); err != nil {
+			return fmt.Errorf("failed to scan faction officer: %w", err)
+		}
+		if faction, ok := byID[factionID]; ok {
+			faction.Officers = append(faction.Officers, playerID)
+		}
+	}
+
+	return rows.Err()
+}
+
+// loadReputation fills in faction standing with NPC governments
+func (r *FactionRepository) loadReputation(ctx context.Context, byID map[uuid.UUID]*models.PlayerFaction) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT faction_id, government_id, reputation FROM faction_reputation
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to query faction reputation: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var factionID uuid.UUID
+		var governmentID string
+		var reputation int
+		if err := rows.Scan(&factionID, &governmentID, &reputation); err != nil {
+			return fmt.Errorf("failed to scan faction reputation: %w", err)
+		}
+		if faction, ok := byID[factionID]; ok {
+			faction.Reputation[governmentID] = reputation
+		}
+	}
+
+	return rows.Err()
+}
+
+// Update saves a faction's leader, progression and settings.
+// Treasury is not written here; use Deposit, Withdraw or AddToTreasury.
+func (r *FactionRepository) Update(ctx context.Context, faction *models.PlayerFaction) error {
+	settings, err := json.Marshal(faction.Settings)
+	if err != nil {
+		return fmt.Errorf("failed to encode faction settings: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE player_factions
+		SET leader_id = $1, home_system = $2, level = $3, experience = $4,
+		    is_recruiting = $5, tax_rate = $6, member_limit = $7, settings = $8
+		WHERE id = $9
+	`,
+		faction.LeaderID,
+		faction.HomeSystem,
+		faction.Level,
+		faction.Experience,
+		faction.IsRecruiting,
+		faction.TaxRate,
+		faction.MemberLimit,
+		settings,
+		faction.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update faction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrFactionNotFound
+	}
+
+	return nil
+}
+
+// AddMember records a player joining a faction
+func (r *FactionRepository) AddMember(ctx context.Context, factionID, playerID uuid.UUID, rank string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO faction_members (faction_id, player_id, rank)
+		VALUES ($1, $2, $3)
+	`, factionID, playerID, rank)
+	if err != nil {
+		return fmt.Errorf("failed to add faction member: %w", err)
+	}
+	return nil
+}
+
+// RemoveMember removes a player's membership and any officer appointment
+func (r *FactionRepository) RemoveMember(ctx context.Context, factionID, playerID uuid.UUID) error {
+	return r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			DELETE FROM faction_officers WHERE faction_id = $1 AND player_id = $2
+		`, factionID, playerID)
+		if err != nil {
+			return fmt.Errorf("failed to remove faction officer: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM faction_members WHERE faction_id = $1 AND player_id = $2
+		`, factionID, playerID)
+		if err != nil {
+			return fmt.Errorf("failed to remove faction member: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// SetOfficer appoints or dismisses an officer and updates the member's rank
+func (r *FactionRepository) SetOfficer(ctx context.Context, factionID, playerID uuid.UUID, officer bool) error {
+	return r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		rank := models.RankMember
+		var err error
+		if officer {
+			rank = models.RankOfficer
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO faction_officers (faction_id, player_id)
+				VALUES ($1, $2)
+				ON CONFLICT DO NOTHING
+			`, factionID, playerID)
+		} else {
+			_, err = tx.ExecContext(ctx, `
+				DELETE FROM faction_officers WHERE faction_id = $1 AND player_id = $2
+			`, factionID, playerID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update faction officers: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE faction_members SET rank = $1 WHERE faction_id = $2 AND player_id = $3
+		`, rank, factionID, playerID)
+		if err != nil {
+			return fmt.Errorf("failed to update member rank: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Deposit moves credits from a player into the faction treasury.
+//
+// The player's balance, the treasury and the member's contribution are
+// updated in one transaction. Returns ErrInsufficientCredits if the player
+// cannot cover the amount.
+//
+// Returns:
+//   - int64: Player's new credit balance
+//   - int64: Faction's new treasury balance
+//   - error: ErrInsufficientCredits, ErrFactionNotFound, or database error
+func (r *FactionRepository) Deposit(ctx context.Context, factionID, playerID uuid.UUID, amount int64) (int64, int64, error) {
+	var credits, treasury int64
+
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			UPDATE players SET credits = credits - $1
+			WHERE id = $2 AND credits >= $1
+			RETURNING credits
+		`, amount, playerID).Scan(&credits)
+		if err == sql.ErrNoRows {
+			return ErrInsufficientCredits
+		}
+		if err != nil {
+			return fmt.Errorf("failed to deduct credits: %w", err)
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			UPDATE player_factions SET treasury = treasury + $1
+			WHERE id = $2
+			RETURNING treasury
+		`, amount, factionID).Scan(&treasury)
+		if err == sql.ErrNoRows {
+			return ErrFactionNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to credit treasury: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE faction_members SET contribution = contribution + $1
+			WHERE faction_id = $2 AND player_id = $3
+		`, amount, factionID, playerID)
+		if err != nil {
+			return fmt.Errorf("failed to record contribution: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return credits, treasury, nil
+}
+
+// Withdraw moves credits from the faction treasury to a player.
+//
+// Both balances are updated in one transaction. Returns
+// ErrInsufficientTreasury if the treasury cannot cover the amount.
+//
+// Returns:
+//   - int64: Player's new credit balance
+//   - int64: Faction's new treasury balance
+//   - error: ErrInsufficientTreasury, ErrPlayerNotFound, or database error
+func (r *FactionRepository) Withdraw(ctx context.Context, factionID, playerID uuid.UUID, amount int64) (int64, int64, error) {
+	var credits, treasury int64
+
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			UPDATE player_factions SET treasury = treasury - $1
+			WHERE id = $2 AND treasury >= $1
+			RETURNING treasury
+		`, amount, factionID).Scan(&treasury)
+		if err == sql.ErrNoRows {
+			return ErrInsufficientTreasury
+		}
+		if err != nil {
+			return fmt.Errorf("failed to debit treasury: %w", err)
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			UPDATE players SET credits = credits + $1
+			WHERE id = $2
+			RETURNING credits
+		`, amount, playerID).Scan(&credits)
+		if err == sql.ErrNoRows {
+			return ErrPlayerNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add credits: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return credits, treasury, nil
+}
+
+// AddToTreasury credits a faction treasury from a non-player source
+// (for example an alliance payout). Returns the new treasury balance.
+func (r *FactionRepository) AddToTreasury(ctx context.Context, factionID uuid.UUID, amount int64) (int64, error) {
+	var treasury int64
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE player_factions SET treasury = treasury + $1
+		WHERE id = $2
+		RETURNING treasury
+	`, amount, factionID).Scan(&treasury)
+	if err == sql.ErrNoRows {
+		return 0, ErrFactionNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to credit treasury: %w", err)
+	}
+	return treasury, nil
+}
+
+// SpendFromTreasury debits a faction treasury for a non-player expense (for
+// example territory upkeep). Returns the new treasury balance, or
+// ErrInsufficientTreasury if the treasury cannot cover the amount.
+func (r *FactionRepository) SpendFromTreasury(ctx context.Context, factionID uuid.UUID, amount int64) (int64, error) {
+	var treasury int64
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE player_factions SET treasury = treasury - $1
+		WHERE id = $2 AND treasury >= $1
+		RETURNING treasury
+	`, amount, factionID).Scan(&treasury)
+	if err == sql.ErrNoRows {
+		return 0, ErrInsufficientTreasury
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to debit treasury: %w", err)
+	}
+	return treasury, nil
+}
+
+// Delete removes a faction. Members, officers and reputation are removed by
+// ON DELETE CASCADE and players.faction_id is cleared by ON DELETE SET NULL.
+func (r *FactionRepository) Delete(ctx context.Context, factionID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM player_factions WHERE id = $1`, factionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete faction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrFactionNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+// File: internal/database/faction_repository_test.go
+// Project: Terminal Velocity
+// Description: Integration tests for the faction repository
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestFactionRepository_PersistenceAndTreasury(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	players := NewPlayerRepository(db)
+	repo := NewFactionRepository(db)
+	ctx := context.Background()
+
+	suffix := uuid.New().String()[:8]
+	leader, err := players.Create(ctx, "faction_leader_"+suffix, "testpassword123")
+	if err != nil {
+		t.Fatalf("Failed to create leader: %v", err)
+	}
+	defer func() { _ = players.Delete(ctx, leader.ID) }()
+
+	member, err := players.Create(ctx, "faction_member_"+suffix, "testpassword123")
+	if err != nil {
+		t.Fatalf("Failed to create member: %v", err)
+	}
+	defer func() { _ = players.Delete(ctx, member.ID) }()
+
+	if err := players.UpdateCredits(ctx, member.ID, 1000); err != nil {
+		t.Fatalf("Failed to set credits: %v", err)
+	}
+
+	faction := models.NewPlayerFaction("Test Faction "+suffix, suffix[:4], leader.ID, models.AlignmentTrader)
+	if err := repo.Create(ctx, faction); err != nil {
+		t.Fatalf("Failed to create faction: %v", err)
+	}
+	defer func() { _ = repo.Delete(ctx, faction.ID) }()
+
+	// Name and tag uniqueness is enforced by the database
+	dupName := models.NewPlayerFaction(faction.Name, "ZZZZ", member.ID, models.AlignmentTrader)
+	if err := repo.Create(ctx, dupName); !errors.Is(err, ErrFactionNameExists) {
+		t.Errorf("Expected ErrFactionNameExists, got %v", err)
+	}
+	dupTag := models.NewPlayerFaction("Other Faction "+suffix, faction.Tag, member.ID, models.AlignmentTrader)
+	if err := repo.Create(ctx, dupTag); !errors.Is(err, ErrFactionTagExists) {
+		t.Errorf("Expected ErrFactionTagExists, got %v", err)
+	}
+
+	if err := repo.AddMember(ctx, faction.ID, member.ID, models.RankMember); err != nil {
+		t.Fatalf("Failed to add member: %v", err)
+	}
+	if err := repo.SetOfficer(ctx, faction.ID, member.ID, true); err != nil {
+		t.Fatalf("Failed to promote member: %v", err)
+	}
+
+	credits, treasury, err := repo.Deposit(ctx, faction.ID, member.ID, 600)
+	if err != nil {
+		t.Fatalf("Deposit failed: %v", err)
+	}
+	if credits != 400 || treasury != 600 {
+		t.Errorf("Expected credits 400 / treasury 600, got %d / %d", credits, treasury)
+	}
+
+	// Overdrawn deposits and withdrawals change nothing
+	if _, _, err := repo.Deposit(ctx, faction.ID, member.ID, 500); !errors.Is(err, ErrInsufficientCredits) {
+		t.Errorf("Expected ErrInsufficientCredits, got %v", err)
+	}
+	if _, _, err := repo.Withdraw(ctx, faction.ID, member.ID, 700); !errors.Is(err, ErrInsufficientTreasury) {
+		t.Errorf("Expected ErrInsufficientTreasury, got %v", err)
+	}
+
+	credits, treasury, err = repo.Withdraw(ctx, faction.ID, member.ID, 100)
+	if err != nil {
+		t.Fatalf("Withdraw failed: %v", err)
+	}
+	if credits != 500 || treasury != 500 {
+		t.Errorf("Expected credits 500 / treasury 500, got %d / %d", credits, treasury)
+	}
+
+	// Everything survives a reload
+	factions, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	var loaded *models.PlayerFaction
+	for _, f := range factions {
+		if f.ID == faction.ID {
+			loaded = f
+		}
+	}
+	if loaded == nil {
+		t.Fatal("Created faction was not loaded")
+	}
+	if loaded.Treasury != 500 {
+		t.Errorf("Expected treasury 500, got %d", loaded.Treasury)
+	}
+	if !loaded.IsMember(member.ID) || !loaded.IsOfficer(member.ID) || !loaded.IsLeader(leader.ID) {
+		t.Errorf("Membership not restored: members=%v officers=%v leader=%s", loaded.Members, loaded.Officers, loaded.LeaderID)
+	}
+	if loaded.Settings.MOTD != faction.Settings.MOTD {
+		t.Errorf("Settings not restored: %+v", loaded.Settings)
+	}
+}
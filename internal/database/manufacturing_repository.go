@@ -0,0 +1,204 @@
+// File: internal/database/manufacturing_repository.go
+// Project: Terminal Velocity
+// Description: Repository for crafting jobs, player stations and researched technologies
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// ManufacturingRepository handles database operations for manufacturing.
+//
+// Tables:
+//   - crafting_jobs: crafting jobs in progress and recently finished
+//   - player_stations: player-owned stations with facilities and storage (JSONB)
+//   - player_technologies: technology levels keyed by player and tech ID
+//
+// The manufacturing manager keeps everything in memory and saves a record
+// after every change, so the Save methods are upserts.
+//
+// Thread-safety:
+//   - All methods are thread-safe
+type ManufacturingRepository struct {
+	db *DB // Database connection pool
+}
+
+// NewManufacturingRepository creates a new manufacturing repository
+func NewManufacturingRepository(db *DB) *ManufacturingRepository {
+	return &ManufacturingRepository{db: db}
+}
+
+// SaveJob inserts or replaces a crafting job
+func (r *ManufacturingRepository) SaveJob(ctx context.Context, job *models.CraftingJob) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO crafting_jobs (id, player_id, blueprint_id, quantity, station_id,
+		                           start_time, completion_time, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO UPDATE SET
+			completion_time = EXCLUDED.completion_time,
+			status = EXCLUDED.status
+	`, job.ID, job.PlayerID, job.BlueprintID, job.Quantity, job.StationID,
+		job.StartTime, job.CompletionTime, job.Status)
+	if err != nil {
+		return fmt.Errorf("failed to save crafting job: %w", err)
+	}
+	return nil
+}
+
+// DeleteJob removes a finished crafting job
+func (r *ManufacturingRepository) DeleteJob(ctx context.Context, jobID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM crafting_jobs WHERE id = $1`, jobID); err != nil {
+		return fmt.Errorf("failed to delete crafting job: %w", err)
+	}
+	return nil
+}
+
+// ListJobs returns every stored crafting job in the order they were started
+func (r *ManufacturingRepository) ListJobs(ctx context.Context) ([]*models.CraftingJob, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, player_id, blueprint_id, quantity, station_id, start_time, completion_time, status
+		FROM crafting_jobs
+		ORDER BY start_time
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query crafting jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*models.CraftingJob
+	for rows.Next() {
+		var job models.CraftingJob
+		var stationID uuid.NullUUID
+		if err := rows.Scan(&job.ID, &job.PlayerID, &job.BlueprintID, &job.Quantity, &stationID,
+			&job.StartTime, &job.CompletionTime, &job.Status); err != nil {
+			return nil, fmt.Errorf("failed to scan crafting job: %w", err)
+		}
+		if stationID.Valid {
+			job.StationID = &stationID.UUID
+		}
+		jobs = append(jobs, &job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating crafting jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// SaveStation inserts or replaces a player station
+func (r *ManufacturingRepository) SaveStation(ctx context.Context, s *models.PlayerStation) error {
+	facilities, err := json.Marshal(s.Facilities)
+	if err != nil {
+		return fmt.Errorf("failed to encode station facilities: %w", err)
+	}
+	storage, err := json.Marshal(s.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to encode station storage: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO player_stations (id, owner_id, owner_name, name, system_id, system_name, level,
+		                             built_at, facilities, storage, storage_capacity, production_bonus, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			level = EXCLUDED.level,
+			facilities = EXCLUDED.facilities,
+			storage = EXCLUDED.storage,
+			storage_capacity = EXCLUDED.storage_capacity,
+			production_bonus = EXCLUDED.production_bonus,
+			status = EXCLUDED.status
+	`, s.ID, s.OwnerID, s.OwnerName, s.Name, s.SystemID, s.SystemName, s.Level,
+		s.BuildTime, facilities, storage, s.StorageCapacity, s.ProductionBonus, s.Status)
+	if err != nil {
+		return fmt.Errorf("failed to save station: %w", err)
+	}
+	return nil
+}
+
+// ListStations returns every player station in the order they were built
+func (r *ManufacturingRepository) ListStations(ctx context.Context) ([]*models.PlayerStation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, owner_id, owner_name, name, system_id, system_name, level,
+		       built_at, facilities, storage, storage_capacity, production_bonus, status
+		FROM player_stations
+		ORDER BY built_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stations: %w", err)
+	}
+	defer rows.Close()
+
+	var stations []*models.PlayerStation
+	for rows.Next() {
+		var s models.PlayerStation
+		var facilities, storage []byte
+		if err := rows.Scan(&s.ID, &s.OwnerID, &s.OwnerName, &s.Name, &s.SystemID, &s.SystemName, &s.Level,
+			&s.BuildTime, &facilities, &storage, &s.StorageCapacity, &s.ProductionBonus, &s.Status); err != nil {
+			return nil, fmt.Errorf("failed to scan station: %w", err)
+		}
+		if err := json.Unmarshal(facilities, &s.Facilities); err != nil {
+			return nil, fmt.Errorf("failed to decode facilities of station %s: %w", s.ID, err)
+		}
+		if err := json.Unmarshal(storage, &s.Storage); err != nil {
+			return nil, fmt.Errorf("failed to decode storage of station %s: %w", s.ID, err)
+		}
+		stations = append(stations, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stations: %w", err)
+	}
+
+	return stations, nil
+}
+
+// SaveTechnology records a player's level in a technology
+func (r *ManufacturingRepository) SaveTechnology(ctx context.Context, playerID uuid.UUID, techID string, level int) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO player_technologies (player_id, tech_id, level)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (player_id, tech_id) DO UPDATE SET level = EXCLUDED.level
+	`, playerID, techID, level)
+	if err != nil {
+		return fmt.Errorf("failed to save technology: %w", err)
+	}
+	return nil
+}
+
+// ListTechnologies returns every player's technology levels, keyed by
+// player ID and then tech ID
+func (r *ManufacturingRepository) ListTechnologies(ctx context.Context) (map[uuid.UUID]map[string]int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT player_id, tech_id, level FROM player_technologies`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query technologies: %w", err)
+	}
+	defer rows.Close()
+
+	technologies := make(map[uuid.UUID]map[string]int)
+	for rows.Next() {
+		var playerID uuid.UUID
+		var techID string
+		var level int
+		if err := rows.Scan(&playerID, &techID, &level); err != nil {
+			return nil, fmt.Errorf("failed to scan technology: %w", err)
+		}
+		if technologies[playerID] == nil {
+			technologies[playerID] = make(map[string]int)
+		}
+		technologies[playerID][techID] = level
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating technologies: %w", err)
+	}
+
+	return technologies, nil
+}
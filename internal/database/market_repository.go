@@ -1,7 +1,7 @@
 // File: internal/database/market_repository.go
 // Project: Terminal Velocity
 // Description: Repository for market prices and commodity trading economy
-// Version: 1.1.0
+// Version: 1.3.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -208,6 +208,45 @@ func (r *MarketRepository) UpdateMarketPrice(ctx context.Context, price *models.
 	return nil
 }
 
+// UpdateMarketPriceIfUnchanged updates a market price only if its last_update
+// still matches expectedLastUpdate.
+//
+// Used by the economy simulation so that a background tick never overwrites a
+// player trade that landed between reading and writing the row. Returns false
+// (with no error) when the row changed underneath the caller.
+func (r *MarketRepository) UpdateMarketPriceIfUnchanged(ctx context.Context, price *models.MarketPrice, expectedLastUpdate int64) (bool, error) {
+	query := `
+		UPDATE market_prices
+		SET buy_price = $1, sell_price = $2, stock = $3, demand = $4, last_update = $5
+		WHERE planet_id = $6 AND commodity_id = $7 AND last_update = $8
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		price.BuyPrice,
+		price.SellPrice,
+		price.Stock,
+		price.Demand,
+		price.LastUpdate,
+		price.PlanetID,
+		price.CommodityID,
+		expectedLastUpdate,
+	)
+
+	if err != nil {
+		errors.RecordGlobalError("market_repository", "update_price_if_unchanged", err)
+		log.Error("Failed to update market price: planet_id=%s, commodity_id=%s, error=%v", price.PlanetID, price.CommodityID, err)
+		return false, fmt.Errorf("failed to update market price: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Error("Failed to get rows affected: planet_id=%s, commodity_id=%s, error=%v", price.PlanetID, price.CommodityID, err)
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
 // DeleteMarketPrice deletes a market price
 func (r *MarketRepository) DeleteMarketPrice(ctx context.Context, planetID uuid.UUID, commodityID string) error {
 	query := `DELETE FROM market_prices WHERE planet_id = $1 AND commodity_id = $2`
@@ -234,6 +273,49 @@ func (r *MarketRepository) DeleteMarketPrice(ctx context.Context, planetID uuid.
 	return nil
 }
 
+// BulkSeedMarketPrices inserts initial market prices in a single transaction.
+//
+// Used when loading a universe file. Prices that already exist for a
+// (planet, commodity) pair are left alone so reloading a universe never
+// resets a live economy.
+//
+// Parameters:
+//   - ctx: Context for timeout and cancellation
+//   - prices: Initial prices with LastUpdate set
+//
+// Returns:
+//   - error: Database error (transaction rolls back on failure)
+func (r *MarketRepository) BulkSeedMarketPrices(ctx context.Context, prices []*models.MarketPrice) error {
+	return r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO market_prices (planet_id, commodity_id, buy_price, sell_price, stock, demand, last_update)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (planet_id, commodity_id) DO NOTHING
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to prepare statement: %w", err)
+		}
+		defer stmt.Close()
+
+		for _, price := range prices {
+			_, err := stmt.ExecContext(ctx,
+				price.PlanetID,
+				price.CommodityID,
+				price.BuyPrice,
+				price.SellPrice,
+				price.Stock,
+				price.Demand,
+				price.LastUpdate,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to seed market price %s at %s: %w", price.CommodityID, price.PlanetID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
 // InitializePlanetMarket initializes market prices for all commodities at a planet
 func (r *MarketRepository) InitializePlanetMarket(ctx context.Context, planetID uuid.UUID) error {
 	// This would typically be called by the pricing engine
@@ -241,8 +323,9 @@ func (r *MarketRepository) InitializePlanetMarket(ctx context.Context, planetID
 	return nil
 }
 
-// GetStaleMarkets returns markets that haven't been updated in a while
-func (r *MarketRepository) GetStaleMarkets(ctx context.Context, olderThanSeconds int64) ([]*models.MarketPrice, error) {
+// GetStaleMarkets returns markets whose last update is earlier than the given
+// Unix timestamp, oldest first, in batches of at most 1000.
+func (r *MarketRepository) GetStaleMarkets(ctx context.Context, cutoff int64) ([]*models.MarketPrice, error) {
 	query := `
 		SELECT planet_id, commodity_id, buy_price, sell_price, stock, demand, last_update
 		FROM market_prices
@@ -251,10 +334,9 @@ func (r *MarketRepository) GetStaleMarkets(ctx context.Context, olderThanSeconds
 		LIMIT 1000
 	`
 
-	currentTime := sql.NullInt64{Int64: olderThanSeconds, Valid: true}
-	rows, err := r.db.QueryContext(ctx, query, currentTime)
+	rows, err := r.db.QueryContext(ctx, query, cutoff)
 	if err != nil {
-		log.Error("Failed to query stale markets: olderThan=%d, error=%v", olderThanSeconds, err)
+		log.Error("Failed to query stale markets: cutoff=%d, error=%v", cutoff, err)
 		return nil, fmt.Errorf("failed to query stale markets: %w", err)
 	}
 	defer rows.Close()
@@ -283,7 +365,7 @@ func (r *MarketRepository) GetStaleMarkets(ctx context.Context, olderThanSeconds
 		return nil, fmt.Errorf("error iterating stale markets: %w", err)
 	}
 
-	log.Debug("Found %d stale markets older than %d seconds", len(prices), olderThanSeconds)
+	log.Debug("Found %d stale markets last updated before %d", len(prices), cutoff)
 	return prices, nil
 }
 
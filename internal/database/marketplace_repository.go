@@ -0,0 +1,1049 @@
+// File: internal/database/marketplace_repository.go
+// Project: Terminal Velocity
+// Description: Repository for marketplace auctions, contracts and bounties with
+//              transactional credit and item escrow
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// Marketplace-related errors returned by repository methods.
+var (
+	// ErrListingNotFound indicates the auction, contract or bounty does not exist.
+	ErrListingNotFound = errors.New("marketplace listing not found")
+
+	// ErrListingClosed indicates the listing is no longer open for this action
+	// (sold, claimed, expired or cancelled, possibly by another session).
+	ErrListingClosed = errors.New("marketplace listing is no longer open")
+
+	// ErrBidTooLow indicates a bid that does not beat the current high bid.
+	ErrBidTooLow = errors.New("bid is too low")
+
+	// ErrItemNotAvailable indicates the auctioned item is not owned by the
+	// seller or is already in mail, escrow or another auction.
+	ErrItemNotAvailable = errors.New("item is not available")
+)
+
+// CreditChange is a committed change to one player's credits
+type CreditChange struct {
+	PlayerID   uuid.UUID
+	Delta      int64
+	NewCredits int64
+	Reason     string
+}
+
+// MarketplaceRepository handles all database operations for the player marketplace.
+//
+// Every method that moves credits or items does so in the same transaction
+// as the listing status change, with the listing row locked (FOR UPDATE).
+// A listing can therefore never be settled twice, and a crash between
+// steps leaves both the money and the listing untouched.
+//
+// Escrow:
+//   - Auctions of outfits and special items hold the player_items row in
+//     LocationAuction (location_id = auction ID) until sold, expired or cancelled.
+//     Unsold items go back where they came from; sold items go to the
+//     winner's current ship (or station storage at their planet without one)
+//   - Bids, contract deposits and bounty amounts are taken from the player
+//     when placed and are refunded or paid out by the settling method
+//
+// Thread-safety:
+//   - All methods are thread-safe
+type MarketplaceRepository struct {
+	db *DB // Database connection pool
+}
+
+// NewMarketplaceRepository creates a new marketplace repository
+func NewMarketplaceRepository(db *DB) *MarketplaceRepository {
+	return &MarketplaceRepository{db: db}
+}
+
+// ============================================================================
+// AUCTIONS
+// ============================================================================
+
+// CreateAuction inserts an auction and moves its item into escrow.
+// Returns ErrItemNotAvailable if the item cannot be escrowed.
+func (r *MarketplaceRepository) CreateAuction(ctx context.Context, auction *models.MarketAuction) error {
+	return r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var itemLocation, itemLocationID sql.NullString
+
+		if auction.Type.EscrowsItem() {
+			err := tx.QueryRowContext(ctx, `
+				SELECT location, location_id FROM player_items
+				WHERE id = $1 AND player_id = $2 AND location IN ($3, $4)
+				FOR UPDATE
+			`, auction.ItemID, auction.SellerID,
+				models.LocationShip, models.LocationStationStorage).Scan(&itemLocation, &itemLocationID)
+			if err == sql.ErrNoRows {
+				return ErrItemNotAvailable
+			}
+			if err != nil {
+				return fmt.Errorf("failed to lock item: %w", err)
+			}
+
+			_, err = tx.ExecContext(ctx, `
+				UPDATE player_items
+				SET location = $1, location_id = $2, updated_at = CURRENT_TIMESTAMP
+				WHERE id = $3
+			`, models.LocationAuction, auction.ID, auction.ItemID)
+			if err != nil {
+				return fmt.Errorf("failed to escrow item: %w", err)
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO marketplace_auctions (id, seller_id, seller_name, type, item_id, item_name,
+			                                  item_location, item_location_id, quantity, description,
+			                                  starting_bid, buyout_price, current_bid, start_time,
+			                                  end_time, status)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		`,
+			auction.ID,
+			auction.SellerID,
+			auction.SellerName,
+			auction.Type,
+			auction.ItemID,
+			auction.ItemName,
+			itemLocation,
+			itemLocationID,
+			auction.Quantity,
+			auction.Description,
+			auction.StartingBid,
+			auction.BuyoutPrice,
+			auction.CurrentBid,
+			auction.StartTime,
+			auction.EndTime,
+			auction.Status,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create auction: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// lockedAuction is the subset of an auction row read under FOR UPDATE
+type lockedAuction struct {
+	sellerID   uuid.UUID
+	auctionTyp models.AuctionType
+	itemID     uuid.UUID
+	currentBid int64
+	highBidder uuid.UUID
+	endTime    time.Time
+	status     string
+}
+
+// lockAuction reads and locks an auction row for the rest of the transaction
+func lockAuction(ctx context.Context, tx *sql.Tx, auctionID uuid.UUID) (*lockedAuction, error) {
+	var a lockedAuction
+	var highBidder sql.NullString
+
+	err := tx.QueryRowContext(ctx, `
+		SELECT seller_id, type, item_id, current_bid, high_bidder_id, end_time, status
+		FROM marketplace_auctions
+		WHERE id = $1
+		FOR UPDATE
+	`, auctionID).Scan(&a.sellerID, &a.auctionTyp, &a.itemID, &a.currentBid, &highBidder, &a.endTime, &a.status)
+	if err == sql.ErrNoRows {
+		return nil, ErrListingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock auction: %w", err)
+	}
+
+	a.highBidder = uuidFromNull(highBidder)
+	return &a, nil
+}
+
+// PlaceBid takes the bid from the bidder, refunds the previous high bidder
+// and records the bid. minBid is the lowest acceptable amount.
+func (r *MarketplaceRepository) PlaceBid(ctx context.Context, auctionID uuid.UUID, bid models.MarketBid, minBid int64) ([]CreditChange, error) {
+	var changes []CreditChange
+
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		changes = nil
+
+		auction, err := lockAuction(ctx, tx, auctionID)
+		if err != nil {
+			return err
+		}
+		if auction.status != "active" || time.Now().After(auction.endTime) {
+			return ErrListingClosed
+		}
+		if bid.Amount < minBid || bid.Amount <= auction.currentBid {
+			return ErrBidTooLow
+		}
+
+		change, err := adjustCredits(ctx, tx, bid.BidderID, -bid.Amount, "auction bid")
+		if err != nil {
+			return err
+		}
+		changes = append(changes, change)
+
+		if auction.highBidder != uuid.Nil && auction.currentBid > 0 {
+			refund, err := adjustCredits(ctx, tx, auction.highBidder, auction.currentBid, "outbid refund")
+			if err != nil {
+				return err
+			}
+			changes = append(changes, refund)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE marketplace_auctions
+			SET current_bid = $1, high_bidder_id = $2, high_bidder_name = $3
+			WHERE id = $4
+		`, bid.Amount, bid.BidderID, bid.BidderName, auctionID)
+		if err != nil {
+			return fmt.Errorf("failed to update auction: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO marketplace_bids (auction_id, bidder_id, bidder_name, amount, placed_at)
+			VALUES ($1, $2, $3, $4, $5)
+		`, auctionID, bid.BidderID, bid.BidderName, bid.Amount, bid.Timestamp)
+		if err != nil {
+			return fmt.Errorf("failed to record bid: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// Buyout sells an auction at its buyout price: the buyer pays, any high
+// bidder is refunded, the seller is paid less the fee and the item is delivered.
+func (r *MarketplaceRepository) Buyout(ctx context.Context, auctionID, buyerID uuid.UUID, buyerName string, price, fee int64) ([]CreditChange, error) {
+	var changes []CreditChange
+
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		changes = nil
+
+		auction, err := lockAuction(ctx, tx, auctionID)
+		if err != nil {
+			return err
+		}
+		if auction.status != "active" || time.Now().After(auction.endTime) {
+			return ErrListingClosed
+		}
+
+		change, err := adjustCredits(ctx, tx, buyerID, -price, "auction buyout")
+		if err != nil {
+			return err
+		}
+		changes = append(changes, change)
+
+		if auction.highBidder != uuid.Nil && auction.currentBid > 0 {
+			refund, err := adjustCredits(ctx, tx, auction.highBidder, auction.currentBid, "outbid refund")
+			if err != nil {
+				return err
+			}
+			changes = append(changes, refund)
+		}
+
+		if auction.auctionTyp.EscrowsItem() {
+			delivered, err := deliverAuctionItem(ctx, tx, auctionID, auction.itemID, buyerID)
+			if err != nil {
+				return err
+			}
+			if !delivered {
+				return ErrItemNotAvailable
+			}
+		}
+
+		payout, err := adjustCredits(ctx, tx, auction.sellerID, price-fee, "auction sale")
+		if err != nil {
+			return err
+		}
+		changes = append(changes, payout)
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE marketplace_auctions
+			SET status = 'sold', current_bid = $1, high_bidder_id = $2, high_bidder_name = $3
+			WHERE id = $4
+		`, price, buyerID, buyerName, auctionID)
+		if err != nil {
+			return fmt.Errorf("failed to update auction: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// SettleAuction closes an auction whose end time has passed.
+//
+// With a high bidder the item goes to the winner and the seller is paid
+// the winning bid less feePercent. If the escrowed item has gone missing
+// the winner's bid is refunded instead. Without bids the item returns to
+// the seller. Already-settled auctions are left alone.
+//
+// Returns the auction's final status and the credit changes made.
+func (r *MarketplaceRepository) SettleAuction(ctx context.Context, auctionID uuid.UUID, feePercent float64) (string, []CreditChange, error) {
+	var status string
+	var changes []CreditChange
+
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		changes = nil
+
+		auction, err := lockAuction(ctx, tx, auctionID)
+		if err != nil {
+			return err
+		}
+		status = auction.status
+		if auction.status != "active" {
+			return nil
+		}
+
+		if auction.highBidder == uuid.Nil {
+			if auction.auctionTyp.EscrowsItem() {
+				if err := returnAuctionItem(ctx, tx, auctionID, auction.itemID); err != nil {
+					return err
+				}
+			}
+			status = "expired"
+		} else {
+			delivered := true
+			if auction.auctionTyp.EscrowsItem() {
+				delivered, err = deliverAuctionItem(ctx, tx, auctionID, auction.itemID, auction.highBidder)
+				if err != nil {
+					return err
+				}
+			}
+
+			if delivered {
+				fee := int64(float64(auction.currentBid) * feePercent)
+				payout, err := adjustCredits(ctx, tx, auction.sellerID, auction.currentBid-fee, "auction sale")
+				if err != nil {
+					return err
+				}
+				changes = append(changes, payout)
+				status = "sold"
+			} else {
+				refund, err := adjustCredits(ctx, tx, auction.highBidder, auction.currentBid, "auction refund")
+				if err != nil {
+					return err
+				}
+				changes = append(changes, refund)
+				status = "expired"
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE marketplace_auctions SET status = $1 WHERE id = $2
+		`, status, auctionID)
+		if err != nil {
+			return fmt.Errorf("failed to update auction: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return status, changes, nil
+}
+
+// CancelAuction cancels an auction without bids and returns its item
+func (r *MarketplaceRepository) CancelAuction(ctx context.Context, auctionID uuid.UUID) error {
+	return r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		auction, err := lockAuction(ctx, tx, auctionID)
+		if err != nil {
+			return err
+		}
+		if auction.status != "active" || auction.currentBid > 0 {
+			return ErrListingClosed
+		}
+
+		if auction.auctionTyp.EscrowsItem() {
+			if err := returnAuctionItem(ctx, tx, auctionID, auction.itemID); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE marketplace_auctions SET status = 'cancelled' WHERE id = $1
+		`, auctionID)
+		if err != nil {
+			return fmt.Errorf("failed to cancel auction: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// deliverAuctionItem transfers an escrowed item to the winner and logs the
+// transfer. The item goes to the winner's current ship, or to station
+// storage at their current planet if they have no ship. Returns false if
+// the item is no longer held by this auction.
+func deliverAuctionItem(ctx context.Context, tx *sql.Tx, auctionID, itemID, winnerID uuid.UUID) (bool, error) {
+	var sellerID uuid.UUID
+	err := tx.QueryRowContext(ctx, `
+		SELECT player_id FROM player_items
+		WHERE id = $1 AND location = $2 AND location_id = $3
+		FOR UPDATE
+	`, itemID, models.LocationAuction, auctionID).Scan(&sellerID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to lock auction item: %w", err)
+	}
+
+	var shipID, planetID sql.NullString
+	err = tx.QueryRowContext(ctx, `
+		SELECT ship_id, current_planet FROM players WHERE id = $1
+	`, winnerID).Scan(&shipID, &planetID)
+	if err == sql.ErrNoRows {
+		return false, ErrPlayerNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get winner location: %w", err)
+	}
+
+	location, locationID := models.LocationShip, shipID
+	if !shipID.Valid {
+		location, locationID = models.LocationStationStorage, planetID
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE player_items
+		SET player_id = $1, location = $2, location_id = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4
+	`, winnerID, location, locationID, itemID)
+	if err != nil {
+		return false, fmt.Errorf("failed to deliver auction item: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO item_transfers (item_id, from_player_id, to_player_id, transfer_type, transfer_id)
+		VALUES ($1, $2, $3, 'auction', $4)
+	`, itemID, sellerID, winnerID, auctionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to log transfer: %w", err)
+	}
+
+	return true, nil
+}
+
+// returnAuctionItem releases an escrowed item back to where the seller
+// listed it from
+func returnAuctionItem(ctx context.Context, tx *sql.Tx, auctionID, itemID uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE player_items
+		SET location = COALESCE(a.item_location, $1), location_id = a.item_location_id,
+		    updated_at = CURRENT_TIMESTAMP
+		FROM marketplace_auctions a
+		WHERE a.id = $2 AND player_items.id = $3
+		  AND player_items.location = $4 AND player_items.location_id = a.id
+	`, models.LocationStationStorage, auctionID, itemID, models.LocationAuction)
+	if err != nil {
+		return fmt.Errorf("failed to return auction item: %w", err)
+	}
+	return nil
+}
+
+// ============================================================================
+// CONTRACTS
+// ============================================================================
+
+// CreateContract takes the poster's deposit and inserts the contract
+func (r *MarketplaceRepository) CreateContract(ctx context.Context, contract *models.MarketContract) (CreditChange, error) {
+	var change CreditChange
+
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		change, err = adjustCredits(ctx, tx, contract.PosterID, -contract.Deposit, "contract deposit")
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO marketplace_contracts (id, poster_id, poster_name, type, title, description,
+			                                   reward, deposit, target_id, target_name,
+			                                   post_time, expiry_time, status)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		`,
+			contract.ID,
+			contract.PosterID,
+			contract.PosterName,
+			contract.Type,
+			contract.Title,
+			contract.Description,
+			contract.Reward,
+			contract.Deposit,
+			nullableUUID(contract.TargetID),
+			contract.TargetName,
+			contract.PostTime,
+			contract.ExpiryTime,
+			contract.Status,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create contract: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return CreditChange{}, err
+	}
+
+	return change, nil
+}
+
+// ClaimContract assigns an open contract to a claimer
+func (r *MarketplaceRepository) ClaimContract(ctx context.Context, contractID, claimerID uuid.UUID, claimerName string, claimTime time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE marketplace_contracts
+		SET status = 'claimed', claimed_by = $1, claimed_name = $2, claim_time = $3
+		WHERE id = $4 AND status = 'open'
+	`, claimerID, claimerName, claimTime, contractID)
+	if err != nil {
+		return fmt.Errorf("failed to claim contract: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrListingClosed
+	}
+
+	return nil
+}
+
+// lockContract reads and locks a contract's status, participants and amounts
+func lockContract(ctx context.Context, tx *sql.Tx, contractID uuid.UUID) (status string, posterID, claimedBy uuid.UUID, reward, deposit int64, err error) {
+	var claimed sql.NullString
+	err = tx.QueryRowContext(ctx, `
+		SELECT status, poster_id, claimed_by, reward, deposit
+		FROM marketplace_contracts
+		WHERE id = $1
+		FOR UPDATE
+	`, contractID).Scan(&status, &posterID, &claimed, &reward, &deposit)
+	if err == sql.ErrNoRows {
+		err = ErrListingNotFound
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to lock contract: %w", err)
+		return
+	}
+	claimedBy = uuidFromNull(claimed)
+	return
+}
+
+// CompleteContract pays the reward to the claimer and closes the contract
+func (r *MarketplaceRepository) CompleteContract(ctx context.Context, contractID, completerID uuid.UUID, completeTime time.Time) (CreditChange, error) {
+	var change CreditChange
+
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		status, _, claimedBy, reward, _, err := lockContract(ctx, tx, contractID)
+		if err != nil {
+			return err
+		}
+		if status != "claimed" || claimedBy != completerID {
+			return ErrListingClosed
+		}
+
+		change, err = adjustCredits(ctx, tx, completerID, reward, "contract reward")
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE marketplace_contracts SET status = 'completed', complete_time = $1 WHERE id = $2
+		`, completeTime, contractID)
+		if err != nil {
+			return fmt.Errorf("failed to complete contract: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return CreditChange{}, err
+	}
+
+	return change, nil
+}
+
+// FailContract charges the claimer a penalty (when they can afford it),
+// refunds the poster's deposit and closes the contract
+func (r *MarketplaceRepository) FailContract(ctx context.Context, contractID uuid.UUID, penaltyPercent float64) ([]CreditChange, error) {
+	var changes []CreditChange
+
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		changes = nil
+
+		status, posterID, claimedBy, reward, deposit, err := lockContract(ctx, tx, contractID)
+		if err != nil {
+			return err
+		}
+		if status != "claimed" {
+			return ErrListingClosed
+		}
+
+		if penalty := int64(float64(reward) * penaltyPercent); claimedBy != uuid.Nil && penalty > 0 {
+			change, err := adjustCredits(ctx, tx, claimedBy, -penalty, "contract penalty")
+			if err != nil && !errors.Is(err, ErrInsufficientCredits) {
+				return err
+			}
+			if err == nil {
+				changes = append(changes, change)
+			}
+		}
+
+		refund, err := adjustCredits(ctx, tx, posterID, deposit, "contract refund")
+		if err != nil {
+			return err
+		}
+		changes = append(changes, refund)
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE marketplace_contracts SET status = 'failed' WHERE id = $1
+		`, contractID)
+		if err != nil {
+			return fmt.Errorf("failed to fail contract: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// ExpireContract refunds the deposit of an unclaimed contract and closes it.
+// Returns ErrListingClosed if the contract is no longer open.
+func (r *MarketplaceRepository) ExpireContract(ctx context.Context, contractID uuid.UUID) (CreditChange, error) {
+	var change CreditChange
+
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		status, posterID, _, _, deposit, err := lockContract(ctx, tx, contractID)
+		if err != nil {
+			return err
+		}
+		if status != "open" {
+			return ErrListingClosed
+		}
+
+		change, err = adjustCredits(ctx, tx, posterID, deposit, "contract refund")
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE marketplace_contracts SET status = 'expired' WHERE id = $1
+		`, contractID)
+		if err != nil {
+			return fmt.Errorf("failed to expire contract: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return CreditChange{}, err
+	}
+
+	return change, nil
+}
+
+// ============================================================================
+// BOUNTIES
+// ============================================================================
+
+// PostBounty takes the bounty amount plus fee from the poster and inserts the bounty
+func (r *MarketplaceRepository) PostBounty(ctx context.Context, bounty *models.MarketBounty, totalCost int64) (CreditChange, error) {
+	var change CreditChange
+
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		change, err = adjustCredits(ctx, tx, bounty.PosterID, -totalCost, "bounty posted")
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO marketplace_bounties (id, poster_id, poster_name, target_id, target_name,
+			                                  amount, reason, post_time, expiry_time, status)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`,
+			bounty.ID,
+			bounty.PosterID,
+			bounty.PosterName,
+			bounty.TargetID,
+			bounty.TargetName,
+			bounty.Amount,
+			bounty.Reason,
+			bounty.PostTime,
+			bounty.ExpiryTime,
+			bounty.Status,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create bounty: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return CreditChange{}, err
+	}
+
+	return change, nil
+}
+
+// ClaimBounties pays every active bounty on the target to the killer.
+// Returns the IDs of the claimed bounties and the killer's credit change.
+func (r *MarketplaceRepository) ClaimBounties(ctx context.Context, targetID, killerID uuid.UUID, killerName string, claimTime time.Time) ([]uuid.UUID, CreditChange, error) {
+	var claimed []uuid.UUID
+	var change CreditChange
+
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		claimed = nil
+
+		rows, err := tx.QueryContext(ctx, `
+			SELECT id, amount, expiry_time
+			FROM marketplace_bounties
+			WHERE target_id = $1 AND status = 'active'
+			FOR UPDATE
+		`, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to lock bounties: %w", err)
+		}
+
+		var total int64
+		for rows.Next() {
+			var id uuid.UUID
+			var amount int64
+			var expiry time.Time
+			if err := rows.Scan(&id, &amount, &expiry); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan bounty: %w", err)
+			}
+			if claimTime.After(expiry) {
+				continue
+			}
+			claimed = append(claimed, id)
+			total += amount
+		}
+		if err := rows.Close(); err != nil {
+			return fmt.Errorf("failed to read bounties: %w", err)
+		}
+
+		if len(claimed) == 0 {
+			return ErrListingNotFound
+		}
+
+		change, err = adjustCredits(ctx, tx, killerID, total, "bounty reward")
+		if err != nil {
+			return err
+		}
+
+		for _, id := range claimed {
+			_, err := tx.ExecContext(ctx, `
+				UPDATE marketplace_bounties
+				SET status = 'claimed', claimed_by = $1, claimed_name = $2, claim_time = $3
+				WHERE id = $4
+			`, killerID, killerName, claimTime, id)
+			if err != nil {
+				return fmt.Errorf("failed to claim bounty: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, CreditChange{}, err
+	}
+
+	return claimed, change, nil
+}
+
+// ExpireBounty refunds an active bounty's amount to its poster and closes it.
+// Returns ErrListingClosed if the bounty is no longer active.
+func (r *MarketplaceRepository) ExpireBounty(ctx context.Context, bountyID uuid.UUID) (CreditChange, error) {
+	var change CreditChange
+
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var status string
+		var posterID uuid.UUID
+		var amount int64
+
+		err := tx.QueryRowContext(ctx, `
+			SELECT status, poster_id, amount FROM marketplace_bounties WHERE id = $1 FOR UPDATE
+		`, bountyID).Scan(&status, &posterID, &amount)
+		if err == sql.ErrNoRows {
+			return ErrListingNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock bounty: %w", err)
+		}
+		if status != "active" {
+			return ErrListingClosed
+		}
+
+		change, err = adjustCredits(ctx, tx, posterID, amount, "bounty refund")
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE marketplace_bounties SET status = 'expired' WHERE id = $1
+		`, bountyID)
+		if err != nil {
+			return fmt.Errorf("failed to expire bounty: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return CreditChange{}, err
+	}
+
+	return change, nil
+}
+
+// ============================================================================
+// LOADING
+// ============================================================================
+
+// GetOpenAuctions returns every active auction with its bid history,
+// including auctions whose end time has passed but are not yet settled
+func (r *MarketplaceRepository) GetOpenAuctions(ctx context.Context) ([]*models.MarketAuction, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, seller_id, seller_name, type, item_id, item_name, quantity, description,
+		       starting_bid, buyout_price, current_bid, high_bidder_id, high_bidder_name,
+		       start_time, end_time, status
+		FROM marketplace_auctions
+		WHERE status = 'active'
+		ORDER BY end_time
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query auctions: %w", err)
+	}
+	defer rows.Close()
+
+	var auctions []*models.MarketAuction
+	byID := make(map[uuid.UUID]*models.MarketAuction)
+
+	for rows.Next() {
+		var a models.MarketAuction
+		var description, highBidder, highBidderName sql.NullString
+
+		err := rows.Scan(
+			&a.ID, &a.SellerID, &a.SellerName, &a.Type, &a.ItemID, &a.ItemName, &a.Quantity, &description,
+			&a.StartingBid, &a.BuyoutPrice, &a.CurrentBid, &highBidder, &highBidderName,
+			&a.StartTime, &a.EndTime, &a.Status,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan auction: %w", err)
+		}
+
+		a.Description = description.String
+		a.HighBidder = uuidFromNull(highBidder)
+		a.HighBidderName = highBidderName.String
+		a.BidHistory = []models.MarketBid{}
+
+		auctions = append(auctions, &a)
+		byID[a.ID] = &a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating auctions: %w", err)
+	}
+
+	bidRows, err := r.db.QueryContext(ctx, `
+		SELECT b.auction_id, b.bidder_id, b.bidder_name, b.amount, b.placed_at
+		FROM marketplace_bids b
+		JOIN marketplace_auctions a ON a.id = b.auction_id
+		WHERE a.status = 'active'
+		ORDER BY b.placed_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bids: %w", err)
+	}
+	defer bidRows.Close()
+
+	for bidRows.Next() {
+		var auctionID uuid.UUID
+		var bidderID sql.NullString
+		var bid models.MarketBid
+		if err := bidRows.Scan(&auctionID, &bidderID, &bid.BidderName, &bid.Amount, &bid.Timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan bid: %w", err)
+		}
+		bid.BidderID = uuidFromNull(bidderID)
+		if auction, ok := byID[auctionID]; ok {
+			auction.BidHistory = append(auction.BidHistory, bid)
+		}
+	}
+	if err := bidRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bids: %w", err)
+	}
+
+	return auctions, nil
+}
+
+// GetOpenContracts returns every open or claimed contract
+func (r *MarketplaceRepository) GetOpenContracts(ctx context.Context) ([]*models.MarketContract, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, poster_id, poster_name, type, title, description, reward, deposit,
+		       target_id, target_name, claimed_by, claimed_name, post_time, expiry_time,
+		       claim_time, status
+		FROM marketplace_contracts
+		WHERE status IN ('open', 'claimed')
+		ORDER BY post_time
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contracts: %w", err)
+	}
+	defer rows.Close()
+
+	var contracts []*models.MarketContract
+	for rows.Next() {
+		var c models.MarketContract
+		var description, targetID, targetName, claimedBy, claimedName sql.NullString
+		var claimTime sql.NullTime
+
+		err := rows.Scan(
+			&c.ID, &c.PosterID, &c.PosterName, &c.Type, &c.Title, &description, &c.Reward, &c.Deposit,
+			&targetID, &targetName, &claimedBy, &claimedName, &c.PostTime, &c.ExpiryTime,
+			&claimTime, &c.Status,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan contract: %w", err)
+		}
+
+		c.Description = description.String
+		c.TargetID = uuidFromNull(targetID)
+		c.TargetName = targetName.String
+		c.ClaimedBy = uuidFromNull(claimedBy)
+		c.ClaimedName = claimedName.String
+		if claimTime.Valid {
+			c.ClaimTime = claimTime.Time
+		}
+
+		contracts = append(contracts, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating contracts: %w", err)
+	}
+
+	return contracts, nil
+}
+
+// GetActiveBounties returns every active bounty, including expired ones not yet refunded
+func (r *MarketplaceRepository) GetActiveBounties(ctx context.Context) ([]*models.MarketBounty, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, poster_id, poster_name, target_id, target_name, amount, reason,
+		       post_time, expiry_time, status
+		FROM marketplace_bounties
+		WHERE status = 'active'
+		ORDER BY post_time
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bounties: %w", err)
+	}
+	defer rows.Close()
+
+	var bounties []*models.MarketBounty
+	for rows.Next() {
+		var b models.MarketBounty
+		var reason sql.NullString
+
+		err := rows.Scan(
+			&b.ID, &b.PosterID, &b.PosterName, &b.TargetID, &b.TargetName, &b.Amount, &reason,
+			&b.PostTime, &b.ExpiryTime, &b.Status,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bounty: %w", err)
+		}
+		b.Reason = reason.String
+
+		bounties = append(bounties, &b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bounties: %w", err)
+	}
+
+	return bounties, nil
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+// adjustCredits changes a player's credits inside a transaction.
+// Debits fail with ErrInsufficientCredits rather than going negative.
+func adjustCredits(ctx context.Context, tx *sql.Tx, playerID uuid.UUID, delta int64, reason string) (CreditChange, error) {
+	var credits int64
+	var err error
+
+	if delta < 0 {
+		err = tx.QueryRowContext(ctx, `
+			UPDATE players SET credits = credits + $1
+			WHERE id = $2 AND credits >= -$1
+			RETURNING credits
+		`, delta, playerID).Scan(&credits)
+		if err == sql.ErrNoRows {
+			return CreditChange{}, ErrInsufficientCredits
+		}
+	} else {
+		err = tx.QueryRowContext(ctx, `
+			UPDATE players SET credits = credits + $1
+			WHERE id = $2
+			RETURNING credits
+		`, delta, playerID).Scan(&credits)
+		if err == sql.ErrNoRows {
+			return CreditChange{}, ErrPlayerNotFound
+		}
+	}
+	if err != nil {
+		return CreditChange{}, fmt.Errorf("failed to update credits: %w", err)
+	}
+
+	return CreditChange{PlayerID: playerID, Delta: delta, NewCredits: credits, Reason: reason}, nil
+}
+
+// uuidFromNull parses a nullable UUID column, returning uuid.Nil for NULL
+func uuidFromNull(ns sql.NullString) uuid.UUID {
+	if !ns.Valid {
+		return uuid.Nil
+	}
+	id, err := uuid.Parse(ns.String)
+	if err != nil {
+		return uuid.Nil
+	}
+	return id
+}
+
+// nullableUUID converts uuid.Nil to a SQL NULL
+func nullableUUID(id uuid.UUID) interface{} {
+	if id == uuid.Nil {
+		return nil
+	}
+	return id
+}
@@ -0,0 +1,199 @@
+// File: internal/database/marketplace_repository_test.go
+// Project: Terminal Velocity
+// Description: Integration tests for the marketplace repository
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestMarketplaceRepository_AuctionEscrowAndSettlement(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	players := NewPlayerRepository(db)
+	items := NewItemRepository(db)
+	repo := NewMarketplaceRepository(db)
+	ctx := context.Background()
+
+	suffix := uuid.New().String()[:8]
+	var ids []uuid.UUID
+	for _, name := range []string{"seller", "bidder1", "bidder2"} {
+		p, err := players.Create(ctx, "market_"+name+"_"+suffix, "testpassword123")
+		if err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		defer func() { _ = players.Delete(ctx, p.ID) }()
+		if err := players.UpdateCredits(ctx, p.ID, 10000); err != nil {
+			t.Fatalf("Failed to set credits: %v", err)
+		}
+		ids = append(ids, p.ID)
+	}
+	seller, bidder1, bidder2 := ids[0], ids[1], ids[2]
+
+	winnerShip := uuid.New()
+	if err := players.UpdateShip(ctx, bidder2, winnerShip); err != nil {
+		t.Fatalf("Failed to set ship: %v", err)
+	}
+
+	storage := uuid.New()
+	item := &models.PlayerItem{
+		PlayerID:    seller,
+		ItemType:    models.ItemTypeOutfit,
+		EquipmentID: "laser_cannon",
+		Location:    models.LocationStationStorage,
+		LocationID:  &storage,
+	}
+	if err := items.CreateItem(ctx, item); err != nil {
+		t.Fatalf("Failed to create item: %v", err)
+	}
+
+	auction := &models.MarketAuction{
+		ID:          uuid.New(),
+		SellerID:    seller,
+		SellerName:  "seller",
+		Type:        models.AuctionTypeOutfit,
+		ItemID:      item.ID,
+		ItemName:    "Laser Cannon",
+		Quantity:    1,
+		StartingBid: 1000,
+		StartTime:   time.Now(),
+		EndTime:     time.Now().Add(time.Hour),
+		Status:      "active",
+	}
+	if err := repo.CreateAuction(ctx, auction); err != nil {
+		t.Fatalf("Failed to create auction: %v", err)
+	}
+
+	// The item cannot be listed twice
+	again := *auction
+	again.ID = uuid.New()
+	if err := repo.CreateAuction(ctx, &again); !errors.Is(err, ErrItemNotAvailable) {
+		t.Errorf("Expected ErrItemNotAvailable, got %v", err)
+	}
+
+	escrowed, err := items.GetItemByID(ctx, item.ID)
+	if err != nil {
+		t.Fatalf("Failed to get item: %v", err)
+	}
+	if escrowed.Location != models.LocationAuction {
+		t.Errorf("Expected item in auction escrow, got %s", escrowed.Location)
+	}
+
+	if _, err := repo.PlaceBid(ctx, auction.ID, models.MarketBid{BidderID: bidder1, BidderName: "bidder1", Amount: 1500, Timestamp: time.Now()}, 1000); err != nil {
+		t.Fatalf("First bid failed: %v", err)
+	}
+	if _, err := repo.PlaceBid(ctx, auction.ID, models.MarketBid{BidderID: bidder2, BidderName: "bidder2", Amount: 1400, Timestamp: time.Now()}, 1500); !errors.Is(err, ErrBidTooLow) {
+		t.Errorf("Expected ErrBidTooLow, got %v", err)
+	}
+	changes, err := repo.PlaceBid(ctx, auction.ID, models.MarketBid{BidderID: bidder2, BidderName: "bidder2", Amount: 2000, Timestamp: time.Now()}, 1575)
+	if err != nil {
+		t.Fatalf("Second bid failed: %v", err)
+	}
+	if len(changes) != 2 || changes[1].PlayerID != bidder1 || changes[1].NewCredits != 10000 {
+		t.Errorf("Expected outbid refund to bidder1, got %+v", changes)
+	}
+
+	// Reload as the manager does on startup
+	open, err := repo.GetOpenAuctions(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load auctions: %v", err)
+	}
+	var loaded *models.MarketAuction
+	for _, a := range open {
+		if a.ID == auction.ID {
+			loaded = a
+		}
+	}
+	if loaded == nil || loaded.CurrentBid != 2000 || loaded.HighBidder != bidder2 || len(loaded.BidHistory) != 2 {
+		t.Fatalf("Unexpected reloaded auction: %+v", loaded)
+	}
+
+	status, changes, err := repo.SettleAuction(ctx, auction.ID, 0.05)
+	if err != nil {
+		t.Fatalf("Failed to settle auction: %v", err)
+	}
+	if status != "sold" || len(changes) != 1 || changes[0].PlayerID != seller || changes[0].NewCredits != 11900 {
+		t.Errorf("Unexpected settlement: %s %+v", status, changes)
+	}
+
+	delivered, err := items.GetItemByID(ctx, item.ID)
+	if err != nil {
+		t.Fatalf("Failed to get item: %v", err)
+	}
+	if delivered.PlayerID != bidder2 || delivered.Location != models.LocationShip ||
+		delivered.LocationID == nil || *delivered.LocationID != winnerShip {
+		t.Errorf("Item not delivered to winner's ship: %+v", delivered)
+	}
+
+	// Settling again is a no-op
+	if status, changes, err := repo.SettleAuction(ctx, auction.ID, 0.05); err != nil || status != "sold" || len(changes) != 0 {
+		t.Errorf("Expected idempotent settlement, got %s %+v %v", status, changes, err)
+	}
+}
+
+func TestMarketplaceRepository_ContractExpiryRefund(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	players := NewPlayerRepository(db)
+	repo := NewMarketplaceRepository(db)
+	ctx := context.Background()
+
+	poster, err := players.Create(ctx, "market_poster_"+uuid.New().String()[:8], "testpassword123")
+	if err != nil {
+		t.Fatalf("Failed to create poster: %v", err)
+	}
+	defer func() { _ = players.Delete(ctx, poster.ID) }()
+
+	if err := players.UpdateCredits(ctx, poster.ID, 1000); err != nil {
+		t.Fatalf("Failed to set credits: %v", err)
+	}
+
+	contract := &models.MarketContract{
+		ID:         uuid.New(),
+		PosterID:   poster.ID,
+		PosterName: "poster",
+		Type:       models.ContractTypeCourier,
+		Title:      "Deliver cargo",
+		Reward:     5000,
+		Deposit:    5250,
+		PostTime:   time.Now(),
+		ExpiryTime: time.Now().Add(time.Hour),
+		Status:     "open",
+	}
+	if _, err := repo.CreateContract(ctx, contract); !errors.Is(err, ErrInsufficientCredits) {
+		t.Errorf("Expected ErrInsufficientCredits, got %v", err)
+	}
+
+	contract.Reward, contract.Deposit = 500, 525
+	change, err := repo.CreateContract(ctx, contract)
+	if err != nil {
+		t.Fatalf("Failed to create contract: %v", err)
+	}
+	if change.NewCredits != 475 {
+		t.Errorf("Expected 475 credits after deposit, got %d", change.NewCredits)
+	}
+
+	change, err = repo.ExpireContract(ctx, contract.ID)
+	if err != nil {
+		t.Fatalf("Failed to expire contract: %v", err)
+	}
+	if change.NewCredits != 1000 {
+		t.Errorf("Expected deposit refunded to 1000, got %d", change.NewCredits)
+	}
+
+	if _, err := repo.ExpireContract(ctx, contract.ID); !errors.Is(err, ErrListingClosed) {
+		t.Errorf("Expected ErrListingClosed, got %v", err)
+	}
+}
@@ -1,7 +1,7 @@
 // File: internal/database/migrations.go
 // Project: Terminal Velocity
-// Description: Database schema migrations and version management
-// Version: 1.2.0
+// Description: Versioned schema migrations with up/down scripts and drift detection
+// Version: 2.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -9,48 +9,717 @@ package database
 
 import (
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
-	"os"
-	"path/filepath"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/game/universe"
 )
 
-// RunMigrations executes SQL migration files from the given path.
+// Migrations are SQL files embedded in the binary from internal/database/migrations:
+//
+//	NNNN_name.up.sql    applies the change
+//	NNNN_name.down.sql  reverts it
 //
-// This method loads and executes the schema.sql file to initialize
-// the database schema. For production use, consider using a proper
-// migration tool like golang-migrate or goose.
+// Versions are applied in ascending order, each in its own transaction, and
+// recorded in schema_migrations with a SHA-256 checksum of the up script.
+// A PostgreSQL advisory lock serializes migrators, so servers starting at the
+// same time apply each migration exactly once.
+//
+// The migrations are the source of truth for the schema. Migration 0001 is
+// the consolidated schema exactly as scripts/schema.sql defined it when
+// migrations started being tracked, and scripts/schema.sql is a frozen copy
+// of it. Databases created from scripts/schema.sql are baselined: 0001 is
+// recorded as applied without being run, and every later migration is
+// applied to them as usual. Schema changes therefore always go in a new
+// migration, never in 0001.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the pg_advisory_lock key held while migrating
+const migrationLockID int64 = 0x5456_4d49_4752 // "TVMIGR"
+
+// Migration errors
+var (
+	// ErrMigrationModified indicates an applied migration's script has changed
+	// since it was applied.
+	ErrMigrationModified = errors.New("applied migration has been modified")
+
+	// ErrUnknownMigration indicates the database has a migration applied that
+	// this build does not know about (the database is newer than the binary).
+	ErrUnknownMigration = errors.New("database has unknown migration applied")
+
+	// ErrNoMigrationToRevert indicates Down was called with nothing applied.
+	ErrNoMigrationToRevert = errors.New("no applied migration to revert")
+)
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string // SQL applying the change
+	Down     string // SQL reverting the change
+	Checksum string // Hex SHA-256 of Up
+}
+
+// MigrationStatus describes one migration's state in the database
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	Modified  bool // Applied with a different checksum than the embedded script
+	Unknown   bool // Applied in the database but not embedded in this build
+}
+
+// SchemaDrift reports differences between the live schema and the schema the
+// applied migrations produce. Column entries are "table.column type".
+type SchemaDrift struct {
+	PendingMigrations  []int
+	ModifiedMigrations []int
+	UnknownMigrations  []int
+
+	MissingTables  []string // Expected but absent from the database
+	ExtraTables    []string // Present in the database but not expected
+	MissingColumns []string
+	ExtraColumns   []string
+	ChangedColumns []string // "table.column expected -> actual"
+	MissingIndexes []string
+	ExtraIndexes   []string
+}
+
+// HasDrift returns true if the live schema differs from the applied migrations.
+// Pending migrations are not drift.
+func (d *SchemaDrift) HasDrift() bool {
+	return len(d.ModifiedMigrations) > 0 || len(d.UnknownMigrations) > 0 ||
+		len(d.MissingTables) > 0 || len(d.ExtraTables) > 0 ||
+		len(d.MissingColumns) > 0 || len(d.ExtraColumns) > 0 || len(d.ChangedColumns) > 0 ||
+		len(d.MissingIndexes) > 0 || len(d.ExtraIndexes) > 0
+}
+
+// LoadMigrations reads NNNN_name.up.sql / NNNN_name.down.sql pairs from dir
+// in fsys, sorted by version. Every version needs both scripts.
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		m := byVersion[version]
+		if m == nil {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			sum := sha256.Sum256(content)
+			m.Up = string(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both up and down scripts", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Migrator applies, reverts and verifies schema migrations
+type Migrator struct {
+	db         *DB
+	migrations []Migration
+}
+
+// NewMigrator creates a migrator for the migrations embedded in the binary
+func NewMigrator(db *DB) (*Migrator, error) {
+	migrations, err := LoadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Migrations returns the known migrations in version order
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// appliedMigration is a row of schema_migrations
+type appliedMigration struct {
+	version   int
+	name      string
+	appliedAt time.Time
+	checksum  string
+}
+
+// querier is satisfied by *sql.Conn and *sql.Tx
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// withLock runs fn on a dedicated connection holding the migration advisory
+// lock, after making sure schema_migrations exists. Blocks until the lock is
+// available or ctx is done.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID); err != nil {
+			log.Warn("Failed to release migration lock: %v", err)
+		}
+	}()
+
+	// Same definition as scripts/schema.sql; its index is created by 0001
+	_, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			id SERIAL PRIMARY KEY,
+			version INTEGER UNIQUE NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			checksum VARCHAR(64)
+		)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// applied returns the rows of schema_migrations in version order
+func applied(ctx context.Context, q querier) ([]appliedMigration, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT version, name, COALESCE(applied_at, CURRENT_TIMESTAMP), COALESCE(checksum, '')
+		FROM schema_migrations
+		ORDER BY version`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var result []appliedMigration
+	for rows.Next() {
+		var a appliedMigration
+		if err := rows.Scan(&a.version, &a.name, &a.appliedAt, &a.checksum); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		result = append(result, a)
+	}
+	return result, rows.Err()
+}
+
+// status merges the known migrations with the applied rows
+func (m *Migrator) status(rows []appliedMigration) []MigrationStatus {
+	appliedByVersion := make(map[int]appliedMigration, len(rows))
+	for _, a := range rows {
+		appliedByVersion[a.version] = a
+	}
+
+	var result []MigrationStatus
+	known := make(map[int]bool, len(m.migrations))
+	for _, mig := range m.migrations {
+		known[mig.Version] = true
+		s := MigrationStatus{Version: mig.Version, Name: mig.Name}
+		if a, ok := appliedByVersion[mig.Version]; ok {
+			s.Applied = true
+			s.AppliedAt = a.appliedAt
+			s.Modified = a.checksum != "" && a.checksum != mig.Checksum
+		}
+		result = append(result, s)
+	}
+	for _, a := range rows {
+		if !known[a.version] {
+			result = append(result, MigrationStatus{
+				Version: a.version, Name: a.name, Applied: true, AppliedAt: a.appliedAt, Unknown: true,
+			})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+
+	return result
+}
+
+// Status returns the state of every known or applied migration
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var result []MigrationStatus
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		rows, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		result = m.status(rows)
+		return nil
+	})
+	return result, err
+}
+
+// Up applies all pending migrations in order and returns the ones applied.
+// Refuses to run if an applied migration was modified or is unknown.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		rows, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if len(rows) == 0 && len(m.migrations) > 0 {
+			baselined, err := m.baseline(ctx, conn)
+			if err != nil {
+				return err
+			}
+			if baselined {
+				if rows, err = applied(ctx, conn); err != nil {
+					return err
+				}
+			}
+		}
+
+		isApplied := make(map[int]bool)
+		for _, s := range m.status(rows) {
+			switch {
+			case s.Unknown:
+				return fmt.Errorf("%w: %04d_%s", ErrUnknownMigration, s.Version, s.Name)
+			case s.Modified:
+				return fmt.Errorf("%w: %04d_%s", ErrMigrationModified, s.Version, s.Name)
+			}
+			isApplied[s.Version] = s.Applied
+		}
+
+		for _, mig := range m.migrations {
+			if isApplied[mig.Version] {
+				continue
+			}
+			if err := m.apply(ctx, conn, mig); err != nil {
+				return err
+			}
+			log.Info("Applied migration %04d_%s", mig.Version, mig.Name)
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// baseline records the first migration as applied if its schema already
+// exists (a database created from scripts/schema.sql). Returns true if it did.
+func (m *Migrator) baseline(ctx context.Context, conn *sql.Conn) (bool, error) {
+	var exists bool
+	if err := conn.QueryRowContext(ctx, `SELECT to_regclass('players') IS NOT NULL`).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to inspect schema: %w", err)
+	}
+	if !exists {
+		return false, nil
+	}
+
+	first := m.migrations[0]
+	_, err := conn.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+		first.Version, first.Name, first.Checksum)
+	if err != nil {
+		return false, fmt.Errorf("failed to baseline schema: %w", err)
+	}
+
+	log.Info("Existing schema baselined at migration %04d_%s", first.Version, first.Name)
+	return true, nil
+}
+
+// apply runs one up script and records it in a single transaction
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %04d: %w", mig.Version, err)
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	if _, err := tx.ExecContext(ctx, mig.Up); err != nil {
+		return fmt.Errorf("migration %04d_%s failed: %w", mig.Version, mig.Name, err)
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+		mig.Version, mig.Name, mig.Checksum)
+	if err != nil {
+		return fmt.Errorf("failed to record migration %04d: %w", mig.Version, err)
+	}
+
+	return tx.Commit()
+}
+
+// Down reverts the most recently applied migrations, up to steps of them,
+// and returns the ones reverted (newest first).
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	byVersion := make(map[int]Migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		byVersion[mig.Version] = mig
+	}
+
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		rows, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return ErrNoMigrationToRevert
+		}
+
+		for i := len(rows) - 1; i >= 0 && len(done) < steps; i-- {
+			mig, ok := byVersion[rows[i].version]
+			if !ok {
+				return fmt.Errorf("%w: %04d_%s", ErrUnknownMigration, rows[i].version, rows[i].name)
+			}
+			if err := m.revert(ctx, conn, mig); err != nil {
+				return err
+			}
+			log.Info("Reverted migration %04d_%s", mig.Version, mig.Name)
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// revert runs one down script and removes its record in a single transaction
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin revert of %04d: %w", mig.Version, err)
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op after commit
+
+	if _, err := tx.ExecContext(ctx, mig.Down); err != nil {
+		return fmt.Errorf("revert of %04d_%s failed: %w", mig.Version, mig.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version); err != nil {
+		return fmt.Errorf("failed to unrecord migration %04d: %w", mig.Version, err)
+	}
+
+	return tx.Commit()
+}
+
+// Verify compares the live schema with the schema its applied migrations
+// produce. The expected schema is built by replaying those migrations into a
+// scratch schema inside a transaction that is always rolled back.
+func (m *Migrator) Verify(ctx context.Context) (*SchemaDrift, error) {
+	drift := &SchemaDrift{}
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		rows, err := applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		isApplied := make(map[int]bool)
+		for _, s := range m.status(rows) {
+			switch {
+			case s.Unknown:
+				drift.UnknownMigrations = append(drift.UnknownMigrations, s.Version)
+			case !s.Applied:
+				drift.PendingMigrations = append(drift.PendingMigrations, s.Version)
+			case s.Modified:
+				drift.ModifiedMigrations = append(drift.ModifiedMigrations, s.Version)
+			}
+			isApplied[s.Version] = s.Applied && !s.Unknown
+		}
+
+		var liveSchema string
+		if err := conn.QueryRowContext(ctx, `SELECT current_schema()`).Scan(&liveSchema); err != nil {
+			return fmt.Errorf("failed to get current schema: %w", err)
+		}
+		live, err := snapshotSchema(ctx, conn, liveSchema)
+		if err != nil {
+			return err
+		}
+
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin verification: %w", err)
+		}
+		defer tx.Rollback() //nolint:errcheck // always rolled back
+
+		scratch := fmt.Sprintf("tv_verify_%d", time.Now().UnixNano())
+		if _, err := tx.ExecContext(ctx, `CREATE SCHEMA `+scratch); err != nil {
+			return fmt.Errorf("failed to create scratch schema: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`SET LOCAL search_path TO %s, %s`, scratch, liveSchema)); err != nil {
+			return fmt.Errorf("failed to set search path: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			CREATE TABLE schema_migrations (
+				id SERIAL PRIMARY KEY,
+				version INTEGER UNIQUE NOT NULL,
+				name VARCHAR(255) NOT NULL,
+				applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				checksum VARCHAR(64)
+			)`); err != nil {
+			return fmt.Errorf("failed to create scratch schema_migrations: %w", err)
+		}
+		for _, mig := range m.migrations {
+			if !isApplied[mig.Version] {
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, mig.Up); err != nil {
+				return fmt.Errorf("failed to replay migration %04d_%s: %w", mig.Version, mig.Name, err)
+			}
+		}
+
+		expected, err := snapshotSchema(ctx, tx, scratch)
+		if err != nil {
+			return err
+		}
+
+		drift.compare(expected, live)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return drift, nil
+}
+
+// schemaSnapshot is the shape of one PostgreSQL schema
+type schemaSnapshot struct {
+	tables  map[string]bool
+	columns map[string]string // "table.column" -> type description
+	indexes map[string]bool
+}
+
+// snapshotSchema reads the tables, columns and indexes of a schema
+func snapshotSchema(ctx context.Context, q querier, schema string) (*schemaSnapshot, error) {
+	snap := &schemaSnapshot{
+		tables:  make(map[string]bool),
+		columns: make(map[string]string),
+		indexes: make(map[string]bool),
+	}
+
+	rows, err := q.QueryContext(ctx, `
+		SELECT table_name FROM information_schema.tables
+		WHERE table_schema = $1 AND table_type = 'BASE TABLE'`, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tables: %w", err)
+	}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan table: %w", err)
+		}
+		snap.tables[name] = true
+	}
+	rows.Close()
+
+	rows, err = q.QueryContext(ctx, `
+		SELECT c.table_name, c.column_name, c.data_type,
+		       COALESCE(c.character_maximum_length, 0), c.is_nullable
+		FROM information_schema.columns c
+		JOIN information_schema.tables t
+		  ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+		WHERE c.table_schema = $1 AND t.table_type = 'BASE TABLE'`, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+	for rows.Next() {
+		var table, column, dataType, nullable string
+		var length int
+		if err := rows.Scan(&table, &column, &dataType, &length, &nullable); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan column: %w", err)
+		}
+		desc := dataType
+		if length > 0 {
+			desc = fmt.Sprintf("%s(%d)", dataType, length)
+		}
+		if nullable == "NO" {
+			desc += " not null"
+		}
+		snap.columns[table+"."+column] = desc
+	}
+	rows.Close()
+
+	rows, err = q.QueryContext(ctx, `SELECT indexname FROM pg_indexes WHERE schemaname = $1`, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read indexes: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan index: %w", err)
+		}
+		snap.indexes[name] = true
+	}
+
+	return snap, rows.Err()
+}
+
+// compare fills the schema differences between expected and live
+func (d *SchemaDrift) compare(expected, live *schemaSnapshot) {
+	d.MissingTables, d.ExtraTables = diffKeys(expected.tables, live.tables)
+	d.MissingIndexes, d.ExtraIndexes = diffKeys(expected.indexes, live.indexes)
+
+	for _, key := range sortedKeys(expected.columns) {
+		table, _, _ := strings.Cut(key, ".")
+		actual, ok := live.columns[key]
+		switch {
+		case !ok:
+			// Columns of missing tables are covered by MissingTables
+			if live.tables[table] {
+				d.MissingColumns = append(d.MissingColumns, key+" "+expected.columns[key])
+			}
+		case actual != expected.columns[key]:
+			d.ChangedColumns = append(d.ChangedColumns, fmt.Sprintf("%s %s -> %s", key, expected.columns[key], actual))
+		}
+	}
+	for _, key := range sortedKeys(live.columns) {
+		table, _, _ := strings.Cut(key, ".")
+		if _, ok := expected.columns[key]; !ok && expected.tables[table] {
+			d.ExtraColumns = append(d.ExtraColumns, key+" "+live.columns[key])
+		}
+	}
+}
+
+// diffKeys returns the sorted keys only in expected and only in actual
+func diffKeys(expected, actual map[string]bool) (missing, extra []string) {
+	for _, key := range sortedKeys(expected) {
+		if !actual[key] {
+			missing = append(missing, key)
+		}
+	}
+	for _, key := range sortedKeys(actual) {
+		if !expected[key] {
+			extra = append(extra, key)
+		}
+	}
+	return missing, extra
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RunMigrations applies all pending embedded migrations.
+//
+// Safe to call from several servers at once: the migration advisory lock
+// makes later callers wait and then find nothing left to apply.
 //
 // Parameters:
 //   - ctx: Context for timeout and cancellation
-//   - migrationsPath: Directory path containing schema.sql
 //
 // Returns:
-//   - error: File read error or SQL execution error
+//   - error: Migration load, lock or SQL execution error
+func (db *DB) RunMigrations(ctx context.Context) error {
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	_, err = migrator.Up(ctx)
+	return err
+}
+
+// LoadUniverseData loads a universe file (JSON or YAML, see universe.File)
+// into the database.
 //
-// Thread-safety:
-//   - Safe to call, but should only be run during server initialization
-//   - Running migrations while server is active may cause issues
-func (db *DB) RunMigrations(ctx context.Context, migrationsPath string) error {
-	// Read the schema file
-	schemaFile := filepath.Join(migrationsPath, "schema.sql")
-	content, err := os.ReadFile(schemaFile)
+// The file is validated before anything is written. Loading is idempotent:
+// systems and planets are upserted by ID, routes and existing market prices
+// are left untouched, so the same file can be loaded repeatedly to reproduce
+// a galaxy on another environment.
+//
+// Parameters:
+//   - ctx: Context for timeout and cancellation
+//   - data: Encoded universe file, typically written by genmap -out
+//
+// Returns:
+//   - error: Parse, validation or database error
+func (db *DB) LoadUniverseData(ctx context.Context, data io.Reader) error {
+	file, err := universe.Decode(data)
 	if err != nil {
-		return fmt.Errorf("failed to read schema file: %w", err)
+		return err
 	}
+	return db.ImportUniverse(ctx, file)
+}
 
-	// Execute the schema
-	if _, err := db.ExecContext(ctx, string(content)); err != nil {
-		return fmt.Errorf("failed to execute schema: %w", err)
+// ImportUniverse writes a decoded universe file to the database.
+//
+// Data is loaded in foreign key order (systems, planets, jump routes, markets)
+// through the repository bulk methods, each in its own transaction. Because
+// every step is idempotent, a partially failed import is completed by simply
+// running it again. Governments are validated against the file but not
+// stored; they are defined in code (models.StandardNPCFactions).
+func (db *DB) ImportUniverse(ctx context.Context, file *universe.File) error {
+	if err := file.Validate(); err != nil {
+		return err
 	}
 
-	return nil
-}
+	systemRepo := NewSystemRepository(db)
+	marketRepo := NewMarketRepository(db)
+
+	if err := systemRepo.BulkCreateSystems(ctx, file.StarSystems()); err != nil {
+		return fmt.Errorf("failed to load systems: %w", err)
+	}
+	if err := systemRepo.BulkCreatePlanets(ctx, file.Planets()); err != nil {
+		return fmt.Errorf("failed to load planets: %w", err)
+	}
+	if err := systemRepo.BulkCreateJumpRoutes(ctx, file.JumpRoutes()); err != nil {
+		return fmt.Errorf("failed to load jump routes: %w", err)
+	}
+	if err := marketRepo.BulkSeedMarketPrices(ctx, file.MarketPrices(time.Now().Unix())); err != nil {
+		return fmt.Errorf("failed to load markets: %w", err)
+	}
 
-// LoadUniverseData loads universe data from the generator into the database
-func (db *DB) LoadUniverseData(ctx context.Context, systemsJSON io.Reader) error {
-	// This will be implemented later when we integrate the universe generator
-	// For now, it's a placeholder
+	log.Info("Loaded universe: systems=%d, routes=%d", len(file.Systems), len(file.Routes))
 	return nil
 }
 
@@ -88,6 +757,7 @@ func (db *DB) ClearDatabase(ctx context.Context) error {
 		"star_systems",
 		"player_reputation",
 		"players",
+		"schema_migrations",
 	}
 
 	for _, table := range tables {
@@ -100,7 +770,7 @@ func (db *DB) ClearDatabase(ctx context.Context) error {
 	return nil
 }
 
-// GetSchemaVersion returns the current schema version
+// GetSchemaVersion returns the highest applied migration version
 func (db *DB) GetSchemaVersion(ctx context.Context) (int, error) {
 	var version int
 	query := `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`
@@ -0,0 +1,47 @@
+-- Reverts 0001_initial_schema: drops every game table.
+-- schema_migrations is kept; the migrator removes this migration's record.
+
+DROP TABLE IF EXISTS item_transfers CASCADE;
+DROP TABLE IF EXISTS player_items CASCADE;
+DROP TABLE IF EXISTS trusted_devices CASCADE;
+DROP TABLE IF EXISTS player_security_settings CASCADE;
+DROP TABLE IF EXISTS rate_limit_tracking CASCADE;
+DROP TABLE IF EXISTS honeypot_attempts CASCADE;
+DROP TABLE IF EXISTS player_sessions CASCADE;
+DROP TABLE IF EXISTS login_history CASCADE;
+DROP TABLE IF EXISTS admin_ip_whitelist CASCADE;
+DROP TABLE IF EXISTS password_reset_tokens CASCADE;
+DROP TABLE IF EXISTS player_two_factor CASCADE;
+DROP TABLE IF EXISTS account_events CASCADE;
+DROP TABLE IF EXISTS player_notifications CASCADE;
+DROP TABLE IF EXISTS player_blocks CASCADE;
+DROP TABLE IF EXISTS friend_requests CASCADE;
+DROP TABLE IF EXISTS player_friends CASCADE;
+DROP TABLE IF EXISTS loadout_favorites CASCADE;
+DROP TABLE IF EXISTS shared_loadouts CASCADE;
+DROP TABLE IF EXISTS player_mail CASCADE;
+DROP TABLE IF EXISTS server_settings CASCADE;
+DROP TABLE IF EXISTS admin_actions CASCADE;
+DROP TABLE IF EXISTS player_mutes CASCADE;
+DROP TABLE IF EXISTS player_bans CASCADE;
+DROP TABLE IF EXISTS admin_users CASCADE;
+DROP TABLE IF EXISTS events CASCADE;
+DROP TABLE IF EXISTS chat_messages CASCADE;
+DROP TABLE IF EXISTS player_missions CASCADE;
+DROP TABLE IF EXISTS missions CASCADE;
+DROP TABLE IF EXISTS market_prices CASCADE;
+DROP TABLE IF EXISTS faction_reputation CASCADE;
+DROP TABLE IF EXISTS faction_officers CASCADE;
+DROP TABLE IF EXISTS faction_members CASCADE;
+DROP TABLE IF EXISTS player_factions CASCADE;
+DROP TABLE IF EXISTS ship_outfits CASCADE;
+DROP TABLE IF EXISTS ship_weapons CASCADE;
+DROP TABLE IF EXISTS ship_cargo CASCADE;
+DROP TABLE IF EXISTS ships CASCADE;
+DROP TABLE IF EXISTS planets CASCADE;
+DROP TABLE IF EXISTS system_connections CASCADE;
+DROP TABLE IF EXISTS star_systems CASCADE;
+DROP TABLE IF EXISTS player_reputation CASCADE;
+DROP TABLE IF EXISTS player_ssh_keys CASCADE;
+DROP TABLE IF EXISTS players CASCADE;
+DROP INDEX IF EXISTS idx_migrations_version;
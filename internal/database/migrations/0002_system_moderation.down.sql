@@ -0,0 +1,10 @@
+-- Reverts 0002_system_moderation. Records made by the system have no
+-- admin to point at and are removed first.
+
+DELETE FROM player_bans WHERE banned_by IS NULL;
+DELETE FROM player_mutes WHERE muted_by IS NULL;
+DELETE FROM admin_actions WHERE admin_id IS NULL;
+
+ALTER TABLE player_bans ALTER COLUMN banned_by SET NOT NULL;
+ALTER TABLE player_mutes ALTER COLUMN muted_by SET NOT NULL;
+ALTER TABLE admin_actions ALTER COLUMN admin_id SET NOT NULL;
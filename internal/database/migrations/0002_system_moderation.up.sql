@@ -0,0 +1,6 @@
+-- Allows moderation records without a human admin (automated bans, the
+-- server bootstrapping its first admin). NULL means "system".
+
+ALTER TABLE player_bans ALTER COLUMN banned_by DROP NOT NULL;
+ALTER TABLE player_mutes ALTER COLUMN muted_by DROP NOT NULL;
+ALTER TABLE admin_actions ALTER COLUMN admin_id DROP NOT NULL;
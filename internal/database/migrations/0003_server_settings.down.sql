@@ -0,0 +1,4 @@
+ALTER TABLE server_settings
+    DROP CONSTRAINT IF EXISTS server_settings_updated_by_fkey,
+    ADD CONSTRAINT server_settings_updated_by_fkey
+        FOREIGN KEY (updated_by) REFERENCES players(id);
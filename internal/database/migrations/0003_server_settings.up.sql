@@ -0,0 +1,9 @@
+-- Server settings changed in the admin panel are stored in the single
+-- server_settings row created by 0001; fields missing from it keep their
+-- defaults. Clear the editor instead of refusing to delete an admin who
+-- last saved the settings.
+
+ALTER TABLE server_settings
+    DROP CONSTRAINT IF EXISTS server_settings_updated_by_fkey,
+    ADD CONSTRAINT server_settings_updated_by_fkey
+        FOREIGN KEY (updated_by) REFERENCES players(id) ON DELETE SET NULL;
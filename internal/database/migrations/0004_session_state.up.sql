@@ -0,0 +1,13 @@
+-- Saved game session state (screen, location, battle or jump in progress),
+-- written periodically and on disconnect so a player who reconnects within
+-- the resume grace window picks up where they left off.
+
+CREATE TABLE IF NOT EXISTS player_session_state (
+    player_id UUID PRIMARY KEY REFERENCES players(id) ON DELETE CASCADE,
+    state JSONB NOT NULL,
+    saved_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    disconnected_at TIMESTAMP
+);
+
+COMMENT ON TABLE player_session_state IS 'Last saved game session state per player, used to resume after a reconnect';
+COMMENT ON COLUMN player_session_state.disconnected_at IS 'When the session ended; NULL while connected or after a crash';
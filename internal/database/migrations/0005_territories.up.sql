@@ -0,0 +1,32 @@
+-- Faction territory claims with control, upkeep and activity state, so the
+-- territory simulation (influence, upkeep, contests and sieges) survives
+-- restarts. The active contest or siege, if any, is stored as JSON.
+
+CREATE TABLE IF NOT EXISTS territories (
+    id UUID PRIMARY KEY,
+    system_id UUID NOT NULL UNIQUE,
+    system_name VARCHAR(100) NOT NULL,
+    faction_id UUID NOT NULL REFERENCES player_factions(id) ON DELETE CASCADE,
+    faction_tag VARCHAR(10) NOT NULL,
+    control_level VARCHAR(20) NOT NULL,
+    control_points INTEGER NOT NULL DEFAULT 0,
+    claimed_at TIMESTAMP NOT NULL,
+    last_upkeep TIMESTAMP NOT NULL,
+    next_upkeep TIMESTAMP NOT NULL,
+    upkeep_cost BIGINT NOT NULL DEFAULT 0,
+    income BIGINT NOT NULL DEFAULT 0,
+    defense_level INTEGER NOT NULL DEFAULT 0,
+    development_level INTEGER NOT NULL DEFAULT 0,
+    has_station BOOLEAN NOT NULL DEFAULT FALSE,
+    member_activity INTEGER NOT NULL DEFAULT 0,
+    trade_volume BIGINT NOT NULL DEFAULT 0,
+    kills INTEGER NOT NULL DEFAULT 0,
+    last_conflict TIMESTAMP,
+    missed_upkeeps INTEGER NOT NULL DEFAULT 0,
+    contest JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_territories_faction ON territories(faction_id);
+
+COMMENT ON TABLE territories IS 'Star systems claimed by player factions';
+COMMENT ON COLUMN territories.contest IS 'Active contest or siege by a rival faction; NULL when uncontested';
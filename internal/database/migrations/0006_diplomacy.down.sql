@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS diplomatic_proposals;
+DROP TABLE IF EXISTS faction_relations;
+DROP TABLE IF EXISTS faction_treaties;
+DROP TABLE IF EXISTS faction_wars;
+DROP TABLE IF EXISTS faction_alliances;
@@ -0,0 +1,70 @@
+-- Diplomacy between player factions: alliances, wars, treaties, relation
+-- scores and proposals awaiting an answer, so agreements and war scores
+-- survive restarts. Faction lists and war scores are stored as JSON.
+-- truce_proposed_by and violated_by are NULL until a faction proposes a
+-- truce or breaks a treaty.
+
+CREATE TABLE IF NOT EXISTS faction_alliances (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    leader_faction_id UUID NOT NULL REFERENCES player_factions(id) ON DELETE CASCADE,
+    member_factions JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    treasury BIGINT NOT NULL DEFAULT 0,
+    last_maintenance TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS faction_wars (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    aggressor_factions JSONB NOT NULL DEFAULT '[]',
+    defender_factions JSONB NOT NULL DEFAULT '[]',
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    war_score JSONB NOT NULL DEFAULT '{}',
+    exhaustion DOUBLE PRECISION NOT NULL DEFAULT 0,
+    truce_proposed_by UUID REFERENCES player_factions(id) ON DELETE SET NULL,
+    truce_proposed_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS faction_treaties (
+    id UUID PRIMARY KEY,
+    type VARCHAR(30) NOT NULL,
+    faction1 UUID NOT NULL REFERENCES player_factions(id) ON DELETE CASCADE,
+    faction2 UUID NOT NULL REFERENCES player_factions(id) ON DELETE CASCADE,
+    terms TEXT NOT NULL DEFAULT '',
+    signed_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    violated_by UUID REFERENCES player_factions(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS faction_relations (
+    faction1 UUID NOT NULL REFERENCES player_factions(id) ON DELETE CASCADE,
+    faction2 UUID NOT NULL REFERENCES player_factions(id) ON DELETE CASCADE,
+    value DOUBLE PRECISION NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (faction1, faction2)
+);
+
+CREATE TABLE IF NOT EXISTS diplomatic_proposals (
+    id UUID PRIMARY KEY,
+    kind VARCHAR(20) NOT NULL,
+    treaty_type VARCHAR(30) NOT NULL DEFAULT '',
+    from_faction_id UUID NOT NULL REFERENCES player_factions(id) ON DELETE CASCADE,
+    to_faction_id UUID NOT NULL REFERENCES player_factions(id) ON DELETE CASCADE,
+    proposed_by UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_faction_treaties_factions ON faction_treaties(faction1, faction2);
+CREATE INDEX IF NOT EXISTS idx_diplomatic_proposals_to ON diplomatic_proposals(to_faction_id);
+
+COMMENT ON TABLE faction_alliances IS 'Alliances between player factions; member_factions excludes the leader';
+COMMENT ON TABLE faction_wars IS 'Wars between player factions; war_score maps faction ID to score';
+COMMENT ON TABLE diplomatic_proposals IS 'Alliance and treaty offers awaiting the other faction leader';
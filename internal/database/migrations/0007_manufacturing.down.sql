@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS player_technologies;
+DROP TABLE IF EXISTS player_stations;
+DROP TABLE IF EXISTS crafting_jobs;
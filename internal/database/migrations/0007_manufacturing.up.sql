@@ -0,0 +1,43 @@
+-- Player manufacturing: crafting jobs, player-owned stations and researched
+-- technology levels, so jobs keep running across restarts and finish while
+-- their player is offline. Station facilities and storage are stored as JSON.
+
+CREATE TABLE IF NOT EXISTS crafting_jobs (
+    id UUID PRIMARY KEY,
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    blueprint_id VARCHAR(50) NOT NULL,
+    quantity INTEGER NOT NULL,
+    station_id UUID,
+    start_time TIMESTAMP NOT NULL,
+    completion_time TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS player_stations (
+    id UUID PRIMARY KEY,
+    owner_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    owner_name VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    system_id UUID NOT NULL,
+    system_name VARCHAR(100) NOT NULL,
+    level INTEGER NOT NULL DEFAULT 1,
+    built_at TIMESTAMP NOT NULL,
+    facilities JSONB NOT NULL DEFAULT '[]',
+    storage JSONB NOT NULL DEFAULT '{}',
+    storage_capacity INTEGER NOT NULL DEFAULT 0,
+    production_bonus DOUBLE PRECISION NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS player_technologies (
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    tech_id VARCHAR(50) NOT NULL,
+    level INTEGER NOT NULL,
+    PRIMARY KEY (player_id, tech_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_crafting_jobs_player ON crafting_jobs(player_id);
+CREATE INDEX IF NOT EXISTS idx_player_stations_system ON player_stations(system_id);
+
+COMMENT ON TABLE crafting_jobs IS 'Crafting jobs; blueprint_id names a built-in blueprint';
+COMMENT ON TABLE player_stations IS 'Player-owned manufacturing stations, dockable in their system';
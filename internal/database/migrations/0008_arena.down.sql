@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS arena_seasons;
+DROP TABLE IF EXISTS arena_tournaments;
+DROP TABLE IF EXISTS arena_matches;
+DROP TABLE IF EXISTS arena_rankings;
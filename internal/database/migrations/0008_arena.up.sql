@@ -0,0 +1,39 @@
+-- Ranked arena: player rankings, match history, tournaments and seasons, so
+-- ratings and brackets survive restarts. Matches and tournaments are stored
+-- as JSON alongside the columns they are queried by.
+
+CREATE TABLE IF NOT EXISTS arena_rankings (
+    player_id UUID PRIMARY KEY REFERENCES players(id) ON DELETE CASCADE,
+    elo INTEGER NOT NULL,
+    data JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS arena_matches (
+    id UUID PRIMARY KEY,
+    status VARCHAR(20) NOT NULL,
+    season INTEGER NOT NULL,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP,
+    data JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS arena_tournaments (
+    id UUID PRIMARY KEY,
+    status VARCHAR(20) NOT NULL,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP,
+    data JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS arena_seasons (
+    number INTEGER PRIMARY KEY,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_arena_rankings_elo ON arena_rankings(elo DESC);
+CREATE INDEX IF NOT EXISTS idx_arena_matches_end ON arena_matches(end_time);
+CREATE INDEX IF NOT EXISTS idx_arena_tournaments_end ON arena_tournaments(end_time);
+
+COMMENT ON TABLE arena_matches IS 'Arena matches; finished matches are kept as history';
+COMMENT ON TABLE arena_seasons IS 'Ranked arena seasons; the highest number is current';
@@ -0,0 +1,2 @@
+ALTER TABLE players DROP COLUMN IF EXISTS resources_mined;
+DROP TABLE IF EXISTS mining_fields;
@@ -0,0 +1,17 @@
+-- Mining: how far each system's resource fields have been mined, so
+-- depletion survives restarts, and the resources each player has mined.
+-- The fields themselves are generated from the galaxy; only the amounts
+-- left in fields that have been mined are stored.
+
+CREATE TABLE IF NOT EXISTS mining_fields (
+    id UUID PRIMARY KEY,
+    system_id UUID NOT NULL REFERENCES star_systems(id) ON DELETE CASCADE,
+    remaining JSONB NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_mining_fields_system ON mining_fields(system_id);
+
+ALTER TABLE players ADD COLUMN IF NOT EXISTS resources_mined JSONB;
+
+COMMENT ON TABLE mining_fields IS 'Resource fields that have been mined; remaining amounts regrow from updated_at';
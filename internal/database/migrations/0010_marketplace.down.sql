@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS marketplace_bounties;
+DROP TABLE IF EXISTS marketplace_contracts;
+DROP TABLE IF EXISTS marketplace_bids;
+DROP TABLE IF EXISTS marketplace_auctions;
@@ -0,0 +1,88 @@
+-- Player marketplace: auctions, bid history, contracts and bounties, with
+-- escrowed items and credits held until they settle. Databases created from
+-- scripts/schema.sql between the marketplace landing and migrations being
+-- tracked already have these tables, so everything is created if missing.
+
+-- Marketplace auctions (escrowed items stay in player_items with location 'auction')
+CREATE TABLE IF NOT EXISTS marketplace_auctions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    seller_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    seller_name VARCHAR(50) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('ship', 'outfit', 'commodity', 'special')),
+    item_id UUID NOT NULL,
+    item_name VARCHAR(200) NOT NULL,
+    item_location VARCHAR(50), -- Where an escrowed item returns to if unsold
+    item_location_id UUID,
+    quantity INTEGER DEFAULT 1,
+    description TEXT,
+    starting_bid BIGINT NOT NULL,
+    buyout_price BIGINT DEFAULT 0,
+    current_bid BIGINT DEFAULT 0,
+    high_bidder_id UUID REFERENCES players(id) ON DELETE SET NULL,
+    high_bidder_name VARCHAR(50),
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'sold', 'expired', 'cancelled')),
+    CONSTRAINT auction_bids_non_negative CHECK (starting_bid > 0 AND current_bid >= 0 AND buyout_price >= 0)
+);
+
+-- Marketplace bid history
+CREATE TABLE IF NOT EXISTS marketplace_bids (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    auction_id UUID NOT NULL REFERENCES marketplace_auctions(id) ON DELETE CASCADE,
+    bidder_id UUID REFERENCES players(id) ON DELETE SET NULL,
+    bidder_name VARCHAR(50) NOT NULL,
+    amount BIGINT NOT NULL,
+    placed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Marketplace contracts (deposit held until completed, failed or expired)
+CREATE TABLE IF NOT EXISTS marketplace_contracts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    poster_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    poster_name VARCHAR(50) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('courier', 'assassination', 'escort', 'bounty_hunt')),
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    reward BIGINT NOT NULL,
+    deposit BIGINT NOT NULL,
+    target_id UUID,
+    target_name VARCHAR(100),
+    claimed_by UUID REFERENCES players(id) ON DELETE SET NULL,
+    claimed_name VARCHAR(50),
+    post_time TIMESTAMP NOT NULL,
+    expiry_time TIMESTAMP NOT NULL,
+    claim_time TIMESTAMP,
+    complete_time TIMESTAMP,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'claimed', 'completed', 'failed', 'expired')),
+    CONSTRAINT contract_reward_positive CHECK (reward > 0 AND deposit >= reward)
+);
+
+-- Marketplace bounties (amount held until claimed or expired)
+CREATE TABLE IF NOT EXISTS marketplace_bounties (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    poster_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    poster_name VARCHAR(50) NOT NULL,
+    target_id UUID NOT NULL,
+    target_name VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL,
+    reason TEXT,
+    post_time TIMESTAMP NOT NULL,
+    expiry_time TIMESTAMP NOT NULL,
+    claimed_by UUID REFERENCES players(id) ON DELETE SET NULL,
+    claimed_name VARCHAR(50),
+    claim_time TIMESTAMP,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'claimed', 'expired')),
+    CONSTRAINT bounty_amount_positive CHECK (amount > 0)
+);
+
+-- Indexes for marketplace
+CREATE INDEX IF NOT EXISTS idx_marketplace_auctions_status ON marketplace_auctions(status, end_time);
+CREATE INDEX IF NOT EXISTS idx_marketplace_bids_auction ON marketplace_bids(auction_id, placed_at);
+CREATE INDEX IF NOT EXISTS idx_marketplace_contracts_status ON marketplace_contracts(status, expiry_time);
+CREATE INDEX IF NOT EXISTS idx_marketplace_bounties_target ON marketplace_bounties(target_id, status);
+
+COMMENT ON TABLE marketplace_auctions IS 'Player auctions with escrowed items and high bids';
+COMMENT ON TABLE marketplace_bids IS 'Bid history for marketplace auctions';
+COMMENT ON TABLE marketplace_contracts IS 'Player-posted contracts with escrowed deposits';
+COMMENT ON TABLE marketplace_bounties IS 'Player-posted bounties with escrowed rewards';
@@ -0,0 +1,2 @@
+ALTER TABLE players DROP COLUMN IF EXISTS total_crafts;
+ALTER TABLE players DROP COLUMN IF EXISTS crafting_skill;
@@ -0,0 +1,9 @@
+-- Crafting progression read with every player and raised by completed
+-- manufacturing jobs. These columns were added by the archived migration
+-- 017_add_crafting_skill_tracking but never made it into the baseline schema.
+
+ALTER TABLE players ADD COLUMN IF NOT EXISTS crafting_skill INTEGER DEFAULT 0 CHECK (crafting_skill >= 0 AND crafting_skill <= 100);
+ALTER TABLE players ADD COLUMN IF NOT EXISTS total_crafts INTEGER DEFAULT 0 CHECK (total_crafts >= 0);
+
+COMMENT ON COLUMN players.crafting_skill IS 'Player crafting skill level (0-100), affects crafting time and unlocks blueprints';
+COMMENT ON COLUMN players.total_crafts IS 'Total number of items successfully crafted by the player';
@@ -0,0 +1,229 @@
+// File: internal/database/migrations_test.go
+// Project: Terminal Velocity
+// Description: Tests for migration loading and schema drift comparison
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/0002_add_bans.up.sql":      {Data: []byte("CREATE TABLE bans (id INT);")},
+		"m/0002_add_bans.down.sql":    {Data: []byte("DROP TABLE bans;")},
+		"m/0001_initial.up.sql":       {Data: []byte("CREATE TABLE players (id INT);")},
+		"m/0001_initial.down.sql":     {Data: []byte("DROP TABLE players;")},
+		"missing/0001_only.up.sql":    {Data: []byte("SELECT 1;")},
+		"badname/schema.sql":          {Data: []byte("SELECT 1;")},
+		"conflict/0001_a.up.sql":      {Data: []byte("SELECT 1;")},
+		"conflict/0001_b.down.sql":    {Data: []byte("SELECT 1;")},
+		"conflict/0002_ok.up.sql":     {Data: []byte("SELECT 1;")},
+		"conflict/0002_ok.down.sql":   {Data: []byte("SELECT 1;")},
+		"empty/.keep":                 {Data: nil},
+		"other/0001_initial.down.sql": {Data: []byte("DROP TABLE players;")},
+	}
+
+	migrations, err := LoadMigrations(fsys, "m")
+	if err != nil {
+		t.Fatalf("LoadMigrations failed: %v", err)
+	}
+	if len(migrations) != 2 || migrations[0].Version != 1 || migrations[1].Name != "add_bans" {
+		t.Fatalf("Unexpected migrations: %+v", migrations)
+	}
+	if migrations[0].Down != "DROP TABLE players;" || len(migrations[0].Checksum) != 64 {
+		t.Errorf("Unexpected migration contents: %+v", migrations[0])
+	}
+
+	for _, dir := range []string{"missing", "badname", "conflict", "empty", "other"} {
+		if _, err := LoadMigrations(fsys, dir); err == nil {
+			t.Errorf("Expected error loading %s", dir)
+		}
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := LoadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		t.Fatalf("Embedded migrations are invalid: %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Version != 1 {
+		t.Fatalf("Expected migration 0001 first, got %+v", migrations)
+	}
+
+	// scripts/schema.sql is a frozen copy of the baseline migration, which
+	// databases created from it are baselined at
+	schema, err := os.ReadFile("../../scripts/schema.sql")
+	if err != nil {
+		t.Fatalf("Failed to read schema.sql: %v", err)
+	}
+	if string(schema) != migrations[0].Up {
+		t.Error("scripts/schema.sql differs from migration 0001")
+	}
+}
+
+func TestSchemaDriftCompare(t *testing.T) {
+	expected := &schemaSnapshot{
+		tables:  map[string]bool{"players": true, "ships": true},
+		columns: map[string]string{"players.id": "uuid not null", "players.name": "character varying(32)", "ships.id": "uuid not null"},
+		indexes: map[string]bool{"players_pkey": true, "idx_players_name": true},
+	}
+	live := &schemaSnapshot{
+		tables:  map[string]bool{"players": true, "scratch": true},
+		columns: map[string]string{"players.id": "uuid not null", "players.name": "text", "players.debug": "boolean", "scratch.id": "integer"},
+		indexes: map[string]bool{"players_pkey": true},
+	}
+
+	drift := &SchemaDrift{PendingMigrations: []int{2}}
+	drift.compare(expected, live)
+
+	want := &SchemaDrift{
+		PendingMigrations: []int{2},
+		MissingTables:     []string{"ships"},
+		ExtraTables:       []string{"scratch"},
+		ExtraColumns:      []string{"players.debug boolean"},
+		ChangedColumns:    []string{"players.name character varying(32) -> text"},
+		MissingIndexes:    []string{"idx_players_name"},
+	}
+	if !reflect.DeepEqual(drift, want) {
+		t.Errorf("Unexpected drift:\n got %+v\nwant %+v", drift, want)
+	}
+	if !drift.HasDrift() {
+		t.Error("Expected drift")
+	}
+
+	if (&SchemaDrift{PendingMigrations: []int{2}}).HasDrift() {
+		t.Error("Pending migrations alone are not drift")
+	}
+}
+
+// setupScratchDB creates an empty database for the test and drops it
+// afterwards. Skips if the test database is unavailable or the user cannot
+// create databases.
+func setupScratchDB(t *testing.T) *DB {
+	t.Helper()
+
+	admin := setupTestDB(t)
+	t.Cleanup(func() { admin.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	name := fmt.Sprintf("tv_migrate_%d", time.Now().UnixNano())
+	if _, err := admin.ExecContext(ctx, `CREATE DATABASE `+name); err != nil {
+		t.Skipf("Skipping migration tests: cannot create database: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.ExecContext(context.Background(), `DROP DATABASE IF EXISTS `+name); err != nil {
+			t.Logf("Failed to drop %s: %v", name, err)
+		}
+	})
+
+	cfg := testDBConfig()
+	cfg.Database = name
+	db, err := NewDB(cfg)
+	if err != nil {
+		t.Fatalf("Failed to connect to %s: %v", name, err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// checkMigratedSchema verifies a fully migrated database has no drift and
+// that players can be created and read back
+func checkMigratedSchema(t *testing.T, ctx context.Context, migrator *Migrator) {
+	t.Helper()
+
+	drift, err := migrator.Verify(ctx)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if drift.HasDrift() || len(drift.PendingMigrations) > 0 {
+		t.Fatalf("Unexpected drift after migrating: %+v", drift)
+	}
+
+	repo := NewPlayerRepository(migrator.db)
+	player, err := repo.Create(ctx, "pilot_"+fmt.Sprint(time.Now().UnixNano()%1e8), "password123")
+	if err != nil {
+		t.Fatalf("Failed to create player: %v", err)
+	}
+	if err := repo.RecordCrafting(ctx, player.ID, 1, 0, 10); err != nil {
+		t.Fatalf("RecordCrafting failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, player.ID); err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if _, err := repo.GetByUsername(ctx, player.Username); err != nil {
+		t.Fatalf("GetByUsername failed: %v", err)
+	}
+
+	var auctions int
+	if err := migrator.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM marketplace_auctions`).Scan(&auctions); err != nil {
+		t.Fatalf("Marketplace tables missing: %v", err)
+	}
+}
+
+func TestMigrateUpEmptyDatabase(t *testing.T) {
+	db := setupScratchDB(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator failed: %v", err)
+	}
+	done, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if len(done) != len(migrator.Migrations()) {
+		t.Fatalf("Expected every migration applied, got %d of %d", len(done), len(migrator.Migrations()))
+	}
+	checkMigratedSchema(t, ctx, migrator)
+
+	reverted, err := migrator.Down(ctx, len(done))
+	if err != nil {
+		t.Fatalf("Down failed: %v", err)
+	}
+	if len(reverted) != len(done) {
+		t.Fatalf("Expected every migration reverted, got %d of %d", len(reverted), len(done))
+	}
+}
+
+func TestMigrateUpBaselinedDatabase(t *testing.T) {
+	db := setupScratchDB(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// A database created from scripts/schema.sql before migrations were tracked
+	schema, err := os.ReadFile("../../scripts/schema.sql")
+	if err != nil {
+		t.Fatalf("Failed to read schema.sql: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, string(schema)); err != nil {
+		t.Fatalf("Failed to load schema.sql: %v", err)
+	}
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator failed: %v", err)
+	}
+	done, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if len(done) != len(migrator.Migrations())-1 {
+		t.Fatalf("Expected every migration after 0001 applied, got %d", len(done))
+	}
+	checkMigratedSchema(t, ctx, migrator)
+}
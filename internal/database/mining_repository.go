@@ -0,0 +1,87 @@
+// File: internal/database/mining_repository.go
+// Project: Terminal Velocity
+// Description: Repository for how far star system resource fields have been mined
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+)
+
+// MiningRepository handles database operations for resource fields.
+//
+// Tables:
+//   - mining_fields: the amounts left in fields that have been mined (JSONB)
+//
+// Fields are generated from the galaxy, so only the amounts left and when
+// they were last brought up to date are stored. The mining manager saves a
+// field after every cycle, so SaveField is an upsert.
+//
+// Thread-safety:
+//   - All methods are thread-safe
+type MiningRepository struct {
+	db *DB // Database connection pool
+}
+
+// NewMiningRepository creates a new mining repository
+func NewMiningRepository(db *DB) *MiningRepository {
+	return &MiningRepository{db: db}
+}
+
+// SaveField inserts or replaces the amounts left in a resource field
+func (r *MiningRepository) SaveField(ctx context.Context, field *models.ResourceField) error {
+	remaining, err := json.Marshal(field.Remaining)
+	if err != nil {
+		return fmt.Errorf("failed to encode field resources: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO mining_fields (id, system_id, remaining, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET
+			remaining = EXCLUDED.remaining,
+			updated_at = EXCLUDED.updated_at
+	`, field.ID, field.SystemID, remaining, field.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save resource field: %w", err)
+	}
+	return nil
+}
+
+// ListFields returns every stored resource field. Only ID, SystemID,
+// Remaining and UpdatedAt are set.
+func (r *MiningRepository) ListFields(ctx context.Context) ([]*models.ResourceField, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, system_id, remaining, updated_at
+		FROM mining_fields
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query resource fields: %w", err)
+	}
+	defer rows.Close()
+
+	var fields []*models.ResourceField
+	for rows.Next() {
+		var field models.ResourceField
+		var remaining []byte
+		if err := rows.Scan(&field.ID, &field.SystemID, &remaining, &field.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan resource field: %w", err)
+		}
+		if err := json.Unmarshal(remaining, &field.Remaining); err != nil {
+			return nil, fmt.Errorf("failed to decode field resources: %w", err)
+		}
+		fields = append(fields, &field)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating resource fields: %w", err)
+	}
+
+	return fields, nil
+}
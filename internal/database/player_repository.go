@@ -2,7 +2,7 @@
 // Project: Terminal Velocity
 // Description: Repository for player account management including authentication,
 //              credits, reputation, and account lifecycle operations
-// Version: 1.1.0
+// Version: 1.4.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -11,6 +11,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -34,6 +35,10 @@ var (
 	// username or password. Returns the same error for both cases to prevent
 	// username enumeration attacks.
 	ErrInvalidCredentials = errors.New("invalid username or password")
+
+	// ErrInsufficientResearch indicates the player has too few research
+	// points to pay for a technology.
+	ErrInsufficientResearch = errors.New("insufficient research points")
 )
 
 // PlayerRepository handles all database operations for player accounts.
@@ -259,22 +264,28 @@ func (r *PlayerRepository) Authenticate(ctx context.Context, username, password
 // GetByID retrieves a player by ID
 func (r *PlayerRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Player, error) {
 	query := `
-		SELECT id, username, credits, current_system, combat_rating,
+		SELECT id, username, credits, current_system, current_planet, ship_id, combat_rating,
 		       total_kills, is_online, is_criminal, faction_id, faction_rank, created_at,
-		       crafting_skill, total_crafts, research_points
+		       crafting_skill, total_crafts, research_points,
+		       COALESCE(total_capture_attempts, 0), COALESCE(successful_boards, 0),
+		       COALESCE(successful_captures, 0), COALESCE(total_mining_ops, 0),
+		       COALESCE(total_yield, 0), resources_mined
 		FROM players
 		WHERE id = $1
 	`
 
 	var player models.Player
-	var currentSystem, factionID sql.NullString
+	var currentSystem, currentPlanet, shipID, factionID sql.NullString
 	var factionRank sql.NullString
+	var resourcesMined []byte
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&player.ID,
 		&player.Username,
 		&player.Credits,
 		&currentSystem,
+		&currentPlanet,
+		&shipID,
 		&player.CombatRating,
 		&player.TotalKills,
 		&player.IsOnline,
@@ -285,6 +296,12 @@ func (r *PlayerRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.P
 		&player.CraftingSkill,
 		&player.TotalCrafts,
 		&player.ResearchPoints,
+		&player.TotalCaptureAttempts,
+		&player.SuccessfulBoards,
+		&player.SuccessfulCaptures,
+		&player.TotalMiningOps,
+		&player.TotalYield,
+		&resourcesMined,
 	)
 
 	if err != nil {
@@ -294,6 +311,12 @@ func (r *PlayerRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.P
 		return nil, fmt.Errorf("failed to query player: %w", err)
 	}
 
+	if len(resourcesMined) > 0 {
+		if err := json.Unmarshal(resourcesMined, &player.ResourcesMined); err != nil {
+			return nil, fmt.Errorf("failed to decode resources mined: %w", err)
+		}
+	}
+
 	// Handle nullable fields
 	if currentSystem.Valid {
 		sysID, err := uuid.Parse(currentSystem.String)
@@ -302,6 +325,20 @@ func (r *PlayerRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.P
 		}
 	}
 
+	if currentPlanet.Valid {
+		planetID, err := uuid.Parse(currentPlanet.String)
+		if err == nil {
+			player.CurrentPlanet = &planetID
+		}
+	}
+
+	if shipID.Valid {
+		sID, err := uuid.Parse(shipID.String)
+		if err == nil {
+			player.ShipID = sID
+		}
+	}
+
 	if factionID.Valid {
 		facID, err := uuid.Parse(factionID.String)
 		if err == nil {
@@ -321,22 +358,28 @@ func (r *PlayerRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.P
 // GetByUsername retrieves a player by username
 func (r *PlayerRepository) GetByUsername(ctx context.Context, username string) (*models.Player, error) {
 	query := `
-		SELECT id, username, credits, current_system, combat_rating,
+		SELECT id, username, credits, current_system, current_planet, ship_id, combat_rating,
 		       total_kills, is_online, is_criminal, faction_id, faction_rank, created_at,
-		       crafting_skill, total_crafts, research_points
+		       crafting_skill, total_crafts, research_points,
+		       COALESCE(total_capture_attempts, 0), COALESCE(successful_boards, 0),
+		       COALESCE(successful_captures, 0), COALESCE(total_mining_ops, 0),
+		       COALESCE(total_yield, 0), resources_mined
 		FROM players
 		WHERE username = $1
 	`
 
 	var player models.Player
-	var currentSystem, factionID sql.NullString
+	var currentSystem, currentPlanet, shipID, factionID sql.NullString
 	var factionRank sql.NullString
+	var resourcesMined []byte
 
 	err := r.db.QueryRowContext(ctx, query, username).Scan(
 		&player.ID,
 		&player.Username,
 		&player.Credits,
 		&currentSystem,
+		&currentPlanet,
+		&shipID,
 		&player.CombatRating,
 		&player.TotalKills,
 		&player.IsOnline,
@@ -347,6 +390,12 @@ func (r *PlayerRepository) GetByUsername(ctx context.Context, username string) (
 		&player.CraftingSkill,
 		&player.TotalCrafts,
 		&player.ResearchPoints,
+		&player.TotalCaptureAttempts,
+		&player.SuccessfulBoards,
+		&player.SuccessfulCaptures,
+		&player.TotalMiningOps,
+		&player.TotalYield,
+		&resourcesMined,
 	)
 
 	if err != nil {
@@ -356,6 +405,12 @@ func (r *PlayerRepository) GetByUsername(ctx context.Context, username string) (
 		return nil, fmt.Errorf("failed to query player: %w", err)
 	}
 
+	if len(resourcesMined) > 0 {
+		if err := json.Unmarshal(resourcesMined, &player.ResourcesMined); err != nil {
+			return nil, fmt.Errorf("failed to decode resources mined: %w", err)
+		}
+	}
+
 	// Handle nullable fields
 	if currentSystem.Valid {
 		sysID, err := uuid.Parse(currentSystem.String)
@@ -364,6 +419,20 @@ func (r *PlayerRepository) GetByUsername(ctx context.Context, username string) (
 		}
 	}
 
+	if currentPlanet.Valid {
+		planetID, err := uuid.Parse(currentPlanet.String)
+		if err == nil {
+			player.CurrentPlanet = &planetID
+		}
+	}
+
+	if shipID.Valid {
+		sID, err := uuid.Parse(shipID.String)
+		if err == nil {
+			player.ShipID = sID
+		}
+	}
+
 	if factionID.Valid {
 		facID, err := uuid.Parse(factionID.String)
 		if err == nil {
@@ -528,6 +597,154 @@ func (r *PlayerRepository) UpdateCredits(ctx context.Context, id uuid.UUID, cred
 	return nil
 }
 
+// SpendCreditsAndResearch atomically deducts credits and research points,
+// as paying for technology research or station work does.
+//
+// Returns the new credit balance, or ErrInsufficientCredits /
+// ErrInsufficientResearch if the player cannot afford the cost. Nothing is
+// deducted when either check fails.
+func (r *PlayerRepository) SpendCreditsAndResearch(ctx context.Context, id uuid.UUID, credits int64, researchPoints int) (int64, error) {
+	var newCredits, haveCredits int64
+	var haveResearch int
+
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `
+			SELECT credits, research_points FROM players WHERE id = $1 FOR UPDATE
+		`, id).Scan(&haveCredits, &haveResearch)
+		if err == sql.ErrNoRows {
+			return ErrPlayerNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read player balance: %w", err)
+		}
+
+		if haveCredits < credits {
+			return ErrInsufficientCredits
+		}
+		if haveResearch < researchPoints {
+			return ErrInsufficientResearch
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			UPDATE players
+			SET credits = credits - $1, research_points = research_points - $2
+			WHERE id = $3
+			RETURNING credits
+		`, credits, researchPoints, id).Scan(&newCredits)
+		if err != nil {
+			return fmt.Errorf("failed to deduct costs: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return newCredits, nil
+}
+
+// RecordCrafting adds completed crafts and earned research points to a
+// player and raises their crafting skill by one, up to maxSkill
+func (r *PlayerRepository) RecordCrafting(ctx context.Context, id uuid.UUID, crafts, researchPoints, maxSkill int) error {
+	query := `
+		UPDATE players
+		SET total_crafts = total_crafts + $1,
+		    research_points = research_points + $2,
+		    crafting_skill = LEAST(crafting_skill + 1, GREATEST(crafting_skill, $3))
+		WHERE id = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, crafts, researchPoints, maxSkill, id)
+	if err != nil {
+		return fmt.Errorf("failed to record crafting: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrPlayerNotFound
+	}
+
+	return nil
+}
+
+// RecordCapture adds boarding attempts, successful boardings and captured
+// ships to a player's capture statistics
+func (r *PlayerRepository) RecordCapture(ctx context.Context, id uuid.UUID, attempts, boards, captures int) error {
+	query := `
+		UPDATE players
+		SET total_capture_attempts = COALESCE(total_capture_attempts, 0) + $1,
+		    successful_boards = COALESCE(successful_boards, 0) + $2,
+		    successful_captures = COALESCE(successful_captures, 0) + $3
+		WHERE id = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, attempts, boards, captures, id)
+	if err != nil {
+		return fmt.Errorf("failed to record capture: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrPlayerNotFound
+	}
+
+	return nil
+}
+
+// RecordMining adds a finished mining operation to a player's statistics:
+// one more operation, its yield, and the quantity of each resource mined
+func (r *PlayerRepository) RecordMining(ctx context.Context, id uuid.UUID, yield int64, resources map[string]int) error {
+	mined, err := json.Marshal(resources)
+	if err != nil {
+		return fmt.Errorf("failed to encode resources mined: %w", err)
+	}
+
+	// resources_mined is a JSON object of resource -> quantity; the new
+	// quantities are added key by key
+	query := `
+		UPDATE players
+		SET total_mining_ops = COALESCE(total_mining_ops, 0) + 1,
+		    total_yield = COALESCE(total_yield, 0) + $1,
+		    resources_mined = (
+		        SELECT COALESCE(jsonb_object_agg(key, total), '{}'::jsonb)
+		        FROM (
+		            SELECT key, SUM(value::bigint) AS total
+		            FROM (
+		                SELECT * FROM jsonb_each_text(COALESCE(players.resources_mined, '{}'::jsonb))
+		                UNION ALL
+		                SELECT * FROM jsonb_each_text($2::jsonb)
+		            ) AS entries
+		            GROUP BY key
+		        ) AS totals
+		    )
+		WHERE id = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, yield, mined, id)
+	if err != nil {
+		return fmt.Errorf("failed to record mining: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrPlayerNotFound
+	}
+
+	return nil
+}
+
 // UpdateReputation updates a player's reputation with a faction atomically.
 //
 // Reputation is stored in a separate table (player_reputation) for performance
@@ -1,7 +1,7 @@
 // File: internal/database/player_repository_test.go
 // Project: Terminal Velocity
 // Description: Database repository for player_repository_test
-// Version: 1.0.0
+// Version: 1.1.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -18,10 +18,9 @@ import (
 // These are integration tests that require a running PostgreSQL database
 // Skip them if DATABASE_URL is not set
 
-func setupTestDB(t *testing.T) *DB {
-	t.Helper()
-
-	cfg := &Config{
+// testDBConfig is the configuration of the integration test database
+func testDBConfig() *Config {
+	return &Config{
 		Host:            "localhost",
 		Port:            5432,
 		User:            "terminal_velocity",
@@ -33,8 +32,12 @@ func setupTestDB(t *testing.T) *DB {
 		ConnMaxLifetime: time.Hour,
 		ConnMaxIdleTime: 10 * time.Minute,
 	}
+}
+
+func setupTestDB(t *testing.T) *DB {
+	t.Helper()
 
-	db, err := NewDB(cfg)
+	db, err := NewDB(testDBConfig())
 	if err != nil {
 		t.Skipf("Skipping database tests: %v", err)
 	}
@@ -0,0 +1,307 @@
+// File: internal/database/security_repository.go
+// Project: Terminal Velocity
+// Description: Repository for account security data (two-factor authentication, login history)
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/security"
+	"github.com/google/uuid"
+)
+
+// Security errors.
+var (
+	// ErrTwoFactorNotFound indicates the player has never started 2FA enrollment.
+	ErrTwoFactorNotFound = errors.New("two-factor configuration not found")
+)
+
+// SecurityRepository handles database operations for account security.
+//
+// Tables:
+//   - player_two_factor: one TOTP configuration per player. Rows with
+//     enabled = false are enrollments the player hasn't confirmed yet.
+//     backup_codes holds SHA-256 hashes, never the plain codes.
+//   - login_history: every login attempt against an existing account, with
+//     the anomalies and risk score computed at the time
+//   - account_events: the security activity log (security.ActivityEvent)
+//   - honeypot_attempts: logins against honeypot usernames
+//
+// SecurityRepository implements security.Store.
+//
+// Thread-safety:
+//   - All methods are thread-safe
+type SecurityRepository struct {
+	db *DB // Database connection pool
+}
+
+// NewSecurityRepository creates a new security repository
+func NewSecurityRepository(db *DB) *SecurityRepository {
+	return &SecurityRepository{db: db}
+}
+
+// ============================================================================
+// TWO-FACTOR AUTHENTICATION
+// ============================================================================
+
+// GetTwoFactor returns a player's 2FA configuration, or ErrTwoFactorNotFound
+func (r *SecurityRepository) GetTwoFactor(ctx context.Context, playerID uuid.UUID) (*security.TwoFactorConfig, error) {
+	query := `
+		SELECT player_id, enabled, secret, COALESCE(array_to_string(backup_codes, ','), ''),
+		       created_at, last_used
+		FROM player_two_factor
+		WHERE player_id = $1
+	`
+
+	var (
+		config   security.TwoFactorConfig
+		codes    string
+		lastUsed sql.NullTime
+	)
+	err := r.db.QueryRowContext(ctx, query, playerID).Scan(
+		&config.PlayerID,
+		&config.Enabled,
+		&config.Secret,
+		&codes,
+		&config.CreatedAt,
+		&lastUsed,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrTwoFactorNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query two-factor config: %w", err)
+	}
+
+	if codes != "" {
+		config.BackupCodes = strings.Split(codes, ",")
+	}
+	if lastUsed.Valid {
+		config.LastUsed = &lastUsed.Time
+	}
+
+	return &config, nil
+}
+
+// IsTwoFactorEnabled reports whether the player has confirmed 2FA enrollment
+func (r *SecurityRepository) IsTwoFactorEnabled(ctx context.Context, playerID uuid.UUID) (bool, error) {
+	config, err := r.GetTwoFactor(ctx, playerID)
+	if errors.Is(err, ErrTwoFactorNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return config.Enabled, nil
+}
+
+// SaveTwoFactor creates or replaces a player's 2FA configuration
+func (r *SecurityRepository) SaveTwoFactor(ctx context.Context, config *security.TwoFactorConfig) error {
+	query := `
+		INSERT INTO player_two_factor (player_id, enabled, secret, backup_codes, created_at, last_used)
+		VALUES ($1, $2, $3, string_to_array($4, ','), $5, $6)
+		ON CONFLICT (player_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			secret = EXCLUDED.secret,
+			backup_codes = EXCLUDED.backup_codes,
+			created_at = EXCLUDED.created_at,
+			last_used = EXCLUDED.last_used
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		config.PlayerID,
+		config.Enabled,
+		config.Secret,
+		strings.Join(config.BackupCodes, ","),
+		config.CreatedAt,
+		config.LastUsed,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save two-factor config: %w", err)
+	}
+
+	return nil
+}
+
+// RecordTwoFactorUse stores the remaining backup codes and last use time
+// after a successful 2FA check
+func (r *SecurityRepository) RecordTwoFactorUse(ctx context.Context, config *security.TwoFactorConfig) error {
+	lastUsed := time.Now()
+	if config.LastUsed != nil {
+		lastUsed = *config.LastUsed
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE player_two_factor
+		SET backup_codes = string_to_array($2, ','), last_used = $3
+		WHERE player_id = $1
+	`, config.PlayerID, strings.Join(config.BackupCodes, ","), lastUsed)
+	if err != nil {
+		return fmt.Errorf("failed to record two-factor use: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteTwoFactor removes a player's 2FA configuration
+func (r *SecurityRepository) DeleteTwoFactor(ctx context.Context, playerID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM player_two_factor WHERE player_id = $1`, playerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete two-factor config: %w", err)
+	}
+	return nil
+}
+
+// ============================================================================
+// LOGIN HISTORY AND SECURITY EVENTS
+// ============================================================================
+
+// SaveLoginRecord stores a login attempt against an existing account
+func (r *SecurityRepository) SaveLoginRecord(ctx context.Context, record *security.LoginRecord) error {
+	anomalies, err := json.Marshal(record.Anomalies)
+	if err != nil {
+		return fmt.Errorf("failed to marshal anomalies: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO login_history (player_id, ip_address, user_agent, success, failure_reason, anomalies, risk_score, timestamp)
+		VALUES ($1, $2, NULLIF($3, ''), $4, NULLIF($5, ''), $6, $7, $8)
+	`,
+		record.PlayerID,
+		record.IPAddress,
+		record.UserAgent,
+		record.Success,
+		record.FailureReason,
+		anomalies,
+		record.RiskScore,
+		record.Timestamp,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save login record: %w", err)
+	}
+
+	return nil
+}
+
+// GetLoginHistory returns a player's most recent login attempts, newest
+// first. An attempt is flagged NewIP when the player had logged in before,
+// but never from that address.
+func (r *SecurityRepository) GetLoginHistory(ctx context.Context, playerID uuid.UUID, limit int) ([]*security.LoginRecord, error) {
+	query := `
+		SELECT h.player_id, h.ip_address, COALESCE(h.user_agent, ''), h.success,
+		       COALESCE(h.failure_reason, ''), COALESCE(h.anomalies, '[]'::jsonb),
+		       COALESCE(h.risk_score, 0), h.timestamp,
+		       EXISTS (
+		           SELECT 1 FROM login_history p
+		           WHERE p.player_id = h.player_id AND p.success AND p.timestamp < h.timestamp
+		       ) AND NOT EXISTS (
+		           SELECT 1 FROM login_history p
+		           WHERE p.player_id = h.player_id AND p.success AND p.timestamp < h.timestamp
+		             AND p.ip_address = h.ip_address
+		       )
+		FROM login_history h
+		WHERE h.player_id = $1
+		ORDER BY h.timestamp DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, playerID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query login history: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*security.LoginRecord
+	for rows.Next() {
+		var (
+			record    security.LoginRecord
+			anomalies []byte
+		)
+		if err := rows.Scan(
+			&record.PlayerID,
+			&record.IPAddress,
+			&record.UserAgent,
+			&record.Success,
+			&record.FailureReason,
+			&anomalies,
+			&record.RiskScore,
+			&record.Timestamp,
+			&record.NewIP,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan login record: %w", err)
+		}
+		if err := json.Unmarshal(anomalies, &record.Anomalies); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal anomalies: %w", err)
+		}
+		records = append(records, &record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating login history: %w", err)
+	}
+
+	return records, nil
+}
+
+// SaveAccountEvent stores a security activity log event. Events without a
+// player (failed logins for unknown usernames) are stored with a NULL
+// player_id.
+func (r *SecurityRepository) SaveAccountEvent(ctx context.Context, event *security.ActivityEvent) error {
+	var details []byte
+	if event.Details != nil {
+		var err error
+		details, err = json.Marshal(event.Details)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event details: %w", err)
+		}
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO account_events (id, player_id, username, event_type, ip_address, user_agent, timestamp, success, details, risk_level)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10)
+		ON CONFLICT (id) DO NOTHING
+	`,
+		event.ID,
+		nullableUUID(event.PlayerID),
+		event.Username,
+		string(event.EventType),
+		event.IPAddress,
+		event.UserAgent,
+		event.Timestamp,
+		event.Success,
+		details,
+		string(event.RiskLevel),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save account event: %w", err)
+	}
+
+	return nil
+}
+
+// SaveHoneypotAttempt stores a login attempt against a honeypot username
+func (r *SecurityRepository) SaveHoneypotAttempt(ctx context.Context, attempt *security.HoneypotAttempt) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO honeypot_attempts (username_attempted, ip_address, timestamp, user_agent, autobanned)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		ON CONFLICT (ip_address, username_attempted, timestamp) DO NOTHING
+	`, attempt.Username, attempt.IPAddress, attempt.Timestamp, attempt.UserAgent, attempt.Autobanned)
+	if err != nil {
+		return fmt.Errorf("failed to save honeypot attempt: %w", err)
+	}
+
+	return nil
+}
+
+// Compile-time check that SecurityRepository persists security events
+var _ security.Store = (*SecurityRepository)(nil)
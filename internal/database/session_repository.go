@@ -0,0 +1,105 @@
+// File: internal/database/session_repository.go
+// Project: Terminal Velocity
+// Description: Repository for saved game session state (resume after reconnect)
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session state errors.
+var (
+	// ErrSessionStateNotFound indicates the player has no saved session state.
+	ErrSessionStateNotFound = errors.New("session state not found")
+)
+
+// SavedSession is a player's last saved game session.
+type SavedSession struct {
+	PlayerID       uuid.UUID
+	State          json.RawMessage // Encoded session.State
+	SavedAt        time.Time
+	DisconnectedAt *time.Time // nil while connected, or if the server stopped without saving it
+}
+
+// SessionRepository handles database operations for saved session state.
+//
+// Tables:
+//   - player_session_state: one row per player holding the latest state
+//     written by the session manager's autosave and on disconnect. The
+//     state is opaque JSON owned by the session package.
+//
+// Thread-safety:
+//   - All methods are thread-safe
+type SessionRepository struct {
+	db *DB // Database connection pool
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(db *DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+// SaveSessionState stores a player's session state, replacing any earlier
+// state. disconnectedAt is nil while the session is still connected.
+func (r *SessionRepository) SaveSessionState(ctx context.Context, playerID uuid.UUID, state json.RawMessage, disconnectedAt *time.Time) error {
+	query := `
+		INSERT INTO player_session_state (player_id, state, saved_at, disconnected_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (player_id) DO UPDATE SET
+			state = EXCLUDED.state,
+			saved_at = EXCLUDED.saved_at,
+			disconnected_at = EXCLUDED.disconnected_at
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, playerID, []byte(state), time.Now(), disconnectedAt); err != nil {
+		return fmt.Errorf("failed to save session state: %w", err)
+	}
+
+	return nil
+}
+
+// GetSessionState returns a player's saved session, or ErrSessionStateNotFound
+func (r *SessionRepository) GetSessionState(ctx context.Context, playerID uuid.UUID) (*SavedSession, error) {
+	query := `
+		SELECT player_id, state, saved_at, disconnected_at
+		FROM player_session_state
+		WHERE player_id = $1
+	`
+
+	var saved SavedSession
+	var state []byte
+	var disconnectedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, playerID).Scan(&saved.PlayerID, &state, &saved.SavedAt, &disconnectedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrSessionStateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query session state: %w", err)
+	}
+
+	saved.State = state
+	if disconnectedAt.Valid {
+		saved.DisconnectedAt = &disconnectedAt.Time
+	}
+
+	return &saved, nil
+}
+
+// DeleteSessionState removes a player's saved session state
+func (r *SessionRepository) DeleteSessionState(ctx context.Context, playerID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM player_session_state WHERE player_id = $1`, playerID); err != nil {
+		return fmt.Errorf("failed to delete session state: %w", err)
+	}
+	return nil
+}
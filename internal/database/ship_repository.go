@@ -2,7 +2,7 @@
 // Project: Terminal Velocity
 // Description: Repository for ship management including cargo, weapons, outfits,
 //              and combat damage tracking
-// Version: 1.2.0
+// Version: 1.4.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -355,6 +355,25 @@ func (r *ShipRepository) AddCargo(ctx context.Context, shipID uuid.UUID, commodi
 	return nil
 }
 
+// AddCargoItems adds every given commodity quantity to a ship's cargo or
+// none of them. Like AddCargo it does not check cargo capacity.
+func (r *ShipRepository) AddCargoItems(ctx context.Context, shipID uuid.UUID, items map[string]int) error {
+	return r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		for commodityID, quantity := range items {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO ship_cargo (ship_id, commodity_id, quantity)
+				VALUES ($1, $2, $3)
+				ON CONFLICT (ship_id, commodity_id)
+				DO UPDATE SET quantity = ship_cargo.quantity + $3
+			`, shipID, commodityID, quantity)
+			if err != nil {
+				return fmt.Errorf("failed to add %s to cargo: %w", commodityID, err)
+			}
+		}
+		return nil
+	})
+}
+
 // RemoveCargo removes cargo from a ship with quantity validation.
 //
 // Checks that sufficient cargo exists before removal to prevent negative quantities.
@@ -405,6 +424,38 @@ func (r *ShipRepository) RemoveCargo(ctx context.Context, shipID uuid.UUID, comm
 	return nil
 }
 
+// ConsumeCargo removes several commodities from a ship's cargo in one
+// transaction, as crafting does with a blueprint's inputs. Either every
+// quantity is removed or, if any commodity is short, none is and
+// ErrInsufficientCargo is returned. Rows that reach zero are deleted.
+func (r *ShipRepository) ConsumeCargo(ctx context.Context, shipID uuid.UUID, items map[string]int) error {
+	return r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		for commodityID, quantity := range items {
+			result, err := tx.ExecContext(ctx, `
+				UPDATE ship_cargo SET quantity = quantity - $3
+				WHERE ship_id = $1 AND commodity_id = $2 AND quantity >= $3
+			`, shipID, commodityID, quantity)
+			if err != nil {
+				return fmt.Errorf("failed to remove cargo: %w", err)
+			}
+
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("failed to get rows affected: %w", err)
+			}
+			if rowsAffected == 0 {
+				return fmt.Errorf("%w: %s", ErrInsufficientCargo, commodityID)
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, `DELETE FROM ship_cargo WHERE ship_id = $1 AND quantity <= 0`, shipID)
+		if err != nil {
+			return fmt.Errorf("failed to clear empty cargo: %w", err)
+		}
+		return nil
+	})
+}
+
 // loadCargo loads cargo for a ship
 func (r *ShipRepository) loadCargo(ctx context.Context, shipID uuid.UUID) ([]models.CargoItem, error) {
 	query := `
@@ -530,3 +581,6 @@ func (r *ShipRepository) loadOutfits(ctx context.Context, shipID uuid.UUID) ([]s
 
 // ErrShipNotFound is returned when a ship is not found
 var ErrShipNotFound = fmt.Errorf("ship not found")
+
+// ErrInsufficientCargo is returned when a ship does not carry enough of a commodity
+var ErrInsufficientCargo = fmt.Errorf("insufficient cargo")
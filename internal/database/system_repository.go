@@ -2,7 +2,7 @@
 // Project: Terminal Velocity
 // Description: Repository for star systems, planets, and jump route management.
 //              Handles universe geography and navigation data.
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -422,6 +422,8 @@ func (r *SystemRepository) GetPlanetsBySystem(ctx context.Context, systemID uuid
 // transaction for maximum performance.
 //
 // All systems are created atomically - if any insert fails, all are rolled back.
+// Systems that already exist (same ID) are updated in place, so loading the
+// same universe file twice leaves the table unchanged.
 //
 // Parameters:
 //   - ctx: Context for timeout and cancellation
@@ -441,6 +443,13 @@ func (r *SystemRepository) BulkCreateSystems(ctx context.Context, systems []*mod
 		stmt, err := tx.PrepareContext(ctx, `
 			INSERT INTO star_systems (id, name, pos_x, pos_y, government_id, tech_level, description)
 			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (id) DO UPDATE SET
+				name = EXCLUDED.name,
+				pos_x = EXCLUDED.pos_x,
+				pos_y = EXCLUDED.pos_y,
+				government_id = EXCLUDED.government_id,
+				tech_level = EXCLUDED.tech_level,
+				description = EXCLUDED.description
 		`)
 		if err != nil {
 			return fmt.Errorf("failed to prepare statement: %w", err)
@@ -506,12 +515,22 @@ func (r *SystemRepository) BulkCreateJumpRoutes(ctx context.Context, routes [][2
 	})
 }
 
-// BulkCreatePlanets creates multiple planets in a single transaction
+// BulkCreatePlanets creates multiple planets in a single transaction.
+// Planets that already exist (same ID) are updated in place.
 func (r *SystemRepository) BulkCreatePlanets(ctx context.Context, planets []*models.Planet) error {
 	return r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
 		stmt, err := tx.PrepareContext(ctx, `
-			INSERT INTO planets (id, system_id, name, description, population, tech_level, services)
-			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			INSERT INTO planets (id, system_id, name, description, x, y, population, tech_level, services)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (id) DO UPDATE SET
+				system_id = EXCLUDED.system_id,
+				name = EXCLUDED.name,
+				description = EXCLUDED.description,
+				x = EXCLUDED.x,
+				y = EXCLUDED.y,
+				population = EXCLUDED.population,
+				tech_level = EXCLUDED.tech_level,
+				services = EXCLUDED.services
 		`)
 		if err != nil {
 			return fmt.Errorf("failed to prepare statement: %w", err)
@@ -524,6 +543,8 @@ func (r *SystemRepository) BulkCreatePlanets(ctx context.Context, planets []*mod
 				planet.SystemID,
 				planet.Name,
 				planet.Description,
+				planet.X,
+				planet.Y,
 				planet.Population,
 				planet.TechLevel,
 				planet.Services,
@@ -0,0 +1,183 @@
+// File: internal/database/territory_repository.go
+// Project: Terminal Velocity
+// Description: Repository for faction territory claims, control and contests
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// TerritoryRepository handles database operations for faction territory.
+//
+// Tables:
+//   - territories: one row per claimed system holding its owner, control,
+//     upkeep schedule, weekly activity and any active contest (JSONB)
+//
+// The territory manager keeps claims in memory and saves a territory after
+// every change, so SaveTerritory is an upsert keyed by system.
+//
+// Thread-safety:
+//   - All methods are thread-safe
+type TerritoryRepository struct {
+	db *DB // Database connection pool
+}
+
+// NewTerritoryRepository creates a new territory repository
+func NewTerritoryRepository(db *DB) *TerritoryRepository {
+	return &TerritoryRepository{db: db}
+}
+
+// SaveTerritory inserts or replaces the claim on a territory's system
+func (r *TerritoryRepository) SaveTerritory(ctx context.Context, t *models.Territory) error {
+	var contest []byte
+	if t.Contest != nil {
+		var err error
+		if contest, err = json.Marshal(t.Contest); err != nil {
+			return fmt.Errorf("failed to encode territory contest: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO territories (id, system_id, system_name, faction_id, faction_tag,
+		                         control_level, control_points, claimed_at, last_upkeep, next_upkeep,
+		                         upkeep_cost, income, defense_level, development_level, has_station,
+		                         member_activity, trade_volume, kills, last_conflict, missed_upkeeps, contest)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+		ON CONFLICT (system_id) DO UPDATE SET
+			id = EXCLUDED.id,
+			system_name = EXCLUDED.system_name,
+			faction_id = EXCLUDED.faction_id,
+			faction_tag = EXCLUDED.faction_tag,
+			control_level = EXCLUDED.control_level,
+			control_points = EXCLUDED.control_points,
+			claimed_at = EXCLUDED.claimed_at,
+			last_upkeep = EXCLUDED.last_upkeep,
+			next_upkeep = EXCLUDED.next_upkeep,
+			upkeep_cost = EXCLUDED.upkeep_cost,
+			income = EXCLUDED.income,
+			defense_level = EXCLUDED.defense_level,
+			development_level = EXCLUDED.development_level,
+			has_station = EXCLUDED.has_station,
+			member_activity = EXCLUDED.member_activity,
+			trade_volume = EXCLUDED.trade_volume,
+			kills = EXCLUDED.kills,
+			last_conflict = EXCLUDED.last_conflict,
+			missed_upkeeps = EXCLUDED.missed_upkeeps,
+			contest = EXCLUDED.contest
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		t.ID,
+		t.SystemID,
+		t.SystemName,
+		t.FactionID,
+		t.FactionTag,
+		t.ControlLevel,
+		t.ControlPoints,
+		t.ClaimedAt,
+		t.LastUpkeep,
+		t.NextUpkeep,
+		t.UpkeepCost,
+		t.Income,
+		t.DefenseLevel,
+		t.DevelopmentLevel,
+		t.HasStation,
+		t.MemberActivity,
+		t.TradeVolume,
+		t.Kills,
+		t.LastConflict,
+		t.MissedUpkeeps,
+		contest,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save territory: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteTerritory removes the claim on a system
+func (r *TerritoryRepository) DeleteTerritory(ctx context.Context, systemID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM territories WHERE system_id = $1`, systemID); err != nil {
+		return fmt.Errorf("failed to delete territory: %w", err)
+	}
+	return nil
+}
+
+// ListTerritories returns every claimed system in claim order
+func (r *TerritoryRepository) ListTerritories(ctx context.Context) ([]*models.Territory, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, system_id, system_name, faction_id, faction_tag,
+		       control_level, control_points, claimed_at, last_upkeep, next_upkeep,
+		       upkeep_cost, income, defense_level, development_level, has_station,
+		       member_activity, trade_volume, kills, last_conflict, missed_upkeeps, contest
+		FROM territories
+		ORDER BY claimed_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query territories: %w", err)
+	}
+	defer rows.Close()
+
+	var territories []*models.Territory
+	for rows.Next() {
+		var t models.Territory
+		var lastConflict sql.NullTime
+		var contest []byte
+
+		err := rows.Scan(
+			&t.ID,
+			&t.SystemID,
+			&t.SystemName,
+			&t.FactionID,
+			&t.FactionTag,
+			&t.ControlLevel,
+			&t.ControlPoints,
+			&t.ClaimedAt,
+			&t.LastUpkeep,
+			&t.NextUpkeep,
+			&t.UpkeepCost,
+			&t.Income,
+			&t.DefenseLevel,
+			&t.DevelopmentLevel,
+			&t.HasStation,
+			&t.MemberActivity,
+			&t.TradeVolume,
+			&t.Kills,
+			&lastConflict,
+			&t.MissedUpkeeps,
+			&contest,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan territory: %w", err)
+		}
+
+		if lastConflict.Valid {
+			conflict := lastConflict.Time
+			t.LastConflict = &conflict
+		}
+		if len(contest) > 0 {
+			t.Contest = &models.TerritoryContest{}
+			if err := json.Unmarshal(contest, t.Contest); err != nil {
+				return nil, fmt.Errorf("failed to decode contest for territory %s: %w", t.ID, err)
+			}
+		}
+
+		territories = append(territories, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating territories: %w", err)
+	}
+
+	return territories, nil
+}
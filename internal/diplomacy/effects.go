@@ -0,0 +1,253 @@
+// File: internal/diplomacy/effects.go
+// Project: Terminal Velocity
+// Description: Gameplay consequences of diplomacy - war checks, war score, alliances and market fees
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// Other systems read diplomatic state through these queries, wired together
+// by the world hub:
+//   - PvP: factions at war can attack each other outside safe zones
+//   - Territory: allies defend each other's contested systems, and captures
+//     and defenses add war score
+//   - Chat: faction chat reaches allied factions
+//   - Trading: market fees in a faction's territory depend on the trader's
+//     standing with the owner (see MarketFeeRate)
+//
+// War score is fed automatically: RecordPvPKill and RecordTerritoryConflict
+// credit the winning faction in every ongoing war against the loser.
+
+package diplomacy
+
+import (
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// AtWar reports whether two factions are fighting an ongoing war
+func (m *Manager) AtWar(faction1, faction2 uuid.UUID) bool {
+	if faction1 == uuid.Nil || faction2 == uuid.Nil {
+		return false
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.areAtWar(faction1, faction2)
+}
+
+// Allied reports whether two different factions share an active alliance
+func (m *Manager) Allied(faction1, faction2 uuid.UUID) bool {
+	if faction1 == uuid.Nil || faction2 == uuid.Nil {
+		return false
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.areAllied(faction1, faction2)
+}
+
+// HasTreaty reports whether a treaty of the given type binds two factions
+func (m *Manager) HasTreaty(faction1, faction2 uuid.UUID, treatyType TreatyType) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.hasTreaty(faction1, faction2, treatyType)
+}
+
+// GetFactionAlliance returns the faction's active alliance, or nil
+func (m *Manager) GetFactionAlliance(factionID uuid.UUID) *Alliance {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.allianceOf(factionID)
+}
+
+// AlliedFactions returns the other members of the faction's alliance
+func (m *Manager) AlliedFactions(factionID uuid.UUID) []uuid.UUID {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	alliance := m.allianceOf(factionID)
+	if alliance == nil {
+		return nil
+	}
+	var allies []uuid.UUID
+	for _, memberID := range alliance.AllFactions() {
+		if memberID != factionID {
+			allies = append(allies, memberID)
+		}
+	}
+	return allies
+}
+
+// GetFactionWars returns the ongoing wars the faction is fighting
+func (m *Manager) GetFactionWars(factionID uuid.UUID) []*War {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var wars []*War
+	for _, war := range m.wars {
+		if allies, _ := war.Side(factionID); allies != nil && war.IsOngoing() {
+			wars = append(wars, war)
+		}
+	}
+	return wars
+}
+
+// GetFactionTreaties returns the treaties in force for the faction
+func (m *Manager) GetFactionTreaties(factionID uuid.UUID) []*Treaty {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	var treaties []*Treaty
+	for _, treaty := range m.treaties {
+		if treaty.Status == "active" && now.Before(treaty.ExpiresAt) &&
+			(treaty.Faction1 == factionID || treaty.Faction2 == factionID) {
+			treaties = append(treaties, treaty)
+		}
+	}
+	return treaties
+}
+
+// GetProposals returns the open proposals made to and by the faction
+func (m *Manager) GetProposals(factionID uuid.UUID) (incoming, outgoing []*models.DiplomaticProposal) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := time.Now()
+	for _, proposal := range m.proposals {
+		if proposal.IsExpired(now) {
+			continue
+		}
+		switch factionID {
+		case proposal.ToFactionID:
+			incoming = append(incoming, proposal)
+		case proposal.FromFactionID:
+			outgoing = append(outgoing, proposal)
+		}
+	}
+	return incoming, outgoing
+}
+
+// RecordPvPKill credits a faction whose pilot defeated an enemy faction's
+// pilot in every ongoing war between them. Returns true if war score changed.
+func (m *Manager) RecordPvPKill(killerFactionID, victimFactionID uuid.UUID) bool {
+	return m.recordVictory(killerFactionID, victimFactionID, m.config.PvPKillWarScore)
+}
+
+// RecordTerritoryConflict credits the winner of a contest or siege for a
+// system: the attacker if it captured the system, otherwise the defender.
+// Returns true if war score changed.
+func (m *Manager) RecordTerritoryConflict(winnerFactionID, loserFactionID uuid.UUID, captured bool) bool {
+	score := m.config.DefenseWarScore
+	if captured {
+		score = m.config.CaptureWarScore
+	}
+	return m.recordVictory(winnerFactionID, loserFactionID, score)
+}
+
+// recordVictory adds war score for the winner in each war against the loser
+func (m *Manager) recordVictory(winnerFactionID, loserFactionID uuid.UUID, score int) bool {
+	if winnerFactionID == uuid.Nil || loserFactionID == uuid.Nil || winnerFactionID == loserFactionID {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	scored := false
+	for _, war := range m.wars {
+		if !war.IsOngoing() {
+			continue
+		}
+		_, enemies := war.Side(winnerFactionID)
+		for _, enemyID := range enemies {
+			if enemyID == loserFactionID {
+				// Hub events have no caller to report to; an unsaved score
+				// is left out rather than kept only in memory
+				if err := m.addWarScore(war, winnerFactionID, score); err != nil {
+					log.Error("Failed to record victory in war %s: %v", war.Name, err)
+				} else {
+					scored = true
+				}
+				break
+			}
+		}
+	}
+	return scored
+}
+
+// addWarScore changes a faction's score in a war (must hold lock)
+func (m *Manager) addWarScore(war *War, factionID uuid.UUID, change int) error {
+	updated := *war
+	updated.WarScore = make(map[uuid.UUID]int, len(war.WarScore))
+	for id, score := range war.WarScore {
+		updated.WarScore[id] = score
+	}
+	updated.WarScore[factionID] += change
+	if err := m.saveWar(&updated); err != nil {
+		return err
+	}
+	*war = updated
+	m.notifyChanged(append(append([]uuid.UUID(nil), war.AggressorFactions...), war.DefenderFactions...)...)
+	return nil
+}
+
+// MarketFeeRate returns the fee, as a fraction of trade value, for a trader
+// from traderFactionID (uuid.Nil for unaffiliated players) trading in a
+// system held by ownerFactionID. Unclaimed systems, the owner's own members
+// and its allies pay nothing; trade agreement partners pay a reduced fee and
+// factions at war with the owner pay a punitive one.
+func (m *Manager) MarketFeeRate(traderFactionID, ownerFactionID uuid.UUID) float64 {
+	if ownerFactionID == uuid.Nil || traderFactionID == ownerFactionID {
+		return 0
+	}
+	if traderFactionID == uuid.Nil {
+		return m.config.TerritoryMarketFee
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	switch {
+	case m.areAllied(traderFactionID, ownerFactionID):
+		return 0
+	case m.areAtWar(traderFactionID, ownerFactionID):
+		return m.config.WarMarketFee
+	case m.hasTreaty(traderFactionID, ownerFactionID, TreatyTradeAgreement):
+		return m.config.TradeAgreementMarketFee
+	default:
+		return m.config.TerritoryMarketFee
+	}
+}
+
+// allianceOf returns the faction's active alliance, or nil (must hold lock)
+func (m *Manager) allianceOf(factionID uuid.UUID) *Alliance {
+	for _, alliance := range m.alliances {
+		if alliance.Status == "active" && alliance.HasFaction(factionID) {
+			return alliance
+		}
+	}
+	return nil
+}
+
+// areAllied checks if two different factions share an alliance (must hold lock)
+func (m *Manager) areAllied(faction1, faction2 uuid.UUID) bool {
+	if faction1 == faction2 {
+		return false
+	}
+	alliance := m.allianceOf(faction1)
+	return alliance != nil && alliance.HasFaction(faction2)
+}
+
+// hasTreaty checks for a treaty in force between two factions (must hold lock)
+func (m *Manager) hasTreaty(faction1, faction2 uuid.UUID, treatyType TreatyType) bool {
+	now := time.Now()
+	for _, treaty := range m.treaties {
+		if treaty.Type == treatyType && treaty.Binds(faction1, faction2, now) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,7 +1,7 @@
 // File: internal/diplomacy/manager.go
 // Project: Terminal Velocity
 // Description: Alliance and diplomacy system for faction relations
-// Version: 1.1.0
+// Version: 1.3.0
 // Author: Claude Code
 // Created: 2025-11-15
 
@@ -16,6 +16,7 @@ import (
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/factions"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	"github.com/google/uuid"
 )
 
@@ -30,6 +31,7 @@ type Manager struct {
 	wars        map[uuid.UUID]*War      // war_id -> war
 	relations   map[string]*Relation    // "faction1_faction2" -> relation
 	treaties    map[uuid.UUID]*Treaty   // treaty_id -> treaty
+	proposals   map[uuid.UUID]*models.DiplomaticProposal // proposal_id -> open proposal
 
 	// Configuration
 	config DiplomacyConfig
@@ -40,6 +42,9 @@ type Manager struct {
 	// Managers
 	factionManager *factions.Manager
 
+	// Persistence (optional)
+	store Store
+
 	// Callbacks
 	onAllianceFormed   func(alliance *Alliance)
 	onAllianceBroken   func(alliance *Alliance)
@@ -48,6 +53,9 @@ type Manager struct {
 	onTreatySigned     func(treaty *Treaty)
 	onTreatyViolated   func(treaty *Treaty)
 
+	// Callback for real-time delivery of changes to the factions involved
+	onChanged func(factionIDs []uuid.UUID)
+
 	// Background workers
 	stopChan chan struct{}
 	wg       sync.WaitGroup
@@ -77,6 +85,19 @@ type DiplomacyConfig struct {
 	RelationChangeLimit    float64       // Max relation change per action
 	HostileThreshold       float64       // Below this = hostile
 	FriendlyThreshold      float64       // Above this = friendly
+
+	// Proposal settings
+	ProposalDuration       time.Duration // How long an alliance or treaty offer stays open
+
+	// War score settings
+	PvPKillWarScore        int           // Score for defeating an enemy faction's pilot
+	CaptureWarScore        int           // Score for taking an enemy faction's system
+	DefenseWarScore        int           // Score for holding a system against an enemy attack
+
+	// Market fee settings (fraction of trade value in another faction's territory)
+	TerritoryMarketFee      float64      // Fee for neutral factions and unaffiliated players
+	TradeAgreementMarketFee float64      // Fee under a trade agreement with the owner
+	WarMarketFee            float64      // Fee for factions at war with the owner
 }
 
 // DefaultDiplomacyConfig returns sensible defaults
@@ -97,6 +118,13 @@ func DefaultDiplomacyConfig() DiplomacyConfig {
 		RelationChangeLimit:     0.10, // Max 10% change per action
 		HostileThreshold:        -0.30, // Below -30% = hostile
 		FriendlyThreshold:       0.30,  // Above 30% = friendly
+		ProposalDuration:        3 * 24 * time.Hour,
+		PvPKillWarScore:         10,
+		CaptureWarScore:         50,
+		DefenseWarScore:         25,
+		TerritoryMarketFee:      0.05, // 5% in foreign territory
+		TradeAgreementMarketFee: 0.01, // 1% for trade partners
+		WarMarketFee:            0.20, // 20% for enemies
 	}
 }
 
@@ -107,6 +135,7 @@ func NewManager(playerRepo *database.PlayerRepository, factionManager *factions.
 		wars:           make(map[uuid.UUID]*War),
 		relations:      make(map[string]*Relation),
 		treaties:       make(map[uuid.UUID]*Treaty),
+		proposals:      make(map[uuid.UUID]*models.DiplomaticProposal),
 		config:         DefaultDiplomacyConfig(),
 		playerRepo:     playerRepo,
 		factionManager: factionManager,
@@ -145,70 +174,45 @@ func (m *Manager) SetCallbacks(
 	m.onTreatyViolated = onTreatyViolated
 }
 
+// SetDiplomacyChangedCallback sets the callback invoked with the factions
+// involved whenever an alliance, war, treaty or proposal changes. The
+// callback is invoked while the manager lock is held and must not call back
+// into the Manager.
+func (m *Manager) SetDiplomacyChangedCallback(callback func(factionIDs []uuid.UUID)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onChanged = callback
+}
+
+// notifyChanged reports a change involving the given factions. Caller must hold m.mu.
+func (m *Manager) notifyChanged(factionIDs ...uuid.UUID) {
+	if m.onChanged != nil {
+		m.onChanged(factionIDs)
+	}
+}
+
 // ============================================================================
 // DATA STRUCTURES
 // ============================================================================
 
-// Alliance represents a multi-faction alliance
-type Alliance struct {
-	ID          uuid.UUID
-	Name        string
-	Description string
-	LeaderFactionID uuid.UUID
-	MemberFactions  []uuid.UUID
-	CreatedAt   time.Time
-	Status      string // "active", "dissolved"
-	Treasury    int64  // Shared alliance treasury
-	LastMaintenance time.Time
-}
-
-// War represents a conflict between factions/alliances
-type War struct {
-	ID           uuid.UUID
-	Name         string
-	AggressorFactions []uuid.UUID
-	DefenderFactions  []uuid.UUID
-	StartTime    time.Time
-	EndTime      time.Time
-	Status       string // "active", "truce_proposed", "ended"
-	WarScore     map[uuid.UUID]int // faction_id -> war score
-	Exhaustion   float64 // 0.0-1.0, increases over time
-	TruceProposedBy uuid.UUID
-	TruceProposedAt time.Time
-}
-
-// Treaty represents a diplomatic agreement
-type Treaty struct {
-	ID          uuid.UUID
-	Type        TreatyType
-	Faction1    uuid.UUID
-	Faction2    uuid.UUID
-	Terms       string // Description of treaty terms
-	SignedAt    time.Time
-	ExpiresAt   time.Time
-	Status      string // "active", "violated", "expired"
-	ViolatedBy  uuid.UUID
-}
-
-// TreatyType defines types of treaties
-type TreatyType string
+// The diplomacy records live in models so the database package can persist
+// them; these aliases keep the diplomacy API self-contained.
+type (
+	Alliance   = models.Alliance   // Multi-faction alliance
+	War        = models.War        // Conflict between factions/alliances
+	Treaty     = models.Treaty     // Diplomatic agreement
+	TreatyType = models.TreatyType // Kind of treaty
+	Relation   = models.Relation   // Relationship between two factions
+)
 
+// Treaty types
 const (
-	TreatyNonAggression  TreatyType = "non_aggression"  // Cannot attack each other
-	TreatyTradeAgreement TreatyType = "trade_agreement" // Trade bonuses
-	TreatyMutualDefense  TreatyType = "mutual_defense"  // Defend if attacked
-	TreatyResearchPact   TreatyType = "research_pact"   // Share technology
+	TreatyNonAggression  = models.TreatyNonAggression  // Cannot attack each other
+	TreatyTradeAgreement = models.TreatyTradeAgreement // Reduced market fees in each other's territory
+	TreatyMutualDefense  = models.TreatyMutualDefense  // Defend if attacked
+	TreatyResearchPact   = models.TreatyResearchPact   // Share technology
 )
 
-// Relation tracks relationship between two factions
-type Relation struct {
-	Faction1    uuid.UUID
-	Faction2    uuid.UUID
-	Value       float64 // -1.0 (hostile) to +1.0 (friendly)
-	Status      string  // "hostile", "neutral", "friendly", "allied"
-	UpdatedAt   time.Time
-}
-
 // ============================================================================
 // ALLIANCE SYSTEM
 // ============================================================================
@@ -218,6 +222,11 @@ func (m *Manager) FormAlliance(ctx context.Context, name, description string, le
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	return m.formAlliance(name, description, leaderFactionID, memberFactions)
+}
+
+// formAlliance creates a new alliance (must hold lock)
+func (m *Manager) formAlliance(name, description string, leaderFactionID uuid.UUID, memberFactions []uuid.UUID) (*Alliance, error) {
 	// Validate faction count
 	totalFactions := len(memberFactions) + 1 // +1 for leader
 	if totalFactions < m.config.MinFactionsForAlliance {
@@ -248,16 +257,22 @@ func (m *Manager) FormAlliance(ctx context.Context, name, description string, le
 		LastMaintenance: time.Now(),
 	}
 
-	m.alliances[alliance.ID] = alliance
-
 	// Update relations between member factions
 	for i, faction1 := range allFactions {
 		for j := i + 1; j < len(allFactions); j++ {
 			faction2 := allFactions[j]
-			m.modifyRelationUnsafe(faction1, faction2, 0.50) // +50% relation
+			if err := m.modifyRelationUnsafe(faction1, faction2, 0.50); err != nil { // +50% relation
+				return nil, err
+			}
 		}
 	}
 
+	if err := m.saveAlliance(alliance); err != nil {
+		return nil, err
+	}
+	m.alliances[alliance.ID] = alliance
+	m.notifyChanged(allFactions...)
+
 	log.Info("Alliance formed: name=%s, leader=%s, members=%d", name, leaderFactionID, len(memberFactions))
 
 	if m.onAllianceFormed != nil {
@@ -286,37 +301,34 @@ func (m *Manager) DisbandAlliance(ctx context.Context, allianceID, factionID uui
 		return fmt.Errorf("alliance is not active")
 	}
 
+	// Save the dissolution before paying out, so a failed write cannot
+	// leave an active alliance whose treasury was already shared out
+	dissolved := *alliance
+	dissolved.Status = "dissolved"
+	dissolved.Treasury = 0
+	if err := m.saveAlliance(&dissolved); err != nil {
+		return err
+	}
+	treasury := alliance.Treasury
+	*alliance = dissolved
+
 	// Distribute treasury evenly among all member factions
-	if alliance.Treasury > 0 && m.factionManager != nil {
+	if treasury > 0 && m.factionManager != nil {
 		allFactions := append([]uuid.UUID{alliance.LeaderFactionID}, alliance.MemberFactions...)
-		sharePerFaction := alliance.Treasury / int64(len(allFactions))
+		sharePerFaction := treasury / int64(len(allFactions))
 
 		// Distribute to each faction's treasury
 		for _, factionID := range allFactions {
-			// Use a dummy player ID (first member) to satisfy Deposit signature
-			// In practice, this is an alliance action, not a player action
-			faction, err := m.factionManager.GetFaction(factionID)
-			if err != nil || faction == nil {
-				log.Warn("Failed to get faction %s for treasury distribution: %v", factionID, err)
-				continue
-			}
-
-			// Deposit to faction treasury (use leader as depositor for logging)
-			if len(faction.Members) > 0 {
-				depositErr := m.factionManager.Deposit(factionID, faction.LeaderID, sharePerFaction)
-				if depositErr != nil {
-					log.Error("Failed to distribute %d credits to faction %s: %v", sharePerFaction, factionID, depositErr)
-				} else {
-					log.Info("Distributed %d credits to faction %s from disbanded alliance", sharePerFaction, factionID)
-				}
+			// Alliance payouts are not player deposits, so no member is charged
+			if err := m.factionManager.AddFunds(factionID, sharePerFaction); err != nil {
+				log.Error("Failed to distribute %d credits to faction %s: %v", sharePerFaction, factionID, err)
+			} else {
+				log.Info("Distributed %d credits to faction %s from disbanded alliance", sharePerFaction, factionID)
 			}
 		}
-
-		// Clear alliance treasury
-		alliance.Treasury = 0
 	}
 
-	alliance.Status = "dissolved"
+	m.notifyChanged(alliance.AllFactions()...)
 
 	log.Info("Alliance disbanded: name=%s", alliance.Name)
 
@@ -345,7 +357,13 @@ func (m *Manager) LeaveAlliance(ctx context.Context, allianceID, factionID uuid.
 	// Remove from members
 	for i, memberID := range alliance.MemberFactions {
 		if memberID == factionID {
-			alliance.MemberFactions = append(alliance.MemberFactions[:i], alliance.MemberFactions[i+1:]...)
+			updated := *alliance
+			updated.MemberFactions = append(append([]uuid.UUID(nil), alliance.MemberFactions[:i]...), alliance.MemberFactions[i+1:]...)
+			if err := m.saveAlliance(&updated); err != nil {
+				return err
+			}
+			*alliance = updated
+			m.notifyChanged(append(alliance.AllFactions(), factionID)...)
 			log.Info("Faction left alliance: faction=%s, alliance=%s", factionID, alliance.Name)
 			return nil
 		}
@@ -363,6 +381,11 @@ func (m *Manager) DeclareWar(ctx context.Context, name string, aggressors, defen
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	return m.declareWar(name, aggressors, defenders)
+}
+
+// declareWar starts a war (must hold lock)
+func (m *Manager) declareWar(name string, aggressors, defenders []uuid.UUID) (*War, error) {
 	// Validate
 	if len(aggressors) == 0 || len(defenders) == 0 {
 		return nil, fmt.Errorf("need at least one aggressor and defender")
@@ -370,7 +393,7 @@ func (m *Manager) DeclareWar(ctx context.Context, name string, aggressors, defen
 
 	// Check for existing wars between these factions
 	for _, war := range m.wars {
-		if war.Status == "active" {
+		if war.IsOngoing() {
 			// Check if any factions overlap
 			for _, aggressor := range aggressors {
 				for _, existingAggressor := range war.AggressorFactions {
@@ -402,15 +425,21 @@ func (m *Manager) DeclareWar(ctx context.Context, name string, aggressors, defen
 		war.WarScore[factionID] = 0
 	}
 
-	m.wars[war.ID] = war
-
 	// Update relations
 	for _, aggressor := range aggressors {
 		for _, defender := range defenders {
-			m.modifyRelationUnsafe(aggressor, defender, -0.75) // -75% relation
+			if err := m.modifyRelationUnsafe(aggressor, defender, -0.75); err != nil { // -75% relation
+				return nil, err
+			}
 		}
 	}
 
+	if err := m.saveWar(war); err != nil {
+		return nil, err
+	}
+	m.wars[war.ID] = war
+	m.notifyChanged(append(append([]uuid.UUID(nil), aggressors...), defenders...)...)
+
 	log.Info("War declared: name=%s, aggressors=%d, defenders=%d", name, len(aggressors), len(defenders))
 
 	if m.onWarDeclared != nil {
@@ -434,6 +463,10 @@ func (m *Manager) ProposeTruce(ctx context.Context, warID, factionID uuid.UUID)
 		return fmt.Errorf("war is not active")
 	}
 
+	if allies, _ := war.Side(factionID); allies == nil {
+		return fmt.Errorf("faction is not part of this war")
+	}
+
 	// Check minimum war duration
 	if time.Since(war.StartTime) < m.config.MinWarDuration {
 		return fmt.Errorf("war must last at least %v before truce", m.config.MinWarDuration)
@@ -444,9 +477,15 @@ func (m *Manager) ProposeTruce(ctx context.Context, warID, factionID uuid.UUID)
 		return fmt.Errorf("must wait %v between truce proposals", m.config.TruceProposalCooldown)
 	}
 
-	war.Status = "truce_proposed"
-	war.TruceProposedBy = factionID
-	war.TruceProposedAt = time.Now()
+	updated := *war
+	updated.Status = "truce_proposed"
+	updated.TruceProposedBy = factionID
+	updated.TruceProposedAt = time.Now()
+	if err := m.saveWar(&updated); err != nil {
+		return err
+	}
+	*war = updated
+	m.notifyChanged(append(append([]uuid.UUID(nil), war.AggressorFactions...), war.DefenderFactions...)...)
 
 	log.Info("Truce proposed: war=%s, proposer=%s", war.Name, factionID)
 	return nil
@@ -466,17 +505,39 @@ func (m *Manager) AcceptTruce(ctx context.Context, warID, factionID uuid.UUID) e
 		return fmt.Errorf("no truce proposed")
 	}
 
-	// End war
-	war.Status = "ended"
-	war.EndTime = time.Now()
+	// The other side answers a truce; either side can accept one proposed
+	// by war exhaustion
+	allies, _ := war.Side(factionID)
+	if allies == nil {
+		return fmt.Errorf("faction is not part of this war")
+	}
+	if war.TruceProposedBy != uuid.Nil {
+		for _, ally := range allies {
+			if ally == war.TruceProposedBy {
+				return fmt.Errorf("truce must be accepted by the other side")
+			}
+		}
+	}
 
 	// Slight relation improvement
 	for _, aggressor := range war.AggressorFactions {
 		for _, defender := range war.DefenderFactions {
-			m.modifyRelationUnsafe(aggressor, defender, 0.20) // +20% relation
+			if err := m.modifyRelationUnsafe(aggressor, defender, 0.20); err != nil { // +20% relation
+				return err
+			}
 		}
 	}
 
+	// End war
+	ended := *war
+	ended.Status = "ended"
+	ended.EndTime = time.Now()
+	if err := m.saveWar(&ended); err != nil {
+		return err
+	}
+	*war = ended
+	m.notifyChanged(append(append([]uuid.UUID(nil), war.AggressorFactions...), war.DefenderFactions...)...)
+
 	log.Info("Truce accepted: war=%s, acceptor=%s", war.Name, factionID)
 
 	if m.onWarEnded != nil {
@@ -496,11 +557,13 @@ func (m *Manager) UpdateWarScore(warID, factionID uuid.UUID, change int) error {
 		return fmt.Errorf("war not found")
 	}
 
-	if war.Status != "active" {
+	if !war.IsOngoing() {
 		return fmt.Errorf("war is not active")
 	}
 
-	war.WarScore[factionID] += change
+	if err := m.addWarScore(war, factionID, change); err != nil {
+		return err
+	}
 	log.Debug("War score updated: war=%s, faction=%s, change=%d", war.Name, factionID, change)
 	return nil
 }
@@ -514,6 +577,11 @@ func (m *Manager) SignTreaty(ctx context.Context, treatyType TreatyType, faction
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	return m.signTreaty(treatyType, faction1, faction2, terms, duration)
+}
+
+// signTreaty creates a new treaty (must hold lock)
+func (m *Manager) signTreaty(treatyType TreatyType, faction1, faction2 uuid.UUID, terms string, duration time.Duration) (*Treaty, error) {
 	// Check treaty limits
 	count1 := m.countActiveTreaties(faction1)
 	count2 := m.countActiveTreaties(faction2)
@@ -539,10 +607,16 @@ func (m *Manager) SignTreaty(ctx context.Context, treatyType TreatyType, faction
 		Status:    "active",
 	}
 
-	m.treaties[treaty.ID] = treaty
-
 	// Improve relations
-	m.modifyRelationUnsafe(faction1, faction2, 0.25) // +25% relation
+	if err := m.modifyRelationUnsafe(faction1, faction2, 0.25); err != nil { // +25% relation
+		return nil, err
+	}
+
+	if err := m.saveTreaty(treaty); err != nil {
+		return nil, err
+	}
+	m.treaties[treaty.ID] = treaty
+	m.notifyChanged(faction1, faction2)
 
 	log.Info("Treaty signed: type=%s, factions=%s,%s", treatyType, faction1, faction2)
 
@@ -567,16 +641,29 @@ func (m *Manager) ViolateTreaty(ctx context.Context, treatyID, violatorID uuid.U
 		return fmt.Errorf("treaty is not active")
 	}
 
-	treaty.Status = "violated"
-	treaty.ViolatedBy = violatorID
+	return m.violateTreaty(treaty, violatorID)
+}
 
+// violateTreaty marks a treaty as broken by a faction (must hold lock)
+func (m *Manager) violateTreaty(treaty *Treaty, violatorID uuid.UUID) error {
 	// Severe relation penalty
 	otherFaction := treaty.Faction1
 	if violatorID == treaty.Faction1 {
 		otherFaction = treaty.Faction2
 	}
 
-	m.modifyRelationUnsafe(violatorID, otherFaction, -m.config.TreatyViolationPenalty)
+	if err := m.modifyRelationUnsafe(violatorID, otherFaction, -m.config.TreatyViolationPenalty); err != nil {
+		return err
+	}
+
+	violated := *treaty
+	violated.Status = "violated"
+	violated.ViolatedBy = violatorID
+	if err := m.saveTreaty(&violated); err != nil {
+		return err
+	}
+	*treaty = violated
+	m.notifyChanged(treaty.Faction1, treaty.Faction2)
 
 	log.Info("Treaty violated: treaty=%s, violator=%s", treaty.ID, violatorID)
 
@@ -592,15 +679,15 @@ func (m *Manager) ViolateTreaty(ctx context.Context, treatyID, violatorID uuid.U
 // ============================================================================
 
 // ModifyRelation changes relationship between two factions
-func (m *Manager) ModifyRelation(faction1, faction2 uuid.UUID, change float64) {
+func (m *Manager) ModifyRelation(faction1, faction2 uuid.UUID, change float64) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	m.modifyRelationUnsafe(faction1, faction2, change)
+	return m.modifyRelationUnsafe(faction1, faction2, change)
 }
 
 // modifyRelationUnsafe changes relationship (must hold lock)
-func (m *Manager) modifyRelationUnsafe(faction1, faction2 uuid.UUID, change float64) {
+func (m *Manager) modifyRelationUnsafe(faction1, faction2 uuid.UUID, change float64) error {
 	// Limit change
 	if change > m.config.RelationChangeLimit {
 		change = m.config.RelationChangeLimit
@@ -610,17 +697,14 @@ func (m *Manager) modifyRelationUnsafe(faction1, faction2 uuid.UUID, change floa
 	}
 
 	key := m.getRelationKey(faction1, faction2)
-	relation, exists := m.relations[key]
-
-	if !exists {
-		relation = &Relation{
-			Faction1:  faction1,
-			Faction2:  faction2,
-			Value:     0.0,
-			Status:    "neutral",
-			UpdatedAt: time.Now(),
-		}
-		m.relations[key] = relation
+	relation := &Relation{
+		Faction1: faction1,
+		Faction2: faction2,
+		Value:    0.0,
+		Status:   "neutral",
+	}
+	if existing, exists := m.relations[key]; exists {
+		*relation = *existing
 	}
 
 	relation.Value += change
@@ -643,6 +727,11 @@ func (m *Manager) modifyRelationUnsafe(faction1, faction2 uuid.UUID, change floa
 	}
 
 	relation.UpdatedAt = time.Now()
+	if err := m.saveRelation(relation); err != nil {
+		return err
+	}
+	m.relations[key] = relation
+	return nil
 }
 
 // GetRelation retrieves relationship between factions
@@ -697,10 +786,11 @@ func (m *Manager) isInAlliance(factionID uuid.UUID) bool {
 	return false
 }
 
-// areAtWar checks if two factions are at war
+// areAtWar checks if two factions are at war. A war with a truce proposed
+// is still being fought.
 func (m *Manager) areAtWar(faction1, faction2 uuid.UUID) bool {
 	for _, war := range m.wars {
-		if war.Status != "active" {
+		if !war.IsOngoing() {
 			continue
 		}
 		for _, aggressor := range war.AggressorFactions {
@@ -757,43 +847,80 @@ func (m *Manager) processMaintenance() {
 	now := time.Now()
 
 	// Relation decay toward neutral
+	// Each change is saved before it is applied; a failed write leaves the
+	// record as stored, to be retried at the next maintenance
 	for _, relation := range m.relations {
-		if relation.Value > 0 {
-			relation.Value -= m.config.RelationDecayRate
-			if relation.Value < 0 {
-				relation.Value = 0
+		decayed := *relation
+		if decayed.Value > 0 {
+			decayed.Value -= m.config.RelationDecayRate
+			if decayed.Value < 0 {
+				decayed.Value = 0
 			}
-		} else if relation.Value < 0 {
-			relation.Value += m.config.RelationDecayRate
-			if relation.Value > 0 {
-				relation.Value = 0
+		} else if decayed.Value < 0 {
+			decayed.Value += m.config.RelationDecayRate
+			if decayed.Value > 0 {
+				decayed.Value = 0
 			}
 		}
+		if decayed.Value == relation.Value {
+			continue
+		}
+		if err := m.saveRelation(&decayed); err != nil {
+			log.Error("Relation decay: %v", err)
+			continue
+		}
+		*relation = decayed
 	}
 
 	// War exhaustion
 	for _, war := range m.wars {
 		if war.Status == "active" {
-			war.Exhaustion += m.config.WarExhaustion
-			if war.Exhaustion >= 1.0 {
-				war.Exhaustion = 1.0
+			exhausted := *war
+			exhausted.Exhaustion += m.config.WarExhaustion
+			if exhausted.Exhaustion >= 1.0 {
+				exhausted.Exhaustion = 1.0
 				// Auto-propose truce at 100% exhaustion
-				if war.TruceProposedBy == uuid.Nil {
-					war.Status = "truce_proposed"
-					war.TruceProposedAt = now
-					log.Info("War exhausted, auto-proposing truce: %s", war.Name)
+				if exhausted.TruceProposedBy == uuid.Nil {
+					exhausted.Status = "truce_proposed"
+					exhausted.TruceProposedAt = now
 				}
 			}
+			if err := m.saveWar(&exhausted); err != nil {
+				log.Error("War exhaustion: %v", err)
+				continue
+			}
+			if exhausted.Status != war.Status {
+				log.Info("War exhausted, auto-proposing truce: %s", war.Name)
+			}
+			*war = exhausted
 		}
 	}
 
 	// Expire treaties
 	for _, treaty := range m.treaties {
 		if treaty.Status == "active" && now.After(treaty.ExpiresAt) {
-			treaty.Status = "expired"
+			expired := *treaty
+			expired.Status = "expired"
+			if err := m.saveTreaty(&expired); err != nil {
+				log.Error("Treaty expiry: %v", err)
+				continue
+			}
+			*treaty = expired
+			m.notifyChanged(treaty.Faction1, treaty.Faction2)
 			log.Info("Treaty expired: %s", treaty.ID)
 		}
 	}
+
+	// Expire unanswered proposals
+	for _, proposal := range m.proposals {
+		if proposal.IsExpired(now) {
+			if err := m.deleteProposal(proposal); err != nil {
+				log.Error("Proposal expiry: %v", err)
+				continue
+			}
+			m.notifyChanged(proposal.FromFactionID, proposal.ToFactionID)
+		}
+	}
 }
 
 // GetStats returns diplomacy statistics
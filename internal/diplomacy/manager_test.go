@@ -0,0 +1,456 @@
+// File: internal/diplomacy/manager_test.go
+// Project: Terminal Velocity
+// Description: Tests for diplomacy - proposals, wars, war score, market fees and persistence
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package diplomacy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/factions"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// memoryStore is an in-memory Store for tests
+type memoryStore struct {
+	alliances map[uuid.UUID]models.Alliance
+	wars      map[uuid.UUID]models.War
+	treaties  map[uuid.UUID]models.Treaty
+	relations map[[2]uuid.UUID]models.Relation
+	proposals map[uuid.UUID]models.DiplomaticProposal
+	failWith  error // returned by every write while set
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		alliances: make(map[uuid.UUID]models.Alliance),
+		wars:      make(map[uuid.UUID]models.War),
+		treaties:  make(map[uuid.UUID]models.Treaty),
+		relations: make(map[[2]uuid.UUID]models.Relation),
+		proposals: make(map[uuid.UUID]models.DiplomaticProposal),
+	}
+}
+
+func (s *memoryStore) SaveAlliance(ctx context.Context, alliance *models.Alliance) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	saved := *alliance
+	saved.MemberFactions = append([]uuid.UUID(nil), alliance.MemberFactions...)
+	s.alliances[alliance.ID] = saved
+	return nil
+}
+
+func (s *memoryStore) ListAlliances(ctx context.Context) ([]*models.Alliance, error) {
+	var alliances []*models.Alliance
+	for _, alliance := range s.alliances {
+		alliance := alliance
+		alliances = append(alliances, &alliance)
+	}
+	return alliances, nil
+}
+
+func (s *memoryStore) SaveWar(ctx context.Context, war *models.War) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	saved := *war
+	saved.WarScore = make(map[uuid.UUID]int, len(war.WarScore))
+	for factionID, score := range war.WarScore {
+		saved.WarScore[factionID] = score
+	}
+	s.wars[war.ID] = saved
+	return nil
+}
+
+func (s *memoryStore) ListWars(ctx context.Context) ([]*models.War, error) {
+	var wars []*models.War
+	for _, war := range s.wars {
+		war := war
+		wars = append(wars, &war)
+	}
+	return wars, nil
+}
+
+func (s *memoryStore) SaveTreaty(ctx context.Context, treaty *models.Treaty) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	s.treaties[treaty.ID] = *treaty
+	return nil
+}
+
+func (s *memoryStore) ListTreaties(ctx context.Context) ([]*models.Treaty, error) {
+	var treaties []*models.Treaty
+	for _, treaty := range s.treaties {
+		treaty := treaty
+		treaties = append(treaties, &treaty)
+	}
+	return treaties, nil
+}
+
+func (s *memoryStore) SaveRelation(ctx context.Context, relation *models.Relation) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	s.relations[[2]uuid.UUID{relation.Faction1, relation.Faction2}] = *relation
+	return nil
+}
+
+func (s *memoryStore) ListRelations(ctx context.Context) ([]*models.Relation, error) {
+	var relations []*models.Relation
+	for _, relation := range s.relations {
+		relation := relation
+		relations = append(relations, &relation)
+	}
+	return relations, nil
+}
+
+func (s *memoryStore) SaveProposal(ctx context.Context, proposal *models.DiplomaticProposal) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	s.proposals[proposal.ID] = *proposal
+	return nil
+}
+
+func (s *memoryStore) DeleteProposal(ctx context.Context, proposalID uuid.UUID) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	delete(s.proposals, proposalID)
+	return nil
+}
+
+func (s *memoryStore) ListProposals(ctx context.Context) ([]*models.DiplomaticProposal, error) {
+	var proposals []*models.DiplomaticProposal
+	for _, proposal := range s.proposals {
+		proposal := proposal
+		proposals = append(proposals, &proposal)
+	}
+	return proposals, nil
+}
+
+type testWorld struct {
+	manager  *Manager
+	factions *factions.Manager
+	store    *memoryStore
+	traders  *models.PlayerFaction
+	miners   *models.PlayerFaction
+	raiders  *models.PlayerFaction
+}
+
+func newTestWorld(t *testing.T) *testWorld {
+	t.Helper()
+
+	w := &testWorld{
+		factions: factions.NewManager(),
+		store:    newMemoryStore(),
+	}
+	w.manager = NewManager(nil, w.factions)
+	w.manager.SetStore(w.store)
+
+	var err error
+	if w.traders, err = w.factions.CreateFaction("Traders", "TRD", uuid.New(), models.AlignmentTrader); err != nil {
+		t.Fatalf("CreateFaction failed: %v", err)
+	}
+	if w.miners, err = w.factions.CreateFaction("Miners", "MIN", uuid.New(), models.AlignmentCorporate); err != nil {
+		t.Fatalf("CreateFaction failed: %v", err)
+	}
+	if w.raiders, err = w.factions.CreateFaction("Raiders", "RAID", uuid.New(), models.AlignmentPirate); err != nil {
+		t.Fatalf("CreateFaction failed: %v", err)
+	}
+	return w
+}
+
+// accept has the leader of the receiving faction accept a proposal
+func (w *testWorld) accept(t *testing.T, proposal *models.DiplomaticProposal, to *models.PlayerFaction) {
+	t.Helper()
+	if err := w.manager.AcceptProposal(context.Background(), proposal.ID, to.LeaderID); err != nil {
+		t.Fatalf("AcceptProposal failed: %v", err)
+	}
+}
+
+func TestAllianceProposals(t *testing.T) {
+	w := newTestWorld(t)
+	ctx := context.Background()
+
+	// Only leaders conduct diplomacy
+	if _, err := w.manager.ProposeAlliance(ctx, w.traders.ID, w.miners.ID, uuid.New()); !errors.Is(err, ErrNotLeader) {
+		t.Fatalf("Expected ErrNotLeader from a non-leader, got %v", err)
+	}
+
+	proposal, err := w.manager.ProposeAlliance(ctx, w.traders.ID, w.miners.ID, w.traders.LeaderID)
+	if err != nil {
+		t.Fatalf("ProposeAlliance failed: %v", err)
+	}
+	if _, err := w.manager.ProposeAlliance(ctx, w.traders.ID, w.miners.ID, w.traders.LeaderID); !errors.Is(err, ErrDuplicateProposal) {
+		t.Errorf("Expected ErrDuplicateProposal, got %v", err)
+	}
+	if incoming, _ := w.manager.GetProposals(w.miners.ID); len(incoming) != 1 {
+		t.Fatalf("Expected 1 incoming proposal, got %d", len(incoming))
+	}
+
+	// The proposer cannot accept its own offer
+	if err := w.manager.AcceptProposal(ctx, proposal.ID, w.traders.LeaderID); !errors.Is(err, ErrNotLeader) {
+		t.Fatalf("Expected ErrNotLeader from the proposer, got %v", err)
+	}
+	w.accept(t, proposal, w.miners)
+
+	if !w.manager.Allied(w.traders.ID, w.miners.ID) {
+		t.Fatal("Expected the factions to be allied")
+	}
+	alliance := w.manager.GetFactionAlliance(w.miners.ID)
+	if alliance == nil || alliance.Name != "TRD-MIN Alliance" || alliance.LeaderFactionID != w.traders.ID {
+		t.Fatalf("Expected the TRD-MIN Alliance led by the traders, got %+v", alliance)
+	}
+	if _, exists := w.store.proposals[proposal.ID]; exists {
+		t.Error("Expected the accepted proposal to be removed from the store")
+	}
+
+	// Only the alliance leader invites new members, who join the existing alliance
+	if _, err := w.manager.ProposeAlliance(ctx, w.miners.ID, w.raiders.ID, w.miners.LeaderID); !errors.Is(err, ErrNotAllianceLeader) {
+		t.Errorf("Expected ErrNotAllianceLeader, got %v", err)
+	}
+	proposal, err = w.manager.ProposeAlliance(ctx, w.traders.ID, w.raiders.ID, w.traders.LeaderID)
+	if err != nil {
+		t.Fatalf("ProposeAlliance failed: %v", err)
+	}
+	w.accept(t, proposal, w.raiders)
+
+	if allies := w.manager.AlliedFactions(w.raiders.ID); len(allies) != 2 {
+		t.Errorf("Expected the raiders to have 2 allies, got %d", len(allies))
+	}
+	if saved := w.store.alliances[alliance.ID]; len(saved.MemberFactions) != 2 {
+		t.Errorf("Expected the saved alliance to have 2 members, got %d", len(saved.MemberFactions))
+	}
+}
+
+func TestDeclineProposal(t *testing.T) {
+	w := newTestWorld(t)
+	ctx := context.Background()
+
+	proposal, err := w.manager.ProposeTreaty(ctx, TreatyNonAggression, w.traders.ID, w.miners.ID, w.traders.LeaderID)
+	if err != nil {
+		t.Fatalf("ProposeTreaty failed: %v", err)
+	}
+	if err := w.manager.DeclineProposal(ctx, proposal.ID, w.raiders.LeaderID); !errors.Is(err, ErrNotLeader) {
+		t.Fatalf("Expected ErrNotLeader from an uninvolved leader, got %v", err)
+	}
+	if err := w.manager.DeclineProposal(ctx, proposal.ID, w.miners.LeaderID); err != nil {
+		t.Fatalf("DeclineProposal failed: %v", err)
+	}
+	if err := w.manager.AcceptProposal(ctx, proposal.ID, w.miners.LeaderID); !errors.Is(err, ErrProposalNotFound) {
+		t.Errorf("Expected ErrProposalNotFound after declining, got %v", err)
+	}
+	if w.manager.HasTreaty(w.traders.ID, w.miners.ID, TreatyNonAggression) {
+		t.Error("Expected no treaty after declining")
+	}
+}
+
+func TestMarketFeeRate(t *testing.T) {
+	w := newTestWorld(t)
+	ctx := context.Background()
+	config := DefaultDiplomacyConfig()
+	owner := w.traders.ID
+
+	if rate := w.manager.MarketFeeRate(w.miners.ID, uuid.Nil); rate != 0 {
+		t.Errorf("Expected no fee in unclaimed space, got %v", rate)
+	}
+	if rate := w.manager.MarketFeeRate(owner, owner); rate != 0 {
+		t.Errorf("Expected no fee in the faction's own territory, got %v", rate)
+	}
+	if rate := w.manager.MarketFeeRate(uuid.Nil, owner); rate != config.TerritoryMarketFee {
+		t.Errorf("Expected the territory fee for unaffiliated players, got %v", rate)
+	}
+	if rate := w.manager.MarketFeeRate(w.miners.ID, owner); rate != config.TerritoryMarketFee {
+		t.Errorf("Expected the territory fee for a neutral faction, got %v", rate)
+	}
+
+	proposal, err := w.manager.ProposeTreaty(ctx, TreatyTradeAgreement, w.miners.ID, owner, w.miners.LeaderID)
+	if err != nil {
+		t.Fatalf("ProposeTreaty failed: %v", err)
+	}
+	w.accept(t, proposal, w.traders)
+	if rate := w.manager.MarketFeeRate(w.miners.ID, owner); rate != config.TradeAgreementMarketFee {
+		t.Errorf("Expected the trade agreement fee, got %v", rate)
+	}
+
+	if err := w.factions.AddFunds(w.raiders.ID, config.WarDeclarationCost); err != nil {
+		t.Fatalf("AddFunds failed: %v", err)
+	}
+	if _, err := w.manager.DeclareFactionWar(ctx, w.raiders.ID, owner, w.raiders.LeaderID); err != nil {
+		t.Fatalf("DeclareFactionWar failed: %v", err)
+	}
+	if rate := w.manager.MarketFeeRate(w.raiders.ID, owner); rate != config.WarMarketFee {
+		t.Errorf("Expected the war fee for an enemy, got %v", rate)
+	}
+}
+
+func TestDeclareFactionWar(t *testing.T) {
+	w := newTestWorld(t)
+	ctx := context.Background()
+	cost := DefaultDiplomacyConfig().WarDeclarationCost
+
+	// The miners ally with the traders and sign a non-aggression pact with the raiders
+	proposal, err := w.manager.ProposeAlliance(ctx, w.traders.ID, w.miners.ID, w.traders.LeaderID)
+	if err != nil {
+		t.Fatalf("ProposeAlliance failed: %v", err)
+	}
+	w.accept(t, proposal, w.miners)
+	proposal, err = w.manager.ProposeTreaty(ctx, TreatyNonAggression, w.raiders.ID, w.miners.ID, w.raiders.LeaderID)
+	if err != nil {
+		t.Fatalf("ProposeTreaty failed: %v", err)
+	}
+	w.accept(t, proposal, w.miners)
+
+	if _, err := w.manager.DeclareFactionWar(ctx, w.raiders.ID, w.miners.ID, w.raiders.LeaderID); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("Expected ErrInsufficientFunds, got %v", err)
+	}
+	if _, err := w.manager.DeclareFactionWar(ctx, w.traders.ID, w.miners.ID, w.traders.LeaderID); !errors.Is(err, ErrCannotAttackAlly) {
+		t.Errorf("Expected ErrCannotAttackAlly, got %v", err)
+	}
+
+	if err := w.factions.AddFunds(w.raiders.ID, cost+100); err != nil {
+		t.Fatalf("AddFunds failed: %v", err)
+	}
+	war, err := w.manager.DeclareFactionWar(ctx, w.raiders.ID, w.miners.ID, w.raiders.LeaderID)
+	if err != nil {
+		t.Fatalf("DeclareFactionWar failed: %v", err)
+	}
+	if raiders, _ := w.factions.GetFaction(w.raiders.ID); raiders.Treasury != 100 {
+		t.Errorf("Expected the declaration to cost %d, treasury is %d", cost, raiders.Treasury)
+	}
+
+	// The miners' ally is drawn into the war
+	if !w.manager.AtWar(w.raiders.ID, w.miners.ID) || !w.manager.AtWar(w.raiders.ID, w.traders.ID) {
+		t.Errorf("Expected the raiders at war with both allies, defenders %v", war.DefenderFactions)
+	}
+	if w.manager.HasTreaty(w.raiders.ID, w.miners.ID, TreatyNonAggression) {
+		t.Error("Expected the declaration to break the non-aggression pact")
+	}
+	for _, treaty := range w.store.treaties {
+		if treaty.Type == TreatyNonAggression && (treaty.Status != "violated" || treaty.ViolatedBy != w.raiders.ID) {
+			t.Errorf("Expected the saved pact to be violated by the raiders, got %s by %s", treaty.Status, treaty.ViolatedBy)
+		}
+	}
+
+	if _, err := w.manager.DeclareFactionWar(ctx, w.raiders.ID, w.traders.ID, w.raiders.LeaderID); !errors.Is(err, ErrAlreadyAtWar) {
+		t.Errorf("Expected ErrAlreadyAtWar, got %v", err)
+	}
+}
+
+func TestWarScoreFromCombat(t *testing.T) {
+	w := newTestWorld(t)
+	config := DefaultDiplomacyConfig()
+
+	// No war, no score
+	if w.manager.RecordPvPKill(w.raiders.ID, w.traders.ID) {
+		t.Fatal("Expected no war score outside a war")
+	}
+
+	war, err := w.manager.DeclareWar(context.Background(), "Test War", []uuid.UUID{w.raiders.ID}, []uuid.UUID{w.traders.ID})
+	if err != nil {
+		t.Fatalf("DeclareWar failed: %v", err)
+	}
+
+	if !w.manager.RecordPvPKill(w.raiders.ID, w.traders.ID) {
+		t.Fatal("Expected a PvP kill to score")
+	}
+	if !w.manager.RecordTerritoryConflict(w.raiders.ID, w.traders.ID, true) {
+		t.Fatal("Expected a capture to score")
+	}
+	if !w.manager.RecordTerritoryConflict(w.traders.ID, w.raiders.ID, false) {
+		t.Fatal("Expected a defense to score")
+	}
+	if w.manager.RecordPvPKill(w.miners.ID, w.traders.ID) {
+		t.Error("Expected no score for a faction outside the war")
+	}
+
+	if score := war.WarScore[w.raiders.ID]; score != config.PvPKillWarScore+config.CaptureWarScore {
+		t.Errorf("Expected raiders score %d, got %d", config.PvPKillWarScore+config.CaptureWarScore, score)
+	}
+	if score := war.WarScore[w.traders.ID]; score != config.DefenseWarScore {
+		t.Errorf("Expected traders score %d, got %d", config.DefenseWarScore, score)
+	}
+	if saved := w.store.wars[war.ID]; saved.WarScore[w.traders.ID] != config.DefenseWarScore {
+		t.Errorf("Expected the war score to be saved, got %v", saved.WarScore)
+	}
+}
+
+func TestFailedWritesLeaveStateUnchanged(t *testing.T) {
+	w := newTestWorld(t)
+	ctx := context.Background()
+
+	proposal, err := w.manager.ProposeAlliance(ctx, w.traders.ID, w.miners.ID, w.traders.LeaderID)
+	if err != nil {
+		t.Fatalf("ProposeAlliance failed: %v", err)
+	}
+
+	w.store.failWith = errors.New("database unavailable")
+	if err := w.manager.AcceptProposal(ctx, proposal.ID, w.miners.LeaderID); !errors.Is(err, w.store.failWith) {
+		t.Fatalf("Expected the store error from AcceptProposal, got %v", err)
+	}
+	if w.manager.Allied(w.traders.ID, w.miners.ID) {
+		t.Error("Expected no alliance after a failed write")
+	}
+	if relation := w.manager.GetRelation(w.traders.ID, w.miners.ID); relation.Value != 0 {
+		t.Errorf("Expected an unchanged relation after a failed write, got %v", relation.Value)
+	}
+	if _, err := w.manager.DeclareWar(ctx, "Test War", []uuid.UUID{w.raiders.ID}, []uuid.UUID{w.traders.ID}); !errors.Is(err, w.store.failWith) {
+		t.Errorf("Expected the store error from DeclareWar, got %v", err)
+	}
+	if w.manager.AtWar(w.raiders.ID, w.traders.ID) {
+		t.Error("Expected no war after a failed write")
+	}
+
+	// The proposal is still open once the store recovers
+	w.store.failWith = nil
+	w.accept(t, proposal, w.miners)
+	if !w.manager.Allied(w.traders.ID, w.miners.ID) {
+		t.Error("Expected the factions to be allied after a retry")
+	}
+}
+
+func TestLoadRestoresDiplomacy(t *testing.T) {
+	w := newTestWorld(t)
+	ctx := context.Background()
+
+	proposal, err := w.manager.ProposeAlliance(ctx, w.traders.ID, w.miners.ID, w.traders.LeaderID)
+	if err != nil {
+		t.Fatalf("ProposeAlliance failed: %v", err)
+	}
+	w.accept(t, proposal, w.miners)
+	if _, err := w.manager.DeclareWar(ctx, "Test War", []uuid.UUID{w.raiders.ID}, []uuid.UUID{w.traders.ID}); err != nil {
+		t.Fatalf("DeclareWar failed: %v", err)
+	}
+	if _, err := w.manager.ProposeTreaty(ctx, TreatyResearchPact, w.miners.ID, w.raiders.ID, w.miners.LeaderID); err != nil {
+		t.Fatalf("ProposeTreaty failed: %v", err)
+	}
+
+	// A restarted server rebuilds diplomacy from the store
+	restarted := NewManager(nil, w.factions)
+	restarted.SetStore(w.store)
+	if err := restarted.Load(ctx); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if !restarted.Allied(w.traders.ID, w.miners.ID) {
+		t.Error("Expected the alliance to survive a restart")
+	}
+	if !restarted.AtWar(w.raiders.ID, w.traders.ID) {
+		t.Error("Expected the war to survive a restart")
+	}
+	if incoming, _ := restarted.GetProposals(w.raiders.ID); len(incoming) != 1 || incoming[0].TreatyType != TreatyResearchPact {
+		t.Errorf("Expected the research pact proposal to survive a restart, got %v", incoming)
+	}
+	if relation := restarted.GetRelation(w.traders.ID, w.miners.ID); relation.Value <= 0 {
+		t.Errorf("Expected the allied relation to survive a restart, got %v", relation.Value)
+	}
+}
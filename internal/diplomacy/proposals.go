@@ -0,0 +1,364 @@
+// File: internal/diplomacy/proposals.go
+// Project: Terminal Velocity
+// Description: Leader-driven diplomacy - alliance and treaty proposals and war declarations
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// Faction leaders conduct diplomacy from the diplomacy screen. Alliances and
+// treaties need both sides: one leader proposes, and the other faction's
+// leader accepts or declines within ProposalDuration. War needs only the
+// aggressor and costs WarDeclarationCost from its treasury. Declaring war
+// breaks every treaty between the two factions, and the defender's alliance
+// and mutual defense partners join the war on the defender's side.
+
+package diplomacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/factions"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotLeader         = errors.New("only the faction leader can conduct diplomacy")
+	ErrNoFactions        = errors.New("faction manager not configured")
+	ErrSameFaction       = errors.New("cannot conduct diplomacy with your own faction")
+	ErrProposalNotFound  = errors.New("proposal not found")
+	ErrProposalExpired   = errors.New("proposal has expired")
+	ErrDuplicateProposal = errors.New("an identical proposal is already open")
+	ErrAlreadyAllied     = errors.New("faction is already in an alliance")
+	ErrNotAllianceLeader = errors.New("only the alliance's leading faction can invite members")
+	ErrAllianceFull      = errors.New("alliance is full")
+	ErrAtWar             = errors.New("factions are at war")
+	ErrAlreadyAtWar      = errors.New("factions are already at war")
+	ErrCannotAttackAlly  = errors.New("cannot declare war on an ally")
+	ErrInvalidTreatyType = errors.New("invalid treaty type")
+	ErrTreatyExists      = errors.New("factions already have this treaty")
+	ErrInsufficientFunds = errors.New("insufficient faction funds")
+)
+
+// treatyTerms describes what each treaty type means in play
+var treatyTerms = map[TreatyType]string{
+	TreatyNonAggression:  "Neither faction will declare war on the other",
+	TreatyTradeAgreement: "Reduced market fees in each other's territory",
+	TreatyMutualDefense:  "Each faction joins wars declared on the other",
+	TreatyResearchPact:   "Shared research between the factions",
+}
+
+// requireLeader returns the faction if playerID leads it (must hold lock)
+func (m *Manager) requireLeader(factionID, playerID uuid.UUID) (*models.PlayerFaction, error) {
+	if m.factionManager == nil {
+		return nil, ErrNoFactions
+	}
+	faction, err := m.factionManager.GetFaction(factionID)
+	if err != nil {
+		return nil, err
+	}
+	if faction.LeaderID != playerID {
+		return nil, ErrNotLeader
+	}
+	return faction, nil
+}
+
+// ProposeAlliance offers another faction an alliance. A faction that leads
+// an alliance invites the other faction to join it; otherwise accepting
+// forms a new alliance of the two.
+func (m *Manager) ProposeAlliance(ctx context.Context, fromFactionID, toFactionID, playerID uuid.UUID) (*models.DiplomaticProposal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkAllianceProposal(fromFactionID, toFactionID); err != nil {
+		return nil, err
+	}
+	return m.propose(models.ProposalAlliance, "", fromFactionID, toFactionID, playerID)
+}
+
+// ProposeTreaty offers another faction a treaty
+func (m *Manager) ProposeTreaty(ctx context.Context, treatyType TreatyType, fromFactionID, toFactionID, playerID uuid.UUID) (*models.DiplomaticProposal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, known := treatyTerms[treatyType]; !known {
+		return nil, ErrInvalidTreatyType
+	}
+	if m.areAtWar(fromFactionID, toFactionID) {
+		return nil, ErrAtWar
+	}
+	if m.hasTreaty(fromFactionID, toFactionID, treatyType) {
+		return nil, ErrTreatyExists
+	}
+	return m.propose(models.ProposalTreaty, treatyType, fromFactionID, toFactionID, playerID)
+}
+
+// propose records a new proposal from a faction leader (must hold lock)
+func (m *Manager) propose(kind models.ProposalKind, treatyType TreatyType, fromFactionID, toFactionID, playerID uuid.UUID) (*models.DiplomaticProposal, error) {
+	if fromFactionID == toFactionID {
+		return nil, ErrSameFaction
+	}
+	if _, err := m.requireLeader(fromFactionID, playerID); err != nil {
+		return nil, err
+	}
+	if _, err := m.factionManager.GetFaction(toFactionID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, existing := range m.proposals {
+		if existing.Kind == kind && existing.TreatyType == treatyType && !existing.IsExpired(now) &&
+			existing.FromFactionID == fromFactionID && existing.ToFactionID == toFactionID {
+			return nil, ErrDuplicateProposal
+		}
+	}
+
+	proposal := &models.DiplomaticProposal{
+		ID:            uuid.New(),
+		Kind:          kind,
+		TreatyType:    treatyType,
+		FromFactionID: fromFactionID,
+		ToFactionID:   toFactionID,
+		ProposedBy:    playerID,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(m.config.ProposalDuration),
+	}
+	if err := m.saveProposal(proposal); err != nil {
+		return nil, err
+	}
+	m.proposals[proposal.ID] = proposal
+	m.notifyChanged(fromFactionID, toFactionID)
+
+	log.Info("Diplomatic proposal: %s from %s to %s", proposal.Describe(), fromFactionID, toFactionID)
+	return proposal, nil
+}
+
+// AcceptProposal puts a proposal into effect. Only the leader of the faction
+// it was made to can accept it.
+func (m *Manager) AcceptProposal(ctx context.Context, proposalID, playerID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	proposal, exists := m.proposals[proposalID]
+	if !exists {
+		return ErrProposalNotFound
+	}
+	if _, err := m.requireLeader(proposal.ToFactionID, playerID); err != nil {
+		return err
+	}
+	if proposal.IsExpired(time.Now()) {
+		if err := m.deleteProposal(proposal); err != nil {
+			return err
+		}
+		m.notifyChanged(proposal.FromFactionID, proposal.ToFactionID)
+		return ErrProposalExpired
+	}
+
+	var err error
+	switch proposal.Kind {
+	case models.ProposalAlliance:
+		err = m.acceptAlliance(proposal)
+	case models.ProposalTreaty:
+		_, err = m.signTreaty(proposal.TreatyType, proposal.FromFactionID, proposal.ToFactionID,
+			treatyTerms[proposal.TreatyType], m.config.TreatyDuration)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The proposal is already in effect; a failed delete only leaves it to
+	// expire, and accepting it again fails its alliance or treaty checks
+	if err := m.deleteProposal(proposal); err != nil {
+		log.Error("Accepted proposal: %v", err)
+	}
+	m.notifyChanged(proposal.FromFactionID, proposal.ToFactionID)
+	return nil
+}
+
+// acceptAlliance joins the proposer's alliance or forms a new one (must hold lock)
+func (m *Manager) acceptAlliance(proposal *models.DiplomaticProposal) error {
+	if err := m.checkAllianceProposal(proposal.FromFactionID, proposal.ToFactionID); err != nil {
+		return err
+	}
+
+	if alliance := m.allianceOf(proposal.FromFactionID); alliance != nil {
+		for _, memberID := range alliance.AllFactions() {
+			if err := m.modifyRelationUnsafe(memberID, proposal.ToFactionID, 0.50); err != nil {
+				return err
+			}
+		}
+		joined := *alliance
+		joined.MemberFactions = append(append([]uuid.UUID(nil), alliance.MemberFactions...), proposal.ToFactionID)
+		if err := m.saveAlliance(&joined); err != nil {
+			return err
+		}
+		*alliance = joined
+		m.notifyChanged(alliance.AllFactions()...)
+		log.Info("Faction joined alliance: faction=%s, alliance=%s", proposal.ToFactionID, alliance.Name)
+		return nil
+	}
+
+	from, err := m.factionManager.GetFaction(proposal.FromFactionID)
+	if err != nil {
+		return err
+	}
+	to, err := m.factionManager.GetFaction(proposal.ToFactionID)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s Alliance", from.Tag, to.Tag)
+	description := fmt.Sprintf("Alliance of %s and %s", from.Name, to.Name)
+	_, err = m.formAlliance(name, description, from.ID, []uuid.UUID{to.ID})
+	return err
+}
+
+// checkAllianceProposal checks that two factions could ally (must hold lock)
+func (m *Manager) checkAllianceProposal(fromFactionID, toFactionID uuid.UUID) error {
+	if m.areAtWar(fromFactionID, toFactionID) {
+		return ErrAtWar
+	}
+	if m.isInAlliance(toFactionID) {
+		return ErrAlreadyAllied
+	}
+	if alliance := m.allianceOf(fromFactionID); alliance != nil {
+		if alliance.LeaderFactionID != fromFactionID {
+			return ErrNotAllianceLeader
+		}
+		if len(alliance.AllFactions()) >= m.config.MaxFactionsInAlliance {
+			return ErrAllianceFull
+		}
+	}
+	return nil
+}
+
+// DeclineProposal rejects a proposal. The leader of either faction can
+// decline it, so a proposer can also withdraw an offer.
+func (m *Manager) DeclineProposal(ctx context.Context, proposalID, playerID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	proposal, exists := m.proposals[proposalID]
+	if !exists {
+		return ErrProposalNotFound
+	}
+	if _, err := m.requireLeader(proposal.ToFactionID, playerID); err != nil {
+		if _, err := m.requireLeader(proposal.FromFactionID, playerID); err != nil {
+			return err
+		}
+	}
+
+	if err := m.deleteProposal(proposal); err != nil {
+		return err
+	}
+	m.notifyChanged(proposal.FromFactionID, proposal.ToFactionID)
+	log.Info("Diplomatic proposal declined: %s from %s to %s", proposal.Describe(), proposal.FromFactionID, proposal.ToFactionID)
+	return nil
+}
+
+// DeclareFactionWar has a faction leader declare war on another faction.
+// The aggressor pays WarDeclarationCost and breaks any treaties with the
+// defender; the defender's allies and mutual defense partners join the war
+// on its side.
+func (m *Manager) DeclareFactionWar(ctx context.Context, aggressorFactionID, defenderFactionID, playerID uuid.UUID) (*War, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if aggressorFactionID == defenderFactionID {
+		return nil, ErrSameFaction
+	}
+	aggressor, err := m.requireLeader(aggressorFactionID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defender, err := m.factionManager.GetFaction(defenderFactionID)
+	if err != nil {
+		return nil, err
+	}
+	if m.areAtWar(aggressorFactionID, defenderFactionID) {
+		return nil, ErrAlreadyAtWar
+	}
+	if m.areAllied(aggressorFactionID, defenderFactionID) {
+		return nil, ErrCannotAttackAlly
+	}
+
+	// The defender's alliance and defense pacts are called in, except
+	// where they are bound to the aggressor as well
+	defenders := []uuid.UUID{defenderFactionID}
+	for _, partnerID := range m.defensePartners(defenderFactionID) {
+		if partnerID != aggressorFactionID && !m.areAllied(partnerID, aggressorFactionID) &&
+			!m.hasTreaty(partnerID, aggressorFactionID, TreatyMutualDefense) {
+			defenders = append(defenders, partnerID)
+		}
+	}
+
+	if err := m.factionManager.SpendFunds(aggressorFactionID, m.config.WarDeclarationCost); err != nil {
+		if errors.Is(err, factions.ErrInsufficientFunds) {
+			return nil, ErrInsufficientFunds
+		}
+		return nil, err
+	}
+	refund := func() {
+		if refundErr := m.factionManager.AddFunds(aggressorFactionID, m.config.WarDeclarationCost); refundErr != nil {
+			log.Error("Failed to refund war declaration to faction %s: %v", aggressorFactionID, refundErr)
+		}
+	}
+
+	// Treaties are broken before the war starts, so a war is never saved
+	// alongside a treaty that still binds the two sides
+	now := time.Now()
+	for _, treaty := range m.treaties {
+		if treaty.Binds(aggressorFactionID, defenderFactionID, now) {
+			if err := m.violateTreaty(treaty, aggressorFactionID); err != nil {
+				refund()
+				return nil, err
+			}
+		}
+	}
+
+	war, err := m.declareWar(fmt.Sprintf("[%s] vs [%s]", aggressor.Tag, defender.Tag),
+		[]uuid.UUID{aggressorFactionID}, defenders)
+	if err != nil {
+		// Refund the declaration; the war never started
+		refund()
+		return nil, err
+	}
+
+	return war, nil
+}
+
+// defensePartners returns the factions that defend a faction: its alliance
+// members and mutual defense treaty partners (must hold lock)
+func (m *Manager) defensePartners(factionID uuid.UUID) []uuid.UUID {
+	var partners []uuid.UUID
+	seen := map[uuid.UUID]bool{factionID: true}
+	if alliance := m.allianceOf(factionID); alliance != nil {
+		for _, memberID := range alliance.AllFactions() {
+			if !seen[memberID] {
+				seen[memberID] = true
+				partners = append(partners, memberID)
+			}
+		}
+	}
+
+	now := time.Now()
+	for _, treaty := range m.treaties {
+		if treaty.Type != TreatyMutualDefense || treaty.Status != "active" || !now.Before(treaty.ExpiresAt) {
+			continue
+		}
+		partnerID := uuid.Nil
+		switch factionID {
+		case treaty.Faction1:
+			partnerID = treaty.Faction2
+		case treaty.Faction2:
+			partnerID = treaty.Faction1
+		}
+		if partnerID != uuid.Nil && !seen[partnerID] {
+			seen[partnerID] = true
+			partners = append(partners, partnerID)
+		}
+	}
+	return partners
+}
@@ -0,0 +1,171 @@
+// File: internal/diplomacy/store.go
+// Project: Terminal Velocity
+// Description: Persistence for alliances, wars, treaties, relations and proposals
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package diplomacy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// dbTimeout bounds each write to the diplomacy store
+const dbTimeout = 5 * time.Second
+
+// Store persists diplomacy state so it survives restarts. The database
+// package's DiplomacyRepository implements it.
+type Store interface {
+	SaveAlliance(ctx context.Context, alliance *models.Alliance) error
+	ListAlliances(ctx context.Context) ([]*models.Alliance, error)
+
+	SaveWar(ctx context.Context, war *models.War) error
+	ListWars(ctx context.Context) ([]*models.War, error)
+
+	SaveTreaty(ctx context.Context, treaty *models.Treaty) error
+	ListTreaties(ctx context.Context) ([]*models.Treaty, error)
+
+	SaveRelation(ctx context.Context, relation *models.Relation) error
+	ListRelations(ctx context.Context) ([]*models.Relation, error)
+
+	SaveProposal(ctx context.Context, proposal *models.DiplomaticProposal) error
+	DeleteProposal(ctx context.Context, proposalID uuid.UUID) error
+	ListProposals(ctx context.Context) ([]*models.DiplomaticProposal, error)
+}
+
+// SetStore sets where diplomacy state is persisted. Without a store the
+// manager keeps everything in memory.
+func (m *Manager) SetStore(store Store) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store = store
+}
+
+// Load replaces the in-memory diplomacy state with the store's
+func (m *Manager) Load(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.store == nil {
+		return nil
+	}
+
+	alliances, err := m.store.ListAlliances(ctx)
+	if err != nil {
+		return err
+	}
+	wars, err := m.store.ListWars(ctx)
+	if err != nil {
+		return err
+	}
+	treaties, err := m.store.ListTreaties(ctx)
+	if err != nil {
+		return err
+	}
+	relations, err := m.store.ListRelations(ctx)
+	if err != nil {
+		return err
+	}
+	proposals, err := m.store.ListProposals(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.alliances = make(map[uuid.UUID]*Alliance, len(alliances))
+	for _, alliance := range alliances {
+		m.alliances[alliance.ID] = alliance
+	}
+	m.wars = make(map[uuid.UUID]*War, len(wars))
+	for _, war := range wars {
+		if war.WarScore == nil {
+			war.WarScore = make(map[uuid.UUID]int)
+		}
+		m.wars[war.ID] = war
+	}
+	m.treaties = make(map[uuid.UUID]*Treaty, len(treaties))
+	for _, treaty := range treaties {
+		m.treaties[treaty.ID] = treaty
+	}
+	m.relations = make(map[string]*Relation, len(relations))
+	for _, relation := range relations {
+		m.relations[m.getRelationKey(relation.Faction1, relation.Faction2)] = relation
+	}
+	m.proposals = make(map[uuid.UUID]*models.DiplomaticProposal, len(proposals))
+	for _, proposal := range proposals {
+		m.proposals[proposal.ID] = proposal
+	}
+
+	log.Info("Loaded diplomacy: alliances=%d, wars=%d, treaties=%d, relations=%d, proposals=%d",
+		len(alliances), len(wars), len(treaties), len(relations), len(proposals))
+	return nil
+}
+
+// persist runs a store write with a timeout and returns its error. Callers
+// write before they change memory, so a failed write leaves the manager
+// matching the store. Caller must hold m.mu.
+func (m *Manager) persist(what string, write func(ctx context.Context, store Store) error) error {
+	if m.store == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	if err := write(ctx, m.store); err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+	return nil
+}
+
+// saveAlliance writes an alliance to the store. Caller must hold m.mu.
+func (m *Manager) saveAlliance(alliance *Alliance) error {
+	return m.persist("alliance "+alliance.Name, func(ctx context.Context, store Store) error {
+		return store.SaveAlliance(ctx, alliance)
+	})
+}
+
+// saveWar writes a war to the store. Caller must hold m.mu.
+func (m *Manager) saveWar(war *War) error {
+	return m.persist("war "+war.Name, func(ctx context.Context, store Store) error {
+		return store.SaveWar(ctx, war)
+	})
+}
+
+// saveTreaty writes a treaty to the store. Caller must hold m.mu.
+func (m *Manager) saveTreaty(treaty *Treaty) error {
+	return m.persist("treaty "+treaty.ID.String(), func(ctx context.Context, store Store) error {
+		return store.SaveTreaty(ctx, treaty)
+	})
+}
+
+// saveRelation writes a relation to the store. Caller must hold m.mu.
+func (m *Manager) saveRelation(relation *Relation) error {
+	return m.persist("relation", func(ctx context.Context, store Store) error {
+		return store.SaveRelation(ctx, relation)
+	})
+}
+
+// saveProposal writes a new proposal to the store. Caller must hold m.mu.
+func (m *Manager) saveProposal(proposal *models.DiplomaticProposal) error {
+	return m.persist("proposal "+proposal.ID.String(), func(ctx context.Context, store Store) error {
+		return store.SaveProposal(ctx, proposal)
+	})
+}
+
+// deleteProposal removes an answered or expired proposal from the store and
+// then from memory. Caller must hold m.mu.
+func (m *Manager) deleteProposal(proposal *models.DiplomaticProposal) error {
+	err := m.persist("proposal "+proposal.ID.String(), func(ctx context.Context, store Store) error {
+		return store.DeleteProposal(ctx, proposal.ID)
+	})
+	if err != nil {
+		return err
+	}
+	delete(m.proposals, proposal.ID)
+	return nil
+}
@@ -0,0 +1,279 @@
+// File: internal/economy/manager.go
+// Project: Terminal Velocity
+// Description: Background economy simulation driving market recovery over time
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+// Package economy runs the background market simulation.
+//
+// Markets recover from player trading over time: stock and demand drift back
+// toward their natural levels and occasional random events shake prices up.
+// The pricing rules live in trading.PricingEngine.SimulateMarketTick; this
+// package decides when to apply them and persists the results.
+//
+// Elapsed Time:
+// Each market row stores the Unix time of its last update. On every tick the
+// manager loads markets that are at least one hour stale and simulates the
+// number of whole hours that have passed. The fractional remainder is kept by
+// advancing last_update by exactly those hours rather than setting it to now,
+// so a server restart (or a slow tick interval) never loses simulated time.
+// Markets for a commodity or planet that no longer exists cannot be
+// simulated; their last_update is set to now so they do not hold back the
+// rest of the backlog.
+//
+// Concurrency:
+// Writes are conditional on last_update being unchanged since the row was
+// read. If a player trade touched the market in between, the trade wins and
+// the market is picked up again on a later tick.
+package economy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/game/trading"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/metrics"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+var log = logger.WithComponent("Economy")
+
+const (
+	// secondsPerHour is the simulation step size
+	secondsPerHour = 3600
+
+	// maxCatchUpHours caps how much downtime is simulated in one go.
+	// SimulateMarketTick converges well before a week of hourly steps.
+	maxCatchUpHours = 168
+
+	// staleBatchSize matches the LIMIT in MarketRepository.GetStaleMarkets
+	staleBatchSize = 1000
+
+	// maxBatchesPerTick bounds the work done in a single tick
+	maxBatchesPerTick = 50
+
+	// tickTimeout bounds the database work of a single tick
+	tickTimeout = 2 * time.Minute
+
+	// DefaultTickInterval is used when no interval is configured
+	DefaultTickInterval = 5 * time.Minute
+)
+
+// Manager periodically advances market simulation for all stale markets
+type Manager struct {
+	marketRepo *database.MarketRepository
+	systemRepo *database.SystemRepository
+
+	// pricing is only used from the worker goroutine (PricingEngine is not thread-safe)
+	pricing  *trading.PricingEngine
+	interval time.Duration
+
+	// Background workers
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// TickResult summarizes one simulation pass
+type TickResult struct {
+	Examined int // Stale markets loaded
+	Updated  int // Markets advanced and persisted
+	Skipped  int // Markets skipped (unknown commodity/planet or concurrent trade)
+	Failed   int // Markets that could not be persisted
+}
+
+// NewManager creates an economy manager ticking at the given interval.
+// A non-positive interval falls back to DefaultTickInterval.
+func NewManager(marketRepo *database.MarketRepository, systemRepo *database.SystemRepository, interval time.Duration) *Manager {
+	if interval <= 0 {
+		interval = DefaultTickInterval
+	}
+
+	return &Manager{
+		marketRepo: marketRepo,
+		systemRepo: systemRepo,
+		pricing:    trading.NewPricingEngine(),
+		interval:   interval,
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// Start begins the simulation worker.
+// The first tick runs immediately so that downtime is caught up on startup.
+func (m *Manager) Start() {
+	m.wg.Add(1)
+	go m.simulationWorker()
+	log.Info("Economy manager started: interval=%v", m.interval)
+}
+
+// Stop gracefully shuts down the economy manager
+func (m *Manager) Stop() {
+	close(m.stopChan)
+	m.wg.Wait()
+	log.Info("Economy manager stopped")
+}
+
+// simulationWorker runs ticks until stopped
+func (m *Manager) simulationWorker() {
+	defer m.wg.Done()
+
+	m.runTick()
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.runTick()
+		case <-m.stopChan:
+			return
+		}
+	}
+}
+
+// runTick performs one tick and records metrics
+func (m *Manager) runTick() {
+	ctx, cancel := context.WithTimeout(context.Background(), tickTimeout)
+	defer cancel()
+
+	// Abort in-flight database work promptly on shutdown
+	go func() {
+		select {
+		case <-m.stopChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	start := time.Now()
+	result := m.Tick(ctx, start)
+	elapsed := time.Since(start)
+
+	collector := metrics.Global()
+	collector.RecordTickTime(elapsed)
+	collector.SetGauge("economy_markets_updated", int64(result.Updated))
+	collector.SetGauge("economy_markets_skipped", int64(result.Skipped))
+	collector.IncrementCounter("economy_ticks")
+	if result.Failed > 0 {
+		collector.IncrementCounter("economy_tick_failures")
+	}
+
+	if result.Examined > 0 {
+		log.Debug("Economy tick: examined=%d, updated=%d, skipped=%d, failed=%d, duration=%v",
+			result.Examined, result.Updated, result.Skipped, result.Failed, elapsed)
+	}
+}
+
+// Tick advances every market that is at least one hour stale as of now.
+// Exported so that admin tooling and tests can force a simulation pass.
+func (m *Manager) Tick(ctx context.Context, now time.Time) TickResult {
+	var result TickResult
+	cutoff := now.Unix() - secondsPerHour
+	planets := make(map[uuid.UUID]*models.Planet)
+
+	for batch := 0; batch < maxBatchesPerTick; batch++ {
+		markets, err := m.marketRepo.GetStaleMarkets(ctx, cutoff)
+		if err != nil {
+			log.Error("Failed to load stale markets: %v", err)
+			result.Failed++
+			return result
+		}
+
+		progressed := 0
+		for _, price := range markets {
+			result.Examined++
+
+			planet, err := m.getPlanet(ctx, planets, price.PlanetID)
+			if err != nil {
+				result.Failed++
+				return result
+			}
+
+			expected := price.LastUpdate
+			commodity := models.GetCommodityByID(price.CommodityID)
+			unknown := commodity == nil || planet == nil
+			if unknown {
+				price.LastUpdate = now.Unix()
+			} else if !advanceMarket(m.pricing, price, commodity, planet, now) {
+				continue
+			}
+
+			ok, err := m.marketRepo.UpdateMarketPriceIfUnchanged(ctx, price, expected)
+			switch {
+			case err != nil:
+				result.Failed++
+			case !ok:
+				// A player trade updated the market; it is no longer stale
+				result.Skipped++
+				progressed++
+			case unknown:
+				// Marked up to date without simulating
+				result.Skipped++
+				progressed++
+			default:
+				result.Updated++
+				progressed++
+			}
+		}
+
+		// Stop once the backlog is drained or nothing more can be advanced
+		if len(markets) < staleBatchSize || progressed == 0 {
+			break
+		}
+	}
+
+	return result
+}
+
+// getPlanet loads a planet once per tick. A planet that no longer exists is
+// returned as nil; other errors end the tick.
+func (m *Manager) getPlanet(ctx context.Context, cache map[uuid.UUID]*models.Planet, planetID uuid.UUID) (*models.Planet, error) {
+	if planet, ok := cache[planetID]; ok {
+		return planet, nil
+	}
+
+	planet, err := m.systemRepo.GetPlanetByID(ctx, planetID)
+	if errors.Is(err, database.ErrPlanetNotFound) {
+		log.Warn("Market for missing planet will not be simulated: planet_id=%s", planetID)
+		planet = nil
+	} else if err != nil {
+		log.Error("Failed to load planet for market simulation: planet_id=%s, error=%v", planetID, err)
+		return nil, err
+	}
+	cache[planetID] = planet
+	return planet, nil
+}
+
+// advanceMarket simulates the whole hours elapsed since price.LastUpdate.
+//
+// LastUpdate is advanced by exactly the simulated hours so the fractional
+// remainder carries over to the next tick. When the gap exceeds
+// maxCatchUpHours the excess is discarded and LastUpdate is set to now.
+// Returns false if less than an hour has elapsed.
+func advanceMarket(engine *trading.PricingEngine, price *models.MarketPrice, commodity *models.Commodity, planet *models.Planet, now time.Time) bool {
+	last := price.LastUpdate
+	hours := (now.Unix() - last) / secondsPerHour
+	if hours < 1 {
+		return false
+	}
+
+	capped := hours > maxCatchUpHours
+	if capped {
+		hours = maxCatchUpHours
+	}
+
+	engine.SimulateMarketTick(price, commodity, planet, int(hours))
+
+	if capped {
+		price.LastUpdate = now.Unix()
+	} else {
+		price.LastUpdate = last + hours*secondsPerHour
+	}
+	return true
+}
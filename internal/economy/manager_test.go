@@ -0,0 +1,100 @@
+// File: internal/economy/manager_test.go
+// Project: Terminal Velocity
+// Description: Tests for elapsed-time handling in the economy simulation
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package economy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/game/trading"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+func testMarket(t *testing.T, lastUpdate int64) (*models.MarketPrice, *models.Commodity, *models.Planet) {
+	t.Helper()
+
+	commodity := models.GetCommodityByID("food")
+	if commodity == nil {
+		t.Fatal("Standard commodity 'food' not found")
+	}
+
+	planet := &models.Planet{
+		ID:         uuid.New(),
+		Name:       "Test Prime",
+		TechLevel:  5,
+		Population: 50000000,
+	}
+
+	price := &models.MarketPrice{
+		PlanetID:    planet.ID,
+		CommodityID: commodity.ID,
+		BuyPrice:    commodity.BasePrice,
+		SellPrice:   commodity.BasePrice,
+		Stock:       0,
+		Demand:      10,
+		LastUpdate:  lastUpdate,
+	}
+
+	return price, commodity, planet
+}
+
+// TestAdvanceMarketKeepsRemainder verifies partial hours carry over to the next tick
+func TestAdvanceMarketKeepsRemainder(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	last := now.Unix() - (3*secondsPerHour + 1200) // 3h20m ago
+	price, commodity, planet := testMarket(t, last)
+
+	if !advanceMarket(trading.NewPricingEngine(), price, commodity, planet, now) {
+		t.Fatal("Expected market to advance")
+	}
+
+	if want := last + 3*secondsPerHour; price.LastUpdate != want {
+		t.Errorf("Expected LastUpdate %d, got %d", want, price.LastUpdate)
+	}
+	if remainder := now.Unix() - price.LastUpdate; remainder != 1200 {
+		t.Errorf("Expected 1200s remainder, got %d", remainder)
+	}
+	if price.Stock == 0 {
+		t.Error("Expected depleted stock to recover")
+	}
+}
+
+// TestAdvanceMarketUnderAnHour verifies fresh markets are left untouched
+func TestAdvanceMarketUnderAnHour(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	last := now.Unix() - 59*60
+	price, commodity, planet := testMarket(t, last)
+
+	if advanceMarket(trading.NewPricingEngine(), price, commodity, planet, now) {
+		t.Error("Market updated less than an hour ago should not advance")
+	}
+	if price.LastUpdate != last || price.Stock != 0 {
+		t.Error("Market should be unchanged")
+	}
+}
+
+// TestAdvanceMarketCapsDowntime verifies long outages are capped and reset to now
+func TestAdvanceMarketCapsDowntime(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	price, commodity, planet := testMarket(t, now.Unix()-30*24*secondsPerHour)
+
+	if !advanceMarket(trading.NewPricingEngine(), price, commodity, planet, now) {
+		t.Fatal("Expected market to advance")
+	}
+	if price.LastUpdate != now.Unix() {
+		t.Errorf("Expected LastUpdate to be reset to now after cap, got %d", price.LastUpdate)
+	}
+}
+
+// TestNewManagerDefaultInterval verifies the fallback tick interval
+func TestNewManagerDefaultInterval(t *testing.T) {
+	if m := NewManager(nil, nil, 0); m.interval != DefaultTickInterval {
+		t.Errorf("Expected default interval %v, got %v", DefaultTickInterval, m.interval)
+	}
+}
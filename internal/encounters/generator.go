@@ -1,7 +1,7 @@
 // File: internal/encounters/generator.go
 // Project: Terminal Velocity
 // Description: Random encounter system
-// Version: 1.0.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -168,6 +168,7 @@ func (g *Generator) GenerateEncounterShips(encounter *models.Encounter) []*model
 			Hull:    shipType.MaxHull,
 			Shields: shipType.MaxShields,
 			Fuel:    shipType.MaxFuel,
+			Crew:    shipType.MaxCrew,
 			Cargo:   []models.CargoItem{},
 			Weapons: []string{},
 			Outfits: []string{},
@@ -219,12 +220,19 @@ func (g *Generator) generateShipName(encounterType models.EncounterType, index i
 
 // generateShipWeapons equips a ship with appropriate weapons
 //
+// Ship types that define an NPC loadout in the content pack get exactly
+// that loadout; others get weapons picked by ship class.
+//
 // Parameters:
 //   - shipType: Type of ship to equip
 //
 // Returns:
 //   - Slice of weapon IDs
 func (g *Generator) generateShipWeapons(shipType *models.ShipType) []string {
+	if len(shipType.NPCWeapons) > 0 {
+		return append([]string(nil), shipType.NPCWeapons...)
+	}
+
 	weapons := []string{}
 
 	// Determine weapon count based on ship class
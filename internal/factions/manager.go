@@ -1,38 +1,64 @@
 // File: internal/factions/manager.go
 // Project: Terminal Velocity
 // Description: Faction management system for player organizations
-// Version: 1.0.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
 package factions
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"sync"
+	"time"
 
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	"github.com/google/uuid"
 )
 
+var log = logger.WithComponent("Factions")
+
+// dbTimeout bounds each write-through to the faction repository
+const dbTimeout = 5 * time.Second
+
 var (
-	ErrFactionNotFound   = errors.New("faction not found")
-	ErrNotMember         = errors.New("player is not a member")
-	ErrInsufficientRank  = errors.New("insufficient rank for this action")
-	ErrFactionFull       = errors.New("faction has reached member limit")
-	ErrAlreadyMember     = errors.New("player is already a member")
-	ErrInsufficientFunds = errors.New("insufficient faction treasury funds")
-	ErrNameTaken         = errors.New("faction name already taken")
-	ErrTagTaken          = errors.New("faction tag already taken")
+	ErrFactionNotFound     = errors.New("faction not found")
+	ErrNotMember           = errors.New("player is not a member")
+	ErrInsufficientRank    = errors.New("insufficient rank for this action")
+	ErrFactionFull         = errors.New("faction has reached member limit")
+	ErrAlreadyMember       = errors.New("player is already a member")
+	ErrInsufficientFunds   = errors.New("insufficient faction treasury funds")
+	ErrNameTaken           = errors.New("faction name already taken")
+	ErrTagTaken            = errors.New("faction tag already taken")
+	ErrInvalidAmount       = errors.New("amount must be positive")
+	ErrInsufficientCredits = errors.New("insufficient player credits")
 )
 
-// Manager handles faction operations and state
+// Manager handles faction operations and state.
+//
+// Factions are always served from memory. A manager created with
+// NewManagerWithRepository also writes every change through to the
+// FactionRepository before applying it in memory, so a failed write leaves
+// the in-memory state untouched, and Load restores all factions on startup.
+// In that mode Deposit and Withdraw move the player's credits together with
+// the treasury in one database transaction.
 type Manager struct {
 	mu       sync.RWMutex
 	factions map[uuid.UUID]*models.PlayerFaction // All factions by ID
 	names    map[string]uuid.UUID                // Name -> ID mapping
 	tags     map[string]uuid.UUID                // Tag -> ID mapping
 	members  map[uuid.UUID]uuid.UUID             // Player ID -> Faction ID
+
+	// Persistence (nil for an in-memory manager)
+	repo *database.FactionRepository
+
+	// Callbacks for real-time faction change delivery
+	onFactionChanged func(faction *models.PlayerFaction)
+	onCreditsChanged func(playerID uuid.UUID, oldCredits, newCredits int64, reason string)
 }
 
 // NewManager creates a new faction manager
@@ -45,6 +71,87 @@ func NewManager() *Manager {
 	}
 }
 
+// NewManagerWithRepository creates a faction manager backed by the database.
+// Call Load before use to restore existing factions.
+func NewManagerWithRepository(repo *database.FactionRepository) *Manager {
+	m := NewManager()
+	m.repo = repo
+	return m
+}
+
+// Load replaces the in-memory factions with those stored in the repository
+func (m *Manager) Load(ctx context.Context) error {
+	if m.repo == nil {
+		return nil
+	}
+
+	factions, err := m.repo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load factions: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.factions = make(map[uuid.UUID]*models.PlayerFaction, len(factions))
+	m.names = make(map[string]uuid.UUID, len(factions))
+	m.tags = make(map[string]uuid.UUID, len(factions))
+	m.members = make(map[uuid.UUID]uuid.UUID)
+
+	for _, faction := range factions {
+		m.factions[faction.ID] = faction
+		m.names[faction.Name] = faction.ID
+		m.tags[faction.Tag] = faction.ID
+		for _, memberID := range faction.Members {
+			m.members[memberID] = faction.ID
+		}
+	}
+
+	log.Info("Loaded %d factions (%d members)", len(m.factions), len(m.members))
+	return nil
+}
+
+// SetFactionChangedCallback sets the callback invoked whenever a faction's
+// membership, ranks, treasury or settings change. The callback is invoked
+// while the manager lock is held and must not call back into the Manager.
+func (m *Manager) SetFactionChangedCallback(callback func(faction *models.PlayerFaction)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onFactionChanged = callback
+}
+
+// SetCreditsChangedCallback sets the callback invoked after a treasury
+// transfer changes a player's stored credits (database-backed managers only)
+func (m *Manager) SetCreditsChangedCallback(callback func(playerID uuid.UUID, oldCredits, newCredits int64, reason string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onCreditsChanged = callback
+}
+
+// notify reports a faction change. Caller must hold m.mu.
+func (m *Manager) notify(faction *models.PlayerFaction) {
+	if m.onFactionChanged != nil {
+		m.onFactionChanged(faction)
+	}
+}
+
+// creditsChanged reports a saved balance change. Caller must hold m.mu.
+func (m *Manager) creditsChanged(playerID uuid.UUID, delta, newCredits int64, reason string) {
+	if m.onCreditsChanged != nil {
+		go m.onCreditsChanged(playerID, newCredits-delta, newCredits, reason)
+	}
+}
+
+// persist runs a repository write if the manager is database-backed
+func (m *Manager) persist(fn func(ctx context.Context, repo *database.FactionRepository) error) error {
+	if m.repo == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	return fn(ctx, m.repo)
+}
+
 // CreateFaction creates a new faction
 func (m *Manager) CreateFaction(name, tag string, founderID uuid.UUID, alignment string) (*models.PlayerFaction, error) {
 	m.mu.Lock()
@@ -67,10 +174,25 @@ func (m *Manager) CreateFaction(name, tag string, founderID uuid.UUID, alignment
 
 	faction := models.NewPlayerFaction(name, tag, founderID, alignment)
 
+	// The database enforces name/tag uniqueness across server processes
+	err := m.persist(func(ctx context.Context, repo *database.FactionRepository) error {
+		return repo.Create(ctx, faction)
+	})
+	if errors.Is(err, database.ErrFactionNameExists) {
+		return nil, ErrNameTaken
+	}
+	if errors.Is(err, database.ErrFactionTagExists) {
+		return nil, ErrTagTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	m.factions[faction.ID] = faction
 	m.names[name] = faction.ID
 	m.tags[tag] = faction.ID
 	m.members[founderID] = faction.ID
+	m.notify(faction)
 
 	return faction, nil
 }
@@ -142,11 +264,20 @@ func (m *Manager) JoinFaction(factionID, playerID uuid.UUID) error {
 		return ErrFactionNotFound
 	}
 
-	if !faction.AddMember(playerID) {
+	if !faction.CanRecruit() {
 		return ErrFactionFull
 	}
 
+	err := m.persist(func(ctx context.Context, repo *database.FactionRepository) error {
+		return repo.AddMember(ctx, factionID, playerID, models.RankMember)
+	})
+	if err != nil {
+		return err
+	}
+
+	faction.AddMember(playerID)
 	m.members[playerID] = factionID
+	m.notify(faction)
 	return nil
 }
 
@@ -172,8 +303,16 @@ func (m *Manager) LeaveFaction(playerID uuid.UUID) error {
 		return errors.New("leader must transfer leadership before leaving")
 	}
 
+	err := m.persist(func(ctx context.Context, repo *database.FactionRepository) error {
+		return repo.RemoveMember(ctx, factionID, playerID)
+	})
+	if err != nil {
+		return err
+	}
+
 	faction.RemoveMember(playerID)
 	delete(m.members, playerID)
+	m.notify(faction)
 
 	return nil
 }
@@ -203,8 +342,20 @@ func (m *Manager) KickMember(factionID, kickerID, targetID uuid.UUID) error {
 		return ErrInsufficientRank
 	}
 
+	if !faction.IsMember(targetID) {
+		return ErrNotMember
+	}
+
+	err := m.persist(func(ctx context.Context, repo *database.FactionRepository) error {
+		return repo.RemoveMember(ctx, factionID, targetID)
+	})
+	if err != nil {
+		return err
+	}
+
 	faction.RemoveMember(targetID)
 	delete(m.members, targetID)
+	m.notify(faction)
 
 	return nil
 }
@@ -224,10 +375,20 @@ func (m *Manager) PromoteMember(factionID, promoterID, targetID uuid.UUID) error
 		return ErrInsufficientRank
 	}
 
-	if !faction.PromoteToOfficer(targetID) {
+	if !faction.IsMember(targetID) || faction.IsOfficer(targetID) {
 		return errors.New("cannot promote player")
 	}
 
+	err := m.persist(func(ctx context.Context, repo *database.FactionRepository) error {
+		return repo.SetOfficer(ctx, factionID, targetID, true)
+	})
+	if err != nil {
+		return err
+	}
+
+	faction.PromoteToOfficer(targetID)
+
+	m.notify(faction)
 	return nil
 }
 
@@ -246,15 +407,31 @@ func (m *Manager) DemoteMember(factionID, demoterID, targetID uuid.UUID) error {
 		return ErrInsufficientRank
 	}
 
-	if !faction.DemoteFromOfficer(targetID) {
+	if faction.IsLeader(targetID) || !faction.IsOfficer(targetID) {
 		return errors.New("cannot demote player")
 	}
 
+	err := m.persist(func(ctx context.Context, repo *database.FactionRepository) error {
+		return repo.SetOfficer(ctx, factionID, targetID, false)
+	})
+	if err != nil {
+		return err
+	}
+
+	faction.DemoteFromOfficer(targetID)
+
+	m.notify(faction)
 	return nil
 }
 
-// Deposit adds credits to faction treasury
+// Deposit adds credits to faction treasury.
+// When database-backed, the amount is taken from the player's credits in the
+// same transaction and ErrInsufficientCredits is returned if they cannot pay.
 func (m *Manager) Deposit(factionID, playerID uuid.UUID, amount int64) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -268,11 +445,34 @@ func (m *Manager) Deposit(factionID, playerID uuid.UUID, amount int64) error {
 		return ErrNotMember
 	}
 
-	faction.Deposit(amount)
+	if m.repo == nil {
+		faction.Deposit(amount)
+		m.notify(faction)
+		return nil
+	}
+
+	var credits, treasury int64
+	err := m.persist(func(ctx context.Context, repo *database.FactionRepository) error {
+		var err error
+		credits, treasury, err = repo.Deposit(ctx, factionID, playerID, amount)
+		return err
+	})
+	if errors.Is(err, database.ErrInsufficientCredits) {
+		return ErrInsufficientCredits
+	}
+	if err != nil {
+		return err
+	}
+
+	faction.Treasury = treasury
+	m.creditsChanged(playerID, -amount, credits, "faction deposit")
+	m.notify(faction)
 	return nil
 }
 
-// Withdraw removes credits from faction treasury
+// Withdraw removes credits from faction treasury.
+// When database-backed, the amount is paid into the player's credits in the
+// same transaction.
 func (m *Manager) Withdraw(factionID, playerID uuid.UUID, amount int64) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -287,10 +487,112 @@ func (m *Manager) Withdraw(factionID, playerID uuid.UUID, amount int64) error {
 		return ErrInsufficientRank
 	}
 
-	if !faction.Withdraw(amount) {
+	if m.repo == nil {
+		if !faction.Withdraw(amount) {
+			return ErrInsufficientFunds
+		}
+		m.notify(faction)
+		return nil
+	}
+
+	if amount <= 0 {
+		return ErrInsufficientFunds
+	}
+
+	var credits, treasury int64
+	err := m.persist(func(ctx context.Context, repo *database.FactionRepository) error {
+		var err error
+		credits, treasury, err = repo.Withdraw(ctx, factionID, playerID, amount)
+		return err
+	})
+	if errors.Is(err, database.ErrInsufficientTreasury) {
 		return ErrInsufficientFunds
 	}
+	if err != nil {
+		return err
+	}
 
+	faction.Treasury = treasury
+	m.creditsChanged(playerID, amount, credits, "faction withdrawal")
+	m.notify(faction)
+	return nil
+}
+
+// AddFunds credits a faction treasury from a source other than a player,
+// such as an alliance payout. No player's credits are touched.
+func (m *Manager) AddFunds(factionID uuid.UUID, amount int64) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	faction, exists := m.factions[factionID]
+	if !exists {
+		return ErrFactionNotFound
+	}
+
+	if m.repo == nil {
+		faction.Deposit(amount)
+		m.notify(faction)
+		return nil
+	}
+
+	var treasury int64
+	err := m.persist(func(ctx context.Context, repo *database.FactionRepository) error {
+		var err error
+		treasury, err = repo.AddToTreasury(ctx, factionID, amount)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	faction.Treasury = treasury
+	m.notify(faction)
+	return nil
+}
+
+// SpendFunds debits a faction treasury for an expense not paid to a player,
+// such as territory upkeep. Returns ErrInsufficientFunds if the treasury
+// cannot cover the amount.
+func (m *Manager) SpendFunds(factionID uuid.UUID, amount int64) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	faction, exists := m.factions[factionID]
+	if !exists {
+		return ErrFactionNotFound
+	}
+
+	if m.repo == nil {
+		if !faction.Withdraw(amount) {
+			return ErrInsufficientFunds
+		}
+		m.notify(faction)
+		return nil
+	}
+
+	var treasury int64
+	err := m.persist(func(ctx context.Context, repo *database.FactionRepository) error {
+		var err error
+		treasury, err = repo.SpendFromTreasury(ctx, factionID, amount)
+		return err
+	})
+	if errors.Is(err, database.ErrInsufficientTreasury) {
+		return ErrInsufficientFunds
+	}
+	if err != nil {
+		return err
+	}
+
+	faction.Treasury = treasury
+	m.notify(faction)
 	return nil
 }
 
@@ -337,7 +639,17 @@ func (m *Manager) UpdateSettings(factionID, playerID uuid.UUID, settings models.
 		return ErrInsufficientRank
 	}
 
+	previous := faction.Settings
 	faction.Settings = settings
+	err := m.persist(func(ctx context.Context, repo *database.FactionRepository) error {
+		return repo.Update(ctx, faction)
+	})
+	if err != nil {
+		faction.Settings = previous
+		return err
+	}
+
+	m.notify(faction)
 	return nil
 }
 
@@ -1,7 +1,7 @@
 // File: internal/fleet/manager.go
 // Project: Terminal Velocity
 // Description: Fleet management system for multi-ship ownership and escorts
-// Version: 1.0.0
+// Version: 1.1.0
 // Author: Claude Code
 // Created: 2025-11-15
 
@@ -157,7 +157,7 @@ type Escort struct {
 	Level       int       // Skill level 1-10
 	Behavior    EscortBehavior
 	CurrentTarget uuid.UUID
-	Status      string    // "active", "defending", "attacking", "idle"
+	Status      string    // "active", "defending", "attacking", "idle", "destroyed", "deserted"
 }
 
 // EscortBehavior defines how an escort acts
@@ -403,7 +403,7 @@ func (m *Manager) HireEscort(ctx context.Context, playerID uuid.UUID, ship *mode
 		HiredAt:  time.Now(),
 		Level:    rand.Intn(5) + 1, // Random level 1-5
 		Behavior: behavior,
-		Status:   "idle",
+		Status:   "active",
 	}
 
 	fleet.Escorts = append(fleet.Escorts, escort)
@@ -520,6 +520,145 @@ func (m *Manager) CommandEscorts(playerID uuid.UUID, command string) error {
 // ESCORT AI
 // ============================================================================
 
+// CombatOrder returns how the escort fights in battle: "attack" engages every
+// hostile, "defend" engages only ships that open fire, and "hold" holds fire.
+// The last fleet command overrides the escort's behavior; passive escorts
+// never engage.
+func (e *Escort) CombatOrder() string {
+	if e.Behavior == BehaviorPassive {
+		return "hold"
+	}
+
+	switch e.Status {
+	case "attacking":
+		return "attack"
+	case "defending":
+		return "defend"
+	case "idle":
+		return "hold"
+	}
+
+	if e.Behavior == BehaviorAggressive {
+		return "attack"
+	}
+	return "defend"
+}
+
+// ActiveEscorts returns copies of the escorts that fly with the player into
+// battle. Each copy has its own copy of the escort's ship, so a battle can
+// damage it freely; record the result with UpdateEscortCondition.
+func (m *Manager) ActiveEscorts(playerID uuid.UUID) []*Escort {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	fleet, exists := m.fleets[playerID]
+	if !exists {
+		return nil
+	}
+
+	var escorts []*Escort
+	for _, escort := range fleet.Escorts {
+		if escort.Ship == nil || escort.Ship.Hull <= 0 {
+			continue
+		}
+		copied := *escort
+		ship := *escort.Ship
+		ship.Weapons = append([]string(nil), escort.Ship.Weapons...)
+		copied.Ship = &ship
+		escorts = append(escorts, &copied)
+	}
+	return escorts
+}
+
+// UpdateEscortCondition records the hull and shields an escort's ship was
+// left with after a battle and saves them to the ship
+func (m *Manager) UpdateEscortCondition(ctx context.Context, playerID, escortID uuid.UUID, hull, shields int) error {
+	m.mu.Lock()
+	escort := m.findEscort(playerID, escortID)
+	if escort == nil || escort.Ship == nil {
+		m.mu.Unlock()
+		return fmt.Errorf("escort not found")
+	}
+	escort.Ship.Hull = hull
+	escort.Ship.Shields = shields
+	shipID := escort.Ship.ID
+	m.mu.Unlock()
+
+	if m.shipRepo == nil {
+		return nil
+	}
+	if err := m.shipRepo.UpdateHullAndShields(ctx, shipID, hull, shields); err != nil {
+		return fmt.Errorf("failed to save escort ship: %w", err)
+	}
+	return nil
+}
+
+// EscortDestroyed removes an escort whose ship was destroyed in battle from
+// the fleet, along with its ship, saves the wrecked ship and reports the loss
+// to the escort destroyed callback
+func (m *Manager) EscortDestroyed(ctx context.Context, playerID, escortID uuid.UUID) error {
+	m.mu.Lock()
+	fleet, exists := m.fleets[playerID]
+	if !exists {
+		m.mu.Unlock()
+		return fmt.Errorf("fleet not found")
+	}
+
+	escortIndex := -1
+	for i, escort := range fleet.Escorts {
+		if escort.ID == escortID {
+			escortIndex = i
+			break
+		}
+	}
+	if escortIndex == -1 {
+		m.mu.Unlock()
+		return fmt.Errorf("escort not found")
+	}
+
+	escort := fleet.Escorts[escortIndex]
+	fleet.Escorts = append(fleet.Escorts[:escortIndex], fleet.Escorts[escortIndex+1:]...)
+	escort.Status = "destroyed"
+	if escort.Ship != nil {
+		escort.Ship.Hull, escort.Ship.Shields = 0, 0
+		for i, ship := range fleet.OwnedShips {
+			if ship.ID == escort.Ship.ID {
+				fleet.OwnedShips = append(fleet.OwnedShips[:i], fleet.OwnedShips[i+1:]...)
+				break
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	log.Info("Escort destroyed: player=%s, pilot=%s", playerID, escort.Pilot)
+
+	if m.onEscortDestroyed != nil {
+		go m.onEscortDestroyed(playerID, escort)
+	}
+
+	if m.shipRepo == nil || escort.Ship == nil {
+		return nil
+	}
+	if err := m.shipRepo.UpdateHullAndShields(ctx, escort.Ship.ID, 0, 0); err != nil {
+		return fmt.Errorf("failed to save escort ship: %w", err)
+	}
+	return nil
+}
+
+// findEscort returns a player's escort, or nil. Caller must hold m.mu.
+func (m *Manager) findEscort(playerID, escortID uuid.UUID) *Escort {
+	fleet, exists := m.fleets[playerID]
+	if !exists {
+		return nil
+	}
+	for _, escort := range fleet.Escorts {
+		if escort.ID == escortID {
+			return escort
+		}
+	}
+	return nil
+}
+
 // UpdateEscortAI updates escort AI behavior (called during combat)
 func (m *Manager) UpdateEscortAI(ctx context.Context, playerID uuid.UUID, availableTargets []uuid.UUID) {
 	m.mu.RLock()
@@ -649,6 +788,7 @@ func (m *Manager) processMaintenance() {
 			if escort.Loyalty < m.config.MinLoyaltyThreshold {
 				// Escort deserts
 				fleet.Escorts = append(fleet.Escorts[:i], fleet.Escorts[i+1:]...)
+				escort.Status = "deserted"
 				log.Info("Escort deserted: player=%s, pilot=%s (low loyalty)", playerID, escort.Pilot)
 
 				if m.onEscortDestroyed != nil {
@@ -0,0 +1,114 @@
+// File: internal/fleet/manager_test.go
+// Project: Terminal Velocity
+// Description: Tests for escorts in battle - combat orders, damage and losses
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package fleet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// addEscort gives the player's fleet an escort flying one of their ships
+func addEscort(m *Manager, playerID uuid.UUID, pilot string, behavior EscortBehavior) *Escort {
+	fleet := m.GetOrCreateFleet(playerID)
+	ship := &models.Ship{ID: uuid.New(), OwnerID: playerID, TypeID: "viper", Hull: 100, Shields: 50, Weapons: []string{"pulse_laser"}}
+	escort := &Escort{ID: uuid.New(), Ship: ship, OwnerID: playerID, Pilot: pilot, Level: 3, Behavior: behavior, Status: "active"}
+
+	m.mu.Lock()
+	fleet.OwnedShips = append(fleet.OwnedShips, ship)
+	fleet.Escorts = append(fleet.Escorts, escort)
+	m.mu.Unlock()
+	return escort
+}
+
+func TestEscortCombatOrders(t *testing.T) {
+	m := NewManager(nil, nil)
+	playerID := uuid.New()
+	aggressive := addEscort(m, playerID, "Vance", BehaviorAggressive)
+	defensive := addEscort(m, playerID, "Kira", BehaviorDefensive)
+	passive := addEscort(m, playerID, "Milo", BehaviorPassive)
+
+	orders := func() map[uuid.UUID]string {
+		result := make(map[uuid.UUID]string)
+		for _, escort := range m.ActiveEscorts(playerID) {
+			result[escort.ID] = escort.CombatOrder()
+		}
+		return result
+	}
+
+	got := orders()
+	if got[aggressive.ID] != "attack" || got[defensive.ID] != "defend" || got[passive.ID] != "hold" {
+		t.Fatalf("unexpected orders from behavior: %v", got)
+	}
+
+	// Fleet commands override behavior, except for passive escorts
+	for command, want := range map[string]string{"attack": "attack", "defend": "defend", "hold": "hold"} {
+		if err := m.CommandEscorts(playerID, command); err != nil {
+			t.Fatalf("CommandEscorts(%s) failed: %v", command, err)
+		}
+		got := orders()
+		if got[defensive.ID] != want || got[passive.ID] != "hold" {
+			t.Errorf("after %s: got %v", command, got)
+		}
+	}
+}
+
+func TestEscortBattleDamageAndLoss(t *testing.T) {
+	m := NewManager(nil, nil)
+	playerID := uuid.New()
+	survivor := addEscort(m, playerID, "Vance", BehaviorAggressive)
+	casualty := addEscort(m, playerID, "Kira", BehaviorDefensive)
+
+	lost := make(chan *Escort, 1)
+	m.SetEscortDestroyedCallback(func(owner uuid.UUID, escort *Escort) {
+		if owner == playerID {
+			lost <- escort
+		}
+	})
+
+	// A battle damages copies, not the fleet's ships
+	escorts := m.ActiveEscorts(playerID)
+	if len(escorts) != 2 {
+		t.Fatalf("expected 2 active escorts, got %d", len(escorts))
+	}
+	escorts[0].Ship.Hull = 1
+	if survivor.Ship.Hull != 100 {
+		t.Fatal("ActiveEscorts should return copies of the escorts' ships")
+	}
+
+	ctx := context.Background()
+	if err := m.UpdateEscortCondition(ctx, playerID, survivor.ID, 40, 10); err != nil {
+		t.Fatalf("UpdateEscortCondition failed: %v", err)
+	}
+	if survivor.Ship.Hull != 40 || survivor.Ship.Shields != 10 {
+		t.Errorf("expected damage to persist to the escort's ship, got %d/%d", survivor.Ship.Hull, survivor.Ship.Shields)
+	}
+
+	if err := m.EscortDestroyed(ctx, playerID, casualty.ID); err != nil {
+		t.Fatalf("EscortDestroyed failed: %v", err)
+	}
+	select {
+	case escort := <-lost:
+		if escort.ID != casualty.ID || escort.Status != "destroyed" {
+			t.Errorf("unexpected lost escort: %+v", escort)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("escort destroyed callback was not called")
+	}
+
+	fleet, _ := m.GetFleet(playerID)
+	if len(fleet.Escorts) != 1 || len(fleet.OwnedShips) != 1 || fleet.OwnedShips[0].ID != survivor.Ship.ID {
+		t.Errorf("expected the lost escort and its ship to leave the fleet, got %d escorts, %d ships", len(fleet.Escorts), len(fleet.OwnedShips))
+	}
+	if err := m.EscortDestroyed(ctx, playerID, casualty.ID); err == nil {
+		t.Error("expected an error for an escort already lost")
+	}
+}
@@ -1,7 +1,7 @@
 // File: internal/game/trading/pricing.go
 // Project: Terminal Velocity
 // Description: Trading and pricing system
-// Version: 1.0.0
+// Version: 1.1.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -72,6 +72,56 @@ func NewPricingEngine() *PricingEngine {
 	}
 }
 
+// NewSeededPricingEngine creates a pricing engine with a fixed random seed.
+//
+// Engines with the same seed given the same sequence of calls produce the same
+// prices, which universe export relies on for reproducible initial markets.
+func NewSeededPricingEngine(seed int64) *PricingEngine {
+	return &PricingEngine{
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// GenerateInitialMarket creates opening prices for every commodity a planet trades.
+//
+// Commodities above the planet's tech level or illegal under the controlling
+// government are left out. LastUpdate is not set; callers stamp it when the
+// market is stored.
+func (e *PricingEngine) GenerateInitialMarket(planet *models.Planet, governmentID string) []models.MarketPrice {
+	var market []models.MarketPrice
+	commodities := models.AllCommodities()
+	for i := range commodities {
+		commodity := &commodities[i]
+		if commodity.TechLevel > planet.TechLevel || isIllegalIn(commodity, governmentID) {
+			continue
+		}
+
+		stock := e.GenerateInitialStock(commodity, planet)
+		demand := e.GenerateInitialDemand(commodity, planet)
+		buyPrice, sellPrice := e.CalculateMarketPrice(commodity, planet, stock, demand)
+
+		market = append(market, models.MarketPrice{
+			PlanetID:    planet.ID,
+			CommodityID: commodity.ID,
+			BuyPrice:    buyPrice,
+			SellPrice:   sellPrice,
+			Stock:       stock,
+			Demand:      demand,
+		})
+	}
+	return market
+}
+
+// isIllegalIn reports whether a commodity is contraband under a government
+func isIllegalIn(commodity *models.Commodity, governmentID string) bool {
+	for _, id := range commodity.IllegalIn {
+		if id == governmentID {
+			return true
+		}
+	}
+	return false
+}
+
 // CalculateMarketPrice calculates buy and sell prices for a commodity at a planet's market.
 //
 // This is the core pricing function that combines all economic factors:
@@ -244,7 +294,10 @@ func (e *PricingEngine) GenerateInitialDemand(commodity *models.Commodity, plane
 
 	// Add randomness
 	variance := int(float64(baseDemand) * 0.4) // ±40%
-	demand := baseDemand + e.rand.Intn(variance*2) - variance
+	demand := baseDemand
+	if variance > 0 {
+		demand += e.rand.Intn(variance*2) - variance
+	}
 
 	if demand < 10 {
 		demand = 10 // Minimum demand
@@ -0,0 +1,438 @@
+// File: internal/game/trading/service.go
+// Project: Terminal Velocity
+// Description: Atomic commodity buy/sell transactions
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package trading
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/metrics"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+var log = logger.WithComponent("Trading")
+
+// Trade errors returned by Service methods. Callers can match them with
+// errors.Is to show a friendly message; the returned error may wrap them
+// with additional detail (e.g. "insufficient credits: need 500, have 200").
+var (
+	ErrInvalidQuantity        = errors.New("invalid quantity")
+	ErrUnknownCommodity       = errors.New("unknown commodity")
+	ErrPlayerNotFound         = errors.New("player not found")
+	ErrNoShip                 = errors.New("no active ship")
+	ErrNotDocked              = errors.New("not docked at a planet")
+	ErrCommodityNotTraded     = errors.New("commodity not traded at this planet")
+	ErrInsufficientCredits    = errors.New("insufficient credits")
+	ErrInsufficientStock      = errors.New("insufficient stock")
+	ErrInsufficientCargoSpace = errors.New("insufficient cargo space")
+	ErrInsufficientCargo      = errors.New("insufficient cargo")
+	ErrInsufficientDemand     = errors.New("insufficient demand")
+)
+
+// Service executes commodity trades atomically.
+//
+// Every trade runs in a single database transaction that locks, in order, the
+// player row, the ship row and the market row (SELECT ... FOR UPDATE). All
+// checks are made against the locked rows, never against session state, so
+// two sessions (or a double keypress) cannot spend the same credits twice or
+// buy stock that has already been sold. The consistent lock order prevents
+// deadlocks between concurrent buys and sells.
+//
+// Price Semantics (matching PricingEngine):
+//   - Player buys at the market's SellPrice
+//   - Player sells at the market's BuyPrice
+//
+// Market Fees:
+// With a fee policy set, a trade in a faction's territory can carry a fee as
+// a fraction of its value, added to a purchase and deducted from a sale.
+//
+// Thread Safety: Safe for concurrent use.
+type Service struct {
+	db         *database.DB
+	systemRepo *database.SystemRepository
+
+	// pricingMu guards pricing (PricingEngine is not thread-safe)
+	pricingMu sync.Mutex
+	pricing   *PricingEngine
+
+	feeMu     sync.RWMutex
+	feePolicy func(playerID, systemID uuid.UUID) float64 // Fee rate for a player in a system (optional)
+}
+
+// TradeResult describes a completed trade
+type TradeResult struct {
+	CommodityID   string
+	Quantity      int
+	PricePerUnit  int64
+	Fee           int64               // Market fee included in Total
+	Total         int64               // Credits paid (buy) or received (sell)
+	NewCredits    int64               // Player credits after the trade
+	CargoQuantity int                 // Units of the commodity now in the hold
+	Market        *models.MarketPrice // Market state after the trade
+}
+
+// NewService creates a trading service
+func NewService(db *database.DB, systemRepo *database.SystemRepository) *Service {
+	return &Service{
+		db:         db,
+		systemRepo: systemRepo,
+		pricing:    NewPricingEngine(),
+	}
+}
+
+// SetMarketFeePolicy sets how the market fee rate is chosen for a player
+// trading in a system, as a fraction of the trade's value
+func (s *Service) SetMarketFeePolicy(policy func(playerID, systemID uuid.UUID) float64) {
+	s.feeMu.Lock()
+	defer s.feeMu.Unlock()
+	s.feePolicy = policy
+}
+
+// feeRate returns the market fee rate for a player trading in a system
+func (s *Service) feeRate(playerID, systemID uuid.UUID) float64 {
+	s.feeMu.RLock()
+	policy := s.feePolicy
+	s.feeMu.RUnlock()
+
+	if policy == nil {
+		return 0
+	}
+	if rate := policy(playerID, systemID); rate > 0 {
+		return rate
+	}
+	return 0
+}
+
+// marketFee returns the fee on a trade's value at the given rate
+func marketFee(value int64, rate float64) int64 {
+	return int64(float64(value) * rate)
+}
+
+// Buy purchases quantity units of a commodity at the player's current planet
+func (s *Service) Buy(ctx context.Context, playerID uuid.UUID, commodityID string, quantity int) (*TradeResult, error) {
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+	return s.buy(ctx, playerID, commodityID, quantity)
+}
+
+// BuyMax purchases as many units as the player can afford, carry and the
+// market has in stock
+func (s *Service) BuyMax(ctx context.Context, playerID uuid.UUID, commodityID string) (*TradeResult, error) {
+	return s.buy(ctx, playerID, commodityID, 0)
+}
+
+// Sell sells quantity units of a commodity at the player's current planet
+func (s *Service) Sell(ctx context.Context, playerID uuid.UUID, commodityID string, quantity int) (*TradeResult, error) {
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+	return s.sell(ctx, playerID, commodityID, quantity)
+}
+
+// SellAll sells every unit of a commodity in the player's hold, or as many
+// as the market will take
+func (s *Service) SellAll(ctx context.Context, playerID uuid.UUID, commodityID string) (*TradeResult, error) {
+	return s.sell(ctx, playerID, commodityID, 0)
+}
+
+// buy runs a purchase; quantity 0 means "as many as possible"
+func (s *Service) buy(ctx context.Context, playerID uuid.UUID, commodityID string, quantity int) (*TradeResult, error) {
+	commodity := models.GetCommodityByID(commodityID)
+	if commodity == nil {
+		return nil, ErrUnknownCommodity
+	}
+
+	var result *TradeResult
+	err := s.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		state, err := s.lockTradeState(ctx, tx, playerID, commodityID)
+		if err != nil {
+			return err
+		}
+
+		cargoFree := state.cargoCapacity - state.cargoUsed
+		price := state.market.SellPrice
+		rate := s.feeRate(playerID, state.planet.SystemID)
+
+		if quantity == 0 {
+			quantity = cargoFree
+			if price > 0 {
+				if affordable := int64(float64(state.credits) / (float64(price) * (1 + rate))); affordable < int64(quantity) {
+					quantity = int(affordable)
+				}
+			}
+			if state.market.Stock < quantity {
+				quantity = state.market.Stock
+			}
+			if quantity <= 0 {
+				switch {
+				case cargoFree <= 0:
+					return ErrInsufficientCargoSpace
+				case state.market.Stock <= 0:
+					return ErrInsufficientStock
+				default:
+					return ErrInsufficientCredits
+				}
+			}
+		}
+
+		fee := marketFee(price*int64(quantity), rate)
+		total := price*int64(quantity) + fee
+		if state.market.Stock < quantity {
+			return fmt.Errorf("%w: available %d", ErrInsufficientStock, state.market.Stock)
+		}
+		if total > state.credits {
+			return fmt.Errorf("%w: need %d, have %d", ErrInsufficientCredits, total, state.credits)
+		}
+		if quantity > cargoFree {
+			return fmt.Errorf("%w: need %d, have %d", ErrInsufficientCargoSpace, quantity, cargoFree)
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE players SET credits = credits - $1 WHERE id = $2`,
+			total, playerID); err != nil {
+			return fmt.Errorf("failed to update credits: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO ship_cargo (ship_id, commodity_id, quantity)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (ship_id, commodity_id)
+			DO UPDATE SET quantity = ship_cargo.quantity + $3`,
+			state.shipID, commodityID, quantity); err != nil {
+			return fmt.Errorf("failed to add cargo: %w", err)
+		}
+
+		// Purchases drain stock and raise demand
+		state.market.Stock -= quantity
+		state.market.Demand += quantity / 2
+		if err := s.updateMarket(ctx, tx, state, commodity); err != nil {
+			return err
+		}
+
+		result = &TradeResult{
+			CommodityID:   commodityID,
+			Quantity:      quantity,
+			PricePerUnit:  price,
+			Fee:           fee,
+			Total:         total,
+			NewCredits:    state.credits - total,
+			CargoQuantity: state.cargoHeld + quantity,
+			Market:        state.market,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.Global().IncrementTrades()
+	metrics.Global().RecordMarketTransaction(result.Total)
+	log.Debug("Buy: player_id=%s, commodity=%s, quantity=%d, total=%d, fee=%d", playerID, commodityID, result.Quantity, result.Total, result.Fee)
+	return result, nil
+}
+
+// sell runs a sale; quantity 0 means "everything in the hold", up to the
+// market's demand
+func (s *Service) sell(ctx context.Context, playerID uuid.UUID, commodityID string, quantity int) (*TradeResult, error) {
+	commodity := models.GetCommodityByID(commodityID)
+	if commodity == nil {
+		return nil, ErrUnknownCommodity
+	}
+
+	var result *TradeResult
+	err := s.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		state, err := s.lockTradeState(ctx, tx, playerID, commodityID)
+		if err != nil {
+			return err
+		}
+
+		if quantity == 0 {
+			quantity = state.cargoHeld
+			if state.market.Demand < quantity && state.cargoHeld > 0 {
+				if state.market.Demand <= 0 {
+					return ErrInsufficientDemand
+				}
+				quantity = state.market.Demand
+			}
+		}
+		if quantity <= 0 || quantity > state.cargoHeld {
+			return fmt.Errorf("%w: have %d", ErrInsufficientCargo, state.cargoHeld)
+		}
+
+		// The market only takes as much as it demands
+		if quantity > state.market.Demand {
+			return fmt.Errorf("%w: available %d", ErrInsufficientDemand, state.market.Demand)
+		}
+
+		price := state.market.BuyPrice
+		fee := marketFee(price*int64(quantity), s.feeRate(playerID, state.planet.SystemID))
+		total := price*int64(quantity) - fee
+
+		if quantity == state.cargoHeld {
+			_, err = tx.ExecContext(ctx,
+				`DELETE FROM ship_cargo WHERE ship_id = $1 AND commodity_id = $2`,
+				state.shipID, commodityID)
+		} else {
+			_, err = tx.ExecContext(ctx,
+				`UPDATE ship_cargo SET quantity = quantity - $3 WHERE ship_id = $1 AND commodity_id = $2`,
+				state.shipID, commodityID, quantity)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to remove cargo: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE players SET credits = credits + $1 WHERE id = $2`,
+			total, playerID); err != nil {
+			return fmt.Errorf("failed to update credits: %w", err)
+		}
+
+		// Sales replenish stock and soften demand
+		state.market.Stock += quantity
+		state.market.Demand -= quantity / 3
+		if state.market.Demand < 10 {
+			state.market.Demand = 10
+		}
+		if err := s.updateMarket(ctx, tx, state, commodity); err != nil {
+			return err
+		}
+
+		result = &TradeResult{
+			CommodityID:   commodityID,
+			Quantity:      quantity,
+			PricePerUnit:  price,
+			Fee:           fee,
+			Total:         total,
+			NewCredits:    state.credits + total,
+			CargoQuantity: state.cargoHeld - quantity,
+			Market:        state.market,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.Global().IncrementTrades()
+	metrics.Global().RecordMarketTransaction(result.Total)
+	log.Debug("Sell: player_id=%s, commodity=%s, quantity=%d, total=%d, fee=%d", playerID, commodityID, result.Quantity, result.Total, result.Fee)
+	return result, nil
+}
+
+// tradeState is the locked view of everything a trade touches
+type tradeState struct {
+	credits       int64
+	planet        *models.Planet
+	shipID        uuid.UUID
+	cargoCapacity int
+	cargoUsed     int
+	cargoHeld     int // Units of the traded commodity in the hold
+	market        *models.MarketPrice
+}
+
+// lockTradeState locks the player, ship and market rows and loads trade state.
+// Lock order: players -> ships -> market_prices.
+func (s *Service) lockTradeState(ctx context.Context, tx *sql.Tx, playerID uuid.UUID, commodityID string) (*tradeState, error) {
+	state := &tradeState{}
+
+	var planetID, shipID uuid.NullUUID
+	err := tx.QueryRowContext(ctx,
+		`SELECT credits, current_planet, ship_id FROM players WHERE id = $1 FOR UPDATE`,
+		playerID).Scan(&state.credits, &planetID, &shipID)
+	if err == sql.ErrNoRows {
+		return nil, ErrPlayerNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock player: %w", err)
+	}
+	if !planetID.Valid {
+		return nil, ErrNotDocked
+	}
+	if !shipID.Valid {
+		return nil, ErrNoShip
+	}
+	state.shipID = shipID.UUID
+
+	var typeID string
+	err = tx.QueryRowContext(ctx,
+		`SELECT type_id FROM ships WHERE id = $1 AND owner_id = $2 FOR UPDATE`,
+		state.shipID, playerID).Scan(&typeID)
+	if err == sql.ErrNoRows {
+		return nil, ErrNoShip
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock ship: %w", err)
+	}
+	shipType := models.GetShipTypeByID(typeID)
+	if shipType == nil {
+		return nil, fmt.Errorf("unknown ship type: %s", typeID)
+	}
+	state.cargoCapacity = shipType.CargoSpace
+
+	// The ship lock serializes cargo changes, so these sums are stable
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(quantity), 0),
+		       COALESCE(SUM(quantity) FILTER (WHERE commodity_id = $2), 0)
+		FROM ship_cargo WHERE ship_id = $1`,
+		state.shipID, commodityID).Scan(&state.cargoUsed, &state.cargoHeld)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cargo: %w", err)
+	}
+
+	market := &models.MarketPrice{PlanetID: planetID.UUID, CommodityID: commodityID}
+	err = tx.QueryRowContext(ctx, `
+		SELECT buy_price, sell_price, stock, demand, last_update
+		FROM market_prices
+		WHERE planet_id = $1 AND commodity_id = $2
+		FOR UPDATE`,
+		planetID.UUID, commodityID).Scan(&market.BuyPrice, &market.SellPrice, &market.Stock, &market.Demand, &market.LastUpdate)
+	if err == sql.ErrNoRows {
+		return nil, ErrCommodityNotTraded
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock market: %w", err)
+	}
+	state.market = market
+
+	// Planet data is static, so it doesn't need to be read inside the transaction
+	state.planet, err = s.systemRepo.GetPlanetByID(ctx, planetID.UUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load planet: %w", err)
+	}
+
+	return state, nil
+}
+
+// updateMarket reprices the locked market row and writes it back
+func (s *Service) updateMarket(ctx context.Context, tx *sql.Tx, state *tradeState, commodity *models.Commodity) error {
+	market := state.market
+
+	s.pricingMu.Lock()
+	market.BuyPrice, market.SellPrice = s.pricing.CalculateMarketPrice(commodity, state.planet, market.Stock, market.Demand)
+	s.pricingMu.Unlock()
+	market.LastUpdate = time.Now().Unix()
+
+	_, err := tx.ExecContext(ctx, `
+		UPDATE market_prices
+		SET buy_price = $1, sell_price = $2, stock = $3, demand = $4, last_update = $5
+		WHERE planet_id = $6 AND commodity_id = $7`,
+		market.BuyPrice, market.SellPrice, market.Stock, market.Demand, market.LastUpdate,
+		market.PlanetID, market.CommodityID)
+	if err != nil {
+		return fmt.Errorf("failed to update market: %w", err)
+	}
+	return nil
+}
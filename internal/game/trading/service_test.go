@@ -0,0 +1,240 @@
+// File: internal/game/trading/service_test.go
+// Project: Terminal Velocity
+// Description: Integration tests for atomic commodity trades
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package trading
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+	"github.com/google/uuid"
+)
+
+// These are integration tests that require a running PostgreSQL database.
+// They are skipped when the test database is unavailable.
+func setupTestDB(t *testing.T) *database.DB {
+	t.Helper()
+
+	cfg := &database.Config{
+		Host:            "localhost",
+		Port:            5432,
+		User:            "terminal_velocity",
+		Password:        "terminal_velocity",
+		Database:        "terminal_velocity_test",
+		SSLMode:         "disable",
+		MaxOpenConns:    10,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: time.Hour,
+		ConnMaxIdleTime: 10 * time.Minute,
+	}
+
+	db, err := database.NewDB(cfg)
+	if err != nil {
+		t.Skipf("Skipping database tests: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.Ping(ctx); err != nil {
+		t.Skipf("Skipping database tests: cannot connect to database: %v", err)
+	}
+
+	return db
+}
+
+// tradeFixture is a docked player with an empty shuttle and a food market
+type tradeFixture struct {
+	playerID uuid.UUID
+	shipID   uuid.UUID
+	planetID uuid.UUID
+}
+
+func setupTradeFixture(t *testing.T, db *database.DB, credits int64, stock int) *tradeFixture {
+	t.Helper()
+	ctx := context.Background()
+
+	f := &tradeFixture{
+		playerID: uuid.New(),
+		shipID:   uuid.New(),
+		planetID: uuid.New(),
+	}
+	systemID := uuid.New()
+	suffix := f.playerID.String()[:8]
+
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		{`INSERT INTO star_systems (id, name, pos_x, pos_y, government_id) VALUES ($1, $2, 0, 0, 'independent')`,
+			[]interface{}{systemID, "Trade Test " + suffix}},
+		{`INSERT INTO planets (id, system_id, name, population, tech_level) VALUES ($1, $2, 'Market', 50000000, 5)`,
+			[]interface{}{f.planetID, systemID}},
+		{`INSERT INTO players (id, username, credits, current_system, current_planet) VALUES ($1, $2, $3, $4, $5)`,
+			[]interface{}{f.playerID, "trader_" + suffix, credits, systemID, f.planetID}},
+		{`INSERT INTO ships (id, owner_id, type_id, name, hull, shields, fuel, crew) VALUES ($1, $2, 'shuttle', 'Test', 100, 50, 100, 1)`,
+			[]interface{}{f.shipID, f.playerID}},
+		{`UPDATE players SET ship_id = $1 WHERE id = $2`,
+			[]interface{}{f.shipID, f.playerID}},
+		{`INSERT INTO market_prices (planet_id, commodity_id, buy_price, sell_price, stock, demand, last_update) VALUES ($1, 'food', 40, 50, $2, 100, $3)`,
+			[]interface{}{f.planetID, stock, time.Now().Unix()}},
+	}
+
+	for _, stmt := range statements {
+		if _, err := db.ExecContext(ctx, stmt.query, stmt.args...); err != nil {
+			t.Fatalf("Failed to set up fixture: %v", err)
+		}
+	}
+
+	t.Cleanup(func() {
+		_, _ = db.ExecContext(ctx, `DELETE FROM players WHERE id = $1`, f.playerID)
+		_, _ = db.ExecContext(ctx, `DELETE FROM star_systems WHERE id = $1`, systemID)
+	})
+
+	return f
+}
+
+func TestServiceBuyAndSell(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	f := setupTradeFixture(t, db, 10000, 100)
+	service := NewService(db, database.NewSystemRepository(db))
+	ctx := context.Background()
+
+	bought, err := service.Buy(ctx, f.playerID, "food", 5)
+	if err != nil {
+		t.Fatalf("Buy failed: %v", err)
+	}
+	if bought.Total != 250 || bought.NewCredits != 9750 || bought.CargoQuantity != 5 {
+		t.Errorf("Unexpected buy result: %+v", bought)
+	}
+	if bought.Market.Stock != 95 {
+		t.Errorf("Expected stock 95, got %d", bought.Market.Stock)
+	}
+
+	sold, err := service.Sell(ctx, f.playerID, "food", 5)
+	if err != nil {
+		t.Fatalf("Sell failed: %v", err)
+	}
+	if sold.CargoQuantity != 0 {
+		t.Errorf("Expected empty hold, got %d", sold.CargoQuantity)
+	}
+
+	if _, err := service.Sell(ctx, f.playerID, "food", 1); !errors.Is(err, ErrInsufficientCargo) {
+		t.Errorf("Expected ErrInsufficientCargo, got %v", err)
+	}
+}
+
+func TestServiceSellLimitedByDemand(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	f := setupTradeFixture(t, db, 10000, 100)
+	service := NewService(db, database.NewSystemRepository(db))
+	ctx := context.Background()
+
+	if _, err := service.Buy(ctx, f.playerID, "food", 5); err != nil {
+		t.Fatalf("Buy failed: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `UPDATE market_prices SET demand = 3 WHERE planet_id = $1 AND commodity_id = 'food'`, f.planetID); err != nil {
+		t.Fatalf("Failed to lower demand: %v", err)
+	}
+
+	if _, err := service.Sell(ctx, f.playerID, "food", 5); !errors.Is(err, ErrInsufficientDemand) {
+		t.Errorf("Expected ErrInsufficientDemand, got %v", err)
+	}
+
+	// Selling everything stops at the market's demand
+	sold, err := service.SellAll(ctx, f.playerID, "food")
+	if err != nil {
+		t.Fatalf("SellAll failed: %v", err)
+	}
+	if sold.Quantity != 3 || sold.CargoQuantity != 2 {
+		t.Errorf("Expected 3 sold and 2 left in the hold, got %+v", sold)
+	}
+}
+
+func TestServiceTypedErrors(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	f := setupTradeFixture(t, db, 100, 3)
+	service := NewService(db, database.NewSystemRepository(db))
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		quantity int
+		want     error
+	}{
+		{"zero quantity", 0, ErrInvalidQuantity},
+		{"more than stock", 4, ErrInsufficientStock},
+		{"more than credits", 3, ErrInsufficientCredits},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.Buy(ctx, f.playerID, "food", tt.quantity); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := service.Buy(ctx, f.playerID, "unobtainium", 1); !errors.Is(err, ErrUnknownCommodity) {
+		t.Errorf("Expected ErrUnknownCommodity, got %v", err)
+	}
+}
+
+// TestServiceConcurrentBuys verifies that simultaneous purchases cannot
+// spend the same credits twice
+func TestServiceConcurrentBuys(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	// Enough credits for exactly one purchase of 10 units at 50 cr
+	f := setupTradeFixture(t, db, 500, 100)
+	service := NewService(db, database.NewSystemRepository(db))
+	ctx := context.Background()
+
+	const attempts = 8
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.Buy(ctx, f.playerID, "food", 10)
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			} else if !errors.Is(err, ErrInsufficientCredits) {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Fatalf("Expected exactly 1 successful purchase, got %d", succeeded)
+	}
+
+	var credits int64
+	if err := db.QueryRowContext(ctx, `SELECT credits FROM players WHERE id = $1`, f.playerID).Scan(&credits); err != nil {
+		t.Fatalf("Failed to read credits: %v", err)
+	}
+	if credits != 0 {
+		t.Errorf("Expected 0 credits, got %d", credits)
+	}
+}
@@ -0,0 +1,485 @@
+// File: internal/game/universe/file.go
+// Project: Terminal Velocity
+// Description: Versioned JSON/YAML universe file format for import and export
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package universe
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// FileFormatVersion is the universe file format version written by this build.
+// Bump it whenever a field changes meaning or is removed.
+const FileFormatVersion = 1
+
+// IndependentGovernmentID is the government of systems no NPC faction controls
+const IndependentGovernmentID = "independent"
+
+// Universe file errors.
+var (
+	// ErrUnknownFileFormat indicates a file extension that is neither JSON nor YAML.
+	ErrUnknownFileFormat = errors.New("unknown universe file format")
+
+	// ErrUnsupportedFileVersion indicates a universe file written by a newer or
+	// older format version than this build understands.
+	ErrUnsupportedFileVersion = errors.New("unsupported universe file version")
+
+	// ErrInvalidUniverseFile indicates a universe file with dangling references,
+	// duplicates or out-of-range values.
+	ErrInvalidUniverseFile = errors.New("invalid universe file")
+)
+
+// FileFormat selects the encoding of a universe file
+type FileFormat string
+
+const (
+	FormatJSON FileFormat = "json"
+	FormatYAML FileFormat = "yaml"
+)
+
+// File is the on-disk representation of a universe.
+//
+// The format is meant to be reviewed and checked in: every list is sorted
+// (governments by ID, systems and planets by name, routes by endpoint IDs,
+// markets by commodity) so regenerating an unchanged universe produces an
+// identical file, and edits show up as small diffs.
+//
+// Jump routes are stored once per pair; loaders create both directions.
+// Initial markets are nested under their planet and only seed prices - the
+// live economy takes over once the universe is loaded.
+type File struct {
+	Version     int               `json:"version" yaml:"version"`
+	Seed        int64             `json:"seed,omitempty" yaml:"seed,omitempty"`
+	Governments []GovernmentEntry `json:"governments" yaml:"governments"`
+	Systems     []SystemEntry     `json:"systems" yaml:"systems"`
+	Routes      []RouteEntry      `json:"routes" yaml:"routes"`
+}
+
+// GovernmentEntry describes a government referenced by systems
+type GovernmentEntry struct {
+	ID         string   `json:"id" yaml:"id"`
+	Name       string   `json:"name" yaml:"name"`
+	Color      string   `json:"color,omitempty" yaml:"color,omitempty"`
+	AlliedWith []string `json:"allied_with,omitempty" yaml:"allied_with,omitempty"`
+	HostileTo  []string `json:"hostile_to,omitempty" yaml:"hostile_to,omitempty"`
+}
+
+// SystemEntry describes a star system and its planets
+type SystemEntry struct {
+	ID           uuid.UUID     `json:"id" yaml:"id"`
+	Name         string        `json:"name" yaml:"name"`
+	X            int           `json:"x" yaml:"x"`
+	Y            int           `json:"y" yaml:"y"`
+	GovernmentID string        `json:"government_id" yaml:"government_id"`
+	TechLevel    int           `json:"tech_level" yaml:"tech_level"`
+	Description  string        `json:"description,omitempty" yaml:"description,omitempty"`
+	Planets      []PlanetEntry `json:"planets,omitempty" yaml:"planets,omitempty"`
+}
+
+// PlanetEntry describes a planet or station and its initial market
+type PlanetEntry struct {
+	ID          uuid.UUID     `json:"id" yaml:"id"`
+	Name        string        `json:"name" yaml:"name"`
+	Description string        `json:"description,omitempty" yaml:"description,omitempty"`
+	X           float64       `json:"x" yaml:"x"`
+	Y           float64       `json:"y" yaml:"y"`
+	Services    []string      `json:"services,omitempty" yaml:"services,omitempty"`
+	Population  int64         `json:"population" yaml:"population"`
+	TechLevel   int           `json:"tech_level" yaml:"tech_level"`
+	Market      []MarketEntry `json:"market,omitempty" yaml:"market,omitempty"`
+}
+
+// MarketEntry is the initial price and stock of one commodity at a planet
+type MarketEntry struct {
+	CommodityID string `json:"commodity_id" yaml:"commodity_id"`
+	BuyPrice    int64  `json:"buy_price" yaml:"buy_price"`
+	SellPrice   int64  `json:"sell_price" yaml:"sell_price"`
+	Stock       int    `json:"stock" yaml:"stock"`
+	Demand      int    `json:"demand" yaml:"demand"`
+}
+
+// RouteEntry is a bidirectional jump route between two systems
+type RouteEntry struct {
+	From uuid.UUID `json:"from" yaml:"from"`
+	To   uuid.UUID `json:"to" yaml:"to"`
+}
+
+// NewFile converts a generated universe into its file representation.
+//
+// Markets are optional; prices for planets not in the universe are ignored.
+// The result is sorted and ready to encode.
+func NewFile(u *Universe, seed int64, markets []models.MarketPrice) *File {
+	f := &File{
+		Version:     FileFormatVersion,
+		Seed:        seed,
+		Governments: standardGovernments(),
+	}
+
+	marketsByPlanet := make(map[uuid.UUID][]MarketEntry)
+	for _, price := range markets {
+		marketsByPlanet[price.PlanetID] = append(marketsByPlanet[price.PlanetID], MarketEntry{
+			CommodityID: price.CommodityID,
+			BuyPrice:    price.BuyPrice,
+			SellPrice:   price.SellPrice,
+			Stock:       price.Stock,
+			Demand:      price.Demand,
+		})
+	}
+
+	planetsBySystem := make(map[uuid.UUID][]PlanetEntry)
+	for _, planet := range u.Planets {
+		planetsBySystem[planet.SystemID] = append(planetsBySystem[planet.SystemID], PlanetEntry{
+			ID:          planet.ID,
+			Name:        planet.Name,
+			Description: planet.Description,
+			X:           planet.X,
+			Y:           planet.Y,
+			Services:    append([]string(nil), planet.Services...),
+			Population:  planet.Population,
+			TechLevel:   planet.TechLevel,
+			Market:      marketsByPlanet[planet.ID],
+		})
+	}
+
+	for _, system := range u.Systems {
+		f.Systems = append(f.Systems, SystemEntry{
+			ID:           system.ID,
+			Name:         system.Name,
+			X:            system.Position.X,
+			Y:            system.Position.Y,
+			GovernmentID: system.GovernmentID,
+			TechLevel:    system.TechLevel,
+			Description:  system.Description,
+			Planets:      planetsBySystem[system.ID],
+		})
+
+		for _, connected := range system.ConnectedSystems {
+			// Store each pair once, lowest ID first
+			if system.ID.String() < connected.String() {
+				f.Routes = append(f.Routes, RouteEntry{From: system.ID, To: connected})
+			}
+		}
+	}
+
+	f.sort()
+	return f
+}
+
+// standardGovernments lists the NPC factions plus the independent government
+func standardGovernments() []GovernmentEntry {
+	governments := []GovernmentEntry{{ID: IndependentGovernmentID, Name: "Independent"}}
+	for _, faction := range models.StandardNPCFactions {
+		governments = append(governments, GovernmentEntry{
+			ID:         faction.ID,
+			Name:       faction.Name,
+			Color:      faction.Color,
+			AlliedWith: append([]string(nil), faction.Allies...),
+			HostileTo:  append([]string(nil), faction.Enemies...),
+		})
+	}
+	return governments
+}
+
+// sort puts every list into its canonical order
+func (f *File) sort() {
+	sort.Slice(f.Governments, func(i, j int) bool {
+		return f.Governments[i].ID < f.Governments[j].ID
+	})
+	sort.Slice(f.Systems, func(i, j int) bool {
+		return f.Systems[i].Name < f.Systems[j].Name
+	})
+	for i := range f.Systems {
+		planets := f.Systems[i].Planets
+		sort.Slice(planets, func(a, b int) bool {
+			return planets[a].Name < planets[b].Name
+		})
+		for j := range planets {
+			market := planets[j].Market
+			sort.Slice(market, func(a, b int) bool {
+				return market[a].CommodityID < market[b].CommodityID
+			})
+		}
+	}
+	sort.Slice(f.Routes, func(i, j int) bool {
+		a, b := f.Routes[i], f.Routes[j]
+		if a.From != b.From {
+			return a.From.String() < b.From.String()
+		}
+		return a.To.String() < b.To.String()
+	})
+}
+
+// Validate checks the file version and that every reference resolves.
+func (f *File) Validate() error {
+	if f.Version != FileFormatVersion {
+		return fmt.Errorf("%w: got %d, want %d", ErrUnsupportedFileVersion, f.Version, FileFormatVersion)
+	}
+
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrInvalidUniverseFile, fmt.Sprintf(format, args...))
+	}
+
+	governments := make(map[string]bool)
+	for _, gov := range f.Governments {
+		if gov.ID == "" || governments[gov.ID] {
+			return invalid("empty or duplicate government id %q", gov.ID)
+		}
+		governments[gov.ID] = true
+	}
+
+	systems := make(map[uuid.UUID]bool)
+	systemNames := make(map[string]bool)
+	planets := make(map[uuid.UUID]bool)
+	for _, system := range f.Systems {
+		if system.ID == uuid.Nil || systems[system.ID] {
+			return invalid("nil or duplicate system id %s", system.ID)
+		}
+		if system.Name == "" || systemNames[system.Name] {
+			return invalid("empty or duplicate system name %q", system.Name)
+		}
+		if !governments[system.GovernmentID] {
+			return invalid("system %s has unknown government %q", system.Name, system.GovernmentID)
+		}
+		if system.TechLevel < 1 || system.TechLevel > 10 {
+			return invalid("system %s tech level %d out of range", system.Name, system.TechLevel)
+		}
+		systems[system.ID] = true
+		systemNames[system.Name] = true
+
+		planetNames := make(map[string]bool)
+		for _, planet := range system.Planets {
+			if planet.ID == uuid.Nil || planets[planet.ID] {
+				return invalid("nil or duplicate planet id %s", planet.ID)
+			}
+			if planet.Name == "" || planetNames[planet.Name] {
+				return invalid("empty or duplicate planet name %q in %s", planet.Name, system.Name)
+			}
+			if planet.TechLevel < 1 || planet.TechLevel > 10 {
+				return invalid("planet %s tech level %d out of range", planet.Name, planet.TechLevel)
+			}
+			planets[planet.ID] = true
+			planetNames[planet.Name] = true
+
+			commodities := make(map[string]bool)
+			for _, entry := range planet.Market {
+				if models.GetCommodityByID(entry.CommodityID) == nil || commodities[entry.CommodityID] {
+					return invalid("unknown or duplicate commodity %q at %s", entry.CommodityID, planet.Name)
+				}
+				if entry.BuyPrice < 0 || entry.SellPrice < 0 || entry.Stock < 0 || entry.Demand < 0 {
+					return invalid("negative market values for %s at %s", entry.CommodityID, planet.Name)
+				}
+				commodities[entry.CommodityID] = true
+			}
+		}
+	}
+
+	for _, route := range f.Routes {
+		if !systems[route.From] || !systems[route.To] {
+			return invalid("route %s -> %s references an unknown system", route.From, route.To)
+		}
+		if route.From == route.To {
+			return invalid("route from %s to itself", route.From)
+		}
+	}
+
+	return nil
+}
+
+// Universe rebuilds the in-memory universe described by the file
+func (f *File) Universe() *Universe {
+	u := &Universe{
+		Systems: make(map[uuid.UUID]*models.StarSystem),
+		Planets: make(map[uuid.UUID]*models.Planet),
+	}
+
+	for _, system := range f.StarSystems() {
+		u.Systems[system.ID] = system
+	}
+	for _, route := range f.Routes {
+		if a, ok := u.Systems[route.From]; ok {
+			a.ConnectedSystems = append(a.ConnectedSystems, route.To)
+		}
+		if b, ok := u.Systems[route.To]; ok {
+			b.ConnectedSystems = append(b.ConnectedSystems, route.From)
+		}
+	}
+	for _, planet := range f.Planets() {
+		u.Planets[planet.ID] = planet
+		system := u.Systems[planet.SystemID]
+		system.Planets = append(system.Planets, *planet)
+	}
+
+	return u
+}
+
+// StarSystems returns the systems in file order, without planets or routes
+func (f *File) StarSystems() []*models.StarSystem {
+	systems := make([]*models.StarSystem, 0, len(f.Systems))
+	for _, entry := range f.Systems {
+		systems = append(systems, &models.StarSystem{
+			ID:               entry.ID,
+			Name:             entry.Name,
+			Position:         models.Position{X: entry.X, Y: entry.Y},
+			GovernmentID:     entry.GovernmentID,
+			TechLevel:        entry.TechLevel,
+			Description:      entry.Description,
+			ConnectedSystems: []uuid.UUID{},
+		})
+	}
+	return systems
+}
+
+// Planets returns every planet in file order
+func (f *File) Planets() []*models.Planet {
+	var planets []*models.Planet
+	for _, system := range f.Systems {
+		for _, entry := range system.Planets {
+			planets = append(planets, &models.Planet{
+				ID:          entry.ID,
+				SystemID:    system.ID,
+				Name:        entry.Name,
+				Description: entry.Description,
+				X:           entry.X,
+				Y:           entry.Y,
+				Services:    append([]string(nil), entry.Services...),
+				Population:  entry.Population,
+				TechLevel:   entry.TechLevel,
+			})
+		}
+	}
+	return planets
+}
+
+// JumpRoutes returns the routes as system ID pairs, one per route
+func (f *File) JumpRoutes() [][2]uuid.UUID {
+	routes := make([][2]uuid.UUID, 0, len(f.Routes))
+	for _, route := range f.Routes {
+		routes = append(routes, [2]uuid.UUID{route.From, route.To})
+	}
+	return routes
+}
+
+// MarketPrices returns the initial markets stamped with the given update time
+func (f *File) MarketPrices(lastUpdate int64) []*models.MarketPrice {
+	var prices []*models.MarketPrice
+	for _, system := range f.Systems {
+		for _, planet := range system.Planets {
+			for _, entry := range planet.Market {
+				prices = append(prices, &models.MarketPrice{
+					PlanetID:    planet.ID,
+					CommodityID: entry.CommodityID,
+					BuyPrice:    entry.BuyPrice,
+					SellPrice:   entry.SellPrice,
+					Stock:       entry.Stock,
+					Demand:      entry.Demand,
+					LastUpdate:  lastUpdate,
+				})
+			}
+		}
+	}
+	return prices
+}
+
+// FormatForPath picks the file format from a path's extension
+func FormatForPath(path string) (FileFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownFileFormat, path)
+	}
+}
+
+// Encode writes the file in the given format
+func (f *File) Encode(w io.Writer, format FileFormat) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(f)
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(f); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFileFormat, format)
+	}
+}
+
+// Decode reads a universe file in either format and validates it.
+//
+// JSON is recognised by its leading brace; anything else is parsed as YAML.
+func Decode(r io.Reader) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read universe file: %w", err)
+	}
+
+	var f File
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&f)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse universe file: %w", err)
+	}
+
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// SaveFile writes the file to path, choosing the format from the extension
+func (f *File) SaveFile(path string) error {
+	format, err := FormatForPath(path)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create universe file: %w", err)
+	}
+	if err := f.Encode(out, format); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to write universe file: %w", err)
+	}
+	return out.Close()
+}
+
+// LoadFile reads and validates a universe file from path
+func LoadFile(path string) (*File, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open universe file: %w", err)
+	}
+	defer in.Close()
+
+	return Decode(in)
+}
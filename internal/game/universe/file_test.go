@@ -0,0 +1,116 @@
+// File: internal/game/universe/file_test.go
+// Project: Terminal Velocity
+// Description: Tests for the universe file format
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package universe
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+func newTestFile(t *testing.T, seed int64) *File {
+	t.Helper()
+
+	config := DefaultConfig()
+	config.NumSystems = 15
+	config.Seed = seed
+	u, err := NewGenerator(config).Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	var markets []models.MarketPrice
+	for _, planet := range u.Planets {
+		markets = append(markets, models.MarketPrice{
+			PlanetID:    planet.ID,
+			CommodityID: "food",
+			BuyPrice:    70,
+			SellPrice:   100,
+			Stock:       200,
+			Demand:      50,
+		})
+	}
+
+	return NewFile(u, seed, markets)
+}
+
+func TestFileRoundTrip(t *testing.T) {
+	f := newTestFile(t, 99)
+	if err := f.Validate(); err != nil {
+		t.Fatalf("Generated file is invalid: %v", err)
+	}
+
+	for _, format := range []FileFormat{FormatJSON, FormatYAML} {
+		var buf bytes.Buffer
+		if err := f.Encode(&buf, format); err != nil {
+			t.Fatalf("Encode %s failed: %v", format, err)
+		}
+
+		decoded, err := Decode(&buf)
+		if err != nil {
+			t.Fatalf("Decode %s failed: %v", format, err)
+		}
+		if !reflect.DeepEqual(f, decoded) {
+			t.Errorf("%s round trip changed the file", format)
+		}
+	}
+
+	// Rebuilding the universe from the file yields the same file again
+	again := NewFile(f.Universe(), f.Seed, nil)
+	for i := range f.Systems {
+		for j := range f.Systems[i].Planets {
+			again.Systems[i].Planets[j].Market = f.Systems[i].Planets[j].Market
+		}
+	}
+	if !reflect.DeepEqual(f, again) {
+		t.Error("File -> Universe -> File changed the file")
+	}
+}
+
+func TestFileIsReproducible(t *testing.T) {
+	var first, second bytes.Buffer
+	if err := newTestFile(t, 1234).Encode(&first, FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	if err := newTestFile(t, 1234).Encode(&second, FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Error("Expected identical files for the same seed")
+	}
+}
+
+func TestFileValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(f *File)
+		want   error
+	}{
+		{"version", func(f *File) { f.Version = FileFormatVersion + 1 }, ErrUnsupportedFileVersion},
+		{"unknown government", func(f *File) { f.Systems[0].GovernmentID = "nobody" }, ErrInvalidUniverseFile},
+		{"duplicate system", func(f *File) { f.Systems[1].ID = f.Systems[0].ID }, ErrInvalidUniverseFile},
+		{"dangling route", func(f *File) { f.Routes[0].To = uuid.New() }, ErrInvalidUniverseFile},
+		{"unknown commodity", func(f *File) { f.Systems[0].Planets[0].Market[0].CommodityID = "unobtainium" }, ErrInvalidUniverseFile},
+	}
+
+	for _, tt := range tests {
+		f := newTestFile(t, 5)
+		tt.mutate(f)
+		if err := f.Validate(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	if _, err := FormatForPath("galaxy.toml"); !errors.Is(err, ErrUnknownFileFormat) {
+		t.Errorf("Expected ErrUnknownFileFormat, got %v", err)
+	}
+}
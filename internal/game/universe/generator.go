@@ -24,6 +24,7 @@ var log = logger.WithComponent("Universe")
 type Generator struct {
 	config  GeneratorConfig
 	rand    *rand.Rand
+	ids     *rand.Rand // Separate stream so IDs don't shift the layout
 	nameGen *NameGenerator
 }
 
@@ -59,16 +60,24 @@ func NewGenerator(config GeneratorConfig) *Generator {
 	if seed == 0 {
 		seed = rand.Int63()
 	}
+	config.Seed = seed
 
 	r := rand.New(rand.NewSource(seed))
 
 	return &Generator{
 		config:  config,
 		rand:    r,
+		ids:     rand.New(rand.NewSource(seed ^ 0x5eed1d5)),
 		nameGen: NewNameGenerator(r),
 	}
 }
 
+// Seed returns the random seed in use, including one chosen at random when
+// the configuration left it at 0
+func (g *Generator) Seed() int64 {
+	return g.config.Seed
+}
+
 // Generate creates a complete universe
 func (g *Generator) Generate() (*Universe, error) {
 	universe := &Universe{
@@ -105,13 +114,20 @@ func (g *Generator) Generate() (*Universe, error) {
 	return universe, nil
 }
 
+// newID returns the next system or planet ID. IDs come from the seed so the
+// same seed always produces the same universe, down to its IDs.
+func (g *Generator) newID() uuid.UUID {
+	id, _ := uuid.NewRandomFromReader(g.ids) // rand.Rand reads never fail
+	return id
+}
+
 // generateSystems creates all star systems
 func (g *Generator) generateSystems() []models.StarSystem {
 	systems := make([]models.StarSystem, g.config.NumSystems)
 
 	// System 0 is always Sol (Earth)
 	systems[0] = models.StarSystem{
-		ID:               uuid.New(),
+		ID:               g.newID(),
 		Name:             "Sol",
 		Position:         models.Position{X: 0, Y: 0},
 		Description:      "Birthplace of humanity. Home to Earth, Mars, and the United Earth Federation capital.",
@@ -140,7 +156,7 @@ func (g *Generator) generateSystem(index int) models.StarSystem {
 	name := g.nameGen.GenerateSystemName()
 
 	return models.StarSystem{
-		ID:               uuid.New(),
+		ID:               g.newID(),
 		Name:             name,
 		Position:         models.Position{X: x, Y: y},
 		Description:      "", // Will be set after faction assignment
@@ -452,7 +468,7 @@ func (g *Generator) generatePlanet(system *models.StarSystem, index int) models.
 	services := g.generateServices(system.TechLevel)
 
 	return models.Planet{
-		ID:          uuid.New(),
+		ID:          g.newID(),
 		SystemID:    system.ID,
 		Name:        planetName,
 		Description: GeneratePlanetDescription(g.rand, isStation),
@@ -499,7 +515,7 @@ func (g *Generator) getDistanceFromSol(pos models.Position) float64 {
 func (g *Generator) generateDescriptions(systems []models.StarSystem) {
 	for i := range systems {
 		distance := g.getDistanceFromSol(systems[i].Position)
-		systems[i].Description = GenerateDescription(systems[i].GovernmentID, distance)
+		systems[i].Description = GenerateDescription(g.rand, systems[i].GovernmentID, distance)
 	}
 }
 
@@ -323,6 +323,7 @@ var independentDescriptions = []string{
 //  2. Randomly select one description from the appropriate pool
 //
 // Parameters:
+//   - r: Random source (the generator's seeded source keeps output reproducible)
 //   - governmentID: The ID of the faction controlling this system
 //   - distanceFromSol: Distance in light-years from Sol (Earth's system)
 //
@@ -333,7 +334,7 @@ var independentDescriptions = []string{
 //
 // The distance thresholds (30 LY, 60 LY, 100 LY) correspond to the core, mid, outer,
 // and edge radius configuration values used during universe generation.
-func GenerateDescription(governmentID string, distanceFromSol float64) string {
+func GenerateDescription(r *rand.Rand, governmentID string, distanceFromSol float64) string {
 	descriptions := independentDescriptions
 
 	switch governmentID {
@@ -354,7 +355,7 @@ func GenerateDescription(governmentID string, distanceFromSol float64) string {
 	}
 
 	// Return random description from appropriate set
-	return descriptions[rand.Intn(len(descriptions))]
+	return descriptions[r.Intn(len(descriptions))]
 }
 
 // Planet description templates
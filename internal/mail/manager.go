@@ -26,7 +26,8 @@ type Manager struct {
 	socialRepo *database.SocialRepository
 
 	// Callbacks for notifications
-	onNewMail func(receiverID uuid.UUID, mail *models.Mail)
+	onNewMail            func(receiverID uuid.UUID, mail *models.Mail)
+	onAttachmentsClaimed func(playerID uuid.UUID, mail *models.Mail)
 }
 
 // NewManager creates a new mail manager
@@ -43,6 +44,13 @@ func (m *Manager) SetNewMailCallback(callback func(receiverID uuid.UUID, mail *m
 	m.onNewMail = callback
 }
 
+// SetAttachmentsClaimedCallback sets the callback for claimed mail attachments
+func (m *Manager) SetAttachmentsClaimedCallback(callback func(playerID uuid.UUID, mail *models.Mail)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onAttachmentsClaimed = callback
+}
+
 // ============================================================================
 // Mail Operations
 // ============================================================================
@@ -255,6 +263,13 @@ func (m *Manager) ClaimAttachments(ctx context.Context, mailID, playerID uuid.UU
 		return 0, fmt.Errorf("failed to claim attachments: %w", err)
 	}
 
+	// Trigger claim callback
+	m.mu.RLock()
+	if m.onAttachmentsClaimed != nil {
+		m.onAttachmentsClaimed(playerID, mail)
+	}
+	m.mu.RUnlock()
+
 	log.Info("Mail attachments claimed: mail=%s, player=%s, credits=%d",
 		mailID, playerID, credits)
 	return credits, nil
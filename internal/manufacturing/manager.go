@@ -1,19 +1,31 @@
 // File: internal/manufacturing/manager.go
 // Project: Terminal Velocity
 // Description: Manufacturing system with crafting, tech tree, and player stations
-// Version: 1.1.0
+// Version: 1.4.0
 // Author: Claude Code
 // Created: 2025-11-15
+//
+// Crafting takes a blueprint's inputs from the player's ship cargo when the
+// job starts. When it completes - checked by a background worker, so jobs
+// finish while the player is offline - weapon and outfit blueprints produce
+// PlayerItems (aboard the ship, or in station storage when crafted at a
+// station) and commodity blueprints add cargo. Completed crafts raise the
+// player's crafting skill and earn research points for the tech tree.
+//
+// Jobs, stations and researched technologies are saved through the Store
+// and restored by Load.
 
 package manufacturing
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"sync"
 	"time"
 
-	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	"github.com/google/uuid"
@@ -21,29 +33,68 @@ import (
 
 var log = logger.WithComponent("Manufacturing")
 
+var (
+	// ErrBlueprintNotFound is returned for an unknown blueprint ID
+	ErrBlueprintNotFound = errors.New("blueprint not found")
+
+	// ErrTechnologyNotFound is returned for an unknown technology ID
+	ErrTechnologyNotFound = errors.New("technology not found")
+
+	// ErrJobNotFound is returned for an unknown crafting job
+	ErrJobNotFound = errors.New("crafting job not found")
+
+	// ErrNotYourJob is returned when acting on another player's job
+	ErrNotYourJob = errors.New("not your crafting job")
+
+	// ErrJobNotInProgress is returned when cancelling a finished job
+	ErrJobNotInProgress = errors.New("job is not in progress")
+
+	// ErrStationNotFound is returned for an unknown station
+	ErrStationNotFound = errors.New("station not found")
+
+	// ErrNotStationOwner is returned when acting on another player's station
+	ErrNotStationOwner = errors.New("not your station")
+
+	// ErrNotAtStation is returned when crafting at a station in another system
+	ErrNotAtStation = errors.New("you must be in the station's system")
+
+	// ErrNoShip is returned when the player has no ship to craft from
+	ErrNoShip = errors.New("you need a ship to craft")
+)
+
 // Manager handles crafting, tech research, and player stations
 type Manager struct {
 	mu sync.RWMutex
 
 	// Manufacturing data
-	blueprints    map[uuid.UUID]*Blueprint
-	craftingJobs  map[uuid.UUID]*CraftingJob
-	stations      map[uuid.UUID]*PlayerStation
-	technologies  map[uuid.UUID]*Technology
-	playerTech    map[uuid.UUID]map[string]int // playerID -> techID -> level
+	blueprints   map[string]*Blueprint
+	craftingJobs map[uuid.UUID]*CraftingJob
+	stations     map[uuid.UUID]*PlayerStation
+	technologies map[string]*Technology
+	playerTech   map[uuid.UUID]map[string]int // playerID -> techID -> level
 
 	// Configuration
 	config ManufacturingConfig
 
-	// Repositories
-	playerRepo *database.PlayerRepository
-	shipRepo   *database.ShipRepository
+	// Player accounts, cargo and items
+	players Players
+	ships   Ships
+	items   Items
+
+	// Persistence (nil keeps everything in memory)
+	store Store
+
+	// Delivered jobs whose crafts could not be recorded yet, retried by the
+	// crafting worker
+	unrecorded map[uuid.UUID]*CraftingJob
 
 	// Callbacks
-	onCraftingComplete  func(job *CraftingJob)
-	onTechResearched    func(playerID uuid.UUID, tech *Technology)
-	onStationBuilt      func(station *PlayerStation)
-	onStationUpgraded   func(station *PlayerStation)
+	onCraftingComplete func(job *CraftingJob)
+	onTechResearched   func(playerID uuid.UUID, tech *Technology)
+	onStationBuilt     func(station *PlayerStation)
+	onStationUpgraded  func(station *PlayerStation)
+	onCreditsChanged   func(playerID uuid.UUID, oldCredits, newCredits int64, reason string)
+	onInventoryChanged func(playerID uuid.UUID)
 
 	// Background workers
 	stopChan chan struct{}
@@ -53,55 +104,62 @@ type Manager struct {
 // ManufacturingConfig defines manufacturing parameters
 type ManufacturingConfig struct {
 	// Crafting settings
-	CraftingSpeedModifier    float64       // Global crafting speed multiplier
-	CraftingCostModifier     float64       // Global cost multiplier
-	MaxConcurrentJobs        int           // Max crafting jobs per player
-	CraftingSkillBonusRate   float64       // Skill bonus per level
+	CraftingSpeedModifier  float64       // Global crafting speed multiplier
+	CraftingCostModifier   float64       // Global multiplier on blueprint inputs
+	MaxConcurrentJobs      int           // Max crafting jobs per player
+	MaxCraftQuantity       int           // Max items per crafting job
+	CraftingSkillBonusRate float64       // Skill bonus per level
+	MaxCraftingSkill       int           // Crafting skill cap
+	JobRetention           time.Duration // How long finished jobs stay listed
 
 	// Tech research settings
-	ResearchPointsPerDay     int           // Daily research points
-	MaxResearchQueue         int           // Max queued research
-	TechCostScaling          float64       // Cost increase per level
-	TechPrerequisiteStrict   bool          // Require all prerequisites
+	ResearchPointsPerTier  int     // Research points earned per crafted item per blueprint tier
+	TechCostScaling        float64 // Cost increase per level
+	TechPrerequisiteStrict bool    // Require all prerequisites
 
 	// Station settings
-	StationBuildCost         int64         // Base cost to build station
-	StationUpgradeCost       int64         // Base upgrade cost
-	MaxStationsPerPlayer     int           // Max stations per player
-	StationProductionBonus   float64       // Production bonus from station
-	StationStorageCapacity   int           // Base storage capacity
+	StationBuildCost       int64   // Base cost to build station
+	StationUpgradeCost     int64   // Base upgrade cost
+	MaxStationsPerPlayer   int     // Max stations per player
+	StationProductionBonus float64 // Production bonus from station
+	StationStorageCapacity int     // Base storage capacity
 }
 
 // DefaultManufacturingConfig returns sensible defaults
 func DefaultManufacturingConfig() ManufacturingConfig {
 	return ManufacturingConfig{
-		CraftingSpeedModifier:    1.0,
-		CraftingCostModifier:     1.0,
-		MaxConcurrentJobs:        3,
-		CraftingSkillBonusRate:   0.05,  // 5% per level
-		ResearchPointsPerDay:     100,
-		MaxResearchQueue:         5,
-		TechCostScaling:          1.5,   // 50% increase per level
-		TechPrerequisiteStrict:   true,
-		StationBuildCost:         1000000,
-		StationUpgradeCost:       500000,
-		MaxStationsPerPlayer:     3,
-		StationProductionBonus:   0.25,  // 25% bonus
-		StationStorageCapacity:   10000,
+		CraftingSpeedModifier:  1.0,
+		CraftingCostModifier:   1.0,
+		MaxConcurrentJobs:      3,
+		MaxCraftQuantity:       10,
+		CraftingSkillBonusRate: 0.05, // 5% per level
+		MaxCraftingSkill:       100,
+		JobRetention:           24 * time.Hour,
+		ResearchPointsPerTier:  5,
+		TechCostScaling:        1.5, // 50% increase per level
+		TechPrerequisiteStrict: true,
+		StationBuildCost:       1000000,
+		StationUpgradeCost:     500000,
+		MaxStationsPerPlayer:   3,
+		StationProductionBonus: 0.25, // 25% bonus
+		StationStorageCapacity: 10000,
 	}
 }
 
-// NewManager creates a new manufacturing manager
-func NewManager(playerRepo *database.PlayerRepository, shipRepo *database.ShipRepository) *Manager {
+// NewManager creates a new manufacturing manager that charges players,
+// takes cargo and creates items through the given repositories
+func NewManager(players Players, ships Ships, items Items) *Manager {
 	m := &Manager{
-		blueprints:   make(map[uuid.UUID]*Blueprint),
+		blueprints:   make(map[string]*Blueprint),
 		craftingJobs: make(map[uuid.UUID]*CraftingJob),
 		stations:     make(map[uuid.UUID]*PlayerStation),
-		technologies: make(map[uuid.UUID]*Technology),
+		technologies: make(map[string]*Technology),
 		playerTech:   make(map[uuid.UUID]map[string]int),
+		unrecorded:   make(map[uuid.UUID]*CraftingJob),
 		config:       DefaultManufacturingConfig(),
-		playerRepo:   playerRepo,
-		shipRepo:     shipRepo,
+		players:      players,
+		ships:        ships,
+		items:        items,
 		stopChan:     make(chan struct{}),
 	}
 
@@ -133,69 +191,64 @@ func (m *Manager) SetCallbacks(
 	onStationBuilt func(station *PlayerStation),
 	onStationUpgraded func(station *PlayerStation),
 ) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.onCraftingComplete = onCraftingComplete
 	m.onTechResearched = onTechResearched
 	m.onStationBuilt = onStationBuilt
 	m.onStationUpgraded = onStationUpgraded
 }
 
-// ============================================================================
-// DATA STRUCTURES
-// ============================================================================
+// SetCreditsChangedCallback sets the callback invoked after research or
+// station work changes a player's stored credits
+func (m *Manager) SetCreditsChangedCallback(callback func(playerID uuid.UUID, oldCredits, newCredits int64, reason string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onCreditsChanged = callback
+}
 
-// Blueprint defines how to craft an item
-type Blueprint struct {
-	ID           uuid.UUID
-	Name         string
-	Description  string
-	ItemType     string // "weapon", "outfit", "ship_component", "consumable"
-	Tier         int    // 1-5 rarity tier
-	CraftingTime time.Duration
-	Requirements map[string]int // resource -> quantity
-	Produces     map[string]int // item -> quantity
-	SkillLevel   int            // Required crafting skill
-	TechRequired string         // Required technology ID (optional)
+// SetInventoryChangedCallback sets the callback invoked after crafting
+// takes cargo from, or delivers cargo and items to, a player
+func (m *Manager) SetInventoryChangedCallback(callback func(playerID uuid.UUID)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onInventoryChanged = callback
 }
 
-// CraftingJob represents an active crafting operation
-type CraftingJob struct {
-	ID           uuid.UUID
-	PlayerID     uuid.UUID
-	BlueprintID  uuid.UUID
-	Blueprint    *Blueprint
-	StartTime    time.Time
-	CompletionTime time.Time
-	Status       string    // "in_progress", "complete", "failed"
-	Quantity     int
-	StationID    *uuid.UUID // Optional: crafting at a station
+// creditsChanged reports a saved balance change. Caller must hold m.mu.
+func (m *Manager) creditsChanged(playerID uuid.UUID, delta, newCredits int64, reason string) {
+	if m.onCreditsChanged != nil {
+		go m.onCreditsChanged(playerID, newCredits-delta, newCredits, reason)
+	}
 }
 
-// PlayerStation represents a player-owned manufacturing station
-type PlayerStation struct {
-	ID            uuid.UUID
-	OwnerID       uuid.UUID
-	Name          string
-	SystemID      uuid.UUID
-	SystemName    string
-	Level         int       // Station upgrade level 1-10
-	BuildTime     time.Time
-	Facilities    []StationFacility
-	Storage       map[string]int // resource -> quantity
-	StorageCapacity int
-	ProductionBonus float64  // Bonus to crafting speed
-	Status        string    // "active", "upgrading", "damaged"
+// inventoryChanged reports a cargo or item change. Caller must hold m.mu.
+func (m *Manager) inventoryChanged(playerID uuid.UUID) {
+	if m.onInventoryChanged != nil {
+		go m.onInventoryChanged(playerID)
+	}
 }
 
-// StationFacility represents a facility within a station
-type StationFacility string
+// ============================================================================
+// DATA STRUCTURES
+// ============================================================================
+
+// Persisted manufacturing records live in models so the database package
+// can store them; they are aliased here for callers of this package.
+type (
+	Blueprint       = models.Blueprint       // How to craft an item
+	CraftingJob     = models.CraftingJob     // Crafting operation
+	PlayerStation   = models.PlayerStation   // Player-owned manufacturing station
+	StationFacility = models.StationFacility // Facility within a station
+)
 
 const (
-	FacilityManufacturing StationFacility = "manufacturing" // Craft items
-	FacilityResearch      StationFacility = "research"      // Research tech
-	FacilityRefinery      StationFacility = "refinery"      // Process raw materials
-	FacilityShipyard      StationFacility = "shipyard"      // Build ships
-	FacilityWarehouse     StationFacility = "warehouse"     // Extra storage
-	FacilityDefense       StationFacility = "defense"       // Station defenses
+	FacilityManufacturing = models.FacilityManufacturing // Craft items
+	FacilityResearch      = models.FacilityResearch      // Research tech
+	FacilityRefinery      = models.FacilityRefinery      // Process raw materials
+	FacilityShipyard      = models.FacilityShipyard      // Build ships
+	FacilityWarehouse     = models.FacilityWarehouse     // Extra storage
+	FacilityDefense       = models.FacilityDefense       // Station defenses
 )
 
 // Technology represents a researchable technology
@@ -205,62 +258,52 @@ type Technology struct {
 	Description   string
 	Category      TechCategory
 	MaxLevel      int
-	ResearchCost  int       // Base research points needed
-	CreditCost    int64     // Credits required
-	Prerequisites []string  // Required tech IDs
-	Unlocks       []string  // What this tech unlocks
-	Benefits      map[string]float64 // Bonuses provided
+	ResearchCost  int                // Base research points needed
+	CreditCost    int64              // Credits required
+	Prerequisites []string           // Required tech IDs
+	Unlocks       []string           // Blueprint IDs this tech unlocks
+	Benefits      map[string]float64 // Bonuses provided per level
 }
 
 // TechCategory defines technology types
 type TechCategory string
 
 const (
-	TechCategoryWeapons    TechCategory = "weapons"
-	TechCategoryDefense    TechCategory = "defense"
-	TechCategoryEngines    TechCategory = "engines"
-	TechCategoryEnergy     TechCategory = "energy"
+	TechCategoryWeapons       TechCategory = "weapons"
+	TechCategoryDefense       TechCategory = "defense"
+	TechCategoryEngines       TechCategory = "engines"
+	TechCategoryEnergy        TechCategory = "energy"
 	TechCategoryManufacturing TechCategory = "manufacturing"
-	TechCategoryCloaking   TechCategory = "cloaking"
-	TechCategoryJumpDrive  TechCategory = "jump_drive"
+	TechCategoryCloaking      TechCategory = "cloaking"
+	TechCategoryJumpDrive     TechCategory = "jump_drive"
 )
 
 // ============================================================================
 // CRAFTING SYSTEM
 // ============================================================================
 
-// StartCrafting initiates a crafting job
-func (m *Manager) StartCrafting(ctx context.Context, playerID uuid.UUID, blueprintID uuid.UUID, quantity int, stationID *uuid.UUID) (*CraftingJob, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	// Get blueprint
-	blueprint, exists := m.blueprints[blueprintID]
-	if !exists {
-		return nil, fmt.Errorf("blueprint not found")
+// StartCrafting initiates a crafting job, taking the blueprint's inputs
+// from the player's ship cargo. A station, if given, must be the player's,
+// in their current system and have a manufacturing facility; its production
+// bonus speeds the job up and items it produces go to its storage.
+//
+// The player and cargo are read and charged without the lock. The checks
+// made under it are repeated when the job is recorded, and the inputs are
+// given back if they no longer pass or the job cannot be saved.
+func (m *Manager) StartCrafting(ctx context.Context, playerID uuid.UUID, blueprintID string, quantity int, stationID *uuid.UUID) (*CraftingJob, error) {
+	if quantity < 1 || quantity > m.config.MaxCraftQuantity {
+		return nil, fmt.Errorf("quantity must be between 1 and %d", m.config.MaxCraftQuantity)
 	}
 
-	// Check concurrent job limit
-	activeJobs := 0
-	for _, job := range m.craftingJobs {
-		if job.PlayerID == playerID && job.Status == "in_progress" {
-			activeJobs++
-		}
-	}
-	if activeJobs >= m.config.MaxConcurrentJobs {
-		return nil, fmt.Errorf("maximum concurrent crafting jobs reached (%d)", m.config.MaxConcurrentJobs)
-	}
-
-	// Check tech requirements
-	if blueprint.TechRequired != "" {
-		playerTechs := m.playerTech[playerID]
-		if playerTechs == nil || playerTechs[blueprint.TechRequired] == 0 {
-			return nil, fmt.Errorf("required technology: %s", blueprint.TechRequired)
-		}
+	m.mu.RLock()
+	blueprint, station, err := m.checkCrafting(playerID, blueprintID, stationID)
+	m.mu.RUnlock()
+	if err != nil {
+		return nil, err
 	}
 
 	// Fetch player to check skill level
-	player, err := m.playerRepo.GetByID(ctx, playerID)
+	player, err := m.players.GetByID(ctx, playerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch player: %w", err)
 	}
@@ -269,143 +312,214 @@ func (m *Manager) StartCrafting(ctx context.Context, playerID uuid.UUID, bluepri
 	if player.CraftingSkill < blueprint.SkillLevel {
 		return nil, fmt.Errorf("insufficient crafting skill: required %d, have %d", blueprint.SkillLevel, player.CraftingSkill)
 	}
+	if station != nil && station.SystemID != player.CurrentSystem {
+		return nil, ErrNotAtStation
+	}
 
 	// Check and deduct resources from player's ship cargo
-	if len(blueprint.Requirements) > 0 {
-		ship, err := m.shipRepo.GetByID(ctx, player.ShipID)
+	if player.ShipID == uuid.Nil {
+		return nil, ErrNoShip
+	}
+	required := m.requirements(blueprint, quantity)
+	if len(required) > 0 {
+		ship, err := m.ships.GetByID(ctx, player.ShipID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch player ship: %w", err)
 		}
-
-		// Check if player has all required resources
-		totalRequired := make(map[string]int)
-		for resource, qty := range blueprint.Requirements {
-			totalRequired[resource] = qty * quantity
-		}
-
-		if err := m.checkResources(ship, totalRequired); err != nil {
+		if err := m.checkResources(ship, required); err != nil {
 			return nil, err
 		}
-
-		// Deduct resources from cargo
-		if err := m.deductResources(ctx, ship, totalRequired); err != nil {
+		if err := m.ships.ConsumeCargo(ctx, ship.ID, required); err != nil {
 			return nil, fmt.Errorf("failed to deduct resources: %w", err)
 		}
 	}
 
+	m.mu.Lock()
+	job, err := m.newCraftingJob(player, blueprintID, quantity, stationID)
+	if err == nil {
+		err = m.saveJob(job)
+	}
+	if err == nil {
+		m.craftingJobs[job.ID] = job
+		m.inventoryChanged(playerID)
+	}
+	m.mu.Unlock()
+
+	if err != nil {
+		// The job was never recorded, so its inputs go back aboard
+		m.returnCargo(ctx, player.ShipID, required)
+		return nil, err
+	}
+
+	log.Info("Crafting started: player=%s, item=%s, quantity=%d, time=%v",
+		playerID, blueprint.Name, quantity, job.CompletionTime.Sub(job.StartTime))
+
+	jobCopy := *job
+	return &jobCopy, nil
+}
+
+// checkCrafting checks that a player may start a blueprint, at a station if
+// given, and returns the blueprint and a copy of the station (must hold lock)
+func (m *Manager) checkCrafting(playerID uuid.UUID, blueprintID string, stationID *uuid.UUID) (*Blueprint, *PlayerStation, error) {
+	// Get blueprint
+	blueprint, exists := m.blueprints[blueprintID]
+	if !exists {
+		return nil, nil, ErrBlueprintNotFound
+	}
+
+	// Check concurrent job limit
+	activeJobs := 0
+	for _, job := range m.craftingJobs {
+		if job.PlayerID == playerID && job.Status == models.CraftingInProgress {
+			activeJobs++
+		}
+	}
+	if activeJobs >= m.config.MaxConcurrentJobs {
+		return nil, nil, fmt.Errorf("maximum concurrent crafting jobs reached (%d)", m.config.MaxConcurrentJobs)
+	}
+
+	// Check tech requirements
+	if blueprint.TechRequired != "" && m.playerTech[playerID][blueprint.TechRequired] == 0 {
+		return nil, nil, fmt.Errorf("required technology: %s", m.techName(blueprint.TechRequired))
+	}
+
+	if stationID == nil {
+		return blueprint, nil, nil
+	}
+	station, exists := m.stations[*stationID]
+	if !exists {
+		return nil, nil, ErrStationNotFound
+	}
+	if station.OwnerID != playerID {
+		return nil, nil, ErrNotStationOwner
+	}
+	if !station.HasFacility(FacilityManufacturing) {
+		return nil, nil, fmt.Errorf("%s has no manufacturing facility", station.Name)
+	}
+	return blueprint, copyStation(station), nil
+}
+
+// newCraftingJob repeats the crafting checks and builds a job whose length
+// reflects the player's skill, technologies and station (must hold lock)
+func (m *Manager) newCraftingJob(player *models.Player, blueprintID string, quantity int, stationID *uuid.UUID) (*CraftingJob, error) {
+	blueprint, station, err := m.checkCrafting(player.ID, blueprintID, stationID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Calculate crafting time
 	baseTime := blueprint.CraftingTime * time.Duration(quantity)
 	productionBonus := 1.0
 
 	// Skill bonus: Each skill level provides CraftingSkillBonusRate% bonus
-	skillBonus := float64(player.CraftingSkill) * m.config.CraftingSkillBonusRate
-	productionBonus += skillBonus
+	productionBonus += float64(player.CraftingSkill) * m.config.CraftingSkillBonusRate
+
+	// Researched technology bonus
+	productionBonus += m.techBonus(player.ID, "crafting_speed")
 
 	// Station bonus
-	if stationID != nil {
-		station, exists := m.stations[*stationID]
-		if exists && station.OwnerID == playerID {
-			productionBonus += station.ProductionBonus
-		}
+	if station != nil {
+		productionBonus += station.ProductionBonus
 	}
 
-	craftingTime := time.Duration(float64(baseTime) / productionBonus)
+	craftingTime := time.Duration(float64(baseTime) / (productionBonus * m.config.CraftingSpeedModifier))
 
-	// Create crafting job
-	job := &CraftingJob{
+	now := time.Now()
+	return &CraftingJob{
 		ID:             uuid.New(),
-		PlayerID:       playerID,
+		PlayerID:       player.ID,
 		BlueprintID:    blueprintID,
 		Blueprint:      blueprint,
-		StartTime:      time.Now(),
-		CompletionTime: time.Now().Add(craftingTime),
-		Status:         "in_progress",
+		StartTime:      now,
+		CompletionTime: now.Add(craftingTime),
+		Status:         models.CraftingInProgress,
 		Quantity:       quantity,
 		StationID:      stationID,
-	}
-
-	m.craftingJobs[job.ID] = job
-
-	log.Info("Crafting started: player=%s, item=%s, quantity=%d, time=%v",
-		playerID, blueprint.Name, quantity, craftingTime)
+	}, nil
+}
 
-	return job, nil
+// returnCargo puts crafting inputs back aboard a ship after a job that took
+// them could not be recorded
+func (m *Manager) returnCargo(ctx context.Context, shipID uuid.UUID, items map[string]int) {
+	if err := m.ships.AddCargoItems(ctx, shipID, items); err != nil {
+		log.Error("Failed to return %v to ship %s: %v", items, shipID, err)
+	}
 }
 
-// CancelCrafting cancels an in-progress crafting job
+// CancelCrafting cancels an in-progress crafting job and returns the unused
+// share of its inputs to the player's ship cargo
 func (m *Manager) CancelCrafting(ctx context.Context, jobID, playerID uuid.UUID) error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
 
 	job, exists := m.craftingJobs[jobID]
 	if !exists {
-		return fmt.Errorf("crafting job not found")
+		m.mu.Unlock()
+		return ErrJobNotFound
 	}
 
 	if job.PlayerID != playerID {
-		return fmt.Errorf("not your crafting job")
+		m.mu.Unlock()
+		return ErrNotYourJob
 	}
 
-	if job.Status != "in_progress" {
-		return fmt.Errorf("job is not in progress")
+	if job.Status != models.CraftingInProgress {
+		m.mu.Unlock()
+		return ErrJobNotInProgress
 	}
 
-	job.Status = "failed"
-
-	// Calculate partial resource refund based on time elapsed
+	// Refund percentage is inverse of progress (if 30% done, refund 70%)
 	now := time.Now()
-	timeElapsed := now.Sub(job.StartTime)
-	totalTime := job.CompletionTime.Sub(job.StartTime)
-
-	if totalTime > 0 {
-		progressPercent := float64(timeElapsed) / float64(totalTime)
-		if progressPercent > 1.0 {
-			progressPercent = 1.0
+	progressPercent := job.Progress(now)
+	refundPercent := 1.0 - progressPercent
+
+	// The cancellation is saved before anything is refunded, so a job that
+	// is still in progress in the store has had no refund
+	cancelled := *job
+	cancelled.Status = models.CraftingCancelled
+	cancelled.CompletionTime = now
+	if err := m.saveJob(&cancelled); err != nil {
+		m.mu.Unlock()
+		return err
+	}
+	*job = cancelled
+	m.mu.Unlock()
+
+	// Calculate refunded amounts for each resource
+	refundItems := make(map[string]int)
+	for resource, required := range m.requirements(job.Blueprint, job.Quantity) {
+		refundAmount := int(float64(required) * refundPercent)
+		if refundAmount > 0 {
+			refundItems[resource] = refundAmount
 		}
+	}
 
-		// Refund percentage is inverse of progress (if 30% done, refund 70%)
-		refundPercent := 1.0 - progressPercent
-
-		// Calculate and refund resources
-		if job.Blueprint != nil && len(job.Blueprint.Requirements) > 0 {
-			log.Info("Crafting cancelled: job=%s, progress=%.1f%%, refunding %.1f%% of resources",
-				jobID, progressPercent*100, refundPercent*100)
-
-			// Calculate refunded amounts for each resource
-			refundItems := make(map[string]int)
-			for resource, required := range job.Blueprint.Requirements {
-				totalRequired := required * job.Quantity
-				refundAmount := int(float64(totalRequired) * refundPercent)
-				if refundAmount > 0 {
-					refundItems[resource] = refundAmount
-					log.Debug("Refund: %s x%d (%.1f%% of %d)",
-						resource, refundAmount, refundPercent*100, totalRequired)
-				}
-			}
+	log.Info("Crafting cancelled: job=%s, progress=%.1f%%, refunding %v",
+		jobID, progressPercent*100, refundItems)
 
-			// Add refunded resources back to player's ship cargo
-			if len(refundItems) > 0 {
-				player, err := m.playerRepo.GetByID(context.Background(), playerID)
-				if err == nil {
-					ship, err := m.shipRepo.GetByID(context.Background(), player.ShipID)
-					if err == nil {
-						if err := m.addItemsToCargo(context.Background(), ship, refundItems); err != nil {
-							log.Error("Failed to refund resources to cargo: %v", err)
-						}
-					} else {
-						log.Error("Failed to fetch player ship for refund: %v", err)
-					}
-				} else {
-					log.Error("Failed to fetch player for refund: %v", err)
-				}
-			}
-		}
+	if len(refundItems) == 0 {
+		return nil
 	}
 
+	// Add refunded resources back to player's ship cargo
+	player, err := m.players.GetByID(ctx, playerID)
+	if err != nil {
+		return fmt.Errorf("job cancelled but failed to fetch player for refund: %w", err)
+	}
+	if player.ShipID == uuid.Nil {
+		return ErrNoShip
+	}
+	if err := m.ships.AddCargoItems(ctx, player.ShipID, refundItems); err != nil {
+		return fmt.Errorf("job cancelled but failed to refund inputs: %w", err)
+	}
+
+	m.mu.RLock()
+	m.inventoryChanged(playerID)
+	m.mu.RUnlock()
+
 	return nil
 }
 
-// GetCraftingJobs retrieves player's crafting jobs
+// GetCraftingJobs retrieves player's crafting jobs, soonest to finish first
 func (m *Manager) GetCraftingJobs(playerID uuid.UUID) []*CraftingJob {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -413,13 +527,17 @@ func (m *Manager) GetCraftingJobs(playerID uuid.UUID) []*CraftingJob {
 	var jobs []*CraftingJob
 	for _, job := range m.craftingJobs {
 		if job.PlayerID == playerID {
-			jobs = append(jobs, job)
+			jobCopy := *job
+			jobs = append(jobs, &jobCopy)
 		}
 	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CompletionTime.Before(jobs[j].CompletionTime)
+	})
 	return jobs
 }
 
-// GetBlueprints retrieves all available blueprints
+// GetBlueprints retrieves all available blueprints by tier, then name
 func (m *Manager) GetBlueprints() []*Blueprint {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -428,58 +546,61 @@ func (m *Manager) GetBlueprints() []*Blueprint {
 	for _, bp := range m.blueprints {
 		blueprints = append(blueprints, bp)
 	}
+	sort.Slice(blueprints, func(i, j int) bool {
+		if blueprints[i].Tier != blueprints[j].Tier {
+			return blueprints[i].Tier < blueprints[j].Tier
+		}
+		return blueprints[i].Name < blueprints[j].Name
+	})
 	return blueprints
 }
 
+// requirements returns a blueprint's inputs for a job of the given size
+func (m *Manager) requirements(blueprint *Blueprint, quantity int) map[string]int {
+	required := make(map[string]int, len(blueprint.Requirements))
+	for resource, qty := range blueprint.Requirements {
+		required[resource] = int(math.Ceil(float64(qty*quantity) * m.config.CraftingCostModifier))
+	}
+	return required
+}
+
 // ============================================================================
 // TECHNOLOGY SYSTEM
 // ============================================================================
 
-// ResearchTechnology initiates technology research
+// ResearchTechnology raises a technology one level, paying its research
+// point and credit cost
 func (m *Manager) ResearchTechnology(ctx context.Context, playerID uuid.UUID, techID string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	// Get technology
-	tech, exists := m.technologies[uuid.MustParse(techID)]
+	tech, exists := m.technologies[techID]
 	if !exists {
-		return fmt.Errorf("technology not found")
+		return ErrTechnologyNotFound
 	}
 
 	// Check prerequisites
 	if m.config.TechPrerequisiteStrict {
 		for _, prereqID := range tech.Prerequisites {
-			playerTechs := m.playerTech[playerID]
-			if playerTechs == nil || playerTechs[prereqID] == 0 {
-				return fmt.Errorf("missing prerequisite: %s", prereqID)
+			if m.playerTech[playerID][prereqID] == 0 {
+				return fmt.Errorf("missing prerequisite: %s", m.techName(prereqID))
 			}
 		}
 	}
 
 	// Get current level
-	playerTechs := m.playerTech[playerID]
-	if playerTechs == nil {
-		playerTechs = make(map[string]int)
-		m.playerTech[playerID] = playerTechs
-	}
-
-	currentLevel := playerTechs[techID]
+	currentLevel := m.playerTech[playerID][techID]
 	if currentLevel >= tech.MaxLevel {
 		return fmt.Errorf("technology already at max level")
 	}
 
-	// Calculate cost
-	costMultiplier := 1.0
-	for i := 0; i < currentLevel; i++ {
-		costMultiplier *= m.config.TechCostScaling
-	}
-	researchCost := int(float64(tech.ResearchCost) * costMultiplier)
-	creditCost := int64(float64(tech.CreditCost) * costMultiplier)
+	researchCost, creditCost := m.researchCost(tech, currentLevel)
 
 	// Get player and check resources
-	player, err := m.playerRepo.GetByID(ctx, playerID)
+	player, err := m.players.GetByID(ctx, playerID)
 	if err != nil {
-		return fmt.Errorf("failed to get player: %v", err)
+		return fmt.Errorf("failed to get player: %w", err)
 	}
 
 	// Check research points
@@ -493,14 +614,23 @@ func (m *Manager) ResearchTechnology(ctx context.Context, playerID uuid.UUID, te
 	}
 
 	// Deduct costs
-	player.ResearchPoints -= researchCost
-	player.Credits -= creditCost
-	if err := m.playerRepo.Update(ctx, player); err != nil {
-		return fmt.Errorf("failed to deduct costs: %v", err)
+	credits, err := m.players.SpendCreditsAndResearch(ctx, playerID, creditCost, researchCost)
+	if err != nil {
+		return fmt.Errorf("failed to deduct costs: %w", err)
 	}
 
 	// Research technology (instant for now, could be time-based)
-	playerTechs[techID]++
+	if err := m.saveTechnology(playerID, techID, currentLevel+1); err != nil {
+		m.refund(ctx, playerID, creditCost, researchCost)
+		return err
+	}
+	playerTechs := m.playerTech[playerID]
+	if playerTechs == nil {
+		playerTechs = make(map[string]int)
+		m.playerTech[playerID] = playerTechs
+	}
+	playerTechs[techID] = currentLevel + 1
+	m.creditsChanged(playerID, -creditCost, credits, "research "+tech.Name)
 
 	log.Info("Technology researched: player=%s, tech=%s, level=%d",
 		playerID, tech.Name, playerTechs[techID])
@@ -512,25 +642,64 @@ func (m *Manager) ResearchTechnology(ctx context.Context, playerID uuid.UUID, te
 	return nil
 }
 
-// GetPlayerTechnologies retrieves player's researched technologies
-func (m *Manager) GetPlayerTechnologies(playerID uuid.UUID) map[string]int {
+// ResearchCost returns the research points and credits needed to raise a
+// technology from the player's current level
+func (m *Manager) ResearchCost(playerID uuid.UUID, techID string) (int, int64) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	techs := m.playerTech[playerID]
-	if techs == nil {
-		return make(map[string]int)
+	tech, exists := m.technologies[techID]
+	if !exists {
+		return 0, 0
 	}
+	return m.researchCost(tech, m.playerTech[playerID][techID])
+}
+
+// researchCost scales a technology's base cost by its current level
+func (m *Manager) researchCost(tech *Technology, currentLevel int) (int, int64) {
+	costMultiplier := 1.0
+	for i := 0; i < currentLevel; i++ {
+		costMultiplier *= m.config.TechCostScaling
+	}
+	return int(float64(tech.ResearchCost) * costMultiplier), int64(float64(tech.CreditCost) * costMultiplier)
+}
+
+// techBonus sums a benefit over the player's researched technology levels
+// (must hold lock)
+func (m *Manager) techBonus(playerID uuid.UUID, benefit string) float64 {
+	bonus := 0.0
+	for techID, level := range m.playerTech[playerID] {
+		if tech, exists := m.technologies[techID]; exists {
+			bonus += tech.Benefits[benefit] * float64(level)
+		}
+	}
+	return bonus
+}
+
+// techName returns a technology's display name, or its ID if unknown
+// (must hold lock)
+func (m *Manager) techName(techID string) string {
+	if tech, exists := m.technologies[techID]; exists {
+		return tech.Name
+	}
+	return techID
+}
+
+// GetPlayerTechnologies retrieves player's researched technologies
+func (m *Manager) GetPlayerTechnologies(playerID uuid.UUID) map[string]int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 
 	// Return copy
 	result := make(map[string]int)
-	for k, v := range techs {
+	for k, v := range m.playerTech[playerID] {
 		result[k] = v
 	}
 	return result
 }
 
-// GetAllTechnologies retrieves all available technologies
+// GetAllTechnologies retrieves all available technologies by category,
+// then cost
 func (m *Manager) GetAllTechnologies() []*Technology {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -539,6 +708,12 @@ func (m *Manager) GetAllTechnologies() []*Technology {
 	for _, tech := range m.technologies {
 		techs = append(techs, tech)
 	}
+	sort.Slice(techs, func(i, j int) bool {
+		if techs[i].Category != techs[j].Category {
+			return techs[i].Category < techs[j].Category
+		}
+		return techs[i].ResearchCost < techs[j].ResearchCost
+	})
 	return techs
 }
 
@@ -563,24 +738,25 @@ func (m *Manager) BuildStation(ctx context.Context, playerID uuid.UUID, name str
 	}
 
 	// Check credits
-	player, err := m.playerRepo.GetByID(ctx, playerID)
+	player, err := m.players.GetByID(ctx, playerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get player: %v", err)
+		return nil, fmt.Errorf("failed to get player: %w", err)
 	}
 	if player.Credits < m.config.StationBuildCost {
 		return nil, fmt.Errorf("insufficient credits (need %d)", m.config.StationBuildCost)
 	}
 
 	// Deduct credits
-	player.Credits -= m.config.StationBuildCost
-	if err := m.playerRepo.Update(ctx, player); err != nil {
-		return nil, fmt.Errorf("failed to deduct credits: %v", err)
+	credits, err := m.spendCredits(ctx, playerID, m.config.StationBuildCost)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create station
 	station := &PlayerStation{
 		ID:              uuid.New(),
 		OwnerID:         playerID,
+		OwnerName:       player.Username,
 		Name:            name,
 		SystemID:        systemID,
 		SystemName:      systemName,
@@ -593,7 +769,12 @@ func (m *Manager) BuildStation(ctx context.Context, playerID uuid.UUID, name str
 		Status:          "active",
 	}
 
+	if err := m.saveStation(station); err != nil {
+		m.refund(ctx, playerID, m.config.StationBuildCost, 0)
+		return nil, err
+	}
 	m.stations[station.ID] = station
+	m.creditsChanged(playerID, -m.config.StationBuildCost, credits, "station construction")
 
 	log.Info("Station built: owner=%s, name=%s, system=%s", playerID, name, systemName)
 
@@ -611,11 +792,11 @@ func (m *Manager) UpgradeStation(ctx context.Context, stationID, playerID uuid.U
 
 	station, exists := m.stations[stationID]
 	if !exists {
-		return fmt.Errorf("station not found")
+		return ErrStationNotFound
 	}
 
 	if station.OwnerID != playerID {
-		return fmt.Errorf("not your station")
+		return ErrNotStationOwner
 	}
 
 	if station.Level >= 10 {
@@ -623,27 +804,34 @@ func (m *Manager) UpgradeStation(ctx context.Context, stationID, playerID uuid.U
 	}
 
 	// Calculate upgrade cost
-	upgradeCost := m.config.StationUpgradeCost * int64(station.Level)
+	upgradeCost := m.StationUpgradeCost(station.Level)
 
 	// Check credits
-	player, err := m.playerRepo.GetByID(ctx, playerID)
+	player, err := m.players.GetByID(ctx, playerID)
 	if err != nil {
-		return fmt.Errorf("failed to get player: %v", err)
+		return fmt.Errorf("failed to get player: %w", err)
 	}
 	if player.Credits < upgradeCost {
 		return fmt.Errorf("insufficient credits (need %d)", upgradeCost)
 	}
 
 	// Deduct credits
-	player.Credits -= upgradeCost
-	if err := m.playerRepo.Update(ctx, player); err != nil {
-		return fmt.Errorf("failed to deduct credits: %v", err)
+	credits, err := m.spendCredits(ctx, playerID, upgradeCost)
+	if err != nil {
+		return err
 	}
 
 	// Upgrade station
-	station.Level++
-	station.ProductionBonus += 0.05 // +5% per level
-	station.StorageCapacity += 1000 // +1000 per level
+	upgraded := copyStation(station)
+	upgraded.Level++
+	upgraded.ProductionBonus += 0.05 // +5% per level
+	upgraded.StorageCapacity += 1000 // +1000 per level
+	if err := m.saveStation(upgraded); err != nil {
+		m.refund(ctx, playerID, upgradeCost, 0)
+		return err
+	}
+	*station = *upgraded
+	m.creditsChanged(playerID, -upgradeCost, credits, "station upgrade")
 
 	log.Info("Station upgraded: station=%s, level=%d", stationID, station.Level)
 
@@ -654,8 +842,19 @@ func (m *Manager) UpgradeStation(ctx context.Context, stationID, playerID uuid.U
 	return nil
 }
 
-// getFacilityCost calculates the cost of adding a facility
-func (m *Manager) getFacilityCost(facility StationFacility, stationLevel int) int64 {
+// StationBuildCost returns the credits needed to build a station
+func (m *Manager) StationBuildCost() int64 {
+	return m.config.StationBuildCost
+}
+
+// StationUpgradeCost returns the credits needed to upgrade a station from
+// the given level
+func (m *Manager) StationUpgradeCost(level int) int64 {
+	return m.config.StationUpgradeCost * int64(level)
+}
+
+// FacilityCost calculates the cost of adding a facility
+func (m *Manager) FacilityCost(facility StationFacility, stationLevel int) int64 {
 	// Base facility cost is 40% of upgrade cost
 	baseCost := int64(float64(m.config.StationUpgradeCost) * 0.4)
 
@@ -689,25 +888,23 @@ func (m *Manager) AddFacility(ctx context.Context, stationID, playerID uuid.UUID
 
 	station, exists := m.stations[stationID]
 	if !exists {
-		return fmt.Errorf("station not found")
+		return ErrStationNotFound
 	}
 
 	if station.OwnerID != playerID {
-		return fmt.Errorf("not your station")
+		return ErrNotStationOwner
 	}
 
 	// Check if already has facility
-	for _, f := range station.Facilities {
-		if f == facility {
-			return fmt.Errorf("station already has this facility")
-		}
+	if station.HasFacility(facility) {
+		return fmt.Errorf("station already has this facility")
 	}
 
 	// Calculate cost and check requirements
-	cost := m.getFacilityCost(facility, station.Level)
+	cost := m.FacilityCost(facility, station.Level)
 
 	// Get player to check credits
-	player, err := m.playerRepo.GetByID(ctx, playerID)
+	player, err := m.players.GetByID(ctx, playerID)
 	if err != nil {
 		return fmt.Errorf("failed to get player: %w", err)
 	}
@@ -739,29 +936,56 @@ func (m *Manager) AddFacility(ctx context.Context, stationID, playerID uuid.UUID
 	}
 
 	// Deduct credits
-	newCredits := player.Credits - cost
-	if err := m.playerRepo.UpdateCredits(ctx, playerID, newCredits); err != nil {
-		return fmt.Errorf("failed to deduct credits: %w", err)
+	credits, err := m.spendCredits(ctx, playerID, cost)
+	if err != nil {
+		return err
 	}
 
 	// Add facility
-	station.Facilities = append(station.Facilities, facility)
+	equipped := copyStation(station)
+	equipped.Facilities = append(equipped.Facilities, facility)
 
 	// Apply facility benefits
 	switch facility {
 	case FacilityWarehouse:
-		station.StorageCapacity += m.config.StationStorageCapacity
+		equipped.StorageCapacity += m.config.StationStorageCapacity
 	case FacilityManufacturing:
-		station.ProductionBonus += 0.15 // +15% production speed
+		equipped.ProductionBonus += 0.15 // +15% production speed
 	case FacilityResearch:
-		station.ProductionBonus += 0.10 // +10% research speed
+		equipped.ProductionBonus += 0.10 // +10% research speed
+	}
+	if err := m.saveStation(equipped); err != nil {
+		m.refund(ctx, playerID, cost, 0)
+		return err
 	}
+	*station = *equipped
+	m.creditsChanged(playerID, -cost, credits, "station facility")
 
 	log.Info("Facility added: station=%s, facility=%s, cost=%d, level=%d",
 		stationID, facility, cost, station.Level)
 	return nil
 }
 
+// spendCredits charges a player for station work and returns their new
+// balance (must hold lock)
+func (m *Manager) spendCredits(ctx context.Context, playerID uuid.UUID, cost int64) (int64, error) {
+	credits, err := m.players.SpendCreditsAndResearch(ctx, playerID, cost, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to deduct credits: %w", err)
+	}
+	return credits, nil
+}
+
+// refund gives back what a player was charged for research or station work
+// that could not be saved. Spending a negative amount returns it in the
+// same transaction that charges do. (must hold lock)
+func (m *Manager) refund(ctx context.Context, playerID uuid.UUID, credits int64, researchPoints int) {
+	if _, err := m.players.SpendCreditsAndResearch(ctx, playerID, -credits, -researchPoints); err != nil {
+		log.Error("Failed to refund %d credits and %d research points to player %s: %v",
+			credits, researchPoints, playerID, err)
+	}
+}
+
 // GetPlayerStations retrieves all stations owned by a player
 func (m *Manager) GetPlayerStations(playerID uuid.UUID) []*PlayerStation {
 	m.mu.RLock()
@@ -770,12 +994,56 @@ func (m *Manager) GetPlayerStations(playerID uuid.UUID) []*PlayerStation {
 	var stations []*PlayerStation
 	for _, station := range m.stations {
 		if station.OwnerID == playerID {
-			stations = append(stations, station)
+			stations = append(stations, copyStation(station))
 		}
 	}
+	sortStations(stations)
 	return stations
 }
 
+// GetStationsInSystem retrieves the active stations players can dock at in
+// a system
+func (m *Manager) GetStationsInSystem(systemID uuid.UUID) []*PlayerStation {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var stations []*PlayerStation
+	for _, station := range m.stations {
+		if station.SystemID == systemID && station.Status == "active" {
+			stations = append(stations, copyStation(station))
+		}
+	}
+	sortStations(stations)
+	return stations
+}
+
+// GetStation retrieves a station by ID
+func (m *Manager) GetStation(stationID uuid.UUID) (*PlayerStation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	station, exists := m.stations[stationID]
+	if !exists {
+		return nil, ErrStationNotFound
+	}
+	return copyStation(station), nil
+}
+
+// copyStation returns a snapshot of a station safe to read without the lock
+// (must hold lock)
+func copyStation(station *PlayerStation) *PlayerStation {
+	stationCopy := *station
+	stationCopy.Facilities = append([]StationFacility(nil), station.Facilities...)
+	return &stationCopy
+}
+
+// sortStations orders stations by when they were built
+func sortStations(stations []*PlayerStation) {
+	sort.Slice(stations, func(i, j int) bool {
+		return stations[i].BuildTime.Before(stations[j].BuildTime)
+	})
+}
+
 // ============================================================================
 // BACKGROUND WORKERS
 // ============================================================================
@@ -797,105 +1065,278 @@ func (m *Manager) craftingWorker() {
 	}
 }
 
-// checkCraftingJobs checks for completed crafting jobs
+// checkCraftingJobs completes due crafting jobs and forgets finished ones
+// older than the retention period. A due job is saved as complete before its
+// output is delivered, without the lock, so a restart cannot deliver it a
+// second time. A job whose delivery fails goes back in progress and is
+// retried on the next pass; if that save fails too, only a restart before
+// the retry loses the output. Crafts that could not be recorded after a
+// delivery are retried on their own, without delivering the output again.
 func (m *Manager) checkCraftingJobs() {
+	now := time.Now()
+
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	unrecorded := make([]*CraftingJob, 0, len(m.unrecorded))
+	for _, job := range m.unrecorded {
+		unrecorded = append(unrecorded, job)
+	}
+	m.mu.Unlock()
 
-	now := time.Now()
+	for _, job := range unrecorded {
+		if err := m.recordCrafting(job); err != nil {
+			log.Error("Failed to record crafting job %s: %v", job.ID, err)
+			continue
+		}
+		m.mu.Lock()
+		delete(m.unrecorded, job.ID)
+		m.mu.Unlock()
+	}
 
+	m.mu.Lock()
+	var due []*CraftingJob
 	for _, job := range m.craftingJobs {
-		if job.Status == "in_progress" && now.After(job.CompletionTime) {
-			job.Status = "complete"
-			log.Info("Crafting completed: job=%s, item=%s", job.ID, job.Blueprint.Name)
-
-			// Update player progression
-			player, err := m.playerRepo.GetByID(context.Background(), job.PlayerID)
-			if err == nil {
-				player.TotalCrafts += job.Quantity
-
-				// Increase crafting skill (1 point per job, cap at 100)
-				if player.CraftingSkill < 100 {
-					player.CraftingSkill++
-					log.Info("Player %s crafting skill increased to %d", job.PlayerID, player.CraftingSkill)
-				}
-
-				// Save updated player
-				if err := m.playerRepo.Update(context.Background(), player); err != nil {
-					log.Error("Failed to update player crafting stats: %v", err)
-				}
-
-				// Add crafted items to player's ship cargo
-				if len(job.Blueprint.Produces) > 0 {
-					ship, err := m.shipRepo.GetByID(context.Background(), player.ShipID)
-					if err == nil {
-						// Calculate total produced items
-						producedItems := make(map[string]int)
-						for itemID, qty := range job.Blueprint.Produces {
-							producedItems[itemID] = qty * job.Quantity
-						}
-
-						// Add items to cargo
-						if err := m.addItemsToCargo(context.Background(), ship, producedItems); err != nil {
-							log.Error("Failed to add crafted items to cargo: %v", err)
-						}
-					} else {
-						log.Error("Failed to fetch player ship for crafting completion: %v", err)
-					}
-				}
-			} else {
-				log.Error("Failed to fetch player for crafting completion: %v", err)
+		switch {
+		case job.Status == models.CraftingInProgress && !now.Before(job.CompletionTime):
+			// Claim the job so it cannot be cancelled while being delivered
+			claimed := *job
+			claimed.Status = models.CraftingComplete
+			if err := m.saveJob(&claimed); err != nil {
+				log.Error("Failed to claim crafting job %s: %v", job.ID, err)
+				continue
 			}
+			job.Status = models.CraftingComplete
+			due = append(due, job)
+		case job.Status != models.CraftingInProgress && now.Sub(job.CompletionTime) > m.config.JobRetention:
+			if err := m.deleteJob(job); err != nil {
+				log.Error("Failed to forget crafting job %s: %v", job.ID, err)
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	for _, job := range due {
+		err := m.deliver(job)
+		var recordErr error
+		if err == nil {
+			recordErr = m.recordCrafting(job)
+		}
 
+		m.mu.Lock()
+		if err != nil {
+			log.Error("Failed to deliver crafting job %s: %v", job.ID, err)
+			job.Status = models.CraftingInProgress
+			if err := m.saveJob(job); err != nil {
+				log.Error("Failed to return crafting job %s to progress: %v", job.ID, err)
+			}
+		} else {
+			if recordErr != nil {
+				log.Error("Failed to record crafting job %s: %v", job.ID, recordErr)
+				m.unrecorded[job.ID] = job
+			}
+			log.Info("Crafting completed: job=%s, item=%s", job.ID, job.Blueprint.Name)
+			m.inventoryChanged(job.PlayerID)
 			if m.onCraftingComplete != nil {
-				go m.onCraftingComplete(job)
+				jobCopy := *job
+				go m.onCraftingComplete(&jobCopy)
 			}
 		}
+		m.mu.Unlock()
+	}
+}
+
+// deliver hands a completed job's output to its player, all of it or none
+func (m *Manager) deliver(job *CraftingJob) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	player, err := m.players.GetByID(ctx, job.PlayerID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch player: %w", err)
+	}
+
+	// Calculate total produced items
+	produced := make(map[string]int, len(job.Blueprint.Produces))
+	for itemID, qty := range job.Blueprint.Produces {
+		produced[itemID] = qty * job.Quantity
+	}
+
+	if job.Blueprint.ItemType == models.BlueprintCommodity {
+		if player.ShipID == uuid.Nil {
+			return ErrNoShip
+		}
+		if err := m.ships.AddCargoItems(ctx, player.ShipID, produced); err != nil {
+			return fmt.Errorf("failed to add crafted cargo: %w", err)
+		}
+	} else {
+		items, err := craftedItems(job, player, produced)
+		if err != nil {
+			return err
+		}
+		if err := m.items.CreateItems(ctx, items); err != nil {
+			return fmt.Errorf("failed to create crafted items: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// recordCrafting counts a delivered job's crafts towards its player's skill
+// and research points
+func (m *Manager) recordCrafting(job *CraftingJob) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	researchPoints := job.Blueprint.Tier * job.Quantity * m.config.ResearchPointsPerTier
+	if err := m.players.RecordCrafting(ctx, job.PlayerID, job.Quantity, researchPoints, m.config.MaxCraftingSkill); err != nil {
+		return fmt.Errorf("failed to update player crafting stats: %w", err)
+	}
+	return nil
+}
+
+// craftedItems builds the PlayerItems a weapon or outfit job produces.
+// Items crafted at a station are kept in its storage; others go aboard the
+// player's ship.
+func craftedItems(job *CraftingJob, player *models.Player, produced map[string]int) ([]*models.PlayerItem, error) {
+	itemType := models.ItemTypeOutfit
+	if job.Blueprint.ItemType == models.BlueprintWeapon {
+		itemType = models.ItemTypeWeapon
+	}
+
+	location := models.LocationShip
+	locationID := player.ShipID
+	if job.StationID != nil {
+		location = models.LocationStationStorage
+		locationID = *job.StationID
+	}
+	if locationID == uuid.Nil {
+		return nil, ErrNoShip
+	}
+
+	var items []*models.PlayerItem
+	for equipmentID, quantity := range produced {
+		for i := 0; i < quantity; i++ {
+			id := locationID
+			items = append(items, &models.PlayerItem{
+				PlayerID:    job.PlayerID,
+				ItemType:    itemType,
+				EquipmentID: equipmentID,
+				Location:    location,
+				LocationID:  &id,
+			})
+		}
 	}
+	return items, nil
 }
 
 // ============================================================================
 // INITIALIZATION
 // ============================================================================
 
-// initializeBlueprints sets up default blueprints
+// initializeBlueprints sets up default blueprints. Blueprint IDs are the
+// IDs of what they produce, and inputs are standard commodities.
 func (m *Manager) initializeBlueprints() {
-	// Example blueprints
 	blueprints := []*Blueprint{
 		{
-			ID:           uuid.New(),
-			Name:         "Basic Laser",
+			ID:           "ammunition",
+			Name:         "Ammunition",
+			Description:  "Press ore into standard munitions",
+			ItemType:     models.BlueprintCommodity,
+			Tier:         1,
+			CraftingTime: 2 * time.Minute,
+			Requirements: map[string]int{"ore": 2, "industrial_chemicals": 1},
+			Produces:     map[string]int{"ammunition": 5},
+		},
+		{
+			ID:           "power_cells",
+			Name:         "Power Cells",
+			Description:  "Charge crystal matrices into power cells",
+			ItemType:     models.BlueprintCommodity,
+			Tier:         1,
+			CraftingTime: 3 * time.Minute,
+			Requirements: map[string]int{"crystals": 1, "industrial_chemicals": 1},
+			Produces:     map[string]int{"power_cells": 2},
+		},
+		{
+			ID:           "pulse_laser",
+			Name:         "Pulse Laser",
 			Description:  "A simple laser weapon",
-			ItemType:     "weapon",
+			ItemType:     models.BlueprintWeapon,
 			Tier:         1,
 			CraftingTime: 5 * time.Minute,
-			Requirements: map[string]int{"iron": 10, "copper": 5},
-			Produces:     map[string]int{"basic_laser": 1},
+			Requirements: map[string]int{"ore": 10, "electronics": 4, "power_cells": 2},
+			Produces:     map[string]int{"pulse_laser": 1},
 			SkillLevel:   1,
 		},
 		{
-			ID:           uuid.New(),
-			Name:         "Shield Generator",
+			ID:           "hull_plating_mk1",
+			Name:         "Hull Plating Mk1",
+			Description:  "Bolt-on armor plates",
+			ItemType:     models.BlueprintOutfit,
+			Tier:         1,
+			CraftingTime: 8 * time.Minute,
+			Requirements: map[string]int{"ore": 15, "construction_materials": 5},
+			Produces:     map[string]int{"hull_plating_mk1": 1},
+			SkillLevel:   1,
+		},
+		{
+			ID:           "shield_booster_mk1",
+			Name:         "Shield Booster Mk1",
 			Description:  "Basic shield protection",
-			ItemType:     "outfit",
+			ItemType:     models.BlueprintOutfit,
 			Tier:         1,
 			CraftingTime: 10 * time.Minute,
-			Requirements: map[string]int{"titanium": 15, "crystals": 3},
-			Produces:     map[string]int{"shield_generator": 1},
+			Requirements: map[string]int{"electronics": 5, "crystals": 3, "power_cells": 2},
+			Produces:     map[string]int{"shield_booster_mk1": 1},
 			SkillLevel:   2,
 		},
 		{
-			ID:           uuid.New(),
-			Name:         "Advanced Thruster",
+			ID:           "beam_laser",
+			Name:         "Beam Laser",
+			Description:  "Continuous beam laser",
+			ItemType:     models.BlueprintWeapon,
+			Tier:         2,
+			CraftingTime: 20 * time.Minute,
+			Requirements: map[string]int{"precious_metals": 5, "electronics": 8, "power_cells": 4},
+			Produces:     map[string]int{"beam_laser": 1},
+			SkillLevel:   5,
+			TechRequired: "weapons_1",
+		},
+		{
+			ID:           "engine_upgrade_mk2",
+			Name:         "Engine Upgrade Mk2",
 			Description:  "High-performance engine component",
-			ItemType:     "ship_component",
+			ItemType:     models.BlueprintOutfit,
 			Tier:         2,
 			CraftingTime: 30 * time.Minute,
-			Requirements: map[string]int{"platinum": 20, "rare_earth": 5},
-			Produces:     map[string]int{"advanced_thruster": 1},
-			SkillLevel:   3,
+			Requirements: map[string]int{"machinery": 10, "precious_metals": 5, "power_cells": 5},
+			Produces:     map[string]int{"engine_upgrade_mk2": 1},
+			SkillLevel:   5,
 			TechRequired: "engines_2",
 		},
+		{
+			ID:           "railgun",
+			Name:         "Railgun",
+			Description:  "Magnetic accelerator cannon",
+			ItemType:     models.BlueprintWeapon,
+			Tier:         3,
+			CraftingTime: 60 * time.Minute,
+			Requirements: map[string]int{"precious_metals": 10, "machinery": 8, "computers": 4},
+			Produces:     map[string]int{"railgun": 1},
+			SkillLevel:   10,
+			TechRequired: "weapons_2",
+		},
+		{
+			ID:           "plasma_cannon",
+			Name:         "Plasma Cannon",
+			Description:  "Superheated plasma projector",
+			ItemType:     models.BlueprintWeapon,
+			Tier:         3,
+			CraftingTime: 60 * time.Minute,
+			Requirements: map[string]int{"radioactives": 6, "crystals": 6, "power_cells": 8},
+			Produces:     map[string]int{"plasma_cannon": 1},
+			SkillLevel:   10,
+			TechRequired: "weapons_2",
+		},
 	}
 
 	for _, bp := range blueprints {
@@ -905,72 +1346,71 @@ func (m *Manager) initializeBlueprints() {
 
 // initializeTechnologies sets up technology tree
 func (m *Manager) initializeTechnologies() {
-	// Example technologies
 	technologies := []*Technology{
 		{
-			ID:           "weapons_1",
-			Name:         "Basic Weapons",
-			Description:  "Unlock basic weapon crafting",
-			Category:     TechCategoryWeapons,
-			MaxLevel:     3,
-			ResearchCost: 100,
-			CreditCost:   10000,
+			ID:            "weapons_1",
+			Name:          "Basic Weapons",
+			Description:   "Unlock beam weapon crafting",
+			Category:      TechCategoryWeapons,
+			MaxLevel:      3,
+			ResearchCost:  100,
+			CreditCost:    10000,
 			Prerequisites: []string{},
-			Unlocks:      []string{"basic_laser", "basic_missile"},
-			Benefits:     map[string]float64{"weapon_damage": 0.10},
+			Unlocks:       []string{"beam_laser"},
+			Benefits:      map[string]float64{"weapon_damage": 0.10},
 		},
 		{
-			ID:           "weapons_2",
-			Name:         "Advanced Weapons",
-			Description:  "Unlock advanced weapon systems",
-			Category:     TechCategoryWeapons,
-			MaxLevel:     3,
-			ResearchCost: 200,
-			CreditCost:   25000,
+			ID:            "weapons_2",
+			Name:          "Advanced Weapons",
+			Description:   "Unlock advanced weapon systems",
+			Category:      TechCategoryWeapons,
+			MaxLevel:      3,
+			ResearchCost:  200,
+			CreditCost:    25000,
 			Prerequisites: []string{"weapons_1"},
-			Unlocks:      []string{"plasma_cannon", "railgun"},
-			Benefits:     map[string]float64{"weapon_damage": 0.20},
+			Unlocks:       []string{"plasma_cannon", "railgun"},
+			Benefits:      map[string]float64{"weapon_damage": 0.20},
 		},
 		{
-			ID:           "engines_1",
-			Name:         "Engine Technology",
-			Description:  "Improve ship engines",
-			Category:     TechCategoryEngines,
-			MaxLevel:     5,
-			ResearchCost: 150,
-			CreditCost:   15000,
+			ID:            "engines_1",
+			Name:          "Engine Technology",
+			Description:   "Improve ship engines",
+			Category:      TechCategoryEngines,
+			MaxLevel:      5,
+			ResearchCost:  150,
+			CreditCost:    15000,
 			Prerequisites: []string{},
-			Unlocks:      []string{"improved_thruster"},
-			Benefits:     map[string]float64{"engine_efficiency": 0.15},
+			Unlocks:       []string{},
+			Benefits:      map[string]float64{"engine_efficiency": 0.15},
 		},
 		{
-			ID:           "engines_2",
-			Name:         "Advanced Propulsion",
-			Description:  "High-performance engines",
-			Category:     TechCategoryEngines,
-			MaxLevel:     5,
-			ResearchCost: 300,
-			CreditCost:   40000,
+			ID:            "engines_2",
+			Name:          "Advanced Propulsion",
+			Description:   "High-performance engines",
+			Category:      TechCategoryEngines,
+			MaxLevel:      5,
+			ResearchCost:  300,
+			CreditCost:    40000,
 			Prerequisites: []string{"engines_1"},
-			Unlocks:      []string{"advanced_thruster"},
-			Benefits:     map[string]float64{"engine_efficiency": 0.25, "max_speed": 0.20},
+			Unlocks:       []string{"engine_upgrade_mk2"},
+			Benefits:      map[string]float64{"engine_efficiency": 0.25, "max_speed": 0.20},
 		},
 		{
-			ID:           "manufacturing_1",
-			Name:         "Industrial Processes",
-			Description:  "Improve manufacturing efficiency",
-			Category:     TechCategoryManufacturing,
-			MaxLevel:     5,
-			ResearchCost: 200,
-			CreditCost:   20000,
+			ID:            "manufacturing_1",
+			Name:          "Industrial Processes",
+			Description:   "Improve manufacturing efficiency",
+			Category:      TechCategoryManufacturing,
+			MaxLevel:      5,
+			ResearchCost:  200,
+			CreditCost:    20000,
 			Prerequisites: []string{},
-			Unlocks:      []string{},
-			Benefits:     map[string]float64{"crafting_speed": 0.20},
+			Unlocks:       []string{},
+			Benefits:      map[string]float64{"crafting_speed": 0.20},
 		},
 	}
 
 	for _, tech := range technologies {
-		m.technologies[uuid.MustParse(tech.ID)] = tech
+		m.technologies[tech.ID] = tech
 	}
 }
 
@@ -985,7 +1425,7 @@ func (m *Manager) GetStats() ManufacturingStats {
 	}
 
 	for _, job := range m.craftingJobs {
-		if job.Status == "in_progress" {
+		if job.Status == models.CraftingInProgress {
 			stats.ActiveCraftingJobs++
 		}
 	}
@@ -1021,74 +1461,9 @@ func (m *Manager) checkResources(ship *models.Ship, required map[string]int) err
 	}
 
 	if len(missing) > 0 {
+		sort.Strings(missing)
 		return fmt.Errorf("insufficient resources: %v", missing)
 	}
 
 	return nil
 }
-
-// deductResources removes resources from ship cargo and saves to database
-func (m *Manager) deductResources(ctx context.Context, ship *models.Ship, toDeduct map[string]int) error {
-	// Create a new cargo list with deducted quantities
-	newCargo := make([]models.CargoItem, 0, len(ship.Cargo))
-
-	for _, item := range ship.Cargo {
-		if deductQty, shouldDeduct := toDeduct[item.CommodityID]; shouldDeduct {
-			remaining := item.Quantity - deductQty
-			if remaining > 0 {
-				// Keep item with reduced quantity
-				newCargo = append(newCargo, models.CargoItem{
-					CommodityID: item.CommodityID,
-					Quantity:    remaining,
-				})
-			}
-			// If remaining <= 0, item is completely removed (not added to newCargo)
-		} else {
-			// Item not being deducted, keep as-is
-			newCargo = append(newCargo, item)
-		}
-	}
-
-	// Update ship cargo
-	ship.Cargo = newCargo
-
-	// Save to database
-	if err := m.shipRepo.Update(ctx, ship); err != nil {
-		return fmt.Errorf("failed to update ship cargo: %w", err)
-	}
-
-	log.Info("Deducted resources from ship %s: %v", ship.ID, toDeduct)
-	return nil
-}
-
-// addItemsToCargo adds produced items to ship cargo and saves to database
-func (m *Manager) addItemsToCargo(ctx context.Context, ship *models.Ship, items map[string]int) error {
-	// Add each produced item to cargo
-	for itemID, quantity := range items {
-		// Check if item already exists in cargo
-		found := false
-		for i := range ship.Cargo {
-			if ship.Cargo[i].CommodityID == itemID {
-				ship.Cargo[i].Quantity += quantity
-				found = true
-				break
-			}
-		}
-
-		// If not found, add new cargo item
-		if !found {
-			ship.Cargo = append(ship.Cargo, models.CargoItem{
-				CommodityID: itemID,
-				Quantity:    quantity,
-			})
-		}
-	}
-
-	// Save to database
-	if err := m.shipRepo.Update(ctx, ship); err != nil {
-		return fmt.Errorf("failed to update ship cargo: %w", err)
-	}
-
-	log.Info("Added crafted items to ship %s: %v", ship.ID, items)
-	return nil
-}
@@ -0,0 +1,495 @@
+// File: internal/manufacturing/manager_test.go
+// Project: Terminal Velocity
+// Description: Tests for manufacturing - crafting against cargo, delivery, cancellation, research and persistence
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package manufacturing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// memoryPlayers is an in-memory Players for tests
+type memoryPlayers struct {
+	players  map[uuid.UUID]*models.Player
+	failWith error // returned by RecordCrafting while set
+}
+
+func (p *memoryPlayers) GetByID(ctx context.Context, id uuid.UUID) (*models.Player, error) {
+	player, exists := p.players[id]
+	if !exists {
+		return nil, fmt.Errorf("player not found")
+	}
+	copied := *player
+	return &copied, nil
+}
+
+func (p *memoryPlayers) SpendCreditsAndResearch(ctx context.Context, id uuid.UUID, credits int64, researchPoints int) (int64, error) {
+	player := p.players[id]
+	if player.Credits < credits || player.ResearchPoints < researchPoints {
+		return 0, fmt.Errorf("insufficient funds")
+	}
+	player.Credits -= credits
+	player.ResearchPoints -= researchPoints
+	return player.Credits, nil
+}
+
+func (p *memoryPlayers) RecordCrafting(ctx context.Context, id uuid.UUID, crafts, researchPoints, maxSkill int) error {
+	if p.failWith != nil {
+		return p.failWith
+	}
+	player := p.players[id]
+	player.TotalCrafts += crafts
+	player.ResearchPoints += researchPoints
+	if player.CraftingSkill < maxSkill {
+		player.CraftingSkill++
+	}
+	return nil
+}
+
+// memoryShips is an in-memory Ships for tests
+type memoryShips struct {
+	ships    map[uuid.UUID]*models.Ship
+	failWith error // returned by AddCargoItems while set
+}
+
+func (s *memoryShips) GetByID(ctx context.Context, id uuid.UUID) (*models.Ship, error) {
+	ship, exists := s.ships[id]
+	if !exists {
+		return nil, fmt.Errorf("ship not found")
+	}
+	copied := *ship
+	copied.Cargo = append([]models.CargoItem(nil), ship.Cargo...)
+	return &copied, nil
+}
+
+func (s *memoryShips) ConsumeCargo(ctx context.Context, shipID uuid.UUID, items map[string]int) error {
+	ship := s.ships[shipID]
+	for commodityID, quantity := range items {
+		if ship.GetCommodityQuantity(commodityID) < quantity {
+			return fmt.Errorf("insufficient %s", commodityID)
+		}
+	}
+	for commodityID, quantity := range items {
+		ship.RemoveCargo(commodityID, quantity)
+	}
+	return nil
+}
+
+func (s *memoryShips) AddCargoItems(ctx context.Context, shipID uuid.UUID, items map[string]int) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	for commodityID, quantity := range items {
+		s.ships[shipID].AddCargo(commodityID, quantity)
+	}
+	return nil
+}
+
+// memoryItems is an in-memory Items for tests
+type memoryItems struct {
+	items []*models.PlayerItem
+}
+
+func (i *memoryItems) CreateItems(ctx context.Context, items []*models.PlayerItem) error {
+	i.items = append(i.items, items...)
+	return nil
+}
+
+// memoryStore is an in-memory Store for tests
+type memoryStore struct {
+	jobs         map[uuid.UUID]models.CraftingJob
+	stations     map[uuid.UUID]models.PlayerStation
+	technologies map[uuid.UUID]map[string]int
+	failWith     error // returned by every write while set
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		jobs:         make(map[uuid.UUID]models.CraftingJob),
+		stations:     make(map[uuid.UUID]models.PlayerStation),
+		technologies: make(map[uuid.UUID]map[string]int),
+	}
+}
+
+func (s *memoryStore) SaveJob(ctx context.Context, job *models.CraftingJob) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	saved := *job
+	saved.Blueprint = nil
+	s.jobs[job.ID] = saved
+	return nil
+}
+
+func (s *memoryStore) DeleteJob(ctx context.Context, jobID uuid.UUID) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	delete(s.jobs, jobID)
+	return nil
+}
+
+func (s *memoryStore) ListJobs(ctx context.Context) ([]*models.CraftingJob, error) {
+	var jobs []*models.CraftingJob
+	for _, job := range s.jobs {
+		job := job
+		jobs = append(jobs, &job)
+	}
+	return jobs, nil
+}
+
+func (s *memoryStore) SaveStation(ctx context.Context, station *models.PlayerStation) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	saved := *station
+	saved.Facilities = append([]models.StationFacility(nil), station.Facilities...)
+	s.stations[station.ID] = saved
+	return nil
+}
+
+func (s *memoryStore) ListStations(ctx context.Context) ([]*models.PlayerStation, error) {
+	var stations []*models.PlayerStation
+	for _, station := range s.stations {
+		station := station
+		stations = append(stations, &station)
+	}
+	return stations, nil
+}
+
+func (s *memoryStore) SaveTechnology(ctx context.Context, playerID uuid.UUID, techID string, level int) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	if s.technologies[playerID] == nil {
+		s.technologies[playerID] = make(map[string]int)
+	}
+	s.technologies[playerID][techID] = level
+	return nil
+}
+
+func (s *memoryStore) ListTechnologies(ctx context.Context) (map[uuid.UUID]map[string]int, error) {
+	return s.technologies, nil
+}
+
+// testWorld holds a manager with one player aboard one ship
+type testWorld struct {
+	manager *Manager
+	store   *memoryStore
+	players *memoryPlayers
+	ships   *memoryShips
+	items   *memoryItems
+	player  *models.Player
+	ship    *models.Ship
+}
+
+func newTestWorld(cargo map[string]int) *testWorld {
+	ship := &models.Ship{ID: uuid.New()}
+	for commodityID, quantity := range cargo {
+		ship.AddCargo(commodityID, quantity)
+	}
+	player := &models.Player{
+		ID:             uuid.New(),
+		Username:       "crafter",
+		Credits:        100000,
+		ShipID:         ship.ID,
+		CurrentSystem:  uuid.New(),
+		ResearchPoints: 100,
+	}
+
+	w := &testWorld{
+		store:   newMemoryStore(),
+		players: &memoryPlayers{players: map[uuid.UUID]*models.Player{player.ID: player}},
+		ships:   &memoryShips{ships: map[uuid.UUID]*models.Ship{ship.ID: ship}},
+		items:   &memoryItems{},
+		player:  player,
+		ship:    ship,
+	}
+	w.manager = NewManager(w.players, w.ships, w.items)
+	w.manager.SetStore(w.store)
+	return w
+}
+
+// finishJob moves a job's completion time into the past
+func (w *testWorld) finishJob(jobID uuid.UUID) {
+	w.manager.mu.Lock()
+	defer w.manager.mu.Unlock()
+	w.manager.craftingJobs[jobID].CompletionTime = time.Now().Add(-time.Second)
+}
+
+func TestCraftingConsumesCargoAndDeliversCommodities(t *testing.T) {
+	w := newTestWorld(map[string]int{"ore": 5, "industrial_chemicals": 2})
+
+	job, err := w.manager.StartCrafting(context.Background(), w.player.ID, "ammunition", 2, nil)
+	if err != nil {
+		t.Fatalf("StartCrafting failed: %v", err)
+	}
+	if got := w.ship.GetCommodityQuantity("ore"); got != 1 {
+		t.Errorf("ore after starting = %d, want 1", got)
+	}
+	if got := w.ship.GetCommodityQuantity("industrial_chemicals"); got != 0 {
+		t.Errorf("industrial_chemicals after starting = %d, want 0", got)
+	}
+	if _, saved := w.store.jobs[job.ID]; !saved {
+		t.Error("crafting job was not saved")
+	}
+
+	// Not due yet: nothing is delivered
+	w.manager.checkCraftingJobs()
+	if got := w.ship.GetCommodityQuantity("ammunition"); got != 0 {
+		t.Fatalf("ammunition before completion = %d, want 0", got)
+	}
+
+	w.finishJob(job.ID)
+	w.manager.checkCraftingJobs()
+
+	if got := w.ship.GetCommodityQuantity("ammunition"); got != 10 {
+		t.Errorf("ammunition after completion = %d, want 10", got)
+	}
+	if w.player.TotalCrafts != 2 || w.player.CraftingSkill != 1 {
+		t.Errorf("crafts = %d, skill = %d; want 2 and 1", w.player.TotalCrafts, w.player.CraftingSkill)
+	}
+	if status := w.store.jobs[job.ID].Status; status != models.CraftingComplete {
+		t.Errorf("stored job status = %q, want %q", status, models.CraftingComplete)
+	}
+}
+
+func TestStartCraftingRequiresCargo(t *testing.T) {
+	w := newTestWorld(map[string]int{"ore": 1, "industrial_chemicals": 1})
+
+	if _, err := w.manager.StartCrafting(context.Background(), w.player.ID, "ammunition", 1, nil); err == nil {
+		t.Fatal("StartCrafting succeeded without enough ore")
+	}
+	if got := w.ship.GetCommodityQuantity("industrial_chemicals"); got != 1 {
+		t.Errorf("industrial_chemicals after failed start = %d, want 1", got)
+	}
+	if len(w.manager.GetCraftingJobs(w.player.ID)) != 0 {
+		t.Error("failed start left a crafting job behind")
+	}
+}
+
+func TestFailedSavesUndoCrafting(t *testing.T) {
+	w := newTestWorld(map[string]int{"ore": 5, "industrial_chemicals": 2})
+	w.store.failWith = fmt.Errorf("database unavailable")
+
+	// An unsaved job gives its inputs back
+	if _, err := w.manager.StartCrafting(context.Background(), w.player.ID, "ammunition", 2, nil); err == nil {
+		t.Fatal("StartCrafting succeeded without saving the job")
+	}
+	if ore, chemicals := w.ship.GetCommodityQuantity("ore"), w.ship.GetCommodityQuantity("industrial_chemicals"); ore != 5 || chemicals != 2 {
+		t.Errorf("cargo after an unsaved start = %d ore, %d industrial_chemicals; want 5 and 2", ore, chemicals)
+	}
+	if len(w.manager.GetCraftingJobs(w.player.ID)) != 0 {
+		t.Error("unsaved start left a crafting job behind")
+	}
+
+	// Unsaved research is refunded
+	if err := w.manager.ResearchTechnology(context.Background(), w.player.ID, "weapons_1"); err == nil {
+		t.Error("ResearchTechnology succeeded without saving the level")
+	}
+	if w.player.Credits != 100000 || w.player.ResearchPoints != 100 {
+		t.Errorf("player has %d CR and %d RP after unsaved research, want 100000 and 100", w.player.Credits, w.player.ResearchPoints)
+	}
+
+	// A job is only delivered once its completion is saved
+	w.store.failWith = nil
+	job, err := w.manager.StartCrafting(context.Background(), w.player.ID, "ammunition", 2, nil)
+	if err != nil {
+		t.Fatalf("StartCrafting failed: %v", err)
+	}
+	w.finishJob(job.ID)
+	w.store.failWith = fmt.Errorf("database unavailable")
+	w.manager.checkCraftingJobs()
+	if got := w.ship.GetCommodityQuantity("ammunition"); got != 0 {
+		t.Fatalf("ammunition delivered without saving the job = %d, want 0", got)
+	}
+
+	w.store.failWith = nil
+	w.manager.checkCraftingJobs()
+	w.manager.checkCraftingJobs()
+	if got := w.ship.GetCommodityQuantity("ammunition"); got != 10 {
+		t.Errorf("ammunition after retrying = %d, want 10", got)
+	}
+	if status := w.store.jobs[job.ID].Status; status != models.CraftingComplete {
+		t.Errorf("stored job status = %q, want %q", status, models.CraftingComplete)
+	}
+}
+
+func TestFailedDeliveryIsRetriedOnce(t *testing.T) {
+	w := newTestWorld(map[string]int{"ore": 5, "industrial_chemicals": 2})
+
+	job, err := w.manager.StartCrafting(context.Background(), w.player.ID, "ammunition", 2, nil)
+	if err != nil {
+		t.Fatalf("StartCrafting failed: %v", err)
+	}
+	w.finishJob(job.ID)
+
+	// Undelivered output sends the job back in progress
+	w.ships.failWith = fmt.Errorf("database unavailable")
+	w.manager.checkCraftingJobs()
+	if got := w.ship.GetCommodityQuantity("ammunition"); got != 0 {
+		t.Fatalf("ammunition after a failed delivery = %d, want 0", got)
+	}
+	if status := w.store.jobs[job.ID].Status; status != models.CraftingInProgress {
+		t.Fatalf("stored job status = %q, want %q", status, models.CraftingInProgress)
+	}
+
+	// Crafts that cannot be recorded are retried without redelivering
+	w.ships.failWith = nil
+	w.players.failWith = fmt.Errorf("database unavailable")
+	w.manager.checkCraftingJobs()
+	if got := w.ship.GetCommodityQuantity("ammunition"); got != 10 {
+		t.Fatalf("ammunition after delivery = %d, want 10", got)
+	}
+	if w.player.TotalCrafts != 0 {
+		t.Fatalf("crafts recorded while failing = %d, want 0", w.player.TotalCrafts)
+	}
+
+	w.players.failWith = nil
+	w.manager.checkCraftingJobs()
+	w.manager.checkCraftingJobs()
+	if got := w.ship.GetCommodityQuantity("ammunition"); got != 10 {
+		t.Errorf("ammunition after retrying = %d, want 10", got)
+	}
+	if w.player.TotalCrafts != 2 || w.player.CraftingSkill != 1 {
+		t.Errorf("crafts = %d, skill = %d; want 2 and 1", w.player.TotalCrafts, w.player.CraftingSkill)
+	}
+}
+
+func TestCraftingWeaponCreatesItems(t *testing.T) {
+	w := newTestWorld(map[string]int{"ore": 10, "electronics": 4, "power_cells": 2})
+	w.player.CraftingSkill = 1
+
+	job, err := w.manager.StartCrafting(context.Background(), w.player.ID, "pulse_laser", 1, nil)
+	if err != nil {
+		t.Fatalf("StartCrafting failed: %v", err)
+	}
+	w.finishJob(job.ID)
+	w.manager.checkCraftingJobs()
+
+	if len(w.items.items) != 1 {
+		t.Fatalf("created %d items, want 1", len(w.items.items))
+	}
+	item := w.items.items[0]
+	if item.ItemType != models.ItemTypeWeapon || item.EquipmentID != "pulse_laser" {
+		t.Errorf("created %s %s, want weapon pulse_laser", item.ItemType, item.EquipmentID)
+	}
+	if item.Location != models.LocationShip || item.LocationID == nil || *item.LocationID != w.ship.ID {
+		t.Errorf("item location = %s, want aboard ship %s", item.Location, w.ship.ID)
+	}
+}
+
+func TestCancelCraftingRefundsUnusedInputs(t *testing.T) {
+	w := newTestWorld(map[string]int{"ore": 4, "industrial_chemicals": 2})
+
+	job, err := w.manager.StartCrafting(context.Background(), w.player.ID, "ammunition", 2, nil)
+	if err != nil {
+		t.Fatalf("StartCrafting failed: %v", err)
+	}
+
+	// Put the job just short of halfway, so half the inputs come back
+	w.manager.mu.Lock()
+	w.manager.craftingJobs[job.ID].StartTime = time.Now().Add(-59 * time.Minute)
+	w.manager.craftingJobs[job.ID].CompletionTime = time.Now().Add(61 * time.Minute)
+	w.manager.mu.Unlock()
+
+	if err := w.manager.CancelCrafting(context.Background(), job.ID, w.player.ID); err != nil {
+		t.Fatalf("CancelCrafting failed: %v", err)
+	}
+	if got := w.ship.GetCommodityQuantity("ore"); got != 2 {
+		t.Errorf("ore after cancelling = %d, want 2", got)
+	}
+	if got := w.ship.GetCommodityQuantity("industrial_chemicals"); got != 1 {
+		t.Errorf("industrial_chemicals after cancelling = %d, want 1", got)
+	}
+	if err := w.manager.CancelCrafting(context.Background(), job.ID, w.player.ID); err != ErrJobNotInProgress {
+		t.Errorf("second cancel error = %v, want %v", err, ErrJobNotInProgress)
+	}
+}
+
+func TestResearchTechnologyChargesPlayer(t *testing.T) {
+	w := newTestWorld(nil)
+
+	charged := make(chan int64, 1)
+	w.manager.SetCreditsChangedCallback(func(playerID uuid.UUID, oldCredits, newCredits int64, reason string) {
+		charged <- oldCredits - newCredits
+	})
+
+	points, credits := w.manager.ResearchCost(w.player.ID, "weapons_1")
+	if err := w.manager.ResearchTechnology(context.Background(), w.player.ID, "weapons_1"); err != nil {
+		t.Fatalf("ResearchTechnology failed: %v", err)
+	}
+
+	if w.player.ResearchPoints != 100-points || w.player.Credits != 100000-credits {
+		t.Errorf("player has %d RP and %d CR after research", w.player.ResearchPoints, w.player.Credits)
+	}
+	select {
+	case got := <-charged:
+		if got != credits {
+			t.Errorf("credits callback reported %d, want %d", got, credits)
+		}
+	case <-time.After(time.Second):
+		t.Error("credits callback was not called")
+	}
+	if level := w.manager.GetPlayerTechnologies(w.player.ID)["weapons_1"]; level != 1 {
+		t.Errorf("weapons_1 level = %d, want 1", level)
+	}
+
+	// Out of research points: the second level is refused
+	if err := w.manager.ResearchTechnology(context.Background(), w.player.ID, "weapons_1"); err == nil {
+		t.Error("research succeeded without enough research points")
+	}
+}
+
+func TestLoadRestoresManufacturing(t *testing.T) {
+	w := newTestWorld(map[string]int{"ore": 2, "industrial_chemicals": 1})
+	w.player.Credits = 2000000
+
+	job, err := w.manager.StartCrafting(context.Background(), w.player.ID, "ammunition", 1, nil)
+	if err != nil {
+		t.Fatalf("StartCrafting failed: %v", err)
+	}
+	station, err := w.manager.BuildStation(context.Background(), w.player.ID, "Forge", w.player.CurrentSystem, "Sol")
+	if err != nil {
+		t.Fatalf("BuildStation failed: %v", err)
+	}
+	if err := w.manager.ResearchTechnology(context.Background(), w.player.ID, "weapons_1"); err != nil {
+		t.Fatalf("ResearchTechnology failed: %v", err)
+	}
+
+	// A restarted server picks up where the old one stopped
+	restarted := NewManager(w.players, w.ships, w.items)
+	restarted.SetStore(w.store)
+	if err := restarted.Load(context.Background()); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	jobs := restarted.GetCraftingJobs(w.player.ID)
+	if len(jobs) != 1 || jobs[0].ID != job.ID || jobs[0].Blueprint == nil {
+		t.Fatalf("restored jobs = %+v, want job %s with its blueprint", jobs, job.ID)
+	}
+	if stations := restarted.GetStationsInSystem(w.player.CurrentSystem); len(stations) != 1 || stations[0].ID != station.ID {
+		t.Errorf("restored stations in system = %+v, want %s", stations, station.Name)
+	}
+	if level := restarted.GetPlayerTechnologies(w.player.ID)["weapons_1"]; level != 1 {
+		t.Errorf("restored weapons_1 level = %d, want 1", level)
+	}
+
+	// The job completes on the restarted server
+	restarted.mu.Lock()
+	restarted.craftingJobs[job.ID].CompletionTime = time.Now().Add(-time.Second)
+	restarted.mu.Unlock()
+	restarted.checkCraftingJobs()
+	if got := w.ship.GetCommodityQuantity("ammunition"); got != 5 {
+		t.Errorf("ammunition after restart = %d, want 5", got)
+	}
+}
@@ -0,0 +1,178 @@
+// File: internal/manufacturing/store.go
+// Project: Terminal Velocity
+// Description: Persistence and player inventory interfaces for manufacturing
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package manufacturing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// dbTimeout bounds each write to the manufacturing store
+const dbTimeout = 5 * time.Second
+
+// Store persists crafting jobs, stations and researched technologies so
+// they survive restarts. The database package's ManufacturingRepository
+// implements it.
+type Store interface {
+	SaveJob(ctx context.Context, job *models.CraftingJob) error
+	DeleteJob(ctx context.Context, jobID uuid.UUID) error
+	ListJobs(ctx context.Context) ([]*models.CraftingJob, error)
+
+	SaveStation(ctx context.Context, station *models.PlayerStation) error
+	ListStations(ctx context.Context) ([]*models.PlayerStation, error)
+
+	SaveTechnology(ctx context.Context, playerID uuid.UUID, techID string, level int) error
+	ListTechnologies(ctx context.Context) (map[uuid.UUID]map[string]int, error)
+}
+
+// Players reads players and charges them for manufacturing. The database
+// package's PlayerRepository implements it.
+type Players interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Player, error)
+
+	// SpendCreditsAndResearch atomically deducts credits and research
+	// points, returning the new credit balance. Negative amounts are added
+	// back, which is how a charge for unsaved work is refunded.
+	SpendCreditsAndResearch(ctx context.Context, id uuid.UUID, credits int64, researchPoints int) (int64, error)
+
+	// RecordCrafting adds completed crafts and earned research points and
+	// raises the crafting skill by one, up to maxSkill
+	RecordCrafting(ctx context.Context, id uuid.UUID, crafts, researchPoints, maxSkill int) error
+}
+
+// Ships takes crafting inputs from and adds outputs to ship cargo. The
+// database package's ShipRepository implements it.
+type Ships interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Ship, error)
+
+	// ConsumeCargo removes every given commodity quantity or none of them
+	ConsumeCargo(ctx context.Context, shipID uuid.UUID, items map[string]int) error
+
+	// AddCargoItems adds every given commodity quantity or none of them
+	AddCargoItems(ctx context.Context, shipID uuid.UUID, items map[string]int) error
+}
+
+// Items creates the weapon and outfit items that crafting produces. The
+// database package's ItemRepository implements it.
+type Items interface {
+	CreateItems(ctx context.Context, items []*models.PlayerItem) error
+}
+
+// SetStore sets where manufacturing state is persisted. Without a store the
+// manager keeps jobs, stations and technologies in memory.
+func (m *Manager) SetStore(store Store) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store = store
+}
+
+// Load replaces the in-memory jobs, stations and technologies with the
+// store's. Jobs that finished while the server was down complete on the
+// worker's first pass.
+func (m *Manager) Load(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.store == nil {
+		return nil
+	}
+
+	jobs, err := m.store.ListJobs(ctx)
+	if err != nil {
+		return err
+	}
+	stations, err := m.store.ListStations(ctx)
+	if err != nil {
+		return err
+	}
+	playerTech, err := m.store.ListTechnologies(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.craftingJobs = make(map[uuid.UUID]*CraftingJob, len(jobs))
+	for _, job := range jobs {
+		blueprint, exists := m.blueprints[job.BlueprintID]
+		if !exists {
+			log.Warn("Skipping crafting job %s: unknown blueprint %s", job.ID, job.BlueprintID)
+			continue
+		}
+		job.Blueprint = blueprint
+		m.craftingJobs[job.ID] = job
+	}
+	m.stations = make(map[uuid.UUID]*PlayerStation, len(stations))
+	for _, station := range stations {
+		if station.Storage == nil {
+			station.Storage = make(map[string]int)
+		}
+		m.stations[station.ID] = station
+	}
+	m.playerTech = playerTech
+	if m.playerTech == nil {
+		m.playerTech = make(map[uuid.UUID]map[string]int)
+	}
+
+	log.Info("Loaded manufacturing: jobs=%d, stations=%d, researching players=%d",
+		len(m.craftingJobs), len(stations), len(playerTech))
+	return nil
+}
+
+// persist runs a store write with a timeout. The error is returned so the
+// caller can keep memory as the store has it, or undo the charge the write
+// was recording. Caller must hold m.mu.
+func (m *Manager) persist(what string, write func(ctx context.Context, store Store) error) error {
+	if m.store == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	if err := write(ctx, m.store); err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+	return nil
+}
+
+// saveJob writes a crafting job to the store. Caller must hold m.mu.
+func (m *Manager) saveJob(job *CraftingJob) error {
+	return m.persist("crafting job "+job.ID.String(), func(ctx context.Context, store Store) error {
+		return store.SaveJob(ctx, job)
+	})
+}
+
+// deleteJob removes a finished job from the store and then from memory.
+// Caller must hold m.mu.
+func (m *Manager) deleteJob(job *CraftingJob) error {
+	err := m.persist("crafting job "+job.ID.String(), func(ctx context.Context, store Store) error {
+		return store.DeleteJob(ctx, job.ID)
+	})
+	if err != nil {
+		return err
+	}
+	delete(m.craftingJobs, job.ID)
+	return nil
+}
+
+// saveStation writes a station to the store. Caller must hold m.mu.
+func (m *Manager) saveStation(station *PlayerStation) error {
+	return m.persist("station "+station.Name, func(ctx context.Context, store Store) error {
+		return store.SaveStation(ctx, station)
+	})
+}
+
+// saveTechnology writes a player's technology level to the store. Caller
+// must hold m.mu.
+func (m *Manager) saveTechnology(playerID uuid.UUID, techID string, level int) error {
+	return m.persist("technology "+techID, func(ctx context.Context, store Store) error {
+		return store.SaveTechnology(ctx, playerID, techID, level)
+	})
+}
@@ -1,7 +1,7 @@
 // File: internal/marketplace/manager.go
 // Project: Terminal Velocity
 // Description: Player marketplace manager for auctions, contracts, and bounties
-// Version: 1.0.0
+// Version: 1.1.0
 // Author: Claude Code
 // Created: 2025-11-15
 
@@ -9,18 +9,26 @@ package marketplace
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	"github.com/google/uuid"
 )
 
 var log = logger.WithComponent("Marketplace")
 
-// Manager handles all marketplace operations including auctions, contracts, and bounties
+// Manager handles all marketplace operations including auctions, contracts, and bounties.
+//
+// Listings are cached in memory for browsing and persisted by the
+// MarketplaceRepository. Every operation that moves credits or items is a
+// single repository transaction; the in-memory listing is updated only after
+// it commits. Load restores open listings on startup and immediately settles
+// anything that expired while the server was down.
 type Manager struct {
 	mu sync.RWMutex
 
@@ -32,14 +40,14 @@ type Manager struct {
 	// Configuration
 	config MarketplaceConfig
 
-	// Repositories
-	playerRepo *database.PlayerRepository
-	shipRepo   *database.ShipRepository
+	// Persistence
+	repo *database.MarketplaceRepository
 
 	// Callbacks
 	onAuctionComplete func(auction *Auction)
 	onContractClaimed func(contract *Contract)
 	onBountyClaimed   func(bounty *Bounty)
+	onCreditsChanged  func(playerID uuid.UUID, oldCredits, newCredits int64, reason string)
 
 	// Background workers
 	stopChan chan struct{}
@@ -89,19 +97,54 @@ func DefaultMarketplaceConfig() MarketplaceConfig {
 	}
 }
 
-// NewManager creates a new marketplace manager
-func NewManager(playerRepo *database.PlayerRepository, shipRepo *database.ShipRepository) *Manager {
+// NewManager creates a new marketplace manager.
+// Call Load before Start to restore listings from the database.
+func NewManager(repo *database.MarketplaceRepository) *Manager {
 	return &Manager{
 		auctions:  make(map[uuid.UUID]*Auction),
 		contracts: make(map[uuid.UUID]*Contract),
 		bounties:  make(map[uuid.UUID]*Bounty),
 		config:    DefaultMarketplaceConfig(),
-		playerRepo: playerRepo,
-		shipRepo:   shipRepo,
+		repo:      repo,
 		stopChan:  make(chan struct{}),
 	}
 }
 
+// Load restores open auctions, contracts and bounties from the database,
+// then settles auctions and refunds contracts and bounties that expired
+// while the server was down.
+func (m *Manager) Load(ctx context.Context) error {
+	auctions, err := m.repo.GetOpenAuctions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load auctions: %w", err)
+	}
+	contracts, err := m.repo.GetOpenContracts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load contracts: %w", err)
+	}
+	bounties, err := m.repo.GetActiveBounties(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load bounties: %w", err)
+	}
+
+	m.mu.Lock()
+	for _, auction := range auctions {
+		m.auctions[auction.ID] = auction
+	}
+	for _, contract := range contracts {
+		m.contracts[contract.ID] = contract
+	}
+	for _, bounty := range bounties {
+		m.bounties[bounty.ID] = bounty
+	}
+	m.mu.Unlock()
+
+	log.Info("Loaded %d auctions, %d contracts, %d bounties", len(auctions), len(contracts), len(bounties))
+
+	m.processExpiries()
+	return nil
+}
+
 // Start begins background workers for marketplace
 func (m *Manager) Start() {
 	m.wg.Add(1)
@@ -131,48 +174,57 @@ func (m *Manager) SetBountyClaimedCallback(callback func(bounty *Bounty)) {
 	m.onBountyClaimed = callback
 }
 
+// SetCreditsChangedCallback sets callback for any player balance change made
+// by the marketplace (bids, refunds, payouts, deposits)
+func (m *Manager) SetCreditsChangedCallback(callback func(playerID uuid.UUID, oldCredits, newCredits int64, reason string)) {
+	m.onCreditsChanged = callback
+}
+
+// creditsChanged reports committed balance changes to the credits callback
+func (m *Manager) creditsChanged(changes ...database.CreditChange) {
+	if m.onCreditsChanged == nil {
+		return
+	}
+	for _, c := range changes {
+		go m.onCreditsChanged(c.PlayerID, c.NewCredits-c.Delta, c.NewCredits, c.Reason)
+	}
+}
+
+// listingError converts repository errors to the messages shown to players
+func listingError(err error, action string) error {
+	switch {
+	case errors.Is(err, database.ErrInsufficientCredits):
+		return fmt.Errorf("insufficient credits")
+	case errors.Is(err, database.ErrListingClosed):
+		return fmt.Errorf("listing is no longer available")
+	case errors.Is(err, database.ErrBidTooLow):
+		return fmt.Errorf("bid is too low")
+	case errors.Is(err, database.ErrItemNotAvailable):
+		return fmt.Errorf("item is not available")
+	default:
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+}
+
 // ============================================================================
 // AUCTION SYSTEM
 // ============================================================================
 
 // AuctionType represents the type of item being auctioned
-type AuctionType string
+type AuctionType = models.AuctionType
 
 const (
-	AuctionTypeShip      AuctionType = "ship"
-	AuctionTypeOutfit    AuctionType = "outfit"
-	AuctionTypeCommodity AuctionType = "commodity"
-	AuctionTypeSpecial   AuctionType = "special" // Rare items, blueprints, etc.
+	AuctionTypeShip      = models.AuctionTypeShip
+	AuctionTypeOutfit    = models.AuctionTypeOutfit
+	AuctionTypeCommodity = models.AuctionTypeCommodity
+	AuctionTypeSpecial   = models.AuctionTypeSpecial
 )
 
-// Auction represents an active auction listing
-type Auction struct {
-	ID          uuid.UUID
-	SellerID    uuid.UUID
-	SellerName  string
-	Type        AuctionType
-	ItemID      uuid.UUID // Ship ID, outfit ID, etc.
-	ItemName    string
-	Quantity    int       // For commodities/stackable items
-	Description string
-	StartingBid int64
-	BuyoutPrice int64     // Instant purchase price (optional)
-	CurrentBid  int64
-	HighBidder  uuid.UUID // Player who has current high bid
-	HighBidderName string
-	StartTime   time.Time
-	EndTime     time.Time
-	Status      string    // "active", "sold", "expired", "cancelled"
-	BidHistory  []Bid
-}
+// Auction represents an auction listing
+type Auction = models.MarketAuction
 
 // Bid represents a bid on an auction
-type Bid struct {
-	BidderID   uuid.UUID
-	BidderName string
-	Amount     int64
-	Timestamp  time.Time
-}
+type Bid = models.MarketBid
 
 // CreateAuction creates a new auction listing
 func (m *Manager) CreateAuction(ctx context.Context, sellerID uuid.UUID, sellerName string, auctionType AuctionType, itemID uuid.UUID, itemName string, quantity int, description string, startingBid int64, duration time.Duration, buyoutPrice int64) (*Auction, error) {
@@ -207,6 +259,10 @@ func (m *Manager) CreateAuction(ctx context.Context, sellerID uuid.UUID, sellerN
 		BidHistory:  []Bid{},
 	}
 
+	if err := m.repo.CreateAuction(ctx, auction); err != nil {
+		return nil, listingError(err, "create auction")
+	}
+
 	m.mu.Lock()
 	m.auctions[auction.ID] = auction
 	m.mu.Unlock()
@@ -247,47 +303,31 @@ func (m *Manager) PlaceBid(ctx context.Context, auctionID uuid.UUID, bidderID uu
 		return fmt.Errorf("bid must be at least %d credits", minBid)
 	}
 
-	// Check if bidder has sufficient credits
-	bidder, err := m.playerRepo.GetByID(ctx, bidderID)
-	if err != nil {
-		return fmt.Errorf("failed to get bidder: %v", err)
-	}
-	if bidder.Credits < amount {
-		return fmt.Errorf("insufficient credits")
-	}
-
-	// Refund previous high bidder
-	if auction.HighBidder != uuid.Nil {
-		previousBidder, err := m.playerRepo.GetByID(ctx, auction.HighBidder)
-		if err == nil {
-			previousBidder.Credits += auction.CurrentBid
-			_ = m.playerRepo.Update(ctx, previousBidder)
-		}
+	// Take the bid and refund the previous high bidder in one transaction
+	bid := Bid{
+		BidderID:   bidderID,
+		BidderName: bidderName,
+		Amount:     amount,
+		Timestamp:  time.Now(),
 	}
-
-	// Deduct credits from new bidder
-	bidder.Credits -= amount
-	if err := m.playerRepo.Update(ctx, bidder); err != nil {
-		return fmt.Errorf("failed to deduct credits: %v", err)
+	changes, err := m.repo.PlaceBid(ctx, auctionID, bid, minBid)
+	if err != nil {
+		return listingError(err, "place bid")
 	}
+	m.creditsChanged(changes...)
 
 	// Update auction
 	auction.CurrentBid = amount
 	auction.HighBidder = bidderID
 	auction.HighBidderName = bidderName
-	auction.BidHistory = append(auction.BidHistory, Bid{
-		BidderID:   bidderID,
-		BidderName: bidderName,
-		Amount:     amount,
-		Timestamp:  time.Now(),
-	})
+	auction.BidHistory = append(auction.BidHistory, bid)
 
 	log.Info("Bid placed: auction=%s, bidder=%s, amount=%d", auction.ItemName, bidderName, amount)
 	return nil
 }
 
 // Buyout instantly purchases an auction at buyout price
-func (m *Manager) Buyout(ctx context.Context, auctionID uuid.UUID, buyerID uuid.UUID) error {
+func (m *Manager) Buyout(ctx context.Context, auctionID uuid.UUID, buyerID uuid.UUID, buyerName string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -304,44 +344,26 @@ func (m *Manager) Buyout(ctx context.Context, auctionID uuid.UUID, buyerID uuid.
 		return fmt.Errorf("auction does not have buyout option")
 	}
 
-	// Check buyer has sufficient credits
-	buyer, err := m.playerRepo.GetByID(ctx, buyerID)
-	if err != nil {
-		return fmt.Errorf("failed to get buyer: %v", err)
-	}
-	if buyer.Credits < auction.BuyoutPrice {
-		return fmt.Errorf("insufficient credits for buyout")
-	}
-
-	// Refund previous high bidder if any
-	if auction.HighBidder != uuid.Nil {
-		previousBidder, err := m.playerRepo.GetByID(ctx, auction.HighBidder)
-		if err == nil {
-			previousBidder.Credits += auction.CurrentBid
-			_ = m.playerRepo.Update(ctx, previousBidder)
-		}
-	}
-
-	// Deduct buyout price from buyer
-	buyer.Credits -= auction.BuyoutPrice
-	if err := m.playerRepo.Update(ctx, buyer); err != nil {
-		return fmt.Errorf("failed to deduct credits: %v", err)
+	if buyerID == auction.SellerID {
+		return fmt.Errorf("cannot buy your own auction")
 	}
 
-	// Pay seller (minus fee)
-	seller, err := m.playerRepo.GetByID(ctx, auction.SellerID)
-	if err == nil {
-		fee := int64(float64(auction.BuyoutPrice) * m.config.AuctionFeePercent)
-		seller.Credits += (auction.BuyoutPrice - fee)
-		_ = m.playerRepo.Update(ctx, seller)
+	// Buyer pays, high bidder is refunded, seller is paid (minus fee) and the
+	// item is delivered in one transaction
+	fee := int64(float64(auction.BuyoutPrice) * m.config.AuctionFeePercent)
+	changes, err := m.repo.Buyout(ctx, auctionID, buyerID, buyerName, auction.BuyoutPrice, fee)
+	if err != nil {
+		return listingError(err, "complete buyout")
 	}
+	m.creditsChanged(changes...)
 
 	// Complete auction
 	auction.Status = "sold"
 	auction.CurrentBid = auction.BuyoutPrice
 	auction.HighBidder = buyerID
+	auction.HighBidderName = buyerName
 
-	log.Info("Auction buyout: auction=%s, buyer=%s, price=%d", auction.ItemName, buyer.Username, auction.BuyoutPrice)
+	log.Info("Auction buyout: auction=%s, buyer=%s, price=%d", auction.ItemName, buyerName, auction.BuyoutPrice)
 
 	// Trigger callback
 	if m.onAuctionComplete != nil {
@@ -373,6 +395,11 @@ func (m *Manager) CancelAuction(ctx context.Context, auctionID uuid.UUID, player
 		return fmt.Errorf("cannot cancel auction with active bids")
 	}
 
+	// Returns the escrowed item to the seller
+	if err := m.repo.CancelAuction(ctx, auctionID); err != nil {
+		return listingError(err, "cancel auction")
+	}
+
 	auction.Status = "cancelled"
 	log.Info("Auction cancelled: auction=%s, seller=%s", auction.ItemName, auction.SellerName)
 	return nil
@@ -406,35 +433,17 @@ func (m *Manager) GetAuction(auctionID uuid.UUID) (*Auction, bool) {
 // ============================================================================
 
 // ContractType represents the type of contract
-type ContractType string
+type ContractType = models.ContractType
 
 const (
-	ContractTypeCourier      ContractType = "courier"      // Deliver cargo
-	ContractTypeAssassination ContractType = "assassination" // Kill a target
-	ContractTypeEscort       ContractType = "escort"       // Escort a ship
-	ContractTypeBountyHunt   ContractType = "bounty_hunt"  // Hunt a bounty target
+	ContractTypeCourier       = models.ContractTypeCourier
+	ContractTypeAssassination = models.ContractTypeAssassination
+	ContractTypeEscort        = models.ContractTypeEscort
+	ContractTypeBountyHunt    = models.ContractTypeBountyHunt
 )
 
 // Contract represents a player-posted contract
-type Contract struct {
-	ID          uuid.UUID
-	PosterID    uuid.UUID
-	PosterName  string
-	Type        ContractType
-	Title       string
-	Description string
-	Reward      int64
-	Deposit     int64 // Amount poster must deposit
-	TargetID    uuid.UUID // Target player/system/location
-	TargetName  string
-	ClaimedBy   uuid.UUID
-	ClaimedName string
-	PostTime    time.Time
-	ExpiryTime  time.Time
-	ClaimTime   time.Time
-	CompleteTime time.Time
-	Status      string // "open", "claimed", "completed", "failed", "expired"
-}
+type Contract = models.MarketContract
 
 // CreateContract posts a new contract
 func (m *Manager) CreateContract(ctx context.Context, posterID uuid.UUID, posterName string, contractType ContractType, title string, description string, reward int64, targetID uuid.UUID, targetName string, duration time.Duration) (*Contract, error) {
@@ -460,21 +469,6 @@ func (m *Manager) CreateContract(ctx context.Context, posterID uuid.UUID, poster
 	// Calculate deposit (reward + posting cost)
 	deposit := reward + m.config.ContractPostCost
 
-	// Check poster has sufficient credits
-	poster, err := m.playerRepo.GetByID(ctx, posterID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get poster: %v", err)
-	}
-	if poster.Credits < deposit {
-		return nil, fmt.Errorf("insufficient credits (need %d)", deposit)
-	}
-
-	// Deduct deposit
-	poster.Credits -= deposit
-	if err := m.playerRepo.Update(ctx, poster); err != nil {
-		return nil, fmt.Errorf("failed to deduct deposit: %v", err)
-	}
-
 	contract := &Contract{
 		ID:          uuid.New(),
 		PosterID:    posterID,
@@ -491,6 +485,16 @@ func (m *Manager) CreateContract(ctx context.Context, posterID uuid.UUID, poster
 		Status:      "open",
 	}
 
+	// Deduct deposit and store the contract together
+	change, err := m.repo.CreateContract(ctx, contract)
+	if errors.Is(err, database.ErrInsufficientCredits) {
+		return nil, fmt.Errorf("insufficient credits (need %d)", deposit)
+	}
+	if err != nil {
+		return nil, listingError(err, "create contract")
+	}
+	m.creditsChanged(change)
+
 	m.mu.Lock()
 	m.contracts[contract.ID] = contract
 	m.mu.Unlock()
@@ -521,10 +525,15 @@ func (m *Manager) ClaimContract(ctx context.Context, contractID uuid.UUID, claim
 		return fmt.Errorf("cannot claim your own contract")
 	}
 
+	claimTime := time.Now()
+	if err := m.repo.ClaimContract(ctx, contractID, claimerID, claimerName, claimTime); err != nil {
+		return listingError(err, "claim contract")
+	}
+
 	contract.Status = "claimed"
 	contract.ClaimedBy = claimerID
 	contract.ClaimedName = claimerName
-	contract.ClaimTime = time.Now()
+	contract.ClaimTime = claimTime
 
 	log.Info("Contract claimed: contract=%s, claimer=%s", contract.Title, claimerName)
 
@@ -554,19 +563,17 @@ func (m *Manager) CompleteContract(ctx context.Context, contractID uuid.UUID, co
 	}
 
 	// Pay reward to completer
-	completer, err := m.playerRepo.GetByID(ctx, completerID)
+	completeTime := time.Now()
+	change, err := m.repo.CompleteContract(ctx, contractID, completerID, completeTime)
 	if err != nil {
-		return fmt.Errorf("failed to get completer: %v", err)
-	}
-	completer.Credits += contract.Reward
-	if err := m.playerRepo.Update(ctx, completer); err != nil {
-		return fmt.Errorf("failed to pay reward: %v", err)
+		return listingError(err, "pay reward")
 	}
+	m.creditsChanged(change)
 
 	contract.Status = "completed"
-	contract.CompleteTime = time.Now()
+	contract.CompleteTime = completeTime
 
-	log.Info("Contract completed: contract=%s, completer=%s, reward=%d", contract.Title, completer.Username, contract.Reward)
+	log.Info("Contract completed: contract=%s, completer=%s, reward=%d", contract.Title, contract.ClaimedName, contract.Reward)
 	return nil
 }
 
@@ -584,24 +591,12 @@ func (m *Manager) FailContract(ctx context.Context, contractID uuid.UUID) error
 		return fmt.Errorf("contract is not claimed")
 	}
 
-	// Apply penalty to claimer
-	if contract.ClaimedBy != uuid.Nil {
-		claimer, err := m.playerRepo.GetByID(ctx, contract.ClaimedBy)
-		if err == nil {
-			penalty := int64(float64(contract.Reward) * m.config.ContractFailurePenalty)
-			if claimer.Credits >= penalty {
-				claimer.Credits -= penalty
-				_ = m.playerRepo.Update(ctx, claimer)
-			}
-		}
-	}
-
-	// Refund poster
-	poster, err := m.playerRepo.GetByID(ctx, contract.PosterID)
-	if err == nil {
-		poster.Credits += contract.Deposit
-		_ = m.playerRepo.Update(ctx, poster)
+	// Apply penalty to claimer and refund poster
+	changes, err := m.repo.FailContract(ctx, contractID, m.config.ContractFailurePenalty)
+	if err != nil {
+		return listingError(err, "fail contract")
 	}
+	m.creditsChanged(changes...)
 
 	contract.Status = "failed"
 	log.Info("Contract failed: contract=%s, claimer=%s", contract.Title, contract.ClaimedName)
@@ -627,21 +622,7 @@ func (m *Manager) GetOpenContracts() []*Contract {
 // ============================================================================
 
 // Bounty represents a bounty on a player's head
-type Bounty struct {
-	ID          uuid.UUID
-	PosterID    uuid.UUID
-	PosterName  string
-	TargetID    uuid.UUID
-	TargetName  string
-	Amount      int64
-	Reason      string
-	PostTime    time.Time
-	ExpiryTime  time.Time
-	ClaimedBy   uuid.UUID
-	ClaimedName string
-	ClaimTime   time.Time
-	Status      string // "active", "claimed", "expired"
-}
+type Bounty = models.MarketBounty
 
 // PostBounty posts a bounty on a player
 func (m *Manager) PostBounty(ctx context.Context, posterID uuid.UUID, posterName string, targetID uuid.UUID, targetName string, amount int64, reason string) (*Bounty, error) {
@@ -668,21 +649,6 @@ func (m *Manager) PostBounty(ctx context.Context, posterID uuid.UUID, posterName
 	fee := int64(float64(amount) * m.config.BountyPostFee)
 	totalCost := amount + fee
 
-	// Check poster has sufficient credits
-	poster, err := m.playerRepo.GetByID(ctx, posterID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get poster: %v", err)
-	}
-	if poster.Credits < totalCost {
-		return nil, fmt.Errorf("insufficient credits (need %d)", totalCost)
-	}
-
-	// Deduct total cost
-	poster.Credits -= totalCost
-	if err := m.playerRepo.Update(ctx, poster); err != nil {
-		return nil, fmt.Errorf("failed to deduct credits: %v", err)
-	}
-
 	bounty := &Bounty{
 		ID:         uuid.New(),
 		PosterID:   posterID,
@@ -696,6 +662,16 @@ func (m *Manager) PostBounty(ctx context.Context, posterID uuid.UUID, posterName
 		Status:     "active",
 	}
 
+	// Deduct total cost and store the bounty together
+	change, err := m.repo.PostBounty(ctx, bounty, totalCost)
+	if errors.Is(err, database.ErrInsufficientCredits) {
+		return nil, fmt.Errorf("insufficient credits (need %d)", totalCost)
+	}
+	if err != nil {
+		return nil, listingError(err, "post bounty")
+	}
+	m.creditsChanged(change)
+
 	m.mu.Lock()
 	m.bounties[bounty.ID] = bounty
 	m.mu.Unlock()
@@ -709,37 +685,36 @@ func (m *Manager) ClaimBounty(ctx context.Context, targetID uuid.UUID, killerID
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	totalPayout := int64(0)
+	// Pay all active bounties on target to the killer
+	claimTime := time.Now()
+	claimed, change, err := m.repo.ClaimBounties(ctx, targetID, killerID, killerName, claimTime)
+	if errors.Is(err, database.ErrListingNotFound) {
+		return 0, fmt.Errorf("no active bounties on target")
+	}
+	if err != nil {
+		return 0, listingError(err, "claim bounty")
+	}
+	m.creditsChanged(change)
 
-	// Find all active bounties on target
-	for _, bounty := range m.bounties {
-		if bounty.TargetID == targetID && bounty.Status == "active" {
-			// Pay bounty to killer
-			killer, err := m.playerRepo.GetByID(ctx, killerID)
-			if err == nil {
-				killer.Credits += bounty.Amount
-				_ = m.playerRepo.Update(ctx, killer)
-				totalPayout += bounty.Amount
-			}
+	for _, id := range claimed {
+		bounty, exists := m.bounties[id]
+		if !exists {
+			continue
+		}
 
-			bounty.Status = "claimed"
-			bounty.ClaimedBy = killerID
-			bounty.ClaimedName = killerName
-			bounty.ClaimTime = time.Now()
+		bounty.Status = "claimed"
+		bounty.ClaimedBy = killerID
+		bounty.ClaimedName = killerName
+		bounty.ClaimTime = claimTime
 
-			log.Info("Bounty claimed: target=%s, killer=%s, amount=%d", bounty.TargetName, killerName, bounty.Amount)
+		log.Info("Bounty claimed: target=%s, killer=%s, amount=%d", bounty.TargetName, killerName, bounty.Amount)
 
-			if m.onBountyClaimed != nil {
-				go m.onBountyClaimed(bounty)
-			}
+		if m.onBountyClaimed != nil {
+			go m.onBountyClaimed(bounty)
 		}
 	}
 
-	if totalPayout == 0 {
-		return 0, fmt.Errorf("no active bounties on target")
-	}
-
-	return totalPayout, nil
+	return change.Delta, nil
 }
 
 // GetActiveBounties returns all active bounties
@@ -791,7 +766,9 @@ func (m *Manager) expiryWorker() {
 	}
 }
 
-// processExpiries checks and processes all expiries
+// processExpiries checks and processes all expiries.
+// Each settlement is its own transaction; one that fails is logged and
+// retried on the next tick.
 func (m *Manager) processExpiries() {
 	ctx := context.Background()
 	now := time.Now()
@@ -802,23 +779,24 @@ func (m *Manager) processExpiries() {
 	// Expire auctions
 	for _, auction := range m.auctions {
 		if auction.Status == "active" && now.After(auction.EndTime) {
-			if auction.HighBidder != uuid.Nil {
-				// Auction sold - pay seller and complete
-				seller, err := m.playerRepo.GetByID(ctx, auction.SellerID)
-				if err == nil {
-					fee := int64(float64(auction.CurrentBid) * m.config.AuctionFeePercent)
-					seller.Credits += (auction.CurrentBid - fee)
-					_ = m.playerRepo.Update(ctx, seller)
-				}
-				auction.Status = "sold"
-				log.Info("Auction completed: item=%s, winner=%s, price=%d", auction.ItemName, auction.HighBidderName, auction.CurrentBid)
+			// Pays the seller and delivers the item, or returns the item if unsold
+			status, changes, err := m.repo.SettleAuction(ctx, auction.ID, m.config.AuctionFeePercent)
+			if err != nil {
+				log.Error("Failed to settle auction %s: %v", auction.ID, err)
+				continue
+			}
+			m.creditsChanged(changes...)
+			auction.Status = status
 
+			switch {
+			case status == "sold":
+				log.Info("Auction completed: item=%s, winner=%s, price=%d", auction.ItemName, auction.HighBidderName, auction.CurrentBid)
 				if m.onAuctionComplete != nil {
 					go m.onAuctionComplete(auction)
 				}
-			} else {
-				// No bids - expire
-				auction.Status = "expired"
+			case auction.HighBidder != uuid.Nil:
+				log.Warn("Auction item missing from escrow, refunded winning bid: item=%s, bidder=%s", auction.ItemName, auction.HighBidderName)
+			default:
 				log.Info("Auction expired: item=%s (no bids)", auction.ItemName)
 			}
 		}
@@ -828,11 +806,12 @@ func (m *Manager) processExpiries() {
 	for _, contract := range m.contracts {
 		if contract.Status == "open" && now.After(contract.ExpiryTime) {
 			// Refund poster
-			poster, err := m.playerRepo.GetByID(ctx, contract.PosterID)
-			if err == nil {
-				poster.Credits += contract.Deposit
-				_ = m.playerRepo.Update(ctx, poster)
+			change, err := m.repo.ExpireContract(ctx, contract.ID)
+			if err != nil {
+				log.Error("Failed to expire contract %s: %v", contract.ID, err)
+				continue
 			}
+			m.creditsChanged(change)
 			contract.Status = "expired"
 			log.Info("Contract expired: title=%s", contract.Title)
 		}
@@ -842,11 +821,12 @@ func (m *Manager) processExpiries() {
 	for _, bounty := range m.bounties {
 		if bounty.Status == "active" && now.After(bounty.ExpiryTime) {
 			// Refund poster
-			poster, err := m.playerRepo.GetByID(ctx, bounty.PosterID)
-			if err == nil {
-				poster.Credits += bounty.Amount
-				_ = m.playerRepo.Update(ctx, poster)
+			change, err := m.repo.ExpireBounty(ctx, bounty.ID)
+			if err != nil {
+				log.Error("Failed to expire bounty %s: %v", bounty.ID, err)
+				continue
 			}
+			m.creditsChanged(change)
 			bounty.Status = "expired"
 			log.Info("Bounty expired: target=%s", bounty.TargetName)
 		}
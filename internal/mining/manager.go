@@ -1,7 +1,7 @@
 // File: internal/mining/manager.go
 // Project: Terminal Velocity
 // Description: Mining and salvage operations manager
-// Version: 1.1.0
+// Version: 1.4.0
 // Author: Claude Code
 // Created: 2025-11-15
 
@@ -9,102 +9,165 @@ package mining
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
-	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	"github.com/google/uuid"
 )
 
 var log = logger.WithComponent("Mining")
 
+var (
+	ErrAlreadyMining = errors.New("already mining")
+	ErrFieldNotFound = errors.New("resource field not found")
+	ErrFieldDepleted = errors.New("resource field is depleted")
+	ErrCargoFull     = errors.New("no cargo space left")
+	ErrNoOperation   = errors.New("no active operation")
+	ErrNoShips       = errors.New("no ship repository to load cargo into")
+)
+
+// Operation statuses
+const (
+	StatusActive    = "active"
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+	StatusFailed    = "failed"
+)
+
 // Manager handles mining and salvage operations
 type Manager struct {
 	mu sync.RWMutex
 
-	// Active operations
+	// Serializes field saves, which run without holding mu
+	saveMu sync.Mutex
+
+	// Active and most recently finished operation of each ship
 	activeOperations map[uuid.UUID]*MiningOperation
 
+	// Resource fields by system and by field ID, generated when a system is
+	// first surveyed
+	systems map[uuid.UUID][]*models.ResourceField
+	fields  map[uuid.UUID]*models.ResourceField
+
+	// Stored depletion of fields that have not been generated yet
+	saved map[uuid.UUID]*models.ResourceField
+
 	// Configuration
 	config MiningConfig
 
 	// Repositories
-	systemRepo *database.SystemRepository
-	shipRepo   *database.ShipRepository
-	playerRepo *database.PlayerRepository
+	ships   Ships
+	players Players
+	store   Store
+
+	// Callback when extracted resources are added to a player's cargo
+	onInventoryChanged func(playerID uuid.UUID)
 }
 
 // MiningConfig defines mining system parameters
 type MiningConfig struct {
 	// Mining parameters
-	BaseMiningYield        float64       // Base yield per mining cycle
-	MiningCycleDuration    time.Duration // How long each mining cycle takes
-	MiningLaserBonus       float64       // Bonus per mining laser level
-	CargoScannerBonus      float64       // Bonus from cargo scanner
-	AsteroidDepletionRate  float64       // How quickly asteroids deplete
-	MaxConcurrentOperations int           // Max mining ops per player
+	BaseMiningYield     float64       // Base yield per mining cycle
+	MiningCycleDuration time.Duration // How long each mining cycle takes
+	MiningLaserBonus    float64       // Bonus per mining laser level
+	CargoScannerBonus   float64       // Bonus per scanner level when mining
+	MaxCycles           int           // Most cycles in one operation
 
 	// Salvage parameters
-	BaseSalvageYield      float64       // Base salvage yield
-	SalvageCycleDuration  time.Duration // Salvage cycle time
-	SalvageScannerBonus   float64       // Bonus from salvage scanner
-	DerelictRareItemChance float64       // Chance of rare items
-	DebrisFieldDensity    float64       // Average debris field density
-
-	// Resource spawn
-	AsteroidSpawnChance  float64 // Chance per system visit
-	DerelictSpawnChance  float64 // Chance per system visit
-	DebrisFieldChance    float64 // Chance per system visit
-	RareResourceModifier float64 // Multiplier for rare resources
+	BaseSalvageYield     float64       // Base salvage yield
+	SalvageCycleDuration time.Duration // Salvage cycle time
+	SalvageScannerBonus  float64       // Bonus per scanner level when salvaging
+
+	// Resource fields
+	MinAsteroids        int           // Fewest asteroids in a system
+	MaxAsteroids        int           // Most asteroids in a system
+	UnchartedChance     float64       // Chance of an asteroid only scanners find
+	DerelictSpawnChance float64       // Chance of a derelict in a system
+	DebrisFieldChance   float64       // Chance of a debris field in a system
+	FrontierTechLevel   int           // Systems at or below this tech level are frontier
+	FrontierRichness    float64       // Multiplier for frontier field sizes
+	UnchartedRichness   float64       // Multiplier for uncharted field sizes
+	FieldRegrowthTime   time.Duration // How long an emptied field takes to regrow
 }
 
 // DefaultMiningConfig returns sensible defaults
 func DefaultMiningConfig() MiningConfig {
 	return MiningConfig{
-		BaseMiningYield:         10.0,
-		MiningCycleDuration:     15 * time.Second,
-		MiningLaserBonus:        0.25,  // +25% per level
-		CargoScannerBonus:       0.15,  // +15% from scanner
-		AsteroidDepletionRate:   0.10,  // -10% per cycle
-		MaxConcurrentOperations: 1,
-		BaseSalvageYield:        8.0,
-		SalvageCycleDuration:    20 * time.Second,
-		SalvageScannerBonus:     0.20,  // +20% from scanner
-		DerelictRareItemChance:  0.05,  // 5% chance
-		DebrisFieldDensity:      0.50,  // 50% average
-		AsteroidSpawnChance:     0.30,  // 30% chance
-		DerelictSpawnChance:     0.10,  // 10% chance
-		DebrisFieldChance:       0.15,  // 15% chance
-		RareResourceModifier:    2.5,   // 2.5x value
-	}
-}
-
-// MiningOperation represents an active mining or salvage operation
+		BaseMiningYield:      10.0,
+		MiningCycleDuration:  15 * time.Second,
+		MiningLaserBonus:     0.25, // +25% per level
+		CargoScannerBonus:    0.15, // +15% per level
+		MaxCycles:            10,
+		BaseSalvageYield:     8.0,
+		SalvageCycleDuration: 20 * time.Second,
+		SalvageScannerBonus:  0.20, // +20% per level
+		MinAsteroids:         1,
+		MaxAsteroids:         3,
+		UnchartedChance:      0.50, // 50% chance
+		DerelictSpawnChance:  0.25, // 25% chance
+		DebrisFieldChance:    0.35, // 35% chance
+		FrontierTechLevel:    3,
+		FrontierRichness:     1.5, // 1.5x resources
+		UnchartedRichness:    1.5, // 1.5x resources
+		FieldRegrowthTime:    24 * time.Hour,
+	}
+}
+
+// MiningOperation represents a mining or salvage operation on a resource
+// field, made of timed cycles that each move resources into cargo
 type MiningOperation struct {
-	ID           uuid.UUID
-	PlayerID     uuid.UUID
-	ShipID       uuid.UUID
-	Type         string // "mining", "salvage"
-	Target       *MiningTarget
-	StartTime    time.Time
-	CyclesLeft   int
-	CurrentYield float64
-	Status       string // "active", "completed", "cancelled"
-	Resources    map[string]int // Resource type -> quantity
-}
-
-// MiningTarget represents what is being mined or salvaged
-type MiningTarget struct {
-	Type        string // "asteroid", "derelict", "debris_field"
-	ID          uuid.UUID
-	Name        string
-	Resources   map[string]float64 // Resource type -> remaining amount
-	Rarity      string             // "common", "uncommon", "rare", "legendary"
-	Coordinates string
-	SystemID    uuid.UUID
+	ID            uuid.UUID
+	PlayerID      uuid.UUID
+	ShipID        uuid.UUID
+	Type          string // "mining", "salvage"
+	FieldID       uuid.UUID
+	FieldName     string
+	StartTime     time.Time
+	CycleStart    time.Time // When the current cycle began
+	CycleDuration time.Duration
+	TotalCycles   int
+	CyclesLeft    int
+	CycleYield    float64 // Amount a full cycle extracts
+	CargoLeft     int     // Free cargo space after the last cycle
+	CurrentYield  float64
+	Status        string         // "active", "completed", "cancelled", "failed"
+	Message       string         // Why the operation stopped, if it has
+	Resources     map[string]int // Resource type -> quantity
+	Cargo         map[string]int // Commodity -> quantity added to cargo
+
+	cancel chan struct{}
+}
+
+// CycleProgress returns how far the current cycle has run, from 0 to 1
+func (op *MiningOperation) CycleProgress(now time.Time) float64 {
+	if op.Status != StatusActive || op.CycleDuration <= 0 {
+		return 0
+	}
+	return math.Min(float64(now.Sub(op.CycleStart))/float64(op.CycleDuration), 1)
+}
+
+// copy returns a snapshot of the operation that is safe to read without
+// holding the manager's lock. Caller must hold m.mu.
+func (op *MiningOperation) copy() *MiningOperation {
+	snapshot := *op
+	snapshot.Resources = make(map[string]int, len(op.Resources))
+	for resource, quantity := range op.Resources {
+		snapshot.Resources[resource] = quantity
+	}
+	snapshot.Cargo = make(map[string]int, len(op.Cargo))
+	for commodity, quantity := range op.Cargo {
+		snapshot.Cargo[commodity] = quantity
+	}
+	snapshot.cancel = nil
+	return &snapshot
 }
 
 // ResourceType represents a mineable or salvageable resource
@@ -125,299 +188,624 @@ const (
 	ResourceOutfits    ResourceType = "salvaged_outfits"
 )
 
+// resourceCommodities maps each resource to the trade commodity it is
+// loaded into cargo as
+var resourceCommodities = map[ResourceType]string{
+	ResourceIron:       "ore",
+	ResourceCopper:     "ore",
+	ResourceTitanium:   "ore",
+	ResourceScrap:      "ore",
+	ResourcePlatinum:   "precious_metals",
+	ResourceGold:       "precious_metals",
+	ResourceRareEarth:  "precious_metals",
+	ResourceCrystals:   "crystals",
+	ResourceDeuterium:  "industrial_chemicals",
+	ResourceComponents: "electronics",
+	ResourceWeapons:    "weapons",
+	ResourceOutfits:    "machinery",
+}
+
+// CommodityFor returns the commodity a resource is loaded into cargo as
+func CommodityFor(resource string) string {
+	if commodity, ok := resourceCommodities[ResourceType(resource)]; ok {
+		return commodity
+	}
+	return "ore"
+}
+
 // NewManager creates a new mining and salvage manager
-func NewManager(systemRepo *database.SystemRepository, shipRepo *database.ShipRepository, playerRepo *database.PlayerRepository) *Manager {
+func NewManager(ships Ships, players Players) *Manager {
 	return &Manager{
 		activeOperations: make(map[uuid.UUID]*MiningOperation),
+		systems:          make(map[uuid.UUID][]*models.ResourceField),
+		fields:           make(map[uuid.UUID]*models.ResourceField),
+		saved:            make(map[uuid.UUID]*models.ResourceField),
 		config:           DefaultMiningConfig(),
-		systemRepo:       systemRepo,
-		shipRepo:         shipRepo,
-		playerRepo:       playerRepo,
+		ships:            ships,
+		players:          players,
 	}
 }
 
-// ScanForResources scans a system for mining and salvage opportunities
-func (m *Manager) ScanForResources(ctx context.Context, systemID uuid.UUID, hasScannerBonus bool) ([]MiningTarget, error) {
-	targets := []MiningTarget{}
+// SetInventoryChangedCallback sets the callback run when a mining cycle adds
+// resources to a player's cargo
+func (m *Manager) SetInventoryChangedCallback(callback func(playerID uuid.UUID)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onInventoryChanged = callback
+}
+
+// ScanForResources surveys a system for mining and salvage fields. A system
+// always has the same fields; their remaining resources reflect mining and
+// regrowth. Uncharted fields are only found with a scanner.
+func (m *Manager) ScanForResources(system *models.StarSystem, scannerLevel int) []*models.ResourceField {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	// Generate asteroids
-	if rand.Float64() < m.config.AsteroidSpawnChance {
-		numAsteroids := rand.Intn(3) + 1 // 1-3 asteroids
-		for i := 0; i < numAsteroids; i++ {
-			asteroid := m.generateAsteroid(systemID)
-			targets = append(targets, asteroid)
+	now := time.Now()
+	var fields []*models.ResourceField
+	for _, field := range m.systemFields(system) {
+		if field.Uncharted && scannerLevel <= 0 {
+			continue
 		}
+		m.regrow(field, now)
+		fields = append(fields, copyField(field))
 	}
 
-	// Generate derelicts
-	if rand.Float64() < m.config.DerelictSpawnChance {
-		derelict := m.generateDerelict(systemID)
-		targets = append(targets, derelict)
-	}
+	log.Debug("Scanned system %s: found %d mining targets", system.Name, len(fields))
+	return fields
+}
 
-	// Generate debris fields
-	if rand.Float64() < m.config.DebrisFieldChance {
-		debris := m.generateDebrisField(systemID)
-		targets = append(targets, debris)
+// systemFields returns a system's fields, generating them and applying any
+// stored depletion on first use. Caller must hold m.mu.
+func (m *Manager) systemFields(system *models.StarSystem) []*models.ResourceField {
+	if fields, exists := m.systems[system.ID]; exists {
+		return fields
 	}
 
-	// Scanner bonus reveals more targets
-	if hasScannerBonus && len(targets) > 0 {
-		// 50% chance to reveal one more target
-		if rand.Float64() < 0.5 {
-			extra := m.generateAsteroid(systemID)
-			targets = append(targets, extra)
+	fields := m.generateFields(system, time.Now())
+	for _, field := range fields {
+		if saved, exists := m.saved[field.ID]; exists {
+			for resource, capacity := range field.Capacity {
+				if remaining, ok := saved.Remaining[resource]; ok {
+					field.Remaining[resource] = math.Min(remaining, capacity)
+				}
+			}
+			field.UpdatedAt = saved.UpdatedAt
+			delete(m.saved, field.ID)
 		}
+		m.fields[field.ID] = field
 	}
+	m.systems[system.ID] = fields
+	return fields
+}
 
-	log.Debug("Scanned system %s: found %d mining targets", systemID, len(targets))
-	return targets, nil
+// generateFields creates a system's resource fields. The random source is
+// seeded from the system ID so the same fields come back after a restart;
+// frontier systems have more and richer asteroids.
+func (m *Manager) generateFields(system *models.StarSystem, now time.Time) []*models.ResourceField {
+	rng := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(system.ID[:8]))))
+
+	richness := 1.0
+	asteroids := m.config.MinAsteroids + rng.Intn(m.config.MaxAsteroids-m.config.MinAsteroids+1)
+	if system.TechLevel <= m.config.FrontierTechLevel {
+		richness = m.config.FrontierRichness
+		asteroids++
+	}
+
+	var fields []*models.ResourceField
+	for i := 0; i < asteroids; i++ {
+		fields = append(fields, m.generateAsteroid(rng, []string{"common", "common", "common", "uncommon", "uncommon", "rare"}, richness))
+	}
+	if rng.Float64() < m.config.UnchartedChance {
+		uncharted := m.generateAsteroid(rng, []string{"uncommon", "rare", "rare"}, richness*m.config.UnchartedRichness)
+		uncharted.Uncharted = true
+		fields = append(fields, uncharted)
+	}
+	if rng.Float64() < m.config.DerelictSpawnChance {
+		fields = append(fields, m.generateDerelict(rng))
+	}
+	if rng.Float64() < m.config.DebrisFieldChance {
+		fields = append(fields, m.generateDebrisField(rng))
+	}
+
+	for i, field := range fields {
+		field.ID = uuid.NewSHA1(system.ID, []byte(fmt.Sprintf("field-%d", i)))
+		field.SystemID = system.ID
+		field.Remaining = make(map[string]float64, len(field.Capacity))
+		for resource, capacity := range field.Capacity {
+			field.Remaining[resource] = capacity
+		}
+		field.UpdatedAt = now
+	}
+	return fields
 }
 
-// generateAsteroid creates a random asteroid target
-func (m *Manager) generateAsteroid(systemID uuid.UUID) MiningTarget {
-	rarities := []string{"common", "common", "common", "uncommon", "uncommon", "rare"}
-	rarity := rarities[rand.Intn(len(rarities))]
+// generateAsteroid creates an asteroid of one of the given rarities
+func (m *Manager) generateAsteroid(rng *rand.Rand, rarities []string, richness float64) *models.ResourceField {
+	rarity := rarities[rng.Intn(len(rarities))]
 
+	amount := func(min, spread int) float64 {
+		return math.Round(float64(rng.Intn(spread)+min) * richness)
+	}
 	resources := make(map[string]float64)
 
 	// Common asteroids have basic resources
 	if rarity == "common" {
-		resources[string(ResourceIron)] = float64(rand.Intn(50) + 50)       // 50-100
-		resources[string(ResourceCopper)] = float64(rand.Intn(30) + 20)     // 20-50
+		resources[string(ResourceIron)] = amount(50, 50)   // 50-100
+		resources[string(ResourceCopper)] = amount(20, 30) // 20-50
 	} else if rarity == "uncommon" {
-		resources[string(ResourceTitanium)] = float64(rand.Intn(40) + 30)   // 30-70
-		resources[string(ResourceGold)] = float64(rand.Intn(20) + 10)       // 10-30
+		resources[string(ResourceTitanium)] = amount(30, 40) // 30-70
+		resources[string(ResourceGold)] = amount(10, 20)     // 10-30
+		resources[string(ResourceDeuterium)] = amount(5, 15) // 5-20
 	} else {
 		// Rare asteroids
-		resources[string(ResourcePlatinum)] = float64(rand.Intn(30) + 20)   // 20-50
-		resources[string(ResourceCrystals)] = float64(rand.Intn(25) + 15)   // 15-40
-		resources[string(ResourceRareEarth)] = float64(rand.Intn(15) + 10)  // 10-25
+		resources[string(ResourcePlatinum)] = amount(20, 30)  // 20-50
+		resources[string(ResourceCrystals)] = amount(15, 25)  // 15-40
+		resources[string(ResourceRareEarth)] = amount(10, 15) // 10-25
 	}
 
-	return MiningTarget{
-		Type:        "asteroid",
-		ID:          uuid.New(),
-		Name:        fmt.Sprintf("%s Asteroid %d", capitalize(rarity), rand.Intn(9999)),
-		Resources:   resources,
-		Rarity:      rarity,
-		Coordinates: fmt.Sprintf("%d,%d,%d", rand.Intn(1000), rand.Intn(1000), rand.Intn(1000)),
-		SystemID:    systemID,
+	return &models.ResourceField{
+		Type:     models.FieldAsteroid,
+		Name:     fmt.Sprintf("%s Asteroid %d", capitalize(rarity), rng.Intn(9999)),
+		Rarity:   rarity,
+		Capacity: resources,
 	}
 }
 
-// generateDerelict creates a random derelict ship target
-func (m *Manager) generateDerelict(systemID uuid.UUID) MiningTarget {
+// generateDerelict creates a derelict ship to salvage
+func (m *Manager) generateDerelict(rng *rand.Rand) *models.ResourceField {
 	rarities := []string{"common", "common", "uncommon", "rare"}
-	rarity := rarities[rand.Intn(len(rarities))]
+	rarity := rarities[rng.Intn(len(rarities))]
 
 	resources := make(map[string]float64)
-	resources[string(ResourceScrap)] = float64(rand.Intn(100) + 50) // 50-150
-	resources[string(ResourceComponents)] = float64(rand.Intn(40) + 20) // 20-60
+	resources[string(ResourceScrap)] = float64(rng.Intn(100) + 50)     // 50-150
+	resources[string(ResourceComponents)] = float64(rng.Intn(40) + 20) // 20-60
 
 	// Rare derelicts may have weapons/outfits
 	if rarity == "uncommon" {
-		resources[string(ResourceWeapons)] = float64(rand.Intn(3) + 1) // 1-3
+		resources[string(ResourceWeapons)] = float64(rng.Intn(3) + 1) // 1-3
 	} else if rarity == "rare" {
-		resources[string(ResourceWeapons)] = float64(rand.Intn(5) + 2) // 2-6
-		resources[string(ResourceOutfits)] = float64(rand.Intn(4) + 1) // 1-4
+		resources[string(ResourceWeapons)] = float64(rng.Intn(5) + 2) // 2-6
+		resources[string(ResourceOutfits)] = float64(rng.Intn(4) + 1) // 1-4
 	}
 
 	shipTypes := []string{"Shuttle", "Fighter", "Freighter", "Corvette", "Destroyer"}
-	shipType := shipTypes[rand.Intn(len(shipTypes))]
+	shipType := shipTypes[rng.Intn(len(shipTypes))]
 
-	return MiningTarget{
-		Type:        "derelict",
-		ID:          uuid.New(),
-		Name:        fmt.Sprintf("Derelict %s", shipType),
-		Resources:   resources,
-		Rarity:      rarity,
-		Coordinates: fmt.Sprintf("%d,%d,%d", rand.Intn(1000), rand.Intn(1000), rand.Intn(1000)),
-		SystemID:    systemID,
+	return &models.ResourceField{
+		Type:     models.FieldDerelict,
+		Name:     fmt.Sprintf("Derelict %s", shipType),
+		Rarity:   rarity,
+		Capacity: resources,
 	}
 }
 
-// generateDebrisField creates a random debris field
-func (m *Manager) generateDebrisField(systemID uuid.UUID) MiningTarget {
+// generateDebrisField creates a debris field to salvage
+func (m *Manager) generateDebrisField(rng *rand.Rand) *models.ResourceField {
 	resources := make(map[string]float64)
-	resources[string(ResourceScrap)] = float64(rand.Intn(200) + 100) // 100-300
-	resources[string(ResourceIron)] = float64(rand.Intn(50) + 25)     // 25-75
-	resources[string(ResourceComponents)] = float64(rand.Intn(30) + 10) // 10-40
+	resources[string(ResourceScrap)] = float64(rng.Intn(200) + 100)    // 100-300
+	resources[string(ResourceIron)] = float64(rng.Intn(50) + 25)       // 25-75
+	resources[string(ResourceComponents)] = float64(rng.Intn(30) + 10) // 10-40
+
+	return &models.ResourceField{
+		Type:     models.FieldDebris,
+		Name:     fmt.Sprintf("Debris Field %d", rng.Intn(9999)),
+		Rarity:   "common",
+		Capacity: resources,
+	}
+}
 
-	return MiningTarget{
-		Type:        "debris_field",
-		ID:          uuid.New(),
-		Name:        fmt.Sprintf("Debris Field %d", rand.Intn(9999)),
-		Resources:   resources,
-		Rarity:      "common",
-		Coordinates: fmt.Sprintf("%d,%d,%d", rand.Intn(1000), rand.Intn(1000), rand.Intn(1000)),
-		SystemID:    systemID,
+// regrow brings a field up to date, regrowing each resource toward its
+// capacity so an emptied field is full again after FieldRegrowthTime.
+// Caller must hold m.mu.
+func (m *Manager) regrow(field *models.ResourceField, now time.Time) {
+	elapsed := now.Sub(field.UpdatedAt)
+	if elapsed <= 0 {
+		return
+	}
+	if m.config.FieldRegrowthTime > 0 {
+		fraction := float64(elapsed) / float64(m.config.FieldRegrowthTime)
+		for resource, capacity := range field.Capacity {
+			field.Remaining[resource] = math.Min(capacity, field.Remaining[resource]+capacity*fraction)
+		}
 	}
+	field.UpdatedAt = now
 }
 
-// StartMining initiates a mining operation
-func (m *Manager) StartMining(ctx context.Context, playerID, shipID uuid.UUID, target *MiningTarget, miningLaserLevel int, hasCargoScanner bool) (*MiningOperation, error) {
+// cycleYield returns how much one cycle extracts from a field and how long
+// it takes with the given mining laser and scanner levels
+func (m *Manager) cycleYield(field *models.ResourceField, laserLevel, scannerLevel int) (float64, time.Duration) {
+	if field.Salvage() {
+		bonus := float64(laserLevel)*m.config.MiningLaserBonus + float64(scannerLevel)*m.config.SalvageScannerBonus
+		return m.config.BaseSalvageYield * (1 + bonus), m.config.SalvageCycleDuration
+	}
+	bonus := float64(laserLevel)*m.config.MiningLaserBonus + float64(scannerLevel)*m.config.CargoScannerBonus
+	return m.config.BaseMiningYield * (1 + bonus), m.config.MiningCycleDuration
+}
+
+// StartMining starts mining or salvaging a field in the ship's system. The
+// ship's mining laser and scanner outfits raise the yield, and the
+// operation stops when the field or the ship's free cargo space runs out.
+func (m *Manager) StartMining(playerID uuid.UUID, ship *models.Ship, system *models.StarSystem, fieldID uuid.UUID) (*MiningOperation, error) {
+	// Without ships nothing mined could reach a hold
+	if m.ships == nil {
+		return nil, ErrNoShips
+	}
+	shipType := models.GetShipTypeByID(ship.TypeID)
+	if shipType == nil {
+		return nil, fmt.Errorf("unknown ship type %s", ship.TypeID)
+	}
+	cargoFree := ship.GetCargoSpace(shipType)
+	if cargoFree <= 0 {
+		return nil, ErrCargoFull
+	}
+	laserLevel, scannerLevel := models.CalculateMiningEquipment(ship.Outfits)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	// Check for existing operation
-	m.mu.RLock()
-	if existing, exists := m.activeOperations[shipID]; exists {
-		m.mu.RUnlock()
-		return nil, fmt.Errorf("already mining (started %v ago)", time.Since(existing.StartTime))
+	if existing, exists := m.activeOperations[ship.ID]; exists && existing.Status == StatusActive {
+		return nil, fmt.Errorf("%w (started %v ago)", ErrAlreadyMining, time.Since(existing.StartTime).Round(time.Second))
 	}
-	m.mu.RUnlock()
 
-	// Check if target has resources left
-	totalResources := 0.0
-	for _, amount := range target.Resources {
-		totalResources += amount
+	var field *models.ResourceField
+	for _, candidate := range m.systemFields(system) {
+		if candidate.ID == fieldID && (!candidate.Uncharted || scannerLevel > 0) {
+			field = candidate
+			break
+		}
 	}
-	if totalResources <= 0 {
-		return nil, fmt.Errorf("target is depleted")
+	if field == nil {
+		return nil, ErrFieldNotFound
 	}
 
-	// Calculate number of cycles based on resources
-	cycles := int(totalResources / m.config.BaseMiningYield)
+	now := time.Now()
+	m.regrow(field, now)
+	total := field.Total()
+	if total < 1 {
+		return nil, ErrFieldDepleted
+	}
+
+	// Calculate number of cycles based on resources and cargo space
+	yield, duration := m.cycleYield(field, laserLevel, scannerLevel)
+	cycles := int(math.Ceil(math.Min(total, float64(cargoFree)) / yield))
 	if cycles < 1 {
 		cycles = 1
 	}
-	if cycles > 10 {
-		cycles = 10 // Cap at 10 cycles
+	if cycles > m.config.MaxCycles {
+		cycles = m.config.MaxCycles
 	}
 
-	// Create mining operation
+	opType := "mining"
+	if field.Salvage() {
+		opType = "salvage"
+	}
 	operation := &MiningOperation{
-		ID:           uuid.New(),
-		PlayerID:     playerID,
-		ShipID:       shipID,
-		Type:         "mining",
-		Target:       target,
-		StartTime:    time.Now(),
-		CyclesLeft:   cycles,
-		CurrentYield: 0,
-		Status:       "active",
-		Resources:    make(map[string]int),
+		ID:            uuid.New(),
+		PlayerID:      playerID,
+		ShipID:        ship.ID,
+		Type:          opType,
+		FieldID:       field.ID,
+		FieldName:     field.Name,
+		StartTime:     now,
+		CycleStart:    now,
+		CycleDuration: duration,
+		TotalCycles:   cycles,
+		CyclesLeft:    cycles,
+		CycleYield:    yield,
+		CargoLeft:     cargoFree,
+		Status:        StatusActive,
+		Resources:     make(map[string]int),
+		Cargo:         make(map[string]int),
+		cancel:        make(chan struct{}),
 	}
+	m.activeOperations[ship.ID] = operation
 
-	m.mu.Lock()
-	m.activeOperations[shipID] = operation
-	m.mu.Unlock()
-
-	log.Info("Mining started: player=%s, target=%s, cycles=%d",
-		playerID, target.Name, cycles)
+	log.Info("Mining started: player=%s, target=%s, cycles=%d, yield=%.1f",
+		playerID, field.Name, cycles, yield)
 
 	// Start mining cycles
-	go m.runMiningCycles(ctx, operation, miningLaserLevel, hasCargoScanner)
+	go m.runMiningCycles(operation)
 
-	return operation, nil
+	return operation.copy(), nil
 }
 
-// runMiningCycles executes mining cycles
-func (m *Manager) runMiningCycles(ctx context.Context, operation *MiningOperation, miningLaserLevel int, hasCargoScanner bool) {
-	for operation.CyclesLeft > 0 && operation.Status == "active" {
-		// Wait for cycle duration
-		time.Sleep(m.config.MiningCycleDuration)
+// runMiningCycles waits out each cycle of an operation and extracts its
+// yield, until the operation completes or is cancelled
+func (m *Manager) runMiningCycles(operation *MiningOperation) {
+	timer := time.NewTimer(operation.CycleDuration)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-operation.cancel:
+			m.finishOperation(operation)
+			return
+		case <-timer.C:
+		}
 
-		// Calculate yield for this cycle
-		baseYield := m.config.BaseMiningYield
-		bonusYield := baseYield * float64(miningLaserLevel) * m.config.MiningLaserBonus
-		if hasCargoScanner {
-			bonusYield += baseYield * m.config.CargoScannerBonus
+		if err := m.completeCycle(operation); err != nil {
+			log.Error("Mining cycle failed: player=%s, target=%s: %v", operation.PlayerID, operation.FieldName, err)
 		}
-		totalYield := baseYield + bonusYield
 
-		// Extract resources from target
-		m.extractResources(operation, totalYield)
+		m.mu.Lock()
+		active := operation.Status == StatusActive
+		if active {
+			operation.CycleStart = time.Now()
+			timer.Reset(operation.CycleDuration)
+		}
+		m.mu.Unlock()
 
-		operation.CyclesLeft--
-		log.Debug("Mining cycle completed: cycles_left=%d, yield=%.1f",
-			operation.CyclesLeft, totalYield)
+		if !active {
+			m.finishOperation(operation)
+			return
+		}
 	}
+}
 
-	// Mark as completed
-	operation.Status = "completed"
-	log.Info("Mining completed: player=%s, total_yield=%.1f",
-		operation.PlayerID, operation.CurrentYield)
+// completeCycle extracts one cycle's yield from the operation's field into
+// the ship's cargo. The hold is checked first, since its free space changes
+// as the player trades, and a cycle takes no more than fits. The yield is
+// taken from the field before the cargo is loaded, without holding m.mu, and
+// whatever did not reach the hold is put back. A failed load or save stops
+// the operation.
+func (m *Manager) completeCycle(operation *MiningOperation) error {
+	cargoFree, err := m.cargoFree(operation.ShipID)
 
-	// Update player stats for completed mining operation
-	if m.playerRepo != nil {
-		if player, err := m.playerRepo.GetByID(ctx, operation.PlayerID); err == nil {
-			player.RecordMiningOperation(int64(operation.CurrentYield), operation.Resources)
-			if err := m.playerRepo.Update(ctx, player); err != nil {
-				log.Error("Failed to update player stats for mining operation: %v", err)
-			}
+	m.mu.Lock()
+	if operation.Status != StatusActive {
+		m.mu.Unlock()
+		return nil
+	}
+	if err != nil {
+		operation.Status = StatusFailed
+		operation.Message = "Cargo hold could not be checked"
+		m.mu.Unlock()
+		return err
+	}
+	operation.CargoLeft = cargoFree
+	if cargoFree <= 0 {
+		operation.Status = StatusCompleted
+		operation.Message = "Cargo hold is full"
+		m.mu.Unlock()
+		return nil
+	}
+	field := m.fields[operation.FieldID]
+	m.regrow(field, time.Now())
+	extracted := extractResources(field, math.Min(operation.CycleYield, float64(cargoFree)))
+	for resource, quantity := range extracted {
+		field.Remaining[resource] -= float64(quantity)
+	}
+	m.mu.Unlock()
+
+	// Resources sharing a commodity go into cargo together
+	cargo := make(map[string]int)
+	for resource, quantity := range extracted {
+		cargo[CommodityFor(resource)] += quantity
+	}
+	commodities := make([]string, 0, len(cargo))
+	for commodity := range cargo {
+		commodities = append(commodities, commodity)
+	}
+	sort.Strings(commodities)
+
+	loaded := make(map[string]bool)
+	var loadErr error
+	for _, commodity := range commodities {
+		if loadErr = m.addCargo(operation.ShipID, commodity, cargo[commodity]); loadErr != nil {
+			loadErr = fmt.Errorf("failed to load %s into cargo: %w", commodity, loadErr)
+			break
 		}
+		loaded[commodity] = true
 	}
 
-	// Clean up after 60 seconds
-	time.Sleep(60 * time.Second)
 	m.mu.Lock()
-	delete(m.activeOperations, operation.ShipID)
+	// Only what reached the hold leaves the field
+	for resource, quantity := range extracted {
+		if !loaded[CommodityFor(resource)] {
+			field.Remaining[resource] = math.Min(field.Capacity[resource], field.Remaining[resource]+float64(quantity))
+			continue
+		}
+		operation.Resources[resource] += quantity
+		operation.CurrentYield += float64(quantity)
+	}
+	for commodity := range loaded {
+		operation.Cargo[commodity] += cargo[commodity]
+		operation.CargoLeft -= cargo[commodity]
+	}
+	if loadErr != nil && operation.Status == StatusActive {
+		operation.Status = StatusFailed
+		operation.Message = "Cargo could not be loaded"
+	}
+	if len(loaded) > 0 && m.onInventoryChanged != nil {
+		go m.onInventoryChanged(operation.PlayerID)
+	}
 	m.mu.Unlock()
+
+	var saveErr error
+	if len(loaded) > 0 {
+		saveErr = m.saveField(field.ID)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if saveErr != nil {
+		if operation.Status == StatusActive {
+			operation.Status = StatusFailed
+			operation.Message = "Field could not be saved"
+		}
+		return errors.Join(loadErr, saveErr)
+	}
+	if operation.Status != StatusActive {
+		return loadErr
+	}
+
+	operation.CyclesLeft--
+	log.Debug("Mining cycle completed: cycles_left=%d, yield=%.1f",
+		operation.CyclesLeft, operation.CurrentYield)
+
+	switch {
+	case field.Total() < 1:
+		operation.Status = StatusCompleted
+		operation.Message = field.Name + " is depleted"
+	case operation.CargoLeft <= 0:
+		operation.Status = StatusCompleted
+		operation.Message = "Cargo hold is full"
+	case operation.CyclesLeft <= 0:
+		operation.Status = StatusCompleted
+	}
+	return nil
 }
 
-// extractResources extracts resources from the target
-func (m *Manager) extractResources(operation *MiningOperation, yieldAmount float64) {
-	target := operation.Target
+// cargoFree returns how much cargo space a ship has left
+func (m *Manager) cargoFree(shipID uuid.UUID) (int, error) {
+	if m.ships == nil {
+		return 0, ErrNoShips
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
 
-	// Calculate what percentage of total resources this yield represents
-	totalRemaining := 0.0
-	for _, amount := range target.Resources {
-		totalRemaining += amount
+	ship, err := m.ships.GetByID(ctx, shipID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check cargo space: %w", err)
 	}
+	shipType := models.GetShipTypeByID(ship.TypeID)
+	if shipType == nil {
+		return 0, fmt.Errorf("unknown ship type %s", ship.TypeID)
+	}
+	return ship.GetCargoSpace(shipType), nil
+}
 
-	if totalRemaining <= 0 {
-		return
+// addCargo adds extracted resources to a ship's cargo
+func (m *Manager) addCargo(shipID uuid.UUID, commodityID string, quantity int) error {
+	if m.ships == nil {
+		return ErrNoShips
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	return m.ships.AddCargo(ctx, shipID, commodityID, quantity)
+}
+
+// extractResources works out how many whole units of each resource a yield
+// takes from a field, in proportion to what is left. It does not change the
+// field.
+func extractResources(field *models.ResourceField, yieldAmount float64) map[string]int {
+	totalRemaining := field.Total()
+	if totalRemaining <= 0 || yieldAmount < 1 {
+		return nil
 	}
 
-	yieldPercentage := yieldAmount / totalRemaining
-	if yieldPercentage > 1.0 {
-		yieldPercentage = 1.0
+	yieldPercentage := math.Min(yieldAmount/totalRemaining, 1.0)
+
+	resources := make([]string, 0, len(field.Remaining))
+	for resource := range field.Remaining {
+		resources = append(resources, resource)
 	}
+	sort.Strings(resources)
 
 	// Extract proportional amounts from each resource type
-	for resourceType, remainingAmount := range target.Resources {
-		if remainingAmount > 0 {
-			extracted := remainingAmount * yieldPercentage
-			operation.Resources[resourceType] += int(extracted)
-			target.Resources[resourceType] -= extracted
-			operation.CurrentYield += extracted
+	extracted := make(map[string]int)
+	largest := ""
+	for _, resource := range resources {
+		remaining := field.Remaining[resource]
+		if quantity := int(remaining * yieldPercentage); quantity > 0 {
+			extracted[resource] = quantity
 		}
+		if largest == "" || remaining > field.Remaining[largest] {
+			largest = resource
+		}
+	}
+
+	// A nearly empty field still gives up its last units
+	if len(extracted) == 0 && field.Remaining[largest] >= 1 {
+		extracted[largest] = 1
+	}
+	return extracted
+}
+
+// finishOperation records a finished operation in the player's statistics
+func (m *Manager) finishOperation(operation *MiningOperation) {
+	log.Info("Mining %s: player=%s, total_yield=%.1f",
+		operation.Status, operation.PlayerID, operation.CurrentYield)
+
+	if m.players == nil || operation.CurrentYield <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	if err := m.players.RecordMining(ctx, operation.PlayerID, int64(operation.CurrentYield), operation.Resources); err != nil {
+		log.Error("Failed to update player stats for mining operation: %v", err)
 	}
 }
 
-// CancelOperation cancels an active mining operation
+// CancelOperation cancels an active mining operation. Resources from
+// finished cycles stay in cargo.
 func (m *Manager) CancelOperation(shipID uuid.UUID) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	operation, exists := m.activeOperations[shipID]
-	if !exists {
-		return fmt.Errorf("no active operation")
+	if !exists || operation.Status != StatusActive {
+		return ErrNoOperation
 	}
 
-	if operation.Status != "active" {
-		return fmt.Errorf("operation already completed")
-	}
+	m.stop(operation, "Cancelled")
+	return nil
+}
 
-	operation.Status = "cancelled"
-	log.Info("Mining operation cancelled: player=%s", operation.PlayerID)
+// CancelPlayerOperations cancels every active operation of a player's
+// ships, as when the player jumps, lands or disconnects, and returns how
+// many were running. Resources from finished cycles stay in cargo.
+func (m *Manager) CancelPlayerOperations(playerID uuid.UUID, reason string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	return nil
+	cancelled := 0
+	for _, operation := range m.activeOperations {
+		if operation.PlayerID == playerID && operation.Status == StatusActive {
+			m.stop(operation, reason)
+			cancelled++
+		}
+	}
+	return cancelled
+}
+
+// stop cancels an active operation; its goroutine records it. Caller must
+// hold m.mu.
+func (m *Manager) stop(operation *MiningOperation, reason string) {
+	operation.Status = StatusCancelled
+	operation.Message = reason
+	close(operation.cancel)
+	log.Info("Mining operation cancelled: player=%s, reason=%s", operation.PlayerID, reason)
 }
 
-// GetActiveOperation retrieves an active operation
+// GetActiveOperation returns a snapshot of a ship's active or most recently
+// finished operation
 func (m *Manager) GetActiveOperation(shipID uuid.UUID) (*MiningOperation, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	operation, exists := m.activeOperations[shipID]
-	return operation, exists
+	if !exists {
+		return nil, false
+	}
+	return operation.copy(), true
 }
 
 // GetActiveOperationCount returns the number of active operations
 func (m *Manager) GetActiveOperationCount() int {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return len(m.activeOperations)
+
+	count := 0
+	for _, operation := range m.activeOperations {
+		if operation.Status == StatusActive {
+			count++
+		}
+	}
+	return count
 }
 
 // Helper functions
@@ -433,6 +821,21 @@ func capitalize(s string) string {
 	return s
 }
 
+// copyField returns a copy of a field that is safe to read without holding
+// the manager's lock
+func copyField(field *models.ResourceField) *models.ResourceField {
+	copied := *field
+	copied.Capacity = make(map[string]float64, len(field.Capacity))
+	for resource, amount := range field.Capacity {
+		copied.Capacity[resource] = amount
+	}
+	copied.Remaining = make(map[string]float64, len(field.Remaining))
+	for resource, amount := range field.Remaining {
+		copied.Remaining[resource] = amount
+	}
+	return &copied
+}
+
 // MiningStats contains statistics about mining operations
 type MiningStats struct {
 	ActiveOperations   int
@@ -443,12 +846,14 @@ type MiningStats struct {
 
 // GetStats returns mining statistics for a specific player
 func (m *Manager) GetStats(ctx context.Context, playerID uuid.UUID) MiningStats {
-	m.mu.RLock()
-	activeOperations := len(m.activeOperations)
-	m.mu.RUnlock()
+	activeOperations := m.GetActiveOperationCount()
+
+	if m.players == nil {
+		return MiningStats{ActiveOperations: activeOperations, MostCommonResource: string(ResourceIron)}
+	}
 
 	// Get player from database to retrieve stats
-	player, err := m.playerRepo.GetByID(ctx, playerID)
+	player, err := m.players.GetByID(ctx, playerID)
 	if err != nil {
 		log.Error("Failed to get player stats: %v", err)
 		return MiningStats{
@@ -0,0 +1,428 @@
+// File: internal/mining/manager_test.go
+// Project: Terminal Velocity
+// Description: Tests for resource fields, mining cycles, cancellation and regrowth
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package mining
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// memoryPlayers is an in-memory Players that tallies mining statistics
+type memoryPlayers struct {
+	mu        sync.Mutex
+	ops       int
+	yield     int64
+	resources map[string]int
+}
+
+func (p *memoryPlayers) GetByID(ctx context.Context, id uuid.UUID) (*models.Player, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &models.Player{ID: id, TotalMiningOps: p.ops, TotalYield: p.yield}, nil
+}
+
+func (p *memoryPlayers) RecordMining(ctx context.Context, id uuid.UUID, yield int64, resources map[string]int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ops++
+	p.yield += yield
+	if p.resources == nil {
+		p.resources = make(map[string]int)
+	}
+	for resource, quantity := range resources {
+		p.resources[resource] += quantity
+	}
+	return nil
+}
+
+func (p *memoryPlayers) operations() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.ops
+}
+
+// memoryShips is an in-memory Ships that tallies cargo by commodity. Every
+// ship is a shuttle holding that cargo.
+type memoryShips struct {
+	mu       sync.Mutex
+	cargo    map[string]int
+	failWith error // returned by every load while set
+}
+
+func (s *memoryShips) GetByID(ctx context.Context, id uuid.UUID) (*models.Ship, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ship := newShip()
+	ship.ID = id
+	for commodity, quantity := range s.cargo {
+		ship.Cargo = append(ship.Cargo, models.CargoItem{CommodityID: commodity, Quantity: quantity})
+	}
+	return ship, nil
+}
+
+func (s *memoryShips) AddCargo(ctx context.Context, shipID uuid.UUID, commodityID string, quantity int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failWith != nil {
+		return s.failWith
+	}
+	if s.cargo == nil {
+		s.cargo = make(map[string]int)
+	}
+	s.cargo[commodityID] += quantity
+	return nil
+}
+
+// memoryStore is an in-memory Store
+type memoryStore struct {
+	mu       sync.Mutex
+	fields   map[uuid.UUID]models.ResourceField
+	failWith error  // returned by every write while set
+	onSave   func() // run before every write
+}
+
+func (s *memoryStore) SaveField(ctx context.Context, field *models.ResourceField) error {
+	if s.onSave != nil {
+		s.onSave()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failWith != nil {
+		return s.failWith
+	}
+	if s.fields == nil {
+		s.fields = make(map[uuid.UUID]models.ResourceField)
+	}
+	saved := models.ResourceField{ID: field.ID, SystemID: field.SystemID, UpdatedAt: field.UpdatedAt,
+		Remaining: make(map[string]float64)}
+	for resource, amount := range field.Remaining {
+		saved.Remaining[resource] = amount
+	}
+	s.fields[field.ID] = saved
+	return nil
+}
+
+func (s *memoryStore) ListFields(ctx context.Context) ([]*models.ResourceField, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var fields []*models.ResourceField
+	for _, field := range s.fields {
+		copied := field
+		fields = append(fields, &copied)
+	}
+	return fields, nil
+}
+
+func newSystem(techLevel int) *models.StarSystem {
+	return &models.StarSystem{ID: uuid.New(), Name: "Test", TechLevel: techLevel}
+}
+
+func newShip(outfits ...string) *models.Ship {
+	return &models.Ship{ID: uuid.New(), TypeID: "shuttle", Outfits: outfits}
+}
+
+// firstAsteroid returns the first charted asteroid in a system
+func firstAsteroid(t *testing.T, m *Manager, system *models.StarSystem) *models.ResourceField {
+	t.Helper()
+	for _, field := range m.ScanForResources(system, 0) {
+		if field.Type == models.FieldAsteroid {
+			return field
+		}
+	}
+	t.Fatal("system has no charted asteroid")
+	return nil
+}
+
+// waitFinished waits for a ship's operation to stop and be recorded
+func waitFinished(t *testing.T, m *Manager, players *memoryPlayers, shipID uuid.UUID, ops int) *MiningOperation {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if op, _ := m.GetActiveOperation(shipID); op != nil && op.Status != StatusActive && players.operations() >= ops {
+			return op
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("mining operation did not finish")
+	return nil
+}
+
+func TestFieldsAreSeededFromTheSystem(t *testing.T) {
+	system := newSystem(5)
+	first := NewManager(nil, nil).ScanForResources(system, 2)
+	again := NewManager(nil, nil).ScanForResources(system, 2)
+
+	if len(first) == 0 || len(first) != len(again) {
+		t.Fatalf("got %d and %d fields, want the same non-zero number", len(first), len(again))
+	}
+	for i := range first {
+		if first[i].ID != again[i].ID || first[i].Name != again[i].Name || first[i].Total() != again[i].Total() {
+			t.Errorf("field %d differs between managers: %+v vs %+v", i, first[i], again[i])
+		}
+	}
+
+	// Uncharted fields need a scanner
+	for _, field := range NewManager(nil, nil).ScanForResources(system, 0) {
+		if field.Uncharted {
+			t.Errorf("uncharted field %s found without a scanner", field.Name)
+		}
+	}
+}
+
+func TestMiningFillsCargoAndDepletesField(t *testing.T) {
+	players, ships, store := &memoryPlayers{}, &memoryShips{}, &memoryStore{}
+	m := NewManager(ships, players)
+	m.SetStore(store)
+	m.config.MiningCycleDuration = 5 * time.Millisecond
+	m.config.FieldRegrowthTime = 0
+	notified := make(chan uuid.UUID, 20)
+	m.SetInventoryChangedCallback(func(playerID uuid.UUID) { notified <- playerID })
+
+	system := newSystem(5)
+	field := firstAsteroid(t, m, system)
+	playerID, ship := uuid.New(), newShip("mining_laser_mk2")
+
+	op, err := m.StartMining(playerID, ship, system, field.ID)
+	if err != nil {
+		t.Fatalf("StartMining failed: %v", err)
+	}
+	if want := m.config.BaseMiningYield * (1 + 2*m.config.MiningLaserBonus); op.CycleYield != want {
+		t.Errorf("cycle yield with a Mk2 laser = %.1f, want %.1f", op.CycleYield, want)
+	}
+	if _, err := m.StartMining(playerID, ship, system, field.ID); !errors.Is(err, ErrAlreadyMining) {
+		t.Errorf("second StartMining error = %v, want ErrAlreadyMining", err)
+	}
+
+	op = waitFinished(t, m, players, ship.ID, 1)
+	if op.Status != StatusCompleted || op.CurrentYield <= 0 {
+		t.Fatalf("operation = %s with yield %.1f, want completed with a yield", op.Status, op.CurrentYield)
+	}
+
+	loaded := 0
+	for _, quantity := range ships.cargo {
+		loaded += quantity
+	}
+	if float64(loaded) != op.CurrentYield || int64(loaded) != players.yield {
+		t.Errorf("cargo got %d, operation yielded %.1f, stats recorded %d", loaded, op.CurrentYield, players.yield)
+	}
+	if shipType := models.GetShipTypeByID(ship.TypeID); loaded > shipType.CargoSpace {
+		t.Errorf("loaded %d into a %d ton hold", loaded, shipType.CargoSpace)
+	}
+	if <-notified != playerID {
+		t.Error("inventory callback not run for the miner")
+	}
+
+	// The field stays depleted, in memory and in the store
+	after := firstAsteroid(t, m, system)
+	if after.ID != field.ID || after.Total() != field.Total()-op.CurrentYield {
+		t.Errorf("field has %.1f left, want %.1f", after.Total(), field.Total()-op.CurrentYield)
+	}
+	reloaded := NewManager(nil, nil)
+	reloaded.config.FieldRegrowthTime = 0
+	reloaded.SetStore(store)
+	if err := reloaded.Load(context.Background()); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if restored := firstAsteroid(t, reloaded, system); restored.Total() != after.Total() {
+		t.Errorf("restored field has %.1f left, want %.1f", restored.Total(), after.Total())
+	}
+}
+
+func TestCancelMining(t *testing.T) {
+	players, ships := &memoryPlayers{}, &memoryShips{}
+	m := NewManager(ships, players)
+	m.config.MiningCycleDuration = time.Hour
+
+	system := newSystem(5)
+	field := firstAsteroid(t, m, system)
+	ship := newShip()
+
+	if _, err := m.StartMining(uuid.New(), ship, system, field.ID); err != nil {
+		t.Fatalf("StartMining failed: %v", err)
+	}
+	if m.GetActiveOperationCount() != 1 {
+		t.Fatalf("active operations = %d, want 1", m.GetActiveOperationCount())
+	}
+	if err := m.CancelOperation(ship.ID); err != nil {
+		t.Fatalf("CancelOperation failed: %v", err)
+	}
+	if err := m.CancelOperation(ship.ID); !errors.Is(err, ErrNoOperation) {
+		t.Errorf("second CancelOperation error = %v, want ErrNoOperation", err)
+	}
+
+	op, _ := m.GetActiveOperation(ship.ID)
+	if op.Status != StatusCancelled || m.GetActiveOperationCount() != 0 || len(ships.cargo) != 0 {
+		t.Errorf("after cancel: status %s, %d active, cargo %v", op.Status, m.GetActiveOperationCount(), ships.cargo)
+	}
+	if _, err := m.StartMining(uuid.New(), ship, system, field.ID); err != nil {
+		t.Errorf("StartMining after a cancel failed: %v", err)
+	}
+}
+
+func TestLeavingCancelsMining(t *testing.T) {
+	players, ships := &memoryPlayers{}, &memoryShips{}
+	m := NewManager(ships, players)
+	m.config.MiningCycleDuration = time.Hour
+
+	system := newSystem(5)
+	field := firstAsteroid(t, m, system)
+	playerID, ship, other := uuid.New(), newShip(), newShip()
+
+	if _, err := m.StartMining(playerID, ship, system, field.ID); err != nil {
+		t.Fatalf("StartMining failed: %v", err)
+	}
+	if _, err := m.StartMining(uuid.New(), other, system, field.ID); err != nil {
+		t.Fatalf("StartMining for another player failed: %v", err)
+	}
+
+	if cancelled := m.CancelPlayerOperations(playerID, "Jumped out of the system"); cancelled != 1 {
+		t.Fatalf("cancelled %d operations, want 1", cancelled)
+	}
+	op, _ := m.GetActiveOperation(ship.ID)
+	if op.Status != StatusCancelled || op.Message != "Jumped out of the system" {
+		t.Errorf("operation = %s (%q), want cancelled for the jump", op.Status, op.Message)
+	}
+	if op, _ := m.GetActiveOperation(other.ID); op.Status != StatusActive {
+		t.Errorf("other player's operation = %s, want active", op.Status)
+	}
+	if cancelled := m.CancelPlayerOperations(playerID, "Disconnected"); cancelled != 0 {
+		t.Errorf("cancelled %d operations a second time, want 0", cancelled)
+	}
+}
+
+func TestMiningStopsAtFreeHoldSpace(t *testing.T) {
+	system := newSystem(5)
+	capacity := models.GetShipTypeByID(newShip().TypeID).CargoSpace
+
+	// Cargo bought after mining started leaves 5 tons of the shuttle's 20
+	players, ships, store := &memoryPlayers{}, &memoryShips{cargo: map[string]int{"food": capacity - 5}}, &memoryStore{}
+	m := NewManager(ships, players)
+	m.SetStore(store)
+	m.config.MiningCycleDuration = 5 * time.Millisecond
+
+	// Fields are saved without holding the manager's lock
+	store.onSave = func() { m.GetActiveOperationCount() }
+
+	field := firstAsteroid(t, m, system)
+	ship := newShip()
+	if _, err := m.StartMining(uuid.New(), ship, system, field.ID); err != nil {
+		t.Fatalf("StartMining failed: %v", err)
+	}
+	op := waitFinished(t, m, players, ship.ID, 1)
+	loaded := 0
+	for _, quantity := range ships.cargo {
+		loaded += quantity
+	}
+	if op.Status != StatusCompleted || loaded <= capacity-5 || loaded > capacity {
+		t.Errorf("operation = %s with %d tons in the hold, want completed with %d-%d", op.Status, loaded, capacity-4, capacity)
+	}
+
+	// A hold filled before the first cycle ends the operation without mining
+	players, ships = &memoryPlayers{}, &memoryShips{cargo: map[string]int{"food": capacity}}
+	m = NewManager(ships, players)
+	m.config.MiningCycleDuration = 5 * time.Millisecond
+	field = firstAsteroid(t, m, system)
+	if _, err := m.StartMining(uuid.New(), ship, system, field.ID); err != nil {
+		t.Fatalf("StartMining failed: %v", err)
+	}
+	op = waitFinished(t, m, players, ship.ID, 0)
+	if op.Status != StatusCompleted || op.Message != "Cargo hold is full" || op.CurrentYield != 0 {
+		t.Errorf("operation = %s (%q) with yield %.1f, want completed with a full hold and nothing mined",
+			op.Status, op.Message, op.CurrentYield)
+	}
+}
+
+func TestStartMiningChecks(t *testing.T) {
+	m := NewManager(&memoryShips{}, nil)
+	system := newSystem(5)
+	field := firstAsteroid(t, m, system)
+
+	if _, err := NewManager(nil, nil).StartMining(uuid.New(), newShip(), system, field.ID); !errors.Is(err, ErrNoShips) {
+		t.Errorf("mining without ships error = %v, want ErrNoShips", err)
+	}
+	if _, err := m.StartMining(uuid.New(), newShip(), system, uuid.New()); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("unknown field error = %v, want ErrFieldNotFound", err)
+	}
+
+	full := newShip()
+	full.Cargo = []models.CargoItem{{CommodityID: "ore", Quantity: models.GetShipTypeByID(full.TypeID).CargoSpace}}
+	if _, err := m.StartMining(uuid.New(), full, system, field.ID); !errors.Is(err, ErrCargoFull) {
+		t.Errorf("full hold error = %v, want ErrCargoFull", err)
+	}
+
+	m.mu.Lock()
+	for resource := range m.fields[field.ID].Remaining {
+		m.fields[field.ID].Remaining[resource] = 0
+	}
+	m.mu.Unlock()
+	m.config.FieldRegrowthTime = 0
+	if _, err := m.StartMining(uuid.New(), newShip(), system, field.ID); !errors.Is(err, ErrFieldDepleted) {
+		t.Errorf("empty field error = %v, want ErrFieldDepleted", err)
+	}
+}
+
+func TestFailedWritesStopMining(t *testing.T) {
+	system := newSystem(5)
+
+	// Cargo that cannot be loaded stays in the field
+	players, ships := &memoryPlayers{}, &memoryShips{failWith: errors.New("database unavailable")}
+	m := NewManager(ships, players)
+	m.config.MiningCycleDuration = 5 * time.Millisecond
+	field := firstAsteroid(t, m, system)
+	ship := newShip()
+	if _, err := m.StartMining(uuid.New(), ship, system, field.ID); err != nil {
+		t.Fatalf("StartMining failed: %v", err)
+	}
+	op := waitFinished(t, m, players, ship.ID, 0)
+	if op.Status != StatusFailed || op.CurrentYield != 0 {
+		t.Errorf("operation = %s with yield %.1f, want failed with nothing mined", op.Status, op.CurrentYield)
+	}
+	if after := firstAsteroid(t, m, system); after.Total() != field.Total() {
+		t.Errorf("field has %.1f left after a failed load, want %.1f", after.Total(), field.Total())
+	}
+
+	// A field that cannot be saved stops the operation after the cargo it loaded
+	players, ships, store := &memoryPlayers{}, &memoryShips{}, &memoryStore{failWith: errors.New("database unavailable")}
+	m = NewManager(ships, players)
+	m.SetStore(store)
+	m.config.MiningCycleDuration = 5 * time.Millisecond
+	field = firstAsteroid(t, m, system)
+	if _, err := m.StartMining(uuid.New(), ship, system, field.ID); err != nil {
+		t.Fatalf("StartMining failed: %v", err)
+	}
+	op = waitFinished(t, m, players, ship.ID, 1)
+	if op.Status != StatusFailed || op.TotalCycles-op.CyclesLeft != 0 || op.CurrentYield <= 0 {
+		t.Errorf("operation = %s after %d cycles with yield %.1f, want failed in the first cycle",
+			op.Status, op.TotalCycles-op.CyclesLeft, op.CurrentYield)
+	}
+}
+
+func TestFieldsRegrow(t *testing.T) {
+	m := NewManager(nil, nil)
+	system := newSystem(5)
+	field := firstAsteroid(t, m, system)
+
+	m.mu.Lock()
+	stored := m.fields[field.ID]
+	for resource := range stored.Remaining {
+		stored.Remaining[resource] = 0
+	}
+	stored.UpdatedAt = time.Now().Add(-m.config.FieldRegrowthTime / 2)
+	m.mu.Unlock()
+
+	regrown := firstAsteroid(t, m, system).Total()
+	if half := field.CapacityTotal() / 2; regrown < half*0.99 || regrown > half*1.01 {
+		t.Errorf("after half the regrowth time the field has %.1f, want about %.1f", regrown, half)
+	}
+}
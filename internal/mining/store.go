@@ -0,0 +1,126 @@
+// File: internal/mining/store.go
+// Project: Terminal Velocity
+// Description: Persistence, player and cargo interfaces for mining
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package mining
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// dbTimeout bounds each write made while mining
+const dbTimeout = 5 * time.Second
+
+// Store persists how far resource fields have been mined so depletion
+// survives restarts. The database package's MiningRepository implements it.
+type Store interface {
+	SaveField(ctx context.Context, field *models.ResourceField) error
+
+	// ListFields returns every stored field; only ID, SystemID, Remaining
+	// and UpdatedAt need to be set
+	ListFields(ctx context.Context) ([]*models.ResourceField, error)
+}
+
+// Players reads players and records their mining statistics. The database
+// package's PlayerRepository implements it.
+type Players interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Player, error)
+
+	// RecordMining adds a finished operation, its yield and the resources
+	// it extracted to the player's statistics
+	RecordMining(ctx context.Context, id uuid.UUID, yield int64, resources map[string]int) error
+}
+
+// Ships reads ship holds and puts extracted resources into cargo. The
+// database package's ShipRepository implements it.
+type Ships interface {
+	// GetByID returns a ship with its cargo and outfits loaded
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Ship, error)
+
+	// AddCargo does not check capacity; mining limits each cycle to the
+	// free space GetByID reports
+	AddCargo(ctx context.Context, shipID uuid.UUID, commodityID string, quantity int) error
+}
+
+// SetStore sets where field depletion is persisted. Without a store fields
+// are kept in memory and start full after a restart.
+func (m *Manager) SetStore(store Store) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store = store
+}
+
+// Load reads how far fields have been mined from the store. Fields are
+// generated when a system is first surveyed and pick up the stored amounts
+// then.
+func (m *Manager) Load(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.store == nil {
+		return nil
+	}
+
+	fields, err := m.store.ListFields(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.saved = make(map[uuid.UUID]*models.ResourceField, len(fields))
+	for _, field := range fields {
+		m.saved[field.ID] = field
+	}
+	// Systems surveyed before the load are generated again on next use
+	m.systems = make(map[uuid.UUID][]*models.ResourceField)
+	m.fields = make(map[uuid.UUID]*models.ResourceField)
+
+	log.Info("Loaded mining: mined fields=%d", len(fields))
+	return nil
+}
+
+// persist runs a store write with a timeout and returns why it failed, so
+// callers can stop before memory runs ahead of the store
+func (m *Manager) persist(store Store, what string, write func(ctx context.Context, store Store) error) error {
+	if store == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	if err := write(ctx, store); err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+	return nil
+}
+
+// saveField writes a field's remaining resources to the store. Caller must
+// not hold m.mu: the field is copied under the lock and written after it is
+// released. Saves run one at a time and copy the field when they start, so
+// the store is left with the latest amounts when miners share a field.
+func (m *Manager) saveField(fieldID uuid.UUID) error {
+	m.saveMu.Lock()
+	defer m.saveMu.Unlock()
+
+	m.mu.RLock()
+	store := m.store
+	field, exists := m.fields[fieldID]
+	if exists {
+		field = copyField(field)
+	}
+	m.mu.RUnlock()
+
+	if !exists {
+		return nil
+	}
+	return m.persist(store, "resource field "+field.Name, func(ctx context.Context, store Store) error {
+		return store.SaveField(ctx, field)
+	})
+}
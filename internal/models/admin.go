@@ -241,6 +241,9 @@ type ServerSettings struct {
 	EnableFactions     bool `json:"enable_factions"`
 	EnableAchievements bool `json:"enable_achievements"`
 	EnableLeaderboards bool `json:"enable_leaderboards"`
+
+	// Security
+	RequireAdmin2FA bool `json:"require_admin_2fa"` // Admins must enroll in two-factor authentication
 }
 
 // NewAdminUser creates a new admin user
@@ -0,0 +1,193 @@
+// File: internal/models/arena.go
+// Project: Terminal Velocity
+// Description: Arena models - ranked matches, rankings, tournaments and seasons
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArenaMatchType defines the type of arena match
+type ArenaMatchType string
+
+const (
+	MatchTypeDuel           ArenaMatchType = "duel"            // 1v1
+	MatchTypeTeamDeathmatch ArenaMatchType = "team_deathmatch" // Team vs Team
+	MatchTypeFreeForAll     ArenaMatchType = "free_for_all"    // Everyone vs Everyone
+	MatchTypeCaptureFlag    ArenaMatchType = "capture_flag"    // CTF mode
+	MatchTypeKingOfHill     ArenaMatchType = "king_of_hill"    // Control point
+	MatchTypeElimination    ArenaMatchType = "elimination"     // Last standing wins
+)
+
+// Arena match statuses
+const (
+	MatchWaiting    = "waiting"     // Waiting for a free arena
+	MatchInProgress = "in_progress" // Being fought
+	MatchCompleted  = "completed"   // Finished with a result
+	MatchCancelled  = "cancelled"   // Abandoned without a result
+)
+
+// ArenaMatch represents an arena match, live or finished
+type ArenaMatch struct {
+	ID            uuid.UUID              `json:"id"`
+	ArenaID       uuid.UUID              `json:"arena_id"` // uuid.Nil while waiting for an arena
+	Type          ArenaMatchType         `json:"type"`
+	Players       []uuid.UUID            `json:"players"`
+	Teams         map[string][]uuid.UUID `json:"teams"`  // "red" -> player IDs, "blue" -> player IDs
+	Scores        map[uuid.UUID]int      `json:"scores"` // player_id -> score (damage dealt)
+	StartTime     time.Time              `json:"start_time"`
+	EndTime       time.Time              `json:"end_time"`
+	Status        string                 `json:"status"` // MatchWaiting, MatchInProgress, MatchCompleted, MatchCancelled
+	Winner        uuid.UUID              `json:"winner"` // uuid.Nil for a draw
+	MatchData     *ArenaMatchData        `json:"match_data"`
+	TournamentID  *uuid.UUID             `json:"tournament_id,omitempty"` // If part of tournament
+	Season        int                    `json:"season"`
+	RatingChanges map[uuid.UUID]int      `json:"rating_changes,omitempty"` // ELO change per player once completed
+}
+
+// Team returns the team a player fights for ("" outside team matches)
+func (m *ArenaMatch) Team(playerID uuid.UUID) string {
+	for team, members := range m.Teams {
+		for _, member := range members {
+			if member == playerID {
+				return team
+			}
+		}
+	}
+	return ""
+}
+
+// Won reports whether a player won the match. In team matches every member
+// of the winner's team wins.
+func (m *ArenaMatch) Won(playerID uuid.UUID) bool {
+	if m.Status != MatchCompleted || m.Winner == uuid.Nil {
+		return false
+	}
+	if playerID == m.Winner {
+		return true
+	}
+	team := m.Team(m.Winner)
+	return team != "" && m.Team(playerID) == team
+}
+
+// HasPlayer reports whether a player takes part in the match
+func (m *ArenaMatch) HasPlayer(playerID uuid.UUID) bool {
+	for _, id := range m.Players {
+		if id == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// ArenaMatchData contains detailed match statistics
+type ArenaMatchData struct {
+	Kills       map[uuid.UUID]int     `json:"kills"`
+	Deaths      map[uuid.UUID]int     `json:"deaths"`
+	Assists     map[uuid.UUID]int     `json:"assists"`
+	DamageDealt map[uuid.UUID]float64 `json:"damage_dealt"`
+	DamageTaken map[uuid.UUID]float64 `json:"damage_taken"`
+	Objectives  map[uuid.UUID]int     `json:"objectives"` // Flags captured, points controlled, etc.
+}
+
+// NewArenaMatchData creates empty match statistics
+func NewArenaMatchData() *ArenaMatchData {
+	return &ArenaMatchData{
+		Kills:       make(map[uuid.UUID]int),
+		Deaths:      make(map[uuid.UUID]int),
+		Assists:     make(map[uuid.UUID]int),
+		DamageDealt: make(map[uuid.UUID]float64),
+		DamageTaken: make(map[uuid.UUID]float64),
+		Objectives:  make(map[uuid.UUID]int),
+	}
+}
+
+// TournamentType defines tournament format
+type TournamentType string
+
+const (
+	TournamentSingleElimination TournamentType = "single_elimination"
+	TournamentDoubleElimination TournamentType = "double_elimination"
+	TournamentRoundRobin        TournamentType = "round_robin"
+	TournamentSwiss             TournamentType = "swiss"
+)
+
+// Tournament statuses
+const (
+	TournamentRegistration = "registration" // Open for entries
+	TournamentInProgress   = "in_progress"  // Bracket being played
+	TournamentCompleted    = "completed"    // Prizes paid
+	TournamentCancelled    = "cancelled"    // Too few entries; fees refunded
+)
+
+// Tournament represents a competitive tournament
+type Tournament struct {
+	ID           uuid.UUID          `json:"id"`
+	Name         string             `json:"name"`
+	Type         TournamentType     `json:"type"`
+	MatchType    ArenaMatchType     `json:"match_type"`
+	EntryFee     int64              `json:"entry_fee"`
+	PrizePool    int64              `json:"prize_pool"`
+	MaxPlayers   int                `json:"max_players"`
+	Participants []uuid.UUID        `json:"participants"`
+	Bracket      *TournamentBracket `json:"bracket,omitempty"`
+	CurrentRound int                `json:"current_round"`
+	StartTime    time.Time          `json:"start_time"` // Scheduled start while registering
+	EndTime      time.Time          `json:"end_time"`
+	Status       string             `json:"status"`  // TournamentRegistration, TournamentInProgress, ...
+	Winners      []uuid.UUID        `json:"winners"` // 1st, 2nd, 3rd place
+	ByeAdvancers []uuid.UUID        `json:"bye_advancers,omitempty"`
+}
+
+// HasParticipant reports whether a player entered the tournament
+func (t *Tournament) HasParticipant(playerID uuid.UUID) bool {
+	for _, id := range t.Participants {
+		if id == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// TournamentBracket represents the tournament structure
+type TournamentBracket struct {
+	Rounds []*TournamentRound `json:"rounds"`
+}
+
+// TournamentRound represents a round in the bracket
+type TournamentRound struct {
+	RoundNumber int         `json:"round_number"`
+	Matches     []uuid.UUID `json:"matches"` // Match IDs
+	Status      string      `json:"status"`  // "pending", "in_progress", "completed"
+}
+
+// ArenaRanking tracks a player's competitive ranking
+type ArenaRanking struct {
+	PlayerID       uuid.UUID `json:"player_id"`
+	PlayerName     string    `json:"player_name"`
+	ELO            int       `json:"elo"`
+	Tier           string    `json:"tier"`
+	Division       int       `json:"division"` // 1-5 within tier
+	Wins           int       `json:"wins"`
+	Losses         int       `json:"losses"`
+	Draws          int       `json:"draws"`
+	WinStreak      int       `json:"win_streak"`
+	HighestELO     int       `json:"highest_elo"`
+	TournamentsWon int       `json:"tournaments_won"`
+	SeasonMatches  int       `json:"season_matches"` // Matches played this season
+	LastMatchTime  time.Time `json:"last_match_time"`
+}
+
+// ArenaSeason is a ranked season. Rankings are softly reset and rewards
+// are paid when it ends.
+type ArenaSeason struct {
+	Number    int       `json:"number"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
@@ -0,0 +1,132 @@
+// File: internal/models/content.go
+// Project: Terminal Velocity
+// Description: Content pack registry - the active ship, commodity, weapon, outfit and equipment definitions
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// Game content (ship types, commodities, weapons, outfits and outfitting
+// equipment) is read from the active content pack rather than straight from
+// the Standard* literals. The literals make up the built-in default pack,
+// which is active until the server installs a pack loaded from a file (see
+// the content package for loading and validation).
+//
+// Lookup functions (GetShipTypeByID, GetCommodityByID, ...) and the All*
+// accessors read from the active pack. Installing a pack swaps it atomically:
+// readers see either the old pack or the new one, never a mix. Returned
+// values belong to the pack and must not be modified.
+
+package models
+
+import "sync/atomic"
+
+// ContentPack is a complete set of game content definitions
+type ContentPack struct {
+	Name        string      `json:"name"`
+	Version     string      `json:"version"`
+	ShipTypes   []ShipType  `json:"ship_types"`
+	Commodities []Commodity `json:"commodities"`
+	Weapons     []Weapon    `json:"weapons"`
+	Outfits     []Outfit    `json:"outfits"`
+	Equipment   []Equipment `json:"equipment"`
+}
+
+// DefaultContentPackName is the name of the built-in content pack
+const DefaultContentPackName = "builtin"
+
+// contentIndex is an installed content pack with lookup tables by ID
+type contentIndex struct {
+	pack        *ContentPack
+	shipTypes   map[string]*ShipType
+	commodities map[string]*Commodity
+	weapons     map[string]*Weapon
+	outfits     map[string]*Outfit
+	equipment   map[string]*Equipment
+}
+
+// activeContent holds the installed *contentIndex
+var activeContent atomic.Pointer[contentIndex]
+
+func init() {
+	SetContentPack(DefaultContentPack())
+}
+
+// DefaultContentPack returns a copy of the built-in content pack
+func DefaultContentPack() *ContentPack {
+	return &ContentPack{
+		Name:        DefaultContentPackName,
+		Version:     "1.0.0",
+		ShipTypes:   append([]ShipType(nil), StandardShipTypes...),
+		Commodities: append([]Commodity(nil), StandardCommodities...),
+		Weapons:     append([]Weapon(nil), StandardWeapons...),
+		Outfits:     append([]Outfit(nil), StandardOutfits...),
+		Equipment:   append([]Equipment(nil), StandardEquipment...),
+	}
+}
+
+// SetContentPack makes pack the active content pack. The pack is used as
+// is and must not be modified afterwards; callers are expected to have
+// validated it.
+func SetContentPack(pack *ContentPack) {
+	index := &contentIndex{
+		pack:        pack,
+		shipTypes:   make(map[string]*ShipType, len(pack.ShipTypes)),
+		commodities: make(map[string]*Commodity, len(pack.Commodities)),
+		weapons:     make(map[string]*Weapon, len(pack.Weapons)),
+		outfits:     make(map[string]*Outfit, len(pack.Outfits)),
+		equipment:   make(map[string]*Equipment, len(pack.Equipment)),
+	}
+
+	for i := range pack.ShipTypes {
+		index.shipTypes[pack.ShipTypes[i].ID] = &pack.ShipTypes[i]
+	}
+	for i := range pack.Commodities {
+		index.commodities[pack.Commodities[i].ID] = &pack.Commodities[i]
+	}
+	for i := range pack.Weapons {
+		index.weapons[pack.Weapons[i].ID] = &pack.Weapons[i]
+	}
+	for i := range pack.Outfits {
+		index.outfits[pack.Outfits[i].ID] = &pack.Outfits[i]
+	}
+	for i := range pack.Equipment {
+		index.equipment[pack.Equipment[i].ID] = &pack.Equipment[i]
+	}
+
+	activeContent.Store(index)
+}
+
+// ActiveContentPack returns the active content pack
+func ActiveContentPack() *ContentPack {
+	return activeContent.Load().pack
+}
+
+// AllShipTypes returns every ship type in the active content pack
+func AllShipTypes() []ShipType {
+	return activeContent.Load().pack.ShipTypes
+}
+
+// AllCommodities returns every commodity in the active content pack
+func AllCommodities() []Commodity {
+	return activeContent.Load().pack.Commodities
+}
+
+// AllWeapons returns every weapon in the active content pack
+func AllWeapons() []Weapon {
+	return activeContent.Load().pack.Weapons
+}
+
+// AllOutfits returns every outfit in the active content pack
+func AllOutfits() []Outfit {
+	return activeContent.Load().pack.Outfits
+}
+
+// AllEquipment returns every outfitting equipment item in the active content pack
+func AllEquipment() []Equipment {
+	return activeContent.Load().pack.Equipment
+}
+
+// GetEquipmentByID finds outfitting equipment by its ID
+func GetEquipmentByID(id string) *Equipment {
+	return activeContent.Load().equipment[id]
+}
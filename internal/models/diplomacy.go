@@ -0,0 +1,167 @@
+// File: internal/models/diplomacy.go
+// Project: Terminal Velocity
+// Description: Diplomacy models - alliances, wars, treaties, relations and proposals between player factions
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Alliance represents a multi-faction alliance
+type Alliance struct {
+	ID              uuid.UUID   `json:"id"`
+	Name            string      `json:"name"`
+	Description     string      `json:"description"`
+	LeaderFactionID uuid.UUID   `json:"leader_faction_id"`
+	MemberFactions  []uuid.UUID `json:"member_factions"` // Members other than the leader
+	CreatedAt       time.Time   `json:"created_at"`
+	Status          string      `json:"status"`   // "active", "dissolved"
+	Treasury        int64       `json:"treasury"` // Shared alliance treasury
+	LastMaintenance time.Time   `json:"last_maintenance"`
+}
+
+// HasFaction reports whether a faction leads or belongs to the alliance
+func (a *Alliance) HasFaction(factionID uuid.UUID) bool {
+	if a.LeaderFactionID == factionID {
+		return true
+	}
+	for _, memberID := range a.MemberFactions {
+		if memberID == factionID {
+			return true
+		}
+	}
+	return false
+}
+
+// AllFactions returns the leader followed by the other members
+func (a *Alliance) AllFactions() []uuid.UUID {
+	return append([]uuid.UUID{a.LeaderFactionID}, a.MemberFactions...)
+}
+
+// War represents a conflict between factions/alliances
+type War struct {
+	ID                uuid.UUID         `json:"id"`
+	Name              string            `json:"name"`
+	AggressorFactions []uuid.UUID       `json:"aggressor_factions"`
+	DefenderFactions  []uuid.UUID       `json:"defender_factions"`
+	StartTime         time.Time         `json:"start_time"`
+	EndTime           time.Time         `json:"end_time"`
+	Status            string            `json:"status"`     // "active", "truce_proposed", "ended"
+	WarScore          map[uuid.UUID]int `json:"war_score"`  // faction_id -> war score
+	Exhaustion        float64           `json:"exhaustion"` // 0.0-1.0, increases over time
+	TruceProposedBy   uuid.UUID         `json:"truce_proposed_by"`
+	TruceProposedAt   time.Time         `json:"truce_proposed_at"`
+}
+
+// IsOngoing reports whether fighting continues. A proposed truce does not
+// stop the war until it is accepted.
+func (w *War) IsOngoing() bool {
+	return w.Status == "active" || w.Status == "truce_proposed"
+}
+
+// Side returns the factions on the same side as factionID and on the
+// opposing side, or nil slices if the faction is not in the war
+func (w *War) Side(factionID uuid.UUID) (allies, enemies []uuid.UUID) {
+	for _, id := range w.AggressorFactions {
+		if id == factionID {
+			return w.AggressorFactions, w.DefenderFactions
+		}
+	}
+	for _, id := range w.DefenderFactions {
+		if id == factionID {
+			return w.DefenderFactions, w.AggressorFactions
+		}
+	}
+	return nil, nil
+}
+
+// TreatyType defines types of treaties
+type TreatyType string
+
+const (
+	TreatyNonAggression  TreatyType = "non_aggression"  // Cannot attack each other
+	TreatyTradeAgreement TreatyType = "trade_agreement" // Reduced market fees in each other's territory
+	TreatyMutualDefense  TreatyType = "mutual_defense"  // Defend if attacked
+	TreatyResearchPact   TreatyType = "research_pact"   // Share technology
+)
+
+// TreatyTypes lists every treaty type in display order
+var TreatyTypes = []TreatyType{
+	TreatyNonAggression,
+	TreatyTradeAgreement,
+	TreatyMutualDefense,
+	TreatyResearchPact,
+}
+
+// Treaty represents a diplomatic agreement
+type Treaty struct {
+	ID         uuid.UUID  `json:"id"`
+	Type       TreatyType `json:"type"`
+	Faction1   uuid.UUID  `json:"faction1"`
+	Faction2   uuid.UUID  `json:"faction2"`
+	Terms      string     `json:"terms"` // Description of treaty terms
+	SignedAt   time.Time  `json:"signed_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	Status     string     `json:"status"` // "active", "violated", "expired"
+	ViolatedBy uuid.UUID  `json:"violated_by"`
+}
+
+// Binds reports whether the treaty is in force between the two factions
+func (t *Treaty) Binds(faction1, faction2 uuid.UUID, now time.Time) bool {
+	if t.Status != "active" || !now.Before(t.ExpiresAt) {
+		return false
+	}
+	return (t.Faction1 == faction1 && t.Faction2 == faction2) ||
+		(t.Faction1 == faction2 && t.Faction2 == faction1)
+}
+
+// Relation tracks relationship between two factions
+type Relation struct {
+	Faction1  uuid.UUID `json:"faction1"`
+	Faction2  uuid.UUID `json:"faction2"`
+	Value     float64   `json:"value"`  // -1.0 (hostile) to +1.0 (friendly)
+	Status    string    `json:"status"` // "hostile", "neutral", "friendly", "allied"
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProposalKind is what a diplomatic proposal asks the other faction to agree to
+type ProposalKind string
+
+const (
+	ProposalAlliance ProposalKind = "alliance" // Form or join an alliance
+	ProposalTreaty   ProposalKind = "treaty"   // Sign a treaty
+)
+
+// DiplomaticProposal is an offer from one faction's leader awaiting the
+// other faction leader's answer. Truces are proposed on the War itself.
+type DiplomaticProposal struct {
+	ID            uuid.UUID    `json:"id"`
+	Kind          ProposalKind `json:"kind"`
+	TreatyType    TreatyType   `json:"treaty_type,omitempty"` // Treaty proposals only
+	FromFactionID uuid.UUID    `json:"from_faction_id"`
+	ToFactionID   uuid.UUID    `json:"to_faction_id"`
+	ProposedBy    uuid.UUID    `json:"proposed_by"` // Leader who made the proposal
+	CreatedAt     time.Time    `json:"created_at"`
+	ExpiresAt     time.Time    `json:"expires_at"`
+}
+
+// IsExpired reports whether the proposal can no longer be accepted
+func (p *DiplomaticProposal) IsExpired(now time.Time) bool {
+	return !now.Before(p.ExpiresAt)
+}
+
+// Describe returns a short description of what the proposal offers
+func (p *DiplomaticProposal) Describe() string {
+	switch p.Kind {
+	case ProposalTreaty:
+		return string(p.TreatyType) + " treaty"
+	default:
+		return string(p.Kind)
+	}
+}
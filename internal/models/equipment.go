@@ -1,7 +1,7 @@
 // File: internal/models/equipment.go
 // Project: Terminal Velocity
 // Description: Ship equipment system - weapons and outfits
-// Version: 1.1.0
+// Version: 1.3.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 //
@@ -52,7 +52,8 @@
 
 package models
 
-// Standard weapons available in the game.
+// Standard weapons available in the game (the weapon section of the
+// built-in default content pack; the game reads weapons from the active pack).
 //
 // Weapons are the primary offensive equipment for ships. Each weapon has:
 //   - Damage: Base damage per hit
@@ -215,7 +216,8 @@ var StandardWeapons = []Weapon{
 	},
 }
 
-// Standard outfits available in the game
+// Standard outfits available in the game (the outfit section of the
+// built-in default content pack)
 var StandardOutfits = []Outfit{
 	// Shield Boosters
 	{
@@ -361,32 +363,71 @@ var StandardOutfits = []Outfit{
 		OutfitSpace: 20,
 		Price:       50000,
 	},
+
+	// Mining Lasers
+	{
+		ID:          "mining_laser_mk1",
+		Name:        "Mining Laser Mk1",
+		Description: "Cuts ore from asteroids and strips wrecks",
+		Type:        "mining_laser",
+		MiningBonus: 1,
+		OutfitSpace: 8,
+		Price:       7000,
+	},
+	{
+		ID:          "mining_laser_mk2",
+		Name:        "Mining Laser Mk2",
+		Description: "High-output industrial cutting beam",
+		Type:        "mining_laser",
+		MiningBonus: 2,
+		OutfitSpace: 12,
+		Price:       16000,
+	},
+	{
+		ID:          "mining_laser_mk3",
+		Name:        "Mining Laser Mk3",
+		Description: "Deep-core extraction array",
+		Type:        "mining_laser",
+		MiningBonus: 3,
+		OutfitSpace: 16,
+		Price:       32000,
+	},
+
+	// Survey Scanners
+	{
+		ID:           "survey_scanner_mk1",
+		Name:         "Survey Scanner Mk1",
+		Description:  "Maps ore seams and uncharted fields",
+		Type:         "scanner",
+		ScannerBonus: 1,
+		OutfitSpace:  5,
+		Price:        9000,
+	},
+	{
+		ID:           "survey_scanner_mk2",
+		Name:         "Survey Scanner Mk2",
+		Description:  "Long-range geological survey suite",
+		Type:         "scanner",
+		ScannerBonus: 2,
+		OutfitSpace:  8,
+		Price:        22000,
+	},
 }
 
 // GetWeaponByID finds a weapon by its ID
 func GetWeaponByID(id string) *Weapon {
-	for i := range StandardWeapons {
-		if StandardWeapons[i].ID == id {
-			return &StandardWeapons[i]
-		}
-	}
-	return nil
+	return activeContent.Load().weapons[id]
 }
 
 // GetOutfitByID finds an outfit by its ID
 func GetOutfitByID(id string) *Outfit {
-	for i := range StandardOutfits {
-		if StandardOutfits[i].ID == id {
-			return &StandardOutfits[i]
-		}
-	}
-	return nil
+	return activeContent.Load().outfits[id]
 }
 
 // GetWeaponsByType returns all weapons of a given type
 func GetWeaponsByType(weaponType string) []Weapon {
 	var result []Weapon
-	for _, weapon := range StandardWeapons {
+	for _, weapon := range AllWeapons() {
 		if weapon.Type == weaponType {
 			result = append(result, weapon)
 		}
@@ -397,7 +438,7 @@ func GetWeaponsByType(weaponType string) []Weapon {
 // GetOutfitsByType returns all outfits of a given type
 func GetOutfitsByType(outfitType string) []Outfit {
 	var result []Outfit
-	for _, outfit := range StandardOutfits {
+	for _, outfit := range AllOutfits() {
 		if outfit.Type == outfitType {
 			result = append(result, outfit)
 		}
@@ -419,3 +460,21 @@ func CalculateShipBonuses(outfitIDs []string) (shields, hull, cargo, fuel, speed
 	}
 	return
 }
+
+// CalculateMiningEquipment returns the best mining laser and survey scanner
+// levels among the installed outfits
+func CalculateMiningEquipment(outfitIDs []string) (laser, scanner int) {
+	for _, id := range outfitIDs {
+		outfit := GetOutfitByID(id)
+		if outfit == nil {
+			continue
+		}
+		if outfit.MiningBonus > laser {
+			laser = outfit.MiningBonus
+		}
+		if outfit.ScannerBonus > scanner {
+			scanner = outfit.ScannerBonus
+		}
+	}
+	return
+}
@@ -0,0 +1,126 @@
+// File: internal/models/manufacturing.go
+// Project: Terminal Velocity
+// Description: Manufacturing models - blueprints, crafting jobs and player-owned stations
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// What a blueprint produces, which decides where the output is delivered
+const (
+	BlueprintWeapon    = "weapon"    // Weapon items (PlayerItem)
+	BlueprintOutfit    = "outfit"    // Outfit items (PlayerItem)
+	BlueprintCommodity = "commodity" // Commodities added to ship cargo
+)
+
+// Blueprint defines how to craft an item
+type Blueprint struct {
+	ID           string         `json:"id"`
+	Name         string         `json:"name"`
+	Description  string         `json:"description"`
+	ItemType     string         `json:"item_type"` // BlueprintWeapon, BlueprintOutfit or BlueprintCommodity
+	Tier         int            `json:"tier"`      // 1-5 rarity tier
+	CraftingTime time.Duration  `json:"crafting_time"`
+	Requirements map[string]int `json:"requirements"`            // Commodity ID -> quantity
+	Produces     map[string]int `json:"produces"`                // Equipment or commodity ID -> quantity
+	SkillLevel   int            `json:"skill_level"`             // Required crafting skill
+	TechRequired string         `json:"tech_required,omitempty"` // Required technology ID (optional)
+}
+
+// Crafting job statuses
+const (
+	CraftingInProgress = "in_progress"
+	CraftingComplete   = "complete"
+	CraftingCancelled  = "cancelled"
+)
+
+// CraftingJob represents a crafting operation. Its inputs are taken from
+// the player's cargo when it starts; its outputs are delivered when it
+// completes, whether or not the player is online.
+type CraftingJob struct {
+	ID             uuid.UUID  `json:"id"`
+	PlayerID       uuid.UUID  `json:"player_id"`
+	BlueprintID    string     `json:"blueprint_id"`
+	Blueprint      *Blueprint `json:"-"` // Resolved from BlueprintID
+	StartTime      time.Time  `json:"start_time"`
+	CompletionTime time.Time  `json:"completion_time"` // When the job finishes, or was cancelled
+	Status         string     `json:"status"`          // CraftingInProgress, CraftingComplete, CraftingCancelled
+	Quantity       int        `json:"quantity"`
+	StationID      *uuid.UUID `json:"station_id,omitempty"` // Optional: crafting at a station
+}
+
+// Progress returns how far the job has come, from 0.0 to 1.0
+func (j *CraftingJob) Progress(now time.Time) float64 {
+	if j.Status != CraftingInProgress {
+		if j.Status == CraftingComplete {
+			return 1.0
+		}
+		return 0
+	}
+	total := j.CompletionTime.Sub(j.StartTime)
+	if total <= 0 {
+		return 1.0
+	}
+	progress := float64(now.Sub(j.StartTime)) / float64(total)
+	if progress > 1.0 {
+		return 1.0
+	}
+	return progress
+}
+
+// StationFacility represents a facility within a station
+type StationFacility string
+
+const (
+	FacilityManufacturing StationFacility = "manufacturing" // Craft items
+	FacilityResearch      StationFacility = "research"      // Research tech
+	FacilityRefinery      StationFacility = "refinery"      // Process raw materials
+	FacilityShipyard      StationFacility = "shipyard"      // Build ships
+	FacilityWarehouse     StationFacility = "warehouse"     // Extra storage
+	FacilityDefense       StationFacility = "defense"       // Station defenses
+)
+
+// StationFacilities lists every facility in display order
+var StationFacilities = []StationFacility{
+	FacilityManufacturing,
+	FacilityResearch,
+	FacilityRefinery,
+	FacilityShipyard,
+	FacilityWarehouse,
+	FacilityDefense,
+}
+
+// PlayerStation represents a player-owned manufacturing station. Stations
+// appear in their system as dockable locations.
+type PlayerStation struct {
+	ID              uuid.UUID         `json:"id"`
+	OwnerID         uuid.UUID         `json:"owner_id"`
+	OwnerName       string            `json:"owner_name"`
+	Name            string            `json:"name"`
+	SystemID        uuid.UUID         `json:"system_id"`
+	SystemName      string            `json:"system_name"`
+	Level           int               `json:"level"` // Station upgrade level 1-10
+	BuildTime       time.Time         `json:"build_time"`
+	Facilities      []StationFacility `json:"facilities"`
+	Storage         map[string]int    `json:"storage"` // Resource -> quantity
+	StorageCapacity int               `json:"storage_capacity"`
+	ProductionBonus float64           `json:"production_bonus"` // Bonus to crafting speed
+	Status          string            `json:"status"`           // "active", "upgrading", "damaged"
+}
+
+// HasFacility reports whether the station has the given facility
+func (s *PlayerStation) HasFacility(facility StationFacility) bool {
+	for _, f := range s.Facilities {
+		if f == facility {
+			return true
+		}
+	}
+	return false
+}
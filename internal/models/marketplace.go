@@ -0,0 +1,114 @@
+// File: internal/models/marketplace.go
+// Project: Terminal Velocity
+// Description: Data models for the player marketplace (auctions, contracts, bounties)
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// Listings are owned by marketplace.Manager and persisted by
+// database.MarketplaceRepository. Credits and escrowed items held by a
+// listing are tracked in the database so they survive restarts:
+//   - Auction: the item (LocationAuction) and the current high bid
+//   - Contract: the poster's deposit (reward + posting cost)
+//   - Bounty: the bounty amount
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuctionType represents the type of item being auctioned
+type AuctionType string
+
+const (
+	AuctionTypeShip      AuctionType = "ship"
+	AuctionTypeOutfit    AuctionType = "outfit"
+	AuctionTypeCommodity AuctionType = "commodity"
+	AuctionTypeSpecial   AuctionType = "special" // Rare items, blueprints, etc.
+)
+
+// EscrowsItem reports whether auctions of this type hold a player_items row
+// in LocationAuction until the auction ends
+func (t AuctionType) EscrowsItem() bool {
+	return t == AuctionTypeOutfit || t == AuctionTypeSpecial
+}
+
+// MarketAuction represents an auction listing
+type MarketAuction struct {
+	ID             uuid.UUID
+	SellerID       uuid.UUID
+	SellerName     string
+	Type           AuctionType
+	ItemID         uuid.UUID // Ship ID, outfit ID, etc.
+	ItemName       string
+	Quantity       int // For commodities/stackable items
+	Description    string
+	StartingBid    int64
+	BuyoutPrice    int64 // Instant purchase price (optional)
+	CurrentBid     int64
+	HighBidder     uuid.UUID // Player who has current high bid
+	HighBidderName string
+	StartTime      time.Time
+	EndTime        time.Time
+	Status         string // "active", "sold", "expired", "cancelled"
+	BidHistory     []MarketBid
+}
+
+// MarketBid represents a bid on an auction
+type MarketBid struct {
+	BidderID   uuid.UUID
+	BidderName string
+	Amount     int64
+	Timestamp  time.Time
+}
+
+// ContractType represents the type of contract
+type ContractType string
+
+const (
+	ContractTypeCourier       ContractType = "courier"       // Deliver cargo
+	ContractTypeAssassination ContractType = "assassination" // Kill a target
+	ContractTypeEscort        ContractType = "escort"        // Escort a ship
+	ContractTypeBountyHunt    ContractType = "bounty_hunt"   // Hunt a bounty target
+)
+
+// MarketContract represents a player-posted contract
+type MarketContract struct {
+	ID           uuid.UUID
+	PosterID     uuid.UUID
+	PosterName   string
+	Type         ContractType
+	Title        string
+	Description  string
+	Reward       int64
+	Deposit      int64     // Amount poster must deposit
+	TargetID     uuid.UUID // Target player/system/location
+	TargetName   string
+	ClaimedBy    uuid.UUID
+	ClaimedName  string
+	PostTime     time.Time
+	ExpiryTime   time.Time
+	ClaimTime    time.Time
+	CompleteTime time.Time
+	Status       string // "open", "claimed", "completed", "failed", "expired"
+}
+
+// MarketBounty represents a player-posted bounty on another player's head
+type MarketBounty struct {
+	ID          uuid.UUID
+	PosterID    uuid.UUID
+	PosterName  string
+	TargetID    uuid.UUID
+	TargetName  string
+	Amount      int64
+	Reason      string
+	PostTime    time.Time
+	ExpiryTime  time.Time
+	ClaimedBy   uuid.UUID
+	ClaimedName string
+	ClaimTime   time.Time
+	Status      string // "active", "claimed", "expired"
+}
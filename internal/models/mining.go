@@ -0,0 +1,59 @@
+// File: internal/models/mining.go
+// Project: Terminal Velocity
+// Description: Mining models - resource fields in star systems
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Resource field types, which decide whether a field is mined or salvaged
+const (
+	FieldAsteroid = "asteroid"     // Mined with mining lasers
+	FieldDerelict = "derelict"     // Salvaged
+	FieldDebris   = "debris_field" // Salvaged
+)
+
+// ResourceField is a place in a star system that can be mined or salvaged.
+// Fields are generated from the galaxy, so a system always has the same
+// fields; mining depletes them and they regrow over time.
+type ResourceField struct {
+	ID        uuid.UUID          `json:"id"`
+	SystemID  uuid.UUID          `json:"system_id"`
+	Type      string             `json:"type"` // FieldAsteroid, FieldDerelict or FieldDebris
+	Name      string             `json:"name"`
+	Rarity    string             `json:"rarity"`     // common, uncommon, rare
+	Uncharted bool               `json:"uncharted"`  // Only found with a scanner
+	Capacity  map[string]float64 `json:"capacity"`   // Resource -> amount when fully grown
+	Remaining map[string]float64 `json:"remaining"`  // Resource -> amount left
+	UpdatedAt time.Time          `json:"updated_at"` // When Remaining was last brought up to date
+}
+
+// Total returns the amount of every resource left in the field
+func (f *ResourceField) Total() float64 {
+	total := 0.0
+	for _, amount := range f.Remaining {
+		total += amount
+	}
+	return total
+}
+
+// CapacityTotal returns the amount of every resource in the fully grown field
+func (f *ResourceField) CapacityTotal() float64 {
+	total := 0.0
+	for _, amount := range f.Capacity {
+		total += amount
+	}
+	return total
+}
+
+// Salvage returns true if the field is salvaged rather than mined
+func (f *ResourceField) Salvage() bool {
+	return f.Type != FieldAsteroid
+}
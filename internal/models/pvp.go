@@ -1,7 +1,7 @@
 // File: internal/models/pvp.go
 // Project: Terminal Velocity
 // Description: PvP combat models with consent system and bounties
-// Version: 1.0.0
+// Version: 1.1.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -237,6 +237,15 @@ func (c *PvPChallenge) Complete(winnerID uuid.UUID) {
 	}
 }
 
+// CompleteDraw marks the combat as finished with no winner
+func (c *PvPChallenge) CompleteDraw() {
+	c.Status = ChallengeComplete
+	now := time.Now()
+	c.EndedAt = &now
+	c.WinnerID = nil
+	c.LoserID = nil
+}
+
 // GetTimeRemaining returns time until expiry
 func (c *PvPChallenge) GetTimeRemaining() string {
 	if c.Status != ChallengePending {
@@ -1,7 +1,7 @@
 // File: internal/models/ship.go
 // Project: Terminal Velocity
 // Description: Data models for ship
-// Version: 1.1.0
+// Version: 1.3.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -92,7 +92,8 @@ type Ship struct {
 //   - Destroyers: Heavy warships
 //   - Cruisers: Capital warships (Cruiser, Battleship)
 //
-// See ship_types.go for the StandardShipTypes array containing all definitions.
+// See ship_types.go for the StandardShipTypes array containing the built-in
+// definitions; the game reads ship types from the active content pack.
 type ShipType struct {
 	// ID is the unique identifier for this ship type (e.g., "shuttle", "battleship")
 	ID string `json:"id"`
@@ -167,6 +168,11 @@ type ShipType struct {
 	// Class categorizes the ship type for filtering and display
 	// Valid values: shuttle, fighter, freighter, corvette, destroyer, cruiser, capital
 	Class string `json:"class"`
+
+	// NPCWeapons lists the weapon IDs fitted to NPC ships of this type
+	// Empty means NPC weapons are picked by ship class
+	// At most WeaponSlots entries; each must be a weapon in the content pack
+	NPCWeapons []string `json:"npc_weapons,omitempty"`
 }
 
 // CargoItem represents a commodity stored in a ship's cargo hold.
@@ -192,7 +198,8 @@ type CargoItem struct {
 //   - Plasma: Balanced, moderate energy use (Plasma Cannon, Plasma Turret)
 //   - Railgun: Very high damage, kinetic, bypasses shields (Railgun, Heavy Railgun)
 //
-// See equipment.go for the StandardWeapons array containing all weapon definitions.
+// See equipment.go for the StandardWeapons array containing the built-in
+// definitions; the game reads weapons from the active content pack.
 type Weapon struct {
 	// ID is the unique identifier for this weapon type (e.g., "pulse_laser")
 	ID string `json:"id"`
@@ -265,15 +272,18 @@ type Weapon struct {
 // Outfit represents ship equipment that enhances capabilities.
 //
 // Outfits provide passive bonuses to ship characteristics and don't occupy
-// weapon slots, but they do consume outfit space. There are 16 standard outfits
+// weapon slots, but they do consume outfit space. There are 20 standard outfits
 // organized into types:
 //   - Shield Boosters: Increase max shields (Mk1: +50, Mk2: +100, Mk3: +200)
 //   - Hull Plating: Increase max hull (Mk1: +50, Mk2: +100, Mk3: +200)
 //   - Cargo Pods: Increase cargo space (Small: +10, Medium: +20, Large: +40)
 //   - Fuel Tanks: Increase fuel capacity (Small: +50, Medium: +100, Large: +200)
 //   - Engine Upgrades: Increase speed (Mk1: +1, Mk2: +2, Mk3: +3)
+//   - Mining Lasers: Increase mining yields (Mk1: level 1, Mk2: 2, Mk3: 3)
+//   - Survey Scanners: Increase yields and find uncharted fields (Mk1: 1, Mk2: 2)
 //
-// See equipment.go for the StandardOutfits array containing all definitions.
+// See equipment.go for the StandardOutfits array containing the built-in
+// definitions; the game reads outfits from the active content pack.
 type Outfit struct {
 	// ID is the unique identifier for this outfit (e.g., "shield_booster_mk1")
 	ID string `json:"id"`
@@ -285,7 +295,8 @@ type Outfit struct {
 	Description string `json:"description"`
 
 	// Type categorizes the outfit for filtering and display
-	// Valid values: shield_booster, hull_reinforcement, cargo_pod, fuel_tank, engine
+	// Valid values: shield_booster, hull_reinforcement, cargo_pod, fuel_tank, engine,
+	// mining_laser, scanner
 	Type string `json:"type"`
 
 	// ShieldBonus is the increase to maximum shields
@@ -314,6 +325,18 @@ type Outfit struct {
 	// Omitted from JSON if 0
 	SpeedBonus int `json:"speed_bonus,omitempty"`
 
+	// MiningBonus is the mining laser level, which raises the yield of each
+	// mining and salvage cycle
+	// Range: 0 (not a mining laser) to 3 (Mk3)
+	// Omitted from JSON if 0
+	MiningBonus int `json:"mining_bonus,omitempty"`
+
+	// ScannerBonus is the survey scanner level, which raises yields and
+	// reveals uncharted resource fields
+	// Range: 0 (not a scanner) to 2 (Mk2)
+	// Omitted from JSON if 0
+	ScannerBonus int `json:"scanner_bonus,omitempty"`
+
 	// OutfitSpace is the amount of outfit space this outfit consumes
 	// Range: 5-25
 	// Must be available in ship's OutfitSpace to install
@@ -1,13 +1,16 @@
 // File: internal/models/ship_types.go
 // Project: Terminal Velocity
 // Description: Standard ship type definitions for the game
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
 package models
 
-// StandardShipTypes defines all ship types available for purchase in the game.
+// StandardShipTypes defines the built-in ship types available for purchase.
+//
+// They are the ship type section of the built-in default content pack; the
+// game reads ship types from the active pack (see content.go).
 //
 // This array contains 11 ship types organized into 6 classes, providing
 // a progression path from starter ships to end-game capital vessels:
@@ -264,18 +267,13 @@ var StandardShipTypes = []ShipType{
 
 // GetShipTypeByID returns a ship type by its ID
 func GetShipTypeByID(id string) *ShipType {
-	for i := range StandardShipTypes {
-		if StandardShipTypes[i].ID == id {
-			return &StandardShipTypes[i]
-		}
-	}
-	return nil
+	return activeContent.Load().shipTypes[id]
 }
 
 // GetShipTypesByClass returns all ship types in a class
 func GetShipTypesByClass(class string) []ShipType {
 	var result []ShipType
-	for _, shipType := range StandardShipTypes {
+	for _, shipType := range AllShipTypes() {
 		if shipType.Class == class {
 			result = append(result, shipType)
 		}
@@ -286,7 +284,7 @@ func GetShipTypesByClass(class string) []ShipType {
 // GetAffordableShipTypes returns ship types within a price range
 func GetAffordableShipTypes(maxPrice int64) []ShipType {
 	var result []ShipType
-	for _, shipType := range StandardShipTypes {
+	for _, shipType := range AllShipTypes() {
 		if shipType.Price <= maxPrice {
 			result = append(result, shipType)
 		}
@@ -1,7 +1,7 @@
 // File: internal/models/territory.go
 // Project: Terminal Velocity
 // Description: Territory control models for faction-owned systems
-// Version: 1.0.0
+// Version: 1.1.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -26,6 +26,30 @@ const (
 	ControlLevelDominant  TerritoryControlLevel = "dominant"  // Maximum control and benefits
 )
 
+// ContestPhase is the stage of a rival faction's attempt to take a system
+type ContestPhase string
+
+const (
+	ContestPhaseContest ContestPhase = "contest" // Attacker and owner compete for influence
+	ContestPhaseSiege   ContestPhase = "siege"   // Attacker won the contest and besieges the system
+)
+
+// TerritoryContest tracks a rival faction's challenge for a system.
+//
+// During both phases, activity in the system by the attacking faction adds
+// to AttackerPoints and activity by the owning faction adds to
+// DefenderPoints instead of the territory's control points.
+type TerritoryContest struct {
+	AttackerFactionID uuid.UUID             `json:"attacker_faction_id"`
+	AttackerTag       string                `json:"attacker_tag"`
+	Phase             ContestPhase          `json:"phase"`
+	StartedAt         time.Time             `json:"started_at"`
+	EndsAt            time.Time             `json:"ends_at"` // When the current phase resolves
+	AttackerPoints    int                   `json:"attacker_points"`
+	DefenderPoints    int                   `json:"defender_points"`
+	PreviousLevel     TerritoryControlLevel `json:"previous_level"` // Restored if the attack fails
+}
+
 // TerritoryBenefit represents bonuses from controlling territory
 type TerritoryBenefit struct {
 	TradeBonus      float64 `json:"trade_bonus"`      // % bonus to trade profits
@@ -63,7 +87,12 @@ type Territory struct {
 	// Activity tracking
 	MemberActivity int        `json:"member_activity"`         // Member visits this week
 	TradeVolume    int64      `json:"trade_volume"`            // Credits traded this week
+	Kills          int        `json:"kills"`                   // Hostile ships destroyed this week
 	LastConflict   *time.Time `json:"last_conflict,omitempty"` // Last contested/attacked
+
+	// Upkeep and conflict state
+	MissedUpkeeps int               `json:"missed_upkeeps"`    // Consecutive unpaid upkeep cycles
+	Contest       *TerritoryContest `json:"contest,omitempty"` // Active contest or siege
 }
 
 // NewTerritory creates a new territory claim
@@ -248,6 +277,33 @@ func (t *Territory) AddControlPoints(points int) {
 	}
 }
 
+// RemoveControlPoints removes control points, dropping control levels as
+// needed. Control never decays below weak; contested is only set by a rival
+// faction's contest.
+func (t *Territory) RemoveControlPoints(points int) {
+	t.ControlPoints -= points
+
+	for t.ControlPoints < 0 {
+		if !t.levelDownControl() {
+			t.ControlPoints = 0
+			return
+		}
+		t.ControlPoints += t.getRequiredControlPoints()
+	}
+}
+
+// ResetWeeklyActivity clears the activity counters at the end of an upkeep cycle
+func (t *Territory) ResetWeeklyActivity() {
+	t.MemberActivity = 0
+	t.TradeVolume = 0
+	t.Kills = 0
+}
+
+// IsContested reports whether a rival faction is contesting or besieging the system
+func (t *Territory) IsContested() bool {
+	return t.Contest != nil
+}
+
 // getRequiredControlPoints returns points needed for next level
 func (t *Territory) getRequiredControlPoints() int {
 	requirements := map[TerritoryControlLevel]int{
@@ -278,6 +334,22 @@ func (t *Territory) levelUpControl() {
 	}
 }
 
+// levelDownControl drops to the previous control level, returning false at weak
+func (t *Territory) levelDownControl() bool {
+	regression := map[TerritoryControlLevel]TerritoryControlLevel{
+		ControlLevelDominant: ControlLevelStrong,
+		ControlLevelStrong:   ControlLevelStable,
+		ControlLevelStable:   ControlLevelWeak,
+	}
+
+	previousLevel, exists := regression[t.ControlLevel]
+	if !exists {
+		return false
+	}
+	t.ControlLevel = previousLevel
+	return true
+}
+
 // UpgradeDefense increases the defense level
 func (t *Territory) UpgradeDefense() bool {
 	if t.DefenseLevel >= 5 {
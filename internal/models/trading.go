@@ -1,7 +1,7 @@
 // File: internal/models/trading.go
 // Project: Terminal Velocity
 // Description: Data models for trading and economy system
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 //
@@ -104,7 +104,8 @@ const (
 	CategoryContraband  = "contraband"
 )
 
-// Standard commodities
+// Standard commodities (the commodity section of the built-in default
+// content pack; the game reads commodities from the active pack)
 var StandardCommodities = []Commodity{
 	// Food & Basic Resources
 	{
@@ -457,18 +458,13 @@ func GetPriceModifier(commodityTechLevel, planetTechLevel int, isBuying bool) fl
 
 // GetCommodityByID finds a commodity by its ID
 func GetCommodityByID(id string) *Commodity {
-	for i := range StandardCommodities {
-		if StandardCommodities[i].ID == id {
-			return &StandardCommodities[i]
-		}
-	}
-	return nil
+	return activeContent.Load().commodities[id]
 }
 
 // GetCommoditiesByCategory returns all commodities in a category
 func GetCommoditiesByCategory(category string) []Commodity {
 	var result []Commodity
-	for _, commodity := range StandardCommodities {
+	for _, commodity := range AllCommodities() {
 		if commodity.Category == category {
 			result = append(result, commodity)
 		}
@@ -479,7 +475,7 @@ func GetCommoditiesByCategory(category string) []Commodity {
 // GetLegalCommoditiesForSystem returns commodities legal in a system
 func GetLegalCommoditiesForSystem(governmentID string) []Commodity {
 	var result []Commodity
-	for _, commodity := range StandardCommodities {
+	for _, commodity := range AllCommodities() {
 		if !commodity.IsIllegal(governmentID) {
 			result = append(result, commodity)
 		}
@@ -490,7 +486,7 @@ func GetLegalCommoditiesForSystem(governmentID string) []Commodity {
 // GetAvailableCommoditiesAtTechLevel returns commodities available at tech level
 func GetAvailableCommoditiesAtTechLevel(techLevel int) []Commodity {
 	var result []Commodity
-	for _, commodity := range StandardCommodities {
+	for _, commodity := range AllCommodities() {
 		if commodity.TechLevel <= techLevel {
 			result = append(result, commodity)
 		}
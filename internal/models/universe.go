@@ -1,7 +1,7 @@
 // File: internal/models/universe.go
 // Project: Terminal Velocity
 // Description: Universe, star system, and planet models
-// Version: 1.2.0
+// Version: 1.3.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -241,3 +241,19 @@ func (s *StarSystem) AddConnection(systemID uuid.UUID) {
 func (s *StarSystem) IsControlledByPlayer() bool {
 	return s.ControlledBy != nil
 }
+
+// Safe zones are the developed core of strongly policed governments
+const (
+	SafeZonePatrolStrength = 7 // Minimum government patrol strength
+	SafeZoneMinTechLevel   = 7 // Minimum system tech level
+)
+
+// IsSafeZone checks if the system's government keeps the peace there.
+// Faction war combat is not allowed in safe zones.
+func (s *StarSystem) IsSafeZone() bool {
+	government := GetFactionByID(s.GovernmentID)
+	if government == nil {
+		return false
+	}
+	return government.PatrolStrength >= SafeZonePatrolStrength && s.TechLevel >= SafeZoneMinTechLevel
+}
@@ -21,6 +21,7 @@ package news
 import (
 	"math/rand"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
@@ -32,9 +33,13 @@ import (
 var log = logger.WithComponent("News")
 
 type Manager struct {
+	mu                 sync.Mutex
 	articles           []*models.NewsArticle
 	lastRandomNewsTime time.Time
 	randomNewsInterval time.Duration
+
+	// Callback for real-time article delivery
+	onNewArticle func(article *models.NewsArticle)
 }
 
 // NewManager creates a new news manager
@@ -49,6 +54,13 @@ func NewManager() *Manager {
 	}
 }
 
+// SetArticleCallback sets the callback invoked whenever an article is published
+func (m *Manager) SetArticleCallback(callback func(article *models.NewsArticle)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onNewArticle = callback
+}
+
 // AddArticle adds a news article to the feed
 //
 // Parameters:
@@ -57,8 +69,16 @@ func (m *Manager) AddArticle(article *models.NewsArticle) {
 	if article == nil {
 		return
 	}
+
+	m.mu.Lock()
 	m.articles = append(m.articles, article)
 	m.pruneExpiredArticles()
+	callback := m.onNewArticle
+	m.mu.Unlock()
+
+	if callback != nil {
+		callback(article)
+	}
 }
 
 // GetRecentArticles returns recent news articles
@@ -70,6 +90,9 @@ func (m *Manager) AddArticle(article *models.NewsArticle) {
 // Returns:
 //   - Slice of recent articles, sorted by creation time (newest first)
 func (m *Manager) GetRecentArticles(count int, category models.NewsCategory) []*models.NewsArticle {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.pruneExpiredArticles()
 
 	// Filter by category if specified
@@ -101,6 +124,9 @@ func (m *Manager) GetRecentArticles(count int, category models.NewsCategory) []*
 // Returns:
 //   - Slice of articles meeting priority threshold
 func (m *Manager) GetArticlesByPriority(minPriority models.NewsPriority) []*models.NewsArticle {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.pruneExpiredArticles()
 
 	filtered := []*models.NewsArticle{}
@@ -126,11 +152,15 @@ func (m *Manager) GetArticlesByPriority(minPriority models.NewsPriority) []*mode
 // Returns:
 //   - Count of non-expired articles
 func (m *Manager) GetArticleCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.pruneExpiredArticles()
 	return len(m.articles)
 }
 
-// pruneExpiredArticles removes expired articles from the feed
+// pruneExpiredArticles removes expired articles from the feed.
+// Caller must hold m.mu.
 func (m *Manager) pruneExpiredArticles() {
 	active := []*models.NewsArticle{}
 	for _, article := range m.articles {
@@ -147,15 +177,18 @@ func (m *Manager) pruneExpiredArticles() {
 //   - New random article if generated, nil otherwise
 func (m *Manager) Update() *models.NewsArticle {
 	// Check if it's time for random news
-	if time.Since(m.lastRandomNewsTime) >= m.randomNewsInterval {
+	m.mu.Lock()
+	due := time.Since(m.lastRandomNewsTime) >= m.randomNewsInterval
+	if due {
 		m.lastRandomNewsTime = time.Now()
+	}
+	m.mu.Unlock()
 
-		// 50% chance to generate random news
-		if rand.Float64() < 0.5 {
-			article := models.GenerateRandomNews()
-			m.AddArticle(article)
-			return article
-		}
+	// 50% chance to generate random news
+	if due && rand.Float64() < 0.5 {
+		article := models.GenerateRandomNews()
+		m.AddArticle(article)
+		return article
 	}
 
 	return nil
@@ -251,6 +284,9 @@ func (m *Manager) GetBreakingNews() []*models.NewsArticle {
 // Returns:
 //   - Slice of player-generated articles
 func (m *Manager) GetPlayerNews(count int) []*models.NewsArticle {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.pruneExpiredArticles()
 
 	playerArticles := []*models.NewsArticle{}
@@ -277,6 +313,9 @@ func (m *Manager) GetPlayerNews(count int) []*models.NewsArticle {
 // Parameters:
 //   - maxAge: Maximum age for articles to keep
 func (m *Manager) ClearOldNews(maxAge time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	cutoffTime := time.Now().Add(-maxAge)
 	active := []*models.NewsArticle{}
 
@@ -294,6 +333,9 @@ func (m *Manager) ClearOldNews(maxAge time.Duration) {
 // Returns:
 //   - Map of category to article count
 func (m *Manager) GetCategoryCount() map[models.NewsCategory]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.pruneExpiredArticles()
 
 	counts := make(map[models.NewsCategory]int)
@@ -1,7 +1,7 @@
 // File: internal/outfitting/manager.go
 // Project: Terminal Velocity
 // Description: Ship outfitting and equipment management
-// Version: 1.0.0
+// Version: 1.1.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -15,71 +15,29 @@ import (
 	"github.com/google/uuid"
 )
 
-// Manager handles ship outfitting and equipment
+// Manager handles ship outfitting and equipment.
+//
+// The equipment catalog is the equipment section of the active content pack
+// (models.AllEquipment), so a content reload changes it for every manager.
 
 type Manager struct {
 	mu        sync.RWMutex
-	equipment map[string]*models.Equipment      // Equipment catalog
 	loadouts  map[uuid.UUID]*models.ShipLoadout // Player loadouts
 	inventory map[uuid.UUID]map[string]int      // Player equipment inventory (playerID -> equipmentID -> quantity)
 }
 
 // NewManager creates a new outfitting manager
 func NewManager() *Manager {
-	m := &Manager{
-		equipment: make(map[string]*models.Equipment),
+	return &Manager{
 		loadouts:  make(map[uuid.UUID]*models.ShipLoadout),
 		inventory: make(map[uuid.UUID]map[string]int),
 	}
-
-	// Load equipment catalog
-	m.loadEquipmentCatalog()
-
-	return m
-}
-
-// loadEquipmentCatalog populates the equipment catalog
-func (m *Manager) loadEquipmentCatalog() {
-	// Weapons
-	m.addEquipment(createLaserCannon())
-	m.addEquipment(createPlasmaTurret())
-	m.addEquipment(createRailgun())
-	m.addEquipment(createMissileLauncher())
-
-	// Shields
-	m.addEquipment(createBasicShield())
-	m.addEquipment(createAdvancedShield())
-	m.addEquipment(createMilitaryShield())
-
-	// Engines
-	m.addEquipment(createBasicEngine())
-	m.addEquipment(createAfterburnerEngine())
-	m.addEquipment(createMilitaryEngine())
-
-	// Reactors
-	m.addEquipment(createBasicReactor())
-	m.addEquipment(createFusionReactor())
-	m.addEquipment(createAntimatterReactor())
-
-	// Utilities
-	m.addEquipment(createCargoPod())
-	m.addEquipment(createFuelTank())
-	m.addEquipment(createScanner())
-	m.addEquipment(createRepairDrone())
-}
-
-// addEquipment adds equipment to the catalog
-func (m *Manager) addEquipment(equipment *models.Equipment) {
-	m.equipment[equipment.ID] = equipment
 }
 
 // GetEquipment retrieves equipment by ID
 func (m *Manager) GetEquipment(equipmentID string) (*models.Equipment, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	equipment, exists := m.equipment[equipmentID]
-	if !exists {
+	equipment := models.GetEquipmentByID(equipmentID)
+	if equipment == nil {
 		return nil, errors.New("equipment not found")
 	}
 
@@ -88,13 +46,11 @@ func (m *Manager) GetEquipment(equipmentID string) (*models.Equipment, error) {
 
 // GetEquipmentByCategory returns all equipment in a category
 func (m *Manager) GetEquipmentByCategory(category models.EquipmentCategory) []*models.Equipment {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
 	var result []*models.Equipment
-	for _, eq := range m.equipment {
-		if eq.Category == category {
-			result = append(result, eq)
+	catalog := models.AllEquipment()
+	for i := range catalog {
+		if catalog[i].Category == category {
+			result = append(result, &catalog[i])
 		}
 	}
 
@@ -103,13 +59,11 @@ func (m *Manager) GetEquipmentByCategory(category models.EquipmentCategory) []*m
 
 // GetEquipmentBySlotType returns all equipment for a slot type
 func (m *Manager) GetEquipmentBySlotType(slotType models.EquipmentSlotType) []*models.Equipment {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
 	var result []*models.Equipment
-	for _, eq := range m.equipment {
-		if eq.SlotType == slotType {
-			result = append(result, eq)
+	catalog := models.AllEquipment()
+	for i := range catalog {
+		if catalog[i].SlotType == slotType {
+			result = append(result, &catalog[i])
 		}
 	}
 
@@ -118,12 +72,10 @@ func (m *Manager) GetEquipmentBySlotType(slotType models.EquipmentSlotType) []*m
 
 // GetAllEquipment returns all available equipment
 func (m *Manager) GetAllEquipment() []*models.Equipment {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
 	var result []*models.Equipment
-	for _, eq := range m.equipment {
-		result = append(result, eq)
+	catalog := models.AllEquipment()
+	for i := range catalog {
+		result = append(result, &catalog[i])
 	}
 
 	return result
@@ -134,8 +86,8 @@ func (m *Manager) PurchaseEquipment(playerID uuid.UUID, equipmentID string, quan
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	equipment, exists := m.equipment[equipmentID]
-	if !exists {
+	equipment := models.GetEquipmentByID(equipmentID)
+	if equipment == nil {
 		return errors.New("equipment not found")
 	}
 
@@ -159,8 +111,8 @@ func (m *Manager) SellEquipment(playerID uuid.UUID, equipmentID string, quantity
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	equipment, exists := m.equipment[equipmentID]
-	if !exists {
+	equipment := models.GetEquipmentByID(equipmentID)
+	if equipment == nil {
 		return 0, errors.New("equipment not found")
 	}
 
@@ -317,8 +269,8 @@ func (m *Manager) InstallEquipment(
 	}
 
 	// Get equipment
-	equipment, exists := m.equipment[equipmentID]
-	if !exists {
+	equipment := models.GetEquipmentByID(equipmentID)
+	if equipment == nil {
 		return errors.New("equipment not found")
 	}
 
@@ -415,14 +367,14 @@ func (m *Manager) GetStats() map[string]int {
 	defer m.mu.RUnlock()
 
 	stats := map[string]int{
-		"equipment_types":        len(m.equipment),
+		"equipment_types":        len(models.AllEquipment()),
 		"total_loadouts":         len(m.loadouts),
 		"players_with_inventory": len(m.inventory),
 	}
 
 	// Count by category
 	categoryCount := make(map[models.EquipmentCategory]int)
-	for _, eq := range m.equipment {
+	for _, eq := range models.AllEquipment() {
 		categoryCount[eq.Category]++
 	}
 
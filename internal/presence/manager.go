@@ -30,6 +30,9 @@ type Manager struct {
 	// Configuration
 	afkThreshold   time.Duration // How long before a player is marked AFK
 	offlineTimeout time.Duration // How long before an inactive player is removed
+
+	// Callback for real-time presence changes (connect, disconnect, movement)
+	onPresenceChanged func(playerID uuid.UUID, online bool)
 }
 
 // NewManager creates a new presence manager
@@ -41,6 +44,22 @@ func NewManager() *Manager {
 	}
 }
 
+// SetPresenceChangedCallback sets the callback invoked when a player comes
+// online, goes offline, or changes location. The callback is invoked while
+// the manager lock is held and must not call back into the Manager.
+func (m *Manager) SetPresenceChangedCallback(callback func(playerID uuid.UUID, online bool)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onPresenceChanged = callback
+}
+
+// notify reports a presence change. Caller must hold m.mu.
+func (m *Manager) notify(playerID uuid.UUID, online bool) {
+	if m.onPresenceChanged != nil {
+		m.onPresenceChanged(playerID, online)
+	}
+}
+
 // Connect registers a player as online
 func (m *Manager) Connect(player *models.Player, ship *models.Ship) {
 	m.mu.Lock()
@@ -48,6 +67,7 @@ func (m *Manager) Connect(player *models.Player, ship *models.Ship) {
 
 	presence := models.NewPlayerPresence(player, ship)
 	m.players[player.ID] = presence
+	m.notify(player.ID, true)
 }
 
 // Disconnect marks a player as offline and removes them from the active list
@@ -55,7 +75,12 @@ func (m *Manager) Disconnect(playerID uuid.UUID) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if _, exists := m.players[playerID]; !exists {
+		return
+	}
+
 	delete(m.players, playerID)
+	m.notify(playerID, false)
 }
 
 // UpdateActivity updates a player's current activity
@@ -75,6 +100,7 @@ func (m *Manager) UpdateLocation(playerID uuid.UUID, systemID uuid.UUID, planetI
 
 	if presence, exists := m.players[playerID]; exists {
 		presence.UpdateLocation(systemID, planetID)
+		m.notify(playerID, true)
 	}
 }
 
@@ -256,6 +282,7 @@ func (m *Manager) CleanupStale() {
 
 	for _, id := range stale {
 		delete(m.players, id)
+		m.notify(id, false)
 	}
 }
 
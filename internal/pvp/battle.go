@@ -0,0 +1,706 @@
+// File: internal/pvp/battle.go
+// Project: Terminal Velocity
+// Description: Server-side battle rooms for live two-player PvP combat
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package pvp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/combat"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// Battle rooms
+//
+// An accepted challenge opens a battle room on the server. Both players fly
+// a copy of their current ship, repaired to full, in one combat.Engine; the
+// challenger fights on the engine's player side and the defender on its
+// enemy side, and neither ship is AI controlled.
+//
+// Turns are simultaneous. Each player submits an action (fire one weapon,
+// fire every ready weapon, or hold fire) and the turn resolves as soon as
+// both have acted or the turn timer runs out, in which case a player who
+// has not acted holds fire. The player who shoots first alternates every
+// turn so that neither side has an advantage.
+//
+// The battle ends when a ship is destroyed, a player forfeits (leaving the
+// battle or disconnecting), or the turn limit is reached, in which case the
+// player who dealt more damage wins. A damage tie goes to the player with
+// more hull remaining, and if that is tied too the battle is a draw. A win
+// is recorded just as CompleteCombat records it, so it feeds GetStats and
+// GetLeaderboard like any other combat; a draw counts in both players'
+// stats but has no combat result.
+//
+// Both players' sessions see the same state: every change is reported to
+// the battle callback with a BattleView snapshot, and GetBattle returns the
+// same snapshot on demand.
+
+const (
+	// defaultTurnTimeout is how long players have to act each turn
+	defaultTurnTimeout = 30 * time.Second
+
+	// maxBattleTurns is the turn limit before a battle is decided on damage
+	maxBattleTurns = 60
+
+	// maxBattleLog is the number of battle log lines kept for the players
+	maxBattleLog = 50
+
+	// battleRetention is how long a finished battle stays viewable
+	battleRetention = 10 * time.Minute
+
+	// dbTimeout bounds the ship lookups made when a battle starts
+	dbTimeout = 5 * time.Second
+)
+
+// Battle errors
+var (
+	ErrNoBattle         = errors.New("not in a battle")
+	ErrInBattle         = errors.New("player is already in a battle")
+	ErrNoShip           = errors.New("no ship with weapons to fight with")
+	ErrOpponentNoShip   = errors.New("opponent has no ship with weapons")
+	ErrInvalidAction    = errors.New("invalid battle action")
+	ErrBattleInProgress = errors.New("combat is being fought in a battle room")
+	ErrNoBoardingInDuel = errors.New("ships cannot be boarded in a duel")
+)
+
+// Players looks up players to find their current ship
+type Players interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Player, error)
+}
+
+// Ships looks up the ships players fly into battle
+type Ships interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Ship, error)
+}
+
+// BattleActionType identifies what a player does in a turn
+type BattleActionType string
+
+const (
+	ActionFire    BattleActionType = "fire"     // Fire the weapon in Slot
+	ActionFireAll BattleActionType = "fire_all" // Fire every ready weapon
+	ActionHold    BattleActionType = "hold"     // Hold fire this turn
+)
+
+// BattleAction is a player's choice for the current turn
+type BattleAction struct {
+	Type BattleActionType
+	Slot int // Weapon slot for ActionFire
+}
+
+// BattleWeapon is one weapon slot in a battle view
+type BattleWeapon struct {
+	Name   string
+	Ready  bool
+	Status string // Why the weapon cannot fire, if it is not ready
+}
+
+// BattleFighter is one player's ship in a battle view
+type BattleFighter struct {
+	PlayerID    uuid.UUID
+	PlayerName  string
+	ShipName    string
+	Hull        int
+	MaxHull     int
+	Shields     int
+	MaxShields  int
+	Weapons     []BattleWeapon
+	DamageDealt int
+	Acted       bool // Has chosen an action for the current turn
+}
+
+// BattleView is a snapshot of a battle room shared by both players
+type BattleView struct {
+	ChallengeID uuid.UUID
+	Type        models.PvPChallengeType
+	Turn        int
+	MaxTurns    int
+	Deadline    time.Time       // When the current turn resolves on its own
+	Fighters    []BattleFighter // Challenger first, then defender
+	Log         []string        // Most recent battle log lines, oldest first
+	Over        bool
+	WinnerID    uuid.UUID // uuid.Nil for a draw
+	Result      string    // How the battle ended
+}
+
+// Fighter returns the player's fighter, or nil if they are not in the battle
+func (v *BattleView) Fighter(playerID uuid.UUID) *BattleFighter {
+	for i := range v.Fighters {
+		if v.Fighters[i].PlayerID == playerID {
+			return &v.Fighters[i]
+		}
+	}
+	return nil
+}
+
+// Opponent returns the fighter facing the player, or nil if they are not
+// in the battle
+func (v *BattleView) Opponent(playerID uuid.UUID) *BattleFighter {
+	if v.Fighter(playerID) == nil {
+		return nil
+	}
+	for i := range v.Fighters {
+		if v.Fighters[i].PlayerID != playerID {
+			return &v.Fighters[i]
+		}
+	}
+	return nil
+}
+
+// PlayerIDs returns the two players in the battle
+func (v *BattleView) PlayerIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(v.Fighters))
+	for _, fighter := range v.Fighters {
+		ids = append(ids, fighter.PlayerID)
+	}
+	return ids
+}
+
+// battleShip is a repaired copy of a player's ship ready for battle
+type battleShip struct {
+	playerID uuid.UUID
+	name     string
+	ship     *models.Ship
+	shipType *models.ShipType
+}
+
+// fighter is one side of a battle room
+type fighter struct {
+	playerID    uuid.UUID
+	name        string
+	combatantID string
+	damage      int
+	action      *BattleAction // Action for the current turn (nil = not yet chosen)
+}
+
+// battle is a live or recently finished battle room
+type battle struct {
+	challenge *models.PvPChallenge
+	engine    *combat.Engine
+	fighters  [2]*fighter // Challenger, defender
+	deadline  time.Time
+	timer     *time.Timer
+	log       []string
+	over      bool
+	winnerID  uuid.UUID
+	result    string
+	endedAt   time.Time
+}
+
+// SetRepositories sets where battle rooms find the players' ships. Until
+// it is set, accepted challenges do not open a battle room and results must
+// be reported with CompleteCombat.
+func (m *Manager) SetRepositories(players Players, ships Ships) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.players = players
+	m.ships = ships
+}
+
+// SetBattleChangedCallback sets the callback invoked whenever a battle room
+// starts, resolves a turn, records an action or ends. The callback is
+// invoked while the manager lock is held and must not call back into the
+// Manager.
+func (m *Manager) SetBattleChangedCallback(callback func(view *BattleView)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onBattleChanged = callback
+}
+
+// loadBattleShip returns a repaired copy of the player's current ship, or
+// ErrNoShip if they have no ship with weapons
+func loadBattleShip(ctx context.Context, players Players, ships Ships, playerID uuid.UUID) (*battleShip, error) {
+	player, err := players.GetByID(ctx, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+	if player.ShipID == uuid.Nil {
+		return nil, ErrNoShip
+	}
+	ship, err := ships.GetByID(ctx, player.ShipID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ship: %w", err)
+	}
+	shipType := models.GetShipTypeByID(ship.TypeID)
+	if shipType == nil || len(ship.Weapons) == 0 {
+		return nil, ErrNoShip
+	}
+
+	battleCopy := *ship
+	battleCopy.Weapons = append([]string(nil), ship.Weapons...)
+	battleCopy.Hull = shipType.MaxHull
+	battleCopy.Shields = shipType.MaxShields
+	return &battleShip{playerID: playerID, name: player.Username, ship: &battleCopy, shipType: shipType}, nil
+}
+
+// prepareBattle loads the challenger's and defender's ships before a
+// challenge starts. A missing ship is ErrNoShip for actorID, the player
+// starting the combat, and ErrOpponentNoShip for the other. It returns nil
+// ships without error if no repositories are set. Must be called without
+// holding m.mu, since it goes to the database.
+func (m *Manager) prepareBattle(challengerID, defenderID, actorID uuid.UUID) ([2]*battleShip, error) {
+	var ships [2]*battleShip
+
+	m.mu.RLock()
+	playerRepo, shipRepo := m.players, m.ships
+	m.mu.RUnlock()
+	if playerRepo == nil || shipRepo == nil {
+		return ships, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	for i, playerID := range []uuid.UUID{challengerID, defenderID} {
+		ship, err := loadBattleShip(ctx, playerRepo, shipRepo, playerID)
+		if errors.Is(err, ErrNoShip) && playerID != actorID {
+			return ships, ErrOpponentNoShip
+		}
+		if err != nil {
+			return ships, err
+		}
+		ships[i] = ship
+	}
+	return ships, nil
+}
+
+// checkNotInBattle returns ErrInBattle if either player is fighting a live
+// battle. Caller must hold m.mu.
+func (m *Manager) checkNotInBattle(playerIDs ...uuid.UUID) error {
+	for _, playerID := range playerIDs {
+		if b, exists := m.battleOf[playerID]; exists && !b.over {
+			return ErrInBattle
+		}
+	}
+	return nil
+}
+
+// startBattle opens a battle room for an active challenge. Nothing happens
+// if the ships were not loaded. Caller must hold m.mu.
+func (m *Manager) startBattle(challenge *models.PvPChallenge, ships [2]*battleShip) {
+	if ships[0] == nil || ships[1] == nil {
+		return
+	}
+
+	b := &battle{
+		challenge: challenge,
+		engine:    combat.NewEngine(time.Now().UnixNano()),
+	}
+	for i, side := range []combat.Side{combat.SidePlayer, combat.SideEnemy} {
+		combatant, err := b.engine.AddCombatant(ships[i].ship, ships[i].shipType, side, nil)
+		if err != nil {
+			// Only possible if both players fly the same ship
+			log.Error("Failed to open battle for challenge %s: %v", challenge.ID, err)
+			return
+		}
+		combatant.Name = fmt.Sprintf("%s (%s)", ships[i].name, combatant.Name)
+		b.fighters[i] = &fighter{
+			playerID:    ships[i].playerID,
+			name:        ships[i].name,
+			combatantID: combatant.ID,
+		}
+	}
+
+	m.battles[challenge.ID] = b
+	m.battleOf[challenge.ChallengerID] = b
+	m.battleOf[challenge.DefenderID] = b
+
+	b.addLog(fmt.Sprintf("%s and %s enter combat", ships[0].name, ships[1].name))
+	m.startTurn(b)
+
+	log.Info("PvP battle started: challenge=%s, %s vs %s", challenge.ID, ships[0].name, ships[1].name)
+}
+
+// startTurn clears the players' actions and starts the turn timer.
+// Caller must hold m.mu.
+func (m *Manager) startTurn(b *battle) {
+	for _, f := range b.fighters {
+		f.action = nil
+	}
+
+	challengeID, turn := b.challenge.ID, b.engine.Turn()
+	b.deadline = time.Now().Add(m.turnTimeout)
+	b.timer = time.AfterFunc(m.turnTimeout, func() {
+		m.turnExpired(challengeID, turn)
+	})
+	m.notifyBattle(b)
+}
+
+// turnExpired resolves a turn whose timer ran out
+func (m *Manager) turnExpired(challengeID uuid.UUID, turn int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, exists := m.battles[challengeID]
+	if !exists || b.over || b.engine.Turn() != turn {
+		return
+	}
+	m.resolveTurn(b)
+}
+
+// SubmitAction records the player's action for the current turn of their
+// battle. The turn resolves once both players have acted; until then the
+// action may be changed.
+func (m *Manager) SubmitAction(playerID uuid.UUID, action BattleAction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, exists := m.battleOf[playerID]
+	if !exists || b.over {
+		return ErrNoBattle
+	}
+	f := b.fighter(playerID)
+
+	switch action.Type {
+	case ActionFireAll, ActionHold:
+	case ActionFire:
+		combatant := b.engine.Combatant(f.combatantID)
+		if action.Slot < 0 || action.Slot >= len(combatant.Weapons) {
+			return fmt.Errorf("%w: no weapon in slot %d", ErrInvalidAction, action.Slot+1)
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidAction, action.Type)
+	}
+
+	f.action = &action
+	if b.fighters[0].action != nil && b.fighters[1].action != nil {
+		m.resolveTurn(b)
+	} else {
+		m.notifyBattle(b)
+	}
+	return nil
+}
+
+// ForfeitBattle ends the player's live battle as a loss. Sessions call it
+// when the player leaves the battle; the world hub calls it when they
+// disconnect.
+func (m *Manager) ForfeitBattle(playerID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, exists := m.battleOf[playerID]
+	if !exists || b.over {
+		return ErrNoBattle
+	}
+
+	opponent := b.opponent(playerID)
+	b.addLog(fmt.Sprintf("%s forfeits the battle!", b.fighter(playerID).name))
+	m.endBattle(b, opponent.playerID, fmt.Sprintf("%s forfeited", b.fighter(playerID).name))
+	return nil
+}
+
+// GetBattle returns the player's live battle, or the last one they fought
+// if it has not been dismissed or expired
+func (m *Manager) GetBattle(playerID uuid.UUID) (*BattleView, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, exists := m.battleOf[playerID]
+	if !exists {
+		return nil, false
+	}
+	return b.view(), true
+}
+
+// BoardingAllowed reports whether the PvP rules let attackerID board
+// defenderID's ship: the two must be fighting each other in a live battle
+// room, and the battle must not be a duel, since duels are fought for
+// honor rather than ships. Returns nil if boarding is allowed.
+func (m *Manager) BoardingAllowed(attackerID, defenderID uuid.UUID) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, exists := m.battleOf[attackerID]
+	if !exists || b.over {
+		return ErrNoBattle
+	}
+	if opponent, ok := m.battleOf[defenderID]; !ok || opponent != b {
+		return ErrNotAuthorized
+	}
+	if b.challenge.Type == models.ChallengeDuel {
+		return ErrNoBoardingInDuel
+	}
+	return nil
+}
+
+// DismissBattle forgets the player's finished battle so that GetBattle no
+// longer returns it. A live battle cannot be dismissed; forfeit it instead.
+func (m *Manager) DismissBattle(playerID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, exists := m.battleOf[playerID]
+	if !exists {
+		return ErrNoBattle
+	}
+	if !b.over {
+		return ErrBattleInProgress
+	}
+	delete(m.battleOf, playerID)
+	return nil
+}
+
+// resolveTurn plays out both players' actions and ends the turn.
+// Caller must hold m.mu.
+func (m *Manager) resolveTurn(b *battle) {
+	b.timer.Stop()
+
+	// The player who shoots first alternates every turn
+	order := []*fighter{b.fighters[0], b.fighters[1]}
+	if b.engine.Turn()%2 == 0 {
+		order[0], order[1] = order[1], order[0]
+	}
+
+	for i, f := range order {
+		if b.engine.Outcome() != combat.OutcomeNone {
+			break
+		}
+		m.performAction(b, f, order[1-i])
+	}
+	if b.engine.Outcome() == combat.OutcomeNone {
+		for _, event := range b.engine.EndTurn() {
+			b.recordEvent(event)
+		}
+	}
+
+	switch b.engine.Outcome() {
+	case combat.OutcomeVictory:
+		m.endBattle(b, b.fighters[0].playerID, fmt.Sprintf("%s's ship was destroyed", b.fighters[1].name))
+	case combat.OutcomeDefeat:
+		m.endBattle(b, b.fighters[1].playerID, fmt.Sprintf("%s's ship was destroyed", b.fighters[0].name))
+	default:
+		if b.engine.Turn() > maxBattleTurns {
+			m.decideOnPoints(b)
+			return
+		}
+		m.startTurn(b)
+	}
+}
+
+// decideOnPoints ends a battle that reached the turn limit: the player who
+// dealt more damage wins, then the one with more hull remaining, and
+// otherwise it is a draw. Caller must hold m.mu.
+func (m *Manager) decideOnPoints(b *battle) {
+	b.addLog("Turn limit reached - decided on damage dealt")
+	first, second := b.fighters[0], b.fighters[1]
+
+	switch {
+	case first.damage > second.damage:
+		m.endBattle(b, first.playerID, fmt.Sprintf("%s dealt the most damage", first.name))
+	case second.damage > first.damage:
+		m.endBattle(b, second.playerID, fmt.Sprintf("%s dealt the most damage", second.name))
+	default:
+		firstHull := b.engine.Combatant(first.combatantID).Ship.Hull
+		secondHull := b.engine.Combatant(second.combatantID).Ship.Hull
+		switch {
+		case firstHull > secondHull:
+			m.endBattle(b, first.playerID, fmt.Sprintf("Damage tied; %s has the most hull remaining", first.name))
+		case secondHull > firstHull:
+			m.endBattle(b, second.playerID, fmt.Sprintf("Damage tied; %s has the most hull remaining", second.name))
+		default:
+			m.endDraw(b, "Damage and hull remaining tied")
+		}
+	}
+}
+
+// performAction carries out one player's action for the turn.
+// Caller must hold m.mu.
+func (m *Manager) performAction(b *battle, f, opponent *fighter) {
+	if f.action == nil {
+		b.addLog(fmt.Sprintf("%s did not act in time", f.name))
+		return
+	}
+
+	combatant := b.engine.Combatant(f.combatantID)
+	switch f.action.Type {
+	case ActionHold:
+		b.addLog(fmt.Sprintf("%s holds fire", f.name))
+
+	case ActionFire:
+		events, err := b.engine.Fire(f.combatantID, f.action.Slot, opponent.combatantID)
+		if err != nil {
+			b.addLog(fmt.Sprintf("%s could not fire: %v", f.name, err))
+			return
+		}
+		for _, event := range events {
+			b.recordEvent(event)
+		}
+
+	case ActionFireAll:
+		fired := false
+		for slot := range combatant.Weapons {
+			events, err := b.engine.Fire(f.combatantID, slot, opponent.combatantID)
+			if err != nil {
+				continue
+			}
+			fired = true
+			for _, event := range events {
+				b.recordEvent(event)
+			}
+			if b.engine.Outcome() != combat.OutcomeNone {
+				return
+			}
+		}
+		if !fired {
+			b.addLog(fmt.Sprintf("%s has no weapon ready", f.name))
+		}
+	}
+}
+
+// endBattle finishes a battle and records the result. Caller must hold m.mu.
+func (m *Manager) endBattle(b *battle, winnerID uuid.UUID, result string) {
+	b.timer.Stop()
+	b.over = true
+	b.winnerID = winnerID
+	b.result = result
+	b.endedAt = time.Now()
+
+	winner, loser := b.fighter(winnerID), b.opponent(winnerID)
+	b.addLog(fmt.Sprintf("%s wins: %s", winner.name, result))
+
+	if _, err := m.completeCombat(b.challenge, winnerID, 0, winner.damage, loser.damage); err != nil {
+		log.Error("Failed to record battle for challenge %s: %v", b.challenge.ID, err)
+	}
+	m.notifyBattle(b)
+
+	log.Info("PvP battle ended: challenge=%s, winner=%s, %s", b.challenge.ID, winner.name, result)
+}
+
+// endDraw finishes a battle that neither player won and records the draw.
+// Caller must hold m.mu.
+func (m *Manager) endDraw(b *battle, result string) {
+	b.timer.Stop()
+	b.over = true
+	b.winnerID = uuid.Nil
+	b.result = result
+	b.endedAt = time.Now()
+
+	b.addLog(fmt.Sprintf("Draw: %s", result))
+
+	if err := m.completeDraw(b.challenge); err != nil {
+		log.Error("Failed to record battle for challenge %s: %v", b.challenge.ID, err)
+	}
+	m.notifyBattle(b)
+
+	log.Info("PvP battle ended in a draw: challenge=%s, %s", b.challenge.ID, result)
+}
+
+// cleanupBattles forgets battles that finished more than battleRetention
+// ago. Caller must hold m.mu.
+func (m *Manager) cleanupBattles() {
+	cutoff := time.Now().Add(-battleRetention)
+	for id, b := range m.battles {
+		if !b.over || b.endedAt.After(cutoff) {
+			continue
+		}
+		delete(m.battles, id)
+		for _, f := range b.fighters {
+			if m.battleOf[f.playerID] == b {
+				delete(m.battleOf, f.playerID)
+			}
+		}
+	}
+}
+
+// notifyBattle reports a battle change. Caller must hold m.mu.
+func (m *Manager) notifyBattle(b *battle) {
+	if m.onBattleChanged != nil {
+		m.onBattleChanged(b.view())
+	}
+}
+
+// fighter returns the player's side of the battle
+func (b *battle) fighter(playerID uuid.UUID) *fighter {
+	if b.fighters[0].playerID == playerID {
+		return b.fighters[0]
+	}
+	return b.fighters[1]
+}
+
+// opponent returns the side facing the player
+func (b *battle) opponent(playerID uuid.UUID) *fighter {
+	if b.fighters[0].playerID == playerID {
+		return b.fighters[1]
+	}
+	return b.fighters[0]
+}
+
+// recordEvent adds an engine event to the damage totals and battle log
+func (b *battle) recordEvent(event combat.Event) {
+	switch event.Type {
+	case combat.EventFire:
+		if event.Hit {
+			for _, f := range b.fighters {
+				if f.combatantID == event.Actor {
+					f.damage += event.Damage
+				}
+			}
+		}
+	case combat.EventShieldRegen, combat.EventTurnStart:
+		// Too frequent to be worth showing
+		return
+	}
+	b.addLog(event.Message)
+}
+
+// addLog appends lines to the battle log, keeping the most recent ones
+func (b *battle) addLog(lines ...string) {
+	b.log = append(b.log, lines...)
+	if len(b.log) > maxBattleLog {
+		b.log = append([]string(nil), b.log[len(b.log)-maxBattleLog:]...)
+	}
+}
+
+// view returns a snapshot of the battle
+func (b *battle) view() *BattleView {
+	view := &BattleView{
+		ChallengeID: b.challenge.ID,
+		Type:        b.challenge.Type,
+		Turn:        b.engine.Turn(),
+		MaxTurns:    maxBattleTurns,
+		Deadline:    b.deadline,
+		Log:         append([]string(nil), b.log...),
+		Over:        b.over,
+		WinnerID:    b.winnerID,
+		Result:      b.result,
+	}
+
+	for _, f := range b.fighters {
+		c := b.engine.Combatant(f.combatantID)
+		shipName := c.Ship.Name
+		if shipName == "" {
+			shipName = c.Type.Name
+		}
+
+		weapons := make([]BattleWeapon, len(c.Weapons))
+		for i, weaponID := range c.Ship.Weapons {
+			weapons[i] = BattleWeapon{Name: weaponID, Status: "unknown weapon"}
+			if weapon := models.GetWeaponByID(weaponID); weapon != nil {
+				ready, status := combat.CanFire(weapon, c.Weapons[i], c.Ship, c.Type)
+				weapons[i] = BattleWeapon{Name: weapon.Name, Ready: ready, Status: status}
+			}
+		}
+
+		view.Fighters = append(view.Fighters, BattleFighter{
+			PlayerID:    f.playerID,
+			PlayerName:  f.name,
+			ShipName:    shipName,
+			Hull:        c.Ship.Hull,
+			MaxHull:     c.Type.MaxHull,
+			Shields:     c.Ship.Shields,
+			MaxShields:  c.Type.MaxShields,
+			Weapons:     weapons,
+			DamageDealt: f.damage,
+			Acted:       f.action != nil,
+		})
+	}
+	return view
+}
@@ -1,12 +1,13 @@
 // File: internal/pvp/manager.go
 // Project: Terminal Velocity
-// Version: 1.0.0
+// Version: 1.3.0
 
 package pvp
 
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
@@ -25,6 +26,7 @@ var (
 	ErrInsufficientFunds = errors.New("insufficient funds for wager")
 	ErrNotInSameSystem   = errors.New("players must be in same system")
 	ErrCannotAttackSelf  = errors.New("cannot attack yourself")
+	ErrWarNotAllowed     = errors.New("faction war combat is not allowed here")
 )
 
 // Manager handles all PvP combat operations
@@ -35,20 +37,80 @@ type Manager struct {
 	bounties   map[uuid.UUID]*models.Bounty         // Target ID -> Bounty
 	stats      map[uuid.UUID]*models.PvPStats       // Player ID -> Stats
 	results    []*models.PvPCombatResult            // Combat history
+
+	// Battle rooms (see battle.go)
+	battles     map[uuid.UUID]*battle // Challenge ID -> Battle
+	battleOf    map[uuid.UUID]*battle // Player ID -> Live or last battle
+	players     Players
+	ships       Ships
+	turnTimeout time.Duration
+
+	// Callback for real-time challenge status delivery
+	onChallengeChanged func(challenge *models.PvPChallenge)
+
+	// Callback for finished combats (e.g. to feed faction war score)
+	onCombatCompleted func(result *models.PvPCombatResult)
+
+	// Decides whether a faction war attack is allowed (nil = never)
+	warPolicy func(attackerID, defenderID, systemID uuid.UUID) error
+
+	// Callback for battle room updates delivered to both players
+	onBattleChanged func(view *BattleView)
 }
 
 // NewManager creates a new PvP manager
 func NewManager() *Manager {
 	return &Manager{
-		challenges: make(map[uuid.UUID]*models.PvPChallenge),
-		byPlayer:   make(map[uuid.UUID][]*models.PvPChallenge),
-		bounties:   make(map[uuid.UUID]*models.Bounty),
-		stats:      make(map[uuid.UUID]*models.PvPStats),
-		results:    []*models.PvPCombatResult{},
+		challenges:  make(map[uuid.UUID]*models.PvPChallenge),
+		byPlayer:    make(map[uuid.UUID][]*models.PvPChallenge),
+		bounties:    make(map[uuid.UUID]*models.Bounty),
+		stats:       make(map[uuid.UUID]*models.PvPStats),
+		results:     []*models.PvPCombatResult{},
+		battles:     make(map[uuid.UUID]*battle),
+		battleOf:    make(map[uuid.UUID]*battle),
+		turnTimeout: defaultTurnTimeout,
+	}
+}
+
+// SetChallengeChangedCallback sets the callback invoked whenever a challenge
+// is issued or changes status. The callback is invoked while the manager lock
+// is held and must not call back into the Manager.
+func (m *Manager) SetChallengeChangedCallback(callback func(challenge *models.PvPChallenge)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onChallengeChanged = callback
+}
+
+// SetCombatCompletedCallback sets the callback invoked with the result of
+// every completed combat. The callback is invoked while the manager lock is
+// held and must not call back into the Manager.
+func (m *Manager) SetCombatCompletedCallback(callback func(result *models.PvPCombatResult)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onCombatCompleted = callback
+}
+
+// SetWarPolicy sets the check made before a faction war attack. It returns
+// an error if the attacker may not attack the defender in the system, such
+// as when their factions are not at war or the system is a safe zone. The
+// policy is invoked while the manager lock is held and must not call back
+// into the Manager. Without a policy, faction war attacks are refused.
+func (m *Manager) SetWarPolicy(policy func(attackerID, defenderID, systemID uuid.UUID) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.warPolicy = policy
+}
+
+// notify reports a challenge change. Caller must hold m.mu.
+func (m *Manager) notify(challenge *models.PvPChallenge) {
+	if m.onChallengeChanged != nil {
+		m.onChallengeChanged(challenge)
 	}
 }
 
-// CreateChallenge creates a new PvP challenge
+// CreateChallenge creates a new PvP challenge. Challenges that need no
+// consent, such as faction war attacks, start combat immediately and open
+// a battle room when ship repositories are set.
 func (m *Manager) CreateChallenge(
 	challengerID uuid.UUID,
 	challengerName string,
@@ -59,9 +121,6 @@ func (m *Manager) CreateChallenge(
 	wager int64,
 	message string,
 ) (*models.PvPChallenge, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	// Cannot challenge yourself
 	if challengerID == defenderID {
 		return nil, ErrCannotAttackSelf
@@ -76,8 +135,39 @@ func (m *Manager) CreateChallenge(
 		systemID,
 	)
 
+	// Combat that starts at once needs both ships before taking the lock
+	var ships [2]*battleShip
+	if !challenge.RequiresConsent {
+		var err error
+		if ships, err = m.prepareBattle(challengerID, defenderID, challengerID); err != nil {
+			return nil, err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Faction war attacks must pass the war policy
+	if challengeType == models.ChallengeFactionWar {
+		if m.warPolicy == nil {
+			return nil, ErrWarNotAllowed
+		}
+		if err := m.warPolicy(challengerID, defenderID, systemID); err != nil {
+			return nil, err
+		}
+	}
+	if !challenge.RequiresConsent {
+		if err := m.checkNotInBattle(challengerID, defenderID); err != nil {
+			return nil, err
+		}
+	}
+
 	challenge.Wager = wager
 	challenge.Message = message
+	if !challenge.RequiresConsent {
+		challenge.Accept()
+		challenge.Start()
+	}
 
 	m.challenges[challenge.ID] = challenge
 	m.byPlayer[challengerID] = append(m.byPlayer[challengerID], challenge)
@@ -86,6 +176,10 @@ func (m *Manager) CreateChallenge(
 	// Ensure both players have stats
 	m.ensureStats(challengerID)
 	m.ensureStats(defenderID)
+	m.notify(challenge)
+	if challenge.Status == models.ChallengeActive {
+		m.startBattle(challenge, ships)
+	}
 
 	return challenge, nil
 }
@@ -143,15 +237,27 @@ func (m *Manager) GetPendingChallenges(playerID uuid.UUID) []*models.PvPChalleng
 	return pending
 }
 
-// AcceptChallenge accepts a combat challenge
+// AcceptChallenge accepts a combat challenge and starts combat. When ship
+// repositories are set, both players' ships are loaded and a battle room
+// is opened; a player without an armed ship cannot accept.
 func (m *Manager) AcceptChallenge(challengeID uuid.UUID, playerID uuid.UUID) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
+	m.mu.RLock()
 	challenge, exists := m.challenges[challengeID]
+	m.mu.RUnlock()
 	if !exists {
 		return ErrChallengeNotFound
 	}
+	if challenge.DefenderID != playerID {
+		return ErrNotAuthorized
+	}
+
+	ships, err := m.prepareBattle(challenge.ChallengerID, challenge.DefenderID, playerID)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
 	// Only defender can accept
 	if challenge.DefenderID != playerID {
@@ -168,9 +274,14 @@ func (m *Manager) AcceptChallenge(challengeID uuid.UUID, playerID uuid.UUID) err
 		challenge.Status = models.ChallengeExpired
 		return ErrChallengeExpired
 	}
+	if err := m.checkNotInBattle(challenge.ChallengerID, challenge.DefenderID); err != nil {
+		return err
+	}
 
 	challenge.Accept()
 	challenge.Start() // Auto-start after acceptance
+	m.notify(challenge)
+	m.startBattle(challenge, ships)
 
 	return nil
 }
@@ -196,11 +307,13 @@ func (m *Manager) DeclineChallenge(challengeID uuid.UUID, playerID uuid.UUID) er
 	}
 
 	challenge.Decline()
+	m.notify(challenge)
 
 	return nil
 }
 
-// CompleteCombat completes a combat and records the result
+// CompleteCombat completes a combat fought outside a battle room and
+// records the result. Battle rooms record their own results.
 func (m *Manager) CompleteCombat(
 	challengeID uuid.UUID,
 	winnerID uuid.UUID,
@@ -215,7 +328,22 @@ func (m *Manager) CompleteCombat(
 	if !exists {
 		return nil, ErrChallengeNotFound
 	}
+	if _, inBattle := m.battles[challengeID]; inBattle {
+		return nil, ErrBattleInProgress
+	}
+
+	return m.completeCombat(challenge, winnerID, creditsTransfer, winnerDamage, loserDamage)
+}
 
+// completeCombat completes an active challenge, updates both players'
+// stats and records the result. Caller must hold m.mu.
+func (m *Manager) completeCombat(
+	challenge *models.PvPChallenge,
+	winnerID uuid.UUID,
+	creditsTransfer int64,
+	winnerDamage int,
+	loserDamage int,
+) (*models.PvPCombatResult, error) {
 	// Must be active
 	if challenge.Status != models.ChallengeActive {
 		return nil, ErrInvalidStatus
@@ -232,7 +360,7 @@ func (m *Manager) CompleteCombat(
 
 	// Create result
 	result := &models.PvPCombatResult{
-		ChallengeID:     challengeID,
+		ChallengeID:     challenge.ID,
 		WinnerID:        winnerID,
 		LoserID:         loserID,
 		WinnerDamage:    winnerDamage,
@@ -279,10 +407,33 @@ func (m *Manager) CompleteCombat(
 
 	// Store result
 	m.results = append(m.results, result)
+	m.notify(challenge)
+	if m.onCombatCompleted != nil {
+		m.onCombatCompleted(result)
+	}
 
 	return result, nil
 }
 
+// completeDraw completes an active challenge that neither player won and
+// records a draw for both. Caller must hold m.mu.
+func (m *Manager) completeDraw(challenge *models.PvPChallenge) error {
+	// Must be active
+	if challenge.Status != models.ChallengeActive {
+		return ErrInvalidStatus
+	}
+
+	challenge.CompleteDraw()
+
+	for _, playerID := range []uuid.UUID{challenge.ChallengerID, challenge.DefenderID} {
+		m.ensureStats(playerID)
+		m.stats[playerID].RecordDraw()
+	}
+	m.notify(challenge)
+
+	return nil
+}
+
 // IssueBounty issues a bounty on a player
 func (m *Manager) IssueBounty(targetID uuid.UUID, targetName string, amount int64, reason string, issuedBy string) *models.Bounty {
 	m.mu.Lock()
@@ -383,11 +534,13 @@ func (m *Manager) GetPlayerResults(playerID uuid.UUID, limit int) []*models.PvPC
 	return results
 }
 
-// CleanupExpiredChallenges removes expired challenges
+// CleanupExpiredChallenges removes expired challenges and finished battles
 func (m *Manager) CleanupExpiredChallenges() int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.cleanupBattles()
+
 	cleaned := 0
 	for id, challenge := range m.challenges {
 		if challenge.IsExpired() {
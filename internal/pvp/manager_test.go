@@ -0,0 +1,377 @@
+// File: internal/pvp/manager_test.go
+// Project: Terminal Velocity
+// Description: Tests for PvP battle rooms - turns, timers, forfeits and stats
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package pvp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// memoryPlayers is an in-memory Players for tests
+type memoryPlayers struct {
+	players map[uuid.UUID]*models.Player
+}
+
+func (p *memoryPlayers) GetByID(ctx context.Context, id uuid.UUID) (*models.Player, error) {
+	player, exists := p.players[id]
+	if !exists {
+		return nil, fmt.Errorf("player not found")
+	}
+	copied := *player
+	return &copied, nil
+}
+
+// memoryShips is an in-memory Ships for tests
+type memoryShips struct {
+	ships map[uuid.UUID]*models.Ship
+}
+
+func (s *memoryShips) GetByID(ctx context.Context, id uuid.UUID) (*models.Ship, error) {
+	ship, exists := s.ships[id]
+	if !exists {
+		return nil, fmt.Errorf("ship not found")
+	}
+	copied := *ship
+	return &copied, nil
+}
+
+// testPvP is a manager with in-memory players and ships that records every
+// battle update it reports
+type testPvP struct {
+	*Manager
+	players *memoryPlayers
+	ships   *memoryShips
+
+	mu      sync.Mutex
+	updates map[uuid.UUID][]*BattleView // player -> battle updates received
+}
+
+func newTestPvP() *testPvP {
+	tp := &testPvP{
+		Manager: NewManager(),
+		players: &memoryPlayers{players: make(map[uuid.UUID]*models.Player)},
+		ships:   &memoryShips{ships: make(map[uuid.UUID]*models.Ship)},
+		updates: make(map[uuid.UUID][]*BattleView),
+	}
+	tp.SetRepositories(tp.players, tp.ships)
+	tp.SetBattleChangedCallback(func(view *BattleView) {
+		tp.mu.Lock()
+		defer tp.mu.Unlock()
+		for _, playerID := range view.PlayerIDs() {
+			tp.updates[playerID] = append(tp.updates[playerID], view)
+		}
+	})
+	return tp
+}
+
+// addPilot creates a player flying a ship of the given type and weapons
+func (tp *testPvP) addPilot(name, shipType string, weapons ...string) uuid.UUID {
+	ship := &models.Ship{ID: uuid.New(), TypeID: shipType, Name: name + "'s ship", Weapons: weapons}
+	player := &models.Player{ID: uuid.New(), Username: name, ShipID: ship.ID}
+	ship.OwnerID = player.ID
+	tp.ships.ships[ship.ID] = ship
+	tp.players.players[player.ID] = player
+	return player.ID
+}
+
+// duel issues a duel from challenger to defender and accepts it
+func (tp *testPvP) duel(t *testing.T, challenger, defender uuid.UUID) *models.PvPChallenge {
+	t.Helper()
+	challenge, err := tp.CreateChallenge(challenger, "challenger", defender, "defender",
+		models.ChallengeDuel, uuid.New(), 0, "")
+	if err != nil {
+		t.Fatalf("CreateChallenge failed: %v", err)
+	}
+	if err := tp.AcceptChallenge(challenge.ID, defender); err != nil {
+		t.Fatalf("AcceptChallenge failed: %v", err)
+	}
+	return challenge
+}
+
+// lastUpdate returns the latest battle update the player received
+func (tp *testPvP) lastUpdate(playerID uuid.UUID) *BattleView {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	updates := tp.updates[playerID]
+	if len(updates) == 0 {
+		return nil
+	}
+	return updates[len(updates)-1]
+}
+
+func TestDuelIsFoughtInBattleRoom(t *testing.T) {
+	tp := newTestPvP()
+	ace := tp.addPilot("ace", "viper", "heavy_laser", "heavy_laser", "heavy_laser", "heavy_laser")
+	rookie := tp.addPilot("rookie", "shuttle", "pulse_laser")
+	challenge := tp.duel(t, ace, rookie)
+
+	view, ok := tp.GetBattle(ace)
+	if !ok || view.ChallengeID != challenge.ID || len(view.Fighters) != 2 {
+		t.Fatalf("GetBattle = %+v, %v; want a battle for the challenge", view, ok)
+	}
+	if view.Fighter(ace).PlayerName != "ace" || view.Opponent(ace).PlayerID != rookie {
+		t.Fatalf("expected ace to face rookie, got %+v", view.Fighters)
+	}
+	if _, err := tp.CompleteCombat(challenge.ID, rookie, 0, 0, 0); !errors.Is(err, ErrBattleInProgress) {
+		t.Fatalf("CompleteCombat error = %v, want ErrBattleInProgress", err)
+	}
+	if _, err := tp.CreateChallenge(ace, "ace", rookie, "rookie", models.ChallengeDuel, uuid.New(), 0, ""); err != nil {
+		t.Fatalf("a pending challenge during a battle should be allowed, got %v", err)
+	}
+
+	for turn := 1; turn <= maxBattleTurns; turn++ {
+		if err := tp.SubmitAction(ace, BattleAction{Type: ActionFireAll}); err != nil {
+			t.Fatalf("SubmitAction failed on turn %d: %v", turn, err)
+		}
+		if view := tp.lastUpdate(rookie); view.Turn != turn || !view.Fighter(ace).Acted {
+			t.Fatalf("rookie should see ace's action on turn %d, got %+v", turn, view)
+		}
+		if err := tp.SubmitAction(rookie, BattleAction{Type: ActionFire, Slot: 0}); err != nil {
+			t.Fatalf("SubmitAction failed on turn %d: %v", turn, err)
+		}
+		if view, _ := tp.GetBattle(ace); view.Over {
+			break
+		}
+	}
+
+	aceView, _ := tp.GetBattle(ace)
+	rookieView := tp.lastUpdate(rookie)
+	if !aceView.Over || aceView.WinnerID != ace {
+		t.Fatalf("expected ace to win, got over=%v winner=%s (%s)", aceView.Over, aceView.WinnerID, aceView.Result)
+	}
+	if !rookieView.Over || rookieView.WinnerID != ace || rookieView.Turn != aceView.Turn {
+		t.Fatalf("both players should see the same result, got %+v", rookieView)
+	}
+	if err := tp.SubmitAction(ace, BattleAction{Type: ActionHold}); !errors.Is(err, ErrNoBattle) {
+		t.Fatalf("SubmitAction after the battle error = %v, want ErrNoBattle", err)
+	}
+
+	winner, loser := tp.GetStats(ace), tp.GetStats(rookie)
+	if winner.Wins != 1 || winner.DuelsWon != 1 || winner.TotalDamageDealt == 0 || loser.Losses != 1 {
+		t.Fatalf("stats not recorded: winner=%+v loser=%+v", winner, loser)
+	}
+	if leaderboard := tp.GetLeaderboard(10); len(leaderboard) < 2 || leaderboard[0].PlayerID != ace {
+		t.Fatalf("expected ace to top the leaderboard, got %+v", leaderboard)
+	}
+	if results := tp.GetPlayerResults(rookie, 10); len(results) != 1 || results[0].WinnerID != ace {
+		t.Fatalf("expected one result won by ace, got %+v", results)
+	}
+
+	if err := tp.DismissBattle(rookie); err != nil {
+		t.Fatalf("DismissBattle failed: %v", err)
+	}
+	if _, ok := tp.GetBattle(rookie); ok {
+		t.Error("a dismissed battle should no longer be returned")
+	}
+}
+
+// holdToTurnLimit has both players hold fire until the battle is decided
+// at the turn limit
+func (tp *testPvP) holdToTurnLimit(t *testing.T, a, b uuid.UUID) *BattleView {
+	t.Helper()
+	for turn := 1; turn <= maxBattleTurns; turn++ {
+		for _, playerID := range []uuid.UUID{a, b} {
+			if err := tp.SubmitAction(playerID, BattleAction{Type: ActionHold}); err != nil {
+				t.Fatalf("SubmitAction failed on turn %d: %v", turn, err)
+			}
+		}
+	}
+	view, _ := tp.GetBattle(a)
+	if !view.Over {
+		t.Fatalf("battle should be over at the turn limit, got turn %d", view.Turn)
+	}
+	return view
+}
+
+func TestTurnLimitDamageTie(t *testing.T) {
+	tp := newTestPvP()
+	viper := tp.addPilot("viper", "viper", "pulse_laser")
+	shuttle := tp.addPilot("shuttle", "shuttle", "pulse_laser")
+	tp.duel(t, shuttle, viper)
+
+	view := tp.holdToTurnLimit(t, shuttle, viper)
+	if view.WinnerID != viper || view.Result != "Damage tied; viper has the most hull remaining" {
+		t.Fatalf("expected viper to win on hull remaining, got winner=%s (%s)", view.WinnerID, view.Result)
+	}
+	if tp.GetStats(viper).Wins != 1 || tp.GetStats(shuttle).Losses != 1 {
+		t.Fatal("a hull decision should count as a win and a loss")
+	}
+
+	// Identical ships with no damage dealt have nothing to separate them
+	alice := tp.addPilot("alice", "viper", "pulse_laser")
+	bob := tp.addPilot("bob", "viper", "pulse_laser")
+	challenge := tp.duel(t, alice, bob)
+
+	view = tp.holdToTurnLimit(t, alice, bob)
+	if view.WinnerID != uuid.Nil || view.Result != "Damage and hull remaining tied" {
+		t.Fatalf("expected a draw, got winner=%s (%s)", view.WinnerID, view.Result)
+	}
+	if challenge.Status != models.ChallengeComplete || challenge.WinnerID != nil {
+		t.Fatalf("a draw should complete the challenge with no winner, got %s %v", challenge.Status, challenge.WinnerID)
+	}
+	for _, playerID := range []uuid.UUID{alice, bob} {
+		if stats := tp.GetStats(playerID); stats.Draws != 1 || stats.Wins != 0 || stats.Losses != 0 {
+			t.Fatalf("a draw should count as a draw for both players, got %+v", stats)
+		}
+		if results := tp.GetPlayerResults(playerID, 10); len(results) != 0 {
+			t.Fatalf("a draw should not record a combat result, got %+v", results)
+		}
+	}
+}
+
+func TestTurnTimerResolvesTurn(t *testing.T) {
+	tp := newTestPvP()
+	tp.turnTimeout = 20 * time.Millisecond
+	alice := tp.addPilot("alice", "viper", "pulse_laser")
+	bob := tp.addPilot("bob", "viper", "pulse_laser")
+	tp.duel(t, alice, bob)
+
+	if err := tp.SubmitAction(alice, BattleAction{Type: ActionFire, Slot: 5}); !errors.Is(err, ErrInvalidAction) {
+		t.Fatalf("SubmitAction error = %v, want ErrInvalidAction", err)
+	}
+	if err := tp.SubmitAction(alice, BattleAction{Type: ActionHold}); err != nil {
+		t.Fatalf("SubmitAction failed: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		view, _ := tp.GetBattle(bob)
+		if view.Turn > 1 {
+			if view.Fighter(alice).Acted || view.Fighter(bob).Acted {
+				t.Fatalf("actions should reset on a new turn, got %+v", view.Fighters)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("turn timer did not resolve the turn")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	view, _ := tp.GetBattle(alice)
+	found := false
+	for _, line := range view.Log {
+		if line == "bob did not act in time" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the log to note bob's missed turn, got %v", view.Log)
+	}
+}
+
+func TestForfeit(t *testing.T) {
+	tp := newTestPvP()
+	alice := tp.addPilot("alice", "viper", "pulse_laser")
+	bob := tp.addPilot("bob", "viper", "pulse_laser")
+	tp.duel(t, alice, bob)
+
+	if _, err := tp.CreateChallenge(bob, "bob", alice, "alice", models.ChallengeDuel, uuid.New(), 0, ""); err != nil {
+		t.Fatalf("CreateChallenge failed: %v", err)
+	}
+	pending := tp.GetPendingChallenges(alice)
+	if len(pending) != 1 {
+		t.Fatalf("expected one pending challenge, got %d", len(pending))
+	}
+	if err := tp.AcceptChallenge(pending[0].ID, alice); !errors.Is(err, ErrInBattle) {
+		t.Fatalf("AcceptChallenge during a battle error = %v, want ErrInBattle", err)
+	}
+	if err := tp.DismissBattle(alice); !errors.Is(err, ErrBattleInProgress) {
+		t.Fatalf("DismissBattle error = %v, want ErrBattleInProgress", err)
+	}
+
+	if err := tp.ForfeitBattle(bob); err != nil {
+		t.Fatalf("ForfeitBattle failed: %v", err)
+	}
+	if err := tp.ForfeitBattle(bob); !errors.Is(err, ErrNoBattle) {
+		t.Fatalf("second ForfeitBattle error = %v, want ErrNoBattle", err)
+	}
+
+	view := tp.lastUpdate(alice)
+	if !view.Over || view.WinnerID != alice || view.Result != "bob forfeited" {
+		t.Fatalf("expected alice to win by forfeit, got %+v", view)
+	}
+	if tp.GetStats(alice).Wins != 1 || tp.GetStats(bob).Losses != 1 {
+		t.Fatal("a forfeit should count as a win and a loss")
+	}
+
+	// With the battle over the pending rematch can be fought
+	if err := tp.AcceptChallenge(pending[0].ID, alice); err != nil {
+		t.Fatalf("AcceptChallenge after the battle failed: %v", err)
+	}
+}
+
+func TestAcceptRequiresShips(t *testing.T) {
+	tp := newTestPvP()
+	armed := tp.addPilot("armed", "viper", "pulse_laser")
+	unarmed := tp.addPilot("unarmed", "shuttle")
+
+	challenge, err := tp.CreateChallenge(armed, "armed", unarmed, "unarmed", models.ChallengeDuel, uuid.New(), 0, "")
+	if err != nil {
+		t.Fatalf("CreateChallenge failed: %v", err)
+	}
+	if err := tp.AcceptChallenge(challenge.ID, unarmed); !errors.Is(err, ErrNoShip) {
+		t.Fatalf("AcceptChallenge error = %v, want ErrNoShip", err)
+	}
+	if challenge.Status != models.ChallengePending {
+		t.Fatalf("a refused accept should leave the challenge pending, got %s", challenge.Status)
+	}
+
+	challenge, err = tp.CreateChallenge(unarmed, "unarmed", armed, "armed", models.ChallengeDuel, uuid.New(), 0, "")
+	if err != nil {
+		t.Fatalf("CreateChallenge failed: %v", err)
+	}
+	if err := tp.AcceptChallenge(challenge.ID, armed); !errors.Is(err, ErrOpponentNoShip) {
+		t.Fatalf("AcceptChallenge error = %v, want ErrOpponentNoShip", err)
+	}
+	if err := tp.AcceptChallenge(challenge.ID, unarmed); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatalf("AcceptChallenge by the challenger error = %v, want ErrNotAuthorized", err)
+	}
+}
+
+func TestBoardingAllowed(t *testing.T) {
+	tp := newTestPvP()
+	alice := tp.addPilot("alice", "viper", "pulse_laser")
+	bob := tp.addPilot("bob", "viper", "pulse_laser")
+	carol := tp.addPilot("carol", "viper", "pulse_laser")
+
+	if err := tp.BoardingAllowed(alice, bob); !errors.Is(err, ErrNoBattle) {
+		t.Fatalf("BoardingAllowed outside a battle error = %v, want ErrNoBattle", err)
+	}
+
+	tp.duel(t, alice, bob)
+	if err := tp.BoardingAllowed(alice, bob); !errors.Is(err, ErrNoBoardingInDuel) {
+		t.Fatalf("BoardingAllowed in a duel error = %v, want ErrNoBoardingInDuel", err)
+	}
+	if err := tp.ForfeitBattle(bob); err != nil {
+		t.Fatalf("ForfeitBattle failed: %v", err)
+	}
+	tp.DismissBattle(alice)
+	tp.DismissBattle(bob)
+
+	challenge, err := tp.CreateChallenge(alice, "alice", bob, "bob", models.ChallengeAggression, uuid.New(), 0, "")
+	if err != nil {
+		t.Fatalf("CreateChallenge failed: %v", err)
+	}
+	if err := tp.AcceptChallenge(challenge.ID, bob); err != nil {
+		t.Fatalf("AcceptChallenge failed: %v", err)
+	}
+	if err := tp.BoardingAllowed(alice, bob); err != nil {
+		t.Fatalf("BoardingAllowed in an aggression battle failed: %v", err)
+	}
+	if err := tp.BoardingAllowed(alice, carol); !errors.Is(err, ErrNotAuthorized) {
+		t.Fatalf("BoardingAllowed against a bystander error = %v, want ErrNotAuthorized", err)
+	}
+}
@@ -1,7 +1,7 @@
 // File: internal/security/activity.go
 // Project: Terminal Velocity
 // Description: Account activity logging for security events and audit trail
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-11-14
 
@@ -129,7 +129,7 @@
 //   - Use details field for event-specific context
 //   - Set buffer size based on activity level (1000-50000 events)
 //
-// Version: 1.1.0
+// Version: 1.2.0
 // Last Updated: 2025-11-16
 package security
 
@@ -207,6 +207,7 @@ type ActivityLogger struct {
 	events     []*ActivityEvent
 	maxEvents  int
 	playerIPs  map[uuid.UUID]map[string]time.Time // playerID -> IP -> last seen
+	onEvent    func(*ActivityEvent)               // Persists events (optional)
 }
 
 // NewActivityLogger creates a new activity logger
@@ -222,10 +223,18 @@ func NewActivityLogger(maxEvents int) *ActivityLogger {
 	}
 }
 
+// SetEventCallback sets a function called with every logged event, used to
+// persist the audit trail beyond the in-memory buffer
+func (al *ActivityLogger) SetEventCallback(fn func(*ActivityEvent)) {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	al.onEvent = fn
+}
+
 // LogEvent logs an activity event
 func (al *ActivityLogger) LogEvent(event *ActivityEvent) {
 	al.mu.Lock()
-	defer al.mu.Unlock()
 
 	// Set ID and timestamp if not already set
 	if event.ID == uuid.Nil {
@@ -250,12 +259,19 @@ func (al *ActivityLogger) LogEvent(event *ActivityEvent) {
 	}
 
 	// Track IP addresses for anomaly detection
-	if event.EventType == ActivityLoginSuccess {
-		al.trackIP(event.PlayerID, event.IPAddress)
+	if event.EventType == ActivityLoginSuccess || event.EventType == ActivityNewIPLogin {
+		al.trackIP(event.PlayerID, event.IPAddress, event.Timestamp)
 	}
 
+	onEvent := al.onEvent
+	al.mu.Unlock()
+
 	// Log to system log based on risk level
 	al.logToSystem(event)
+
+	if onEvent != nil {
+		onEvent(event)
+	}
 }
 
 // Log logs a simple activity event
@@ -288,13 +304,14 @@ func (al *ActivityLogger) LogLoginSuccess(playerID uuid.UUID, username, ipAddres
 	al.Log(playerID, username, eventType, ipAddress, true, details)
 }
 
-// LogLoginFailure logs a failed login attempt
-func (al *ActivityLogger) LogLoginFailure(username, ipAddress, reason string) {
+// LogLoginFailure logs a failed login attempt. playerID is uuid.Nil when
+// the username doesn't belong to an account.
+func (al *ActivityLogger) LogLoginFailure(playerID uuid.UUID, username, ipAddress, reason string) {
 	details := map[string]interface{}{
 		"reason": reason,
 	}
 
-	al.Log(uuid.Nil, username, ActivityLoginFailure, ipAddress, false, details)
+	al.Log(playerID, username, ActivityLoginFailure, ipAddress, false, details)
 }
 
 // IsNewIP checks if an IP is new for a player
@@ -311,13 +328,24 @@ func (al *ActivityLogger) IsNewIP(playerID uuid.UUID, ipAddress string) bool {
 	return !seen
 }
 
+// SeedIP marks an IP as known for a player, e.g. from stored login history
+func (al *ActivityLogger) SeedIP(playerID uuid.UUID, ipAddress string, seen time.Time) {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	if last, exists := al.playerIPs[playerID][ipAddress]; exists && last.After(seen) {
+		return
+	}
+	al.trackIP(playerID, ipAddress, seen)
+}
+
 // trackIP tracks an IP address for a player
-func (al *ActivityLogger) trackIP(playerID uuid.UUID, ipAddress string) {
+func (al *ActivityLogger) trackIP(playerID uuid.UUID, ipAddress string, seen time.Time) {
 	if _, exists := al.playerIPs[playerID]; !exists {
 		al.playerIPs[playerID] = make(map[string]time.Time)
 	}
 
-	al.playerIPs[playerID][ipAddress] = time.Now()
+	al.playerIPs[playerID][ipAddress] = seen
 }
 
 // GetPlayerEvents returns recent events for a player
@@ -1,7 +1,7 @@
 // File: internal/security/anomaly.go
 // Project: Terminal Velocity
 // Description: Login anomaly detection for suspicious activity
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-11-14
 
@@ -116,7 +116,7 @@
 // Manager.ValidateLogin() automatically calls DetectAnomalies() and logs suspicious
 // logins. High-risk logins can trigger additional verification challenges.
 //
-// Version: 1.1.0
+// Version: 1.2.0
 // Last Updated: 2025-11-16
 package security
 
@@ -154,6 +154,13 @@ func NewAnomalyDetector() *AnomalyDetector {
 
 // RecordLogin records a login for pattern analysis
 func (ad *AnomalyDetector) RecordLogin(playerID uuid.UUID, ipAddress, userAgent string) {
+	ad.RecordLoginAt(playerID, ipAddress, userAgent, time.Now())
+}
+
+// RecordLoginAt records a login that happened at the given time. Used to
+// rebuild a player's profile from stored login history; records must be
+// added oldest first.
+func (ad *AnomalyDetector) RecordLoginAt(playerID uuid.UUID, ipAddress, userAgent string, at time.Time) {
 	ad.mu.Lock()
 	defer ad.mu.Unlock()
 
@@ -170,7 +177,7 @@ func (ad *AnomalyDetector) RecordLogin(playerID uuid.UUID, ipAddress, userAgent
 		ad.history[playerID] = history
 	}
 
-	now := time.Now()
+	now := at
 
 	// Record timestamp
 	history.Timestamps = append(history.Timestamps, now)
@@ -1,7 +1,7 @@
 // File: internal/security/manager.go
 // Project: Terminal Velocity
 // Description: Central security manager integrating all security features
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-11-14
 
@@ -33,13 +33,14 @@
 // Security Flow (Login):
 //
 //	1. ValidateLogin() - Pre-authentication security checks
-//	   - Check if username is honeypot
+//	   - Check if username is honeypot (auto-ban via ban callback)
 //	   - Detect login anomalies (new IP, device, location)
-//	   - Calculate risk score
-//	2. OnLoginSuccess() - Post-authentication processing
+//	   - Calculate risk score (ErrChallengeRequired above threshold)
+//	2. OnLoginSuccess() / OnLoginFailure() - Post-authentication processing
 //	   - Create secure session
-//	   - Log successful login event
+//	   - Log login event
 //	   - Track IP for future anomaly detection
+//	   - Persist the attempt to login history (if a Store is set)
 //	3. Session Active - Ongoing security
 //	   - Update activity timestamps
 //	   - Monitor for timeout
@@ -61,9 +62,15 @@
 //	securityMgr := security.NewManager(config)
 //	defer securityMgr.Stop()
 //
+//	// Persist events and ban honeypot attackers
+//	securityMgr.SetStore(securityRepo)
+//	securityMgr.SetBanCallback(rateLimiter.BanIP)
+//
 //	// Validate login attempt
 //	err := securityMgr.ValidateLogin(username, password, ipAddress, userAgent, playerID)
-//	if err != nil {
+//	if errors.Is(err, security.ErrChallengeRequired) {
+//	    // High-risk login - require a second factor
+//	} else if err != nil {
 //	    // Login rejected due to security concerns
 //	    return err
 //	}
@@ -107,17 +114,34 @@
 //	    EnableAllFeatures bool  // Default: true
 //	}
 //
-// Version: 1.1.0
+// Version: 1.2.0
 // Last Updated: 2025-11-16
 package security
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Login validation errors.
+var (
+	// ErrAuthenticationFailed rejects a login without saying why (honeypot
+	// accounts).
+	ErrAuthenticationFailed = errors.New("authentication failed")
+
+	// ErrChallengeRequired marks a high-risk login that must pass an extra
+	// verification step (2FA or password) before it completes.
+	ErrChallengeRequired = errors.New("additional verification required")
+)
+
+// historySeedSize is how many stored logins rebuild a player's anomaly
+// profile after a restart.
+const historySeedSize = 100
+
 // Manager is the central security manager that coordinates all security subsystems.
 //
 // Manager provides a unified interface for authentication security checks, session
@@ -139,6 +163,11 @@ type Manager struct {
 	honeypot  *HoneypotDetector // Honeypot attack detection
 	anomaly   *AnomalyDetector  // Login anomaly detection
 	config    *Config           // Security configuration
+
+	mu     sync.Mutex
+	store  Store                                                  // Persistence (optional)
+	banIP  func(ip string, reason string, duration time.Duration) // Auto-ban hook (optional)
+	seeded map[uuid.UUID]bool                                     // Players whose history is loaded
 }
 
 // Config holds security manager configuration.
@@ -250,6 +279,7 @@ func NewManager(config *Config) *Manager {
 		honeypot: NewHoneypotDetector(config.HoneypotAutoban, config.HoneypotAutobanDuration),
 		anomaly:  NewAnomalyDetector(),
 		config:   config,
+		seeded:   make(map[uuid.UUID]bool),
 	}
 
 	log.Info("Security manager initialized with all features enabled")
@@ -257,6 +287,33 @@ func NewManager(config *Config) *Manager {
 	return manager
 }
 
+// SetStore sets where login history, account events and honeypot attempts
+// are persisted. Without a store the manager keeps everything in memory.
+func (m *Manager) SetStore(store Store) {
+	m.mu.Lock()
+	m.store = store
+	m.mu.Unlock()
+
+	if store == nil {
+		m.activity.SetEventCallback(nil)
+		return
+	}
+	m.activity.SetEventCallback(func(event *ActivityEvent) {
+		if err := store.SaveAccountEvent(context.Background(), event); err != nil {
+			log.Error("Failed to persist account event %s: %v", event.EventType, err)
+		}
+	})
+}
+
+// SetBanCallback sets the function used to ban IPs that hit honeypot
+// accounts (typically the rate limiter's BanIP).
+func (m *Manager) SetBanCallback(fn func(ip string, reason string, duration time.Duration)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.banIP = fn
+}
+
 // ValidateLogin validates a login attempt with all security checks.
 //
 // This method performs comprehensive pre-authentication security validation including
@@ -274,45 +331,69 @@ func NewManager(config *Config) *Manager {
 //   - password: Password (unused currently, for future password strength checks)
 //   - ipAddress: IP address of login attempt
 //   - userAgent: User agent string (optional, for device tracking)
-//   - playerID: Player ID if known (uuid.Nil if unknown/first login)
+//   - playerID: Player ID if the account exists (uuid.Nil if unknown). Honeypot
+//     names only trap usernames that don't belong to a real account.
 //
 // Returns:
 //   - error: Returns error if login should be rejected, nil if checks pass
 //
 // Errors:
-//   - Returns generic "authentication failed" for honeypot attempts (logs details internally)
-//   - Future: May return specific errors for 2FA challenges, account locks, etc.
+//   - ErrAuthenticationFailed: Honeypot account (details are logged internally;
+//     the IP is banned through the ban callback when auto-ban is enabled)
+//   - ErrChallengeRequired: Risk score at or above AnomalyRiskThreshold with
+//     RequireChallengeOnAnomaly set. The caller should verify a second factor
+//     after the credentials check instead of rejecting the login.
 //
 // Example:
 //
 //	// Before credential verification
 //	err := manager.ValidateLogin(username, password, ipAddress, userAgent, playerID)
-//	if err != nil {
+//	stepUp := errors.Is(err, security.ErrChallengeRequired)
+//	if err != nil && !stepUp {
 //	    log.Warn("Security validation failed: %v", err)
 //	    return err
 //	}
 //
-//	// Now verify credentials...
-//
-// Note: This method logs security events internally. Honeypot attempts trigger
-// auto-ban if configured.
+//	// Now verify credentials (and a second factor if stepUp)...
 func (m *Manager) ValidateLogin(username, password, ipAddress, userAgent string, playerID uuid.UUID) error {
 	// Check honeypot
-	if m.honeypot.IsHoneypot(username) {
+	if playerID == uuid.Nil && m.honeypot.IsHoneypot(username) {
 		m.honeypot.RecordAttempt(username, ipAddress)
-		m.activity.LogLoginFailure(username, ipAddress, "honeypot_account")
+		m.activity.LogLoginFailure(uuid.Nil, username, ipAddress, "honeypot_account")
 
 		// Check if should autoban
-		if m.honeypot.ShouldAutoban(ipAddress) {
+		autoban := m.honeypot.ShouldAutoban(ipAddress)
+		if autoban {
 			log.Error("AUTOBAN triggered for honeypot attempt: ip=%s, username=%s", ipAddress, username)
-			return fmt.Errorf("authentication failed")
 		}
 
-		return fmt.Errorf("authentication failed")
+		m.mu.Lock()
+		store, banIP := m.store, m.banIP
+		m.mu.Unlock()
+
+		if store != nil {
+			attempt := &HoneypotAttempt{
+				Username:   username,
+				IPAddress:  ipAddress,
+				UserAgent:  userAgent,
+				Autobanned: autoban && banIP != nil,
+				Timestamp:  time.Now(),
+			}
+			if err := store.SaveHoneypotAttempt(context.Background(), attempt); err != nil {
+				log.Error("Failed to persist honeypot attempt from %s: %v", ipAddress, err)
+			}
+		}
+		if autoban && banIP != nil {
+			banIP(ipAddress, "honeypot account: "+username, m.config.HoneypotAutobanDuration)
+		}
+
+		return ErrAuthenticationFailed
 	}
 
 	// If playerID is known, check for anomalies
 	if playerID != uuid.Nil {
+		m.seedHistory(playerID)
+
 		anomalies := m.anomaly.DetectAnomalies(playerID, ipAddress, userAgent)
 		if len(anomalies) > 0 {
 			log.Warn("Login anomalies detected: player=%s, ip=%s, anomalies=%v",
@@ -326,8 +407,8 @@ func (m *Manager) ValidateLogin(username, password, ipAddress, userAgent string,
 			// Check risk score
 			riskScore := m.anomaly.GetRiskScore(playerID, ipAddress, userAgent)
 			if riskScore >= m.config.AnomalyRiskThreshold && m.config.RequireChallengeOnAnomaly {
-				// In a full implementation, this would trigger 2FA or email verification
 				log.Warn("High risk login detected (score: %d): player=%s, ip=%s", riskScore, username, ipAddress)
+				return ErrChallengeRequired
 			}
 		}
 	}
@@ -337,9 +418,15 @@ func (m *Manager) ValidateLogin(username, password, ipAddress, userAgent string,
 
 // OnLoginSuccess handles successful login
 func (m *Manager) OnLoginSuccess(playerID uuid.UUID, username, ipAddress, userAgent string) (*Session, error) {
+	m.seedHistory(playerID)
+
 	// Check if this is a new IP
 	isNewIP := m.activity.IsNewIP(playerID, ipAddress)
 
+	// Score the login before it becomes part of the player's profile
+	record := m.newLoginRecord(playerID, ipAddress, userAgent)
+	record.Success = true
+
 	// Log successful login
 	m.activity.LogLoginSuccess(playerID, username, ipAddress, isNewIP)
 
@@ -349,8 +436,12 @@ func (m *Manager) OnLoginSuccess(playerID uuid.UUID, username, ipAddress, userAg
 	// Create session
 	session, err := m.sessions.CreateSession(playerID, username, ipAddress)
 	if err != nil {
+		record.Success = false
+		record.FailureReason = "max_sessions"
+		m.saveLoginRecord(record)
 		return nil, err
 	}
+	m.saveLoginRecord(record)
 
 	log.Info("Login successful: player=%s, ip=%s, sessionID=%s, newIP=%v",
 		username, ipAddress, session.ID, isNewIP)
@@ -358,13 +449,78 @@ func (m *Manager) OnLoginSuccess(playerID uuid.UUID, username, ipAddress, userAg
 	return session, nil
 }
 
-// OnLoginFailure handles failed login
-func (m *Manager) OnLoginFailure(username, ipAddress, reason string) {
-	m.activity.LogLoginFailure(username, ipAddress, reason)
+// OnLoginFailure handles failed login. playerID is uuid.Nil when the
+// username doesn't belong to an account; only attempts against real
+// accounts go into login history.
+func (m *Manager) OnLoginFailure(playerID uuid.UUID, username, ipAddress, userAgent, reason string) {
+	m.activity.LogLoginFailure(playerID, username, ipAddress, reason)
 
-	// Check if honeypot
-	if m.honeypot.IsHoneypot(username) {
-		m.honeypot.RecordAttempt(username, ipAddress)
+	if playerID == uuid.Nil {
+		return
+	}
+
+	m.seedHistory(playerID)
+	record := m.newLoginRecord(playerID, ipAddress, userAgent)
+	record.FailureReason = reason
+	m.saveLoginRecord(record)
+}
+
+// newLoginRecord scores a login attempt against the player's profile
+func (m *Manager) newLoginRecord(playerID uuid.UUID, ipAddress, userAgent string) *LoginRecord {
+	return &LoginRecord{
+		PlayerID:  playerID,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+		Anomalies: m.anomaly.DetectAnomalies(playerID, ipAddress, userAgent),
+		RiskScore: m.anomaly.GetRiskScore(playerID, ipAddress, userAgent),
+		Timestamp: time.Now(),
+	}
+}
+
+// saveLoginRecord persists a login attempt if a store is set
+func (m *Manager) saveLoginRecord(record *LoginRecord) {
+	m.mu.Lock()
+	store := m.store
+	m.mu.Unlock()
+
+	if store == nil {
+		return
+	}
+	if err := store.SaveLoginRecord(context.Background(), record); err != nil {
+		log.Error("Failed to persist login record for %s: %v", record.PlayerID, err)
+	}
+}
+
+// seedHistory loads a player's stored successful logins into the anomaly
+// detector and known-IP list the first time the player is seen, so new-IP
+// detection survives restarts
+func (m *Manager) seedHistory(playerID uuid.UUID) {
+	m.mu.Lock()
+	store := m.store
+	if store == nil || m.seeded[playerID] {
+		m.mu.Unlock()
+		return
+	}
+	m.seeded[playerID] = true
+	m.mu.Unlock()
+
+	records, err := store.GetLoginHistory(context.Background(), playerID, historySeedSize)
+	if err != nil {
+		log.Error("Failed to load login history for %s: %v", playerID, err)
+		m.mu.Lock()
+		delete(m.seeded, playerID)
+		m.mu.Unlock()
+		return
+	}
+
+	// History is newest first; profiles are built oldest first
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		if !record.Success {
+			continue
+		}
+		m.anomaly.RecordLoginAt(playerID, record.IPAddress, record.UserAgent, record.Timestamp)
+		m.activity.SeedIP(playerID, record.IPAddress, record.Timestamp)
 	}
 }
 
@@ -0,0 +1,150 @@
+// File: internal/security/manager_test.go
+// Project: Terminal Velocity
+// Description: Tests for login validation, honeypot bans and persisted login history
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package security
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memoryStore is an in-memory Store for tests
+type memoryStore struct {
+	mu        sync.Mutex
+	events    []*ActivityEvent
+	logins    []*LoginRecord
+	honeypots []*HoneypotAttempt
+}
+
+func (s *memoryStore) SaveAccountEvent(ctx context.Context, event *ActivityEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *memoryStore) SaveLoginRecord(ctx context.Context, record *LoginRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logins = append(s.logins, record)
+	return nil
+}
+
+func (s *memoryStore) SaveHoneypotAttempt(ctx context.Context, attempt *HoneypotAttempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.honeypots = append(s.honeypots, attempt)
+	return nil
+}
+
+func (s *memoryStore) GetLoginHistory(ctx context.Context, playerID uuid.UUID, limit int) ([]*LoginRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var records []*LoginRecord
+	for i := len(s.logins) - 1; i >= 0 && len(records) < limit; i-- {
+		if s.logins[i].PlayerID == playerID {
+			records = append(records, s.logins[i])
+		}
+	}
+	return records, nil
+}
+
+func newTestManager(t *testing.T) (*Manager, *memoryStore) {
+	t.Helper()
+
+	m := NewManager(nil)
+	t.Cleanup(m.Stop)
+
+	store := &memoryStore{}
+	m.SetStore(store)
+	return m, store
+}
+
+func TestHoneypotBansIP(t *testing.T) {
+	m, store := newTestManager(t)
+
+	var bannedIP string
+	m.SetBanCallback(func(ip, reason string, duration time.Duration) {
+		bannedIP = ip
+	})
+
+	err := m.ValidateLogin("admin", "hunter2", "203.0.113.9", "SSH-2.0-Go", uuid.Nil)
+	if !errors.Is(err, ErrAuthenticationFailed) {
+		t.Fatalf("Expected ErrAuthenticationFailed, got %v", err)
+	}
+	if bannedIP != "203.0.113.9" {
+		t.Errorf("Expected honeypot IP to be banned, got %q", bannedIP)
+	}
+	if len(store.honeypots) != 1 || !store.honeypots[0].Autobanned {
+		t.Errorf("Expected one autobanned honeypot attempt, got %+v", store.honeypots)
+	}
+	if len(store.events) == 0 || store.events[0].EventType != ActivityLoginFailure {
+		t.Errorf("Expected login failure event to be persisted, got %+v", store.events)
+	}
+
+	// A real account with a honeypot name is not a trap
+	if err := m.ValidateLogin("admin", "hunter2", "198.51.100.1", "", uuid.New()); err != nil {
+		t.Errorf("Expected real account to pass, got %v", err)
+	}
+}
+
+func TestHighRiskLoginRequiresChallenge(t *testing.T) {
+	m, store := newTestManager(t)
+	playerID := uuid.New()
+
+	// Known history from before a restart: a login minutes ago
+	store.logins = append(store.logins, &LoginRecord{
+		PlayerID:  playerID,
+		IPAddress: "198.51.100.1",
+		UserAgent: "SSH-2.0-OpenSSH_9.6",
+		Success:   true,
+		Timestamp: time.Now().Add(-10 * time.Minute),
+	})
+
+	// Same address and client is fine
+	if err := m.ValidateLogin("alice", "", "198.51.100.1", "SSH-2.0-OpenSSH_9.6", playerID); err != nil {
+		t.Fatalf("Expected familiar login to pass, got %v", err)
+	}
+
+	// New address and client right after the last login
+	err := m.ValidateLogin("alice", "", "203.0.113.50", "SSH-2.0-PuTTY", playerID)
+	if !errors.Is(err, ErrChallengeRequired) {
+		t.Fatalf("Expected ErrChallengeRequired, got %v", err)
+	}
+
+	if _, err := m.OnLoginSuccess(playerID, "alice", "203.0.113.50", "SSH-2.0-PuTTY"); err != nil {
+		t.Fatalf("OnLoginSuccess failed: %v", err)
+	}
+	record := store.logins[len(store.logins)-1]
+	if !record.Success || record.RiskScore < m.config.AnomalyRiskThreshold {
+		t.Errorf("Expected high-risk success record, got %+v", record)
+	}
+	if m.activity.IsNewIP(playerID, "203.0.113.50") {
+		t.Error("Expected IP to be known after a successful login")
+	}
+}
+
+func TestLoginFailureRecorded(t *testing.T) {
+	m, store := newTestManager(t)
+	playerID := uuid.New()
+
+	m.OnLoginFailure(playerID, "alice", "198.51.100.1", "", "invalid_password")
+	m.OnLoginFailure(uuid.Nil, "nobody", "198.51.100.1", "", "invalid_password")
+
+	if len(store.logins) != 1 || store.logins[0].Success || store.logins[0].FailureReason != "invalid_password" {
+		t.Errorf("Expected one failed login record for the known account, got %+v", store.logins)
+	}
+	if len(store.events) != 2 {
+		t.Errorf("Expected both failures in the account events, got %d", len(store.events))
+	}
+}
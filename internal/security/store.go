@@ -0,0 +1,57 @@
+// File: internal/security/store.go
+// Project: Terminal Velocity
+// Description: Persistence interface for login history, account events and honeypot attempts
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package security
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginRecord is one authentication attempt against a known account.
+type LoginRecord struct {
+	PlayerID      uuid.UUID
+	IPAddress     string
+	UserAgent     string
+	Success       bool
+	FailureReason string   // Empty for successful logins
+	Anomalies     []string // Anomalies detected at login time (new_ip, new_device, ...)
+	RiskScore     int      // 0-100
+	Timestamp     time.Time
+
+	// NewIP is set when reading history: the attempt came from an address
+	// the player had never logged in from before.
+	NewIP bool
+}
+
+// HoneypotAttempt is a login attempt against a honeypot account.
+type HoneypotAttempt struct {
+	Username   string
+	IPAddress  string
+	UserAgent  string
+	Autobanned bool
+	Timestamp  time.Time
+}
+
+// Store persists security events so they survive restarts. The database
+// package's SecurityRepository implements it.
+type Store interface {
+	// SaveAccountEvent stores an activity log event
+	SaveAccountEvent(ctx context.Context, event *ActivityEvent) error
+
+	// SaveLoginRecord stores a login attempt against a known account
+	SaveLoginRecord(ctx context.Context, record *LoginRecord) error
+
+	// SaveHoneypotAttempt stores a honeypot hit
+	SaveHoneypotAttempt(ctx context.Context, attempt *HoneypotAttempt) error
+
+	// GetLoginHistory returns a player's most recent login attempts,
+	// newest first
+	GetLoginHistory(ctx context.Context, playerID uuid.UUID, limit int) ([]*LoginRecord, error)
+}
@@ -1,7 +1,7 @@
 // File: internal/security/totp.go
 // Project: Terminal Velocity
 // Description: Two-Factor Authentication (TOTP) support
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-11-14
 
@@ -38,7 +38,8 @@
 // Security Considerations:
 //   - Secrets are 32 bytes (256 bits) for strong security
 //   - Backup codes are base32-encoded for readability (no ambiguous characters)
-//   - Backup codes should be stored hashed (not implemented here, do in database layer)
+//   - Backup codes are stored as SHA-256 hashes (HashBackupCode); the plain codes
+//     are only shown once, when generated
 //   - QR codes should only be displayed over secure connections
 //   - Time sync is critical: server time must be accurate (use NTP)
 //   - Code verification has ~30 second window (1 period before/after)
@@ -101,23 +102,33 @@
 //	    }
 //	}
 //
-// Version: 1.1.0
+// Version: 1.2.0
 // Last Updated: 2025-11-16
 package security
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base32"
+	"encoding/hex"
 	"fmt"
 	"image/png"
 	"io"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/boombuler/barcode/qr"
 	"github.com/google/uuid"
 	"github.com/pquerna/otp"
 	"github.com/pquerna/otp/totp"
 )
 
+// BackupCodeCount is the number of backup codes issued at enrollment and
+// on every rotation
+const BackupCodeCount = 10
+
 // TwoFactorConfig holds 2FA configuration for a player
 type TwoFactorConfig struct {
 	PlayerID     uuid.UUID
@@ -160,8 +171,7 @@ func (tfm *TwoFactorManager) GenerateSecret(username string) (string, error) {
 
 // GenerateQRCode generates a QR code for TOTP setup
 func (tfm *TwoFactorManager) GenerateQRCode(username, secret string, output io.Writer) error {
-	key, err := otp.NewKeyFromURL(fmt.Sprintf("otpauth://totp/%s:%s?secret=%s&issuer=%s",
-		tfm.issuer, username, secret, tfm.issuer))
+	key, err := otp.NewKeyFromURL(tfm.GetTOTPURL(username, secret))
 	if err != nil {
 		return fmt.Errorf("failed to create TOTP key: %w", err)
 	}
@@ -236,5 +246,136 @@ func generateRandomCode(length int) (string, error) {
 // GetTOTPURL returns the TOTP URL for manual entry
 func (tfm *TwoFactorManager) GetTOTPURL(username, secret string) string {
 	return fmt.Sprintf("otpauth://totp/%s:%s?secret=%s&issuer=%s",
-		tfm.issuer, username, secret, tfm.issuer)
+		url.PathEscape(tfm.issuer), url.PathEscape(username), secret, url.QueryEscape(tfm.issuer))
+}
+
+// NewConfig starts enrollment for a player.
+//
+// The returned config is disabled until the player confirms a code from
+// their authenticator app. Only hashes of the backup codes are kept in the
+// config; the plain codes are returned so they can be shown once.
+func (tfm *TwoFactorManager) NewConfig(playerID uuid.UUID, username string) (*TwoFactorConfig, []string, error) {
+	secret, err := tfm.GenerateSecret(username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := &TwoFactorConfig{
+		PlayerID:  playerID,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}
+
+	codes, err := tfm.RotateBackupCodes(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return config, codes, nil
+}
+
+// RotateBackupCodes replaces all of a player's backup codes and returns the
+// new plain codes
+func (tfm *TwoFactorManager) RotateBackupCodes(config *TwoFactorConfig) ([]string, error) {
+	codes, err := tfm.GenerateBackupCodes(BackupCodeCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate backup codes: %w", err)
+	}
+
+	config.BackupCodes = make([]string, len(codes))
+	for i, code := range codes {
+		config.BackupCodes[i] = HashBackupCode(code)
+	}
+
+	return codes, nil
+}
+
+// Authenticate checks a login code against a player's config.
+//
+// Six-digit codes are checked as TOTP codes; anything else is treated as a
+// backup code. A matching backup code is removed from the config so it
+// can't be used again. On success LastUsed is set and the caller should
+// persist the config.
+func (tfm *TwoFactorManager) Authenticate(config *TwoFactorConfig, code string) (ok bool, usedBackupCode bool) {
+	code = strings.TrimSpace(code)
+
+	if len(code) == 6 && strings.Trim(code, "0123456789") == "" {
+		ok = tfm.VerifyCode(config.Secret, code)
+	} else {
+		hash := HashBackupCode(code)
+		for i, stored := range config.BackupCodes {
+			if subtle.ConstantTimeCompare([]byte(stored), []byte(hash)) == 1 {
+				remaining := make([]string, 0, len(config.BackupCodes)-1)
+				remaining = append(remaining, config.BackupCodes[:i]...)
+				config.BackupCodes = append(remaining, config.BackupCodes[i+1:]...)
+				ok, usedBackupCode = true, true
+				break
+			}
+		}
+	}
+
+	if ok {
+		now := time.Now()
+		config.LastUsed = &now
+	}
+
+	return ok, usedBackupCode
+}
+
+// HashBackupCode returns the stored form of a backup code. Codes are
+// compared case-insensitively, ignoring spaces and dashes.
+func HashBackupCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// FormatBackupCode splits a backup code into two groups for display
+func FormatBackupCode(code string) string {
+	if len(code) <= 4 {
+		return code
+	}
+	return code[:4] + "-" + code[4:]
+}
+
+// RenderQRCode renders the TOTP setup QR code as text for a terminal.
+//
+// Each character covers two rows of modules using half-block glyphs.
+// Light modules (and the quiet zone) are drawn as blocks, so the code must
+// be displayed light-on-dark to scan reliably.
+func (tfm *TwoFactorManager) RenderQRCode(username, secret string) (string, error) {
+	code, err := qr.Encode(tfm.GetTOTPURL(username, secret), qr.L, qr.Auto)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate QR code: %w", err)
+	}
+
+	const quietZone = 2
+	size := code.Bounds().Dx()
+	light := func(x, y int) bool {
+		if x < 0 || y < 0 || x >= size || y >= size {
+			return true
+		}
+		r, _, _, _ := code.At(x, y).RGBA()
+		return r > 0x7fff
+	}
+
+	var sb strings.Builder
+	for y := -quietZone; y < size+quietZone; y += 2 {
+		for x := -quietZone; x < size+quietZone; x++ {
+			top, bottom := light(x, y), light(x, y+1)
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String(), nil
 }
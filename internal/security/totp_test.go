@@ -0,0 +1,99 @@
+// File: internal/security/totp_test.go
+// Project: Terminal Velocity
+// Description: Tests for TOTP verification, backup codes and QR rendering
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package security
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/pquerna/otp/totp"
+)
+
+func TestTwoFactorAuthenticate(t *testing.T) {
+	tfm := NewTwoFactorManager("")
+	config, codes, err := tfm.NewConfig(uuid.New(), "alice")
+	if err != nil {
+		t.Fatalf("NewConfig failed: %v", err)
+	}
+	if config.Enabled {
+		t.Error("New config should not be enabled until confirmed")
+	}
+	if len(codes) != BackupCodeCount || len(config.BackupCodes) != BackupCodeCount {
+		t.Fatalf("Expected %d backup codes, got %d/%d", BackupCodeCount, len(codes), len(config.BackupCodes))
+	}
+	for i, code := range codes {
+		if config.BackupCodes[i] == code {
+			t.Fatal("Backup codes must be stored hashed")
+		}
+	}
+
+	code, err := totp.GenerateCode(config.Secret, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, backup := tfm.Authenticate(config, code); !ok || backup {
+		t.Errorf("Expected TOTP code to pass, got ok=%v backup=%v", ok, backup)
+	}
+	if config.LastUsed == nil {
+		t.Error("Expected LastUsed to be set")
+	}
+	if ok, _ := tfm.Authenticate(config, "000000"); ok && code != "000000" {
+		t.Error("Expected wrong TOTP code to fail")
+	}
+
+	// Backup codes work once, in any case and with the display dash
+	formatted := strings.ToLower(FormatBackupCode(codes[3]))
+	if ok, backup := tfm.Authenticate(config, formatted); !ok || !backup {
+		t.Fatalf("Expected backup code to pass, got ok=%v backup=%v", ok, backup)
+	}
+	if len(config.BackupCodes) != BackupCodeCount-1 {
+		t.Errorf("Expected used backup code to be removed, %d left", len(config.BackupCodes))
+	}
+	if ok, _ := tfm.Authenticate(config, codes[3]); ok {
+		t.Error("Backup code should only work once")
+	}
+
+	// Rotation invalidates the old codes
+	if _, err := tfm.RotateBackupCodes(config); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := tfm.Authenticate(config, codes[0]); ok {
+		t.Error("Old backup code should not work after rotation")
+	}
+}
+
+func TestRenderQRCode(t *testing.T) {
+	tfm := NewTwoFactorManager("Terminal Velocity")
+	secret, err := tfm.GenerateSecret("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	qrCode, err := tfm.RenderQRCode("bob", secret)
+	if err != nil {
+		t.Fatalf("RenderQRCode failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(qrCode, "\n"), "\n")
+	width := utf8.RuneCountInString(lines[0])
+	if width < 25 {
+		t.Fatalf("QR code too small: %d columns", width)
+	}
+	for i, line := range lines {
+		if utf8.RuneCountInString(line) != width {
+			t.Fatalf("Line %d has %d columns, expected %d", i, utf8.RuneCountInString(line), width)
+		}
+	}
+	// Two module rows per line plus the quiet zone
+	if len(lines) != (width+1)/2 {
+		t.Errorf("Expected %d lines for %d columns, got %d", (width+1)/2, width, len(lines))
+	}
+}
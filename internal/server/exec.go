@@ -0,0 +1,500 @@
+// File: internal/server/exec.go
+// Project: Terminal Velocity
+// Description: Non-interactive SSH exec commands for scripts and status bars
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/api"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/ssh"
+)
+
+// Exit statuses reported to exec clients
+const (
+	execExitOK           = 0 // Command succeeded
+	execExitFailure      = 1 // Command failed (not found, server error)
+	execExitUsage        = 2 // Unknown command or bad arguments
+	execExitUnauthorized = 3 // No player: the client did not use a registered SSH key
+)
+
+// execTimeout bounds how long a single exec command may run
+const execTimeout = 10 * time.Second
+
+// errExecUsage marks errors caused by bad command-line arguments
+var errExecUsage = errors.New("usage")
+
+// execRequestMsg is the payload of an SSH "exec" request (RFC 4254 6.5)
+type execRequestMsg struct {
+	Command string
+}
+
+// exitStatusMsg is the payload of an SSH "exit-status" request (RFC 4254 6.10)
+type exitStatusMsg struct {
+	Status uint32
+}
+
+// systemLookup resolves star systems for exec output and arguments
+type systemLookup interface {
+	GetSystemByID(ctx context.Context, id uuid.UUID) (*models.StarSystem, error)
+	GetSystemByName(ctx context.Context, name string) (*models.StarSystem, error)
+}
+
+// execEnv is everything an exec command can read.
+// Commands are read-only: game state is queried through the API client.
+type execEnv struct {
+	client   api.Client
+	systems  systemLookup
+	playerID uuid.UUID // uuid.Nil if the connection is not authenticated
+}
+
+// execResult is the output of a successful command.
+// With --json the result is encoded as-is; otherwise writeText prints it.
+type execResult interface {
+	writeText(w io.Writer)
+}
+
+// execCommand is one exec command
+type execCommand struct {
+	usage   string
+	summary string
+	run     func(ctx context.Context, env *execEnv, args []string) (execResult, error)
+}
+
+// execCommands lists the available commands by name
+var execCommands = map[string]execCommand{
+	"status": {
+		usage:   "status",
+		summary: "Credits, location and ship condition",
+		run:     execStatus,
+	},
+	"market": {
+		usage:   "market [system]",
+		summary: "Commodity prices in a system (default: current system)",
+		run:     execMarket,
+	},
+	"cargo": {
+		usage:   "cargo",
+		summary: "Cargo hold contents",
+		run:     execCargo,
+	},
+	"mail": {
+		usage:   "mail unread",
+		summary: "Unread mail",
+		run:     execMail,
+	},
+}
+
+// runExecCommand runs one exec command line and returns its exit status.
+// Results go to stdout and errors to stderr. "--json" anywhere on the
+// line selects JSON output.
+func runExecCommand(ctx context.Context, env *execEnv, line string, stdout, stderr io.Writer) int {
+	var args []string
+	jsonOutput := false
+	for _, arg := range strings.Fields(line) {
+		if arg == "--json" {
+			jsonOutput = true
+			continue
+		}
+		args = append(args, arg)
+	}
+
+	if len(args) == 0 {
+		writeExecHelp(stderr)
+		return execExitUsage
+	}
+	if args[0] == "help" || args[0] == "--help" {
+		writeExecHelp(stdout)
+		return execExitOK
+	}
+
+	cmd, ok := execCommands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command: %s\n\n", args[0])
+		writeExecHelp(stderr)
+		return execExitUsage
+	}
+
+	if env.playerID == uuid.Nil {
+		fmt.Fprintln(stderr, "authentication required: connect with an SSH key registered to your account")
+		return execExitUnauthorized
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, execTimeout)
+	defer cancel()
+
+	result, err := cmd.run(ctx, env, args[1:])
+	if errors.Is(err, errExecUsage) {
+		fmt.Fprintf(stderr, "usage: %s\n", cmd.usage)
+		return execExitUsage
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", args[0], err)
+		return execExitFailure
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			fmt.Fprintf(stderr, "%s: failed to encode JSON: %v\n", args[0], err)
+			return execExitFailure
+		}
+		return execExitOK
+	}
+
+	result.writeText(stdout)
+	return execExitOK
+}
+
+// writeExecHelp lists the available commands
+func writeExecHelp(w io.Writer) {
+	names := make([]string, 0, len(execCommands))
+	for name := range execCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Terminal Velocity commands (add --json for JSON output):")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		cmd := execCommands[name]
+		fmt.Fprintf(tw, "  %s\t%s\n", cmd.usage, cmd.summary)
+	}
+	tw.Flush()
+}
+
+// systemName returns a system's name, or its ID if it cannot be found
+func (e *execEnv) systemName(ctx context.Context, id uuid.UUID) string {
+	if e.systems != nil {
+		if system, err := e.systems.GetSystemByID(ctx, id); err == nil {
+			return system.Name
+		}
+	}
+	return id.String()
+}
+
+// ============================================================================
+// COMMANDS
+// ============================================================================
+
+type statusResult struct {
+	Username   string      `json:"username"`
+	Credits    int64       `json:"credits"`
+	SystemID   uuid.UUID   `json:"system_id"`
+	SystemName string      `json:"system_name"`
+	Docked     bool        `json:"docked"`
+	Status     string      `json:"status"`
+	Ship       *shipStatus `json:"ship,omitempty"`
+}
+
+type shipStatus struct {
+	Type       string `json:"type"`
+	Name       string `json:"name,omitempty"`
+	Hull       int32  `json:"hull"`
+	MaxHull    int32  `json:"max_hull"`
+	Shields    int32  `json:"shields"`
+	MaxShields int32  `json:"max_shields"`
+	Fuel       int32  `json:"fuel"`
+	MaxFuel    int32  `json:"max_fuel"`
+	CargoUsed  int32  `json:"cargo_used"`
+	CargoSpace int32  `json:"cargo_space"`
+}
+
+func execStatus(ctx context.Context, env *execEnv, args []string) (execResult, error) {
+	if len(args) != 0 {
+		return nil, errExecUsage
+	}
+
+	state, err := env.client.GetPlayerState(ctx, env.playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &statusResult{
+		Username:   state.Username,
+		Credits:    state.Credits,
+		SystemID:   state.CurrentSystemID,
+		SystemName: env.systemName(ctx, state.CurrentSystemID),
+		Docked:     state.CurrentPlanetID != nil,
+		Status:     string(state.Status),
+	}
+	if ship := state.Ship; ship != nil {
+		result.Ship = &shipStatus{
+			Type:       ship.ShipType,
+			Name:       ship.CustomName,
+			Hull:       ship.Hull,
+			MaxHull:    ship.MaxHull,
+			Shields:    ship.Shields,
+			MaxShields: ship.MaxShields,
+			Fuel:       ship.Fuel,
+			MaxFuel:    ship.MaxFuel,
+			CargoUsed:  ship.CargoUsed,
+			CargoSpace: ship.CargoSpace,
+		}
+	}
+
+	return result, nil
+}
+
+func (r *statusResult) writeText(w io.Writer) {
+	location := "in space"
+	if r.Docked {
+		location = "docked"
+	}
+
+	fmt.Fprintf(w, "Pilot:    %s\n", r.Username)
+	fmt.Fprintf(w, "Credits:  %d\n", r.Credits)
+	fmt.Fprintf(w, "System:   %s (%s)\n", r.SystemName, location)
+	if r.Ship != nil {
+		ship := r.Ship.Type
+		if r.Ship.Name != "" {
+			ship = fmt.Sprintf("%s \"%s\"", r.Ship.Type, r.Ship.Name)
+		}
+		fmt.Fprintf(w, "Ship:     %s\n", ship)
+		fmt.Fprintf(w, "Hull:     %d/%d\n", r.Ship.Hull, r.Ship.MaxHull)
+		fmt.Fprintf(w, "Shields:  %d/%d\n", r.Ship.Shields, r.Ship.MaxShields)
+		fmt.Fprintf(w, "Fuel:     %d/%d\n", r.Ship.Fuel, r.Ship.MaxFuel)
+		fmt.Fprintf(w, "Cargo:    %d/%d\n", r.Ship.CargoUsed, r.Ship.CargoSpace)
+	}
+}
+
+type marketResult struct {
+	SystemID    uuid.UUID   `json:"system_id"`
+	SystemName  string      `json:"system_name"`
+	Commodities []marketRow `json:"commodities"`
+}
+
+type marketRow struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	BuyPrice  int32  `json:"buy_price"`
+	SellPrice int32  `json:"sell_price"`
+	Stock     int32  `json:"stock"`
+	Illegal   bool   `json:"illegal"`
+}
+
+func execMarket(ctx context.Context, env *execEnv, args []string) (execResult, error) {
+	var systemID uuid.UUID
+	var systemName string
+
+	if len(args) == 0 {
+		state, err := env.client.GetPlayerState(ctx, env.playerID)
+		if err != nil {
+			return nil, err
+		}
+		systemID = state.CurrentSystemID
+		systemName = env.systemName(ctx, systemID)
+	} else {
+		// System names may contain spaces; IDs are accepted too
+		name := strings.Join(args, " ")
+		if id, err := uuid.Parse(name); err == nil {
+			systemID = id
+			systemName = env.systemName(ctx, id)
+		} else {
+			if env.systems == nil {
+				return nil, fmt.Errorf("unknown system: %s", name)
+			}
+			system, err := env.systems.GetSystemByName(ctx, name)
+			if err != nil {
+				return nil, fmt.Errorf("unknown system: %s", name)
+			}
+			systemID, systemName = system.ID, system.Name
+		}
+	}
+
+	market, err := env.client.GetMarket(ctx, systemID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &marketResult{
+		SystemID:    systemID,
+		SystemName:  systemName,
+		Commodities: make([]marketRow, 0, len(market.Commodities)),
+	}
+	for _, c := range market.Commodities {
+		result.Commodities = append(result.Commodities, marketRow{
+			ID:        c.CommodityID,
+			Name:      c.Name,
+			BuyPrice:  c.BuyPrice,
+			SellPrice: c.SellPrice,
+			Stock:     c.Stock,
+			Illegal:   c.IsIllegal,
+		})
+	}
+	sort.Slice(result.Commodities, func(i, j int) bool {
+		return result.Commodities[i].Name < result.Commodities[j].Name
+	})
+
+	return result, nil
+}
+
+func (r *marketResult) writeText(w io.Writer) {
+	fmt.Fprintf(w, "Market: %s\n", r.SystemName)
+	if len(r.Commodities) == 0 {
+		fmt.Fprintln(w, "No commodities traded here.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Commodity\tBuy\tSell\tStock\t")
+	for _, c := range r.Commodities {
+		name := c.Name
+		if c.Illegal {
+			name += " (illegal)"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t\n", name, c.BuyPrice, c.SellPrice, c.Stock)
+	}
+	tw.Flush()
+}
+
+type cargoResult struct {
+	Used     int32      `json:"used"`
+	Capacity int32      `json:"capacity"`
+	Cargo    []cargoRow `json:"cargo"`
+}
+
+type cargoRow struct {
+	Commodity string `json:"commodity"`
+	Quantity  int32  `json:"quantity"`
+}
+
+func execCargo(ctx context.Context, env *execEnv, args []string) (execResult, error) {
+	if len(args) != 0 {
+		return nil, errExecUsage
+	}
+
+	inventory, err := env.client.GetPlayerInventory(ctx, env.playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &cargoResult{
+		Used:     inventory.CargoUsed,
+		Capacity: inventory.TotalCargoSpace,
+		Cargo:    make([]cargoRow, 0, len(inventory.Cargo)),
+	}
+	for commodity, quantity := range inventory.Cargo {
+		if quantity > 0 {
+			result.Cargo = append(result.Cargo, cargoRow{Commodity: commodity, Quantity: quantity})
+		}
+	}
+	sort.Slice(result.Cargo, func(i, j int) bool {
+		return result.Cargo[i].Commodity < result.Cargo[j].Commodity
+	})
+
+	return result, nil
+}
+
+func (r *cargoResult) writeText(w io.Writer) {
+	fmt.Fprintf(w, "Cargo: %d/%d\n", r.Used, r.Capacity)
+	if len(r.Cargo) == 0 {
+		fmt.Fprintln(w, "Hold is empty.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range r.Cargo {
+		fmt.Fprintf(tw, "  %s\t%d\n", c.Commodity, c.Quantity)
+	}
+	tw.Flush()
+}
+
+type mailResult struct {
+	Unread   int32     `json:"unread"`
+	Messages []mailRow `json:"messages"`
+}
+
+type mailRow struct {
+	ID      uuid.UUID `json:"id"`
+	From    string    `json:"from"`
+	Subject string    `json:"subject"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+func execMail(ctx context.Context, env *execEnv, args []string) (execResult, error) {
+	if len(args) != 1 || args[0] != "unread" {
+		return nil, errExecUsage
+	}
+
+	mail, err := env.client.GetUnreadMail(ctx, env.playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &mailResult{
+		Unread:   mail.UnreadCount,
+		Messages: make([]mailRow, 0, len(mail.Messages)),
+	}
+	for _, m := range mail.Messages {
+		result.Messages = append(result.Messages, mailRow{
+			ID:      m.MailID,
+			From:    m.SenderName,
+			Subject: m.Subject,
+			SentAt:  m.SentAt,
+		})
+	}
+
+	return result, nil
+}
+
+func (r *mailResult) writeText(w io.Writer) {
+	fmt.Fprintf(w, "Unread mail: %d\n", r.Unread)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, m := range r.Messages {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", m.SentAt.Format("2006-01-02 15:04"), m.From, m.Subject)
+	}
+	tw.Flush()
+}
+
+// ============================================================================
+// SSH SESSION
+// ============================================================================
+
+// handleExec runs an exec request's command on the session channel, sends
+// the exit status and closes the channel.
+func (s *Server) handleExec(perms *ssh.Permissions, channel ssh.Channel, command string) {
+	defer channel.Close()
+
+	env := &execEnv{
+		client:   s.apiClient,
+		systems:  s.systemRepo,
+		playerID: playerIDFromPermissions(perms),
+	}
+
+	log.Debug("Exec command from player %s: %q", env.playerID, command)
+	status := runExecCommand(context.Background(), env, command, channel, channel.Stderr())
+
+	if _, err := channel.SendRequest("exit-status", false, ssh.Marshal(&exitStatusMsg{Status: uint32(status)})); err != nil {
+		log.Debug("Failed to send exit status: %v", err)
+	}
+}
+
+// playerIDFromPermissions returns the player authenticated by public key,
+// or uuid.Nil for anonymous connections
+func playerIDFromPermissions(perms *ssh.Permissions) uuid.UUID {
+	if perms == nil || perms.Extensions == nil {
+		return uuid.Nil
+	}
+	playerID, err := uuid.Parse(perms.Extensions["player_id"])
+	if err != nil {
+		return uuid.Nil
+	}
+	return playerID
+}
@@ -0,0 +1,149 @@
+// File: internal/server/exec_test.go
+// Project: Terminal Velocity
+// Description: Tests for the SSH exec command dispatcher
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/api"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	execPlayerID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	execSolID    = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+)
+
+// fakeExecClient implements the read-only queries used by exec commands.
+// Other methods panic via the nil embedded interface.
+type fakeExecClient struct {
+	api.Client
+}
+
+func (c *fakeExecClient) GetPlayerState(ctx context.Context, playerID uuid.UUID) (*api.PlayerState, error) {
+	return &api.PlayerState{
+		PlayerID:        playerID,
+		Username:        "pilot",
+		Credits:         12500,
+		CurrentSystemID: execSolID,
+		Status:          api.PlayerStatusInSpace,
+		Ship:            &api.Ship{ShipType: "shuttle", Hull: 80, MaxHull: 100},
+	}, nil
+}
+
+func (c *fakeExecClient) GetMarket(ctx context.Context, systemID uuid.UUID) (*api.Market, error) {
+	if systemID != execSolID {
+		return nil, api.ErrNotFound
+	}
+	return &api.Market{
+		SystemID: systemID,
+		Commodities: []*api.CommodityListing{
+			{CommodityID: "food", Name: "Food", BuyPrice: 40, SellPrice: 50, Stock: 100},
+			{CommodityID: "electronics", Name: "Electronics", BuyPrice: 300, SellPrice: 320, Stock: 20},
+		},
+	}, nil
+}
+
+func (c *fakeExecClient) GetPlayerInventory(ctx context.Context, playerID uuid.UUID) (*api.Inventory, error) {
+	return &api.Inventory{Cargo: map[string]int32{"food": 10, "metals": 0}, CargoUsed: 10, TotalCargoSpace: 50}, nil
+}
+
+func (c *fakeExecClient) GetUnreadMail(ctx context.Context, playerID uuid.UUID) (*api.MailList, error) {
+	return &api.MailList{
+		UnreadCount: 1,
+		Messages:    []*api.MailMessage{{MailID: uuid.New(), SenderName: "trader", Subject: "Hello"}},
+	}, nil
+}
+
+// fakeSystems knows a single system, Sol
+type fakeSystems struct{}
+
+func (fakeSystems) GetSystemByID(ctx context.Context, id uuid.UUID) (*models.StarSystem, error) {
+	if id != execSolID {
+		return nil, database.ErrSystemNotFound
+	}
+	return &models.StarSystem{ID: execSolID, Name: "Sol"}, nil
+}
+
+func (fakeSystems) GetSystemByName(ctx context.Context, name string) (*models.StarSystem, error) {
+	if name != "Sol" {
+		return nil, database.ErrSystemNotFound
+	}
+	return &models.StarSystem{ID: execSolID, Name: "Sol"}, nil
+}
+
+func runExec(playerID uuid.UUID, line string) (status int, stdout, stderr string) {
+	env := &execEnv{client: &fakeExecClient{}, systems: fakeSystems{}, playerID: playerID}
+	var out, errOut bytes.Buffer
+	status = runExecCommand(context.Background(), env, line, &out, &errOut)
+	return status, out.String(), errOut.String()
+}
+
+func TestExecCommandExitStatuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		playerID uuid.UUID
+		line     string
+		want     int
+	}{
+		{"help", uuid.Nil, "help", execExitOK},
+		{"empty", execPlayerID, "", execExitUsage},
+		{"unknown command", execPlayerID, "jump Sol", execExitUsage},
+		{"bad arguments", execPlayerID, "mail all", execExitUsage},
+		{"anonymous", uuid.Nil, "status", execExitUnauthorized},
+		{"unknown system", execPlayerID, "market Vega", execExitFailure},
+		{"status", execPlayerID, "status", execExitOK},
+		{"market by name", execPlayerID, "market Sol", execExitOK},
+		{"cargo", execPlayerID, "cargo", execExitOK},
+		{"mail", execPlayerID, "mail unread", execExitOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status, _, stderr := runExec(tt.playerID, tt.line); status != tt.want {
+				t.Errorf("Expected exit status %d, got %d (stderr: %q)", tt.want, status, stderr)
+			}
+		})
+	}
+}
+
+func TestExecCommandTextOutput(t *testing.T) {
+	_, stdout, _ := runExec(execPlayerID, "status")
+	for _, want := range []string{"pilot", "12500", "Sol (in space)", "Hull:     80/100"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected status output to contain %q, got:\n%s", want, stdout)
+		}
+	}
+
+	// Current system is the default market
+	_, stdout, _ = runExec(execPlayerID, "market")
+	if !strings.Contains(stdout, "Market: Sol") || strings.Index(stdout, "Electronics") > strings.Index(stdout, "Food") {
+		t.Errorf("Unexpected market output:\n%s", stdout)
+	}
+}
+
+func TestExecCommandJSONOutput(t *testing.T) {
+	status, stdout, _ := runExec(execPlayerID, "cargo --json")
+	if status != execExitOK {
+		t.Fatalf("Expected success, got %d", status)
+	}
+
+	var cargo cargoResult
+	if err := json.Unmarshal([]byte(stdout), &cargo); err != nil {
+		t.Fatalf("Output is not JSON: %v\n%s", err, stdout)
+	}
+	if cargo.Used != 10 || len(cargo.Cargo) != 1 || cargo.Cargo[0].Commodity != "food" {
+		t.Errorf("Unexpected cargo: %+v", cargo)
+	}
+}
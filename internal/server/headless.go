@@ -0,0 +1,143 @@
+// File: internal/server/headless.go
+// Project: Terminal Velocity
+// Description: Headless game server exposing the game API over gRPC
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/api"
+	apiserver "github.com/JoshuaAFerguson/terminal-velocity/internal/api/server"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/content"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/economy"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/missions"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/quests"
+)
+
+// HeadlessServer runs the game server without the SSH gateway.
+//
+// Phase 2 Architecture:
+// The game logic (api/server.GameServer) is served over gRPC so that SSH
+// gateways and other frontends can run as separate processes using an
+// api.Client in ClientModeGRPC.
+//
+// Lifecycle mirrors Server: NewHeadlessServer connects to the database and
+// builds the game server, Start blocks until the context is cancelled.
+type HeadlessServer struct {
+	config         *Config
+	addr           string
+	db             *database.DB
+	economyManager *economy.Manager
+	gameServer     *apiserver.GameServer
+	grpcServer     *apiserver.GRPCServer
+}
+
+// NewHeadlessServer creates a headless game server listening on addr.
+// A nil tlsConfig serves plaintext gRPC, which is only allowed on a loopback
+// address; any other address needs mutual TLS (tlsConfig.CAFile).
+func NewHeadlessServer(configFile string, addr string, tlsConfig *api.TLSConfig) (*HeadlessServer, error) {
+	if err := apiserver.CheckListenAddress(addr, tlsConfig); err != nil {
+		return nil, err
+	}
+
+	config, err := loadConfig(configFile, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if _, err := content.NewLoader(config.Game.ContentPack).Load(); err != nil {
+		return nil, fmt.Errorf("failed to load content pack: %w", err)
+	}
+
+	db, err := database.NewDB(config.Database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if err := db.RunMigrations(context.Background()); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	systemRepo := database.NewSystemRepository(db)
+	marketRepo := database.NewMarketRepository(db)
+
+	gameServer, err := apiserver.NewGameServer(&apiserver.Config{
+		DB:         db,
+		PlayerRepo: database.NewPlayerRepository(db),
+		SystemRepo: systemRepo,
+		ShipRepo:   database.NewShipRepository(db),
+		MarketRepo: marketRepo,
+		SSHKeyRepo: database.NewSSHKeyRepository(db),
+		MailRepo:   database.NewMailRepository(db),
+		MissionMgr: missions.NewManager(),
+		QuestMgr:   quests.NewManager(),
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create game server: %w", err)
+	}
+
+	grpcServer, err := apiserver.NewGRPCServer(gameServer, tlsConfig)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &HeadlessServer{
+		config: config,
+		addr:   addr,
+		db:     db,
+		economyManager: economy.NewManager(marketRepo, systemRepo,
+			time.Duration(config.Game.MarketUpdateInterval)*time.Second),
+		gameServer: gameServer,
+		grpcServer: grpcServer,
+	}, nil
+}
+
+// Start serves gRPC until the context is cancelled, then shuts down
+func (h *HeadlessServer) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", h.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", h.addr, err)
+	}
+
+	h.economyManager.Start()
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- h.grpcServer.Serve(listener)
+	}()
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+		log.Info("Context cancelled, shutting down headless server...")
+	case serveErr = <-errChan:
+		log.Error("gRPC server stopped unexpectedly: %v", serveErr)
+	}
+
+	// End player update streams so the graceful stop does not wait on them
+	h.gameServer.Updates().Close()
+	h.grpcServer.Stop()
+	h.economyManager.Stop()
+
+	if err := h.db.Close(); err != nil {
+		log.Warn("Error closing database: %v", err)
+	}
+
+	log.Info("Headless server shutdown complete")
+	if serveErr != nil && !errors.Is(serveErr, net.ErrClosed) {
+		return serveErr
+	}
+	return nil
+}
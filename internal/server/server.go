@@ -1,7 +1,7 @@
 // File: internal/server/server.go
 // Project: Terminal Velocity
 // Description: SSH server implementation with anonymous login and application-layer authentication
-// Version: 2.2.0
+// Version: 2.13.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -52,22 +52,39 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"time"
 
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/admin"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/api"
+	apiserver "github.com/JoshuaAFerguson/terminal-velocity/internal/api/server"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/arena"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/capture"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/content"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/economy"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/factions"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/fleet"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/game/trading"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/friends"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/mail"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/manufacturing"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/marketplace"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/metrics"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/mining"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/missions"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/notifications"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/quests"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/ratelimit"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/security"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/session"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/tui"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/world"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/ssh"
@@ -104,22 +121,26 @@ var log = logger.WithComponent("server")
 // used from a single goroutine. However, it spawns goroutines for each connection,
 // and all shared resources (database, managers, metrics) have internal synchronization.
 type Server struct {
-	config        *Config
-	port          int
-	sshConfig     *ssh.ServerConfig
-	listener      net.Listener
-	sessions      map[string]*PlayerSession
-	db            *database.DB
-	playerRepo    *database.PlayerRepository
-	systemRepo    *database.SystemRepository
-	sshKeyRepo    *database.SSHKeyRepository
-	shipRepo      *database.ShipRepository
-	marketRepo    *database.MarketRepository
-	mailRepo      *database.MailRepository
-	socialRepo    *database.SocialRepository
-	itemRepo      *database.ItemRepository
-	metricsServer *metrics.Server
-	rateLimiter   *ratelimit.Limiter
+	config          *Config
+	port            int
+	sshConfig       *ssh.ServerConfig
+	listener        net.Listener
+	sessions        map[string]*PlayerSession
+	db              *database.DB
+	playerRepo      *database.PlayerRepository
+	systemRepo      *database.SystemRepository
+	sshKeyRepo      *database.SSHKeyRepository
+	shipRepo        *database.ShipRepository
+	marketRepo      *database.MarketRepository
+	mailRepo        *database.MailRepository
+	socialRepo      *database.SocialRepository
+	itemRepo        *database.ItemRepository
+	factionRepo     *database.FactionRepository
+	marketplaceRepo *database.MarketplaceRepository
+	adminRepo       *database.AdminRepository
+	securityRepo    *database.SecurityRepository
+	metricsServer   *metrics.Server
+	rateLimiter     *ratelimit.Limiter
 
 	// Managers
 	fleetManager         *fleet.Manager
@@ -127,6 +148,28 @@ type Server struct {
 	notificationsManager *notifications.Manager
 	friendsManager       *friends.Manager
 	marketplaceManager   *marketplace.Manager
+	economyManager       *economy.Manager
+	adminManager         *admin.Manager
+	securityManager      *security.Manager // Honeypots, login anomalies and history
+	sessionManager       *session.Manager  // Autosave and reconnect-to-resume
+	manufacturingManager *manufacturing.Manager // Crafting, research and player stations
+	arenaManager         *arena.Manager         // Ranked arena matches, seasons and tournaments
+	captureManager       *capture.Manager       // Boarding actions and captured ships
+	miningManager        *mining.Manager        // Resource fields and mining operations
+
+	// Game content (hot-reloaded from the admin panel)
+	contentLoader *content.Loader
+
+	// Services
+	tradingService *trading.Service
+	twoFactor      *security.TwoFactorManager
+	apiClient      api.Client // In-process game API (exec commands)
+
+	// Shared world state (chat, presence, factions, trade, PvP, territory, news)
+	worldHub *world.Hub
+
+	// Real-time player updates (credits/cargo changes from other sessions)
+	updateBus *apiserver.UpdateBus
 }
 
 // Config holds server configuration loaded from YAML file or defaults.
@@ -146,6 +189,11 @@ type Server struct {
 //     password: "password"
 //   metrics_enabled: true
 //   rate_limit_enabled: true
+//   game:
+//     market_update_interval: 300
+//   session:
+//     resume_grace: 300
+//     duplicate_login: "takeover"
 //
 // Fields are merged: file config overrides defaults, command-line flags override both.
 //
@@ -176,6 +224,25 @@ type Config struct {
 	AllowRegistration  bool // Allow new user registration
 	RequireEmail       bool // Require email for new accounts
 	RequireEmailVerify bool // Require email verification (future)
+
+	// Game simulation settings
+	Game GameConfig
+
+	// Session persistence settings
+	Session SessionConfig
+}
+
+// GameConfig holds game simulation settings (the "game" section of the config file)
+type GameConfig struct {
+	MarketUpdateInterval int    `yaml:"market_update_interval"` // Seconds between economy ticks
+	ContentPack          string `yaml:"content_pack"`           // Content pack file (.yaml/.json); empty for the built-in pack
+}
+
+// SessionConfig holds session persistence settings (the "session" section of the config file)
+type SessionConfig struct {
+	AutosaveInterval int    `yaml:"autosave_interval"` // Seconds between saves of each player's session state
+	ResumeGrace      int    `yaml:"resume_grace"`      // Seconds after a disconnect a reconnect resumes the session
+	DuplicateLogin   string `yaml:"duplicate_login"`   // "takeover" (end the old session) or "refuse" (reject the new login)
 }
 
 // loadConfig loads configuration from YAML file if it exists, otherwise uses defaults.
@@ -230,6 +297,18 @@ func loadConfig(configFile string, port int) (*Config, error) {
 		AllowRegistration:  true,
 		RequireEmail:       true,
 		RequireEmailVerify: false,
+
+		// Default game settings
+		Game: GameConfig{
+			MarketUpdateInterval: 300,
+		},
+
+		// Default session settings
+		Session: SessionConfig{
+			AutosaveInterval: 30,
+			ResumeGrace:      300,
+			DuplicateLogin:   string(session.DuplicateTakeover),
+		},
 	}
 
 	// If no config file specified or file doesn't exist, use defaults
@@ -306,6 +385,28 @@ func loadConfig(configFile string, port int) (*Config, error) {
 	config.RequireEmail = fileConfig.RequireEmail
 	config.RequireEmailVerify = fileConfig.RequireEmailVerify
 
+	// Merge game settings
+	if fileConfig.Game.MarketUpdateInterval > 0 {
+		config.Game.MarketUpdateInterval = fileConfig.Game.MarketUpdateInterval
+	}
+	if fileConfig.Game.ContentPack != "" {
+		config.Game.ContentPack = fileConfig.Game.ContentPack
+	}
+
+	// Merge session settings
+	if fileConfig.Session.AutosaveInterval > 0 {
+		config.Session.AutosaveInterval = fileConfig.Session.AutosaveInterval
+	}
+	if fileConfig.Session.ResumeGrace > 0 {
+		config.Session.ResumeGrace = fileConfig.Session.ResumeGrace
+	}
+	if fileConfig.Session.DuplicateLogin != "" {
+		if _, err := session.ParseDuplicatePolicy(fileConfig.Session.DuplicateLogin); err != nil {
+			return nil, fmt.Errorf("invalid session.duplicate_login in %s: %w", configFile, err)
+		}
+		config.Session.DuplicateLogin = fileConfig.Session.DuplicateLogin
+	}
+
 	log.Info("Loaded configuration from %s", configFile)
 	return config, nil
 }
@@ -358,6 +459,12 @@ func NewServer(configFile string, port int) (*Server, error) {
 		sessions: make(map[string]*PlayerSession),
 	}
 
+	// Load game content before anything looks up ships or commodities
+	srv.contentLoader = content.NewLoader(config.Game.ContentPack)
+	if _, err := srv.contentLoader.Load(); err != nil {
+		return nil, fmt.Errorf("failed to load content pack: %w", err)
+	}
+
 	// Initialize database
 	log.Debug("Initializing database connection")
 	if err := srv.initDatabase(); err != nil {
@@ -394,6 +501,7 @@ func NewServer(configFile string, port int) (*Server, error) {
 	if config.RateLimitEnabled {
 		log.Debug("Initializing rate limiter")
 		srv.rateLimiter = ratelimit.NewLimiter(config.RateLimit)
+		srv.securityManager.SetBanCallback(srv.rateLimiter.BanIP)
 		log.Info("Rate limiter enabled: maxConnPerIP=%d, maxAuthAttempts=%d, autoban=%d failures",
 			config.RateLimit.MaxConnectionsPerIP, config.RateLimit.MaxAuthAttempts, config.RateLimit.AutobanThreshold)
 	}
@@ -402,13 +510,29 @@ func NewServer(configFile string, port int) (*Server, error) {
 	return srv, nil
 }
 
+// notifyEscortLost tells a player that one of their escorts was destroyed
+// in battle or deserted
+func (s *Server) notifyEscortLost(playerID uuid.UUID, escort *fleet.Escort) {
+	title, message := "Escort Destroyed", fmt.Sprintf("Your escort %s was destroyed in battle.", escort.Pilot)
+	if escort.Status == "deserted" {
+		title, message = "Escort Deserted", fmt.Sprintf("Your escort %s has deserted your fleet.", escort.Pilot)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.notificationsManager.NotifySystemMessage(ctx, playerID, title, message, 7*24*time.Hour); err != nil {
+		log.Warn("Failed to notify %s of lost escort: %v", playerID, err)
+	}
+}
+
 // initDatabase initializes the database connection pool and all data access components.
 //
 // Initialization Steps:
 //   1. Connect to PostgreSQL using pgx connection pool
-//   2. Create repository instances (PlayerRepository, SystemRepository, etc.)
-//   3. Create manager instances (FleetManager, MailManager, etc.)
-//   4. Start background workers for managers
+//   2. Apply pending schema migrations (database.RunMigrations)
+//   3. Create repository instances (PlayerRepository, SystemRepository, etc.)
+//   4. Create manager instances (FleetManager, MailManager, etc.)
+//   5. Start background workers for managers
 //
 // Repositories (Data Access Layer):
 //   - PlayerRepository: Player accounts, authentication, online status
@@ -419,21 +543,41 @@ func NewServer(configFile string, port int) (*Server, error) {
 //   - MailRepository: Player mail messages
 //   - SocialRepository: Friends, notifications, social features
 //   - ItemRepository: Items and equipment
+//   - FactionRepository: Player factions, membership and treasury
+//   - MarketplaceRepository: Auctions, contracts, bounties and their escrow
 //
 // Managers (Business Logic):
-//   - FleetManager: Fleet operations and coordination
+//   - FleetManager: Fleet operations and coordination (escorts lost in
+//     battle or to desertion are reported as notifications)
 //   - MailManager: Mail delivery and notifications
 //   - NotificationsManager: Real-time notifications (starts background worker)
 //   - FriendsManager: Friend relationship management
-//   - MarketplaceManager: Player marketplace (starts background worker)
+//   - MarketplaceManager: Player marketplace (loads and settles stored
+//     listings, then starts background worker)
+//   - WorldHub: Shared chat, presence, factions, trade, PvP, territory and news
+//     (starts background worker; one instance shared by every session)
+//   - WorldHub factions are loaded from and written through to the database
+//   - WorldHub PvP battle rooms load both players' ships from the repositories
+//   - CaptureManager: Boarding and ship capture (player ships may only be
+//     boarded under the PvP battle rules)
+//   - ManufacturingManager: Crafting jobs, research and player stations
+//     (loads stored state, then starts background worker that completes
+//     jobs whether or not their player is online)
+//   - ArenaManager: Ranked arena (loads rankings, history, tournaments and
+//     season, then starts workers that fight matches and end seasons)
+//   - MiningManager: Mining and salvage (loads how far resource fields have
+//     been mined; fields regrow over time)
+//   - UpdateBus: Per-player credit/cargo updates fed by mail, marketplace,
+//     faction treasury transfers, manufacturing, mining and the arena
+//   - API client: In-process game API used by SSH exec commands
 //
 // Connection Pool:
 // Uses pgx/v5 connection pooling with configuration from database.Config.
 // Pool size, timeouts, and other settings are managed by the database package.
 //
 // Error Handling:
-// Database connection failures are fatal errors that prevent server startup.
-// All cleanup is handled by the caller (NewServer).
+// Database connection and migration failures are fatal errors that prevent
+// server startup. All cleanup is handled by the caller (NewServer).
 func (s *Server) initDatabase() error {
 	log.Debug("Connecting to database at %s:%d", s.config.Database.Host, s.config.Database.Port)
 
@@ -444,6 +588,12 @@ func (s *Server) initDatabase() error {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Apply pending schema migrations (serialized across servers)
+	if err := s.db.RunMigrations(context.Background()); err != nil {
+		log.Error("Schema migration failed: %v", err)
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
 	// Initialize repositories
 	log.Debug("Initializing database repositories")
 	s.playerRepo = database.NewPlayerRepository(s.db)
@@ -454,24 +604,146 @@ func (s *Server) initDatabase() error {
 	s.mailRepo = database.NewMailRepository(s.db)
 	s.socialRepo = database.NewSocialRepository(s.db)
 	s.itemRepo = database.NewItemRepository(s.db)
+	s.factionRepo = database.NewFactionRepository(s.db)
+	s.marketplaceRepo = database.NewMarketplaceRepository(s.db)
+	s.adminRepo = database.NewAdminRepository(s.db)
+	s.securityRepo = database.NewSecurityRepository(s.db)
+	sessionRepo := database.NewSessionRepository(s.db)
 
 	// Initialize managers
 	log.Debug("Initializing game managers")
 	s.fleetManager = fleet.NewManager(s.playerRepo, s.shipRepo)
 	s.mailManager = mail.NewManager(s.socialRepo)
 	s.notificationsManager = notifications.NewManager(s.socialRepo)
+	s.fleetManager.SetEscortDestroyedCallback(s.notifyEscortLost)
 	s.friendsManager = friends.NewManager(s.socialRepo)
-	s.marketplaceManager = marketplace.NewManager(s.playerRepo, s.shipRepo)
+	s.marketplaceManager = marketplace.NewManager(s.marketplaceRepo)
+	if err := s.marketplaceManager.Load(context.Background()); err != nil {
+		log.Error("Failed to load marketplace: %v", err)
+		return err
+	}
+	s.adminManager = admin.NewManager(s.playerRepo, s.adminRepo)
+	if err := s.adminManager.Load(context.Background()); err != nil {
+		log.Error("Failed to load bans and mutes: %v", err)
+		return err
+	}
+	s.adminManager.SetContentLoader(s.contentLoader)
+	s.tradingService = trading.NewService(s.db, s.systemRepo)
+	s.twoFactor = security.NewTwoFactorManager("Terminal Velocity")
+	s.securityManager = security.NewManager(nil)
+	s.securityManager.SetStore(s.securityRepo)
+	s.sessionManager = session.NewManager(s.playerRepo, s.shipRepo, sessionRepo)
+	s.sessionManager.SetSaveInterval(s.config.Session.AutosaveInterval)
+	s.sessionManager.SetResumeGrace(s.config.Session.ResumeGrace)
+	duplicatePolicy, _ := session.ParseDuplicatePolicy(s.config.Session.DuplicateLogin)
+	s.sessionManager.SetDuplicatePolicy(duplicatePolicy)
+	s.economyManager = economy.NewManager(s.marketRepo, s.systemRepo,
+		time.Duration(s.config.Game.MarketUpdateInterval)*time.Second)
+	s.manufacturingManager = manufacturing.NewManager(s.playerRepo, s.shipRepo, s.itemRepo)
+	s.manufacturingManager.SetStore(database.NewManufacturingRepository(s.db))
+	if err := s.manufacturingManager.Load(context.Background()); err != nil {
+		log.Error("Failed to load manufacturing: %v", err)
+		return err
+	}
+	s.arenaManager = arena.NewManager(s.playerRepo, s.shipRepo)
+	s.arenaManager.SetStore(database.NewArenaRepository(s.db))
+	s.arenaManager.SetMailer(s.mailManager)
+	if err := s.arenaManager.Load(context.Background()); err != nil {
+		log.Error("Failed to load arena: %v", err)
+		return err
+	}
+	s.miningManager = mining.NewManager(s.shipRepo, s.playerRepo)
+	s.miningManager.SetStore(database.NewMiningRepository(s.db))
+	if err := s.miningManager.Load(context.Background()); err != nil {
+		log.Error("Failed to load mining: %v", err)
+		return err
+	}
+
+	factionManager := factions.NewManagerWithRepository(s.factionRepo)
+	if err := factionManager.Load(context.Background()); err != nil {
+		log.Error("Failed to load factions: %v", err)
+		return err
+	}
+	s.worldHub = world.NewHubWithFactions(factionManager)
+	s.worldHub.Chat.SetMuteChecker(s.adminManager.IsMuted)
+	s.worldHub.Territory.SetStore(database.NewTerritoryRepository(s.db))
+	s.worldHub.Territory.SetNotifier(s.notificationsManager)
+	if err := s.worldHub.Territory.Load(context.Background()); err != nil {
+		log.Error("Failed to load territories: %v", err)
+		return err
+	}
+	s.worldHub.Diplomacy.SetStore(database.NewDiplomacyRepository(s.db))
+	if err := s.worldHub.Diplomacy.Load(context.Background()); err != nil {
+		log.Error("Failed to load diplomacy: %v", err)
+		return err
+	}
+	s.worldHub.PvP.SetRepositories(s.playerRepo, s.shipRepo)
+	s.captureManager = capture.NewManager(s.shipRepo, s.playerRepo)
+	s.captureManager.SetPvPPolicy(s.worldHub.PvP.BoardingAllowed)
+	if err := s.loadSafeZones(context.Background()); err != nil {
+		log.Error("Failed to load safe zones: %v", err)
+		return err
+	}
+	s.tradingService.SetMarketFeePolicy(s.worldHub.MarketFeeRate)
+	s.updateBus = apiserver.NewUpdateBus()
+	s.wirePlayerUpdates()
+
+	gameServer, err := apiserver.NewGameServer(&apiserver.Config{
+		DB:         s.db,
+		PlayerRepo: s.playerRepo,
+		SystemRepo: s.systemRepo,
+		ShipRepo:   s.shipRepo,
+		MarketRepo: s.marketRepo,
+		SSHKeyRepo: s.sshKeyRepo,
+		MailRepo:   s.mailRepo,
+		MissionMgr: missions.NewManager(),
+		QuestMgr:   quests.NewManager(),
+		Updates:    s.updateBus,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create game API: %w", err)
+	}
+	s.apiClient, err = api.NewClient(&api.ClientConfig{
+		Mode:            api.ClientModeInProcess,
+		InProcessServer: gameServer,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create game API client: %w", err)
+	}
 
 	// Start background workers for managers
 	s.fleetManager.Start()
 	s.notificationsManager.Start()
 	s.marketplaceManager.Start()
+	s.economyManager.Start()
+	s.manufacturingManager.Start()
+	s.arenaManager.Start()
+	s.worldHub.Start()
 
 	log.Info("Database connected successfully")
 	return nil
 }
 
+// loadSafeZones tells the world hub which systems forbid faction war
+// combat. The universe does not change while the server runs, so this is
+// read once at startup.
+func (s *Server) loadSafeZones(ctx context.Context) error {
+	systems, err := s.systemRepo.ListSystems(ctx)
+	if err != nil {
+		return err
+	}
+
+	var safeZones []uuid.UUID
+	for _, system := range systems {
+		if system.IsSafeZone() {
+			safeZones = append(safeZones, system.ID)
+		}
+	}
+	s.worldHub.SetSafeZones(safeZones)
+	log.Info("Safe zones: %d of %d systems", len(safeZones), len(systems))
+	return nil
+}
+
 // Start starts the SSH server and begins accepting connections.
 //
 // Execution Flow:
@@ -587,13 +859,13 @@ func (s *Server) acceptConnections(ctx context.Context) {
 // If rate limit exceeded, connection is rejected with error message.
 //
 // SSH Handshake (Security Layer 2):
-// Uses anonymous authentication (NoClientAuth=true), meaning:
+// Uses anonymous authentication (see initSSHConfig), meaning:
 //   - All SSH connections are accepted at protocol level
 //   - No SSH username/password validation
-//   - No SSH public key validation at this layer
+//   - Registered SSH public keys identify the player (used by exec commands)
 //   - Server presents host key for client verification
 //
-// Authentication is deferred to application layer (login screen in TUI).
+// Interactive authentication is deferred to application layer (login screen in TUI).
 //
 // Channel Handling:
 // Only "session" channels are accepted. Other channel types (port forwarding,
@@ -654,6 +926,13 @@ func (s *Server) handleConnection(conn net.Conn) {
 	}()
 
 	log.Info("SSH connection established: user=%s, addr=%s", sshConn.User(), sshConn.RemoteAddr())
+
+	// Key logins get a security session that lasts as long as the connection
+	if sshConn.Permissions != nil {
+		if sessionID, err := uuid.Parse(sshConn.Permissions.Extensions["session_id"]); err == nil {
+			defer s.securityManager.OnLogout(sessionID)
+		}
+	}
 	metrics.Global().IncrementActiveConnections()
 	metrics.Global().IncrementLogins()
 	defer metrics.Global().DecrementActiveConnections()
@@ -678,7 +957,7 @@ func (s *Server) handleConnection(conn net.Conn) {
 
 		log.Debug("Accepted session channel for %s", sshConn.User())
 		// Handle this session
-		go s.handleSession(sshConn.User(), sshConn.Permissions, channel, requests)
+		go s.handleSession(sshConn, channel, requests)
 	}
 
 	log.Debug("SSH connection closed for %s", sshConn.User())
@@ -690,48 +969,94 @@ func (s *Server) handleConnection(conn net.Conn) {
 // An SSH session consists of a series of requests from the client:
 //   1. "pty-req" - Request pseudo-terminal allocation (for TUI)
 //   2. "shell" - Request shell execution (where we launch the game)
-//   3. Other requests - Window size changes, signals, etc. (rejected)
+//   3. "exec" - Run a non-interactive command instead of the TUI (see exec.go)
+//   4. "window-change" - Client terminal was resized
+//   5. Other requests - Signals, env, etc. (rejected)
 //
 // Flow:
 //   1. Loop reading SSH requests on channel
-//   2. Accept "pty-req" (needed for BubbleTea TUI)
-//   3. Accept "shell" and launch anonymous session (login screen)
-//   4. Return after shell session ends
+//   2. Accept "pty-req" and record TERM and the initial terminal size
+//   3. Accept "shell" and launch anonymous session (login screen) in a goroutine
+//   4. Keep reading requests so resizes reach the running TUI
+//   5. Return once the channel closes (session ended or client disconnected)
 //
 // Anonymous Session:
 // Since SSH authentication is anonymous, all shells start with the login screen.
 // Players authenticate at application layer (via TUI) and then proceed to game.
 //
 // Parameters:
-//   - username: SSH username (from SSH handshake, unused in anonymous mode)
-//   - perms: SSH permissions (from auth callback, unused in anonymous mode)
+//   - conn: SSH connection (username, remote address and client version;
+//     permissions carry player_id for registered public keys)
 //   - channel: SSH channel for I/O (will be used by BubbleTea)
 //   - requests: Channel of SSH requests (pty-req, shell, etc.)
 //
 // Request Handling:
-//   - "pty-req": Accepted (BubbleTea needs a PTY for TUI rendering)
-//   - "shell": Accepted and starts anonymous session
+//   - "pty-req": Accepted before the shell starts; TERM selects the session's
+//     color profile and the size becomes the TUI's first tea.WindowSizeMsg
+//   - "window-change": Delivered to the TUI as a tea.WindowSizeMsg
+//   - "shell": Accepted once and starts anonymous session
+//   - "exec": Accepted once (instead of "shell"); runs the command as the
+//     player authenticated by public key and reports its exit status
 //   - All others: Rejected
 //
 // Session Lifecycle:
-// Once "shell" is accepted, control transfers to startAnonymousSession which
-// runs the BubbleTea login screen. This function returns when TUI exits.
+// Once "shell" is accepted, startAnonymousSession runs the BubbleTea login
+// screen and closes the channel when the TUI exits, which ends this loop.
 //
 // Thread Safety:
 // Each session runs in its own goroutine. SSH channel provides I/O synchronization.
-func (s *Server) handleSession(username string, perms *ssh.Permissions, channel ssh.Channel, requests <-chan *ssh.Request) {
+func (s *Server) handleSession(conn *ssh.ServerConn, channel ssh.Channel, requests <-chan *ssh.Request) {
 	defer channel.Close()
 
-	// Handle session requests (pty-req, shell, etc.)
+	username := conn.User()
+	perms := conn.Permissions
+
+	term := newSessionTerminal()
+	shellStarted := false
+
+	// Handle session requests (pty-req, shell, window-change, etc.)
 	for req := range requests {
 		switch req.Type {
 		case "pty-req":
+			pty, err := parsePtyRequest(req.Payload)
+			if err != nil || shellStarted {
+				log.Debug("Rejected pty-req from %s: %v", username, err)
+				req.Reply(false, nil)
+				continue
+			}
+			term.term = pty.Term
+			term.resize(pty.Columns, pty.Rows)
+			req.Reply(true, nil)
+		case "window-change":
+			size, err := parseWindowChange(req.Payload)
+			if err != nil {
+				log.Debug("Rejected window-change from %s: %v", username, err)
+				req.Reply(false, nil)
+				continue
+			}
+			term.resize(size.Columns, size.Rows)
 			req.Reply(true, nil)
 		case "shell":
+			if shellStarted {
+				req.Reply(false, nil)
+				continue
+			}
+			shellStarted = true
 			req.Reply(true, nil)
 			// Start anonymous session (login screen)
-			s.startAnonymousSession(channel)
-			return
+			go func() {
+				defer channel.Close()
+				s.startAnonymousSession(conn, channel, term)
+			}()
+		case "exec":
+			var exec execRequestMsg
+			if shellStarted || ssh.Unmarshal(req.Payload, &exec) != nil {
+				req.Reply(false, nil)
+				continue
+			}
+			shellStarted = true
+			req.Reply(true, nil)
+			go s.handleExec(perms, channel, exec.Command)
 		default:
 			req.Reply(false, nil)
 		}
@@ -739,7 +1064,7 @@ func (s *Server) handleSession(username string, perms *ssh.Permissions, channel
 }
 
 // startGameSession starts a game session for a player
-func (s *Server) startGameSession(username string, perms *ssh.Permissions, channel ssh.Channel) {
+func (s *Server) startGameSession(username string, perms *ssh.Permissions, channel ssh.Channel, term *sessionTerminal) {
 	log.Debug("startGameSession called for user=%s", username)
 	ctx := context.Background()
 
@@ -768,7 +1093,7 @@ func (s *Server) startGameSession(username string, perms *ssh.Permissions, chann
 		if err == database.ErrPlayerNotFound && s.config.AllowRegistration {
 			log.Info("Starting registration flow for new user: %s", username)
 			// Start registration flow
-			s.startRegistrationSession(username, channel)
+			s.startRegistrationSession(username, channel, term)
 			return
 		} else if err != nil {
 			log.Error("Error checking for player %s: %v", username, err)
@@ -783,6 +1108,11 @@ func (s *Server) startGameSession(username string, perms *ssh.Permissions, chann
 
 	log.Info("Starting game session for user=%s, playerID=%s", username, playerID)
 
+	// Mark player as online for the length of the session
+	if err := s.playerRepo.SetOnlineStatus(ctx, playerID, true); err != nil {
+		log.Warn("Failed to set online status for %s: %v", username, err)
+	}
+
 	// Initialize TUI model
 	model := tui.NewModel(
 		playerID,
@@ -795,25 +1125,29 @@ func (s *Server) startGameSession(username string, perms *ssh.Permissions, chann
 		s.mailRepo,
 		s.socialRepo,
 		s.itemRepo,
+		s.securityRepo,
 		s.fleetManager,
+		s.captureManager,
+		s.miningManager,
 		s.mailManager,
 		s.notificationsManager,
 		s.friendsManager,
 		s.marketplaceManager,
+		s.adminManager,
+		s.sessionManager,
+		s.tradingService,
+		s.manufacturingManager,
+		s.arenaManager,
+		s.worldHub,
+		s.updateBus,
 	)
 
-	// Create BubbleTea program with SSH channel as input/output
-	p := tea.NewProgram(
-		model,
-		tea.WithInput(channel),
-		tea.WithOutput(channel),
-		tea.WithAltScreen(), // Use alternate screen buffer to prevent artifacts
-	)
-
-	// Run the program
-	if _, err := p.Run(); err != nil {
+	// Run the BubbleTea program with SSH channel as input/output
+	finalModel, err := term.run(model, channel)
+	if err != nil {
 		log.Error("Error running TUI for %s: %v", username, err)
 	}
+	closeSessionModel(finalModel, channel)
 
 	log.Info("Game session ended for user=%s, playerID=%s", username, playerID)
 
@@ -838,57 +1172,63 @@ func (s *Server) startGameSession(username string, perms *ssh.Permissions, chann
 //
 // TUI Integration:
 //   - Input: SSH channel (reads keypresses from client)
-//   - Output: SSH channel (sends terminal output to client)
+//   - Output: SSH channel, downsampled to the client's color profile
 //   - AltScreen: Enabled (uses alternate screen buffer for clean rendering)
+//   - Resize: pty-req and window-change sizes arrive as tea.WindowSizeMsg
 //
 // The login model (NewLoginModel) is a minimal TUI that only handles authentication.
 // After successful login, it's replaced by the full game model.
 //
 // Parameters:
+//   - conn: SSH connection metadata (remote address and client version for
+//     login security checks)
 //   - channel: SSH channel for I/O with the client
+//   - term: Terminal negotiated by handleSession (TERM and size updates)
 //
 // Blocking Behavior:
 // This function blocks until the TUI exits (user quits or disconnects).
 //
 // Error Handling:
 // TUI errors are logged but not returned. The session simply ends.
-func (s *Server) startAnonymousSession(channel ssh.Channel) {
+func (s *Server) startAnonymousSession(conn ssh.ConnMetadata, channel ssh.Channel, term *sessionTerminal) {
 	log.Debug("startAnonymousSession called")
 
 	// Initialize TUI model with login screen
-	model := tui.NewLoginModel(s.playerRepo, s.systemRepo, s.sshKeyRepo, s.shipRepo, s.marketRepo, s.mailRepo, s.socialRepo)
-
-	// Create BubbleTea program with SSH channel as input/output
-	p := tea.NewProgram(
-		model,
-		tea.WithInput(channel),
-		tea.WithOutput(channel),
-		tea.WithAltScreen(), // Use alternate screen buffer to prevent artifacts
-	)
+	model := tui.NewLoginModel(s.playerRepo, s.systemRepo, s.sshKeyRepo, s.shipRepo, s.marketRepo, s.mailRepo, s.socialRepo, s.securityRepo, s.securityManager, remoteIP(conn), string(conn.ClientVersion()), s.sessionManager, s.adminManager, s.fleetManager, s.captureManager, s.miningManager, s.tradingService, s.manufacturingManager, s.arenaManager, s.worldHub, s.updateBus)
 
-	// Run the program
-	if _, err := p.Run(); err != nil {
+	// Run the BubbleTea program with SSH channel as input/output
+	finalModel, err := term.run(model, channel)
+	if err != nil {
 		log.Info("Error running login TUI: %v", err)
 	}
+	closeSessionModel(finalModel, channel)
 
 	log.Info("Anonymous session ended")
 }
 
+// closeSessionModel releases a finished session's shared-world resources
+// (world hub subscription and online presence), saves its game session for
+// resuming and shows the player why the session ended, if the model says.
+func closeSessionModel(finalModel tea.Model, channel ssh.Channel) {
+	m, ok := finalModel.(tui.Model)
+	if !ok {
+		return
+	}
+	m.Close()
+	if msg := m.ExitMessage(); msg != "" {
+		if _, err := channel.Write([]byte(msg + "\r\n")); err != nil {
+			log.Debug("Failed to write exit message: %v", err)
+		}
+	}
+}
+
 // startRegistrationSession starts a registration session for a new player
-func (s *Server) startRegistrationSession(username string, channel ssh.Channel) {
+func (s *Server) startRegistrationSession(username string, channel ssh.Channel, term *sessionTerminal) {
 	// Initialize TUI model for registration
 	model := tui.NewRegistrationModel(username, s.config.RequireEmail, nil, s.playerRepo, s.systemRepo, s.sshKeyRepo, s.shipRepo, s.marketRepo)
 
-	// Create BubbleTea program with SSH channel as input/output
-	p := tea.NewProgram(
-		model,
-		tea.WithInput(channel),
-		tea.WithOutput(channel),
-		tea.WithAltScreen(), // Use alternate screen buffer to prevent artifacts
-	)
-
-	// Run the program
-	if _, err := p.Run(); err != nil {
+	// Run the BubbleTea program with SSH channel as input/output
+	if _, err := term.run(model, channel); err != nil {
 		log.Info("Error running registration TUI for %s: %v", username, err)
 	}
 
@@ -899,9 +1239,11 @@ func (s *Server) startRegistrationSession(username string, channel ssh.Channel)
 //
 // SSH Configuration Strategy:
 // This server uses an unconventional SSH setup called "anonymous authentication":
-//   - NoClientAuth is enabled (skips SSH-level authentication)
-//   - No password callback (would validate SSH passwords)
-//   - No public key callback (would validate SSH keys)
+//   - The "none" method is refused for every username alike, so clients
+//     offer their SSH keys and the server reveals nothing about accounts
+//   - Registered SSH keys identify the player (exec commands need this)
+//   - Password and keyboard-interactive are accepted without checking, so
+//     clients without a matching key still get in anonymously
 //   - Host key is loaded/generated for server identity
 //
 // Why Anonymous SSH?
@@ -912,7 +1254,7 @@ func (s *Server) startRegistrationSession(username string, channel ssh.Channel)
 //   - Application-specific auth logic (reputation checks, bans, etc.)
 //
 // Instead, we:
-//   1. Accept all SSH connections (NoClientAuth=true)
+//   1. Accept all SSH connections (any password or keyboard-interactive)
 //   2. Present login screen via TUI
 //   3. Handle authentication at application layer
 //   4. Provide rich feedback and interactivity
@@ -938,8 +1280,17 @@ func (s *Server) initSSHConfig() error {
 	s.sshConfig = &ssh.ServerConfig{}
 
 	// Anonymous authentication - accept all connections
-	// Authentication is handled at the application layer (login screen)
-	s.sshConfig.NoClientAuth = true
+	// Authentication is handled at the application layer (login screen).
+	// NoClientAuth stays off: "none" is refused for every username, so the
+	// client goes on to offer its keys and the refusal says nothing about
+	// which accounts exist or have keys.
+
+	// Registered public keys identify the player for exec commands. A
+	// client without a matching key falls back to an anonymous password
+	// or keyboard-interactive login.
+	s.sshConfig.PublicKeyCallback = s.handlePublicKeyAuth
+	s.sshConfig.PasswordCallback = s.handleAnonymousPassword
+	s.sshConfig.KeyboardInteractiveCallback = s.handleAnonymousAuth
 
 	// Load or generate persistent SSH host key
 	log.Debug("Loading SSH host key from: %s", s.config.HostKeyPath)
@@ -949,7 +1300,7 @@ func (s *Server) initSSHConfig() error {
 	}
 
 	s.sshConfig.AddHostKey(privateKey)
-	log.Info("SSH authentication: anonymous (authentication via login screen)")
+	log.Info("SSH authentication: anonymous (authentication via login screen), registered keys for exec commands")
 	log.Info("SSH host key fingerprint: %s", ssh.FingerprintSHA256(privateKey.PublicKey()))
 	return nil
 }
@@ -970,6 +1321,16 @@ func (s *Server) handlePasswordAuth(conn ssh.ConnMetadata, password []byte) (*ss
 		}
 	}
 
+	// Security checks (honeypots, anomalies) before credentials
+	var knownID uuid.UUID
+	if existing, err := s.playerRepo.GetByUsername(ctx, username); err == nil {
+		knownID = existing.ID
+	}
+	stepUp, err := s.checkLogin(conn, username, knownID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid username or password")
+	}
+
 	// Try to authenticate
 	player, err := s.playerRepo.Authenticate(ctx, username, string(password))
 	if err != nil {
@@ -977,6 +1338,7 @@ func (s *Server) handlePasswordAuth(conn ssh.ConnMetadata, password []byte) (*ss
 		if s.rateLimiter != nil {
 			s.rateLimiter.RecordAuthFailure(remoteAddr, username)
 		}
+		s.securityManager.OnLoginFailure(knownID, username, remoteIP(conn), string(conn.ClientVersion()), "invalid_password")
 
 		if err == database.ErrInvalidCredentials {
 			// Check if user exists
@@ -1008,7 +1370,7 @@ func (s *Server) handlePasswordAuth(conn ssh.ConnMetadata, password []byte) (*ss
 		s.rateLimiter.RecordAuthSuccess(remoteAddr)
 	}
 
-	return s.onSuccessfulAuth(ctx, player)
+	return s.completeAuth(ctx, conn, player, stepUp, false)
 }
 
 // handlePublicKeyAuth handles SSH public key authentication
@@ -1033,8 +1395,10 @@ func (s *Server) handlePublicKeyAuth(conn ssh.ConnMetadata, key ssh.PublicKey) (
 	// Try to find the player by public key
 	playerID, err := s.sshKeyRepo.GetPlayerIDByPublicKey(ctx, keyData)
 	if err != nil {
-		// Record auth failure for rate limiting
-		if s.rateLimiter != nil {
+		// Unregistered keys are routine (clients offer every key they have
+		// before falling back to anonymous login), so only other errors
+		// count toward the rate limit
+		if s.rateLimiter != nil && err != database.ErrSSHKeyNotFound {
 			s.rateLimiter.RecordAuthFailure(remoteAddr, username)
 		}
 
@@ -1077,9 +1441,16 @@ func (s *Server) handlePublicKeyAuth(conn ssh.ConnMetadata, key ssh.PublicKey) (
 	// Verify username matches
 	if player.Username != username {
 		log.Info("SSH key login - username mismatch: %s vs %s", username, player.Username)
+		s.securityManager.OnLoginFailure(player.ID, player.Username, remoteIP(conn), string(conn.ClientVersion()), "username_mismatch")
 		return nil, fmt.Errorf("username does not match public key")
 	}
 
+	// Security checks (login anomalies) for the identified player
+	stepUp, err := s.checkLogin(conn, username, player.ID)
+	if err != nil {
+		return nil, fmt.Errorf("authentication error")
+	}
+
 	// Update last used timestamp for the key
 	go func() {
 		fingerprint := ssh.FingerprintSHA256(key)
@@ -1088,17 +1459,176 @@ func (s *Server) handlePublicKeyAuth(conn ssh.ConnMetadata, key ssh.PublicKey) (
 		}
 	}()
 
+	return s.completeAuth(ctx, conn, player, stepUp, true)
+}
+
+// checkLogin runs the security manager's pre-authentication checks. It
+// returns stepUp for high-risk logins that must pass a second factor, and
+// an error if the login must be rejected (honeypot accounts).
+func (s *Server) checkLogin(conn ssh.ConnMetadata, username string, playerID uuid.UUID) (stepUp bool, err error) {
+	err = s.securityManager.ValidateLogin(username, "", remoteIP(conn), string(conn.ClientVersion()), playerID)
+	if errors.Is(err, security.ErrChallengeRequired) {
+		log.Warn("Step-up verification required for %s from %s", username, conn.RemoteAddr())
+		return true, nil
+	}
+	if err != nil {
+		log.Warn("Login rejected by security checks: %s from %s: %v", username, conn.RemoteAddr(), err)
+		return false, err
+	}
+	return false, nil
+}
+
+// completeAuth finishes a login whose first factor passed. Players with
+// two-factor authentication answer a TOTP challenge first. High-risk key
+// logins (stepUp) from players without 2FA must enter their account
+// password; a password login has already proven it.
+func (s *Server) completeAuth(ctx context.Context, conn ssh.ConnMetadata, player *models.Player, stepUp, keyLogin bool) (*ssh.Permissions, error) {
+	twoFactor, err := s.securityRepo.GetTwoFactor(ctx, player.ID)
+	if err != nil && err != database.ErrTwoFactorNotFound {
+		log.Error("Failed to load 2FA config for %s: %v", player.Username, err)
+		return nil, fmt.Errorf("authentication error")
+	}
+	if twoFactor != nil && twoFactor.Enabled {
+		return nil, &ssh.PartialSuccessError{
+			Next: ssh.ServerAuthCallbacks{
+				KeyboardInteractiveCallback: s.twoFactorChallenge(player, twoFactor),
+			},
+		}
+	}
+
+	if stepUp && keyLogin && player.PasswordHash != "" {
+		return nil, &ssh.PartialSuccessError{
+			Next: ssh.ServerAuthCallbacks{
+				KeyboardInteractiveCallback: s.passwordChallenge(player),
+			},
+		}
+	}
+
 	// Successful authentication
-	return s.onSuccessfulAuth(ctx, player)
+	return s.onSuccessfulAuth(ctx, conn, player)
 }
 
-// onSuccessfulAuth handles post-authentication tasks
-func (s *Server) onSuccessfulAuth(ctx context.Context, player *models.Player) (*ssh.Permissions, error) {
-	// Update last login and set online status
-	go func() {
+// twoFactorChallenge returns the keyboard-interactive step that follows a
+// successful key login for players with 2FA enabled. The player gets
+// three tries to enter a TOTP or backup code.
+func (s *Server) twoFactorChallenge(player *models.Player, config *security.TwoFactorConfig) func(ssh.ConnMetadata, ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+	return func(conn ssh.ConnMetadata, client ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
 		ctx := context.Background()
-		s.playerRepo.UpdateLastLogin(ctx, player.ID)
-		s.playerRepo.SetOnlineStatus(ctx, player.ID, true)
+		remoteAddr := conn.RemoteAddr()
+
+		for attempt := 0; attempt < 3; attempt++ {
+			answers, err := client(conn.User(), "Two-factor authentication", []string{"Authentication code: "}, []bool{false})
+			if err != nil {
+				return nil, err
+			}
+			if len(answers) != 1 {
+				continue
+			}
+
+			ok, usedBackup := s.twoFactor.Authenticate(config, answers[0])
+			if !ok {
+				log.Info("Failed 2FA code for %s from %s", player.Username, remoteAddr)
+				if s.rateLimiter != nil {
+					s.rateLimiter.RecordAuthFailure(remoteAddr, player.Username)
+				}
+				s.securityManager.OnLoginFailure(player.ID, player.Username, remoteIP(conn), string(conn.ClientVersion()), "invalid_2fa_code")
+				continue
+			}
+
+			if err := s.securityRepo.RecordTwoFactorUse(ctx, config); err != nil {
+				log.Error("Failed to record 2FA use for %s: %v", player.Username, err)
+				return nil, fmt.Errorf("authentication error")
+			}
+			if usedBackup {
+				log.Info("Backup code used by %s (%d remaining)", player.Username, len(config.BackupCodes))
+			}
+			return s.onSuccessfulAuth(ctx, conn, player)
+		}
+
+		return nil, fmt.Errorf("invalid two-factor code")
+	}
+}
+
+// passwordChallenge returns the keyboard-interactive step that asks for
+// the account password after a high-risk key login by a player without
+// 2FA. The player gets three tries.
+func (s *Server) passwordChallenge(player *models.Player) func(ssh.ConnMetadata, ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+	return func(conn ssh.ConnMetadata, client ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+		ctx := context.Background()
+		remoteAddr := conn.RemoteAddr()
+
+		for attempt := 0; attempt < 3; attempt++ {
+			answers, err := client(conn.User(), "Unusual login - please confirm your password", []string{"Password: "}, []bool{false})
+			if err != nil {
+				return nil, err
+			}
+			if len(answers) != 1 {
+				continue
+			}
+
+			if _, err := s.playerRepo.Authenticate(ctx, player.Username, answers[0]); err != nil {
+				log.Info("Failed step-up password for %s from %s", player.Username, remoteAddr)
+				if s.rateLimiter != nil {
+					s.rateLimiter.RecordAuthFailure(remoteAddr, player.Username)
+				}
+				s.securityManager.OnLoginFailure(player.ID, player.Username, remoteIP(conn), string(conn.ClientVersion()), "invalid_password")
+				continue
+			}
+
+			return s.onSuccessfulAuth(ctx, conn, player)
+		}
+
+		return nil, fmt.Errorf("invalid password")
+	}
+}
+
+// handleAnonymousPassword accepts password authentication without checking
+// the password, for clients that cannot log in any other way after "none"
+// is refused. The login screen authenticates the player.
+func (s *Server) handleAnonymousPassword(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+	log.Debug("Anonymous password login: %s from %s", conn.User(), conn.RemoteAddr())
+	return &ssh.Permissions{}, nil
+}
+
+// handleAnonymousAuth accepts keyboard-interactive authentication without
+// asking anything. The connection has no player until the login screen
+// authenticates one.
+func (s *Server) handleAnonymousAuth(conn ssh.ConnMetadata, client ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+	log.Debug("Anonymous login: %s from %s", conn.User(), conn.RemoteAddr())
+	return &ssh.Permissions{}, nil
+}
+
+// onSuccessfulAuth handles post-authentication tasks.
+// The security session it opens is closed when the connection ends
+// (handleConnection).
+func (s *Server) onSuccessfulAuth(ctx context.Context, conn ssh.ConnMetadata, player *models.Player) (*ssh.Permissions, error) {
+	// Banned players are turned away before a session is created. The
+	// banner carries the reason and expiry to the client.
+	ban, err := s.adminManager.CheckBan(ctx, player.ID)
+	if err != nil {
+		log.Error("Failed to check ban for %s: %v", player.Username, err)
+		return nil, fmt.Errorf("ban check failed")
+	}
+	if ban != nil {
+		log.Warn("Rejected banned player: %s (ID: %s)", player.Username, player.ID)
+		s.securityManager.OnLoginFailure(player.ID, player.Username, remoteIP(conn), string(conn.ClientVersion()), "banned")
+		return nil, &ssh.BannerError{
+			Err:     fmt.Errorf("player %s is banned", player.Username),
+			Message: admin.BanMessage(ban) + "\r\n",
+		}
+	}
+
+	session, err := s.securityManager.OnLoginSuccess(player.ID, player.Username, remoteIP(conn), string(conn.ClientVersion()))
+	if err != nil {
+		log.Warn("Rejected login for %s: %v", player.Username, err)
+		return nil, err
+	}
+
+	// Update last login. Online status belongs to game sessions
+	// (startGameSession) and presence, not to every authenticated
+	// connection: exec commands must not change it.
+	go func() {
+		s.playerRepo.UpdateLastLogin(context.Background(), player.ID)
 	}()
 
 	log.Info("Successful login: %s (ID: %s)", player.Username, player.ID)
@@ -1106,12 +1636,22 @@ func (s *Server) onSuccessfulAuth(ctx context.Context, player *models.Player) (*
 	// Return permissions with player ID
 	return &ssh.Permissions{
 		Extensions: map[string]string{
-			"player_id": player.ID.String(),
-			"username":  player.Username,
+			"player_id":  player.ID.String(),
+			"username":   player.Username,
+			"session_id": session.ID.String(),
 		},
 	}, nil
 }
 
+// remoteIP returns the client's IP address without the port
+func remoteIP(conn ssh.ConnMetadata) string {
+	host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return conn.RemoteAddr().String()
+	}
+	return host
+}
+
 // handleNewUserRegistration handles registration for a new user with password
 func (s *Server) handleNewUserRegistration(ctx context.Context, conn ssh.ConnMetadata, password string) (*ssh.Permissions, error) {
 	username := conn.User()
@@ -1187,6 +1727,38 @@ func (s *Server) shutdown() error {
 		}
 	}
 
+	// Stop economy simulation before the database closes
+	if s.economyManager != nil {
+		s.economyManager.Stop()
+	}
+	if s.manufacturingManager != nil {
+		s.manufacturingManager.Stop()
+	}
+	if s.arenaManager != nil {
+		s.arenaManager.Stop()
+	}
+
+	// Stop admin background work before the database closes
+	if s.adminManager != nil {
+		s.adminManager.Shutdown()
+	}
+	if s.securityManager != nil {
+		s.securityManager.Stop()
+	}
+
+	// Save every open game session so players can resume after the restart
+	if s.sessionManager != nil {
+		s.sessionManager.Shutdown()
+	}
+
+	// Stop shared world state (closes all session subscriptions)
+	if s.worldHub != nil {
+		s.worldHub.Stop()
+	}
+	if s.updateBus != nil {
+		s.updateBus.Close()
+	}
+
 	// Shutdown rate limiter
 	if s.rateLimiter != nil {
 		s.rateLimiter.Stop()
@@ -0,0 +1,141 @@
+// File: internal/server/terminal.go
+// Project: Terminal Velocity
+// Description: SSH pty negotiation, window resizing and per-session color profiles
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package server
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"golang.org/x/crypto/ssh"
+)
+
+func init() {
+	// Styles are shared by every session, so they always render in full
+	// color. Each session's output is downsampled to its own terminal's
+	// profile by sessionTerminal.
+	lipgloss.SetColorProfile(termenv.TrueColor)
+}
+
+// ptyRequestMsg is the payload of an SSH "pty-req" request (RFC 4254 6.2)
+type ptyRequestMsg struct {
+	Term     string
+	Columns  uint32
+	Rows     uint32
+	WidthPx  uint32
+	HeightPx uint32
+	Modes    string
+}
+
+// windowChangeMsg is the payload of an SSH "window-change" request (RFC 4254 6.7)
+type windowChangeMsg struct {
+	Columns  uint32
+	Rows     uint32
+	WidthPx  uint32
+	HeightPx uint32
+}
+
+// parsePtyRequest decodes a pty-req payload
+func parsePtyRequest(payload []byte) (*ptyRequestMsg, error) {
+	var msg ptyRequestMsg
+	if err := ssh.Unmarshal(payload, &msg); err != nil {
+		return nil, fmt.Errorf("invalid pty-req payload: %w", err)
+	}
+	return &msg, nil
+}
+
+// parseWindowChange decodes a window-change payload
+func parseWindowChange(payload []byte) (*windowChangeMsg, error) {
+	var msg windowChangeMsg
+	if err := ssh.Unmarshal(payload, &msg); err != nil {
+		return nil, fmt.Errorf("invalid window-change payload: %w", err)
+	}
+	return &msg, nil
+}
+
+// sessionTerminal is the client terminal negotiated on one SSH session channel.
+//
+// The request loop in handleSession is the only writer: it sets term from
+// pty-req (before the shell starts) and queues sizes from pty-req and
+// window-change. A program started with run receives the queued sizes as
+// tea.WindowSizeMsg. Only the latest undelivered size is kept, so the
+// request loop never blocks on a busy program.
+type sessionTerminal struct {
+	term    string                 // TERM from pty-req ("" if no pty was requested)
+	resizes chan tea.WindowSizeMsg // Latest undelivered size (capacity 1)
+}
+
+// newSessionTerminal creates a terminal with no size and no TERM
+func newSessionTerminal() *sessionTerminal {
+	return &sessionTerminal{
+		resizes: make(chan tea.WindowSizeMsg, 1),
+	}
+}
+
+// resize queues a new terminal size, replacing any size not yet delivered.
+// Zero dimensions (pixel-only updates) are ignored.
+func (t *sessionTerminal) resize(columns, rows uint32) {
+	if columns == 0 || rows == 0 {
+		return
+	}
+
+	msg := tea.WindowSizeMsg{Width: int(columns), Height: int(rows)}
+	for {
+		select {
+		case t.resizes <- msg:
+			return
+		default:
+			// Drop the stale size and try again
+			select {
+			case <-t.resizes:
+			default:
+			}
+		}
+	}
+}
+
+// colorProfile returns the color profile for the client's TERM.
+// Terminals without color support get Ascii rather than NoTTY so cursor
+// movement and other non-color sequences still reach them.
+func (t *sessionTerminal) colorProfile() colorprofile.Profile {
+	profile := colorprofile.Env([]string{"TERM=" + t.term})
+	if profile < colorprofile.Ascii {
+		profile = colorprofile.Ascii
+	}
+	return profile
+}
+
+// run runs a BubbleTea program on the session channel until it exits,
+// delivering terminal size changes to it as they arrive.
+func (t *sessionTerminal) run(model tea.Model, channel ssh.Channel) (tea.Model, error) {
+	p := tea.NewProgram(
+		model,
+		tea.WithInput(channel),
+		tea.WithOutput(&colorprofile.Writer{Forward: channel, Profile: t.colorProfile()}),
+		tea.WithAltScreen(), // Use alternate screen buffer to prevent artifacts
+	)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for {
+			select {
+			case msg := <-t.resizes:
+				// Returns immediately once the program has exited
+				p.Send(msg)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return p.Run()
+}
@@ -0,0 +1,75 @@
+// File: internal/server/terminal_test.go
+// Project: Terminal Velocity
+// Description: Tests for SSH pty negotiation and session color profiles
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package server
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/colorprofile"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestParsePtyRequest(t *testing.T) {
+	payload := ssh.Marshal(ptyRequestMsg{Term: "xterm-256color", Columns: 160, Rows: 48})
+
+	pty, err := parsePtyRequest(payload)
+	if err != nil {
+		t.Fatalf("parsePtyRequest failed: %v", err)
+	}
+	if pty.Term != "xterm-256color" || pty.Columns != 160 || pty.Rows != 48 {
+		t.Errorf("Unexpected pty request: %+v", pty)
+	}
+
+	if _, err := parsePtyRequest(payload[:6]); err == nil {
+		t.Error("Expected error for truncated payload")
+	}
+}
+
+func TestSessionTerminalKeepsLatestSize(t *testing.T) {
+	term := newSessionTerminal()
+
+	term.resize(80, 24)
+	term.resize(0, 0) // Pixel-only change is ignored
+	term.resize(200, 60)
+
+	select {
+	case msg := <-term.resizes:
+		if msg.Width != 200 || msg.Height != 60 {
+			t.Errorf("Expected 200x60, got %dx%d", msg.Width, msg.Height)
+		}
+	default:
+		t.Fatal("Expected a queued size")
+	}
+
+	select {
+	case msg := <-term.resizes:
+		t.Errorf("Expected only the latest size, also got %dx%d", msg.Width, msg.Height)
+	default:
+	}
+}
+
+func TestSessionTerminalColorProfile(t *testing.T) {
+	tests := []struct {
+		term string
+		want colorprofile.Profile
+	}{
+		{"xterm-256color", colorprofile.ANSI256},
+		{"xterm", colorprofile.ANSI},
+		{"kitty", colorprofile.TrueColor},
+		{"dumb", colorprofile.Ascii},
+		{"", colorprofile.Ascii},
+	}
+
+	for _, tt := range tests {
+		term := newSessionTerminal()
+		term.term = tt.term
+		if got := term.colorProfile(); got != tt.want {
+			t.Errorf("TERM=%q: expected %s, got %s", tt.term, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// File: internal/server/updates.go
+// Project: Terminal Velocity
+// Description: Wiring of manager callbacks into the player update bus
+// Version: 1.3.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package server
+
+import (
+	"context"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/manufacturing"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/marketplace"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// wirePlayerUpdates connects manager callbacks to the update bus so that
+// sessions see credit and cargo changes made outside their own session
+// (auction wins and refunds, mail attachments, faction treasury transfers,
+// arena entry fees and prizes, mined resources).
+func (s *Server) wirePlayerUpdates() {
+	bus := s.updateBus
+
+	s.marketplaceManager.SetCreditsChangedCallback(func(playerID uuid.UUID, oldCredits, newCredits int64, reason string) {
+		bus.PublishCredits(playerID, oldCredits, newCredits, reason)
+	})
+	s.marketplaceManager.SetAuctionCompleteCallback(func(auction *marketplace.Auction) {
+		if auction.HighBidder != uuid.Nil {
+			bus.PublishInventory(auction.HighBidder, nil)
+		}
+	})
+
+	s.worldHub.Factions.SetCreditsChangedCallback(func(playerID uuid.UUID, oldCredits, newCredits int64, reason string) {
+		bus.PublishCredits(playerID, oldCredits, newCredits, reason)
+	})
+
+	s.manufacturingManager.SetCreditsChangedCallback(func(playerID uuid.UUID, oldCredits, newCredits int64, reason string) {
+		bus.PublishCredits(playerID, oldCredits, newCredits, reason)
+	})
+	s.manufacturingManager.SetInventoryChangedCallback(func(playerID uuid.UUID) {
+		bus.PublishInventory(playerID, nil)
+	})
+	s.manufacturingManager.SetCallbacks(
+		func(job *manufacturing.CraftingJob) { s.publishProgress(job.PlayerID) },
+		func(playerID uuid.UUID, tech *manufacturing.Technology) { s.publishProgress(playerID) },
+		nil,
+		nil,
+	)
+
+	s.miningManager.SetInventoryChangedCallback(func(playerID uuid.UUID) {
+		bus.PublishInventory(playerID, nil)
+	})
+
+	s.arenaManager.SetCreditsChangedCallback(func(playerID uuid.UUID, delta int64, reason string) {
+		s.publishCreditsDelta(playerID, delta, reason)
+	})
+
+	s.mailManager.SetNewMailCallback(func(receiverID uuid.UUID, mail *models.Mail) {
+		// Attached credits are deducted from the sender when mail is sent
+		if mail.SenderID != nil && mail.AttachedCredits > 0 {
+			s.publishCreditsDelta(*mail.SenderID, -mail.AttachedCredits, "mail sent")
+		}
+	})
+	s.mailManager.SetAttachmentsClaimedCallback(func(playerID uuid.UUID, mail *models.Mail) {
+		if mail.AttachedCredits > 0 {
+			s.publishCreditsDelta(playerID, mail.AttachedCredits, "mail attachment")
+		}
+		if len(mail.AttachedItems) > 0 {
+			bus.PublishInventory(playerID, nil)
+		}
+	})
+}
+
+// publishCreditsDelta publishes a credits update using the stored balance
+func (s *Server) publishCreditsDelta(playerID uuid.UUID, delta int64, reason string) {
+	player, err := s.playerRepo.GetByID(context.Background(), playerID)
+	if err != nil {
+		log.Warn("Failed to load player %s for credits update: %v", playerID, err)
+		return
+	}
+	s.updateBus.PublishCredits(playerID, player.Credits-delta, player.Credits, reason)
+}
+
+// publishProgress publishes the player's stored crafting and research progress
+func (s *Server) publishProgress(playerID uuid.UUID) {
+	player, err := s.playerRepo.GetByID(context.Background(), playerID)
+	if err != nil {
+		log.Warn("Failed to load player %s for progress update: %v", playerID, err)
+		return
+	}
+	s.updateBus.PublishProgress(player)
+}
@@ -1,7 +1,7 @@
 // File: internal/session/manager.go
 // Project: Terminal Velocity
 // Description: Session management and auto-persistence for multiplayer server
-// Version: 1.0.0
+// Version: 1.1.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 
@@ -9,6 +9,9 @@ package session
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -18,10 +21,40 @@ import (
 	"github.com/google/uuid"
 )
 
-// Session represents an active player session
-
 var log = logger.WithComponent("Session")
 
+// saveTimeout bounds a single session state save
+const saveTimeout = 10 * time.Second
+
+// Session errors
+var (
+	// ErrAlreadyConnected indicates the player already has an active session
+	// and the duplicate login policy is DuplicateRefuse.
+	ErrAlreadyConnected = errors.New("player is already connected")
+
+	// ErrUnknownDuplicatePolicy indicates an invalid duplicate login policy name
+	ErrUnknownDuplicatePolicy = errors.New("unknown duplicate login policy")
+)
+
+// DuplicatePolicy decides what happens when a player logs in while they
+// already have an active session
+type DuplicatePolicy string
+
+const (
+	DuplicateTakeover DuplicatePolicy = "takeover" // End the old session; the new one resumes its state
+	DuplicateRefuse   DuplicatePolicy = "refuse"   // Reject the new login
+)
+
+// ParseDuplicatePolicy converts a config value into a DuplicatePolicy
+func ParseDuplicatePolicy(value string) (DuplicatePolicy, error) {
+	switch policy := DuplicatePolicy(value); policy {
+	case DuplicateTakeover, DuplicateRefuse:
+		return policy, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownDuplicatePolicy, value)
+}
+
+// Session represents an active player session
 type Session struct {
 	ID           uuid.UUID
 	PlayerID     uuid.UUID
@@ -41,6 +74,13 @@ type Session struct {
 	CurrentScreen string
 	DirtyState    bool // Has unsaved changes
 	LastError     error
+
+	// Resume state
+	State      State         // Latest state pushed with UpdateState
+	version    uint64        // Incremented on every State change
+	kicked     chan struct{} // Closed when the server ends the session
+	kickReason string        // Why the session was ended (set before kicked closes)
+	replaced   bool          // Ended by a newer login (set before kicked closes)
 }
 
 // Manager handles player sessions and auto-persistence
@@ -51,11 +91,14 @@ type Manager struct {
 	// Repositories for persistence
 	playerRepo *database.PlayerRepository
 	shipRepo   *database.ShipRepository
+	stateRepo  *database.SessionRepository
 
 	// Configuration
 	saveInterval      time.Duration
 	inactivityTimeout time.Duration
 	enableAutosave    bool
+	resumeGrace       time.Duration   // How long after a disconnect state is resumed
+	duplicatePolicy   DuplicatePolicy // What a second login does
 
 	// Background workers
 	ctx    context.Context
@@ -63,10 +106,12 @@ type Manager struct {
 	wg     sync.WaitGroup
 }
 
-// NewManager creates a new session manager
+// NewManager creates a new session manager. Repositories may be nil, in
+// which case the corresponding state is not persisted.
 func NewManager(
 	playerRepo *database.PlayerRepository,
 	shipRepo *database.ShipRepository,
+	stateRepo *database.SessionRepository,
 ) *Manager {
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -74,9 +119,12 @@ func NewManager(
 		sessions:          make(map[uuid.UUID]*Session),
 		playerRepo:        playerRepo,
 		shipRepo:          shipRepo,
+		stateRepo:         stateRepo,
 		saveInterval:      30 * time.Second, // Save every 30 seconds
 		inactivityTimeout: 15 * time.Minute,
 		enableAutosave:    true,
+		resumeGrace:       5 * time.Minute,
+		duplicatePolicy:   DuplicateTakeover,
 		ctx:               ctx,
 		cancel:            cancel,
 	}
@@ -98,9 +146,17 @@ func (m *Manager) CreateSession(
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	session := newSession(playerID, username, ipAddress)
+	m.sessions[playerID] = session
+
+	return session
+}
+
+// newSession creates an active session with no unsaved changes
+func newSession(playerID uuid.UUID, username, ipAddress string) *Session {
 	now := time.Now()
 
-	session := &Session{
+	return &Session{
 		ID:           uuid.New(),
 		PlayerID:     playerID,
 		Username:     username,
@@ -110,11 +166,162 @@ func (m *Manager) CreateSession(
 		IPAddress:    ipAddress,
 		IsActive:     true,
 		DirtyState:   false,
+		kicked:       make(chan struct{}),
 	}
+}
 
+// Connect starts a session for a player who just logged in.
+//
+// If the player already has an active session, the duplicate login policy
+// applies: DuplicateRefuse returns ErrAlreadyConnected, DuplicateTakeover
+// kicks the old session and hands its state to the new one. Otherwise the
+// state saved when the player last disconnected is returned if that was
+// within the resume grace window.
+//
+// Returns the new session and the state to resume (nil to start fresh).
+func (m *Manager) Connect(ctx context.Context, playerID uuid.UUID, username, ipAddress string) (*Session, *State, error) {
+	m.mu.Lock()
+	var resume *State
+	if existing, exists := m.sessions[playerID]; exists && existing.IsActive {
+		if m.duplicatePolicy == DuplicateRefuse {
+			m.mu.Unlock()
+			return nil, nil, ErrAlreadyConnected
+		}
+		existing.IsActive = false
+		existing.replaced = true
+		kick(existing, "You logged in from another connection.")
+		state := existing.State
+		resume = &state
+		log.Info("Session for %s taken over by a new login from %s", username, ipAddress)
+	}
+	session := newSession(playerID, username, ipAddress)
 	m.sessions[playerID] = session
+	grace := m.resumeGrace
+	m.mu.Unlock()
 
-	return session
+	if resume == nil {
+		resume = m.loadResumeState(ctx, playerID, grace)
+	}
+
+	return session, resume, nil
+}
+
+// loadResumeState returns the player's saved state if they were last seen
+// within the grace window, or nil
+func (m *Manager) loadResumeState(ctx context.Context, playerID uuid.UUID, grace time.Duration) *State {
+	if m.stateRepo == nil {
+		return nil
+	}
+
+	saved, err := m.stateRepo.GetSessionState(ctx, playerID)
+	if err != nil {
+		if !errors.Is(err, database.ErrSessionStateNotFound) {
+			log.Warn("Failed to load session state for %s: %v", playerID, err)
+		}
+		return nil
+	}
+
+	// Without a disconnect time the server stopped uncleanly; the last
+	// autosave is the best estimate of when the player was last seen
+	lastSeen := saved.SavedAt
+	if saved.DisconnectedAt != nil {
+		lastSeen = *saved.DisconnectedAt
+	}
+	if time.Since(lastSeen) > grace {
+		return nil
+	}
+
+	var state State
+	if err := json.Unmarshal(saved.State, &state); err != nil {
+		log.Warn("Discarding unreadable session state for %s: %v", playerID, err)
+		return nil
+	}
+	return &state
+}
+
+// UpdateState records the session's current state. The change is saved
+// by the next autosave or on disconnect. Updates from a session that has
+// been replaced or ended are ignored.
+func (m *Manager) UpdateState(session *Session, state State) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.sessions[session.PlayerID] != session || !session.IsActive {
+		return
+	}
+	if session.State.equal(state) {
+		return
+	}
+
+	session.State = state
+	session.version++
+	session.DirtyState = true
+}
+
+// Disconnect ends a session and saves its state so the player can resume
+// within the grace window. Does nothing for a session that was taken over.
+func (m *Manager) Disconnect(ctx context.Context, session *Session) error {
+	m.mu.Lock()
+	if m.sessions[session.PlayerID] != session {
+		m.mu.Unlock()
+		return nil
+	}
+	delete(m.sessions, session.PlayerID)
+	session.IsActive = false
+	state := session.State
+	m.mu.Unlock()
+
+	now := time.Now()
+	return m.saveState(ctx, session.PlayerID, state, &now)
+}
+
+// Kick ends a player's active session from the server side. The session's
+// Kicked channel closes and its owner is expected to disconnect. Returns
+// false if the player has no active session.
+func (m *Manager) Kick(playerID uuid.UUID, reason string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, exists := m.sessions[playerID]
+	if !exists || !session.IsActive {
+		return false
+	}
+	session.IsActive = false
+	kick(session, reason)
+	return true
+}
+
+// kick closes a session's Kicked channel once. Callers must hold m.mu.
+func kick(session *Session, reason string) {
+	select {
+	case <-session.kicked:
+	default:
+		session.kickReason = reason
+		close(session.kicked)
+	}
+}
+
+// saveState writes a session's state and the player's location
+func (m *Manager) saveState(ctx context.Context, playerID uuid.UUID, state State, disconnectedAt *time.Time) error {
+	if m.stateRepo == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode session state: %w", err)
+	}
+	if err := m.stateRepo.SaveSessionState(ctx, playerID, data, disconnectedAt); err != nil {
+		return err
+	}
+
+	if m.playerRepo != nil && state.SystemID != uuid.Nil {
+		if err := m.playerRepo.UpdateLocation(ctx, playerID, state.SystemID, state.PlanetID); err != nil {
+			return fmt.Errorf("failed to save location: %w", err)
+		}
+	}
+
+	return nil
 }
 
 // GetSession retrieves a session by player ID
@@ -244,7 +451,11 @@ func (m *Manager) EndSession(
 func (m *Manager) autosaveWorker() {
 	defer m.wg.Done()
 
-	ticker := time.NewTicker(m.saveInterval)
+	m.mu.RLock()
+	interval := m.saveInterval
+	m.mu.RUnlock()
+
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
@@ -252,7 +463,15 @@ func (m *Manager) autosaveWorker() {
 		case <-m.ctx.Done():
 			return
 		case <-ticker.C:
-			if m.enableAutosave {
+			m.mu.RLock()
+			enabled := m.enableAutosave
+			if m.saveInterval != interval && m.saveInterval > 0 {
+				interval = m.saveInterval
+				ticker.Reset(interval)
+			}
+			m.mu.RUnlock()
+
+			if enabled {
 				m.performAutosave()
 			}
 		}
@@ -261,22 +480,39 @@ func (m *Manager) autosaveWorker() {
 
 // performAutosave saves all dirty sessions
 func (m *Manager) performAutosave() {
+	type pendingSave struct {
+		session *Session
+		state   State
+		version uint64
+	}
+
 	m.mu.RLock()
-	sessionsToSave := make([]*Session, 0)
+	sessionsToSave := make([]pendingSave, 0)
 
 	for _, session := range m.sessions {
 		if session.IsActive && session.DirtyState {
-			sessionsToSave = append(sessionsToSave, session)
+			sessionsToSave = append(sessionsToSave, pendingSave{session, session.State, session.version})
 		}
 	}
 	m.mu.RUnlock()
 
-	// Note: In a real implementation, we would fetch player/ship data
-	// and call SavePlayerState for each session that needs saving
-	// For now, we just track which sessions need saving
-	for _, session := range sessionsToSave {
-		// Log autosave event
-		_ = session // Placeholder for actual save logic
+	for _, pending := range sessionsToSave {
+		ctx, cancel := context.WithTimeout(m.ctx, saveTimeout)
+		err := m.saveState(ctx, pending.session.PlayerID, pending.state, nil)
+		cancel()
+
+		m.mu.Lock()
+		if err != nil {
+			pending.session.LastError = err
+			log.Warn("Autosave failed for %s: %v", pending.session.Username, err)
+		} else {
+			pending.session.LastSave = time.Now()
+			// Changes pushed during the save stay dirty for the next round
+			if pending.session.version == pending.version {
+				pending.session.DirtyState = false
+			}
+		}
+		m.mu.Unlock()
 	}
 }
 
@@ -322,7 +558,9 @@ func (m *Manager) cleanupInactiveSessions() {
 			continue // Session was already cleaned up
 		}
 		session.IsActive = false
-		// Note: In production, we would trigger a final save here
+		// The owner disconnects, which saves the final state
+		kick(session, fmt.Sprintf("Disconnected after %d minutes of inactivity.", int(m.inactivityTimeout.Minutes())))
+		log.Info("Session for %s timed out", session.Username)
 	}
 }
 
@@ -403,10 +641,42 @@ func (m *Manager) EnableAutosave(enabled bool) {
 	m.enableAutosave = enabled
 }
 
-// Shutdown gracefully shuts down the session manager
+// SetResumeGrace sets how long after a disconnect a player's state is resumed
+func (m *Manager) SetResumeGrace(seconds int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.resumeGrace = time.Duration(seconds) * time.Second
+}
+
+// SetDuplicatePolicy sets what happens when a player logs in twice
+func (m *Manager) SetDuplicatePolicy(policy DuplicatePolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.duplicatePolicy = policy
+}
+
+// Shutdown gracefully shuts down the session manager. Every open session's
+// state is saved as disconnected, so players who reconnect after a restart
+// within the grace window resume where they were.
 func (m *Manager) Shutdown() {
 	m.cancel()
 	m.wg.Wait()
+
+	m.mu.Lock()
+	sessions := m.sessions
+	m.sessions = make(map[uuid.UUID]*Session)
+	m.mu.Unlock()
+
+	now := time.Now()
+	for _, session := range sessions {
+		ctx, cancel := context.WithTimeout(context.Background(), saveTimeout)
+		if err := m.saveState(ctx, session.PlayerID, session.State, &now); err != nil {
+			log.Warn("Failed to save session for %s on shutdown: %v", session.Username, err)
+		}
+		cancel()
+	}
 }
 
 // SessionStats holds session statistics
@@ -427,6 +697,24 @@ func (s *Session) GetIdleDuration() time.Duration {
 	return time.Since(s.LastActivity)
 }
 
+// Kicked returns a channel that is closed when the server ends the session
+// (another login took it over, or it timed out)
+func (s *Session) Kicked() <-chan struct{} {
+	return s.kicked
+}
+
+// KickReason returns why the session was ended. Only valid once Kicked is
+// closed.
+func (s *Session) KickReason() string {
+	return s.kickReason
+}
+
+// Replaced reports whether a newer login took the session over. Only valid
+// once Kicked is closed.
+func (s *Session) Replaced() bool {
+	return s.replaced
+}
+
 // NeedsSave checks if the session has unsaved changes
 func (s *Session) NeedsSave() bool {
 	return s.DirtyState && time.Since(s.LastSave) > 30*time.Second
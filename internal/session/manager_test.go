@@ -0,0 +1,98 @@
+// File: internal/session/manager_test.go
+// Project: Terminal Velocity
+// Description: Tests for duplicate logins, takeover and session state tracking
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+
+	m := NewManager(nil, nil, nil)
+	t.Cleanup(m.Shutdown)
+	return m
+}
+
+func TestTakeoverResumesState(t *testing.T) {
+	m := newTestManager(t)
+	ctx := context.Background()
+	playerID := uuid.New()
+
+	first, resume, err := m.Connect(ctx, playerID, "alice", "198.51.100.1")
+	if err != nil || resume != nil {
+		t.Fatalf("Expected fresh session, got resume=%+v err=%v", resume, err)
+	}
+	m.UpdateState(first, State{Screen: "trading", SystemID: uuid.New()})
+	if !first.DirtyState {
+		t.Error("Expected state change to mark the session dirty")
+	}
+
+	second, resume, err := m.Connect(ctx, playerID, "alice", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if resume == nil || resume.Screen != "trading" {
+		t.Fatalf("Expected the old session's state, got %+v", resume)
+	}
+
+	select {
+	case <-first.Kicked():
+		if first.KickReason() == "" {
+			t.Error("Expected a kick reason")
+		}
+	default:
+		t.Fatal("Expected the old session to be kicked")
+	}
+
+	// The old session can no longer change state or end the new one
+	m.UpdateState(first, State{Screen: "shipyard"})
+	if err := m.Disconnect(ctx, first); err != nil {
+		t.Fatalf("Disconnect failed: %v", err)
+	}
+	current, ok := m.GetSession(playerID)
+	if !ok || current != second || current.State.Screen != "" {
+		t.Errorf("Expected the new session to be unaffected, got %+v", current)
+	}
+}
+
+func TestRefuseDuplicateLogin(t *testing.T) {
+	m := newTestManager(t)
+	m.SetDuplicatePolicy(DuplicateRefuse)
+	ctx := context.Background()
+	playerID := uuid.New()
+
+	first, _, err := m.Connect(ctx, playerID, "bob", "198.51.100.1")
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if _, _, err := m.Connect(ctx, playerID, "bob", "203.0.113.7"); !errors.Is(err, ErrAlreadyConnected) {
+		t.Fatalf("Expected ErrAlreadyConnected, got %v", err)
+	}
+
+	// Once the first session ends the player can log in again
+	if err := m.Disconnect(ctx, first); err != nil {
+		t.Fatalf("Disconnect failed: %v", err)
+	}
+	if _, _, err := m.Connect(ctx, playerID, "bob", "203.0.113.7"); err != nil {
+		t.Errorf("Expected login after disconnect to succeed, got %v", err)
+	}
+}
+
+func TestParseDuplicatePolicy(t *testing.T) {
+	if policy, err := ParseDuplicatePolicy("refuse"); err != nil || policy != DuplicateRefuse {
+		t.Errorf("Expected refuse, got %q (%v)", policy, err)
+	}
+	if _, err := ParseDuplicatePolicy("kick"); !errors.Is(err, ErrUnknownDuplicatePolicy) {
+		t.Errorf("Expected ErrUnknownDuplicatePolicy, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+// File: internal/session/state.go
+// Project: Terminal Velocity
+// Description: Resumable session state saved by autosave and on disconnect
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package session
+
+import (
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/combat"
+	"github.com/google/uuid"
+)
+
+// State is what a player was doing, saved so a reconnect can put them back.
+//
+// The TUI pushes a new State with UpdateState whenever it changes; the
+// manager writes it to the database on the autosave interval and when the
+// player disconnects.
+type State struct {
+	Screen   string     `json:"screen"`              // Screen key understood by the TUI ("" = main menu)
+	SystemID uuid.UUID  `json:"system_id"`           // Current star system
+	PlanetID *uuid.UUID `json:"planet_id,omitempty"` // Planet the player is docked at (nil = in space)
+
+	Combat *combat.Record `json:"combat,omitempty"` // Battle in progress
+	Jump   *JumpState     `json:"jump,omitempty"`   // Hyperspace jump in progress
+}
+
+// JumpState is a hyperspace jump that had started when the state was saved
+type JumpState struct {
+	FromSystemID uuid.UUID `json:"from_system_id"`
+	ToSystemID   uuid.UUID `json:"to_system_id"`
+}
+
+// equal reports whether two states would save the same thing. Battles are
+// compared by seed and action count: a battle only changes through actions.
+func (s State) equal(other State) bool {
+	if s.Screen != other.Screen || s.SystemID != other.SystemID {
+		return false
+	}
+	if (s.PlanetID == nil) != (other.PlanetID == nil) || (s.PlanetID != nil && *s.PlanetID != *other.PlanetID) {
+		return false
+	}
+	if (s.Jump == nil) != (other.Jump == nil) || (s.Jump != nil && *s.Jump != *other.Jump) {
+		return false
+	}
+	if (s.Combat == nil) != (other.Combat == nil) {
+		return false
+	}
+	return s.Combat == nil ||
+		(s.Combat.Seed == other.Combat.Seed && len(s.Combat.Actions) == len(other.Combat.Actions))
+}
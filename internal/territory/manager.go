@@ -1,13 +1,48 @@
 // File: internal/territory/manager.go
 // Project: Terminal Velocity
-// Version: 1.0.0
+// Description: Territory simulation - faction claims, influence, upkeep, contests and sieges
+// Version: 1.3.0
+// Author: Joshua Ferguson
+// Created: 2025-01-07
+//
+// Control:
+// Activity by the owning faction in its own system earns control points:
+// member visits, trade and kills. Enough points raise the control level
+// (weak → stable → strong → dominant); see models.Territory.
+//
+// Weekly Cycle:
+// When a territory's upkeep falls due, its weekly income is paid into the
+// owning faction's treasury and its upkeep is charged from it. Unpaid upkeep
+// costs control points and can drop the control level; after
+// MaxMissedUpkeeps unpaid cycles in a row the claim is released. The weekly
+// activity counters then reset.
+//
+// Contests and Sieges:
+// An officer of a rival faction can contest a system for ContestFee. For
+// ContestDuration both factions' activity in the system earns contest
+// points instead of control points. If the attacker out-scores the owner, a
+// siege runs for SiegeDuration; an attacker who out-scores the owner plus
+// the system's defense bonus takes the system. A failed attack restores the
+// owner's previous control level. The owner's leader and officers are
+// warned when a contest or siege begins. With an ally checker set, the
+// owner's allies earn defender points too.
+//
+// Persistence:
+// With a Store set, claims, contests, transfers and releases are written
+// through immediately, and a claim, contest or outcome that cannot be saved
+// does not take effect. Activity is batched: changed territories are saved
+// on the next Update.
 
 package territory
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"sync"
+	"time"
 
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/factions"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	"github.com/google/uuid"
@@ -15,26 +50,174 @@ import (
 
 var log = logger.WithComponent("Territory")
 
+// dbTimeout bounds each write to the territory store
+const dbTimeout = 5 * time.Second
+
+// Simulation tuning
+const (
+	MemberVisitPoints = 2     // Control points per member visit
+	KillPoints        = 5     // Control points per hostile ship destroyed
+	TradePointVolume  = 10000 // Credits traded per control point
+
+	UpkeepInterval    = 7 * 24 * time.Hour // Length of the upkeep and income cycle
+	MissedUpkeepDecay = 50                 // Control points lost per unpaid upkeep
+	MaxMissedUpkeeps  = 3                  // Consecutive unpaid cycles before the claim is released
+
+	ContestFee      int64 = 25000              // Treasury cost to contest a system
+	ContestDuration       = 24 * time.Hour     // Influence contest window
+	SiegeDuration         = 48 * time.Hour     // Siege window after a won contest
+	ContestCooldown       = 3 * 24 * time.Hour // Protection after a contest or siege ends
+)
+
 var (
 	ErrAlreadyClaimed    = errors.New("system already claimed")
 	ErrNotClaimed        = errors.New("system not claimed")
 	ErrNotOwner          = errors.New("not territory owner")
 	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrOwnTerritory      = errors.New("cannot contest your own territory")
+	ErrAlreadyContested  = errors.New("system is already contested")
+	ErrContestCooldown   = errors.New("system was contested recently")
+	ErrNoFactions        = errors.New("faction manager not configured")
 )
 
+// attackWarning is a territory attack notification queued for sending
+// after the manager lock is released
+type attackWarning struct {
+	recipients   []uuid.UUID
+	attackerID   uuid.UUID
+	attackerName string
+	systemName   string
+}
+
 type Manager struct {
 	mu          sync.RWMutex
 	territories map[uuid.UUID]*models.Territory
 	byFaction   map[uuid.UUID][]*models.Territory
+	dirty       map[uuid.UUID]bool // Systems with activity not yet saved
+
+	store    Store             // Persistence (optional)
+	factions *factions.Manager // Treasury for income, upkeep and contest fees
+	notifier Notifier          // Attack warnings (optional)
+
+	// Tells whether a faction is allied with a system's owner (optional)
+	allies func(factionID, ownerFactionID uuid.UUID) bool
+
+	// Callback for real-time territory change delivery
+	onTerritoryChanged func(territory *models.Territory)
+
+	// Callback for contests and sieges won or lost
+	onConflictResolved func(territory *models.Territory, winnerFactionID, loserFactionID uuid.UUID, captured bool)
 }
 
 func NewManager() *Manager {
 	return &Manager{
 		territories: make(map[uuid.UUID]*models.Territory),
 		byFaction:   make(map[uuid.UUID][]*models.Territory),
+		dirty:       make(map[uuid.UUID]bool),
+	}
+}
+
+// SetStore sets where territory claims are persisted. Without a store the
+// manager keeps everything in memory.
+func (m *Manager) SetStore(store Store) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store = store
+}
+
+// SetFactions sets the faction manager whose treasuries receive territory
+// income and pay upkeep and contest fees
+func (m *Manager) SetFactions(factionManager *factions.Manager) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.factions = factionManager
+}
+
+// SetNotifier sets where territory attack warnings are sent
+func (m *Manager) SetNotifier(notifier Notifier) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifier = notifier
+}
+
+// SetAllyChecker sets how the manager tells whether a faction is allied with
+// a system's owner. Allies' activity in a contested system counts toward its
+// defense.
+func (m *Manager) SetAllyChecker(allied func(factionID, ownerFactionID uuid.UUID) bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.allies = allied
+}
+
+// SetConflictResolvedCallback sets the callback invoked when an attack on a
+// system ends: captured is true if the attacker took the system, otherwise
+// the owner held it. The callback is invoked while the manager lock is held
+// and must not call back into the Manager.
+func (m *Manager) SetConflictResolvedCallback(callback func(territory *models.Territory, winnerFactionID, loserFactionID uuid.UUID, captured bool)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onConflictResolved = callback
+}
+
+// SetTerritoryChangedCallback sets the callback invoked whenever a system is
+// claimed or its control changes. The callback is invoked while the manager
+// lock is held and must not call back into the Manager.
+func (m *Manager) SetTerritoryChangedCallback(callback func(territory *models.Territory)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onTerritoryChanged = callback
+}
+
+// notify reports a territory change. Caller must hold m.mu.
+func (m *Manager) notify(territory *models.Territory) {
+	if m.onTerritoryChanged != nil {
+		m.onTerritoryChanged(territory)
+	}
+}
+
+// Load replaces the in-memory territories with those in the store
+func (m *Manager) Load(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.store == nil {
+		return nil
+	}
+
+	territories, err := m.store.ListTerritories(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.territories = make(map[uuid.UUID]*models.Territory, len(territories))
+	m.byFaction = make(map[uuid.UUID][]*models.Territory)
+	m.dirty = make(map[uuid.UUID]bool)
+	for _, t := range territories {
+		m.territories[t.SystemID] = t
+		m.byFaction[t.FactionID] = append(m.byFaction[t.FactionID], t)
+	}
+
+	log.Info("Loaded %d territories", len(territories))
+	return nil
+}
+
+// save writes a territory to the store, which includes any activity not yet
+// saved. Caller must hold m.mu.
+func (m *Manager) save(t *models.Territory) error {
+	if m.store == nil {
+		return nil
 	}
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	if err := m.store.SaveTerritory(ctx, t); err != nil {
+		return fmt.Errorf("failed to save territory %s: %w", t.SystemName, err)
+	}
+	delete(m.dirty, t.SystemID)
+	return nil
 }
 
+// ClaimSystem claims an unclaimed system for a faction
 func (m *Manager) ClaimSystem(systemID uuid.UUID, systemName string, factionID uuid.UUID, factionTag string) (*models.Territory, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -44,9 +227,15 @@ func (m *Manager) ClaimSystem(systemID uuid.UUID, systemName string, factionID u
 	}
 
 	territory := models.NewTerritory(systemID, systemName, factionID, factionTag)
+	if err := m.save(territory); err != nil {
+		return nil, err
+	}
+
 	m.territories[systemID] = territory
 	m.byFaction[factionID] = append(m.byFaction[factionID], territory)
+	m.notify(territory)
 
+	log.Info("Faction [%s] claimed %s", factionTag, systemName)
 	return territory, nil
 }
 
@@ -65,7 +254,7 @@ func (m *Manager) GetFactionTerritories(factionID uuid.UUID) []*models.Territory
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	return m.byFaction[factionID]
+	return append([]*models.Territory(nil), m.byFaction[factionID]...)
 }
 
 func (m *Manager) GetAllTerritories() []*models.Territory {
@@ -86,3 +275,414 @@ func (m *Manager) IsSystemClaimed(systemID uuid.UUID) bool {
 	_, exists := m.territories[systemID]
 	return exists
 }
+
+// RecordMemberActivity records a faction member arriving in a system
+func (m *Manager) RecordMemberActivity(systemID, factionID uuid.UUID) {
+	m.recordActivity(systemID, factionID, MemberVisitPoints, func(t *models.Territory) {
+		t.MemberActivity++
+	})
+}
+
+// RecordTrade records a completed trade in a system. All trade counts
+// toward the system's income; trade by an involved faction also earns it
+// influence. factionID is uuid.Nil for players outside a faction.
+func (m *Manager) RecordTrade(systemID, factionID uuid.UUID, volume int64) {
+	if volume <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, exists := m.territories[systemID]
+	if !exists {
+		return
+	}
+	t.TradeVolume += volume
+	m.dirty[systemID] = true
+	m.addInfluence(t, factionID, int(volume/TradePointVolume))
+}
+
+// RecordKill records a faction member destroying a hostile ship in a system
+func (m *Manager) RecordKill(systemID, factionID uuid.UUID) {
+	m.recordActivity(systemID, factionID, KillPoints, func(t *models.Territory) {
+		t.Kills++
+	})
+}
+
+// recordActivity applies activity by a faction involved in a system
+func (m *Manager) recordActivity(systemID, factionID uuid.UUID, points int, count func(t *models.Territory)) {
+	if factionID == uuid.Nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, exists := m.territories[systemID]
+	if !exists {
+		return
+	}
+	if factionID == t.FactionID {
+		count(t)
+		m.dirty[systemID] = true
+	}
+	m.addInfluence(t, factionID, points)
+}
+
+// addInfluence credits points to the owner's control or, while the system
+// is contested, to the attacker's or defender's score; the owner's allies
+// score for the defense. Factions not involved in the system earn nothing.
+// Caller must hold m.mu.
+func (m *Manager) addInfluence(t *models.Territory, factionID uuid.UUID, points int) {
+	if factionID == uuid.Nil || points <= 0 {
+		return
+	}
+
+	if t.Contest != nil {
+		switch factionID {
+		case t.FactionID:
+			t.Contest.DefenderPoints += points
+		case t.Contest.AttackerFactionID:
+			t.Contest.AttackerPoints += points
+		default:
+			if m.allies == nil || !m.allies(factionID, t.FactionID) {
+				return
+			}
+			t.Contest.DefenderPoints += points
+		}
+		m.dirty[t.SystemID] = true
+		return
+	}
+
+	if factionID != t.FactionID {
+		return
+	}
+	level := t.ControlLevel
+	t.AddControlPoints(points)
+	m.dirty[t.SystemID] = true
+	if t.ControlLevel != level {
+		log.Info("[%s] control of %s rose to %s", t.FactionTag, t.SystemName, t.ControlLevel)
+		m.notify(t)
+	}
+}
+
+// ContestSystem starts a rival faction's challenge for a system. The player
+// must be an officer of the attacking faction, which pays ContestFee.
+func (m *Manager) ContestSystem(systemID, attackerFactionID, playerID uuid.UUID) (*models.Territory, error) {
+	m.mu.Lock()
+
+	t, exists := m.territories[systemID]
+	if !exists {
+		m.mu.Unlock()
+		return nil, ErrNotClaimed
+	}
+	if t.FactionID == attackerFactionID {
+		m.mu.Unlock()
+		return nil, ErrOwnTerritory
+	}
+	if t.Contest != nil {
+		m.mu.Unlock()
+		return nil, ErrAlreadyContested
+	}
+	now := time.Now()
+	if t.LastConflict != nil && now.Sub(*t.LastConflict) < ContestCooldown {
+		m.mu.Unlock()
+		return nil, ErrContestCooldown
+	}
+	if m.factions == nil {
+		m.mu.Unlock()
+		return nil, ErrNoFactions
+	}
+
+	attacker, err := m.factions.GetFaction(attackerFactionID)
+	if err != nil {
+		m.mu.Unlock()
+		return nil, err
+	}
+	if !attacker.IsOfficer(playerID) {
+		m.mu.Unlock()
+		return nil, factions.ErrInsufficientRank
+	}
+	if err := m.factions.SpendFunds(attackerFactionID, ContestFee); err != nil {
+		m.mu.Unlock()
+		if errors.Is(err, factions.ErrInsufficientFunds) {
+			return nil, ErrInsufficientFunds
+		}
+		return nil, err
+	}
+
+	// The fee is refunded if the contest cannot be saved
+	contested := *t
+	contested.Contest = &models.TerritoryContest{
+		AttackerFactionID: attackerFactionID,
+		AttackerTag:       attacker.Tag,
+		Phase:             models.ContestPhaseContest,
+		StartedAt:         now,
+		EndsAt:            now.Add(ContestDuration),
+		PreviousLevel:     t.ControlLevel,
+	}
+	contested.ControlLevel = models.ControlLevelContested
+	contested.LastConflict = &now
+	if err := m.save(&contested); err != nil {
+		if refundErr := m.factions.AddFunds(attackerFactionID, ContestFee); refundErr != nil {
+			log.Error("Failed to refund the contest fee for %s to [%s]: %v", t.SystemName, attacker.Tag, refundErr)
+		}
+		m.mu.Unlock()
+		return nil, err
+	}
+	*t = contested
+	m.notify(t)
+
+	warning := m.attackWarning(t, attacker)
+	m.mu.Unlock()
+
+	log.Info("Faction [%s] contested %s held by [%s]", attacker.Tag, t.SystemName, t.FactionTag)
+	m.sendWarnings([]attackWarning{warning})
+	return t, nil
+}
+
+// Update runs the simulation up to now: it resolves contests and sieges
+// whose window has ended, runs due upkeep cycles and saves changed
+// territories. The world hub calls it from its maintenance sweep.
+func (m *Manager) Update(now time.Time) {
+	var warnings []attackWarning
+
+	m.mu.Lock()
+	for systemID, t := range m.territories {
+		if t.Contest != nil && !now.Before(t.Contest.EndsAt) {
+			warning, ok, err := m.resolveContest(t, now)
+			if err != nil {
+				log.Error("Failed to resolve the attack on %s: %v", t.SystemName, err)
+			} else if ok {
+				warnings = append(warnings, warning)
+			}
+		}
+		if !now.Before(t.NextUpkeep) {
+			if released := m.runUpkeepCycle(t, now); released {
+				continue
+			}
+		}
+		if m.dirty[systemID] {
+			if err := m.save(t); err != nil {
+				log.Error("Failed to save activity in %s: %v", t.SystemName, err)
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	m.sendWarnings(warnings)
+}
+
+// resolveContest ends the current phase of a contest. A won contest turns
+// into a siege, which is returned as a warning to send. An outcome that
+// cannot be saved leaves the territory as it was, to be resolved on a later
+// Update. Caller must hold m.mu.
+func (m *Manager) resolveContest(t *models.Territory, now time.Time) (attackWarning, bool, error) {
+	contest := *t.Contest
+	resolved := *t
+	resolved.LastConflict = &now
+
+	switch {
+	case contest.Phase == models.ContestPhaseContest && contest.AttackerPoints > contest.DefenderPoints:
+		contest.Phase = models.ContestPhaseSiege
+		contest.StartedAt = now
+		contest.EndsAt = now.Add(SiegeDuration)
+		contest.AttackerPoints = 0
+		contest.DefenderPoints = 0
+		resolved.Contest = &contest
+		if err := m.save(&resolved); err != nil {
+			return attackWarning{}, false, err
+		}
+		*t = resolved
+		m.notify(t)
+		log.Info("Faction [%s] is besieging %s", contest.AttackerTag, t.SystemName)
+
+		if m.factions != nil {
+			if attacker, err := m.factions.GetFaction(contest.AttackerFactionID); err == nil {
+				return m.attackWarning(t, attacker), true, nil
+			}
+		}
+		return attackWarning{}, false, nil
+
+	case contest.Phase == models.ContestPhaseSiege &&
+		contest.AttackerPoints > contest.DefenderPoints+t.GetBenefits().DefenseBonus:
+		defenderID := t.FactionID
+		if err := m.transfer(t, contest.AttackerFactionID, contest.AttackerTag, now); err != nil {
+			return attackWarning{}, false, err
+		}
+		m.conflictResolved(t, contest.AttackerFactionID, defenderID, true)
+
+	default:
+		resolved.ControlLevel = contest.PreviousLevel
+		resolved.Contest = nil
+		if err := m.save(&resolved); err != nil {
+			return attackWarning{}, false, err
+		}
+		*t = resolved
+		log.Info("[%s] held %s against [%s]", t.FactionTag, t.SystemName, contest.AttackerTag)
+		m.notify(t)
+		m.conflictResolved(t, t.FactionID, contest.AttackerFactionID, false)
+	}
+
+	return attackWarning{}, false, nil
+}
+
+// conflictResolved reports the outcome of an attack. Caller must hold m.mu.
+func (m *Manager) conflictResolved(t *models.Territory, winnerFactionID, loserFactionID uuid.UUID, captured bool) {
+	if m.onConflictResolved != nil {
+		m.onConflictResolved(t, winnerFactionID, loserFactionID, captured)
+	}
+}
+
+// transfer hands a besieged system to the attacking faction. The siege
+// destroys the system's defenses. Caller must hold m.mu.
+func (m *Manager) transfer(t *models.Territory, factionID uuid.UUID, factionTag string, now time.Time) error {
+	taken := *t
+	taken.FactionID = factionID
+	taken.FactionTag = factionTag
+	taken.ControlLevel = models.ControlLevelWeak
+	taken.ControlPoints = 0
+	taken.ClaimedAt = now
+	taken.LastUpkeep = now
+	taken.NextUpkeep = now.Add(UpkeepInterval)
+	taken.LastConflict = &now
+	taken.DefenseLevel = 0
+	taken.MissedUpkeeps = 0
+	taken.Contest = nil
+	taken.ResetWeeklyActivity()
+	taken.CalculateUpkeep()
+	if err := m.save(&taken); err != nil {
+		return err
+	}
+
+	log.Info("Faction [%s] took %s from [%s]", factionTag, t.SystemName, t.FactionTag)
+	m.removeFromFaction(t)
+	*t = taken
+	m.byFaction[factionID] = append(m.byFaction[factionID], t)
+	m.notify(t)
+	return nil
+}
+
+// runUpkeepCycle pays the week's income into the owner's treasury and
+// charges upkeep. Returns true if unpaid upkeep released the claim.
+// Caller must hold m.mu.
+func (m *Manager) runUpkeepCycle(t *models.Territory, now time.Time) bool {
+	income := t.CalculateIncome()
+	upkeep := t.CalculateUpkeep()
+
+	paid := true
+	if m.factions != nil {
+		if income > 0 {
+			if err := m.factions.AddFunds(t.FactionID, income); err != nil {
+				log.Error("Failed to pay %d CR income from %s to [%s]: %v", income, t.SystemName, t.FactionTag, err)
+			}
+		}
+		if err := m.factions.SpendFunds(t.FactionID, upkeep); err != nil {
+			log.Warn("[%s] missed %d CR upkeep for %s: %v", t.FactionTag, upkeep, t.SystemName, err)
+			paid = false
+		}
+	}
+
+	if paid {
+		t.MissedUpkeeps = 0
+		t.LastUpkeep = now
+		t.AddControlPoints(10)
+	} else {
+		t.MissedUpkeeps++
+		t.RemoveControlPoints(MissedUpkeepDecay)
+		if t.MissedUpkeeps >= MaxMissedUpkeeps {
+			m.release(t)
+			return true
+		}
+	}
+
+	t.NextUpkeep = t.NextUpkeep.Add(UpkeepInterval)
+	t.ResetWeeklyActivity()
+	if err := m.save(t); err != nil {
+		// The treasury has already moved; the cycle is saved on a later Update
+		log.Error("Failed to save the upkeep cycle for %s: %v", t.SystemName, err)
+		m.dirty[t.SystemID] = true
+	}
+	m.notify(t)
+	return false
+}
+
+// release drops a faction's claim on a system. Caller must hold m.mu.
+func (m *Manager) release(t *models.Territory) {
+	log.Info("[%s] lost %s after %d missed upkeep payments", t.FactionTag, t.SystemName, t.MissedUpkeeps)
+
+	if m.store != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+		defer cancel()
+		if err := m.store.DeleteTerritory(ctx, t.SystemID); err != nil {
+			log.Error("Failed to delete territory %s: %v", t.SystemName, err)
+		}
+	}
+
+	m.removeFromFaction(t)
+	delete(m.territories, t.SystemID)
+	delete(m.dirty, t.SystemID)
+	m.notify(t)
+}
+
+// removeFromFaction drops a territory from its owner's list. Caller must hold m.mu.
+func (m *Manager) removeFromFaction(t *models.Territory) {
+	owned := m.byFaction[t.FactionID]
+	for i, candidate := range owned {
+		if candidate == t {
+			owned = append(owned[:i], owned[i+1:]...)
+			break
+		}
+	}
+	if len(owned) == 0 {
+		delete(m.byFaction, t.FactionID)
+	} else {
+		m.byFaction[t.FactionID] = owned
+	}
+}
+
+// attackWarning builds the warning for a territory's leadership. Caller must hold m.mu.
+func (m *Manager) attackWarning(t *models.Territory, attacker *models.PlayerFaction) attackWarning {
+	warning := attackWarning{
+		attackerID:   attacker.ID,
+		attackerName: attacker.Name,
+		systemName:   t.SystemName,
+	}
+	if m.factions == nil {
+		return warning
+	}
+
+	defender, err := m.factions.GetFaction(t.FactionID)
+	if err != nil {
+		return warning
+	}
+	warning.recipients = append(warning.recipients, defender.LeaderID)
+	for _, officerID := range defender.Officers {
+		if officerID != defender.LeaderID {
+			warning.recipients = append(warning.recipients, officerID)
+		}
+	}
+	return warning
+}
+
+// sendWarnings delivers attack warnings. Must be called without m.mu held.
+func (m *Manager) sendWarnings(warnings []attackWarning) {
+	m.mu.RLock()
+	notifier := m.notifier
+	m.mu.RUnlock()
+
+	if notifier == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	for _, w := range warnings {
+		for _, recipientID := range w.recipients {
+			if err := notifier.NotifyTerritoryAttack(ctx, recipientID, w.attackerID, w.attackerName, w.systemName); err != nil {
+				log.Error("Failed to send territory attack warning for %s: %v", w.systemName, err)
+			}
+		}
+	}
+}
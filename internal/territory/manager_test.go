@@ -0,0 +1,300 @@
+// File: internal/territory/manager_test.go
+// Project: Terminal Velocity
+// Description: Tests for the territory simulation - influence, upkeep, contests and sieges
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package territory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/factions"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// memoryStore is an in-memory Store for tests
+type memoryStore struct {
+	saved    map[uuid.UUID]models.Territory
+	failWith error // returned by every write while set
+}
+
+func (s *memoryStore) SaveTerritory(ctx context.Context, t *models.Territory) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	s.saved[t.SystemID] = *t
+	return nil
+}
+
+func (s *memoryStore) DeleteTerritory(ctx context.Context, systemID uuid.UUID) error {
+	if s.failWith != nil {
+		return s.failWith
+	}
+	delete(s.saved, systemID)
+	return nil
+}
+
+func (s *memoryStore) ListTerritories(ctx context.Context) ([]*models.Territory, error) {
+	var territories []*models.Territory
+	for _, t := range s.saved {
+		t := t
+		territories = append(territories, &t)
+	}
+	return territories, nil
+}
+
+// warningRecorder is a Notifier that records who was warned
+type warningRecorder struct {
+	recipients []uuid.UUID
+}
+
+func (r *warningRecorder) NotifyTerritoryAttack(ctx context.Context, factionLeaderID, attackerFactionID uuid.UUID, attackerFactionName, systemName string) error {
+	r.recipients = append(r.recipients, factionLeaderID)
+	return nil
+}
+
+type testWorld struct {
+	manager   *Manager
+	factions  *factions.Manager
+	store     *memoryStore
+	warnings  *warningRecorder
+	owner     *models.PlayerFaction
+	attacker  *models.PlayerFaction
+	territory *models.Territory
+}
+
+func newTestWorld(t *testing.T) *testWorld {
+	t.Helper()
+
+	w := &testWorld{
+		manager:  NewManager(),
+		factions: factions.NewManager(),
+		store:    &memoryStore{saved: make(map[uuid.UUID]models.Territory)},
+		warnings: &warningRecorder{},
+	}
+	w.manager.SetStore(w.store)
+	w.manager.SetFactions(w.factions)
+	w.manager.SetNotifier(w.warnings)
+
+	var err error
+	if w.owner, err = w.factions.CreateFaction("Owners", "OWN", uuid.New(), models.AlignmentTrader); err != nil {
+		t.Fatalf("CreateFaction failed: %v", err)
+	}
+	if w.attacker, err = w.factions.CreateFaction("Raiders", "RAID", uuid.New(), models.AlignmentPirate); err != nil {
+		t.Fatalf("CreateFaction failed: %v", err)
+	}
+	if w.territory, err = w.manager.ClaimSystem(uuid.New(), "Sol", w.owner.ID, w.owner.Tag); err != nil {
+		t.Fatalf("ClaimSystem failed: %v", err)
+	}
+	return w
+}
+
+func TestActivityRaisesControl(t *testing.T) {
+	w := newTestWorld(t)
+	systemID := w.territory.SystemID
+
+	for i := 0; i < 10; i++ {
+		w.manager.RecordKill(systemID, w.owner.ID)
+	}
+	w.manager.RecordTrade(systemID, w.owner.ID, 250000)
+	w.manager.RecordMemberActivity(systemID, w.owner.ID)
+
+	// 50 kill + 25 trade + 2 visit points; rivals and players outside a
+	// faction earn no control, though their trade still counts as volume
+	w.manager.RecordKill(systemID, w.attacker.ID)
+	w.manager.RecordTrade(systemID, uuid.Nil, 50000)
+	if w.territory.ControlLevel != models.ControlLevelWeak || w.territory.ControlPoints != 77 {
+		t.Fatalf("Expected 77 points at weak, got %d at %s", w.territory.ControlPoints, w.territory.ControlLevel)
+	}
+	if w.territory.TradeVolume != 300000 || w.territory.Kills != 10 || w.territory.MemberActivity != 1 {
+		t.Errorf("Expected weekly counters to include all trade, got %+v", w.territory)
+	}
+
+	w.manager.RecordTrade(systemID, w.owner.ID, 300000)
+	if w.territory.ControlLevel != models.ControlLevelStable {
+		t.Errorf("Expected control to rise to stable, got %s", w.territory.ControlLevel)
+	}
+
+	// Activity is saved on the next update
+	w.manager.Update(time.Now())
+	if saved := w.store.saved[systemID]; saved.ControlLevel != models.ControlLevelStable {
+		t.Errorf("Expected the update to save the new control level, got %s", saved.ControlLevel)
+	}
+}
+
+func TestUpkeepCycle(t *testing.T) {
+	w := newTestWorld(t)
+	systemID := w.territory.SystemID
+
+	if err := w.factions.AddFunds(w.owner.ID, 1000); err != nil {
+		t.Fatalf("AddFunds failed: %v", err)
+	}
+	w.manager.RecordTrade(systemID, uuid.Nil, 100000)
+
+	// Income (375 + 1% of trade) is paid before the 1000 CR upkeep
+	due := w.territory.NextUpkeep
+	w.manager.Update(due)
+	if w.owner.Treasury != 1375 {
+		t.Errorf("Expected treasury 1375 after income and upkeep, got %d", w.owner.Treasury)
+	}
+	if w.territory.MissedUpkeeps != 0 || w.territory.TradeVolume != 0 || !w.territory.NextUpkeep.Equal(due.Add(UpkeepInterval)) {
+		t.Errorf("Expected a paid cycle to reset activity and schedule the next, got %+v", w.territory)
+	}
+
+	// Income alone no longer covers upkeep: each miss decays control
+	for i := 1; i < MaxMissedUpkeeps; i++ {
+		w.factions.SpendFunds(w.owner.ID, w.owner.Treasury)
+		w.manager.Update(w.territory.NextUpkeep)
+		if w.territory.MissedUpkeeps != i {
+			t.Fatalf("Expected %d missed upkeeps, got %d", i, w.territory.MissedUpkeeps)
+		}
+	}
+
+	w.factions.SpendFunds(w.owner.ID, w.owner.Treasury)
+	w.manager.Update(w.territory.NextUpkeep)
+	if w.manager.IsSystemClaimed(systemID) || len(w.manager.GetFactionTerritories(w.owner.ID)) != 0 {
+		t.Error("Expected the claim to be released after too many missed upkeeps")
+	}
+	if _, saved := w.store.saved[systemID]; saved {
+		t.Error("Expected the released claim to be deleted from the store")
+	}
+}
+
+func TestContestAndSiege(t *testing.T) {
+	w := newTestWorld(t)
+	systemID := w.territory.SystemID
+
+	if _, err := w.manager.ContestSystem(systemID, w.attacker.ID, w.attacker.LeaderID); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("Expected ErrInsufficientFunds, got %v", err)
+	}
+	if _, err := w.manager.ContestSystem(systemID, w.owner.ID, w.owner.LeaderID); !errors.Is(err, ErrOwnTerritory) {
+		t.Fatalf("Expected ErrOwnTerritory, got %v", err)
+	}
+
+	w.factions.AddFunds(w.attacker.ID, ContestFee)
+	if _, err := w.manager.ContestSystem(systemID, w.attacker.ID, w.attacker.LeaderID); err != nil {
+		t.Fatalf("ContestSystem failed: %v", err)
+	}
+	if w.attacker.Treasury != 0 || w.territory.ControlLevel != models.ControlLevelContested {
+		t.Errorf("Expected the fee to be paid and the system contested, got treasury %d, level %s",
+			w.attacker.Treasury, w.territory.ControlLevel)
+	}
+	if len(w.warnings.recipients) != 1 || w.warnings.recipients[0] != w.owner.LeaderID {
+		t.Errorf("Expected the owner's leader to be warned, got %v", w.warnings.recipients)
+	}
+	if saved := w.store.saved[systemID]; saved.Contest == nil {
+		t.Error("Expected the contest to be saved")
+	}
+
+	// The attacker out-influences the owner, turning the contest into a siege
+	w.manager.RecordKill(systemID, w.attacker.ID)
+	w.manager.RecordMemberActivity(systemID, w.owner.ID)
+	w.manager.Update(w.territory.Contest.EndsAt)
+	if w.territory.Contest == nil || w.territory.Contest.Phase != models.ContestPhaseSiege {
+		t.Fatalf("Expected a siege, got %+v", w.territory.Contest)
+	}
+	if len(w.warnings.recipients) != 2 {
+		t.Errorf("Expected a second warning for the siege, got %d", len(w.warnings.recipients))
+	}
+
+	w.manager.RecordKill(systemID, w.attacker.ID)
+	w.manager.Update(w.territory.Contest.EndsAt)
+	if w.territory.FactionID != w.attacker.ID || w.territory.ControlLevel != models.ControlLevelWeak || w.territory.Contest != nil {
+		t.Fatalf("Expected the attacker to take the system, got %+v", w.territory)
+	}
+	if len(w.manager.GetFactionTerritories(w.owner.ID)) != 0 || len(w.manager.GetFactionTerritories(w.attacker.ID)) != 1 {
+		t.Error("Expected the system to move to the attacker's territories")
+	}
+	if saved := w.store.saved[systemID]; saved.FactionID != w.attacker.ID {
+		t.Error("Expected the transfer to be saved")
+	}
+}
+
+func TestFailedContestRestoresControl(t *testing.T) {
+	w := newTestWorld(t)
+	systemID := w.territory.SystemID
+	w.territory.ControlLevel = models.ControlLevelStrong
+	w.territory.DefenseLevel = 1
+
+	w.factions.AddFunds(w.attacker.ID, ContestFee)
+	if _, err := w.manager.ContestSystem(systemID, w.attacker.ID, w.attacker.LeaderID); err != nil {
+		t.Fatalf("ContestSystem failed: %v", err)
+	}
+	w.manager.RecordKill(systemID, w.attacker.ID)
+	w.manager.Update(w.territory.Contest.EndsAt)
+
+	// One kill is not enough to beat the owner's defense bonus in the siege
+	w.manager.RecordKill(systemID, w.attacker.ID)
+	w.manager.Update(w.territory.Contest.EndsAt)
+	if w.territory.FactionID != w.owner.ID || w.territory.ControlLevel != models.ControlLevelStrong || w.territory.Contest != nil {
+		t.Fatalf("Expected the owner to hold at strong control, got %+v", w.territory)
+	}
+
+	w.factions.AddFunds(w.attacker.ID, ContestFee)
+	if _, err := w.manager.ContestSystem(systemID, w.attacker.ID, w.attacker.LeaderID); !errors.Is(err, ErrContestCooldown) {
+		t.Errorf("Expected ErrContestCooldown right after a siege, got %v", err)
+	}
+}
+
+func TestFailedSavesLeaveTerritoryUnchanged(t *testing.T) {
+	w := newTestWorld(t)
+	systemID := w.territory.SystemID
+	w.store.failWith = errors.New("database unavailable")
+
+	if _, err := w.manager.ClaimSystem(uuid.New(), "Vega", w.owner.ID, w.owner.Tag); err == nil {
+		t.Error("Expected ClaimSystem to fail with a failing store")
+	}
+	if len(w.manager.GetFactionTerritories(w.owner.ID)) != 1 {
+		t.Error("Expected the unsaved claim not to be added")
+	}
+
+	w.factions.AddFunds(w.attacker.ID, ContestFee)
+	if _, err := w.manager.ContestSystem(systemID, w.attacker.ID, w.attacker.LeaderID); err == nil {
+		t.Fatal("Expected ContestSystem to fail with a failing store")
+	}
+	if w.attacker.Treasury != ContestFee || w.territory.Contest != nil || len(w.warnings.recipients) != 0 {
+		t.Fatalf("Expected the fee refunded and no contest, got treasury %d, contest %+v", w.attacker.Treasury, w.territory.Contest)
+	}
+
+	// An outcome that cannot be saved is resolved on a later update
+	w.store.failWith = nil
+	if _, err := w.manager.ContestSystem(systemID, w.attacker.ID, w.attacker.LeaderID); err != nil {
+		t.Fatalf("ContestSystem failed: %v", err)
+	}
+	w.manager.RecordKill(systemID, w.attacker.ID)
+	ends := w.territory.Contest.EndsAt
+	w.store.failWith = errors.New("database unavailable")
+	w.manager.Update(ends)
+	if w.territory.Contest.Phase != models.ContestPhaseContest {
+		t.Fatalf("Expected the contest to stay unresolved, got phase %s", w.territory.Contest.Phase)
+	}
+
+	w.store.failWith = nil
+	w.manager.Update(ends)
+	if w.territory.Contest == nil || w.territory.Contest.Phase != models.ContestPhaseSiege {
+		t.Fatalf("Expected a siege once the store recovers, got %+v", w.territory.Contest)
+	}
+	if saved := w.store.saved[systemID]; saved.Contest == nil || saved.Contest.Phase != models.ContestPhaseSiege {
+		t.Error("Expected the siege to be saved")
+	}
+}
+
+func TestLoadRestoresTerritories(t *testing.T) {
+	w := newTestWorld(t)
+
+	reloaded := NewManager()
+	reloaded.SetStore(w.store)
+	if err := reloaded.Load(context.Background()); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if !reloaded.IsSystemClaimed(w.territory.SystemID) || len(reloaded.GetFactionTerritories(w.owner.ID)) != 1 {
+		t.Error("Expected the claim to be restored from the store")
+	}
+}
@@ -0,0 +1,34 @@
+// File: internal/territory/store.go
+// Project: Terminal Velocity
+// Description: Persistence and notification interfaces for the territory simulation
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package territory
+
+import (
+	"context"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/google/uuid"
+)
+
+// Store persists territory claims so they survive restarts. The database
+// package's TerritoryRepository implements it.
+type Store interface {
+	// SaveTerritory inserts or replaces the claim on a territory's system
+	SaveTerritory(ctx context.Context, t *models.Territory) error
+
+	// DeleteTerritory removes the claim on a system
+	DeleteTerritory(ctx context.Context, systemID uuid.UUID) error
+
+	// ListTerritories returns every claimed system
+	ListTerritories(ctx context.Context) ([]*models.Territory, error)
+}
+
+// Notifier warns faction leadership that their territory is under attack.
+// The notifications package's Manager implements it.
+type Notifier interface {
+	NotifyTerritoryAttack(ctx context.Context, factionLeaderID, attackerFactionID uuid.UUID, attackerFactionName, systemName string) error
+}
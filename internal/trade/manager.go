@@ -33,6 +33,9 @@ type Manager struct {
 	byPlayer map[uuid.UUID][]*models.TradeOffer // Player ID -> Offers (sent or received)
 	escrow   map[uuid.UUID]*models.TradeEscrow  // Trade ID -> Escrow
 	history  map[uuid.UUID]*models.TradeHistory // Player ID -> History
+
+	// Callback for real-time offer status delivery
+	onOfferChanged func(offer *models.TradeOffer)
 }
 
 // NewManager creates a new trade manager
@@ -45,6 +48,22 @@ func NewManager() *Manager {
 	}
 }
 
+// SetOfferChangedCallback sets the callback invoked whenever an offer is
+// created or changes status. The callback is invoked while the manager lock
+// is held and must not call back into the Manager.
+func (m *Manager) SetOfferChangedCallback(callback func(offer *models.TradeOffer)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onOfferChanged = callback
+}
+
+// notify reports an offer change. Caller must hold m.mu.
+func (m *Manager) notify(offer *models.TradeOffer) {
+	if m.onOfferChanged != nil {
+		m.onOfferChanged(offer)
+	}
+}
+
 // CreateOffer creates a new trade offer
 func (m *Manager) CreateOffer(
 	initiatorID uuid.UUID,
@@ -70,6 +89,8 @@ func (m *Manager) CreateOffer(
 	m.byPlayer[initiatorID] = append(m.byPlayer[initiatorID], offer)
 	m.byPlayer[recipientID] = append(m.byPlayer[recipientID], offer)
 
+	m.notify(offer)
+
 	return offer
 }
 
@@ -191,6 +212,7 @@ func (m *Manager) AcceptOffer(tradeID uuid.UUID, playerID uuid.UUID) error {
 	escrow.LockRecipientAssets(offer.RequestedCredits, recipientItems)
 
 	m.escrow[tradeID] = escrow
+	m.notify(offer)
 
 	return nil
 }
@@ -220,6 +242,7 @@ func (m *Manager) RejectOffer(tradeID uuid.UUID, playerID uuid.UUID) error {
 	// Update history
 	m.ensureHistory(offer.InitiatorID)
 	m.history[offer.InitiatorID].RecordTrade(offer.GetTotalOfferedValue(), false)
+	m.notify(offer)
 
 	return nil
 }
@@ -255,6 +278,7 @@ func (m *Manager) CancelOffer(tradeID uuid.UUID, playerID uuid.UUID) error {
 	// Update history
 	m.ensureHistory(playerID)
 	m.history[playerID].RecordTrade(offer.GetTotalOfferedValue(), false)
+	m.notify(offer)
 
 	return nil
 }
@@ -287,6 +311,7 @@ func (m *Manager) CompleteTrade(tradeID uuid.UUID) error {
 	tradeValue := offer.GetTotalOfferedValue()
 	m.history[offer.InitiatorID].RecordTrade(tradeValue, true)
 	m.history[offer.RecipientID].RecordTrade(tradeValue, true)
+	m.notify(offer)
 
 	return nil
 }
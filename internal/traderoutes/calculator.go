@@ -1,7 +1,7 @@
 // File: internal/traderoutes/calculator.go
 // Project: Terminal Velocity
 // Description: Trade route calculator and optimization
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-14
 
@@ -110,7 +110,7 @@ func (c *Calculator) FindBestRoutes(ctx context.Context, opts *RouteOptions) ([]
 			}
 
 			// Check all commodities
-			for _, commodity := range models.StandardCommodities {
+			for _, commodity := range models.AllCommodities() {
 				// Skip illegal goods if not included
 				if !opts.IncludeIllegal && commodity.IsIllegal(fromSystem.GovernmentID) {
 					continue
@@ -226,7 +226,7 @@ func (c *Calculator) FindRoutesBetween(ctx context.Context, fromID, toID uuid.UU
 	routes := make([]*TradeRoute, 0)
 
 	// Check all commodities
-	for _, commodity := range models.StandardCommodities {
+	for _, commodity := range models.AllCommodities() {
 		// Calculate estimated prices (without market data)
 		buyModifier := models.GetPriceModifier(commodity.TechLevel, fromSystem.TechLevel, false)
 		sellModifier := models.GetPriceModifier(commodity.TechLevel, toSystem.TechLevel, false)
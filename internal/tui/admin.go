@@ -1,7 +1,7 @@
 // File: internal/tui/admin.go
 // Project: Terminal Velocity
 // Description: Server administration panel with RBAC-controlled moderation tools
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 //
@@ -49,6 +49,7 @@ type adminModel struct {
 	cursor   int              // Current menu selection cursor position
 	isAdmin  bool             // Whether current player has admin access
 	role     models.AdminRole // Specific admin role (Owner, Admin, Moderator, Helper)
+	message  string           // Result of the last settings change
 }
 
 // newAdminModel creates a new admin panel model with default state
@@ -68,6 +69,8 @@ func newAdminModel() adminModel {
 //   - Enter/Space: Select menu item or perform action
 //   - Esc/Backspace: Return to main menu (from main view) or previous view
 //   - U: Unban player (when on ban list) or unmute player (when on mute list)
+//   - T: Toggle "require 2FA for admins" (settings view)
+//   - R: Reload the content pack file (settings view)
 //
 // Message Handling:
 //   - tea.KeyMsg: Navigation and selection
@@ -109,6 +112,29 @@ func (m Model) updateAdmin(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "enter", " ":
 			return m.handleAdminSelect()
+
+		case "t":
+			if m.adminModel.viewMode == adminViewSettings && m.adminManager != nil {
+				settings := m.adminManager.GetSettings()
+				settings.RequireAdmin2FA = !settings.RequireAdmin2FA
+				if err := m.adminManager.UpdateSettings(m.playerID, settings); err != nil {
+					m.adminModel.message = fmt.Sprintf("Failed to update settings: %v", err)
+				} else {
+					m.adminModel.message = "Require admin 2FA: " + boolToString(settings.RequireAdmin2FA)
+				}
+			}
+			return m, nil
+
+		case "r":
+			if m.adminModel.viewMode == adminViewSettings && m.adminManager != nil {
+				status, err := m.adminManager.ReloadContent(m.playerID)
+				if err != nil {
+					m.adminModel.message = fmt.Sprintf("Content reload failed: %v", err)
+				} else {
+					m.adminModel.message = fmt.Sprintf("Reloaded content pack %s %s", status.Name, status.Version)
+				}
+			}
+			return m, nil
 		}
 	}
 
@@ -135,6 +161,7 @@ func (m Model) handleAdminSelect() (tea.Model, tea.Cmd) {
 		if m.adminModel.cursor < len(views) {
 			m.adminModel.viewMode = views[m.adminModel.cursor]
 			m.adminModel.cursor = 0 // Reset cursor for new view
+			m.adminModel.message = ""
 		}
 	}
 
@@ -493,6 +520,7 @@ func (m Model) viewAdminMetrics() string {
 //   - General section: Server name, max players, tick rate
 //   - Economy section: Starting credits, multiplier, tax rate
 //   - Gameplay section: PvP, permadeath, combat difficulty, pirate frequency
+//   - Security section: Whether admins must use two-factor authentication
 //   - Note: "Settings editing coming soon"
 //   - Footer: Navigation instructions
 //
@@ -508,7 +536,9 @@ func (m Model) viewAdminMetrics() string {
 //   - Combat Difficulty: AI difficulty scaling (0.0 - 2.0)
 //   - Pirate Frequency: Probability of pirate encounters (0.0 - 1.0)
 //
-// Note: Currently read-only - editing functionality planned for future release
+// Note: Only "Require Admin 2FA" can be changed (T key, needs PermServerSettings);
+// editing the other settings is planned for a future release. The R key
+// hot-reloads the content pack file (also needs PermServerSettings).
 //
 // Data Source:
 //   - Fetches from adminManager.GetSettings()
@@ -540,9 +570,33 @@ func (m Model) viewAdminSettings() string {
 	s += fmt.Sprintf("  Permadeath:       %s\n", boolToString(settings.PermadeathMode))
 	s += fmt.Sprintf("  Combat Difficulty: %s\n", statsStyle.Render(fmt.Sprintf("%.2f", settings.CombatDifficulty)))
 	s += fmt.Sprintf("  Pirate Frequency:  %s%%\n", statsStyle.Render(fmt.Sprintf("%.0f", settings.PirateFrequency*100)))
+	s += "\n"
 
-	s += "\n" + helpStyle.Render("(Settings editing coming soon)") + "\n"
-	s += "\n" + renderFooter("ESC: Back")
+	s += "Security:\n"
+	s += fmt.Sprintf("  Require Admin 2FA: %s\n", boolToString(settings.RequireAdmin2FA))
+
+	if status, ok := m.adminManager.ContentStatus(); ok {
+		source := status.Path
+		if source == "" {
+			source = "built-in"
+		}
+		s += "\n"
+		s += "Content Pack:\n"
+		s += fmt.Sprintf("  Pack:    %s %s (%s)\n", status.Name, status.Version, source)
+		s += fmt.Sprintf("  Content: %s ship types, %s commodities, %s weapons, %s outfits, %s equipment\n",
+			statsStyle.Render(fmt.Sprintf("%d", status.ShipTypes)),
+			statsStyle.Render(fmt.Sprintf("%d", status.Commodities)),
+			statsStyle.Render(fmt.Sprintf("%d", status.Weapons)),
+			statsStyle.Render(fmt.Sprintf("%d", status.Outfits)),
+			statsStyle.Render(fmt.Sprintf("%d", status.Equipment)))
+		s += fmt.Sprintf("  Loaded:  %s\n", status.LoadedAt.Format("2006-01-02 15:04:05"))
+	}
+
+	if m.adminModel.message != "" {
+		s += "\n" + statsStyle.Render(m.adminModel.message) + "\n"
+	}
+	s += "\n" + helpStyle.Render("(Editing other settings coming soon)") + "\n"
+	s += "\n" + renderFooter("T: Toggle admin 2FA  •  R: Reload content  •  ESC: Back")
 	return s
 }
 
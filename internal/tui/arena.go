@@ -0,0 +1,572 @@
+// File: internal/tui/arena.go
+// Project: Terminal Velocity
+// Description: Arena screen - ranked queue, live matches, tournaments, history and leaderboard
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// The arena screen has five tabs:
+// - Ranked: The player's rating and season, and the matchmaking queue
+// - Live: Matches being fought right now, open to spectators
+// - Tournaments: Scheduled, running and recent tournaments
+// - History: The player's finished matches with rating changes
+// - Leaderboard: Top ranked players this season
+//
+// Matches are fought on the server with each player's own ship. Watching a
+// match (your own or as a spectator) shows every ship's hull and shields
+// and the battle log, refreshed every second.
+
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/arena"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+// Arena tabs, in the order tab cycles through them
+const (
+	arenaTabRanked      = "ranked"      // Rating and matchmaking queue
+	arenaTabLive        = "live"        // Matches open to spectators
+	arenaTabTournaments = "tournaments" // Tournament registration and brackets
+	arenaTabHistory     = "history"     // Finished matches
+	arenaTabLeaderboard = "leaderboard" // Top ranked players
+)
+
+// arenaTabs lists the tabs in display order
+var arenaTabs = []string{
+	arenaTabRanked,
+	arenaTabLive,
+	arenaTabTournaments,
+	arenaTabHistory,
+	arenaTabLeaderboard,
+}
+
+// arenaRefreshInterval is how often the arena screen redraws live state
+const arenaRefreshInterval = time.Second
+
+// arenaModel contains the state for the arena screen
+type arenaModel struct {
+	tab        string    // Current tab
+	cursor     int       // Selected row in the current tab
+	watching   uuid.UUID // Match being watched (uuid.Nil if none)
+	spectating bool      // Watching as a spectator rather than a participant
+	tickID     int       // Identifies the current refresh loop
+	message    string    // Result of the last action
+}
+
+// arenaActionMsg reports the result of an arena action
+type arenaActionMsg struct {
+	message  string
+	err      error
+	watching uuid.UUID // Match to start watching as a spectator, if any
+}
+
+// arenaTickMsg redraws the arena screen while it is open
+type arenaTickMsg struct {
+	id int
+}
+
+// newArenaModel creates an arena screen model on the ranked tab
+func newArenaModel() arenaModel {
+	return arenaModel{tab: arenaTabRanked}
+}
+
+// arenaTick schedules the next redraw of the arena screen
+func arenaTick(id int) tea.Cmd {
+	return tea.Tick(arenaRefreshInterval, func(time.Time) tea.Msg {
+		return arenaTickMsg{id: id}
+	})
+}
+
+// updateArena handles input for the arena screen.
+//
+// Key Bindings (all tabs):
+//   - esc/backspace/q: Stop watching, or return to the main menu
+//   - tab: Next tab
+//   - up/k, down/j: Select a row
+//
+// Key Bindings (per tab):
+//   - Ranked: 1 queues for a duel, 2 for team deathmatch, x leaves the
+//     queue, enter watches your match
+//   - Live: enter spectates the selected match
+//   - Tournaments: enter registers for the selected tournament
+func (m Model) updateArena(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case arenaTickMsg:
+		// Only the latest refresh loop keeps running
+		if msg.id != m.arenaModel.tickID || m.screen != ScreenArena {
+			return m, nil
+		}
+		return m, arenaTick(msg.id)
+
+	case arenaActionMsg:
+		if msg.err != nil {
+			m.arenaModel.message = errorStyle.Render(fmt.Sprintf("Failed: %v", msg.err))
+		} else {
+			m.arenaModel.message = successStyle.Render(msg.message)
+		}
+		if msg.watching != uuid.Nil {
+			m.arenaModel.watching = msg.watching
+			m.arenaModel.spectating = true
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.arenaModel.watching != uuid.Nil {
+			return m.updateArenaWatch(msg)
+		}
+
+		switch msg.String() {
+		case "esc", "backspace", "q":
+			m.screen = ScreenMainMenu
+			return m, tea.ClearScreen
+
+		case "tab":
+			for i, tab := range arenaTabs {
+				if tab == m.arenaModel.tab {
+					m.arenaModel.tab = arenaTabs[(i+1)%len(arenaTabs)]
+					break
+				}
+			}
+			m.arenaModel.cursor = 0
+			m.arenaModel.message = ""
+			return m, nil
+
+		case "up", "k":
+			if m.arenaModel.cursor > 0 {
+				m.arenaModel.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.arenaModel.cursor < m.arenaRows()-1 {
+				m.arenaModel.cursor++
+			}
+			return m, nil
+		}
+
+		switch m.arenaModel.tab {
+		case arenaTabRanked:
+			return m.updateArenaRanked(msg)
+		case arenaTabLive:
+			return m.updateArenaLive(msg)
+		case arenaTabTournaments:
+			return m.updateArenaTournaments(msg)
+		}
+	}
+
+	return m, nil
+}
+
+// updateArenaWatch handles keys while watching a match
+func (m Model) updateArenaWatch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "backspace", "q":
+		matchID := m.arenaModel.watching
+		spectating := m.arenaModel.spectating
+		m.arenaModel.watching = uuid.Nil
+		m.arenaModel.spectating = false
+		m.arenaModel.message = ""
+		if spectating {
+			return m, func() tea.Msg {
+				// The match may have ended, which removes its spectators
+				_ = m.arenaManager.LeaveSpectator(context.Background(), matchID, m.playerID)
+				return nil
+			}
+		}
+	}
+	return m, nil
+}
+
+// updateArenaRanked handles ranked tab keys
+func (m Model) updateArenaRanked(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "1", "2":
+		matchType := arena.MatchTypeDuel
+		if msg.String() == "2" {
+			matchType = arena.MatchTypeTeamDeathmatch
+		}
+		return m, func() tea.Msg {
+			if err := m.arenaManager.QueueForMatch(context.Background(), m.playerID, matchType); err != nil {
+				return arenaActionMsg{err: err}
+			}
+			return arenaActionMsg{message: fmt.Sprintf("Queued for %s", arenaMatchTypeName(matchType))}
+		}
+
+	case "x":
+		entry := m.arenaManager.GetQueueEntry(m.playerID)
+		if entry == nil {
+			m.arenaModel.message = errorStyle.Render("You are not in a queue")
+			return m, nil
+		}
+		if err := m.arenaManager.LeaveQueue(m.playerID, entry.MatchType); err != nil {
+			m.arenaModel.message = errorStyle.Render(fmt.Sprintf("Failed: %v", err))
+		} else {
+			m.arenaModel.message = successStyle.Render("Left the queue")
+		}
+
+	case "enter", " ":
+		match := m.arenaManager.GetActiveMatch(m.playerID)
+		if match == nil || match.Status != models.MatchInProgress {
+			m.arenaModel.message = errorStyle.Render("You have no match being fought")
+			return m, nil
+		}
+		m.arenaModel.watching = match.ID
+		m.arenaModel.spectating = false
+		m.arenaModel.message = ""
+	}
+	return m, nil
+}
+
+// updateArenaLive handles live tab keys
+func (m Model) updateArenaLive(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	matches := m.arenaManager.GetActiveMatches()
+	if (msg.String() == "enter" || msg.String() == " ") && m.arenaModel.cursor < len(matches) {
+		match := matches[m.arenaModel.cursor]
+		if match.HasPlayer(m.playerID) {
+			m.arenaModel.watching = match.ID
+			m.arenaModel.spectating = false
+			return m, nil
+		}
+		return m, func() tea.Msg {
+			if err := m.arenaManager.JoinAsSpectator(context.Background(), match.ID, m.playerID); err != nil {
+				return arenaActionMsg{err: err}
+			}
+			return arenaActionMsg{watching: match.ID}
+		}
+	}
+	return m, nil
+}
+
+// updateArenaTournaments handles tournament tab keys
+func (m Model) updateArenaTournaments(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	tournaments := m.arenaManager.GetTournaments()
+	if (msg.String() == "enter" || msg.String() == " ") && m.arenaModel.cursor < len(tournaments) {
+		tournament := tournaments[m.arenaModel.cursor]
+		return m, func() tea.Msg {
+			if err := m.arenaManager.RegisterForTournament(context.Background(), tournament.ID, m.playerID); err != nil {
+				return arenaActionMsg{err: err}
+			}
+			return arenaActionMsg{message: fmt.Sprintf("Registered for %s (%s entry fee paid)",
+				tournament.Name, formatCredits(tournament.EntryFee))}
+		}
+	}
+	return m, nil
+}
+
+// arenaRows returns the number of selectable rows in the current tab
+func (m Model) arenaRows() int {
+	if m.arenaManager == nil {
+		return 0
+	}
+	switch m.arenaModel.tab {
+	case arenaTabLive:
+		return len(m.arenaManager.GetActiveMatches())
+	case arenaTabTournaments:
+		return len(m.arenaManager.GetTournaments())
+	default:
+		return 0
+	}
+}
+
+// arenaPlayerName returns a player's name as known to the arena
+func (m Model) arenaPlayerName(playerID uuid.UUID) string {
+	if playerID == m.playerID {
+		return m.username
+	}
+	if name := m.arenaManager.GetPlayerRanking(playerID).PlayerName; name != "" {
+		return name
+	}
+	return playerID.String()[:8]
+}
+
+// arenaMatchTypeName returns a match type's display name
+func arenaMatchTypeName(matchType arena.MatchType) string {
+	switch matchType {
+	case arena.MatchTypeDuel:
+		return "Duel"
+	case arena.MatchTypeTeamDeathmatch:
+		return "Team Deathmatch"
+	default:
+		return string(matchType)
+	}
+}
+
+// arenaSides renders a match's players as "red vs blue"
+func (m Model) arenaSides(match *arena.Match) string {
+	sides := make([]string, 0, 2)
+	for _, team := range []string{"red", "blue"} {
+		names := make([]string, 0, len(match.Teams[team]))
+		for _, playerID := range match.Teams[team] {
+			names = append(names, m.arenaPlayerName(playerID))
+		}
+		sides = append(sides, strings.Join(names, " & "))
+	}
+	return strings.Join(sides, " vs ")
+}
+
+// viewArena renders the arena screen.
+//
+// Layout:
+//   - Header: Season and the player's rank
+//   - Tab bar
+//   - Current tab's list
+//   - Footer with controls
+//
+// While watching a match the whole screen shows the match instead.
+func (m Model) viewArena() string {
+	if m.arenaManager == nil {
+		return "The arena is unavailable\n\n" + renderFooter("ESC: Back")
+	}
+	if m.arenaModel.watching != uuid.Nil {
+		return m.viewArenaWatch()
+	}
+
+	season := m.arenaManager.GetCurrentSeason()
+	ranking := m.arenaManager.GetPlayerRanking(m.playerID)
+
+	s := titleStyle.Render("⚔ ARENA") + "\n\n"
+	s += fmt.Sprintf("Season %d - ends in %s | Rank: %s | ELO: %d\n\n",
+		season.Number, formatDuration(time.Until(season.EndTime)),
+		highlightStyle.Render(fmt.Sprintf("%s %d", ranking.Tier, ranking.Division)), ranking.ELO)
+
+	tabs := make([]string, 0, len(arenaTabs))
+	for _, tab := range arenaTabs {
+		label := strings.ToUpper(tab[:1]) + tab[1:]
+		if tab == m.arenaModel.tab {
+			label = highlightStyle.Render("[" + label + "]")
+		}
+		tabs = append(tabs, label)
+	}
+	s += strings.Join(tabs, "  ") + "\n\n"
+
+	footer := "Tab: Next Tab | ESC: Back"
+	switch m.arenaModel.tab {
+	case arenaTabRanked:
+		s += m.viewArenaRanked(ranking)
+		footer = "1: Queue Duel | 2: Queue Team Deathmatch | X: Leave Queue | Enter: Watch Match | " + footer
+	case arenaTabLive:
+		s += m.viewArenaLive()
+		footer = "Enter: Spectate | " + footer
+	case arenaTabTournaments:
+		s += m.viewArenaTournaments()
+		footer = "Enter: Register | " + footer
+	case arenaTabHistory:
+		s += m.viewArenaHistory()
+	default:
+		s += m.viewArenaLeaderboard()
+	}
+
+	if m.arenaModel.message != "" {
+		s += "\n" + m.arenaModel.message + "\n"
+	}
+
+	s += "\n" + renderFooter(footer)
+	return s
+}
+
+// viewArenaRanked renders the player's record and queue or match status
+func (m Model) viewArenaRanked(ranking *arena.PlayerRanking) string {
+	s := fmt.Sprintf("Record: %d W / %d L / %d D | Win Streak: %d | Highest ELO: %d | Tournaments Won: %d\n",
+		ranking.Wins, ranking.Losses, ranking.Draws, ranking.WinStreak, ranking.HighestELO, ranking.TournamentsWon)
+	s += fmt.Sprintf("Matches this season: %d\n\n", ranking.SeasonMatches)
+
+	if match := m.arenaManager.GetActiveMatch(m.playerID); match != nil {
+		if match.Status == models.MatchInProgress {
+			s += successStyle.Render(fmt.Sprintf("%s in progress: %s", arenaMatchTypeName(match.Type), m.arenaSides(match))) + "\n"
+		} else {
+			s += highlightStyle.Render(fmt.Sprintf("%s waiting for a free arena: %s", arenaMatchTypeName(match.Type), m.arenaSides(match))) + "\n"
+		}
+		return s
+	}
+
+	if entry := m.arenaManager.GetQueueEntry(m.playerID); entry != nil {
+		s += highlightStyle.Render(fmt.Sprintf("Searching for a %s opponent... (%s)",
+			arenaMatchTypeName(entry.MatchType), time.Since(entry.QueueTime).Round(time.Second))) + "\n"
+		return s
+	}
+
+	s += "Queue for a ranked match. You fight with your current ship, repaired to full;\n"
+	s += "it flies itself, so pick your loadout well.\n"
+	return s
+}
+
+// viewArenaLive renders the matches being fought
+func (m Model) viewArenaLive() string {
+	matches := m.arenaManager.GetActiveMatches()
+	if len(matches) == 0 {
+		return helpStyle.Render("No matches are being fought") + "\n"
+	}
+
+	s := ""
+	for i, match := range matches {
+		cursor := "  "
+		if i == m.arenaModel.cursor {
+			cursor = "> "
+		}
+		spectators := len(m.arenaManager.GetSpectators(match.ID))
+		s += fmt.Sprintf("%s%-16s %s  (%s, %d watching)\n", cursor, arenaMatchTypeName(match.Type),
+			m.arenaSides(match), time.Since(match.StartTime).Round(time.Second), spectators)
+	}
+	return s
+}
+
+// viewArenaTournaments renders tournaments and, for the selected one, its
+// bracket progress
+func (m Model) viewArenaTournaments() string {
+	tournaments := m.arenaManager.GetTournaments()
+	if len(tournaments) == 0 {
+		return helpStyle.Render("No tournaments scheduled") + "\n"
+	}
+
+	s := ""
+	for i, tournament := range tournaments {
+		cursor := "  "
+		if i == m.arenaModel.cursor {
+			cursor = "> "
+		}
+		status := ""
+		switch tournament.Status {
+		case models.TournamentRegistration:
+			status = fmt.Sprintf("starts in %s", formatDuration(time.Until(tournament.StartTime)))
+		case models.TournamentInProgress:
+			status = successStyle.Render(fmt.Sprintf("round %d of %d", tournament.CurrentRound, len(tournament.Bracket.Rounds)))
+		case models.TournamentCompleted:
+			status = "won by " + m.arenaPlayerName(tournament.Winners[0])
+		default:
+			status = helpStyle.Render(tournament.Status)
+		}
+		entered := ""
+		if tournament.HasParticipant(m.playerID) {
+			entered = highlightStyle.Render(" [entered]")
+		}
+		s += fmt.Sprintf("%s%-28s %2d/%-2d  fee %s  pool %s  %s%s\n", cursor, tournament.Name,
+			len(tournament.Participants), tournament.MaxPlayers, formatCredits(tournament.EntryFee),
+			formatCredits(tournament.PrizePool), status, entered)
+	}
+
+	if m.arenaModel.cursor < len(tournaments) {
+		tournament := tournaments[m.arenaModel.cursor]
+		if tournament.Status == models.TournamentCompleted {
+			s += "\nPlacings:\n"
+			for place, playerID := range tournament.Winners {
+				s += fmt.Sprintf("  %d. %s\n", place+1, m.arenaPlayerName(playerID))
+			}
+		}
+	}
+	return s
+}
+
+// viewArenaHistory renders the player's recent matches
+func (m Model) viewArenaHistory() string {
+	history := m.arenaManager.GetMatchHistory(m.playerID, 15)
+	if len(history) == 0 {
+		return helpStyle.Render("You have not played any arena matches") + "\n"
+	}
+
+	s := ""
+	for _, match := range history {
+		result := helpStyle.Render("DRAW")
+		switch {
+		case match.Won(m.playerID):
+			result = successStyle.Render("WIN ")
+		case match.Winner != uuid.Nil:
+			result = errorStyle.Render("LOSS")
+		}
+		change := match.RatingChanges[m.playerID]
+		s += fmt.Sprintf("%s %+4d  %-16s %s  %s\n", result, change, arenaMatchTypeName(match.Type),
+			m.arenaSides(match), match.EndTime.Format("Jan 2 15:04"))
+	}
+	return s
+}
+
+// viewArenaLeaderboard renders the top ranked players
+func (m Model) viewArenaLeaderboard() string {
+	leaders := m.arenaManager.GetLeaderboard(20)
+	if len(leaders) == 0 {
+		return helpStyle.Render("No ranked matches have been played yet") + "\n"
+	}
+
+	s := fmt.Sprintf("  %-4s %-20s %-16s %5s  %s\n", "#", "Pilot", "Rank", "ELO", "W/L/D")
+	for i, ranking := range leaders {
+		line := fmt.Sprintf("  %-4d %-20s %-16s %5d  %d/%d/%d", i+1, ranking.PlayerName,
+			fmt.Sprintf("%s %d", ranking.Tier, ranking.Division), ranking.ELO,
+			ranking.Wins, ranking.Losses, ranking.Draws)
+		if ranking.PlayerID == m.playerID {
+			line = highlightStyle.Render(line)
+		}
+		s += line + "\n"
+	}
+	return s
+}
+
+// viewArenaWatch renders a live match: each team's ships with hull and
+// shields, then the battle log
+func (m Model) viewArenaWatch() string {
+	view, err := m.arenaManager.GetMatchView(m.arenaModel.watching)
+	if err != nil {
+		return errorStyle.Render(fmt.Sprintf("Match unavailable: %v", err)) + "\n\n" + renderFooter("ESC: Back")
+	}
+	match := view.Match
+
+	s := titleStyle.Render(fmt.Sprintf("⚔ %s - %s", strings.ToUpper(arenaMatchTypeName(match.Type)), view.ArenaName)) + "\n\n"
+
+	if match.Status != models.MatchInProgress {
+		switch {
+		case match.Status == models.MatchCancelled:
+			s += helpStyle.Render("The match was cancelled") + "\n"
+		case match.Winner == uuid.Nil:
+			s += highlightStyle.Render("The match ended in a draw") + "\n"
+		default:
+			s += successStyle.Render(fmt.Sprintf("%s wins!", m.arenaPlayerName(match.Winner))) + "\n"
+		}
+		if change, played := match.RatingChanges[m.playerID]; played {
+			s += fmt.Sprintf("Your rating change: %+d\n", change)
+		}
+		s += "\n" + renderFooter("ESC: Back")
+		return s
+	}
+
+	s += fmt.Sprintf("Turn %d", view.Turn)
+	if m.arenaModel.spectating {
+		s += helpStyle.Render(fmt.Sprintf("  (spectating with %d others)", len(m.arenaManager.GetSpectators(match.ID))-1))
+	}
+	s += "\n\n"
+
+	for _, team := range []string{"red", "blue"} {
+		s += highlightStyle.Render(strings.ToUpper(team)) + "\n"
+		for _, fighter := range view.Fighters {
+			if fighter.Team != team {
+				continue
+			}
+			name := fmt.Sprintf("%s (%s)", fighter.PlayerName, fighter.ShipName)
+			if !fighter.Active {
+				s += helpStyle.Render(fmt.Sprintf("  %-30s out of the fight", name)) + "\n"
+				continue
+			}
+			s += fmt.Sprintf("  %-30s Hull %s %d/%d  Shields %s %d/%d  Dmg %d\n", name,
+				m.renderStatusBar(fighter.Hull, fighter.MaxHull, 10, "█", "░"), fighter.Hull, fighter.MaxHull,
+				m.renderStatusBar(fighter.Shields, fighter.MaxShields, 10, "█", "░"), fighter.Shields, fighter.MaxShields,
+				match.Scores[fighter.PlayerID])
+		}
+		s += "\n"
+	}
+
+	lines := view.Log
+	if len(lines) > 10 {
+		lines = lines[len(lines)-10:]
+	}
+	for _, line := range lines {
+		s += helpStyle.Render(line) + "\n"
+	}
+
+	s += "\n" + renderFooter("ESC: Stop Watching")
+	return s
+}
@@ -1,7 +1,7 @@
 // File: internal/tui/chat.go
 // Project: Terminal Velocity
 // Description: Chat screen - Multiplayer communication across multiple channels with commands
-// Version: 1.2.0
+// Version: 1.3.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 //
@@ -409,6 +409,10 @@ func (m *Model) sendChatMessage() {
 		return
 	}
 
+	if m.showMuteNotice(m.chatModel.currentChannel) {
+		return
+	}
+
 	// Send based on current channel
 	switch m.chatModel.currentChannel {
 	case models.ChatChannelGlobal:
@@ -436,8 +440,8 @@ func (m *Model) sendChatMessage() {
 			return
 		}
 
-		// Send message to all faction members
-		m.chatManager.SendFactionMessage(faction.ID.String(), m.playerID, m.username, content, faction.Members)
+		// Send message to all faction members and allied factions
+		m.chatManager.SendFactionMessage(faction.ID.String(), m.playerID, m.username, content, m.factionChatRecipients(faction))
 
 	case models.ChatChannelDirect:
 		if m.chatModel.dmRecipient != "" {
@@ -462,6 +466,23 @@ func (m *Model) sendChatMessage() {
 	}
 }
 
+// showMuteNotice tells a muted player why their message was not sent.
+// Returns true if the player is muted.
+func (m *Model) showMuteNotice(channel models.ChatChannel) bool {
+	if m.adminManager == nil {
+		return false
+	}
+	mute := m.adminManager.GetMute(m.playerID)
+	if mute == nil {
+		return false
+	}
+
+	notice := fmt.Sprintf("You are muted until %s (%s).",
+		mute.ExpiresAt.Format("2006-01-02 15:04"), mute.Reason)
+	m.chatManager.GetOrCreateHistory(m.playerID).AddMessage(models.NewSystemMessage(channel, notice))
+	return true
+}
+
 // handleChatCommand processes chat slash commands.
 // Supported commands: /help, /dm, /clear, /me
 // Unknown commands show error message in chat.
@@ -493,6 +514,10 @@ func (m *Model) handleChatCommand(command string) {
 			return
 		}
 
+		if m.showMuteNotice(models.ChatChannelDirect) {
+			return
+		}
+
 		recipientUsername := parts[1]
 		content := strings.Join(parts[2:], " ")
 
@@ -518,7 +543,7 @@ func (m *Model) handleChatCommand(command string) {
 		m.chatModel.scrollOffset = 0
 
 	case "/me":
-		if len(parts) < 2 {
+		if len(parts) < 2 || m.showMuteNotice(m.chatModel.currentChannel) {
 			return
 		}
 
@@ -1,7 +1,7 @@
 // File: internal/tui/combat.go
 // Project: Terminal Velocity
 // Description: Combat screen - Turn-based space combat interface
-// Version: 1.3.0
+// Version: 1.8.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 //
@@ -14,6 +14,7 @@
 // - Shield and hull damage system with regeneration
 // - Weapon states: cooldowns, ammo, accuracy, range
 // - Victory/defeat handling with rewards and penalties
+// - Hired escorts fight alongside the player under their fleet orders
 //
 // Combat Mechanics:
 // - Player acts first, then all enemies take turns
@@ -23,6 +24,7 @@
 // - Accuracy affected by range, weapon type, and AI difficulty
 // - Enemy AI uses tactical decisions (fire, retreat, evade)
 // - Defeat: 10% credits penalty, ship restored to 10% hull
+// - Rules are resolved by combat.Engine; this screen renders its event log
 // - Victory: Loot drops, kill count, combat rating, achievements
 //
 // Combat Flow:
@@ -31,17 +33,39 @@
 // - Turn counter advances
 // - Victory when all enemies destroyed
 // - Defeat when player ship hull reaches 0
+//
+// Escorts:
+// - Every active escort joins the battle as an AI ally, at an AI level set
+//   by its pilot's skill
+// - Its fleet orders decide when it fires: attack engages every enemy,
+//   defend only enemies that have opened fire, hold (and passive escorts)
+//   never fire
+// - Orders can be changed mid-battle and take effect on the next enemy turn
+// - Escorts destroyed in battle are lost from the fleet; survivors keep the
+//   damage they took
+// - If the player's ship is destroyed, surviving escorts withdraw
+//
+// Boarding:
+// - A target whose hull and shields have been shot down is disabled and can
+//   be boarded, which takes the player's turn
+// - The capture manager resolves the boarding from both ships' crews; the
+//   boarding and any capture are shown in the combat log
+// - A captured NPC ship leaves the battle and joins the player's fleet
+// - Another player's ship can only be boarded under the PvP rules
 
 package tui
 
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strings"
+	"time"
 
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/combat"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
 )
 
 // combatModel contains the state for the combat screen.
@@ -51,13 +75,12 @@ type combatModel struct {
 	mode string // Current combat mode (tactical view, weapon selection, target selection)
 
 	// Combat state
-	playerShip   *models.Ship                    // Player's ship in combat
-	playerType   *models.ShipType                // Player's ship type for stats
-	enemyShips   []*models.Ship                  // All enemy ships in combat
-	enemyTypes   map[string]*models.ShipType     // Enemy ship types by type ID
-	enemyAI      map[string]*combat.AIState      // AI states for each enemy (keyed by ship ID)
-	allyShips    []*models.Ship                  // Allied ships (future feature)
-	weaponStates []*combat.WeaponState           // Weapon cooldowns and ammo tracking
+	engine     *combat.Engine              // Battle rules, AI and event log
+	playerShip *models.Ship                // Player's ship in combat
+	playerType *models.ShipType            // Player's ship type for stats
+	enemyShips []*models.Ship              // Enemy ships still in the battle
+	enemyTypes map[string]*models.ShipType // Enemy ship types by type ID
+	escorts    map[string]uuid.UUID        // Escort combatant ID -> fleet escort ID, until the escort's fate is recorded
 
 	// UI state
 	selectedTarget int      // Currently selected enemy target (index in enemyShips)
@@ -95,10 +118,101 @@ func newCombatModel() combatModel {
 		turnNumber:   1,
 		playerTurn:   true,
 		loading:      false,
-		enemyAI:      make(map[string]*combat.AIState),
 	}
 }
 
+// startCombat switches to the combat screen for a battle between the
+// player's current ship, with their escorts, and the given enemies. Enemies
+// and escorts are AI controlled.
+func (m *Model) startCombat(enemies []*models.Ship) {
+	m.combat = newCombatModel()
+	m.combat.engine = combat.NewEngine(time.Now().UnixNano())
+	m.combat.playerShip = m.currentShip
+	m.combat.playerType = models.GetShipTypeByID(m.currentShip.TypeID)
+	m.combat.enemyTypes = make(map[string]*models.ShipType)
+	m.combat.escorts = make(map[string]uuid.UUID)
+
+	if _, err := m.combat.engine.AddCombatant(m.combat.playerShip, m.combat.playerType, combat.SidePlayer, nil); err != nil {
+		m.combat.error = fmt.Sprintf("Failed to start combat: %v", err)
+	}
+	if m.fleetManager != nil {
+		for _, escort := range m.fleetManager.ActiveEscorts(m.playerID) {
+			// The player may have hired an escort for the ship they are flying
+			if escort.Ship.ID == m.combat.playerShip.ID {
+				continue
+			}
+			shipType := models.GetShipTypeByID(escort.Ship.TypeID)
+			if _, err := m.combat.engine.AddCombatant(escort.Ship, shipType, combat.SidePlayer, combat.NewAIState(escortAILevel(escort.Level))); err != nil {
+				continue
+			}
+			id := escort.Ship.ID.String()
+			_ = m.combat.engine.SetStance(id, escortStance(escort.CombatOrder()))
+			m.combat.escorts[id] = escort.ID
+		}
+		if len(m.combat.escorts) > 0 {
+			m.addCombatLog(fmt.Sprintf("%d escort(s) fly in formation with you", len(m.combat.escorts)))
+		}
+	}
+	for _, ship := range enemies {
+		shipType := models.GetShipTypeByID(ship.TypeID)
+		if _, err := m.combat.engine.AddCombatant(ship, shipType, combat.SideEnemy, combat.NewAIState(combat.AILevelMedium)); err != nil {
+			continue
+		}
+		m.combat.enemyShips = append(m.combat.enemyShips, ship)
+		m.combat.enemyTypes[ship.TypeID] = shipType
+	}
+
+	m.screen = ScreenCombat
+}
+
+// resumeCombat switches to the combat screen for a battle rebuilt with
+// combat.Replay after a reconnect. The player's ship becomes the engine's
+// copy, which carries the damage taken so far. Returns false if the battle
+// is over or isn't the player's current ship's.
+func (m *Model) resumeCombat(engine *combat.Engine) bool {
+	players := engine.Combatants(combat.SidePlayer)
+	if engine.Outcome() != combat.OutcomeNone || len(players) == 0 ||
+		m.currentShip == nil || players[0].ID != m.currentShip.ID.String() {
+		return false
+	}
+
+	m.combat = newCombatModel()
+	m.combat.engine = engine
+	m.combat.playerShip = players[0].Ship
+	m.combat.playerType = players[0].Type
+	m.combat.enemyTypes = make(map[string]*models.ShipType)
+	m.combat.escorts = make(map[string]uuid.UUID)
+	m.currentShip = players[0].Ship
+
+	// Escorts lost earlier in the battle have already left the fleet
+	if m.fleetManager != nil {
+		for _, escort := range m.fleetManager.ActiveEscorts(m.playerID) {
+			if ally := engine.Combatant(escort.Ship.ID.String()); ally != nil && ally.Side == combat.SidePlayer && ally != players[0] {
+				m.combat.escorts[ally.ID] = escort.ID
+			}
+		}
+	}
+
+	for _, enemy := range engine.Combatants(combat.SideEnemy) {
+		m.combat.enemyTypes[enemy.Ship.TypeID] = enemy.Type
+		if enemy.Active() {
+			m.combat.enemyShips = append(m.combat.enemyShips, enemy.Ship)
+		}
+	}
+	m.combat.turnNumber = engine.Turn()
+
+	for _, event := range engine.Events() {
+		if event.Type == combat.EventShieldRegen && event.Actor != players[0].ID {
+			continue
+		}
+		m.addCombatLog(event.Message)
+	}
+	m.addCombatLog("Connection restored - the battle continues")
+
+	m.screen = ScreenCombat
+	return true
+}
+
 // updateCombat handles input and state updates for the combat screen.
 //
 // Key Bindings (Tactical Mode):
@@ -108,6 +222,8 @@ func newCombatModel() combatModel {
 //   - w: Enter weapon selection mode
 //   - f: Fire selected weapon at selected target
 //   - e: End turn (triggers enemy AI phase)
+//   - b: Board the selected target once it is disabled (ends the turn)
+//   - a/d/h: Order escorts to attack, defend or hold fire
 //   - +/=: Zoom in radar
 //   - -/_: Zoom out radar
 //
@@ -144,6 +260,7 @@ func (m Model) updateCombat(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "esc":
 			if m.combat.mode == "tactical" {
 				// Return to main menu
+				m.updateEscorts(true)
 				m.screen = ScreenMainMenu
 				return m, nil
 			}
@@ -152,6 +269,7 @@ func (m Model) updateCombat(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case "backspace":
+			m.updateEscorts(true)
 			m.screen = ScreenMainMenu
 			return m, nil
 
@@ -192,6 +310,16 @@ func (m Model) updateCombat(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m.executeEndTurn()
 			}
 
+		case "b": // Board selected target
+			if m.combat.mode == "tactical" && m.combat.playerTurn {
+				return m.executeBoard()
+			}
+
+		case "a", "d", "h": // Escort orders
+			if m.combat.mode == "tactical" && m.combat.playerTurn {
+				m.commandEscorts(map[string]string{"a": "attack", "d": "defend", "h": "hold"}[msg.String()])
+			}
+
 		case "+", "=": // Zoom in radar
 			if m.combat.radarZoom < 5 {
 				m.combat.radarZoom++
@@ -247,7 +375,7 @@ func (m Model) handleCombatAction() (tea.Model, tea.Cmd) {
 }
 
 func (m Model) executeFireWeapon() (tea.Model, tea.Cmd) {
-	if m.combat.playerShip == nil || len(m.combat.enemyShips) == 0 {
+	if m.combat.engine == nil || m.combat.playerShip == nil || len(m.combat.enemyShips) == 0 {
 		return m, nil
 	}
 
@@ -262,196 +390,123 @@ func (m Model) executeFireWeapon() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	weaponID := m.combat.playerShip.Weapons[m.combat.selectedWeapon]
-	weapon := models.GetWeaponByID(weaponID)
-	if weapon == nil {
-		m.addCombatLog("Error: Invalid weapon")
+	target := m.combat.enemyShips[m.combat.selectedTarget]
+	events, err := m.combat.engine.Fire(m.combat.playerShip.ID.String(), m.combat.selectedWeapon, target.ID.String())
+	if err != nil {
+		m.addCombatLog(fmt.Sprintf("Cannot fire: %v", err))
 		return m, nil
 	}
 
-	target := m.combat.enemyShips[m.combat.selectedTarget]
-	targetType := m.combat.enemyTypes[target.TypeID]
+	m.applyCombatEvents(events)
 
-	// Get weapon state
-	var weaponState *combat.WeaponState
-	for _, ws := range m.combat.weaponStates {
-		if ws.WeaponID == weapon.ID {
-			weaponState = ws
-			break
-		}
+	return m, nil
+}
+
+// executeBoard sends a boarding party from the player's ship to the
+// selected target, which must be disabled. The capture manager resolves the
+// boarding, the engine applies it to the battle, and a captured ship is
+// claimed for the player's fleet. Boarding ends the player's turn.
+func (m Model) executeBoard() (tea.Model, tea.Cmd) {
+	if m.combat.engine == nil || m.combat.playerShip == nil || len(m.combat.enemyShips) == 0 {
+		return m, nil
 	}
-	if weaponState == nil {
-		weaponState = combat.InitializeWeaponState(weapon)
-		m.combat.weaponStates = append(m.combat.weaponStates, weaponState)
+	if m.captureManager == nil {
+		m.addCombatLog("Boarding is not available")
+		return m, nil
+	}
+	if m.combat.selectedTarget >= len(m.combat.enemyShips) {
+		m.addCombatLog("Error: No target selected")
+		return m, nil
 	}
 
-	// Fire weapon (distance placeholder)
-	distance := 500
-	result := combat.Fire(weapon, weaponState, m.combat.playerShip, target,
-		m.combat.playerType, targetType, distance)
-
-	// Add to combat log
-	m.addCombatLog(result.Message)
-
-	// Check if target destroyed
-	if target.Hull <= 0 {
-		m.addCombatLog(fmt.Sprintf("%s DESTROYED!", target.Name))
-
-		// Record kill for player progression
-		if m.player != nil {
-			m.player.RecordKill()
-
-			// Check for achievement unlocks
-			m.checkAchievements()
+	target := m.combat.enemyShips[m.combat.selectedTarget]
+	outcome, err := m.captureManager.Board(context.Background(), m.combat.playerShip, target)
+	if err != nil {
+		m.addCombatLog(fmt.Sprintf("Cannot board: %v", err))
+		return m, nil
+	}
 
-			// Show rating update if it changed
-			newRating := m.player.CombatRating
-			if newRating%10 == 0 { // Show message every 10 points
-				m.addCombatLog(fmt.Sprintf("Combat Rating: %d (%s)", newRating, m.player.GetCombatRankTitle()))
-			}
+	events, err := m.combat.engine.Board(m.combat.playerShip.ID.String(), target.ID.String(), combat.BoardingResult{
+		Boarded:        outcome.Success,
+		Captured:       outcome.CaptureSuccess,
+		AttackerLosses: outcome.AttackerLosses,
+		DefenderLosses: outcome.DefenderLosses,
+		Message:        fmt.Sprintf("Boarding %s: %s", target.Name, outcome.Message),
+	})
+	if err != nil {
+		m.addCombatLog(fmt.Sprintf("Cannot board: %v", err))
+		return m, nil
+	}
 
-			// Show achievement notification if any
-			if notification := m.getAchievementNotification(); notification != "" {
-				m.addCombatLog(notification)
-				m.clearAchievementNotification()
-			}
+	// The capture manager has saved the stats; keep the loaded player in step
+	if m.player != nil {
+		m.player.RecordCaptureAttempt()
+		if outcome.Success {
+			m.player.RecordSuccessfulBoard()
 		}
-
-		// Remove from enemy list
-		m.combat.enemyShips = append(m.combat.enemyShips[:m.combat.selectedTarget],
-			m.combat.enemyShips[m.combat.selectedTarget+1:]...)
-		if m.combat.selectedTarget >= len(m.combat.enemyShips) && m.combat.selectedTarget > 0 {
-			m.combat.selectedTarget--
+		if outcome.CaptureSuccess {
+			m.player.RecordSuccessfulCapture()
 		}
+	}
 
-		// Check if combat over
-		if len(m.combat.enemyShips) == 0 {
-			m.addCombatLog("VICTORY! All enemies destroyed!")
-			m.combat.playerTurn = false
+	m.applyCombatEvents(events)
+	if outcome.CaptureSuccess {
+		m.claimCapturedShip(target)
+	}
 
-			// Generate news for significant battles (handled on combat end)
-		}
+	if m.combat.engine.Outcome() != combat.OutcomeNone {
+		m.addCombatLog("Press ESC to return to main menu")
+		return m, nil
 	}
+	return m.executeEndTurn()
+}
 
-	return m, nil
+// claimCapturedShip hands a ship taken by boarders to the player and adds
+// it to their fleet, which starts with the ship they are flying
+func (m *Model) claimCapturedShip(captured *models.Ship) {
+	// The engine keeps its own ship; the prize is a copy
+	prize := *captured
+	prize.Cargo = append([]models.CargoItem(nil), captured.Cargo...)
+	prize.Weapons = append([]string(nil), captured.Weapons...)
+	prize.Outfits = append([]string(nil), captured.Outfits...)
+	prize.WeaponAmmo = maps.Clone(captured.WeaponAmmo)
+
+	ctx := context.Background()
+	if err := m.captureManager.ClaimShip(ctx, m.playerID, &prize); err != nil {
+		m.addCombatLog(fmt.Sprintf("Failed to claim %s: %v", prize.Name, err))
+		return
+	}
+	if m.fleetManager == nil {
+		m.addCombatLog(fmt.Sprintf("%s is now yours", prize.Name))
+		return
+	}
+
+	if fleet, exists := m.fleetManager.GetFleet(m.playerID); (!exists || len(fleet.OwnedShips) == 0) && m.currentShip != nil {
+		flagship := *m.currentShip
+		_ = m.fleetManager.AddShip(ctx, m.playerID, &flagship)
+	}
+	if err := m.fleetManager.AddShip(ctx, m.playerID, &prize); err != nil {
+		m.addCombatLog(fmt.Sprintf("%s is yours, but your fleet has no room for it: %v", prize.Name, err))
+		return
+	}
+	m.addCombatLog(fmt.Sprintf("%s joins your fleet", prize.Name))
 }
 
 func (m Model) executeEndTurn() (tea.Model, tea.Cmd) {
+	if m.combat.engine == nil {
+		return m, nil
+	}
+
 	m.combat.playerTurn = false
-	m.combat.turnNumber++
-	m.addCombatLog(fmt.Sprintf("--- Turn %d ---", m.combat.turnNumber))
 
 	// Execute enemy AI turns
 	m.addCombatLog("Enemy turn...")
-
-	// Create player ship list for AI (enemies' perspective)
-	playerShips := []*models.Ship{}
-	if m.combat.playerShip != nil {
-		playerShips = append(playerShips, m.combat.playerShip)
-	}
-
-	// Execute AI for each enemy ship
-	for _, enemyShip := range m.combat.enemyShips {
-		if enemyShip.Hull <= 0 {
-			continue // Skip destroyed ships
-		}
-
-		enemyShipID := enemyShip.ID.String()
-		enemyType := m.combat.enemyTypes[enemyShip.TypeID]
-
-		// Initialize AI state if not exists (default to Medium difficulty)
-		if m.combat.enemyAI[enemyShipID] == nil {
-			m.combat.enemyAI[enemyShipID] = combat.NewAIState(combat.AILevelMedium)
-		}
-
-		ai := m.combat.enemyAI[enemyShipID]
-
-		// Decide actions for this enemy
-		actions := combat.DecideAction(
-			ai,
-			enemyShip,
-			enemyType,
-			playerShips,
-			map[string]*models.ShipType{m.combat.playerType.ID: m.combat.playerType},
-			m.combat.allyShips,
-			1.0, // deltaTime = 1 turn
-		)
-
-		// Execute AI actions
-		for _, action := range actions {
-			switch action.Type {
-			case "fire":
-				// Find weapon and execute attack
-				for _, weaponID := range enemyShip.Weapons {
-					if weaponID == action.WeaponID {
-						weapon := models.GetWeaponByID(weaponID)
-						if weapon != nil && m.combat.playerShip != nil {
-							// Apply accuracy modifier based on AI level
-							accuracy := combat.ApplyAIAccuracyModifier(ai, float64(weapon.Accuracy)/100.0)
-
-							// Simple hit calculation
-							hit := (accuracy >= 0.5) // Simplified for now
-							if hit {
-								damage := weapon.Damage
-								// Apply damage to player ship
-								if m.combat.playerShip.Shields > 0 {
-									m.combat.playerShip.Shields -= damage
-									if m.combat.playerShip.Shields < 0 {
-										overflow := -m.combat.playerShip.Shields
-										m.combat.playerShip.Shields = 0
-										m.combat.playerShip.Hull -= overflow
-									}
-									m.addCombatLog(fmt.Sprintf("Enemy %s fires %s - HIT! -%d shields",
-										enemyType.Name, weapon.Name, damage))
-								} else {
-									m.combat.playerShip.Hull -= damage
-									m.addCombatLog(fmt.Sprintf("Enemy %s fires %s - HIT! -%d hull",
-										enemyType.Name, weapon.Name, damage))
-								}
-							} else {
-								m.addCombatLog(fmt.Sprintf("Enemy %s fires %s - MISS!",
-									enemyType.Name, weapon.Name))
-							}
-						}
-						break
-					}
-				}
-
-			case "retreat":
-				m.addCombatLog(fmt.Sprintf("Enemy %s attempts to retreat!", enemyType.Name))
-
-			case "evade":
-				m.addCombatLog(fmt.Sprintf("Enemy %s takes evasive maneuvers", enemyType.Name))
-			}
-		}
-
-		// Regenerate enemy shields
-		if enemyType != nil && enemyShip.Shields < enemyType.MaxShields {
-			regen := enemyType.ShieldRegen
-			enemyShip.Shields += regen
-			if enemyShip.Shields > enemyType.MaxShields {
-				enemyShip.Shields = enemyType.MaxShields
-			}
-		}
-	}
-
-	// Regenerate player shields
-	if m.combat.playerShip != nil && m.combat.playerType != nil {
-		if m.combat.playerShip.Shields < m.combat.playerType.MaxShields {
-			regen := m.combat.playerType.ShieldRegen
-			m.combat.playerShip.Shields += regen
-			if m.combat.playerShip.Shields > m.combat.playerType.MaxShields {
-				m.combat.playerShip.Shields = m.combat.playerType.MaxShields
-			}
-			m.addCombatLog(fmt.Sprintf("Shields recharged +%d", regen))
-		}
-	}
-
-	// Update weapon cooldowns
-	combat.UpdateCooldowns(m.combat.weaponStates, 1.0)
+	m.syncEscortOrders()
+	m.applyCombatEvents(m.combat.engine.EndTurn())
 
 	// Check combat end conditions
 	if m.combat.playerShip != nil && m.combat.playerShip.Hull <= 0 {
+		m.withdrawEscorts()
 		m.addCombatLog("Your ship has been destroyed!")
 		m.addCombatLog("You eject from your ship and are rescued...")
 
@@ -497,17 +552,7 @@ func (m Model) executeEndTurn() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Check for enemy defeat
-	allEnemiesDestroyed := true
-	for _, enemy := range m.combat.enemyShips {
-		if enemy.Hull > 0 {
-			allEnemiesDestroyed = false
-			break
-		}
-	}
-
-	if allEnemiesDestroyed && len(m.combat.enemyShips) > 0 {
-		m.addCombatLog("All enemies destroyed - Victory!")
+	if m.combat.engine.Outcome() != combat.OutcomeNone {
 		m.addCombatLog("Press ESC to return to main menu")
 		// Don't start player's turn - combat is over
 		return m, nil
@@ -519,6 +564,171 @@ func (m Model) executeEndTurn() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// applyCombatEvents renders engine events into the combat log, records the
+// player's kills and drops ships that have left the battle from the target list.
+func (m *Model) applyCombatEvents(events []combat.Event) {
+	playerID := m.combat.playerShip.ID.String()
+
+	for _, event := range events {
+		// Only the player's own shield recharge is worth a log line
+		if event.Type == combat.EventShieldRegen && event.Actor != playerID {
+			continue
+		}
+
+		m.addCombatLog(event.Message)
+
+		if event.Type == combat.EventDestroyed && event.Actor == playerID && m.player != nil {
+			// Record kill for player progression
+			m.player.RecordKill()
+			if m.territoryManager != nil {
+				m.territoryManager.RecordKill(m.player.CurrentSystem, m.territoryFactionID())
+			}
+
+			// Check for achievement unlocks
+			m.checkAchievements()
+
+			// Show rating update if it changed
+			newRating := m.player.CombatRating
+			if newRating%10 == 0 { // Show message every 10 points
+				m.addCombatLog(fmt.Sprintf("Combat Rating: %d (%s)", newRating, m.player.GetCombatRankTitle()))
+			}
+
+			// Show achievement notification if any
+			if notification := m.getAchievementNotification(); notification != "" {
+				m.addCombatLog(notification)
+				m.clearAchievementNotification()
+			}
+		}
+	}
+
+	// Keep only enemies still in the battle
+	m.combat.enemyShips = m.combat.enemyShips[:0]
+	for _, enemy := range m.combat.engine.Combatants(combat.SideEnemy) {
+		if enemy.Active() {
+			m.combat.enemyShips = append(m.combat.enemyShips, enemy.Ship)
+		}
+	}
+	if m.combat.selectedTarget >= len(m.combat.enemyShips) {
+		m.combat.selectedTarget = max(len(m.combat.enemyShips)-1, 0)
+	}
+
+	m.combat.turnNumber = m.combat.engine.Turn()
+	if m.combat.engine.Outcome() != combat.OutcomeNone {
+		m.combat.playerTurn = false
+	}
+
+	m.updateEscorts(m.combat.engine.Outcome() != combat.OutcomeNone)
+}
+
+// escortAILevel maps an escort pilot's skill level (1-10) to an AI level
+func escortAILevel(level int) combat.AILevel {
+	aiLevel := combat.AILevel((level - 1) / 2)
+	if aiLevel < combat.AILevelEasy {
+		return combat.AILevelEasy
+	}
+	if aiLevel > combat.AILevelAce {
+		return combat.AILevelAce
+	}
+	return aiLevel
+}
+
+// escortStance maps an escort's combat order to the engine stance it fights with
+func escortStance(order string) combat.Stance {
+	switch order {
+	case "attack":
+		return combat.StanceEngage
+	case "defend":
+		return combat.StanceDefend
+	default:
+		return combat.StanceHold
+	}
+}
+
+// commandEscorts issues a fleet command ("attack", "defend" or "hold") to
+// the player's escorts in the battle
+func (m *Model) commandEscorts(command string) {
+	if m.fleetManager == nil || len(m.combat.escorts) == 0 || m.combat.engine.Outcome() != combat.OutcomeNone {
+		return
+	}
+	if err := m.fleetManager.CommandEscorts(m.playerID, command); err != nil {
+		m.addCombatLog(fmt.Sprintf("Escorts did not respond: %v", err))
+		return
+	}
+	m.syncEscortOrders()
+	m.addCombatLog(fmt.Sprintf("Escorts ordered to %s", command))
+}
+
+// syncEscortOrders updates the stance of each escort in the battle from its
+// current fleet orders, which may have changed since the battle began
+func (m *Model) syncEscortOrders() {
+	if m.fleetManager == nil || len(m.combat.escorts) == 0 {
+		return
+	}
+	for _, escort := range m.fleetManager.ActiveEscorts(m.playerID) {
+		id := escort.Ship.ID.String()
+		if _, inBattle := m.combat.escorts[id]; inBattle {
+			_ = m.combat.engine.SetStance(id, escortStance(escort.CombatOrder()))
+		}
+	}
+}
+
+// withdrawEscorts pulls the player's surviving escorts out of the battle
+// once the player's ship is lost
+func (m *Model) withdrawEscorts() {
+	for _, ally := range m.combat.engine.Combatants(combat.SidePlayer) {
+		if _, isEscort := m.combat.escorts[ally.ID]; !isEscort || !ally.Active() {
+			continue
+		}
+		if events, err := m.combat.engine.Retreat(ally.ID); err == nil {
+			m.applyCombatEvents(events)
+		}
+	}
+}
+
+// updateEscorts records the fate of the player's escorts in the fleet: an
+// escort destroyed in battle is lost straight away, and once the battle is
+// over (final) the survivors keep the hull and shields they were left with.
+func (m *Model) updateEscorts(final bool) {
+	if m.fleetManager == nil || m.combat.engine == nil || len(m.combat.escorts) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+	for _, ally := range m.combat.engine.Combatants(combat.SidePlayer) {
+		escortID, isEscort := m.combat.escorts[ally.ID]
+		if !isEscort {
+			continue
+		}
+
+		switch {
+		case ally.Destroyed():
+			if err := m.fleetManager.EscortDestroyed(ctx, m.playerID, escortID); err != nil {
+				log.Warn("Failed to record lost escort for %s: %v", m.username, err)
+			}
+			m.addCombatLog(fmt.Sprintf("Your escort %s has been lost!", ally.Name))
+		case final:
+			if err := m.fleetManager.UpdateEscortCondition(ctx, m.playerID, escortID, ally.Ship.Hull, ally.Ship.Shields); err != nil {
+				log.Warn("Failed to save escort damage for %s: %v", m.username, err)
+			}
+		default:
+			continue
+		}
+		delete(m.combat.escorts, ally.ID)
+	}
+}
+
+// playerWeaponState returns the engine's state for a player weapon slot
+func (m Model) playerWeaponState(slot int) *combat.WeaponState {
+	if m.combat.engine == nil || m.combat.playerShip == nil {
+		return nil
+	}
+	player := m.combat.engine.Combatant(m.combat.playerShip.ID.String())
+	if player == nil || slot >= len(player.Weapons) {
+		return nil
+	}
+	return player.Weapons[slot]
+}
+
 func (m *Model) addCombatLog(message string) {
 	m.combat.combatLog = append(m.combat.combatLog, message)
 	// Keep only last N lines
@@ -599,11 +809,20 @@ func (m Model) viewTacticalDisplay() string {
 	s += m.renderShipStatus(m.combat.playerShip, m.combat.playerType, "YOUR SHIP")
 	s += "\n"
 
+	// Escorts flying with the player
+	if escorts := m.renderEscortStatus(); escorts != "" {
+		s += escorts
+		s += "\n"
+	}
+
 	// Target ship status (right side, if selected)
 	if m.combat.selectedTarget < len(m.combat.enemyShips) {
 		target := m.combat.enemyShips[m.combat.selectedTarget]
 		targetType := m.combat.enemyTypes[target.TypeID]
 		s += m.renderShipStatus(target, targetType, "TARGET")
+		if m.targetDisabled() {
+			s += successStyle.Render("  DISABLED - ready to be boarded") + "\n"
+		}
 		s += "\n"
 	}
 
@@ -623,6 +842,12 @@ func (m Model) viewTacticalDisplay() string {
 
 	// Controls
 	helpText := "T: Target  •  W: Weapons  •  F: Fire  •  E: End Turn  •  +/-: Radar Zoom  •  ESC: Main Menu"
+	if len(m.combat.escorts) > 0 {
+		helpText = "T: Target  •  W: Weapons  •  F: Fire  •  E: End Turn  •  A/D/H: Escorts Attack/Defend/Hold  •  +/-: Zoom  •  ESC: Menu"
+	}
+	if m.targetDisabled() {
+		helpText = "B: Board  •  " + helpText
+	}
 	if !m.combat.playerTurn {
 		helpText = "Enemy turn in progress..."
 	}
@@ -631,6 +856,52 @@ func (m Model) viewTacticalDisplay() string {
 	return s
 }
 
+// renderEscortStatus lists the escorts in the battle with their hull and
+// current orders. Returns "" if the player has no escorts in the battle.
+func (m Model) renderEscortStatus() string {
+	if m.combat.engine == nil || m.combat.playerShip == nil {
+		return ""
+	}
+
+	s := ""
+	for _, ally := range m.combat.engine.Combatants(combat.SidePlayer) {
+		if ally.ID == m.combat.playerShip.ID.String() {
+			continue
+		}
+
+		status := map[combat.Stance]string{
+			combat.StanceEngage: "attacking",
+			combat.StanceDefend: "defending",
+			combat.StanceHold:   "holding fire",
+		}[ally.Stance]
+		statusStyle := statsStyle
+		switch {
+		case ally.Destroyed():
+			status, statusStyle = "DESTROYED", errorStyle
+		case ally.Retreated:
+			status, statusStyle = "withdrawn", helpStyle
+		}
+
+		s += fmt.Sprintf("  %-20s %s %d/%d  %s\n",
+			ally.Name,
+			m.renderStatusBar(ally.Ship.Hull, ally.Type.MaxHull, 10, "█", "░"), max(ally.Ship.Hull, 0), ally.Type.MaxHull,
+			statusStyle.Render(status))
+	}
+	if s == "" {
+		return ""
+	}
+	return subtitleStyle.Render("ESCORTS") + "\n" + s
+}
+
+// targetDisabled reports whether the selected target can be boarded
+func (m Model) targetDisabled() bool {
+	if m.captureManager == nil || !m.combat.playerTurn || m.combat.selectedTarget >= len(m.combat.enemyShips) {
+		return false
+	}
+	disabled, _ := m.captureManager.CanDisable(m.combat.enemyShips[m.combat.selectedTarget])
+	return disabled
+}
+
 func (m Model) renderShipStatus(ship *models.Ship, shipType *models.ShipType, label string) string {
 	if ship == nil || shipType == nil {
 		return ""
@@ -702,6 +973,20 @@ func (m Model) renderRadar() string {
 	centerY := size / 2
 	radar[centerY][centerX] = 'P'
 
+	// Place escorts in a line behind the player
+	if m.combat.engine != nil {
+		offset := 0
+		for _, ally := range m.combat.engine.Combatants(combat.SidePlayer) {
+			if ally.Ship == m.combat.playerShip || !ally.Active() {
+				continue
+			}
+			offset++
+			if x := centerX - 2*offset; x >= 0 {
+				radar[centerY+1][x] = 'A'
+			}
+		}
+	}
+
 	// Place enemies (simplified positions)
 	for i, enemy := range m.combat.enemyShips {
 		if enemy.Hull > 0 {
@@ -727,7 +1012,7 @@ func (m Model) renderRadar() string {
 		s += "  " + string(row) + "\n"
 	}
 	s += "  " + strings.Repeat("─", size) + "\n"
-	s += "  P=You  E=Enemy  T=Target\n"
+	s += "  P=You  A=Escort  E=Enemy  T=Target\n"
 
 	return s
 }
@@ -759,14 +1044,7 @@ func (m Model) renderWeaponStatus() string {
 			continue
 		}
 
-		// Find weapon state
-		var state *combat.WeaponState
-		for _, ws := range m.combat.weaponStates {
-			if ws.WeaponID == weapon.ID {
-				state = ws
-				break
-			}
-		}
+		state := m.playerWeaponState(i)
 
 		prefix := "  "
 		if i == m.combat.selectedWeapon {
@@ -863,14 +1141,7 @@ func (m Model) viewWeaponSelection() string {
 			continue
 		}
 
-		// Find weapon state
-		var state *combat.WeaponState
-		for _, ws := range m.combat.weaponStates {
-			if ws.WeaponID == weapon.ID {
-				state = ws
-				break
-			}
-		}
+		state := m.playerWeaponState(i)
 
 		status := "Ready"
 		if state != nil && state.CooldownRemaining > 0 {
@@ -0,0 +1,422 @@
+// File: internal/tui/diplomacy.go
+// Project: Terminal Velocity
+// Description: Diplomacy screen - faction leaders propose and answer alliances, treaties, wars and truces
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// The diplomacy screen is reached from the player's faction view and shows:
+// - The faction's alliance and its members
+// - Ongoing wars with war score and truce status
+// - Treaties in force and when they expire
+// - Open proposals made to and by the faction
+//
+// Every member can view the screen; only the faction leader can act.
+//
+// View Modes:
+//   - overview: Diplomatic standing, incoming proposals and wars
+//   - target: Pick another faction to propose to or declare war on
+//
+// Diplomatic changes made by other sessions arrive as world.EventDiplomacy
+// events, which re-render the screen with fresh data.
+
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/diplomacy"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+// Diplomacy view modes
+const (
+	diplomacyViewOverview = "overview" // Standing, proposals and wars
+	diplomacyViewTarget   = "target"   // Choose a faction to act on
+)
+
+// diplomacyModel contains the state for the diplomacy screen
+type diplomacyModel struct {
+	viewMode     string // Current view: "overview", "target"
+	cursor       int    // Selection across incoming proposals, then wars
+	targetCursor int    // Selected faction in target mode
+	treatyIndex  int    // Treaty type offered in target mode (index into models.TreatyTypes)
+	message      string // Result of the last diplomatic action
+}
+
+// diplomacyActionMsg reports the result of a diplomatic action
+type diplomacyActionMsg struct {
+	message string
+	err     error
+}
+
+// newDiplomacyModel creates a diplomacy screen model in overview mode
+func newDiplomacyModel() diplomacyModel {
+	return diplomacyModel{
+		viewMode: diplomacyViewOverview,
+	}
+}
+
+// updateDiplomacy handles input for the diplomacy screen.
+//
+// Key Bindings (Overview):
+//   - esc/backspace/q: Return to the faction view
+//   - up/k, down/j: Select an incoming proposal or war
+//   - a/enter: Accept the selected proposal, or propose/accept a truce
+//   - r: Decline the selected proposal
+//   - f: Choose a faction to propose to or declare war on
+//
+// Key Bindings (Target):
+//   - esc: Return to the overview
+//   - up/k, down/j: Select a faction
+//   - tab: Cycle the treaty type to offer
+//   - a: Propose an alliance
+//   - t: Propose the selected treaty
+//   - w: Declare war
+func (m Model) updateDiplomacy(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.diplomacyModel.viewMode == diplomacyViewTarget {
+			return m.updateDiplomacyTarget(msg)
+		}
+
+		incoming, wars := m.diplomacyItems()
+		switch msg.String() {
+		case "esc", "backspace", "q":
+			m.screen = ScreenFactions
+			return m, tea.ClearScreen
+
+		case "up", "k":
+			if m.diplomacyModel.cursor > 0 {
+				m.diplomacyModel.cursor--
+			}
+
+		case "down", "j":
+			if m.diplomacyModel.cursor < len(incoming)+len(wars)-1 {
+				m.diplomacyModel.cursor++
+			}
+
+		case "a", "enter":
+			cursor := m.diplomacyModel.cursor
+			if cursor < len(incoming) {
+				return m, m.answerProposal(incoming[cursor], true)
+			}
+			if cursor-len(incoming) < len(wars) {
+				return m, m.answerWar(wars[cursor-len(incoming)])
+			}
+
+		case "r":
+			if m.diplomacyModel.cursor < len(incoming) {
+				return m, m.answerProposal(incoming[m.diplomacyModel.cursor], false)
+			}
+
+		case "f":
+			m.diplomacyModel.viewMode = diplomacyViewTarget
+			m.diplomacyModel.targetCursor = 0
+			m.diplomacyModel.message = ""
+		}
+
+	case diplomacyActionMsg:
+		if msg.err != nil {
+			m.diplomacyModel.message = errorStyle.Render(fmt.Sprintf("Failed: %v", msg.err))
+		} else {
+			m.diplomacyModel.message = successStyle.Render(msg.message)
+			m.diplomacyModel.viewMode = diplomacyViewOverview
+			m.diplomacyModel.cursor = 0
+		}
+	}
+
+	return m, nil
+}
+
+// updateDiplomacyTarget handles input while choosing a faction to act on
+func (m Model) updateDiplomacyTarget(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	targets := m.diplomacyTargets()
+
+	switch msg.String() {
+	case "esc", "backspace", "q":
+		m.diplomacyModel.viewMode = diplomacyViewOverview
+
+	case "up", "k":
+		if m.diplomacyModel.targetCursor > 0 {
+			m.diplomacyModel.targetCursor--
+		}
+
+	case "down", "j":
+		if m.diplomacyModel.targetCursor < len(targets)-1 {
+			m.diplomacyModel.targetCursor++
+		}
+
+	case "tab":
+		m.diplomacyModel.treatyIndex = (m.diplomacyModel.treatyIndex + 1) % len(models.TreatyTypes)
+
+	case "a", "t", "w":
+		if m.diplomacyModel.targetCursor < len(targets) {
+			return m, m.diplomaticAction(msg.String(), targets[m.diplomacyModel.targetCursor])
+		}
+	}
+
+	return m, nil
+}
+
+// diplomacyItems returns the selectable entries of the overview: proposals
+// awaiting the player's faction, then its ongoing wars
+func (m Model) diplomacyItems() ([]*models.DiplomaticProposal, []*diplomacy.War) {
+	factionID := m.territoryFactionID()
+	if factionID == uuid.Nil || m.diplomacyManager == nil {
+		return nil, nil
+	}
+	incoming, _ := m.diplomacyManager.GetProposals(factionID)
+	return incoming, m.diplomacyManager.GetFactionWars(factionID)
+}
+
+// diplomacyTargets returns every faction other than the player's
+func (m Model) diplomacyTargets() []*models.PlayerFaction {
+	factionID := m.territoryFactionID()
+	var targets []*models.PlayerFaction
+	for _, faction := range m.factionManager.GetAllFactions() {
+		if faction.ID != factionID {
+			targets = append(targets, faction)
+		}
+	}
+	return targets
+}
+
+// diplomaticAction proposes an alliance ("a") or treaty ("t") to the target
+// faction, or declares war on it ("w")
+func (m Model) diplomaticAction(action string, target *models.PlayerFaction) tea.Cmd {
+	treatyType := models.TreatyTypes[m.diplomacyModel.treatyIndex]
+	return func() tea.Msg {
+		factionID := m.territoryFactionID()
+		if factionID == uuid.Nil {
+			return diplomacyActionMsg{err: fmt.Errorf("you are not in a faction")}
+		}
+		ctx := context.Background()
+
+		switch action {
+		case "a":
+			if _, err := m.diplomacyManager.ProposeAlliance(ctx, factionID, target.ID, m.playerID); err != nil {
+				return diplomacyActionMsg{err: err}
+			}
+			return diplomacyActionMsg{message: fmt.Sprintf("Proposed an alliance to %s", target.GetFullName())}
+
+		case "t":
+			if _, err := m.diplomacyManager.ProposeTreaty(ctx, treatyType, factionID, target.ID, m.playerID); err != nil {
+				return diplomacyActionMsg{err: err}
+			}
+			return diplomacyActionMsg{message: fmt.Sprintf("Proposed a %s treaty to %s", treatyType, target.GetFullName())}
+
+		default:
+			war, err := m.diplomacyManager.DeclareFactionWar(ctx, factionID, target.ID, m.playerID)
+			if err != nil {
+				return diplomacyActionMsg{err: err}
+			}
+			return diplomacyActionMsg{message: fmt.Sprintf("Declared war: %s", war.Name)}
+		}
+	}
+}
+
+// answerProposal accepts or declines a proposal made to the player's faction
+func (m Model) answerProposal(proposal *models.DiplomaticProposal, accept bool) tea.Cmd {
+	return func() tea.Msg {
+		from := m.factionLabel(proposal.FromFactionID)
+		if !accept {
+			if err := m.diplomacyManager.DeclineProposal(context.Background(), proposal.ID, m.playerID); err != nil {
+				return diplomacyActionMsg{err: err}
+			}
+			return diplomacyActionMsg{message: fmt.Sprintf("Declined %s from %s", proposal.Describe(), from)}
+		}
+
+		if err := m.diplomacyManager.AcceptProposal(context.Background(), proposal.ID, m.playerID); err != nil {
+			return diplomacyActionMsg{err: err}
+		}
+		return diplomacyActionMsg{message: fmt.Sprintf("Accepted %s from %s", proposal.Describe(), from)}
+	}
+}
+
+// answerWar proposes a truce in a war, or accepts one the other side proposed
+func (m Model) answerWar(war *diplomacy.War) tea.Cmd {
+	return func() tea.Msg {
+		faction, err := m.factionManager.GetPlayerFaction(m.playerID)
+		if err != nil {
+			return diplomacyActionMsg{err: err}
+		}
+		if faction.LeaderID != m.playerID {
+			return diplomacyActionMsg{err: diplomacy.ErrNotLeader}
+		}
+
+		if war.Status == "truce_proposed" {
+			if err := m.diplomacyManager.AcceptTruce(context.Background(), war.ID, faction.ID); err != nil {
+				return diplomacyActionMsg{err: err}
+			}
+			return diplomacyActionMsg{message: fmt.Sprintf("Truce accepted - %s is over", war.Name)}
+		}
+
+		if err := m.diplomacyManager.ProposeTruce(context.Background(), war.ID, faction.ID); err != nil {
+			return diplomacyActionMsg{err: err}
+		}
+		return diplomacyActionMsg{message: fmt.Sprintf("Proposed a truce in %s", war.Name)}
+	}
+}
+
+// factionLabel returns a faction's display name, or a placeholder if it
+// no longer exists
+func (m Model) factionLabel(factionID uuid.UUID) string {
+	faction, err := m.factionManager.GetFaction(factionID)
+	if err != nil {
+		return "Unknown faction"
+	}
+	return faction.GetFullName()
+}
+
+// factionLabels joins the display names of several factions
+func (m Model) factionLabels(factionIDs []uuid.UUID) string {
+	names := make([]string, 0, len(factionIDs))
+	for _, factionID := range factionIDs {
+		names = append(names, m.factionLabel(factionID))
+	}
+	return strings.Join(names, ", ")
+}
+
+// viewDiplomacy renders the diplomacy screen (dispatches to the target view).
+//
+// Layout:
+//   - Alliance: Name and member factions
+//   - Wars: Opponents, war score and truce status (selectable)
+//   - Treaties: Type, partner and expiry
+//   - Proposals: Incoming (selectable) and outgoing
+//   - Footer with controls
+func (m Model) viewDiplomacy() string {
+	faction, err := m.factionManager.GetPlayerFaction(m.playerID)
+	if err != nil {
+		return "You are not in a faction\n\n" + renderFooter("ESC: Back")
+	}
+	if m.diplomacyModel.viewMode == diplomacyViewTarget {
+		return m.viewDiplomacyTarget(faction)
+	}
+
+	s := titleStyle.Render(fmt.Sprintf("🤝 DIPLOMACY - %s", faction.GetFullName())) + "\n\n"
+	if faction.LeaderID != m.playerID {
+		s += helpStyle.Render("Only the faction leader can conduct diplomacy") + "\n\n"
+	}
+
+	incoming, wars := m.diplomacyItems()
+	marker := func(item int) string {
+		if item == m.diplomacyModel.cursor {
+			return "> "
+		}
+		return "  "
+	}
+
+	// Alliance
+	s += "Alliance:\n"
+	if alliance := m.diplomacyManager.GetFactionAlliance(faction.ID); alliance != nil {
+		s += fmt.Sprintf("  %s - %s\n", alliance.Name, m.factionLabels(alliance.AllFactions()))
+	} else {
+		s += helpStyle.Render("  None") + "\n"
+	}
+
+	// Proposals awaiting an answer come first in the cursor order
+	s += "\nIncoming Proposals:\n"
+	if len(incoming) == 0 {
+		s += helpStyle.Render("  None") + "\n"
+	}
+	for i, proposal := range incoming {
+		s += fmt.Sprintf("%s%s from %s (expires in %s)\n", marker(i), proposal.Describe(),
+			m.factionLabel(proposal.FromFactionID), time.Until(proposal.ExpiresAt).Round(time.Minute))
+	}
+
+	// Wars
+	s += "\nWars:\n"
+	if len(wars) == 0 {
+		s += helpStyle.Render("  At peace") + "\n"
+	}
+	for i, war := range wars {
+		allies, enemies := war.Side(faction.ID)
+		ourScore, theirScore := 0, 0
+		for _, id := range allies {
+			ourScore += war.WarScore[id]
+		}
+		for _, id := range enemies {
+			theirScore += war.WarScore[id]
+		}
+		line := fmt.Sprintf("%s⚔ %s vs %s | Score %d - %d", marker(len(incoming)+i), war.Name, m.factionLabels(enemies), ourScore, theirScore)
+		if war.Status == "truce_proposed" {
+			line += fmt.Sprintf(" | Truce proposed by %s", m.factionLabel(war.TruceProposedBy))
+		}
+		s += errorStyle.Render(line) + "\n"
+	}
+
+	// Treaties
+	s += "\nTreaties:\n"
+	treaties := m.diplomacyManager.GetFactionTreaties(faction.ID)
+	if len(treaties) == 0 {
+		s += helpStyle.Render("  None") + "\n"
+	}
+	for _, treaty := range treaties {
+		partner := treaty.Faction2
+		if partner == faction.ID {
+			partner = treaty.Faction1
+		}
+		s += fmt.Sprintf("  %s with %s (expires %s)\n", treaty.Type, m.factionLabel(partner), treaty.ExpiresAt.Format("2006-01-02"))
+	}
+
+	// Outgoing proposals
+	_, outgoing := m.diplomacyManager.GetProposals(faction.ID)
+	if len(outgoing) > 0 {
+		s += "\nAwaiting Answer:\n"
+		for _, proposal := range outgoing {
+			s += fmt.Sprintf("  %s to %s\n", proposal.Describe(), m.factionLabel(proposal.ToFactionID))
+		}
+	}
+
+	if m.diplomacyModel.message != "" {
+		s += "\n" + m.diplomacyModel.message + "\n"
+	}
+
+	s += "\n" + renderFooter("A: Accept / Truce | R: Decline | F: Choose Faction | ESC: Back")
+	return s
+}
+
+// viewDiplomacyTarget renders the faction picker with the player's current
+// standing towards each faction
+func (m Model) viewDiplomacyTarget(faction *models.PlayerFaction) string {
+	s := titleStyle.Render("🤝 CHOOSE A FACTION") + "\n\n"
+
+	targets := m.diplomacyTargets()
+	if len(targets) == 0 {
+		s += helpStyle.Render("There are no other factions") + "\n"
+	}
+	for i, target := range targets {
+		cursor := "  "
+		if i == m.diplomacyModel.targetCursor {
+			cursor = "> "
+		}
+
+		standing := ""
+		switch {
+		case m.diplomacyManager.Allied(faction.ID, target.ID):
+			standing = successStyle.Render(" [Allied]")
+		case m.diplomacyManager.AtWar(faction.ID, target.ID):
+			standing = errorStyle.Render(" [At War]")
+		}
+		s += fmt.Sprintf("%s%s - %d members%s\n", cursor, target.GetFullName(), len(target.Members), standing)
+	}
+
+	treatyType := models.TreatyTypes[m.diplomacyModel.treatyIndex]
+	s += fmt.Sprintf("\nTreaty to offer: %s\n", highlightStyle.Render(string(treatyType)))
+	s += helpStyle.Render(fmt.Sprintf("Declaring war costs %d CR from the treasury and breaks any treaties",
+		diplomacy.DefaultDiplomacyConfig().WarDeclarationCost)) + "\n"
+
+	if m.diplomacyModel.message != "" {
+		s += "\n" + m.diplomacyModel.message + "\n"
+	}
+
+	s += "\n" + renderFooter("A: Propose Alliance | Tab: Treaty Type | T: Propose Treaty | W: Declare War | ESC: Back")
+	return s
+}
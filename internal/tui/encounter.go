@@ -159,18 +159,7 @@ func (m Model) handleEncounterOption() (tea.Model, tea.Cmd) {
 		m.encounterModel.resolved = true
 
 		// Initialize combat with encounter ships
-		m.combat = newCombatModel()
-		m.combat.playerShip = m.currentShip
-		m.combat.playerType = models.GetShipTypeByID(m.currentShip.TypeID)
-
-		// Generate encounter ships
-		m.combat.enemyShips = m.encounterModel.generator.GenerateEncounterShips(m.encounterModel.encounter)
-		m.combat.enemyTypes = make(map[string]*models.ShipType)
-		for _, ship := range m.combat.enemyShips {
-			m.combat.enemyTypes[ship.TypeID] = models.GetShipTypeByID(ship.TypeID)
-		}
-
-		m.screen = ScreenCombat
+		m.startCombat(m.encounterModel.generator.GenerateEncounterShips(m.encounterModel.encounter))
 		return m, nil
 
 	case "flee":
@@ -192,16 +181,7 @@ func (m Model) handleEncounterOption() (tea.Model, tea.Cmd) {
 			m.encounterModel.resolved = true
 
 			// Initialize combat
-			m.combat = newCombatModel()
-			m.combat.playerShip = m.currentShip
-			m.combat.playerType = models.GetShipTypeByID(m.currentShip.TypeID)
-			m.combat.enemyShips = m.encounterModel.generator.GenerateEncounterShips(m.encounterModel.encounter)
-			m.combat.enemyTypes = make(map[string]*models.ShipType)
-			for _, ship := range m.combat.enemyShips {
-				m.combat.enemyTypes[ship.TypeID] = models.GetShipTypeByID(ship.TypeID)
-			}
-
-			m.screen = ScreenCombat
+			m.startCombat(m.encounterModel.generator.GenerateEncounterShips(m.encounterModel.encounter))
 			return m, nil
 		}
 
@@ -248,18 +228,10 @@ func (m Model) handleEncounterOption() (tea.Model, tea.Cmd) {
 			m.encounterModel.encounter.Hostile = true
 
 			// Start combat
-			m.combat = newCombatModel()
-			m.combat.playerShip = m.currentShip
-			m.combat.playerType = models.GetShipTypeByID(m.currentShip.TypeID)
-			m.combat.enemyShips = m.encounterModel.generator.GenerateEncounterShips(m.encounterModel.encounter)
-			m.combat.enemyTypes = make(map[string]*models.ShipType)
-			for _, ship := range m.combat.enemyShips {
-				m.combat.enemyTypes[ship.TypeID] = models.GetShipTypeByID(ship.TypeID)
-			}
+			m.startCombat(m.encounterModel.generator.GenerateEncounterShips(m.encounterModel.encounter))
 
 			m.encounterModel.encounter.Resolve()
 			m.encounterModel.resolved = true
-			m.screen = ScreenCombat
 			return m, nil
 		} else {
 			m.encounterModel.message = "Scan complete. You're clear to proceed."
@@ -309,16 +281,7 @@ func (m Model) handleEncounterOption() (tea.Model, tea.Cmd) {
 				m.encounterModel.encounter.Hostile = true
 
 				// Start combat
-				m.combat = newCombatModel()
-				m.combat.playerShip = m.currentShip
-				m.combat.playerType = models.GetShipTypeByID(m.currentShip.TypeID)
-				m.combat.enemyShips = m.encounterModel.generator.GenerateEncounterShips(m.encounterModel.encounter)
-				m.combat.enemyTypes = make(map[string]*models.ShipType)
-				for _, ship := range m.combat.enemyShips {
-					m.combat.enemyTypes[ship.TypeID] = models.GetShipTypeByID(ship.TypeID)
-				}
-
-				m.screen = ScreenCombat
+				m.startCombat(m.encounterModel.generator.GenerateEncounterShips(m.encounterModel.encounter))
 				m.encounterModel.encounter.Resolve()
 				m.encounterModel.resolved = true
 				return m, nil
@@ -1,7 +1,7 @@
 // File: internal/tui/factions.go
 // Project: Terminal Velocity
 // Description: Factions screen - Player faction management with creation and membership
-// Version: 1.1.0
+// Version: 1.3.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 //
@@ -13,6 +13,7 @@
 // - Member role display (Leader, Officers, Members)
 // - Recruitment status indicators
 // - Faction statistics dashboard
+// - Faction territory with control, upkeep and contest status
 //
 // View Modes:
 //   - list: Browse all factions on server
@@ -31,6 +32,8 @@
 //   - Treasury balance
 //   - Founded date
 //   - Recruitment status
+//   - Claimed systems; officers can claim or contest the current system
+//   - Diplomacy screen for alliances, wars and treaties (see diplomacy.go)
 //
 // Visual Features:
 //   - [Recruiting] badge for open factions
@@ -41,10 +44,13 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/territory"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/uuid"
 )
@@ -58,6 +64,13 @@ type factionsModel struct {
 	createTag   string // Faction tag input (creation mode)
 	createAlign string // Faction alignment input (creation mode)
 	inputField  int    // Active input field in creation: 0=name, 1=tag, 2=alignment
+	message     string // Result of the last territory action
+}
+
+// territoryActionMsg reports the result of claiming or contesting a system
+type territoryActionMsg struct {
+	message string
+	err     error
 }
 
 // newFactionsModel creates and initializes a new factions screen model.
@@ -78,6 +91,9 @@ func newFactionsModel() factionsModel {
 //   - down/j: Move cursor down in faction list
 //   - c: Enter faction creation mode
 //   - v: View current faction details
+//   - t: Claim the current system (my_faction, officers)
+//   - x: Contest the current system (my_faction, officers)
+//   - d: Open the diplomacy screen (my_faction)
 //
 // Key Bindings (Create Mode):
 //   - esc: Cancel creation, return to list
@@ -135,6 +151,31 @@ func (m Model) updateFactions(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "v":
 			// View my faction
 			m.factionsModel.viewMode = "my_faction"
+			m.factionsModel.message = ""
+
+		case "t":
+			if m.factionsModel.viewMode == "my_faction" {
+				return m, m.claimCurrentSystem()
+			}
+
+		case "x":
+			if m.factionsModel.viewMode == "my_faction" {
+				return m, m.contestCurrentSystem()
+			}
+
+		case "d":
+			if m.factionsModel.viewMode == "my_faction" {
+				m.diplomacyModel = newDiplomacyModel()
+				m.screen = ScreenDiplomacy
+				return m, tea.ClearScreen
+			}
+		}
+
+	case territoryActionMsg:
+		if msg.err != nil {
+			m.factionsModel.message = errorStyle.Render(fmt.Sprintf("Failed: %v", msg.err))
+		} else {
+			m.factionsModel.message = successStyle.Render(msg.message)
 		}
 	}
 
@@ -177,7 +218,7 @@ func (m Model) updateFactionsCreate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if len(msg.String()) == 1 {
 			if m.factionsModel.inputField == 0 && len(m.factionsModel.createName) < 30 {
 				m.factionsModel.createName += msg.String()
-			} else if m.factionsModel.inputField == 1 && len(m.factionsModel.createTag) < 5 {
+			} else if m.factionsModel.inputField == 1 && len(m.factionsModel.createTag) < 4 {
 				m.factionsModel.createTag += strings.ToUpper(msg.String())
 			}
 		}
@@ -303,6 +344,7 @@ func (m Model) viewFactionsCreate() string {
 //   - Title: Faction name with tag
 //   - Faction info: Leader, Founded, Members, Level, Treasury, Alignment
 //   - Member list: Leader, Officers (⭐), Members (👤)
+//   - Territory: Claimed systems with control, upkeep and contests
 //   - Footer with controls
 //
 // Returns error message if player not in a faction.
@@ -335,7 +377,95 @@ func (m Model) viewMyFaction() string {
 		}
 	}
 
-	s += "\n" + renderFooter("ESC: Back to List")
+	// Territory
+	s += "\nTerritory:\n"
+	territories := m.territoryManager.GetFactionTerritories(faction.ID)
+	if len(territories) == 0 {
+		s += helpStyle.Render("  No systems claimed") + "\n"
+	}
+	for _, t := range territories {
+		s += renderTerritory(t)
+	}
+
+	if m.factionsModel.message != "" {
+		s += "\n" + m.factionsModel.message + "\n"
+	}
+
+	s += "\n" + renderFooter("T: Claim System | X: Contest System | D: Diplomacy | ESC: Back to List")
+	return s
+}
+
+// claimCurrentSystem claims the player's current system for their faction.
+// Only officers and the leader can claim territory.
+func (m Model) claimCurrentSystem() tea.Cmd {
+	return func() tea.Msg {
+		faction, err := m.factionManager.GetPlayerFaction(m.playerID)
+		if err != nil {
+			return territoryActionMsg{err: err}
+		}
+		if !faction.IsOfficer(m.playerID) || m.player == nil {
+			return territoryActionMsg{err: fmt.Errorf("only officers can claim territory")}
+		}
+
+		systemName := m.player.CurrentSystem.String()
+		if m.systemRepo != nil {
+			system, err := m.systemRepo.GetSystemByID(context.Background(), m.player.CurrentSystem)
+			if err != nil {
+				return territoryActionMsg{err: err}
+			}
+			systemName = system.Name
+		}
+
+		if _, err := m.territoryManager.ClaimSystem(m.player.CurrentSystem, systemName, faction.ID, faction.Tag); err != nil {
+			return territoryActionMsg{err: err}
+		}
+		return territoryActionMsg{message: fmt.Sprintf("Claimed %s for %s", systemName, faction.GetFullName())}
+	}
+}
+
+// contestCurrentSystem challenges another faction's claim on the player's
+// current system, paying the contest fee from the treasury
+func (m Model) contestCurrentSystem() tea.Cmd {
+	return func() tea.Msg {
+		faction, err := m.factionManager.GetPlayerFaction(m.playerID)
+		if err != nil {
+			return territoryActionMsg{err: err}
+		}
+		if m.player == nil {
+			return territoryActionMsg{err: territory.ErrNotClaimed}
+		}
+
+		t, err := m.territoryManager.ContestSystem(m.player.CurrentSystem, faction.ID, m.playerID)
+		if err != nil {
+			return territoryActionMsg{err: err}
+		}
+		return territoryActionMsg{message: fmt.Sprintf("Contesting %s held by [%s] for %d CR - out-influence them within %s",
+			t.SystemName, t.FactionTag, territory.ContestFee, territory.ContestDuration)}
+	}
+}
+
+// renderTerritory renders one claimed system with its control, upkeep and
+// any contest against it
+func renderTerritory(t *models.Territory) string {
+	s := fmt.Sprintf("  %s %s - %s", t.ControlLevel.GetColorIndicator(), t.SystemName, t.ControlLevel.GetDisplayName())
+	if t.Contest == nil {
+		s += fmt.Sprintf(" (%d pts)", t.ControlPoints)
+	}
+	s += fmt.Sprintf(" | Upkeep %d CR, %s", t.UpkeepCost, t.GetUpkeepStatus())
+	if t.MissedUpkeeps > 0 {
+		s += errorStyle.Render(fmt.Sprintf(" | %d missed", t.MissedUpkeeps))
+	}
+	s += "\n"
+
+	if t.Contest != nil {
+		phase := "Contested"
+		if t.Contest.Phase == models.ContestPhaseSiege {
+			phase = "Under siege"
+		}
+		s += errorStyle.Render(fmt.Sprintf("     ⚔ %s by [%s]: %d vs %d, ends in %s",
+			phase, t.Contest.AttackerTag, t.Contest.AttackerPoints, t.Contest.DefenderPoints,
+			time.Until(t.Contest.EndsAt).Round(time.Minute))) + "\n"
+	}
 	return s
 }
 
@@ -1,7 +1,7 @@
 // File: internal/tui/login.go
 // Project: Terminal Velocity
 // Description: Login screen - Authenticates players via username/password with ASCII branding
-// Version: 2.1.0
+// Version: 2.3.0
 // Author: Joshua Ferguson
 // Created: 2025-01-14
 //
@@ -17,10 +17,13 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/admin"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/security"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/uuid"
 )
@@ -67,8 +70,9 @@ func newLoginModel() loginModel {
 // loginSuccessMsg is a BubbleTea message sent when authentication succeeds.
 // Contains the authenticated player's ID and username.
 type loginSuccessMsg struct {
-	playerID uuid.UUID // Authenticated player's unique identifier
-	username string    // Authenticated player's username
+	playerID  uuid.UUID // Authenticated player's unique identifier
+	username  string    // Authenticated player's username
+	sessionID uuid.UUID // Security session (uuid.Nil without a security manager)
 }
 
 // loginFailureMsg is a BubbleTea message sent when authentication fails.
@@ -353,6 +357,7 @@ func (m Model) viewLogin() string {
 //   2. User presses enter on login button
 //   3. isAuthenticating flag set, async authentication starts
 //   4. On success: loginSuccessMsg received, loads player data
+//      (or loginTwoFactorMsg: switches to the 2FA code challenge)
 //   5. On failure: loginFailureMsg received, shows error
 //
 // State Transitions:
@@ -367,7 +372,13 @@ func (m Model) updateLogin(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.loginModel.isAuthenticating = false
 			m.playerID = msg.playerID
 			m.username = msg.username
+			m.securitySession = msg.sessionID
 			return m, m.loadPlayer()
+		case loginTwoFactorMsg:
+			// Password accepted - ask for the 2FA code
+			m.loginModel.isAuthenticating = false
+			m.loginModel.password = ""
+			return m.startTwoFactorChallenge(msg.playerID, msg.username), nil
 		case loginFailureMsg:
 			// Login failed - show error
 			m.loginModel.isAuthenticating = false
@@ -454,14 +465,33 @@ func (m Model) updateLogin(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Transition to main menu, or wherever the player's last session left off
+		m.screen = ScreenMainMenu
+		resumed, sessionCmd, err := m.openGameSession()
+		if err != nil {
+			// Duplicate login refused
+			m.screen = ScreenLogin
+			m.loginModel.error = refuseDuplicateLogin(err)
+			if m.securityManager != nil && m.securitySession != uuid.Nil {
+				m.securityManager.OnLogout(m.securitySession)
+			}
+			m.securitySession = uuid.Nil
+			m.player = nil
+			m.currentShip = nil
+			m.playerID = uuid.Nil
+			m.username = ""
+			return m, nil
+		}
+		m = resumed
+
 		// Initialize presence when player loads
+		var cmd tea.Cmd
 		if m.player != nil {
 			m.InitializePresence()
+			cmd = tea.Batch(sessionCmd, m.subscribeWorld(), m.subscribePlayerUpdates(), m.checkTwoFactorPolicy())
 		}
 
-		// Transition to main menu
-		m.screen = ScreenMainMenu
-		return m, nil
+		return m, cmd
 	}
 
 	return m, nil
@@ -496,9 +526,14 @@ func (m Model) handleLogin() (Model, tea.Cmd) {
 // authenticateUser performs async authentication against the database.
 // Returns a tea.Cmd that executes the authentication in the background.
 //
+// Every attempt goes through the security manager: honeypot usernames are
+// rejected (and their IP banned) before the password is checked, and the
+// result is recorded in the player's login history.
+//
 // Success Flow:
 //   - Returns loginSuccessMsg with player ID and username
 //   - Triggers player data loading
+//   - With 2FA enabled, returns loginTwoFactorMsg instead (code challenge)
 //
 // Failure Flow:
 //   - Returns loginFailureMsg with error message
@@ -506,23 +541,93 @@ func (m Model) handleLogin() (Model, tea.Cmd) {
 //
 // Database Errors:
 //   - ErrInvalidCredentials: User-friendly message
+//   - Active ban: Ban reason and expiry
 //   - Other errors: Technical error message
 func (m Model) authenticateUser() tea.Cmd {
+	username := m.loginModel.username
+	password := m.loginModel.password
 	return func() tea.Msg {
 		ctx := context.Background()
 
+		// Look up the account so honeypot names only trap unknown usernames
+		var knownID uuid.UUID
+		existing, err := m.playerRepo.GetByUsername(ctx, username)
+		if err == nil {
+			knownID = existing.ID
+		} else if err != database.ErrPlayerNotFound {
+			return loginFailureMsg{error: fmt.Sprintf("Authentication error: %v", err)}
+		}
+
+		if m.securityManager != nil {
+			err := m.securityManager.ValidateLogin(username, password, m.remoteIP, m.clientVersion, knownID)
+			if err != nil && !errors.Is(err, security.ErrChallengeRequired) {
+				return loginFailureMsg{error: "Invalid username or password"}
+			}
+		}
+
 		// Authenticate user
-		player, err := m.playerRepo.Authenticate(ctx, m.loginModel.username, m.loginModel.password)
+		player, err := m.playerRepo.Authenticate(ctx, username, password)
 		if err != nil {
+			m.recordLoginFailure(knownID, username, "invalid_password")
 			if err == database.ErrInvalidCredentials {
 				return loginFailureMsg{error: "Invalid username or password"}
 			}
 			return loginFailureMsg{error: fmt.Sprintf("Authentication error: %v", err)}
 		}
 
+		// Banned players never get past the login screen
+		if m.adminManager != nil {
+			ban, err := m.adminManager.CheckBan(ctx, player.ID)
+			if err != nil {
+				return loginFailureMsg{error: fmt.Sprintf("Authentication error: %v", err)}
+			}
+			if ban != nil {
+				m.recordLoginFailure(player.ID, player.Username, "banned")
+				return loginFailureMsg{error: admin.BanMessage(ban)}
+			}
+		}
+
+		// Players with 2FA enabled must pass the code challenge first
+		if m.securityRepo != nil {
+			enabled, err := m.securityRepo.IsTwoFactorEnabled(ctx, player.ID)
+			if err != nil {
+				return loginFailureMsg{error: fmt.Sprintf("Authentication error: %v", err)}
+			}
+			if enabled {
+				return loginTwoFactorMsg{playerID: player.ID, username: player.Username}
+			}
+		}
+
+		sessionID, err := m.recordLoginSuccess(player.ID, player.Username)
+		if err != nil {
+			return loginFailureMsg{error: err.Error()}
+		}
+
 		return loginSuccessMsg{
-			playerID: player.ID,
-			username: player.Username,
+			playerID:  player.ID,
+			username:  player.Username,
+			sessionID: sessionID,
 		}
 	}
 }
+
+// recordLoginSuccess opens a security session for a completed login and
+// records it in the player's login history.
+func (m Model) recordLoginSuccess(playerID uuid.UUID, username string) (uuid.UUID, error) {
+	if m.securityManager == nil {
+		return uuid.Nil, nil
+	}
+	session, err := m.securityManager.OnLoginSuccess(playerID, username, m.remoteIP, m.clientVersion)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return session.ID, nil
+}
+
+// recordLoginFailure records a failed login attempt. playerID is uuid.Nil
+// for usernames without an account.
+func (m Model) recordLoginFailure(playerID uuid.UUID, username, reason string) {
+	if m.securityManager != nil {
+		m.securityManager.OnLoginFailure(playerID, username, m.remoteIP, m.clientVersion, reason)
+	}
+}
@@ -0,0 +1,193 @@
+// File: internal/tui/login_history.go
+// Project: Terminal Velocity
+// Description: Recent logins screen - Player's login history with new-IP and risk flags
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// Opened from Settings > Security. Lists the player's most recent login
+// attempts (successful and failed) with the address and client used.
+// Attempts from an address the player had never logged in from before are
+// flagged NEW IP, and attempts the anomaly detector scored as high risk
+// are flagged HIGH RISK.
+
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/security"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// loginHistoryLimit is how many login attempts the screen shows
+const loginHistoryLimit = 20
+
+// highRiskScore is the risk score from which a login is flagged HIGH RISK
+// (security.DefaultConfig's AnomalyRiskThreshold)
+const highRiskScore = 50
+
+// loginFailureReasons maps recorded failure reasons to display text
+var loginFailureReasons = map[string]string{
+	"invalid_password":  "wrong password",
+	"invalid_2fa_code":  "wrong 2FA code",
+	"banned":            "account banned",
+	"max_sessions":      "too many sessions",
+	"username_mismatch": "wrong username for key",
+}
+
+// loginHistoryModel contains the state for the recent logins screen.
+type loginHistoryModel struct {
+	records []*security.LoginRecord // Newest first
+	loaded  bool
+	error   string
+	cursor  int // First visible record
+}
+
+// loginHistoryMsg carries the player's login history.
+type loginHistoryMsg struct {
+	records []*security.LoginRecord
+	err     error
+}
+
+// openLoginHistory switches to the recent logins screen and loads the
+// player's history.
+func (m Model) openLoginHistory() (tea.Model, tea.Cmd) {
+	m.screen = ScreenLoginHistory
+	m.loginHistoryModel = loginHistoryModel{}
+	return m, m.loadLoginHistory()
+}
+
+// loadLoginHistory fetches the player's recent login attempts.
+func (m Model) loadLoginHistory() tea.Cmd {
+	playerID := m.playerID
+	return func() tea.Msg {
+		if m.securityRepo == nil {
+			return loginHistoryMsg{err: errors.New("login history is unavailable")}
+		}
+		records, err := m.securityRepo.GetLoginHistory(context.Background(), playerID, loginHistoryLimit)
+		return loginHistoryMsg{records: records, err: err}
+	}
+}
+
+// updateLoginHistory handles input and async results for the recent
+// logins screen.
+//
+// Key Bindings:
+//   - up/down: Scroll
+//   - r: Refresh
+//   - esc: Back to security settings
+func (m Model) updateLoginHistory(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case loginHistoryMsg:
+		m.loginHistoryModel.loaded = true
+		m.loginHistoryModel.error = ""
+		if msg.err != nil {
+			m.loginHistoryModel.error = fmt.Sprintf("Failed to load login history: %v", msg.err)
+			return m, nil
+		}
+		m.loginHistoryModel.records = msg.records
+		m.loginHistoryModel.cursor = 0
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "backspace":
+			m.screen = ScreenTwoFactor
+			return m, nil
+
+		case "up", "k":
+			if m.loginHistoryModel.cursor > 0 {
+				m.loginHistoryModel.cursor--
+			}
+
+		case "down", "j":
+			if m.loginHistoryModel.cursor < len(m.loginHistoryModel.records)-1 {
+				m.loginHistoryModel.cursor++
+			}
+
+		case "r":
+			m.loginHistoryModel.loaded = false
+			return m, m.loadLoginHistory()
+		}
+	}
+
+	return m, nil
+}
+
+// viewLoginHistory renders the recent logins screen.
+func (m Model) viewLoginHistory() string {
+	s := renderHeader(m.username, m.player.Credits, "Security")
+	s += "\n"
+	s += subtitleStyle.Render("=== Recent Logins ===") + "\n\n"
+
+	if m.loginHistoryModel.error != "" {
+		return s + errorStyle.Render(m.loginHistoryModel.error) + "\n\n" + renderFooter("R: Retry  •  ESC: Back")
+	}
+	if !m.loginHistoryModel.loaded {
+		return s + helpStyle.Render("Loading...") + "\n"
+	}
+
+	records := m.loginHistoryModel.records
+	if len(records) == 0 {
+		return s + helpStyle.Render("No logins recorded yet.") + "\n\n" + renderFooter("ESC: Back")
+	}
+
+	newIPs := 0
+	for _, record := range records {
+		if record.NewIP {
+			newIPs++
+		}
+	}
+	if newIPs > 0 {
+		s += errorStyle.Render(fmt.Sprintf("%d attempt(s) came from new addresses.", newIPs)) + "\n"
+		s += helpStyle.Render("If you don't recognize them, change your password and enable two-factor authentication.") + "\n\n"
+	}
+
+	s += fmt.Sprintf("  %-16s  %-39s  %-24s  %s\n", "WHEN", "ADDRESS", "RESULT", "FLAGS")
+
+	// Leave room for the header, summary and footer
+	visible := m.height - 14
+	if visible < 5 {
+		visible = 5
+	}
+	end := m.loginHistoryModel.cursor + visible
+	if end > len(records) {
+		end = len(records)
+	}
+
+	for _, record := range records[m.loginHistoryModel.cursor:end] {
+		result := successStyle.Render(PadRight("OK", 24))
+		if !record.Success {
+			reason, ok := loginFailureReasons[record.FailureReason]
+			if !ok {
+				reason = record.FailureReason
+			}
+			result = errorStyle.Render(PadRight("FAILED - "+reason, 24))
+		}
+
+		var flags string
+		if record.NewIP {
+			flags += errorStyle.Render("NEW IP") + " "
+		}
+		if record.RiskScore >= highRiskScore {
+			flags += errorStyle.Render(fmt.Sprintf("HIGH RISK (%d)", record.RiskScore))
+		}
+
+		s += fmt.Sprintf("  %-16s  %-39s  %s  %s\n",
+			record.Timestamp.Format("2006-01-02 15:04"),
+			record.IPAddress,
+			result,
+			flags,
+		)
+	}
+
+	if len(records) > visible {
+		s += helpStyle.Render(fmt.Sprintf("\n  Showing %d-%d of %d", m.loginHistoryModel.cursor+1, end, len(records))) + "\n"
+	}
+
+	s += "\n" + renderFooter("↑/↓: Scroll  •  R: Refresh  •  ESC: Back")
+	return s
+}
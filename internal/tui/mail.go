@@ -192,7 +192,9 @@ func (m *Model) updateMail(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.mail.loading = false
 		if msg.err == "" {
 			// Update player credits if any were claimed
-			if msg.credits > 0 {
+			if msg.newCredits >= 0 {
+				m.player.Credits = msg.newCredits
+			} else if msg.credits > 0 {
 				m.player.Credits += msg.credits
 			}
 			// Reload mail to show updated attachment status
@@ -921,13 +923,18 @@ func (m *Model) claimMailAttachments(mailID uuid.UUID) tea.Cmd {
 		credits, err := m.mailManager.ClaimAttachments(ctx, mailID, m.playerID)
 
 		errStr := ""
+		newCredits := int64(-1)
 		if err != nil {
 			errStr = err.Error()
+		} else if player, loadErr := m.playerRepo.GetByID(ctx, m.playerID); loadErr == nil {
+			// Use the stored balance so a concurrent update stream event can't double count
+			newCredits = player.Credits
 		}
 
 		return mailClaimedMsg{
-			credits: credits,
-			err:     errStr,
+			credits:    credits,
+			newCredits: newCredits,
+			err:        errStr,
 		}
 	}
 }
@@ -965,6 +972,7 @@ type mailDeletedMsg struct {
 }
 
 type mailClaimedMsg struct {
-	credits int64
-	err     string
+	credits    int64
+	newCredits int64 // Balance after the claim, -1 if it could not be read
+	err        string
 }
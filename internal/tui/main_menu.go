@@ -1,7 +1,7 @@
 // File: internal/tui/main_menu.go
 // Project: Terminal Velocity
 // Description: Main menu screen - Central navigation hub for accessing all game features
-// Version: 1.1.0
+// Version: 1.6.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 //
@@ -55,6 +55,9 @@ func newMainMenuModel() mainMenuModel {
 			{label: "Players", screen: ScreenPlayers},
 			{label: "Chat", screen: ScreenChat},
 			{label: "Factions", screen: ScreenFactions},
+			{label: "Manufacturing", screen: ScreenManufacturing},
+			{label: "Arena", screen: ScreenArena},
+			{label: "Mining", screen: ScreenMining},
 			{label: "Trade", screen: ScreenTrade},
 			{label: "PvP Combat", screen: ScreenPvP},
 			{label: "News", screen: ScreenNews},
@@ -104,80 +107,112 @@ func (m Model) updateMainMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			// Otherwise, navigate to the target screen
-			m.screen = selected.screen
+			return m.enterScreen(selected.screen)
+		}
+	}
 
-			// Initialize screen-specific data
-			if selected.screen == ScreenNavigation {
-				m.navigation = newNavigationModel()
-				return m, m.loadConnectedSystems()
-			}
-			if selected.screen == ScreenTrading {
-				m.trading = newTradingModel()
-				return m, m.loadTradingMarket()
-			}
-			if selected.screen == ScreenCargo {
-				m.cargo = newCargoModel()
-				return m, nil
-			}
-			if selected.screen == ScreenShipyard {
-				m.shipyard = newShipyardModel()
-				return m, m.loadShipyard()
-			}
-			if selected.screen == ScreenOutfitter {
-				m.outfitter = newOutfitterModel()
-				return m, m.loadOutfitter()
-			}
-			if selected.screen == ScreenOutfitterEnhanced {
-				m.outfitterEnhanced = newOutfitterEnhancedModel()
-				// Load player inventory and loadouts
-				m.outfitterEnhanced.inventory = m.outfittingManager.GetPlayerInventory(m.playerID)
-				m.outfitterEnhanced.loadouts = m.outfittingManager.GetPlayerLoadouts(m.playerID)
-				return m, nil
-			}
-			if selected.screen == ScreenShipManagement {
-				m.shipManagement = newShipManagementModel()
-				return m, m.loadOwnedShips()
-			}
-			if selected.screen == ScreenLeaderboards {
-				m.leaderboardsModel = newLeaderboardsModel()
-				return m, m.refreshLeaderboards()
-			}
-			if selected.screen == ScreenSettings {
-				m.settingsModel = newSettingsModel()
-				// Load player settings
-				if playerSettings, err := m.settingsManager.LoadSettings(m.playerID); err == nil {
-					m.settingsModel.settings = playerSettings
-				}
-				return m, nil
-			}
-			if selected.screen == ScreenAdmin {
-				m.adminModel = newAdminModel()
-				// Check if player is admin
-				m.adminModel.isAdmin = m.adminManager.IsAdmin(m.playerID)
-				if m.adminModel.isAdmin {
-					// Get admin role from manager
-					// For now, default to moderator
-					m.adminModel.role = "moderator"
-				}
-				return m, nil
-			}
-			if selected.screen == ScreenTutorial {
-				m.tutorialModel = newTutorialModel()
-				m.tutorialModel.viewMode = tutorialViewList
-				m.tutorialModel.allTutorials = m.tutorialManager.GetAllTutorials()
-				return m, nil
-			}
-			if selected.screen == ScreenQuests {
-				m.questsModel = newQuestsModel()
-				m.questsModel.viewMode = questViewActive
-				m.questsModel.activeQuests = m.questManager.GetActiveQuests(m.playerID)
-				m.questsModel.availableQuests = m.questManager.GetAvailableQuests(m.playerID)
-				m.questsModel.completedQuests = m.questManager.GetCompletedQuests(m.playerID)
-				return m, nil
-			}
+	return m, nil
+}
+
+// enterScreen switches to a screen reachable from the main menu and
+// initializes its state (loading data, setting up models). Also used to
+// reopen a resumed session's screen.
+func (m Model) enterScreen(screen Screen) (Model, tea.Cmd) {
+	m.screen = screen
 
-			return m, nil
+	// Initialize screen-specific data
+	if screen == ScreenNavigation {
+		m.navigation = newNavigationModel()
+		return m, m.loadConnectedSystems()
+	}
+	if screen == ScreenTrading {
+		m.trading = newTradingModel()
+		return m, m.loadTradingMarket()
+	}
+	if screen == ScreenCargo {
+		m.cargo = newCargoModel()
+		return m, nil
+	}
+	if screen == ScreenShipyard {
+		m.shipyard = newShipyardModel()
+		return m, m.loadShipyard()
+	}
+	if screen == ScreenOutfitter {
+		m.outfitter = newOutfitterModel()
+		return m, m.loadOutfitter()
+	}
+	if screen == ScreenOutfitterEnhanced {
+		m.outfitterEnhanced = newOutfitterEnhancedModel()
+		// Load player inventory and loadouts
+		m.outfitterEnhanced.inventory = m.outfittingManager.GetPlayerInventory(m.playerID)
+		m.outfitterEnhanced.loadouts = m.outfittingManager.GetPlayerLoadouts(m.playerID)
+		return m, nil
+	}
+	if screen == ScreenShipManagement {
+		m.shipManagement = newShipManagementModel()
+		return m, m.loadOwnedShips()
+	}
+	if screen == ScreenLeaderboards {
+		m.leaderboardsModel = newLeaderboardsModel()
+		return m, m.refreshLeaderboards()
+	}
+	if screen == ScreenSettings {
+		m.settingsModel = newSettingsModel()
+		// Load player settings
+		if playerSettings, err := m.settingsManager.LoadSettings(m.playerID); err == nil {
+			m.settingsModel.settings = playerSettings
 		}
+		return m, nil
+	}
+	if screen == ScreenAdmin {
+		m.adminModel = newAdminModel()
+		// Check if player is admin
+		m.adminModel.isAdmin = m.adminManager.IsAdmin(m.playerID)
+		if m.adminModel.isAdmin {
+			// Get admin role from manager
+			// For now, default to moderator
+			m.adminModel.role = "moderator"
+		}
+		return m, nil
+	}
+	if screen == ScreenTutorial {
+		m.tutorialModel = newTutorialModel()
+		m.tutorialModel.viewMode = tutorialViewList
+		m.tutorialModel.allTutorials = m.tutorialManager.GetAllTutorials()
+		return m, nil
+	}
+	if screen == ScreenManufacturing {
+		docked := m.manufacturingModel.dockedStationID
+		m.manufacturingModel = newManufacturingModel()
+		m.manufacturingModel.dockedStationID = docked
+		return m, nil
+	}
+	if screen == ScreenPvP {
+		// Rejoin a live battle rather than the challenge list
+		if view, exists := m.pvpManager.GetBattle(m.playerID); exists && !view.Over {
+			return m.openPvPBattle()
+		}
+		return m, nil
+	}
+	if screen == ScreenArena {
+		tickID := m.arenaModel.tickID + 1
+		m.arenaModel = newArenaModel()
+		m.arenaModel.tickID = tickID
+		return m, arenaTick(tickID)
+	}
+	if screen == ScreenMining {
+		tickID := m.miningModel.tickID + 1
+		m.miningModel = newMiningModel()
+		m.miningModel.tickID = tickID
+		return m, tea.Batch(m.loadMining(), miningTick(tickID))
+	}
+	if screen == ScreenQuests {
+		m.questsModel = newQuestsModel()
+		m.questsModel.viewMode = questViewActive
+		m.questsModel.activeQuests = m.questManager.GetActiveQuests(m.playerID)
+		m.questsModel.availableQuests = m.questManager.GetAvailableQuests(m.playerID)
+		m.questsModel.completedQuests = m.questManager.GetCompletedQuests(m.playerID)
+		return m, nil
 	}
 
 	return m, nil
@@ -0,0 +1,542 @@
+// File: internal/tui/manufacturing.go
+// Project: Terminal Velocity
+// Description: Manufacturing screen - blueprints, crafting jobs, technology research and player stations
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// The manufacturing screen is reached from the main menu, or by docking at a
+// player station in space. It has four tabs:
+// - Blueprints: Craft items from commodities in the current ship's cargo
+// - Jobs: Crafting jobs in progress and recently finished
+// - Research: Spend research points and credits on technologies
+// - Stations: Build, upgrade and equip the player's stations
+//
+// Crafting while docked at one of the player's stations with a
+// manufacturing facility uses the station's production bonus, and weapon
+// and outfit output goes to the station's storage instead of the ship.
+//
+// Crafting jobs run on the server and finish whether or not the player is
+// online. Cargo, credits and progress changes arrive as player updates.
+
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/manufacturing"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+// Manufacturing tabs, in the order tab cycles through them
+const (
+	manufacturingTabBlueprints = "blueprints" // Craft from blueprints
+	manufacturingTabJobs       = "jobs"       // Crafting jobs
+	manufacturingTabResearch   = "research"   // Technology research
+	manufacturingTabStations   = "stations"   // Player stations
+)
+
+// manufacturingTabs lists the tabs in display order
+var manufacturingTabs = []string{
+	manufacturingTabBlueprints,
+	manufacturingTabJobs,
+	manufacturingTabResearch,
+	manufacturingTabStations,
+}
+
+// manufacturingModel contains the state for the manufacturing screen
+type manufacturingModel struct {
+	tab             string    // Current tab: "blueprints", "jobs", "research", "stations"
+	cursor          int       // Selected row in the current tab
+	quantity        int       // Items to craft from the selected blueprint
+	facilityIndex   int       // Facility to add in the stations tab (index into models.StationFacilities)
+	dockedStationID uuid.UUID // Station the player is docked at (uuid.Nil if none)
+	message         string    // Result of the last action
+}
+
+// manufacturingActionMsg reports the result of a manufacturing action
+type manufacturingActionMsg struct {
+	message string
+	err     error
+}
+
+// newManufacturingModel creates a manufacturing screen model on the blueprints tab
+func newManufacturingModel() manufacturingModel {
+	return manufacturingModel{
+		tab:      manufacturingTabBlueprints,
+		quantity: 1,
+	}
+}
+
+// updateManufacturing handles input for the manufacturing screen.
+//
+// Key Bindings (all tabs):
+//   - esc/backspace/q: Return to the main menu (undocks from a station)
+//   - tab: Next tab
+//   - up/k, down/j: Select a row
+//
+// Key Bindings (per tab):
+//   - Blueprints: +/- change quantity, enter crafts
+//   - Jobs: x cancels the selected job
+//   - Research: enter researches the selected technology
+//   - Stations: b builds a station here, u upgrades, f cycles the facility,
+//     a adds it, enter docks at the selected station
+func (m Model) updateManufacturing(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "backspace", "q":
+			m.manufacturingModel.dockedStationID = uuid.Nil
+			m.screen = ScreenMainMenu
+			return m, tea.ClearScreen
+
+		case "tab":
+			for i, tab := range manufacturingTabs {
+				if tab == m.manufacturingModel.tab {
+					m.manufacturingModel.tab = manufacturingTabs[(i+1)%len(manufacturingTabs)]
+					break
+				}
+			}
+			m.manufacturingModel.cursor = 0
+			m.manufacturingModel.message = ""
+			return m, nil
+
+		case "up", "k":
+			if m.manufacturingModel.cursor > 0 {
+				m.manufacturingModel.cursor--
+			}
+			return m, nil
+
+		case "down", "j":
+			if m.manufacturingModel.cursor < m.manufacturingRows()-1 {
+				m.manufacturingModel.cursor++
+			}
+			return m, nil
+		}
+
+		switch m.manufacturingModel.tab {
+		case manufacturingTabBlueprints:
+			return m.updateManufacturingBlueprints(msg)
+		case manufacturingTabJobs:
+			return m.updateManufacturingJobs(msg)
+		case manufacturingTabResearch:
+			return m.updateManufacturingResearch(msg)
+		default:
+			return m.updateManufacturingStations(msg)
+		}
+
+	case manufacturingActionMsg:
+		if msg.err != nil {
+			m.manufacturingModel.message = errorStyle.Render(fmt.Sprintf("Failed: %v", msg.err))
+		} else {
+			m.manufacturingModel.message = successStyle.Render(msg.message)
+		}
+		if m.manufacturingModel.cursor >= m.manufacturingRows() {
+			m.manufacturingModel.cursor = 0
+		}
+	}
+
+	return m, nil
+}
+
+// updateManufacturingBlueprints handles blueprint tab keys
+func (m Model) updateManufacturingBlueprints(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	blueprints := m.manufacturingManager.GetBlueprints()
+	maxQuantity := manufacturing.DefaultManufacturingConfig().MaxCraftQuantity
+
+	switch msg.String() {
+	case "+", "=":
+		if m.manufacturingModel.quantity < maxQuantity {
+			m.manufacturingModel.quantity++
+		}
+	case "-", "_":
+		if m.manufacturingModel.quantity > 1 {
+			m.manufacturingModel.quantity--
+		}
+	case "enter", " ":
+		if m.manufacturingModel.cursor < len(blueprints) {
+			return m, m.startCrafting(blueprints[m.manufacturingModel.cursor], m.manufacturingModel.quantity)
+		}
+	}
+	return m, nil
+}
+
+// updateManufacturingJobs handles jobs tab keys
+func (m Model) updateManufacturingJobs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	jobs := m.manufacturingManager.GetCraftingJobs(m.playerID)
+	if msg.String() == "x" && m.manufacturingModel.cursor < len(jobs) {
+		job := jobs[m.manufacturingModel.cursor]
+		return m, func() tea.Msg {
+			if err := m.manufacturingManager.CancelCrafting(context.Background(), job.ID, m.playerID); err != nil {
+				return manufacturingActionMsg{err: err}
+			}
+			return manufacturingActionMsg{message: fmt.Sprintf("Cancelled %s - unspent materials returned to cargo", job.Blueprint.Name)}
+		}
+	}
+	return m, nil
+}
+
+// updateManufacturingResearch handles research tab keys
+func (m Model) updateManufacturingResearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	technologies := m.manufacturingManager.GetAllTechnologies()
+	if (msg.String() == "enter" || msg.String() == " ") && m.manufacturingModel.cursor < len(technologies) {
+		tech := technologies[m.manufacturingModel.cursor]
+		return m, func() tea.Msg {
+			if err := m.manufacturingManager.ResearchTechnology(context.Background(), m.playerID, tech.ID); err != nil {
+				return manufacturingActionMsg{err: err}
+			}
+			level := m.manufacturingManager.GetPlayerTechnologies(m.playerID)[tech.ID]
+			return manufacturingActionMsg{message: fmt.Sprintf("Researched %s level %d", tech.Name, level)}
+		}
+	}
+	return m, nil
+}
+
+// updateManufacturingStations handles stations tab keys
+func (m Model) updateManufacturingStations(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	stations := m.manufacturingManager.GetPlayerStations(m.playerID)
+	var selected *manufacturing.PlayerStation
+	if m.manufacturingModel.cursor < len(stations) {
+		selected = stations[m.manufacturingModel.cursor]
+	}
+
+	switch msg.String() {
+	case "b":
+		return m, m.buildStation(len(stations) + 1)
+
+	case "f":
+		m.manufacturingModel.facilityIndex = (m.manufacturingModel.facilityIndex + 1) % len(models.StationFacilities)
+
+	case "u":
+		if selected != nil {
+			return m, func() tea.Msg {
+				if err := m.manufacturingManager.UpgradeStation(context.Background(), selected.ID, m.playerID); err != nil {
+					return manufacturingActionMsg{err: err}
+				}
+				return manufacturingActionMsg{message: fmt.Sprintf("Upgraded %s to level %d", selected.Name, selected.Level+1)}
+			}
+		}
+
+	case "a":
+		if selected != nil {
+			facility := models.StationFacilities[m.manufacturingModel.facilityIndex]
+			return m, func() tea.Msg {
+				if err := m.manufacturingManager.AddFacility(context.Background(), selected.ID, m.playerID, facility); err != nil {
+					return manufacturingActionMsg{err: err}
+				}
+				return manufacturingActionMsg{message: fmt.Sprintf("Added a %s facility to %s", facility, selected.Name)}
+			}
+		}
+
+	case "enter", " ":
+		if selected != nil {
+			if m.player == nil || selected.SystemID != m.player.CurrentSystem {
+				m.manufacturingModel.message = errorStyle.Render(fmt.Sprintf("%s is in %s - travel there to dock", selected.Name, selected.SystemName))
+				return m, nil
+			}
+			m.manufacturingModel.dockedStationID = selected.ID
+			m.manufacturingModel.message = successStyle.Render(fmt.Sprintf("Docked at %s", selected.Name))
+		}
+	}
+	return m, nil
+}
+
+// manufacturingRows returns the number of selectable rows in the current tab
+func (m Model) manufacturingRows() int {
+	if m.manufacturingManager == nil {
+		return 0
+	}
+	switch m.manufacturingModel.tab {
+	case manufacturingTabBlueprints:
+		return len(m.manufacturingManager.GetBlueprints())
+	case manufacturingTabJobs:
+		return len(m.manufacturingManager.GetCraftingJobs(m.playerID))
+	case manufacturingTabResearch:
+		return len(m.manufacturingManager.GetAllTechnologies())
+	default:
+		return len(m.manufacturingManager.GetPlayerStations(m.playerID))
+	}
+}
+
+// dockedStation returns the station the player is docked at, or nil if they
+// are not docked or the station is no longer in their system
+func (m Model) dockedStation() *manufacturing.PlayerStation {
+	if m.manufacturingModel.dockedStationID == uuid.Nil || m.player == nil {
+		return nil
+	}
+	station, err := m.manufacturingManager.GetStation(m.manufacturingModel.dockedStationID)
+	if err != nil || station.SystemID != m.player.CurrentSystem {
+		return nil
+	}
+	return station
+}
+
+// startCrafting starts a crafting job, at the docked station if it is the
+// player's own
+func (m Model) startCrafting(blueprint *manufacturing.Blueprint, quantity int) tea.Cmd {
+	var stationID *uuid.UUID
+	if station := m.dockedStation(); station != nil && station.OwnerID == m.playerID {
+		stationID = &station.ID
+	}
+	return func() tea.Msg {
+		job, err := m.manufacturingManager.StartCrafting(context.Background(), m.playerID, blueprint.ID, quantity, stationID)
+		if err != nil {
+			return manufacturingActionMsg{err: err}
+		}
+		return manufacturingActionMsg{message: fmt.Sprintf("Crafting %dx %s - ready in %s",
+			job.Quantity, blueprint.Name, job.CompletionTime.Sub(job.StartTime).Round(time.Second))}
+	}
+}
+
+// buildStation builds a new station in the player's current system
+func (m Model) buildStation(number int) tea.Cmd {
+	return func() tea.Msg {
+		if m.player == nil {
+			return manufacturingActionMsg{err: fmt.Errorf("player not loaded")}
+		}
+		systemName := m.player.CurrentSystem.String()
+		if m.systemRepo != nil {
+			system, err := m.systemRepo.GetSystemByID(context.Background(), m.player.CurrentSystem)
+			if err == nil {
+				systemName = system.Name
+			}
+		}
+
+		name := fmt.Sprintf("%s Station %d", m.player.Username, number)
+		station, err := m.manufacturingManager.BuildStation(context.Background(), m.playerID, name, m.player.CurrentSystem, systemName)
+		if err != nil {
+			return manufacturingActionMsg{err: err}
+		}
+		return manufacturingActionMsg{message: fmt.Sprintf("Built %s in %s", station.Name, station.SystemName)}
+	}
+}
+
+// commodityName returns a commodity's display name, or its ID if unknown
+func commodityName(commodityID string) string {
+	if commodity := models.GetCommodityByID(commodityID); commodity != nil {
+		return commodity.Name
+	}
+	return commodityID
+}
+
+// formatQuantities renders a quantity map as "3x Name, 2x Name" in a stable order
+func formatQuantities(quantities map[string]int, multiplier int) string {
+	ids := make([]string, 0, len(quantities))
+	for id := range quantities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, fmt.Sprintf("%dx %s", quantities[id]*multiplier, commodityName(id)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// viewManufacturing renders the manufacturing screen.
+//
+// Layout:
+//   - Header: Crafting skill, research points and docked station
+//   - Tab bar
+//   - Current tab's list, with details for the selected row
+//   - Footer with controls
+func (m Model) viewManufacturing() string {
+	if m.manufacturingManager == nil {
+		return "Manufacturing is unavailable\n\n" + renderFooter("ESC: Back")
+	}
+
+	s := titleStyle.Render("🏭 MANUFACTURING") + "\n\n"
+	if m.player != nil {
+		s += fmt.Sprintf("Crafting Skill: %d | Research Points: %d | Credits: %s\n",
+			m.player.CraftingSkill, m.player.ResearchPoints, formatCredits(m.player.Credits))
+	}
+	if station := m.dockedStation(); station != nil {
+		s += fmt.Sprintf("Docked at: %s (owned by %s)\n", highlightStyle.Render(station.Name), station.OwnerName)
+	}
+	s += "\n"
+
+	tabs := make([]string, 0, len(manufacturingTabs))
+	for _, tab := range manufacturingTabs {
+		label := strings.ToUpper(tab[:1]) + tab[1:]
+		if tab == m.manufacturingModel.tab {
+			label = highlightStyle.Render("[" + label + "]")
+		}
+		tabs = append(tabs, label)
+	}
+	s += strings.Join(tabs, "  ") + "\n\n"
+
+	footer := "Tab: Next Tab | ESC: Back"
+	switch m.manufacturingModel.tab {
+	case manufacturingTabBlueprints:
+		s += m.viewManufacturingBlueprints()
+		footer = "Enter: Craft | +/-: Quantity | " + footer
+	case manufacturingTabJobs:
+		s += m.viewManufacturingJobs()
+		footer = "X: Cancel Job | " + footer
+	case manufacturingTabResearch:
+		s += m.viewManufacturingResearch()
+		footer = "Enter: Research | " + footer
+	default:
+		s += m.viewManufacturingStations()
+		footer = "B: Build Here | U: Upgrade | F: Facility | A: Add Facility | Enter: Dock | " + footer
+	}
+
+	if m.manufacturingModel.message != "" {
+		s += "\n" + m.manufacturingModel.message + "\n"
+	}
+
+	s += "\n" + renderFooter(footer)
+	return s
+}
+
+// viewManufacturingBlueprints renders the blueprint list and the selected
+// blueprint's inputs against the current ship's cargo
+func (m Model) viewManufacturingBlueprints() string {
+	s := ""
+	techLevels := m.manufacturingManager.GetPlayerTechnologies(m.playerID)
+	blueprints := m.manufacturingManager.GetBlueprints()
+	for i, blueprint := range blueprints {
+		cursor := "  "
+		if i == m.manufacturingModel.cursor {
+			cursor = "> "
+		}
+		line := fmt.Sprintf("%sT%d %-22s %-9s %s", cursor, blueprint.Tier, blueprint.Name, blueprint.ItemType, blueprint.CraftingTime)
+		switch {
+		case blueprint.TechRequired != "" && techLevels[blueprint.TechRequired] == 0:
+			s += helpStyle.Render(line+" [needs "+blueprint.TechRequired+"]") + "\n"
+		case m.player != nil && m.player.CraftingSkill < blueprint.SkillLevel:
+			s += helpStyle.Render(fmt.Sprintf("%s [skill %d]", line, blueprint.SkillLevel)) + "\n"
+		default:
+			s += line + "\n"
+		}
+	}
+
+	if m.manufacturingModel.cursor < len(blueprints) {
+		blueprint := blueprints[m.manufacturingModel.cursor]
+		quantity := m.manufacturingModel.quantity
+		s += fmt.Sprintf("\n%s\n", blueprint.Description)
+		s += fmt.Sprintf("Quantity: %s\n", highlightStyle.Render(fmt.Sprintf("%d", quantity)))
+		s += "Requires: "
+		ids := make([]string, 0, len(blueprint.Requirements))
+		for id := range blueprint.Requirements {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		parts := make([]string, 0, len(ids))
+		for _, id := range ids {
+			need := blueprint.Requirements[id] * quantity
+			have := 0
+			if m.currentShip != nil {
+				have = m.currentShip.GetCommodityQuantity(id)
+			}
+			part := fmt.Sprintf("%d/%d %s", have, need, commodityName(id))
+			if have < need {
+				part = errorStyle.Render(part)
+			}
+			parts = append(parts, part)
+		}
+		s += strings.Join(parts, ", ") + "\n"
+		s += "Produces: " + formatQuantities(blueprint.Produces, quantity) + "\n"
+	}
+	return s
+}
+
+// viewManufacturingJobs renders the player's crafting jobs with progress
+func (m Model) viewManufacturingJobs() string {
+	jobs := m.manufacturingManager.GetCraftingJobs(m.playerID)
+	if len(jobs) == 0 {
+		return helpStyle.Render("No crafting jobs") + "\n"
+	}
+
+	s := ""
+	now := time.Now()
+	for i, job := range jobs {
+		cursor := "  "
+		if i == m.manufacturingModel.cursor {
+			cursor = "> "
+		}
+		status := fmt.Sprintf("%3.0f%% - %s left", job.Progress(now)*100, time.Until(job.CompletionTime).Round(time.Second))
+		switch job.Status {
+		case models.CraftingComplete:
+			status = successStyle.Render("Complete")
+		case models.CraftingCancelled:
+			status = helpStyle.Render("Cancelled")
+		}
+		s += fmt.Sprintf("%s%dx %-22s %s\n", cursor, job.Quantity, job.Blueprint.Name, status)
+	}
+	return s
+}
+
+// viewManufacturingResearch renders the technologies with the player's
+// level and the cost of the next level
+func (m Model) viewManufacturingResearch() string {
+	s := ""
+	levels := m.manufacturingManager.GetPlayerTechnologies(m.playerID)
+	technologies := m.manufacturingManager.GetAllTechnologies()
+	for i, tech := range technologies {
+		cursor := "  "
+		if i == m.manufacturingModel.cursor {
+			cursor = "> "
+		}
+		level := levels[tech.ID]
+		cost := "Maxed"
+		if level < tech.MaxLevel {
+			points, credits := m.manufacturingManager.ResearchCost(m.playerID, tech.ID)
+			cost = fmt.Sprintf("%d RP + %s", points, formatCredits(credits))
+		}
+		s += fmt.Sprintf("%s%-22s Lv %d/%d  %s\n", cursor, tech.Name, level, tech.MaxLevel, cost)
+	}
+
+	if m.manufacturingModel.cursor < len(technologies) {
+		tech := technologies[m.manufacturingModel.cursor]
+		s += "\n" + tech.Description + "\n"
+		if len(tech.Prerequisites) > 0 {
+			s += "Requires: " + strings.Join(tech.Prerequisites, ", ") + "\n"
+		}
+		if len(tech.Unlocks) > 0 {
+			s += "Unlocks: " + strings.Join(tech.Unlocks, ", ") + "\n"
+		}
+	}
+	return s
+}
+
+// viewManufacturingStations renders the player's stations and the costs of
+// building, upgrading and equipping them
+func (m Model) viewManufacturingStations() string {
+	s := ""
+	stations := m.manufacturingManager.GetPlayerStations(m.playerID)
+	if len(stations) == 0 {
+		s += helpStyle.Render("You have no stations") + "\n"
+	}
+	for i, station := range stations {
+		cursor := "  "
+		if i == m.manufacturingModel.cursor {
+			cursor = "> "
+		}
+		s += fmt.Sprintf("%s%-24s %-14s Lv %d  %s\n", cursor, station.Name, station.SystemName, station.Level, station.Status)
+	}
+
+	facility := models.StationFacilities[m.manufacturingModel.facilityIndex]
+	s += fmt.Sprintf("\nBuild cost: %s\n", formatCredits(m.manufacturingManager.StationBuildCost()))
+	if m.manufacturingModel.cursor < len(stations) {
+		station := stations[m.manufacturingModel.cursor]
+		facilities := make([]string, 0, len(station.Facilities))
+		for _, f := range station.Facilities {
+			facilities = append(facilities, string(f))
+		}
+		s += fmt.Sprintf("Facilities: %s\n", strings.Join(facilities, ", "))
+		s += fmt.Sprintf("Storage: %s\n", formatQuantities(station.Storage, 1))
+		s += fmt.Sprintf("Production bonus: +%.0f%%\n", station.ProductionBonus*100)
+		s += fmt.Sprintf("Upgrade cost: %s\n", formatCredits(m.manufacturingManager.StationUpgradeCost(station.Level)))
+		s += fmt.Sprintf("Facility to add: %s (%s)\n", highlightStyle.Render(string(facility)),
+			formatCredits(m.manufacturingManager.FacilityCost(facility, station.Level)))
+	}
+	return s
+}
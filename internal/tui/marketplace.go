@@ -313,7 +313,7 @@ func (m *Model) updateMarketplaceViewAuction(msg tea.KeyMsg) (tea.Model, tea.Cmd
 	case "o":
 		// Buyout - Implement buyout logic
 		if auction.BuyoutPrice > 0 && auction.Status == "active" && m.marketplaceManager != nil {
-			err := m.marketplaceManager.Buyout(context.Background(), auction.ID, m.playerID)
+			err := m.marketplaceManager.Buyout(context.Background(), auction.ID, m.playerID, m.username)
 			if err != nil {
 				m.marketplace.error = fmt.Sprintf("Failed to buyout: %v", err)
 			} else {
@@ -1256,13 +1256,8 @@ func (m *Model) postBounty() tea.Cmd {
 			return marketplaceBountyPostedMsg{err: fmt.Sprintf("Failed to post bounty: %v", err)}
 		}
 
-		// Deduct total cost from player credits
-		m.player.Credits -= totalCost
-		// Update player in database
-		if err := m.playerRepo.Update(ctx, m.player); err != nil {
-			return marketplaceBountyPostedMsg{err: fmt.Sprintf("Failed to update credits: %v", err)}
-		}
-
+		// The marketplace has already charged the cost; the new balance
+		// arrives through the player update stream
 		return marketplaceBountyPostedMsg{err: ""}
 	}
 }
@@ -1358,13 +1353,8 @@ func (m *Model) createContract() tea.Cmd {
 			return marketplaceContractCreatedMsg{err: fmt.Sprintf("Failed to create contract: %v", err)}
 		}
 
-		// Deduct reward from player credits (held in escrow)
-		m.player.Credits -= reward
-		// Update player in database
-		if err := m.playerRepo.Update(ctx, m.player); err != nil {
-			return marketplaceContractCreatedMsg{err: fmt.Sprintf("Failed to update credits: %v", err)}
-		}
-
+		// The marketplace has already taken the deposit; the new balance
+		// arrives through the player update stream
 		return marketplaceContractCreatedMsg{err: ""}
 	}
 }
@@ -1,7 +1,7 @@
 // File: internal/tui/messages.go
 // Project: Terminal Velocity
 // Description: Custom message type definitions for async BubbleTea operations
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-14
 //
@@ -46,6 +46,7 @@
 package tui
 
 import (
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/game/trading"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	"github.com/google/uuid"
 )
@@ -79,11 +80,12 @@ type tradingEnhancedDataMsg struct {
 //
 // The receiving screen should update the player's credits and cargo display.
 type transactionCompleteMsg struct {
-	action      string // "buy" or "sell"
-	commodityID string // ID of the commodity traded
-	quantity    int    // Amount bought or sold
-	newBalance  int64  // Player's credits after transaction
-	err         error  // Error if transaction failed
+	action      string               // "buy" or "sell"
+	commodityID string               // ID of the commodity traded
+	quantity    int                  // Amount bought or sold
+	newBalance  int64                // Player's credits after transaction
+	result      *trading.TradeResult // Committed trade (nil on failure)
+	err         error                // Error if transaction failed
 }
 
 // ===== Shipyard Messages =====
@@ -311,11 +313,12 @@ type loadoutActionMsg struct {
 
 // spaceViewLoadedMsg is sent when space view data has been loaded.
 type spaceViewLoadedMsg struct {
-	system      *models.StarSystem // Current star system
-	planets     []*models.Planet   // Planets in system
-	nearbyShips []*models.Ship     // Other ships in vicinity
-	playerShip  *models.Ship       // Player's ship
-	err         error              // Error if loading failed
+	system      *models.StarSystem      // Current star system
+	planets     []*models.Planet        // Planets in system
+	stations    []*models.PlayerStation // Player stations in system
+	nearbyShips []*models.Ship          // Other ships in vicinity
+	playerShip  *models.Ship            // Player's ship
+	err         error                   // Error if loading failed
 }
 
 // targetSelectedMsg is sent when a target has been selected in space view.
@@ -0,0 +1,384 @@
+// File: internal/tui/mining.go
+// Project: Terminal Velocity
+// Description: Mining screen - resource fields, mining cycles and salvage
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// The mining screen lists the resource fields in the player's current
+// system: asteroids to mine, and derelicts and debris fields to salvage.
+// Fields are the same for every pilot, deplete as they are worked and
+// regrow over time. Uncharted asteroids only show up with a survey scanner.
+//
+// An operation runs a number of timed cycles on the server; each cycle moves
+// resources into the cargo hold. The screen shows the current cycle's
+// progress, refreshed twice a second. Cancelling keeps what finished cycles
+// already loaded; jumping, landing or disconnecting cancels too. Mining
+// laser and survey scanner outfits raise the yield.
+
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/mining"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+// miningRefreshInterval is how often the mining screen redraws cycle progress
+const miningRefreshInterval = 500 * time.Millisecond
+
+// miningModel contains the state for the mining screen
+type miningModel struct {
+	system  *models.StarSystem      // Current system (nil until loaded)
+	fields  []*models.ResourceField // Fields visible with the ship's scanner
+	stats   *mining.MiningStats     // Player's mining record (nil until loaded)
+	cursor  int                     // Selected field
+	started uuid.UUID               // Operation whose end reloads the record
+	tickID  int                     // Identifies the current refresh loop
+	message string                  // Result of the last action
+}
+
+// miningLoadedMsg carries the current system and the player's mining record
+type miningLoadedMsg struct {
+	system *models.StarSystem
+	stats  mining.MiningStats
+	err    error
+}
+
+// miningTickMsg redraws the mining screen while it is open
+type miningTickMsg struct {
+	id int
+}
+
+// newMiningModel creates an empty mining screen model
+func newMiningModel() miningModel {
+	return miningModel{}
+}
+
+// miningTick schedules the next redraw of the mining screen
+func miningTick(id int) tea.Cmd {
+	return tea.Tick(miningRefreshInterval, func(time.Time) tea.Msg {
+		return miningTickMsg{id: id}
+	})
+}
+
+// loadMining loads the player's current system and mining record
+func (m Model) loadMining() tea.Cmd {
+	if m.player == nil || m.systemRepo == nil || m.miningManager == nil {
+		return nil
+	}
+	playerID, systemID := m.playerID, m.player.CurrentSystem
+	return func() tea.Msg {
+		ctx := context.Background()
+		system, err := m.systemRepo.GetSystemByID(ctx, systemID)
+		if err != nil {
+			return miningLoadedMsg{err: err}
+		}
+		return miningLoadedMsg{system: system, stats: m.miningManager.GetStats(ctx, playerID)}
+	}
+}
+
+// miningEquipment returns the current ship's mining laser and scanner levels
+func (m Model) miningEquipment() (laser, scanner int) {
+	if m.currentShip == nil {
+		return 0, 0
+	}
+	return models.CalculateMiningEquipment(m.currentShip.Outfits)
+}
+
+// refreshMiningFields re-reads the fields of the current system
+func (m *Model) refreshMiningFields() {
+	if m.miningModel.system == nil {
+		return
+	}
+	_, scanner := m.miningEquipment()
+	m.miningModel.fields = m.miningManager.ScanForResources(m.miningModel.system, scanner)
+	if m.miningModel.cursor >= len(m.miningModel.fields) {
+		m.miningModel.cursor = 0
+	}
+}
+
+// cancelMining stops the player's operations when they leave the fields
+// being mined
+func (m Model) cancelMining(reason string) {
+	if m.miningManager != nil && m.playerID != uuid.Nil {
+		m.miningManager.CancelPlayerOperations(m.playerID, reason)
+	}
+}
+
+// miningOperation returns the current ship's active or last operation
+func (m Model) miningOperation() *mining.MiningOperation {
+	if m.currentShip == nil || m.miningManager == nil {
+		return nil
+	}
+	operation, _ := m.miningManager.GetActiveOperation(m.currentShip.ID)
+	return operation
+}
+
+// updateMining handles input for the mining screen.
+//
+// Key Bindings:
+//   - esc/backspace/q: Return to the main menu (mining carries on)
+//   - up/k, down/j: Select a field
+//   - enter/space: Mine or salvage the selected field
+//   - x: Cancel the operation (loaded resources stay in the hold)
+func (m Model) updateMining(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case miningTickMsg:
+		// Only the latest refresh loop keeps running
+		if msg.id != m.miningModel.tickID || m.screen != ScreenMining {
+			return m, nil
+		}
+		m.refreshMiningFields()
+
+		// Reload the player's record once their operation ends
+		if operation := m.miningOperation(); operation != nil && operation.ID == m.miningModel.started &&
+			operation.Status != mining.StatusActive {
+			m.miningModel.started = uuid.Nil
+			return m, tea.Batch(miningTick(msg.id), m.loadMining())
+		}
+		return m, miningTick(msg.id)
+
+	case miningLoadedMsg:
+		if msg.err != nil {
+			m.miningModel.message = errorStyle.Render(fmt.Sprintf("Failed to survey the system: %v", msg.err))
+			return m, nil
+		}
+		m.miningModel.system = msg.system
+		m.miningModel.stats = &msg.stats
+		m.refreshMiningFields()
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "backspace", "q":
+			m.screen = ScreenMainMenu
+			return m, tea.ClearScreen
+
+		case "up", "k":
+			if m.miningModel.cursor > 0 {
+				m.miningModel.cursor--
+			}
+
+		case "down", "j":
+			if m.miningModel.cursor < len(m.miningModel.fields)-1 {
+				m.miningModel.cursor++
+			}
+
+		case "enter", " ":
+			return m.startMining()
+
+		case "x":
+			if m.currentShip == nil || m.miningManager == nil {
+				return m, nil
+			}
+			if err := m.miningManager.CancelOperation(m.currentShip.ID); err != nil {
+				m.miningModel.message = errorStyle.Render(fmt.Sprintf("Failed: %v", err))
+			} else {
+				m.miningModel.message = successStyle.Render("Operation cancelled")
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// startMining starts an operation on the selected field
+func (m Model) startMining() (tea.Model, tea.Cmd) {
+	if m.miningModel.cursor >= len(m.miningModel.fields) || m.currentShip == nil || m.player == nil ||
+		m.miningManager == nil {
+		return m, nil
+	}
+	if m.player.IsDocked() {
+		m.miningModel.message = errorStyle.Render("Launch from the planet before mining")
+		return m, nil
+	}
+
+	field := m.miningModel.fields[m.miningModel.cursor]
+	operation, err := m.miningManager.StartMining(m.playerID, m.currentShip, m.miningModel.system, field.ID)
+	if err != nil {
+		m.miningModel.message = errorStyle.Render(fmt.Sprintf("Failed: %v", err))
+		return m, nil
+	}
+
+	m.miningModel.started = operation.ID
+
+	verb := "Mining"
+	if operation.Type == "salvage" {
+		verb = "Salvaging"
+	}
+	m.miningModel.message = successStyle.Render(fmt.Sprintf("%s %s: %d cycles of %.0f units",
+		verb, field.Name, operation.TotalCycles, operation.CycleYield))
+	return m, nil
+}
+
+// viewMining renders the mining screen.
+//
+// Layout:
+//   - Header: System, mining equipment and free cargo space
+//   - Field list with what is left in each
+//   - Current or last operation with cycle progress
+//   - Player's mining record
+//   - Footer with controls
+func (m Model) viewMining() string {
+	if m.miningManager == nil {
+		return "Mining is unavailable\n\n" + renderFooter("ESC: Back")
+	}
+
+	s := titleStyle.Render("⛏ MINING") + "\n\n"
+	if m.miningModel.system == nil {
+		s += helpStyle.Render("Surveying the system...") + "\n"
+		if m.miningModel.message != "" {
+			s += "\n" + m.miningModel.message + "\n"
+		}
+		return s + "\n" + renderFooter("ESC: Back")
+	}
+
+	laser, scanner := m.miningEquipment()
+	s += fmt.Sprintf("System: %s | Mining Laser: %s | Survey Scanner: %s",
+		highlightStyle.Render(m.miningModel.system.Name), miningLevel(laser), miningLevel(scanner))
+	if m.currentShip != nil {
+		if shipType := models.GetShipTypeByID(m.currentShip.TypeID); shipType != nil {
+			s += fmt.Sprintf(" | Cargo: %d/%d", m.currentShip.GetCargoUsed(), shipType.CargoSpace)
+		}
+	}
+	s += "\n\n"
+
+	s += m.viewMiningFields() + "\n"
+	s += m.viewMiningOperation()
+
+	if stats := m.miningModel.stats; stats != nil {
+		s += fmt.Sprintf("\nOperations: %d | Total Yield: %.0f | Most Mined: %s\n",
+			stats.TotalOperations, stats.TotalYield, miningResourceName(stats.MostCommonResource))
+	}
+
+	if m.miningModel.message != "" {
+		s += "\n" + m.miningModel.message + "\n"
+	}
+
+	s += "\n" + renderFooter("↑/↓: Select | Enter: Mine/Salvage | X: Cancel | ESC: Back")
+	return s
+}
+
+// viewMiningFields renders the system's resource fields
+func (m Model) viewMiningFields() string {
+	if len(m.miningModel.fields) == 0 {
+		return helpStyle.Render("No resource fields found in this system") + "\n"
+	}
+
+	s := ""
+	for i, field := range m.miningModel.fields {
+		cursor := "  "
+		if i == m.miningModel.cursor {
+			cursor = "> "
+		}
+
+		left := int(field.Total())
+		capacity := int(field.CapacityTotal())
+		percent := 0
+		if capacity > 0 {
+			percent = left * 100 / capacity
+		}
+
+		name := field.Name
+		if field.Uncharted {
+			name += " *"
+		}
+		s += fmt.Sprintf("%s%-24s %-8s [%s] %3d%%  %s\n", cursor, name, field.Rarity,
+			m.renderStatusBar(left, capacity, 10, "█", "░"), percent, miningFieldResources(field))
+	}
+	if _, scanner := m.miningEquipment(); scanner > 0 {
+		s += helpStyle.Render("* Uncharted - found by your survey scanner") + "\n"
+	}
+	return s
+}
+
+// viewMiningOperation renders the current ship's operation
+func (m Model) viewMiningOperation() string {
+	operation := m.miningOperation()
+	if operation == nil {
+		return helpStyle.Render("Select a field to mine or salvage. Resources go straight into your cargo hold.") + "\n"
+	}
+
+	verb := "Mining"
+	if operation.Type == "salvage" {
+		verb = "Salvaging"
+	}
+
+	s := ""
+	switch operation.Status {
+	case mining.StatusActive:
+		cycle := operation.TotalCycles - operation.CyclesLeft + 1
+		progress := int(operation.CycleProgress(time.Now()) * 100)
+		s += successStyle.Render(fmt.Sprintf("%s %s - cycle %d/%d", verb, operation.FieldName, cycle, operation.TotalCycles)) + "\n"
+		s += fmt.Sprintf("[%s] %3d%%\n", m.renderStatusBar(progress, 100, 30, "█", "░"), progress)
+	case mining.StatusCompleted:
+		s += successStyle.Render(fmt.Sprintf("%s %s completed", verb, operation.FieldName))
+		if operation.Message != "" {
+			s += " - " + operation.Message
+		}
+		s += "\n"
+	default:
+		s += errorStyle.Render(fmt.Sprintf("%s %s %s", verb, operation.FieldName, operation.Status))
+		if operation.Message != "" && operation.Status == mining.StatusFailed {
+			s += " - " + operation.Message
+		}
+		s += "\n"
+	}
+
+	if len(operation.Cargo) == 0 {
+		return s
+	}
+	commodities := make([]string, 0, len(operation.Cargo))
+	for commodityID := range operation.Cargo {
+		commodities = append(commodities, commodityID)
+	}
+	sort.Strings(commodities)
+	loaded := make([]string, 0, len(commodities))
+	for _, commodityID := range commodities {
+		name := commodityID
+		if commodity := models.GetCommodityByID(commodityID); commodity != nil {
+			name = commodity.Name
+		}
+		loaded = append(loaded, fmt.Sprintf("%s %d", name, operation.Cargo[commodityID]))
+	}
+	s += "Loaded: " + strings.Join(loaded, ", ") + "\n"
+	return s
+}
+
+// miningFieldResources lists the resources left in a field, most plentiful first
+func miningFieldResources(field *models.ResourceField) string {
+	resources := make([]string, 0, len(field.Remaining))
+	for resource := range field.Remaining {
+		resources = append(resources, resource)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return field.Remaining[resources[i]] > field.Remaining[resources[j]]
+	})
+
+	names := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		names = append(names, miningResourceName(resource))
+	}
+	return strings.Join(names, ", ")
+}
+
+// miningResourceName returns a resource's display name
+func miningResourceName(resource string) string {
+	return strings.ReplaceAll(resource, "_", " ")
+}
+
+// miningLevel renders a mining equipment level
+func miningLevel(level int) string {
+	if level == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("Mk%d", level)
+}
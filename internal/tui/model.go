@@ -1,7 +1,7 @@
 // File: internal/tui/model.go
 // Project: Terminal Velocity
 // Description: Core TUI model with BubbleTea integration, screen routing, and state management
-// Version: 1.3.0
+// Version: 1.9.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 //
@@ -36,15 +36,23 @@ import (
 
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/achievements"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/admin"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/api"
+	apiserver "github.com/JoshuaAFerguson/terminal-velocity/internal/api/server"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/arena"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/capture"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/chat"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/diplomacy"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/encounters"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/factions"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/fleet"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/friends"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/game/trading"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/leaderboards"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/mail"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/manufacturing"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/marketplace"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/mining"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/missions"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/news"
@@ -53,10 +61,13 @@ import (
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/presence"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/pvp"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/quests"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/security"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/session"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/settings"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/territory"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/trade"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/tutorial"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/world"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/uuid"
 )
@@ -204,6 +215,24 @@ const (
 
 	// ScreenNotifications displays game notifications and alerts
 	ScreenNotifications
+
+	// ScreenTwoFactor handles the 2FA login challenge and enrollment
+	ScreenTwoFactor
+
+	// ScreenLoginHistory lists the player's recent logins
+	ScreenLoginHistory
+
+	// ScreenDiplomacy lets faction leaders manage alliances, wars and treaties
+	ScreenDiplomacy
+
+	// ScreenManufacturing manages blueprints, crafting jobs, research and stations
+	ScreenManufacturing
+
+	// ScreenArena runs ranked matchmaking, spectating, tournaments and the arena leaderboard
+	ScreenArena
+
+	// ScreenMining mines and salvages the resource fields of the current system
+	ScreenMining
 )
 
 // Model is the main TUI model that holds all application state.
@@ -271,8 +300,41 @@ type Model struct {
 	shipRepo   *database.ShipRepository   // Player ships and equipment
 	marketRepo *database.MarketRepository // Market prices and commodities
 	mailRepo   *database.MailRepository   // Player mail system
-	socialRepo *database.SocialRepository // Friends, blocks, etc.
-	itemRepo   *database.ItemRepository   // Items and equipment
+	socialRepo   *database.SocialRepository   // Friends, blocks, etc.
+	itemRepo     *database.ItemRepository     // Items and equipment
+	securityRepo *database.SecurityRepository // Two-factor authentication
+
+	// twoFactor verifies TOTP and backup codes and renders setup QR codes
+	twoFactor *security.TwoFactorManager
+
+	// securityManager runs honeypot and anomaly checks for the login screen
+	// and records login history. Nil for key logins, which the server
+	// checks during the SSH handshake.
+	securityManager *security.Manager
+	remoteIP        string    // Client IP address
+	clientVersion   string    // Client SSH version string (device tracking)
+	securitySession uuid.UUID // Security session opened at login
+
+	// sessionManager saves the player's screen, location and any battle or
+	// jump in progress, and resumes them after a reconnect (see session.go)
+	sessionManager *session.Manager
+	gameSession    *session.Session // Open once the player is loaded
+	exitMessage    string           // Shown by the server after the program exits
+
+	// tradingService executes commodity trades atomically (shared, server-owned)
+	tradingService *trading.Service
+
+	// manufacturingManager runs crafting, research and player stations (shared, server-owned)
+	manufacturingManager *manufacturing.Manager
+
+	// arenaManager runs ranked arena matches, seasons and tournaments (shared, server-owned)
+	arenaManager *arena.Manager
+
+	// captureManager resolves boarding actions and hands over captured ships (shared, server-owned)
+	captureManager *capture.Manager
+
+	// miningManager runs mining operations on the galaxy's resource fields (shared, server-owned)
+	miningManager *mining.Manager
 
 	// ===== Terminal Dimensions =====
 
@@ -304,6 +366,10 @@ type Model struct {
 	playersModel         playersModel              // Online players list
 	chatModel            chatModel                 // Multi-channel chat
 	factionsModel        factionsModel             // Faction management
+	diplomacyModel       diplomacyModel            // Faction diplomacy
+	manufacturingModel   manufacturingModel        // Crafting, research and stations
+	arenaModel           arenaModel                // Ranked arena
+	miningModel          miningModel               // Resource fields and mining cycles
 	tradeModel           tradeModel                // Player trading
 	pvpModel             pvpModel                  // PvP challenges
 	helpModel            helpModel                 // Context-sensitive help
@@ -327,6 +393,8 @@ type Model struct {
 	friends              friendsState              // Friends list
 	marketplace          marketplaceState          // Player marketplace
 	notifications        notificationsState        // Notifications
+	twoFactorModel       twoFactorModel            // 2FA challenge and enrollment
+	loginHistoryModel    loginHistoryModel         // Recent logins
 
 	// ===== Game System Managers =====
 	// Managers encapsulate game systems and often run background workers
@@ -344,6 +412,7 @@ type Model struct {
 	marketplaceManager   *marketplace.Manager    // Player marketplace
 	factionManager       *factions.Manager       // Player factions
 	territoryManager     *territory.Manager      // Territory control
+	diplomacyManager     *diplomacy.Manager      // Faction diplomacy
 	tradeManager         *trade.Manager          // Player trading
 	pvpManager           *pvp.Manager            // PvP combat
 	encounterManager     *encounters.Manager     // Random encounters
@@ -354,6 +423,24 @@ type Model struct {
 	questManager         *quests.Manager         // Quest system
 	missionManager       *missions.Manager       // Mission system
 
+	// ===== Shared World State =====
+	// The chat, presence, faction, territory, diplomacy, trade, PvP and news
+	// managers above are server singletons owned by worldHub. This session
+	// learns about changes made by other sessions through worldSub rather than
+	// by polling.
+
+	worldHub *world.Hub          // Server-wide world-state hub
+	worldSub *world.Subscription // World events for this session's player
+
+	// ===== Real-Time Player Updates =====
+	// Credits/cargo changes made outside this session (auctions, mail claims,
+	// API trades) arrive on updateStream; see player_updates.go.
+
+	playerUpdates      *apiserver.UpdateBus   // Server-wide player update bus
+	updateStream       api.PlayerUpdateStream // This session's stream
+	updateStreamPlayer uuid.UUID              // Player the stream belongs to
+	cancelUpdates      context.CancelFunc     // Ends updateStream
+
 	// ===== Achievement Display Queue =====
 
 	// pendingAchievements holds newly unlocked achievements waiting to be displayed
@@ -414,13 +501,23 @@ func NewModel(
 	mailRepo *database.MailRepository,
 	socialRepo *database.SocialRepository,
 	itemRepo *database.ItemRepository,
+	securityRepo *database.SecurityRepository,
 	fleetManager *fleet.Manager,
+	captureManager *capture.Manager,
+	miningManager *mining.Manager,
 	mailManager *mail.Manager,
 	notificationsManager *notifications.Manager,
 	friendsManager *friends.Manager,
 	marketplaceManager *marketplace.Manager,
+	adminManager *admin.Manager,
+	sessionManager *session.Manager,
+	tradingService *trading.Service,
+	manufacturingManager *manufacturing.Manager,
+	arenaManager *arena.Manager,
+	worldHub *world.Hub,
+	playerUpdates *apiserver.UpdateBus,
 ) Model {
-	return Model{
+	m := Model{
 		screen:              ScreenMainMenu,
 		playerID:            playerID,
 		username:            username,
@@ -432,6 +529,12 @@ func NewModel(
 		mailRepo:            mailRepo,
 		socialRepo:          socialRepo,
 		itemRepo:            itemRepo,
+		securityRepo:        securityRepo,
+		twoFactor:           security.NewTwoFactorManager("Terminal Velocity"),
+		tradingService:      tradingService,
+		manufacturingManager: manufacturingManager,
+		arenaManager:        arenaManager,
+		playerUpdates:       playerUpdates,
 		width:               80,
 		height:              24,
 		mainMenu:            newMainMenuModel(),
@@ -447,25 +550,24 @@ func NewModel(
 		pendingAchievements: []*models.Achievement{},
 		encounterModel:      newEncounterModel(),
 		newsModel:           newNewsModel(),
-		newsManager:         news.NewManager(),
 		leaderboardsModel:   newLeaderboardsModel(),
 		leaderboardManager:  leaderboards.NewManager(),
 		playersModel:        newPlayersModel(),
-		presenceManager:     presence.NewManager(),
 		chatModel:           newChatModel(),
-		chatManager:         chat.NewManager(),
 		fleetManager:        fleetManager,
+		captureManager:      captureManager,
+		miningManager:       miningManager,
 		mailManager:         mailManager,
 		notificationsManager: notificationsManager,
 		friendsManager:      friendsManager,
 		marketplaceManager:  marketplaceManager,
 		factionsModel:       newFactionsModel(),
-		factionManager:      factions.NewManager(),
-		territoryManager:    territory.NewManager(),
+		diplomacyModel:      newDiplomacyModel(),
+		manufacturingModel:  newManufacturingModel(),
+		arenaModel:          newArenaModel(),
+		miningModel:         newMiningModel(),
 		tradeModel:          newTradeModel(),
-		tradeManager:        trade.NewManager(),
 		pvpModel:            newPvPModel(),
-		pvpManager:          pvp.NewManager(),
 		helpModel:           newHelpModel(),
 		encounterManager:    encounters.NewManager(),
 		outfitterEnhanced:   newOutfitterEnhancedModel(),
@@ -473,7 +575,8 @@ func NewModel(
 		settingsModel:       newSettingsModel(),
 		settingsManager:     settings.NewManager(".config/terminal-velocity"),
 		adminModel:          newAdminModel(),
-		adminManager:        admin.NewManager(playerRepo),
+		adminManager:        adminManager,
+		sessionManager:      sessionManager,
 		tutorialModel:       newTutorialModel(),
 		tutorialManager:     tutorial.NewManager(),
 		questsModel:         newQuestsModel(),
@@ -492,6 +595,8 @@ func NewModel(
 		friends:              newFriendsState(),
 		notifications:        newNotificationsState(),
 	}
+	m.attachWorld(worldHub)
+	return m
 }
 
 // InitializeTutorials initializes tutorial progress for the player
@@ -540,8 +645,22 @@ func NewLoginModel(
 	marketRepo *database.MarketRepository,
 	mailRepo *database.MailRepository,
 	socialRepo *database.SocialRepository,
+	securityRepo *database.SecurityRepository,
+	securityManager *security.Manager,
+	remoteIP string,
+	clientVersion string,
+	sessionManager *session.Manager,
+	adminManager *admin.Manager,
+	fleetManager *fleet.Manager,
+	captureManager *capture.Manager,
+	miningManager *mining.Manager,
+	tradingService *trading.Service,
+	manufacturingManager *manufacturing.Manager,
+	arenaManager *arena.Manager,
+	worldHub *world.Hub,
+	playerUpdates *apiserver.UpdateBus,
 ) Model {
-	return Model{
+	m := Model{
 		screen:              ScreenLogin,
 		playerID:            uuid.Nil,
 		username:            "",
@@ -552,6 +671,16 @@ func NewLoginModel(
 		marketRepo:          marketRepo,
 		mailRepo:            mailRepo,
 		socialRepo:          socialRepo,
+		securityRepo:        securityRepo,
+		twoFactor:           security.NewTwoFactorManager("Terminal Velocity"),
+		securityManager:     securityManager,
+		remoteIP:            remoteIP,
+		clientVersion:       clientVersion,
+		sessionManager:      sessionManager,
+		tradingService:      tradingService,
+		manufacturingManager: manufacturingManager,
+		arenaManager:        arenaManager,
+		playerUpdates:       playerUpdates,
 		width:               80,
 		height:              24,
 		loginModel:          newLoginModel(),
@@ -568,21 +697,21 @@ func NewLoginModel(
 		pendingAchievements: []*models.Achievement{},
 		encounterModel:      newEncounterModel(),
 		newsModel:           newNewsModel(),
-		newsManager:         news.NewManager(),
 		leaderboardsModel:   newLeaderboardsModel(),
 		leaderboardManager:  leaderboards.NewManager(),
 		playersModel:        newPlayersModel(),
-		presenceManager:     presence.NewManager(),
 		chatModel:           newChatModel(),
-		chatManager:         chat.NewManager(),
 		mailManager:         mail.NewManager(socialRepo),
+		fleetManager:        fleetManager,
+		captureManager:      captureManager,
+		miningManager:       miningManager,
 		factionsModel:       newFactionsModel(),
-		factionManager:      factions.NewManager(),
-		territoryManager:    territory.NewManager(),
+		diplomacyModel:      newDiplomacyModel(),
+		manufacturingModel:  newManufacturingModel(),
+		arenaModel:          newArenaModel(),
+		miningModel:         newMiningModel(),
 		tradeModel:          newTradeModel(),
-		tradeManager:        trade.NewManager(),
 		pvpModel:            newPvPModel(),
-		pvpManager:          pvp.NewManager(),
 		helpModel:           newHelpModel(),
 		encounterManager:    encounters.NewManager(),
 		outfitterEnhanced:   newOutfitterEnhancedModel(),
@@ -590,7 +719,7 @@ func NewLoginModel(
 		settingsModel:       newSettingsModel(),
 		settingsManager:     settings.NewManager(".config/terminal-velocity"),
 		adminModel:          newAdminModel(),
-		adminManager:        admin.NewManager(playerRepo),
+		adminManager:        adminManager,
 		tutorialModel:       newTutorialModel(),
 		tutorialManager:     tutorial.NewManager(),
 		questsModel:         newQuestsModel(),
@@ -606,6 +735,8 @@ func NewLoginModel(
 		combatEnhanced:      newCombatEnhancedModel(),
 		questBoardEnhanced:  newQuestBoardEnhancedModel(),
 	}
+	m.attachWorld(worldHub)
+	return m
 }
 
 // NewRegistrationModel creates a new TUI model for registration
@@ -687,7 +818,19 @@ func (m Model) Init() tea.Cmd {
 // Returns:
 //   - Updated tea.Model (always return m, not Model)
 //   - Optional tea.Cmd for async operations
+//
+// After each update the resulting state is pushed to the session manager
+// so it can be resumed after a reconnect.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	if updated, ok := next.(Model); ok {
+		updated.syncSession(msg)
+	}
+	return next, cmd
+}
+
+// update routes a message to the global handlers or the current screen
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -702,16 +845,46 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case playerLoadedMsg:
+		// The login screen owns the transition into the game
+		if m.screen == ScreenLogin {
+			return m.updateLogin(msg)
+		}
+
 		m.player = msg.player
 		m.currentShip = msg.ship
 		m.err = msg.err
 
-		// Initialize presence when player loads
+		// Initialize presence and world updates when player loads
 		if m.player != nil && m.err == nil {
+			resumed, sessionCmd, err := m.openGameSession()
+			if err != nil {
+				m.exitMessage = refuseDuplicateLogin(err)
+				return m, tea.Quit
+			}
+			m = resumed
 			m.InitializePresence()
+			return m, tea.Batch(sessionCmd, m.subscribeWorld(), m.subscribePlayerUpdates(), m.checkTwoFactorPolicy())
 		}
 
 		return m, nil
+
+	case sessionKickedMsg:
+		// Another login took over the session, or it timed out
+		m.exitMessage = msg.reason
+		return m, tea.Quit
+
+	case twoFactorPolicyMsg:
+		// Server policy requires 2FA and the player hasn't enrolled
+		return m.startTwoFactorEnrollment(true), nil
+
+	case worldEventMsg:
+		return m.handleWorldEvent(msg)
+
+	case playerUpdateMsg:
+		return m.handlePlayerUpdate(msg)
+
+	case shipRefreshedMsg:
+		return m.handleShipRefreshed(msg)
 	}
 
 	// Delegate to screen-specific update
@@ -798,6 +971,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.updateMarketplace(msg)
 	case ScreenNotifications:
 		return m.updateNotifications(msg)
+	case ScreenTwoFactor:
+		return m.updateTwoFactor(msg)
+	case ScreenLoginHistory:
+		return m.updateLoginHistory(msg)
+	case ScreenDiplomacy:
+		return m.updateDiplomacy(msg)
+	case ScreenManufacturing:
+		return m.updateManufacturing(msg)
+	case ScreenArena:
+		return m.updateArena(msg)
+	case ScreenMining:
+		return m.updateMining(msg)
 	default:
 		return m, nil
 	}
@@ -831,13 +1016,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // Returns:
 //   - String to display in the terminal
 func (m Model) View() string {
+	// The 2FA login challenge runs before the player is loaded
+	preLogin := m.screen == ScreenLogin || m.screen == ScreenRegistration ||
+		(m.screen == ScreenTwoFactor && m.twoFactorModel.mode == twoFactorModeChallenge)
+
 	// Show error if present (but not on login screen)
-	if m.err != nil && m.screen != ScreenLogin && m.screen != ScreenRegistration {
+	if m.err != nil && !preLogin {
 		return errorView(m.err.Error())
 	}
 
 	// Loading state (but not on login or registration screen)
-	if m.player == nil && m.screen != ScreenLogin && m.screen != ScreenRegistration {
+	if m.player == nil && !preLogin {
 		return loadingView()
 	}
 
@@ -925,6 +1114,18 @@ func (m Model) View() string {
 		return m.viewMarketplace()
 	case ScreenNotifications:
 		return m.viewNotifications()
+	case ScreenTwoFactor:
+		return m.viewTwoFactor()
+	case ScreenLoginHistory:
+		return m.viewLoginHistory()
+	case ScreenDiplomacy:
+		return m.viewDiplomacy()
+	case ScreenManufacturing:
+		return m.viewManufacturing()
+	case ScreenArena:
+		return m.viewArena()
+	case ScreenMining:
+		return m.viewMining()
 	default:
 		return "Unknown screen"
 	}
@@ -1,7 +1,7 @@
 // File: internal/tui/navigation.go
 // Project: Terminal Velocity
 // Description: Navigation screen - System jumping and hyperspace travel interface
-// Version: 1.1.0
+// Version: 1.5.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 //
@@ -60,6 +60,7 @@ type jumpCompleteMsg struct {
 	success bool               // True if jump succeeded
 	system  *models.StarSystem // Destination system
 	err     error              // Error if jump failed
+	resumed bool               // Arrival of a jump saved before a disconnect (fuel already deducted)
 }
 
 // jumpInitiatedMsg is sent when a jump sequence begins.
@@ -190,12 +191,15 @@ func (m Model) updateNavigation(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.navigation.jumpTotal = 0
 
 		if msg.success {
+			// Mining stops at the system boundary
+			m.cancelMining("Jumped out of the system")
+
 			// Update local state
 			m.player.CurrentSystem = msg.system.ID
 			m.navigation.currentSystem = msg.system
 
 			// Update ship fuel in local model
-			if m.currentShip != nil {
+			if m.currentShip != nil && !msg.resumed {
 				jumpCost := calculateJumpCost(m.navigation.currentSystem, msg.system)
 				m.currentShip.Fuel -= jumpCost
 				if m.currentShip.Fuel < 0 {
@@ -209,6 +213,11 @@ func (m Model) updateNavigation(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.checkAchievements()
 			}
 
+			// Faction members arriving in a claimed system build its control
+			if m.territoryManager != nil {
+				m.territoryManager.RecordMemberActivity(msg.system.ID, m.territoryFactionID())
+			}
+
 			// Check for random encounter
 			generator := encounters.NewGenerator()
 			dangerLevel := 5 // Default danger level, could be from system data
@@ -280,6 +289,15 @@ func (m Model) viewNavigation() string {
 			}
 			info += fmt.Sprintf("Planets: %s\n", strings.Join(planetNames, ", "))
 		}
+		if m.manufacturingManager != nil {
+			if stations := m.manufacturingManager.GetStationsInSystem(sys.ID); len(stations) > 0 {
+				stationNames := make([]string, len(stations))
+				for i, station := range stations {
+					stationNames[i] = fmt.Sprintf("%s (%s)", station.Name, station.OwnerName)
+				}
+				info += fmt.Sprintf("Stations: %s\n", strings.Join(stationNames, ", "))
+			}
+		}
 		s += boxStyle.Render(info) + "\n\n"
 	}
 
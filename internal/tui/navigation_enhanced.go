@@ -1,7 +1,7 @@
 // File: internal/tui/navigation_enhanced.go
 // Project: Terminal Velocity
 // Description: Enhanced navigation screen with visual star map
-// Version: 1.0.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-14
 
@@ -438,6 +438,10 @@ func (m Model) updateNavigationEnhanced(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else {
 				destName = "Unknown System"
 			}
+			if msg.destination != nil && m.territoryManager != nil {
+				m.territoryManager.RecordMemberActivity(msg.destination.ID, m.territoryFactionID())
+			}
+			m.cancelMining("Jumped out of the system")
 			m.errorMessage = fmt.Sprintf("Jumped to %s. Fuel used: %d units", destName, msg.fuelUsed)
 			m.showErrorDialog = true
 			// Return to space view after successful jump
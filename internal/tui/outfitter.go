@@ -1,7 +1,7 @@
 // File: internal/tui/outfitter.go
 // Project: Terminal Velocity
 // Description: Outfitter screen - Weapon and outfit installation interface
-// Version: 1.1.0
+// Version: 1.3.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 //
@@ -23,7 +23,7 @@
 //
 // Tabs:
 // - Weapons: Browse and install weapons (9 standard weapons)
-// - Outfits: Browse and install outfits (16 standard outfits)
+// - Outfits: Browse and install outfits (20 standard outfits)
 // - Installed: View and remove currently equipped items
 
 package tui
@@ -348,7 +348,7 @@ func (m Model) viewOutfitterBrowse() string {
 
 func (m Model) viewWeaponsList() string {
 	s := ""
-	weapons := models.StandardWeapons
+	weapons := models.AllWeapons()
 
 	// Sort by price
 	sort.Slice(weapons, func(i, j int) bool {
@@ -395,7 +395,7 @@ func (m Model) viewWeaponsList() string {
 
 func (m Model) viewOutfitsList() string {
 	s := ""
-	outfits := models.StandardOutfits
+	outfits := models.AllOutfits()
 
 	// Sort by type then price
 	sort.Slice(outfits, func(i, j int) bool {
@@ -575,9 +575,9 @@ func (m Model) viewRemoveConfirmation() string {
 
 func (m Model) getMaxCursor() int {
 	if m.outfitter.tab == "weapons" {
-		return len(models.StandardWeapons) - 1
+		return len(models.AllWeapons()) - 1
 	} else if m.outfitter.tab == "outfits" {
-		return len(models.StandardOutfits) - 1
+		return len(models.AllOutfits()) - 1
 	} else if m.outfitter.tab == "installed" {
 		return len(m.currentShip.Weapons) + len(m.currentShip.Outfits) - 1
 	}
@@ -651,6 +651,12 @@ func (m Model) getOutfitEffectString(outfit *models.Outfit) string {
 	if outfit.SpeedBonus > 0 {
 		effects = append(effects, fmt.Sprintf("+%d speed", outfit.SpeedBonus))
 	}
+	if outfit.MiningBonus > 0 {
+		effects = append(effects, fmt.Sprintf("mining laser L%d", outfit.MiningBonus))
+	}
+	if outfit.ScannerBonus > 0 {
+		effects = append(effects, fmt.Sprintf("survey scanner L%d", outfit.ScannerBonus))
+	}
 	if len(effects) == 0 {
 		return "No effect"
 	}
@@ -662,13 +668,13 @@ func (m Model) getOutfitEffectString(outfit *models.Outfit) string {
 func (m Model) confirmInstallEquipment() tea.Cmd {
 	return func() tea.Msg {
 		if m.outfitter.tab == "weapons" {
-			weapons := models.StandardWeapons
+			weapons := models.AllWeapons()
 			if m.outfitter.cursor < len(weapons) {
 				m.outfitter.selectedWeapon = &weapons[m.outfitter.cursor]
 				m.outfitter.mode = "confirm_install"
 			}
 		} else if m.outfitter.tab == "outfits" {
-			outfits := models.StandardOutfits
+			outfits := models.AllOutfits()
 			if m.outfitter.cursor < len(outfits) {
 				m.outfitter.selectedOutfit = &outfits[m.outfitter.cursor]
 				m.outfitter.mode = "confirm_install"
@@ -828,8 +834,8 @@ func (m Model) loadOutfitter() tea.Cmd {
 		// For now, just return success
 		// In future, filter by tech level/location
 		return outfitterLoadedMsg{
-			weapons: models.StandardWeapons,
-			outfits: models.StandardOutfits,
+			weapons: models.AllWeapons(),
+			outfits: models.AllOutfits(),
 			err:     nil,
 		}
 	}
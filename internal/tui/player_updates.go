@@ -0,0 +1,151 @@
+// File: internal/tui/player_updates.go
+// Project: Terminal Velocity
+// Description: Session integration with real-time player update streams
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// Credits and cargo can change outside this session: an auction is won, a
+// bid is refunded, mail attachments are claimed, a crafting job completes, or
+// the API server handles a trade. The server publishes those changes on an
+// UpdateBus and each session streams the updates for its own player so the
+// HUD stays current.
+//
+// Update Flow:
+//   1. Player data loads and subscribePlayerUpdates() opens a stream
+//   2. waitForPlayerUpdate() blocks in a tea.Cmd until the next update
+//   3. Update() receives playerUpdateMsg, applies it, and waits again
+//   4. Close() cancels the stream context when the session ends
+
+package tui
+
+import (
+	"context"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/api"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+// playerUpdateMsg is sent when the player's update stream delivers a change
+type playerUpdateMsg struct {
+	update *api.PlayerUpdate
+}
+
+// shipRefreshedMsg carries the player's ship re-read after an external change
+type shipRefreshedMsg struct {
+	ship      *models.Ship
+	cargoOnly bool
+	err       error
+}
+
+// subscribePlayerUpdates opens this session's player update stream.
+// Safe to call again after a player reload; the existing stream is reused.
+func (m *Model) subscribePlayerUpdates() tea.Cmd {
+	if m.playerUpdates == nil || m.playerID == uuid.Nil {
+		return nil
+	}
+
+	if m.updateStream != nil {
+		if m.updateStreamPlayer == m.playerID {
+			return nil
+		}
+		m.cancelUpdates()
+		m.updateStream.Close()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.updateStream = m.playerUpdates.Subscribe(ctx, m.playerID)
+	m.updateStreamPlayer = m.playerID
+	m.cancelUpdates = cancel
+	return waitForPlayerUpdate(m.updateStream)
+}
+
+// waitForPlayerUpdate returns a command that blocks until the next update.
+// It returns nil once the stream ends, stopping the listen loop.
+func waitForPlayerUpdate(stream api.PlayerUpdateStream) tea.Cmd {
+	return func() tea.Msg {
+		update, err := stream.Recv()
+		if err != nil {
+			return nil
+		}
+		return playerUpdateMsg{update: update}
+	}
+}
+
+// handlePlayerUpdate applies an external change to the session's cached state.
+//
+// Credits and progress updates carry the new values and are applied
+// directly, so the session's autosave does not write back stale ones. Ship
+// and inventory updates trigger a re-read of the current ship, since the
+// TUI works with models.Ship rather than API types.
+func (m Model) handlePlayerUpdate(msg playerUpdateMsg) (tea.Model, tea.Cmd) {
+	cmds := []tea.Cmd{}
+	if m.updateStream != nil {
+		cmds = append(cmds, waitForPlayerUpdate(m.updateStream))
+	}
+
+	update := msg.update
+	switch update.Type {
+	case api.UpdateTypeCredits:
+		if m.player != nil && update.CreditsUpdate != nil {
+			m.player.Credits = update.CreditsUpdate.NewCredits
+		}
+	case api.UpdateTypeProgress:
+		if m.player != nil && update.ProgressUpdate != nil {
+			m.player.CraftingSkill = int(update.ProgressUpdate.CraftingSkill)
+			m.player.TotalCrafts = int(update.ProgressUpdate.TotalCrafts)
+			m.player.ResearchPoints = int(update.ProgressUpdate.ResearchPoints)
+		}
+	case api.UpdateTypeInventory:
+		cmds = append(cmds, m.refreshShip(true))
+	case api.UpdateTypeShip:
+		cmds = append(cmds, m.refreshShip(false))
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// refreshShip re-reads the player's current ship.
+// With cargoOnly set, only the cargo hold is replaced so that unsaved
+// in-flight state (position, shields during combat) is left alone.
+func (m Model) refreshShip(cargoOnly bool) tea.Cmd {
+	if m.player == nil || m.player.ShipID == uuid.Nil || m.shipRepo == nil {
+		return nil
+	}
+
+	shipID := m.player.ShipID
+	shipRepo := m.shipRepo
+	return func() tea.Msg {
+		ship, err := shipRepo.GetByID(context.Background(), shipID)
+		return shipRefreshedMsg{ship: ship, cargoOnly: cargoOnly, err: err}
+	}
+}
+
+// handleShipRefreshed installs a re-read ship if it is still the current one
+func (m Model) handleShipRefreshed(msg shipRefreshedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil || msg.ship == nil {
+		return m, nil
+	}
+	if m.currentShip == nil || m.currentShip.ID != msg.ship.ID {
+		return m, nil
+	}
+
+	if msg.cargoOnly {
+		m.currentShip.Cargo = msg.ship.Cargo
+	} else {
+		m.currentShip = msg.ship
+	}
+	return m, nil
+}
+
+// closePlayerUpdates cancels the session's update stream
+func (m Model) closePlayerUpdates() {
+	if m.cancelUpdates != nil {
+		m.cancelUpdates()
+	}
+	if m.updateStream != nil {
+		m.updateStream.Close()
+	}
+}
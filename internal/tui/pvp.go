@@ -1,7 +1,7 @@
 // File: internal/tui/pvp.go
 // Project: Terminal Velocity
 // Description: PvP Combat screen - Player versus player combat challenges and bounty hunting
-// Version: 1.2.0
+// Version: 1.4.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 //
@@ -9,7 +9,7 @@
 // - Player versus player combat challenge system
 // - Bounty board with wanted players
 // - Combat statistics and leaderboards
-// - Multiple challenge types (Duel, Aggression, Bounty Hunt)
+// - Multiple challenge types (Duel, Aggression, Bounty Hunt, Faction War)
 // - Wager system for stakes-based combat
 // - Combat rating and honor ranking
 // - Challenge management (create, accept, decline, track)
@@ -19,18 +19,21 @@
 //   - bounties: Bounty board with wanted players
 //   - stats: Combat statistics and top pilots leaderboard
 //   - create: Create new combat challenge form
+//   - battle: Live battle against another player (see pvp_battle.go)
 //
 // Challenge Types:
 //   - Duel (⚔️): Honorable 1v1 combat, no penalties
 //   - Aggression (💢): Unprovoked attack, may result in bounty
 //   - Bounty Hunt (🎯): Hunt wanted players for bounty reward
+//   - Faction War (⚔️): Attack a member of a faction yours is at war with;
+//     needs no consent but is refused in safe zones
 //
 // Challenge Flow:
 //   1. Create challenge: Select target, type, wager, message
 //   2. Challenge sent: Target receives challenge request
 //   3. Target accepts/declines
-//   4. On accept: Combat initiated via combat system
-//   5. Winner receives wager + reputation
+//   4. On accept: Both players enter a server-side battle room
+//   5. Winner receives wager + reputation, recorded in stats and leaderboard
 //
 // Bounty System:
 //   - Players earn bounties from criminal actions
@@ -63,7 +66,6 @@ import (
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/google/uuid"
 )
 
 // PvP view modes
@@ -73,6 +75,7 @@ const (
 	pvpViewBounties   = "bounties"   // Bounty board
 	pvpViewStats      = "stats"      // Player stats
 	pvpViewCreate     = "create"     // Create new challenge
+	pvpViewBattle     = "battle"     // Live battle
 )
 
 // pvpModel contains the state for the PvP combat screen.
@@ -82,6 +85,9 @@ type pvpModel struct {
 	cursor            int                   // Current cursor position in list
 	selectedChallenge *models.PvPChallenge  // Challenge being viewed/interacted with
 	challengeTypes    []models.PvPChallengeType // Available challenge types for creation
+	message           string                // Result of the last challenge action
+	tickID            int                   // Identifies the current battle refresh loop
+	confirmForfeit    bool                  // X was pressed once in a live battle
 
 	// Create mode fields
 	createTarget     string // Target player username
@@ -102,6 +108,7 @@ func newPvPModel() pvpModel {
 			models.ChallengeDuel,
 			models.ChallengeAggression,
 			models.ChallengeBountyHunt,
+			models.ChallengeFactionWar,
 		},
 		createType: string(models.ChallengeDuel),
 	}
@@ -112,13 +119,34 @@ func newPvPModel() pvpModel {
 //
 // View Mode Routing:
 //   - create: Handled by updatePvPCreate()
+//   - battle: Handled by updatePvPBattle()
 //   - challenges/bounties/stats: Handled by updatePvPList()
 func (m Model) updatePvP(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case pvpBattleTickMsg:
+		// Only the latest refresh loop keeps running
+		if msg.id != m.pvpModel.tickID || m.pvpModel.viewMode != pvpViewBattle {
+			return m, nil
+		}
+		return m, pvpBattleTick(msg.id)
+
+	case pvpActionMsg:
+		if msg.err != nil {
+			m.pvpModel.message = errorStyle.Render(fmt.Sprintf("Failed: %v", msg.err))
+			return m, nil
+		}
+		if msg.battle {
+			return m.openPvPBattle()
+		}
+		m.pvpModel.message = successStyle.Render(msg.message)
+		return m, nil
+
 	case tea.KeyMsg:
 		switch m.pvpModel.viewMode {
 		case pvpViewCreate:
 			return m.updatePvPCreate(msg)
+		case pvpViewBattle:
+			return m.updatePvPBattle(msg)
 		default:
 			return m.updatePvPList(msg)
 		}
@@ -180,19 +208,8 @@ func (m Model) updatePvPList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.pvpModel.viewMode == pvpViewChallenges {
 			challenges := m.pvpManager.GetPendingChallenges(m.playerID)
 			if m.pvpModel.cursor < len(challenges) {
-				challenge := challenges[m.pvpModel.cursor]
-				_ = m.pvpManager.AcceptChallenge(challenge.ID, m.playerID)
-
-				// Initialize PvP combat with the challenger
-				m.combatEnhanced.initializePvPCombat(
-					challenge.ID,
-					challenge.ChallengerName,
-					"Corvette", // Default ship type for now
-				)
-
-				// Transition to combat screen
-				m.screen = ScreenCombatEnhanced
-				return m, nil
+				// Accepting opens a battle room for both players
+				return m, m.acceptPvPChallengeCmd(challenges[m.pvpModel.cursor])
 			}
 		} else if m.pvpModel.viewMode == pvpViewBounties {
 			// Hunt bounty
@@ -254,7 +271,7 @@ func (m Model) updatePvPCreate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 
 			// Create challenge with actual target ID
-			_, _ = m.pvpManager.CreateChallenge(
+			challenge, err := m.pvpManager.CreateChallenge(
 				m.playerID,
 				m.username,
 				targetPlayer.ID,
@@ -264,6 +281,17 @@ func (m Model) updatePvPCreate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.pvpModel.createWager,
 				m.pvpModel.createMessage,
 			)
+			if err != nil {
+				m.pvpModel.message = errorStyle.Render(fmt.Sprintf("Challenge failed: %v", err))
+			} else if challenge.Type == models.ChallengeFactionWar {
+				// Faction war attacks start immediately
+				if view, exists := m.pvpManager.GetBattle(m.playerID); exists && view.ChallengeID == challenge.ID {
+					return m.openPvPBattle()
+				}
+				m.pvpModel.message = successStyle.Render(fmt.Sprintf("Attack on %s started", challenge.DefenderName))
+			} else {
+				m.pvpModel.message = successStyle.Render(fmt.Sprintf("Challenge sent to %s", challenge.DefenderName))
+			}
 		}
 		m.pvpModel.viewMode = pvpViewChallenges
 		m.pvpModel.cursor = 0
@@ -334,6 +362,8 @@ func (m Model) viewPvP() string {
 	switch m.pvpModel.viewMode {
 	case pvpViewCreate:
 		return m.viewPvPCreate()
+	case pvpViewBattle:
+		return m.viewPvPBattle()
 	case pvpViewBounties:
 		return m.viewPvPBounties()
 	case pvpViewStats:
@@ -421,6 +451,10 @@ func (m Model) viewPvPChallenges() string {
 		}
 	}
 
+	if m.pvpModel.message != "" {
+		s.WriteString("\n" + m.pvpModel.message + "\n")
+	}
+
 	s.WriteString("\n")
 	s.WriteString("Controls: [↑/↓] Navigate [A] Accept [R] Reject [N] New Challenge [Q] Back\n")
 
@@ -616,25 +650,10 @@ func (m Model) viewPvPCreate() string {
 	s.WriteString("Challenge Types:\n")
 	s.WriteString("  ⚔️  Duel: Honorable combat, no penalties\n")
 	s.WriteString("  💢 Aggression: Unprovoked attack, bounty risk\n")
-	s.WriteString("  🎯 Bounty Hunt: Hunt wanted players for reward\n\n")
+	s.WriteString("  🎯 Bounty Hunt: Hunt wanted players for reward\n")
+	s.WriteString("  ⚔️  Faction War: Attack an enemy faction's pilot outside safe zones\n\n")
 
 	s.WriteString("Controls: [Tab] Next Field [Enter] Send Challenge [Esc] Cancel\n")
 
 	return boxStyle.Render(s.String())
 }
-
-// simulateCombat simulates combat for demonstration purposes.
-// In production, combat is handled by the combat system.
-func (m *Model) simulateCombat(challengeID uuid.UUID) {
-	// Simple 50/50 simulation
-	winnerID := m.playerID
-
-	// Simulate combat result
-	_, _ = m.pvpManager.CompleteCombat(
-		challengeID,
-		winnerID,
-		1000, // Credits transfer
-		850,  // Winner damage dealt
-		450,  // Loser damage dealt
-	)
-}
@@ -0,0 +1,218 @@
+// File: internal/tui/pvp_battle.go
+// Project: Terminal Velocity
+// Description: PvP battle view - live two-player combat in a server-side battle room
+// Version: 1.1.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// An accepted challenge is fought in a battle room owned by the shared PvP
+// manager, so both players' sessions show the same battle. Each turn both
+// players choose an action; the turn resolves once both have chosen or the
+// turn timer runs out. The view redraws every second for the timer and
+// whenever the world hub reports a battle update.
+//
+// Leaving the battle forfeits it, and so does disconnecting. When a battle
+// starts, both players are taken to this view from wherever they are.
+
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/pvp"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+// pvpBattleRefreshInterval is how often the battle view redraws its timer
+const pvpBattleRefreshInterval = time.Second
+
+// pvpActionMsg reports the result of a PvP action run as a command
+type pvpActionMsg struct {
+	message string
+	err     error
+	battle  bool // A battle room was opened
+}
+
+// pvpBattleTickMsg redraws the battle view while it is open
+type pvpBattleTickMsg struct {
+	id int
+}
+
+// pvpBattleTick schedules the next redraw of the battle view
+func pvpBattleTick(id int) tea.Cmd {
+	return tea.Tick(pvpBattleRefreshInterval, func(time.Time) tea.Msg {
+		return pvpBattleTickMsg{id: id}
+	})
+}
+
+// acceptPvPChallengeCmd accepts a challenge, which loads both ships and
+// opens the battle room
+func (m Model) acceptPvPChallengeCmd(challenge *models.PvPChallenge) tea.Cmd {
+	pvpManager, playerID := m.pvpManager, m.playerID
+	return func() tea.Msg {
+		if err := pvpManager.AcceptChallenge(challenge.ID, playerID); err != nil {
+			return pvpActionMsg{err: err}
+		}
+		_, battle := pvpManager.GetBattle(playerID)
+		return pvpActionMsg{
+			message: fmt.Sprintf("Accepted %s's challenge", challenge.ChallengerName),
+			battle:  battle,
+		}
+	}
+}
+
+// openPvPBattle switches to the battle view and starts its refresh loop
+func (m Model) openPvPBattle() (Model, tea.Cmd) {
+	m.screen = ScreenPvP
+	m.pvpModel.viewMode = pvpViewBattle
+	m.pvpModel.confirmForfeit = false
+	m.pvpModel.message = ""
+	m.pvpModel.tickID++
+	return m, pvpBattleTick(m.pvpModel.tickID)
+}
+
+// updatePvPBattle handles input in the battle view.
+//
+// Key Bindings (live battle):
+//   - 1-9: Fire the weapon in that slot
+//   - f/space: Fire every ready weapon
+//   - h: Hold fire
+//   - x: Forfeit (press twice to confirm)
+//
+// Key Bindings (finished battle):
+//   - esc/q/enter: Return to the challenge list
+func (m Model) updatePvPBattle(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	view, exists := m.pvpManager.GetBattle(m.playerID)
+	if !exists || view.Over {
+		switch msg.String() {
+		case "esc", "q", "enter":
+			_ = m.pvpManager.DismissBattle(m.playerID)
+			m.pvpModel.viewMode = pvpViewChallenges
+			m.pvpModel.cursor = 0
+			m.pvpModel.message = ""
+		}
+		return m, nil
+	}
+
+	key := msg.String()
+	if key != "x" {
+		m.pvpModel.confirmForfeit = false
+	}
+
+	var action pvp.BattleAction
+	switch key {
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		action = pvp.BattleAction{Type: pvp.ActionFire, Slot: int(key[0] - '1')}
+	case "f", " ":
+		action = pvp.BattleAction{Type: pvp.ActionFireAll}
+	case "h":
+		action = pvp.BattleAction{Type: pvp.ActionHold}
+	case "x":
+		if !m.pvpModel.confirmForfeit {
+			m.pvpModel.confirmForfeit = true
+			m.pvpModel.message = errorStyle.Render("Press X again to forfeit the battle")
+			return m, nil
+		}
+		m.pvpModel.confirmForfeit = false
+		if err := m.pvpManager.ForfeitBattle(m.playerID); err != nil {
+			m.pvpModel.message = errorStyle.Render(fmt.Sprintf("Forfeit failed: %v", err))
+		} else {
+			m.pvpModel.message = ""
+		}
+		return m, nil
+	case "esc", "q":
+		m.pvpModel.message = helpStyle.Render("The battle is live - press X to forfeit")
+		return m, nil
+	default:
+		return m, nil
+	}
+
+	if err := m.pvpManager.SubmitAction(m.playerID, action); err != nil {
+		m.pvpModel.message = errorStyle.Render(err.Error())
+	} else {
+		m.pvpModel.message = ""
+	}
+	return m, nil
+}
+
+// viewPvPBattle renders the battle: both ships with hull and shields, the
+// player's weapons, the turn timer and the battle log
+func (m Model) viewPvPBattle() string {
+	view, exists := m.pvpManager.GetBattle(m.playerID)
+	if !exists {
+		return titleStyle.Render("⚔️ PvP Battle") + "\n\n" +
+			helpStyle.Render("You are not in a battle") + "\n" + renderFooter("ESC: Back")
+	}
+	me, opponent := view.Fighter(m.playerID), view.Opponent(m.playerID)
+
+	s := titleStyle.Render(fmt.Sprintf("⚔️ %s vs %s", me.PlayerName, opponent.PlayerName)) + "\n\n"
+
+	if view.Over {
+		if view.WinnerID == m.playerID {
+			s += successStyle.Render("VICTORY! "+view.Result) + "\n"
+		} else if view.WinnerID == uuid.Nil {
+			s += helpStyle.Render("DRAW! "+view.Result) + "\n"
+		} else {
+			s += errorStyle.Render("DEFEAT! "+view.Result) + "\n"
+		}
+		s += fmt.Sprintf("Damage dealt: %d  Damage taken: %d\n\n", me.DamageDealt, opponent.DamageDealt)
+	} else {
+		remaining := time.Until(view.Deadline).Round(time.Second)
+		if remaining < 0 {
+			remaining = 0
+		}
+		s += fmt.Sprintf("Turn %d/%d  -  %s left to act\n\n", view.Turn, view.MaxTurns, remaining)
+	}
+
+	for _, fighter := range []*pvp.BattleFighter{me, opponent} {
+		status := helpStyle.Render("choosing...")
+		if fighter.Acted {
+			status = successStyle.Render("ready")
+		}
+		if view.Over {
+			status = ""
+		}
+		s += fmt.Sprintf("%-30s Hull %s %d/%d  Shields %s %d/%d  %s\n",
+			fmt.Sprintf("%s (%s)", fighter.PlayerName, fighter.ShipName),
+			m.renderStatusBar(fighter.Hull, fighter.MaxHull, 10, "█", "░"), fighter.Hull, fighter.MaxHull,
+			m.renderStatusBar(fighter.Shields, fighter.MaxShields, 10, "█", "░"), fighter.Shields, fighter.MaxShields,
+			status)
+	}
+
+	if !view.Over {
+		s += "\n" + highlightStyle.Render("Weapons") + "\n"
+		for i, weapon := range me.Weapons {
+			if i >= 9 {
+				break
+			}
+			state := successStyle.Render("ready")
+			if !weapon.Ready {
+				state = helpStyle.Render(weapon.Status)
+			}
+			s += fmt.Sprintf("  [%d] %-24s %s\n", i+1, weapon.Name, state)
+		}
+	}
+
+	s += "\n"
+	lines := view.Log
+	if len(lines) > 10 {
+		lines = lines[len(lines)-10:]
+	}
+	for _, line := range lines {
+		s += helpStyle.Render(line) + "\n"
+	}
+
+	if m.pvpModel.message != "" {
+		s += "\n" + m.pvpModel.message + "\n"
+	}
+
+	if view.Over {
+		s += renderFooter("ESC: Back to Challenges")
+	} else {
+		s += renderFooter("1-9: Fire Weapon  F: Fire All  H: Hold Fire  X: Forfeit")
+	}
+	return s
+}
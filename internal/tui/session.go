@@ -0,0 +1,214 @@
+// File: internal/tui/session.go
+// Project: Terminal Velocity
+// Description: Game session tracking - Pushes resumable state to the session manager and restores it on reconnect
+// Version: 1.3.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// Once the player is loaded the model opens a session with the session
+// manager. After every update it pushes the player's location, screen and
+// any battle or hyperspace jump in progress; the manager saves that on its
+// autosave interval and when the connection ends. A player who reconnects
+// within the resume grace window (or logs in again while still connected,
+// with the takeover policy) is put back where they were:
+//   - a battle in progress is rebuilt with combat.Replay
+//   - a jump whose arrival was not processed arrives now (encounter roll included)
+//   - otherwise the saved screen is reopened as if chosen from the main menu
+
+package tui
+
+import (
+	"context"
+	"errors"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/combat"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/session"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+// combatScreenKey is the saved screen key for a battle in progress
+const combatScreenKey = "combat"
+
+// sessionScreenKeys names the screens a session can resume on. They are the
+// screens reachable from the main menu, which enterScreen can rebuild from
+// scratch. Other screens resume on the main menu.
+var sessionScreenKeys = map[Screen]string{
+	ScreenMainMenu:          "main_menu",
+	ScreenGame:              "game",
+	ScreenNavigation:        "navigation",
+	ScreenTrading:           "trading",
+	ScreenCargo:             "cargo",
+	ScreenShipyard:          "shipyard",
+	ScreenOutfitter:         "outfitter",
+	ScreenOutfitterEnhanced: "outfitter_enhanced",
+	ScreenShipManagement:    "ship_management",
+	ScreenMissions:          "missions",
+	ScreenQuests:            "quests",
+	ScreenAchievements:      "achievements",
+	ScreenLeaderboards:      "leaderboards",
+	ScreenPlayers:           "players",
+	ScreenChat:              "chat",
+	ScreenFactions:          "factions",
+	ScreenManufacturing:     "manufacturing",
+	ScreenArena:             "arena",
+	ScreenMining:            "mining",
+	ScreenTrade:             "trade",
+	ScreenPvP:               "pvp",
+	ScreenNews:              "news",
+	ScreenHelp:              "help",
+	ScreenSettings:          "settings",
+	ScreenTutorial:          "tutorial",
+}
+
+// sessionKickedMsg is sent when the server ends the session (another login
+// took it over, or it timed out).
+type sessionKickedMsg struct {
+	reason string
+}
+
+// openGameSession starts the session manager's session for the loaded
+// player and restores the state to resume, if any. Callers set the screen
+// the player lands on without a resume before calling it. Returns
+// session.ErrAlreadyConnected if the duplicate login policy refuses the login.
+func (m Model) openGameSession() (Model, tea.Cmd, error) {
+	if m.sessionManager == nil || m.player == nil {
+		return m, nil, nil
+	}
+
+	gameSession, resume, err := m.sessionManager.Connect(context.Background(), m.playerID, m.username, m.remoteIP)
+	if err != nil {
+		return m, nil, err
+	}
+	m.gameSession = gameSession
+
+	cmds := []tea.Cmd{waitForSessionKick(gameSession)}
+	if resume != nil {
+		var cmd tea.Cmd
+		m, cmd = m.resumeSession(resume)
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...), nil
+}
+
+// waitForSessionKick waits for the server to end the session
+func waitForSessionKick(gameSession *session.Session) tea.Cmd {
+	return func() tea.Msg {
+		<-gameSession.Kicked()
+		return sessionKickedMsg{reason: gameSession.KickReason()}
+	}
+}
+
+// resumeSession puts the player back where a saved state left them
+func (m Model) resumeSession(state *session.State) (Model, tea.Cmd) {
+	if state.Combat != nil {
+		engine, err := combat.Replay(state.Combat)
+		if err != nil {
+			log.Warn("Could not resume battle for %s: %v", m.username, err)
+		} else if m.resumeCombat(engine) {
+			return m, nil
+		}
+	}
+
+	if state.Jump != nil && m.player.CurrentSystem == state.Jump.ToSystemID {
+		// The jump was saved but the arrival was never processed
+		m.screen = ScreenNavigation
+		m.navigation = newNavigationModel()
+		return m, m.resumeJump(state.Jump.ToSystemID)
+	}
+
+	for screen, key := range sessionScreenKeys {
+		if key == state.Screen {
+			return m.enterScreen(screen)
+		}
+	}
+	return m, nil
+}
+
+// resumeJump completes the arrival of a jump whose fuel and location were
+// already saved
+func (m Model) resumeJump(systemID uuid.UUID) tea.Cmd {
+	return func() tea.Msg {
+		system, err := m.systemRepo.GetSystemByID(context.Background(), systemID)
+		if err != nil {
+			return jumpCompleteMsg{success: false, err: err}
+		}
+		return jumpCompleteMsg{success: true, system: system, resumed: true}
+	}
+}
+
+// sessionState captures the state to save for the current model
+func (m Model) sessionState() session.State {
+	state := session.State{
+		SystemID: m.player.CurrentSystem,
+		PlanetID: m.player.CurrentPlanet,
+	}
+
+	if m.screen == ScreenCombat {
+		if m.combat.engine != nil && m.combat.engine.Outcome() == combat.OutcomeNone {
+			state.Screen = combatScreenKey
+			state.Combat = m.combat.engine.Record()
+		}
+	} else if key, ok := sessionScreenKeys[m.screen]; ok {
+		state.Screen = key
+	}
+
+	if m.navigation.jumping && m.navigation.jumpTarget != nil && m.navigation.currentSystem != nil {
+		state.Jump = &session.JumpState{
+			FromSystemID: m.navigation.currentSystem.ID,
+			ToSystemID:   m.navigation.jumpTarget.ID,
+		}
+	}
+
+	return state
+}
+
+// syncSession reports activity and the current state to the session manager
+func (m Model) syncSession(msg tea.Msg) {
+	if m.sessionManager == nil || m.gameSession == nil || m.player == nil {
+		return
+	}
+
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m.sessionManager.UpdateActivity(m.playerID, sessionScreenKeys[m.screen])
+	}
+	m.sessionManager.UpdateState(m.gameSession, m.sessionState())
+}
+
+// closeGameSession saves the session's final state
+func (m Model) closeGameSession() {
+	if m.sessionManager == nil || m.gameSession == nil {
+		return
+	}
+	if err := m.sessionManager.Disconnect(context.Background(), m.gameSession); err != nil {
+		log.Warn("Failed to save session for %s: %v", m.username, err)
+	}
+}
+
+// sessionReplaced reports whether a newer login took this session over
+func (m Model) sessionReplaced() bool {
+	if m.gameSession == nil {
+		return false
+	}
+	select {
+	case <-m.gameSession.Kicked():
+		return m.gameSession.Replaced()
+	default:
+		return false
+	}
+}
+
+// ExitMessage returns a message to show the player after the program exits
+// (for example why the session was ended), or "".
+func (m Model) ExitMessage() string {
+	return m.exitMessage
+}
+
+// refuseDuplicateLogin returns the message shown when the duplicate login
+// policy rejects a login
+func refuseDuplicateLogin(err error) string {
+	if errors.Is(err, session.ErrAlreadyConnected) {
+		return "You are already logged in from another connection"
+	}
+	return "Failed to start session: " + err.Error()
+}
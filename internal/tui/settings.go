@@ -1,7 +1,7 @@
 // File: internal/tui/settings.go
 // Project: Terminal Velocity
 // Description: Settings screen - Player preferences and configuration across 6 categories
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 //
@@ -20,6 +20,7 @@
 //   - Controls: Keybindings for movement, actions, combat (view-only, customization coming soon)
 //   - Privacy: Online status, location, ship info visibility, trade/PvP/party requests, blocklist, friends list
 //   - Notifications: Achievement, level up, trade, combat, player joined, news, encounters, system messages, chat notifications
+//   - Security: Two-factor authentication and recent logins (opens the two-factor screen)
 //
 // Display Settings:
 //   - Color Scheme: default, dark, light, high_contrast, colorblind
@@ -180,6 +181,10 @@ func (m Model) handleSettingsSelect() (tea.Model, tea.Cmd) {
 	if m.settingsModel.viewMode == settingsViewMain {
 		// Navigate to category
 		categories := []string{"display", "audio", "gameplay", "controls", "privacy", "notifications"}
+		if m.settingsModel.cursor == len(categories) {
+			// Security lives on its own screen
+			return m.openTwoFactorSettings()
+		}
 		if m.settingsModel.cursor < len(categories) {
 			m.settingsModel.viewMode = categories[m.settingsModel.cursor]
 			m.settingsModel.cursor = 0
@@ -343,7 +348,7 @@ func (m *Model) toggleNotificationSetting() {
 func (m Model) getSettingsMaxCursor() int {
 	switch m.settingsModel.viewMode {
 	case settingsViewMain:
-		return 5 // 6 categories
+		return 6 // 6 categories + Security
 	case settingsViewDisplay:
 		return 4 // 5 settings
 	case settingsViewAudio:
@@ -401,6 +406,7 @@ func (m Model) viewSettingsMain() string {
 		{"Controls", "Keybindings and input settings"},
 		{"Privacy", "Visibility and social settings"},
 		{"Notifications", "Alert and message preferences"},
+		{"Security", "Two-factor authentication and recent logins"},
 	}
 
 	for i, cat := range categories {
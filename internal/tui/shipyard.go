@@ -1,7 +1,7 @@
 // File: internal/tui/shipyard.go
 // Project: Terminal Velocity
 // Description: Shipyard screen - Ship purchasing and comparison interface
-// Version: 1.1.0
+// Version: 1.2.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 //
@@ -823,7 +823,7 @@ func (m Model) loadShipyard() tea.Cmd {
 
 		// Filter ships by player's combat rating and tech level
 		var availableShips []models.ShipType
-		for _, ship := range models.StandardShipTypes {
+		for _, ship := range models.AllShipTypes() {
 			// Check combat rating requirement
 			if ship.MinCombatRating > m.player.CombatRating {
 				continue
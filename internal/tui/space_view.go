@@ -1,7 +1,7 @@
 // File: internal/tui/space_view.go
 // Project: Terminal Velocity
 // Description: Main space view with 2D viewport, HUD, radar, status, and real-time interactions
-// Version: 1.1.0
+// Version: 1.4.0
 // Author: Joshua Ferguson
 // Created: 2025-01-14
 
@@ -40,8 +40,9 @@ type spaceObject struct {
 	x, y     float64
 	distance float64
 	hostile  bool
-	objType  string    // "planet", "ship", "enemy", "player"
-	playerID uuid.UUID // Player ID for DM chat (only for player ships)
+	objType   string    // "planet", "station", "ship", "enemy", "player"
+	playerID  uuid.UUID // Player ID for DM chat (only for player ships)
+	stationID uuid.UUID // Player station to dock at (only for stations)
 }
 
 type playerPosition struct {
@@ -113,6 +114,33 @@ func convertPlanetsToSpaceObjects(planets []*models.Planet, playerPos playerPosi
 	return objects
 }
 
+// convertStationsToSpaceObjects converts player stations to spaceObject for
+// display, on a wider ring than the planets
+func convertStationsToSpaceObjects(stations []*models.PlayerStation, playerPos playerPosition) []spaceObject {
+	objects := make([]spaceObject, 0, len(stations))
+
+	for i, station := range stations {
+		angle := (float64(i) + 0.5) * (360.0 / float64(len(stations))) * (3.14159 / 180.0)
+		distance := 250.0 + float64(i)*20.0
+
+		x := playerPos.x + distance*math.Cos(angle)
+		y := playerPos.y + distance*math.Sin(angle)
+
+		objects = append(objects, spaceObject{
+			name:      station.Name,
+			icon:      "⌂", // Station icon
+			x:         x,
+			y:         y,
+			distance:  distance,
+			hostile:   false,
+			objType:   "station",
+			stationID: station.ID,
+		})
+	}
+
+	return objects
+}
+
 // Command functions for async space view operations
 
 // loadSpaceViewDataCmd loads current system data, planets, and nearby ships
@@ -147,6 +175,12 @@ func (m Model) loadSpaceViewDataCmd() tea.Cmd {
 			}
 		}
 
+		// Player stations are dockable locations in their system
+		var stations []*models.PlayerStation
+		if m.player != nil && m.manufacturingManager != nil {
+			stations = m.manufacturingManager.GetStationsInSystem(m.player.CurrentSystem)
+		}
+
 		// Get nearby ships from presence manager
 		if m.player != nil && m.presenceManager != nil {
 			playersInSystem := m.presenceManager.GetPlayersInSystem(m.player.CurrentSystem)
@@ -178,6 +212,7 @@ func (m Model) loadSpaceViewDataCmd() tea.Cmd {
 		return spaceViewLoadedMsg{
 			system:      system,
 			planets:     planets,
+			stations:    stations,
 			nearbyShips: nearbyShips,
 			playerShip:  m.currentShip,
 			err:         nil,
@@ -192,13 +227,8 @@ func (m Model) cycleTargetCmd() tea.Cmd {
 		targetables := []interface{}{}
 		targetTypes := []string{}
 
-		// Add planets
-		for _, planet := range m.spaceView.planets {
-			targetables = append(targetables, planet)
-			targetTypes = append(targetTypes, "planet")
-		}
-
-		// Add ships
+		// Add planets, stations and ships (in the order they are displayed,
+		// so the index matches m.spaceView.ships)
 		for _, ship := range m.spaceView.ships {
 			targetables = append(targetables, ship)
 			targetTypes = append(targetTypes, ship.objType)
@@ -261,6 +291,12 @@ func (m Model) hailTargetCmd() tea.Cmd {
 			message += "Receiving docking clearance and planet information.\n"
 			message += "Use [L] to land on this planet."
 
+		case "station":
+			// Hailing a player station - offer docking
+			message = "Hailing " + target.name + "...\n"
+			message += "Station control: \"Docking bay open.\"\n"
+			message += "Use [L] to dock at this station."
+
 		case "player":
 			// Hailing another player - could open DM chat
 			message = "Hailing " + target.name + "...\n"
@@ -660,7 +696,18 @@ func (m Model) updateSpaceView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case "l", "L":
-			// Land on planet (if near one)
+			// Dock at a targeted station, otherwise land on planet (if near one)
+			if m.spaceView.hasTarget && m.spaceView.targetIndex < len(m.spaceView.ships) {
+				if target := m.spaceView.ships[m.spaceView.targetIndex]; target.objType == "station" {
+					m.cancelMining("Docked at " + target.name)
+					m.manufacturingModel = newManufacturingModel()
+					m.manufacturingModel.dockedStationID = target.stationID
+					m.manufacturingModel.message = successStyle.Render("Docked at " + target.name)
+					m.screen = ScreenManufacturing
+					return m, nil
+				}
+			}
+			m.cancelMining("Landed")
 			m.screen = ScreenLanding
 			return m, nil
 
@@ -752,7 +799,7 @@ func (m Model) updateSpaceView(msg tea.Msg) (tea.Model, tea.Cmd) {
 								if err == nil && faction != nil {
 									// Convert UUID to string for faction ID
 									factionIDStr := factionID.String()
-									memberIDs := faction.Members
+									memberIDs := m.factionChatRecipients(faction)
 
 									m.chatManager.SendFactionMessage(factionIDStr, m.playerID, username, m.spaceView.chatInput, memberIDs)
 								} else {
@@ -821,10 +868,12 @@ func (m Model) updateSpaceView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Convert ships and planets to spaceObjects for rendering
 			shipObjects := convertShipsToSpaceObjects(msg.nearbyShips, m.spaceView.player)
 			planetObjects := convertPlanetsToSpaceObjects(msg.planets, m.spaceView.player)
+			stationObjects := convertStationsToSpaceObjects(msg.stations, m.spaceView.player)
 
-			// Combine all objects (ships + planets)
-			allObjects := make([]spaceObject, 0, len(shipObjects)+len(planetObjects))
+			// Combine all objects (planets + stations + ships)
+			allObjects := make([]spaceObject, 0, len(shipObjects)+len(planetObjects)+len(stationObjects))
 			allObjects = append(allObjects, planetObjects...)
+			allObjects = append(allObjects, stationObjects...)
 			allObjects = append(allObjects, shipObjects...)
 			m.spaceView.ships = allObjects
 
@@ -1,7 +1,7 @@
 // File: internal/tui/trading.go
 // Project: Terminal Velocity
 // Description: Trading screen - Commodity market and dynamic economy interface
-// Version: 1.3.0
+// Version: 1.5.0
 // Author: Joshua Ferguson
 // Created: 2025-01-07
 //
@@ -19,7 +19,7 @@
 // - Prices fluctuate based on stock and demand
 // - Player trades affect market conditions
 // - Cargo space limits enforced by ship type
-// - Transaction rollback on database errors
+// - Atomic transactions via trading.Service (no partial trades)
 // - 50% price adjustment on supply/demand changes
 
 package tui
@@ -29,10 +29,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/game/trading"
-	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -49,7 +47,6 @@ type tradingModel struct {
 	currentPlanet     *models.Planet          // Current planet (market location)
 	loading           bool                    // True while loading market data
 	error             string                  // Error or status message to display
-	pricingEngine     *trading.PricingEngine  // Engine for dynamic price calculations
 }
 
 // marketLoadedMsg is sent when market data has been loaded from database.
@@ -64,21 +61,20 @@ type marketLoadedMsg struct {
 // tradeCompleteMsg is sent when a buy/sell transaction completes.
 // Contains success status, profit/loss amount, and any transaction error.
 type tradeCompleteMsg struct {
-	success bool   // True if trade succeeded
-	profit  int64  // Profit (positive for sell) or cost (negative for buy)
-	err     error  // Error if trade failed
+	success bool                 // True if trade succeeded
+	profit  int64                // Profit (positive for sell) or cost (negative for buy)
+	result  *trading.TradeResult // Committed trade (nil on failure)
+	err     error                // Error if trade failed
 }
 
 // newTradingModel creates and initializes a new trading screen model.
 // Sets loading flag to true to trigger market data load on screen entry.
-// Initializes pricing engine for dynamic market calculations.
 func newTradingModel() tradingModel {
 	return tradingModel{
-		cursor:        0,
-		mode:          "market",
-		quantity:      1,
-		loading:       true,
-		pricingEngine: trading.NewPricingEngine(),
+		cursor:   0,
+		mode:     "market",
+		quantity: 1,
+		loading:  true,
 	}
 }
 
@@ -196,7 +192,8 @@ func (m Model) updateTrading(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tradeCompleteMsg:
 		if msg.success {
-			// Trade successful - reload market
+			// Trade successful - sync local state and reload market
+			m.applyTradeResult(msg.result)
 			m.trading.mode = "market"
 			m.trading.quantity = 1
 			m.trading.selectedCommodity = nil
@@ -512,7 +509,7 @@ func (m Model) loadTradingMarket() tea.Cmd {
 		}
 
 		// Get all commodities
-		commodities := models.StandardCommodities
+		commodities := models.AllCommodities()
 
 		// Sort by category
 		sort.Slice(commodities, func(i, j int) bool {
@@ -531,235 +528,101 @@ func (m Model) loadTradingMarket() tea.Cmd {
 	}
 }
 
-// executeBuy executes a buy transaction
+// executeBuy executes a buy transaction.
+// Credits, cargo and market stock are updated atomically by the trading service;
+// local player/ship state is synced from the result in Update.
 func (m Model) executeBuy() tea.Cmd {
-	return func() tea.Msg {
-		ctx := context.Background()
+	commodity := m.trading.selectedCommodity
+	quantity := m.trading.quantity
+	playerID := m.playerID
 
-		// Validate we have all required data
-		if m.trading.selectedCommodity == nil {
+	return func() tea.Msg {
+		if commodity == nil {
 			return tradeCompleteMsg{
 				success: false,
 				err:     fmt.Errorf("no commodity selected"),
 			}
 		}
-
-		if m.currentShip == nil {
-			return tradeCompleteMsg{
-				success: false,
-				err:     fmt.Errorf("no ship available"),
-			}
-		}
-
-		if m.trading.currentPlanet == nil {
-			return tradeCompleteMsg{
-				success: false,
-				err:     fmt.Errorf("not docked at a planet"),
-			}
-		}
-
-		// Find market price
-		var price *models.MarketPrice
-		for _, p := range m.trading.marketPrices {
-			if p.CommodityID == m.trading.selectedCommodity.ID {
-				price = p
-				break
-			}
-		}
-
-		if price == nil {
-			return tradeCompleteMsg{
-				success: false,
-				err:     fmt.Errorf("price data not available"),
-			}
-		}
-
-		// Calculate total cost
-		totalCost := price.SellPrice * int64(m.trading.quantity)
-
-		// Validate credits
-		if totalCost > m.player.Credits {
+		if m.tradingService == nil {
 			return tradeCompleteMsg{
 				success: false,
-				err:     fmt.Errorf("insufficient credits (need %d)", totalCost),
+				err:     fmt.Errorf("trading unavailable"),
 			}
 		}
 
-		// Validate cargo space
-		cargoSpace := 100 // Default fallback
-		shipType := models.GetShipTypeByID(m.currentShip.TypeID)
-		if shipType != nil {
-			cargoSpace = shipType.CargoSpace
-		}
-		cargoUsed := m.currentShip.GetCargoUsed()
-		cargoAvailable := cargoSpace - cargoUsed
-		if m.trading.quantity > cargoAvailable {
-			return tradeCompleteMsg{
-				success: false,
-				err:     fmt.Errorf("insufficient cargo space (have %d)", cargoAvailable),
-			}
-		}
-
-		// Validate stock availability
-		if m.trading.quantity > price.Stock {
-			return tradeCompleteMsg{
-				success: false,
-				err:     fmt.Errorf("insufficient stock (available: %d)", price.Stock),
-			}
-		}
-
-		// Execute transaction: deduct credits
-		newCredits := m.player.Credits - totalCost
-		err := m.playerRepo.UpdateCredits(ctx, m.player.ID, newCredits)
-		if err != nil {
-			return tradeCompleteMsg{
-				success: false,
-				err:     fmt.Errorf("failed to update credits: %w", err),
-			}
-		}
-
-		// Add cargo to ship
-		err = m.shipRepo.AddCargo(ctx, m.currentShip.ID, m.trading.selectedCommodity.ID, m.trading.quantity)
+		result, err := m.tradingService.Buy(context.Background(), playerID, commodity.ID, quantity)
 		if err != nil {
-			// Rollback: restore credits
-			m.playerRepo.UpdateCredits(ctx, m.player.ID, m.player.Credits)
 			return tradeCompleteMsg{
 				success: false,
-				err:     fmt.Errorf("failed to add cargo: %w", err),
+				err:     err,
 			}
 		}
 
-		// Update market: decrease stock, adjust prices
-		price.Stock -= m.trading.quantity
-		price.Demand += m.trading.quantity / 2
-		price.BuyPrice, price.SellPrice = m.trading.pricingEngine.CalculateMarketPrice(
-			m.trading.selectedCommodity,
-			m.trading.currentPlanet,
-			price.Stock,
-			price.Demand,
-		)
-		price.LastUpdate = time.Now().Unix()
-
-		err = m.marketRepo.UpdateMarketPrice(ctx, price)
-		if err != nil {
-			// Continue anyway - market update failure shouldn't block the trade
-			logger.Warn("Failed to update market price after buy: commodityID=%s, error=%v", m.trading.selectedCommodity.ID, err)
-		}
-
-		// Update local player state
-		m.player.Credits = newCredits
-
 		return tradeCompleteMsg{
 			success: true,
-			profit:  -totalCost, // Negative because we spent money
-			err:     nil,
+			profit:  -result.Total, // Negative because we spent money
+			result:  result,
 		}
 	}
 }
 
-// executeSell executes a sell transaction
+// executeSell executes a sell transaction.
+// Cargo, credits and market stock are updated atomically by the trading service;
+// local player/ship state is synced from the result in Update.
 func (m Model) executeSell() tea.Cmd {
-	return func() tea.Msg {
-		ctx := context.Background()
+	commodity := m.trading.selectedCommodity
+	quantity := m.trading.quantity
+	playerID := m.playerID
 
-		// Validate we have all required data
-		if m.trading.selectedCommodity == nil {
+	return func() tea.Msg {
+		if commodity == nil {
 			return tradeCompleteMsg{
 				success: false,
 				err:     fmt.Errorf("no commodity selected"),
 			}
 		}
-
-		if m.currentShip == nil {
-			return tradeCompleteMsg{
-				success: false,
-				err:     fmt.Errorf("no ship available"),
-			}
-		}
-
-		if m.trading.currentPlanet == nil {
-			return tradeCompleteMsg{
-				success: false,
-				err:     fmt.Errorf("not docked at a planet"),
-			}
-		}
-
-		// Find market price
-		var price *models.MarketPrice
-		for _, p := range m.trading.marketPrices {
-			if p.CommodityID == m.trading.selectedCommodity.ID {
-				price = p
-				break
-			}
-		}
-
-		if price == nil {
-			return tradeCompleteMsg{
-				success: false,
-				err:     fmt.Errorf("price data not available"),
-			}
-		}
-
-		// Validate cargo
-		inCargo := m.currentShip.GetCommodityQuantity(m.trading.selectedCommodity.ID)
-		if m.trading.quantity > inCargo {
-			return tradeCompleteMsg{
-				success: false,
-				err:     fmt.Errorf("insufficient cargo (have %d)", inCargo),
-			}
-		}
-
-		// Calculate total revenue
-		totalRevenue := price.BuyPrice * int64(m.trading.quantity)
-
-		// Execute transaction: remove cargo from ship
-		err := m.shipRepo.RemoveCargo(ctx, m.currentShip.ID, m.trading.selectedCommodity.ID, m.trading.quantity)
-		if err != nil {
+		if m.tradingService == nil {
 			return tradeCompleteMsg{
 				success: false,
-				err:     fmt.Errorf("failed to remove cargo: %w", err),
+				err:     fmt.Errorf("trading unavailable"),
 			}
 		}
 
-		// Add credits
-		newCredits := m.player.Credits + totalRevenue
-		err = m.playerRepo.UpdateCredits(ctx, m.player.ID, newCredits)
+		result, err := m.tradingService.Sell(context.Background(), playerID, commodity.ID, quantity)
 		if err != nil {
-			// Rollback: restore cargo
-			m.shipRepo.AddCargo(ctx, m.currentShip.ID, m.trading.selectedCommodity.ID, m.trading.quantity)
 			return tradeCompleteMsg{
 				success: false,
-				err:     fmt.Errorf("failed to update credits: %w", err),
+				err:     err,
 			}
 		}
 
-		// Update market: increase stock, adjust prices
-		price.Stock += m.trading.quantity
-		price.Demand -= m.trading.quantity / 3
-		if price.Demand < 10 {
-			price.Demand = 10
+		return tradeCompleteMsg{
+			success: true,
+			profit:  result.Total, // Positive because we gained money
+			result:  result,
 		}
-		price.BuyPrice, price.SellPrice = m.trading.pricingEngine.CalculateMarketPrice(
-			m.trading.selectedCommodity,
-			m.trading.currentPlanet,
-			price.Stock,
-			price.Demand,
-		)
-		price.LastUpdate = time.Now().Unix()
+	}
+}
 
-		err = m.marketRepo.UpdateMarketPrice(ctx, price)
-		if err != nil {
-			// Continue anyway - market update failure shouldn't block the trade
-			logger.Warn("Failed to update market price after sell: commodityID=%s, error=%v", m.trading.selectedCommodity.ID, err)
+// applyTradeResult syncs the session's player credits and ship cargo with a
+// committed trade and records the trade toward territory control in the
+// player's system. Must be called from Update, never from a tea.Cmd.
+func (m *Model) applyTradeResult(result *trading.TradeResult) {
+	if result == nil {
+		return
+	}
+	if m.player != nil {
+		m.player.Credits = result.NewCredits
+		if m.territoryManager != nil {
+			m.territoryManager.RecordTrade(m.player.CurrentSystem, m.territoryFactionID(), result.Total)
 		}
-
-		// Update local player state
-		m.player.Credits = newCredits
-
-		return tradeCompleteMsg{
-			success: true,
-			profit:  totalRevenue, // Positive because we gained money
-			err:     nil,
+	}
+	if m.currentShip != nil {
+		delta := result.CargoQuantity - m.currentShip.GetCommodityQuantity(result.CommodityID)
+		if delta > 0 {
+			m.currentShip.AddCargo(result.CommodityID, delta)
+		} else if delta < 0 {
+			m.currentShip.RemoveCargo(result.CommodityID, -delta)
 		}
 	}
 }
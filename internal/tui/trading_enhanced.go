@@ -1,7 +1,7 @@
 // File: internal/tui/trading_enhanced.go
 // Project: Terminal Velocity
 // Description: Enhanced trading screen with market listings
-// Version: 1.0.0
+// Version: 1.1.0
 // Author: Joshua Ferguson
 // Created: 2025-01-14
 
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/game/trading"
 	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -194,194 +195,49 @@ func (m Model) viewTradingEnhanced() string {
 
 // buyCommodityCmd purchases a commodity from the market
 func (m Model) buyCommodityCmd(commodityName string, quantity int) tea.Cmd {
-	return func() tea.Msg {
-		ctx := context.Background()
-
-		// Validate inputs
-		if quantity <= 0 {
-			quantity = 1 // Default to 1
-		}
-
-		// Check if player has a ship
-		if m.currentShip == nil {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("no ship equipped"),
-			}
-		}
-
-		// Check if player is on a planet
-		if m.player.CurrentPlanet == nil {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("not landed on a planet"),
-			}
-		}
-
-		// Get market price for this commodity
-		// Map commodity name to standardized commodity ID
-		commodityID := getCommodityID(commodityName)
-		marketPrice, err := m.marketRepo.GetMarketPrice(ctx, *m.player.CurrentPlanet, commodityID)
-		if err != nil {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("commodity not available: %w", err),
-			}
-		}
-
-		// Calculate total cost
-		totalCost := marketPrice.BuyPrice * int64(quantity)
-
-		// Check if player has enough credits
-		if m.player.Credits < totalCost {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("insufficient credits (need %d, have %d)", totalCost, m.player.Credits),
-			}
-		}
-
-		// Check cargo space
-		shipType := models.GetShipTypeByID(m.currentShip.TypeID)
-		if shipType == nil {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("ship type not found"),
-			}
-		}
-
-		cargoUsed := m.currentShip.GetCargoUsed()
-		cargoAvailable := shipType.CargoSpace - cargoUsed
-
-		if quantity > cargoAvailable {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("insufficient cargo space (need %d tons, have %d tons available)", quantity, cargoAvailable),
-			}
-		}
-
-		// Add cargo to ship
-		err = m.shipRepo.AddCargo(ctx, m.currentShip.ID, commodityID, quantity)
-		if err != nil {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("failed to add cargo: %w", err),
-			}
-		}
-
-		// Deduct credits
-		m.player.Credits -= totalCost
-		err = m.playerRepo.UpdateCredits(ctx, m.playerID, m.player.Credits)
-		if err != nil {
-			// Rollback cargo addition
-			_ = m.shipRepo.RemoveCargo(ctx, m.currentShip.ID, commodityID, quantity)
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("failed to deduct credits: %w", err),
-			}
-		}
-
-		// Update market stock (decrease)
-		_ = m.marketRepo.UpdateStock(ctx, *m.player.CurrentPlanet, commodityID, -quantity)
-
-		return transactionCompleteMsg{
-			action:      "buy",
-			commodityID: commodityName,
-			quantity:    quantity,
-			newBalance:  m.player.Credits,
-			err:         nil,
-		}
+	if quantity <= 0 {
+		quantity = 1 // Default to 1
 	}
+	return m.enhancedTradeCmd("buy", commodityName, func(ctx context.Context, commodityID string) (*trading.TradeResult, error) {
+		return m.tradingService.Buy(ctx, m.playerID, commodityID, quantity)
+	})
 }
 
 // sellCommodityCmd sells a commodity to the market
 func (m Model) sellCommodityCmd(commodityName string, quantity int) tea.Cmd {
-	return func() tea.Msg {
-		ctx := context.Background()
-
-		// Validate inputs
-		if quantity <= 0 {
-			quantity = 1 // Default to 1
-		}
-
-		// Check if player has a ship
-		if m.currentShip == nil {
-			return transactionCompleteMsg{
-				action: "sell",
-				err:    fmt.Errorf("no ship equipped"),
-			}
-		}
-
-		// Check if player is on a planet
-		if m.player.CurrentPlanet == nil {
-			return transactionCompleteMsg{
-				action: "sell",
-				err:    fmt.Errorf("not landed on a planet"),
-			}
-		}
-
-		// Get commodity ID
-		commodityID := getCommodityID(commodityName)
-
-		// Check if player actually has this commodity in cargo
-		hasQuantity := false
-		quantityInCargo := 0
-		for _, item := range m.currentShip.Cargo {
-			if item.CommodityID == commodityID {
-				hasQuantity = item.Quantity >= quantity
-				quantityInCargo = item.Quantity
-				break
-			}
-		}
-
-		if !hasQuantity {
-			return transactionCompleteMsg{
-				action: "sell",
-				err:    fmt.Errorf("insufficient cargo (need %d tons, have %d tons)", quantity, quantityInCargo),
-			}
-		}
-
-		// Get market price for this commodity
-		marketPrice, err := m.marketRepo.GetMarketPrice(ctx, *m.player.CurrentPlanet, commodityID)
-		if err != nil {
-			return transactionCompleteMsg{
-				action: "sell",
-				err:    fmt.Errorf("commodity not available: %w", err),
-			}
-		}
-
-		// Calculate total earnings
-		totalEarnings := marketPrice.SellPrice * int64(quantity)
+	if quantity <= 0 {
+		quantity = 1 // Default to 1
+	}
+	return m.enhancedTradeCmd("sell", commodityName, func(ctx context.Context, commodityID string) (*trading.TradeResult, error) {
+		return m.tradingService.Sell(ctx, m.playerID, commodityID, quantity)
+	})
+}
 
-		// Remove cargo from ship
-		err = m.shipRepo.RemoveCargo(ctx, m.currentShip.ID, commodityID, quantity)
-		if err != nil {
+// enhancedTradeCmd runs a trading service call for the enhanced trading screen
+func (m Model) enhancedTradeCmd(action, commodityName string, trade func(ctx context.Context, commodityID string) (*trading.TradeResult, error)) tea.Cmd {
+	return func() tea.Msg {
+		if m.tradingService == nil {
 			return transactionCompleteMsg{
-				action: "sell",
-				err:    fmt.Errorf("failed to remove cargo (not enough in cargo?): %w", err),
+				action: action,
+				err:    fmt.Errorf("trading unavailable"),
 			}
 		}
 
-		// Add credits
-		m.player.Credits += totalEarnings
-		err = m.playerRepo.UpdateCredits(ctx, m.playerID, m.player.Credits)
+		// Map commodity name to standardized commodity ID
+		result, err := trade(context.Background(), getCommodityID(commodityName))
 		if err != nil {
-			// Rollback cargo removal
-			_ = m.shipRepo.AddCargo(ctx, m.currentShip.ID, commodityID, quantity)
 			return transactionCompleteMsg{
-				action: "sell",
-				err:    fmt.Errorf("failed to add credits: %w", err),
+				action: action,
+				err:    err,
 			}
 		}
 
-		// Update market stock (increase)
-		_ = m.marketRepo.UpdateStock(ctx, *m.player.CurrentPlanet, commodityID, quantity)
-
 		return transactionCompleteMsg{
-			action:      "sell",
+			action:      action,
 			commodityID: commodityName,
-			quantity:    quantity,
-			newBalance:  m.player.Credits,
-			err:         nil,
+			quantity:    result.Quantity,
+			newBalance:  result.NewCredits,
+			result:      result,
 		}
 	}
 }
@@ -422,7 +278,7 @@ func (m Model) updateTradingEnhanced(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Max buy - calculate maximum affordable quantity
 			if m.tradingEnhanced.selectedCommodity < len(m.tradingEnhanced.commodities) {
 				commodity := m.tradingEnhanced.commodities[m.tradingEnhanced.selectedCommodity]
-				return m, m.maxBuyCommodityCmd(commodity.name, commodity.buyPrice)
+				return m, m.maxBuyCommodityCmd(commodity.name)
 			}
 			return m, nil
 
@@ -447,7 +303,8 @@ func (m Model) updateTradingEnhanced(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.errorMessage = fmt.Sprintf("%s failed: %v", msg.action, msg.err)
 			m.showErrorDialog = true
 		} else {
-			// Show success message
+			// Sync local credits/cargo, then show success message
+			m.applyTradeResult(msg.result)
 			var actionText string
 			if msg.action == "buy" {
 				actionText = "Purchased"
@@ -456,6 +313,9 @@ func (m Model) updateTradingEnhanced(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.errorMessage = fmt.Sprintf("%s %d %s. Balance: %d credits",
 				actionText, msg.quantity, msg.commodityID, msg.newBalance)
+			if msg.result != nil && msg.result.Fee > 0 {
+				m.errorMessage += fmt.Sprintf(" (territory market fee: %d credits)", msg.result.Fee)
+			}
 			m.showErrorDialog = true
 		}
 		return m, nil
@@ -488,204 +348,19 @@ func getCommodityID(commodityName string) string {
 	return strings.ToLower(strings.ReplaceAll(commodityName, " ", "_"))
 }
 
-// maxBuyCommodityCmd calculates and purchases maximum affordable quantity
-func (m Model) maxBuyCommodityCmd(commodityName string, pricePerTon int) tea.Cmd {
-	return func() tea.Msg {
-		ctx := context.Background()
-
-		// Check if player has a ship
-		if m.currentShip == nil {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("no ship equipped"),
-			}
-		}
-
-		// Check if player is on a planet
-		if m.player.CurrentPlanet == nil {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("not landed on a planet"),
-			}
-		}
-
-		// Get ship type for cargo capacity
-		shipType := models.GetShipTypeByID(m.currentShip.TypeID)
-		if shipType == nil {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("ship type not found"),
-			}
-		}
-
-		// Calculate available cargo space
-		cargoUsed := m.currentShip.GetCargoUsed()
-		cargoAvailable := shipType.CargoSpace - cargoUsed
-
-		if cargoAvailable <= 0 {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("no cargo space available"),
-			}
-		}
-
-		// Calculate maximum affordable based on credits
-		maxAffordable := int(m.player.Credits / int64(pricePerTon))
-
-		// Take minimum of cargo space and affordable quantity
-		maxQuantity := cargoAvailable
-		if maxAffordable < maxQuantity {
-			maxQuantity = maxAffordable
-		}
-
-		if maxQuantity <= 0 {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("insufficient credits or cargo space"),
-			}
-		}
-
-		// Get commodity ID
-		commodityID := getCommodityID(commodityName)
-
-		// Get market price for validation
-		marketPrice, err := m.marketRepo.GetMarketPrice(ctx, *m.player.CurrentPlanet, commodityID)
-		if err != nil {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("commodity not available: %w", err),
-			}
-		}
-
-		// Calculate total cost
-		totalCost := marketPrice.BuyPrice * int64(maxQuantity)
-
-		// Double-check credits
-		if m.player.Credits < totalCost {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("insufficient credits"),
-			}
-		}
-
-		// Add cargo to ship
-		err = m.shipRepo.AddCargo(ctx, m.currentShip.ID, commodityID, maxQuantity)
-		if err != nil {
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("failed to add cargo: %w", err),
-			}
-		}
-
-		// Deduct credits
-		m.player.Credits -= totalCost
-		err = m.playerRepo.UpdateCredits(ctx, m.playerID, m.player.Credits)
-		if err != nil {
-			// Rollback cargo addition
-			_ = m.shipRepo.RemoveCargo(ctx, m.currentShip.ID, commodityID, maxQuantity)
-			return transactionCompleteMsg{
-				action: "buy",
-				err:    fmt.Errorf("failed to deduct credits: %w", err),
-			}
-		}
-
-		// Update market stock (decrease)
-		_ = m.marketRepo.UpdateStock(ctx, *m.player.CurrentPlanet, commodityID, -maxQuantity)
-
-		return transactionCompleteMsg{
-			action:      "buy",
-			commodityID: commodityName,
-			quantity:    maxQuantity,
-			newBalance:  m.player.Credits,
-			err:         nil,
-		}
-	}
+// maxBuyCommodityCmd purchases the maximum affordable quantity that fits in
+// the hold and is in stock
+func (m Model) maxBuyCommodityCmd(commodityName string) tea.Cmd {
+	return m.enhancedTradeCmd("buy", commodityName, func(ctx context.Context, commodityID string) (*trading.TradeResult, error) {
+		return m.tradingService.BuyMax(ctx, m.playerID, commodityID)
+	})
 }
 
 // sellAllCommodityCmd sells all of a commodity in cargo
 func (m Model) sellAllCommodityCmd(commodityName string) tea.Cmd {
-	return func() tea.Msg {
-		ctx := context.Background()
-
-		// Check if player has a ship
-		if m.currentShip == nil {
-			return transactionCompleteMsg{
-				action: "sell",
-				err:    fmt.Errorf("no ship equipped"),
-			}
-		}
-
-		// Check if player is on a planet
-		if m.player.CurrentPlanet == nil {
-			return transactionCompleteMsg{
-				action: "sell",
-				err:    fmt.Errorf("not landed on a planet"),
-			}
-		}
-
-		// Get commodity ID
-		commodityID := getCommodityID(commodityName)
-
-		// Find quantity in cargo
-		quantityInCargo := 0
-		for _, item := range m.currentShip.Cargo {
-			if item.CommodityID == commodityID {
-				quantityInCargo = item.Quantity
-				break
-			}
-		}
-
-		if quantityInCargo <= 0 {
-			return transactionCompleteMsg{
-				action: "sell",
-				err:    fmt.Errorf("no %s in cargo", commodityName),
-			}
-		}
-
-		// Get market price for this commodity
-		marketPrice, err := m.marketRepo.GetMarketPrice(ctx, *m.player.CurrentPlanet, commodityID)
-		if err != nil {
-			return transactionCompleteMsg{
-				action: "sell",
-				err:    fmt.Errorf("commodity not available: %w", err),
-			}
-		}
-
-		// Calculate total earnings
-		totalEarnings := marketPrice.SellPrice * int64(quantityInCargo)
-
-		// Remove cargo from ship
-		err = m.shipRepo.RemoveCargo(ctx, m.currentShip.ID, commodityID, quantityInCargo)
-		if err != nil {
-			return transactionCompleteMsg{
-				action: "sell",
-				err:    fmt.Errorf("failed to remove cargo: %w", err),
-			}
-		}
-
-		// Add credits
-		m.player.Credits += totalEarnings
-		err = m.playerRepo.UpdateCredits(ctx, m.playerID, m.player.Credits)
-		if err != nil {
-			// Rollback cargo removal
-			_ = m.shipRepo.AddCargo(ctx, m.currentShip.ID, commodityID, quantityInCargo)
-			return transactionCompleteMsg{
-				action: "sell",
-				err:    fmt.Errorf("failed to add credits: %w", err),
-			}
-		}
-
-		// Update market stock (increase)
-		_ = m.marketRepo.UpdateStock(ctx, *m.player.CurrentPlanet, commodityID, quantityInCargo)
-
-		return transactionCompleteMsg{
-			action:      "sell",
-			commodityID: commodityName,
-			quantity:    quantityInCargo,
-			newBalance:  m.player.Credits,
-			err:         nil,
-		}
-	}
+	return m.enhancedTradeCmd("sell", commodityName, func(ctx context.Context, commodityID string) (*trading.TradeResult, error) {
+		return m.tradingService.SellAll(ctx, m.playerID, commodityID)
+	})
 }
 
 // Add ScreenTradingEnhanced constant to Screen enum when integrating
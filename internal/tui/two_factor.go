@@ -0,0 +1,627 @@
+// File: internal/tui/two_factor.go
+// Project: Terminal Velocity
+// Description: Two-factor authentication screen - Login challenge, enrollment and backup codes
+// Version: 1.0.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// The two-factor screen has four modes:
+// - Challenge: After password login, asks for a TOTP or backup code before
+//   the player is loaded
+// - Status: Opened from Settings > Security; enable, disable, or rotate
+//   backup codes, or view recent logins
+// - Enroll: Shows the setup QR code and secret, then asks for a code to
+//   confirm the authenticator app works
+// - Backup codes: Shows newly generated backup codes exactly once
+//
+// Disabling 2FA and rotating backup codes both ask for a current code first.
+// When server policy requires 2FA (admins with RequireAdmin2FA set),
+// enrollment is forced after login and can't be dismissed or disabled.
+
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/database"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/security"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
+)
+
+// Two-factor screen modes
+const (
+	twoFactorModeChallenge   = "challenge"
+	twoFactorModeStatus      = "status"
+	twoFactorModeEnroll      = "enroll"
+	twoFactorModeConfirm     = "confirm"
+	twoFactorModeBackupCodes = "backup_codes"
+)
+
+// Actions that need a current code (twoFactorModeConfirm)
+const (
+	twoFactorActionDisable = "disable"
+	twoFactorActionRotate  = "rotate"
+)
+
+// maxTwoFactorAttempts is how many wrong codes the login challenge accepts
+// before returning to the login screen
+const maxTwoFactorAttempts = 5
+
+// qrStyle draws the setup QR code light-on-dark regardless of the
+// terminal's own colors, so it scans reliably
+var qrStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#FFFFFF")).
+	Background(lipgloss.Color("#000000"))
+
+// twoFactorModel contains the state for the two-factor screen.
+type twoFactorModel struct {
+	mode     string // Current mode (see twoFactorMode* constants)
+	action   string // Pending action in confirm mode
+	cursor   int    // Status menu cursor
+	input    string // Code being typed
+	error    string // Error message to display
+	message  string // Success message to display
+	busy     bool   // True while a database request is in flight
+	required bool   // Server policy requires 2FA; enrollment can't be skipped
+	attempts int    // Failed challenge attempts
+
+	// Login challenge
+	pendingID   uuid.UUID // Player waiting on the challenge
+	pendingName string    // Their username
+
+	// Status
+	loaded      bool // Status has been loaded
+	enabled     bool // 2FA is enabled for the player
+	codesLeft   int  // Unused backup codes
+	statusError string
+
+	// Enrollment
+	config      *security.TwoFactorConfig // Unconfirmed config
+	qrCode      string                    // Rendered QR code
+	backupCodes []string                  // Plain backup codes, shown once
+}
+
+// newTwoFactorModel creates an empty two-factor screen model.
+func newTwoFactorModel() twoFactorModel {
+	return twoFactorModel{mode: twoFactorModeStatus}
+}
+
+// loginTwoFactorMsg is sent when the password was correct but the player
+// still has to pass the 2FA challenge.
+type loginTwoFactorMsg struct {
+	playerID uuid.UUID
+	username string
+}
+
+// twoFactorStatusMsg carries the player's 2FA status for the status view.
+type twoFactorStatusMsg struct {
+	enabled   bool
+	codesLeft int
+	err       error
+}
+
+// twoFactorPolicyMsg is sent after the player loads when server policy
+// requires 2FA and the player hasn't enrolled.
+type twoFactorPolicyMsg struct{}
+
+// twoFactorVerifiedMsg is the result of checking a code against the
+// stored config.
+type twoFactorVerifiedMsg struct {
+	ok        bool
+	codesLeft int
+	sessionID uuid.UUID // Security session opened by the login
+	err       error
+}
+
+// twoFactorSavedMsg is sent after enrollment, rotation or removal is stored.
+type twoFactorSavedMsg struct {
+	backupCodes []string // New plain codes to show, if any
+	message     string
+	err         error
+}
+
+// openTwoFactorSettings switches to the status view and loads the player's
+// 2FA status.
+func (m Model) openTwoFactorSettings() (tea.Model, tea.Cmd) {
+	m.screen = ScreenTwoFactor
+	m.twoFactorModel = newTwoFactorModel()
+	return m, m.loadTwoFactorStatus()
+}
+
+// startTwoFactorChallenge switches to the login challenge for a player
+// whose password was accepted.
+func (m Model) startTwoFactorChallenge(playerID uuid.UUID, username string) Model {
+	m.screen = ScreenTwoFactor
+	m.twoFactorModel = twoFactorModel{
+		mode:        twoFactorModeChallenge,
+		pendingID:   playerID,
+		pendingName: username,
+	}
+	return m
+}
+
+// startTwoFactorEnrollment generates a new secret and backup codes and
+// shows the setup QR code.
+func (m Model) startTwoFactorEnrollment(required bool) Model {
+	m.screen = ScreenTwoFactor
+	m.twoFactorModel.mode = twoFactorModeEnroll
+	m.twoFactorModel.required = required
+	m.twoFactorModel.input = ""
+	m.twoFactorModel.error = ""
+	m.twoFactorModel.message = ""
+
+	config, codes, err := m.twoFactor.NewConfig(m.playerID, m.username)
+	if err != nil {
+		m.twoFactorModel.error = fmt.Sprintf("Failed to start enrollment: %v", err)
+		return m
+	}
+	qrCode, err := m.twoFactor.RenderQRCode(m.username, config.Secret)
+	if err != nil {
+		m.twoFactorModel.error = fmt.Sprintf("Failed to render QR code: %v", err)
+	}
+
+	m.twoFactorModel.config = config
+	m.twoFactorModel.backupCodes = codes
+	m.twoFactorModel.qrCode = qrCode
+	return m
+}
+
+// checkTwoFactorPolicy reports whether the loaded player must enroll
+// before playing.
+func (m Model) checkTwoFactorPolicy() tea.Cmd {
+	if m.adminManager == nil || m.securityRepo == nil || !m.adminManager.RequiresTwoFactor(m.playerID) {
+		return nil
+	}
+
+	playerID := m.playerID
+	return func() tea.Msg {
+		enabled, err := m.securityRepo.IsTwoFactorEnabled(context.Background(), playerID)
+		if err != nil || enabled {
+			return nil
+		}
+		return twoFactorPolicyMsg{}
+	}
+}
+
+// loadTwoFactorStatus fetches the player's 2FA status.
+func (m Model) loadTwoFactorStatus() tea.Cmd {
+	playerID := m.playerID
+	return func() tea.Msg {
+		if m.securityRepo == nil {
+			return twoFactorStatusMsg{err: errors.New("two-factor authentication is unavailable")}
+		}
+		config, err := m.securityRepo.GetTwoFactor(context.Background(), playerID)
+		if errors.Is(err, database.ErrTwoFactorNotFound) {
+			return twoFactorStatusMsg{}
+		}
+		if err != nil {
+			return twoFactorStatusMsg{err: err}
+		}
+		return twoFactorStatusMsg{enabled: config.Enabled, codesLeft: len(config.BackupCodes)}
+	}
+}
+
+// verifyTwoFactorCode checks a login challenge code against the player's
+// stored config and records its use (a used backup code is removed) and
+// the login attempt.
+func (m Model) verifyTwoFactorCode(playerID uuid.UUID, username, code string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		config, err := m.securityRepo.GetTwoFactor(ctx, playerID)
+		if err != nil {
+			return twoFactorVerifiedMsg{err: err}
+		}
+
+		ok, _ := m.twoFactor.Authenticate(config, code)
+		if !ok {
+			m.recordLoginFailure(playerID, username, "invalid_2fa_code")
+			return twoFactorVerifiedMsg{}
+		}
+		if err := m.securityRepo.RecordTwoFactorUse(ctx, config); err != nil {
+			return twoFactorVerifiedMsg{err: err}
+		}
+		sessionID, err := m.recordLoginSuccess(playerID, username)
+		if err != nil {
+			return twoFactorVerifiedMsg{err: err}
+		}
+		return twoFactorVerifiedMsg{ok: true, codesLeft: len(config.BackupCodes), sessionID: sessionID}
+	}
+}
+
+// confirmTwoFactorEnrollment checks the first code from the authenticator
+// app and stores the now-enabled config.
+func (m Model) confirmTwoFactorEnrollment(code string) tea.Cmd {
+	config := m.twoFactorModel.config
+	backupCodes := m.twoFactorModel.backupCodes
+	return func() tea.Msg {
+		if !m.twoFactor.VerifyCode(config.Secret, strings.TrimSpace(code)) {
+			return twoFactorSavedMsg{err: errors.New("Invalid code - check your authenticator app and try again")}
+		}
+
+		enabled := *config
+		enabled.Enabled = true
+		if err := m.securityRepo.SaveTwoFactor(context.Background(), &enabled); err != nil {
+			return twoFactorSavedMsg{err: err}
+		}
+		return twoFactorSavedMsg{backupCodes: backupCodes, message: "Two-factor authentication enabled"}
+	}
+}
+
+// applyTwoFactorAction performs a confirmed disable or rotate action.
+func (m Model) applyTwoFactorAction(action, code string) tea.Cmd {
+	playerID := m.playerID
+	return func() tea.Msg {
+		ctx := context.Background()
+		config, err := m.securityRepo.GetTwoFactor(ctx, playerID)
+		if err != nil {
+			return twoFactorSavedMsg{err: err}
+		}
+		if ok, _ := m.twoFactor.Authenticate(config, code); !ok {
+			return twoFactorSavedMsg{err: errors.New("Invalid code")}
+		}
+
+		switch action {
+		case twoFactorActionDisable:
+			if err := m.securityRepo.DeleteTwoFactor(ctx, playerID); err != nil {
+				return twoFactorSavedMsg{err: err}
+			}
+			return twoFactorSavedMsg{message: "Two-factor authentication disabled"}
+
+		case twoFactorActionRotate:
+			codes, err := m.twoFactor.RotateBackupCodes(config)
+			if err != nil {
+				return twoFactorSavedMsg{err: err}
+			}
+			if err := m.securityRepo.SaveTwoFactor(ctx, config); err != nil {
+				return twoFactorSavedMsg{err: err}
+			}
+			return twoFactorSavedMsg{backupCodes: codes, message: "New backup codes generated"}
+		}
+
+		return twoFactorSavedMsg{err: fmt.Errorf("unknown action %q", action)}
+	}
+}
+
+// updateTwoFactor handles input and async results for the two-factor screen.
+//
+// Key Bindings (code entry modes):
+//   - 0-9, A-Z, -: Type a code
+//   - backspace: Delete last character
+//   - enter: Submit code
+//   - esc: Cancel (back to login, or to the status view)
+//
+// Key Bindings (status mode):
+//   - up/down: Select action
+//   - enter: Perform action
+//   - esc: Back to settings
+//
+// Key Bindings (backup codes mode):
+//   - enter/esc: Done
+func (m Model) updateTwoFactor(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case twoFactorStatusMsg:
+		m.twoFactorModel.loaded = true
+		m.twoFactorModel.enabled = msg.enabled
+		m.twoFactorModel.codesLeft = msg.codesLeft
+		m.twoFactorModel.statusError = ""
+		if msg.err != nil {
+			m.twoFactorModel.statusError = fmt.Sprintf("Failed to load status: %v", msg.err)
+		}
+		return m, nil
+
+	case twoFactorVerifiedMsg:
+		m.twoFactorModel.busy = false
+		if msg.err != nil {
+			m.twoFactorModel.error = fmt.Sprintf("Verification error: %v", msg.err)
+			return m, nil
+		}
+		if !msg.ok {
+			m.twoFactorModel.attempts++
+			if m.twoFactorModel.attempts >= maxTwoFactorAttempts {
+				m.screen = ScreenLogin
+				m.loginModel = newLoginModel()
+				m.loginModel.error = "Too many invalid codes"
+				return m, nil
+			}
+			m.twoFactorModel.error = "Invalid code"
+			return m, nil
+		}
+
+		// Challenge passed - finish the login
+		m.playerID = m.twoFactorModel.pendingID
+		m.username = m.twoFactorModel.pendingName
+		m.securitySession = msg.sessionID
+		m.screen = ScreenLogin
+		m.loginModel = newLoginModel()
+		return m, m.loadPlayer()
+
+	case twoFactorSavedMsg:
+		m.twoFactorModel.busy = false
+		if msg.err != nil {
+			m.twoFactorModel.error = msg.err.Error()
+			return m, nil
+		}
+		m.twoFactorModel.message = msg.message
+		m.twoFactorModel.error = ""
+		m.twoFactorModel.config = nil
+		if len(msg.backupCodes) > 0 {
+			m.twoFactorModel.mode = twoFactorModeBackupCodes
+			m.twoFactorModel.backupCodes = msg.backupCodes
+			return m, nil
+		}
+		m.twoFactorModel.mode = twoFactorModeStatus
+		m.twoFactorModel.cursor = 0
+		return m, m.loadTwoFactorStatus()
+
+	case tea.KeyMsg:
+		if m.twoFactorModel.busy {
+			return m, nil
+		}
+		switch m.twoFactorModel.mode {
+		case twoFactorModeStatus:
+			return m.updateTwoFactorStatus(msg)
+		case twoFactorModeBackupCodes:
+			if msg.String() == "enter" || msg.String() == "esc" {
+				m.twoFactorModel.backupCodes = nil
+				if m.twoFactorModel.required {
+					m.twoFactorModel = newTwoFactorModel()
+					m.screen = ScreenMainMenu
+					return m, nil
+				}
+				m.twoFactorModel.mode = twoFactorModeStatus
+				m.twoFactorModel.cursor = 0
+				return m, m.loadTwoFactorStatus()
+			}
+			return m, nil
+		default:
+			return m.updateTwoFactorInput(msg)
+		}
+	}
+
+	return m, nil
+}
+
+// updateTwoFactorStatus handles the status menu.
+func (m Model) updateTwoFactorStatus(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	options := m.twoFactorStatusOptions()
+
+	switch msg.String() {
+	case "esc", "backspace":
+		m.screen = ScreenSettings
+		return m, nil
+
+	case "up", "k":
+		if m.twoFactorModel.cursor > 0 {
+			m.twoFactorModel.cursor--
+		}
+
+	case "down", "j":
+		if m.twoFactorModel.cursor < len(options)-1 {
+			m.twoFactorModel.cursor++
+		}
+
+	case "enter", " ":
+		if !m.twoFactorModel.loaded || m.twoFactorModel.cursor >= len(options) {
+			return m, nil
+		}
+		m.twoFactorModel.message = ""
+		m.twoFactorModel.error = ""
+		switch options[m.twoFactorModel.cursor] {
+		case "Enable two-factor authentication":
+			return m.startTwoFactorEnrollment(false), nil
+		case "Generate new backup codes":
+			m.twoFactorModel.mode = twoFactorModeConfirm
+			m.twoFactorModel.action = twoFactorActionRotate
+		case "Disable two-factor authentication":
+			m.twoFactorModel.mode = twoFactorModeConfirm
+			m.twoFactorModel.action = twoFactorActionDisable
+		case "View recent logins":
+			return m.openLoginHistory()
+		}
+	}
+
+	return m, nil
+}
+
+// twoFactorStatusOptions lists the actions available in the status view.
+// Players the server requires to use 2FA can't disable it.
+func (m Model) twoFactorStatusOptions() []string {
+	if !m.twoFactorModel.enabled {
+		return []string{"Enable two-factor authentication", "View recent logins"}
+	}
+	options := []string{"Generate new backup codes"}
+	if m.adminManager == nil || !m.adminManager.RequiresTwoFactor(m.playerID) {
+		options = append(options, "Disable two-factor authentication")
+	}
+	return append(options, "View recent logins")
+}
+
+// updateTwoFactorInput handles code entry for the challenge, enroll and
+// confirm modes.
+func (m Model) updateTwoFactorInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		switch m.twoFactorModel.mode {
+		case twoFactorModeChallenge:
+			m.screen = ScreenLogin
+			m.loginModel = newLoginModel()
+			m.twoFactorModel = newTwoFactorModel()
+		case twoFactorModeEnroll:
+			if m.twoFactorModel.required {
+				return m, nil
+			}
+			m.twoFactorModel.config = nil
+			m.twoFactorModel.backupCodes = nil
+			m.twoFactorModel.mode = twoFactorModeStatus
+		default:
+			m.twoFactorModel.mode = twoFactorModeStatus
+		}
+		m.twoFactorModel.input = ""
+		m.twoFactorModel.error = ""
+		return m, nil
+
+	case "backspace":
+		if len(m.twoFactorModel.input) > 0 {
+			m.twoFactorModel.input = m.twoFactorModel.input[:len(m.twoFactorModel.input)-1]
+		}
+		return m, nil
+
+	case "enter":
+		code := m.twoFactorModel.input
+		if code == "" {
+			return m, nil
+		}
+		m.twoFactorModel.input = ""
+		m.twoFactorModel.error = ""
+		m.twoFactorModel.busy = true
+
+		switch m.twoFactorModel.mode {
+		case twoFactorModeChallenge:
+			return m, m.verifyTwoFactorCode(m.twoFactorModel.pendingID, m.twoFactorModel.pendingName, code)
+		case twoFactorModeEnroll:
+			if m.twoFactorModel.config == nil {
+				m.twoFactorModel.busy = false
+				return m, nil
+			}
+			return m, m.confirmTwoFactorEnrollment(code)
+		case twoFactorModeConfirm:
+			return m, m.applyTwoFactorAction(m.twoFactorModel.action, code)
+		}
+		m.twoFactorModel.busy = false
+		return m, nil
+
+	default:
+		key := strings.ToUpper(msg.String())
+		if len(key) == 1 && len(m.twoFactorModel.input) < 16 && strings.ContainsAny(key, "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ-") {
+			m.twoFactorModel.input += key
+			m.twoFactorModel.error = ""
+		}
+		return m, nil
+	}
+}
+
+// viewTwoFactor renders the two-factor screen.
+func (m Model) viewTwoFactor() string {
+	var s string
+	if m.twoFactorModel.mode == twoFactorModeChallenge {
+		s = titleStyle.Render("TERMINAL VELOCITY") + "\n"
+	} else {
+		s = renderHeader(m.username, m.player.Credits, "Security")
+		s += "\n"
+	}
+	s += subtitleStyle.Render("=== Two-Factor Authentication ===") + "\n\n"
+
+	switch m.twoFactorModel.mode {
+	case twoFactorModeChallenge:
+		s += fmt.Sprintf("Signing in as %s.\n\n", highlightStyle.Render(m.twoFactorModel.pendingName))
+		s += "Enter the 6-digit code from your authenticator app,\n"
+		s += "or one of your backup codes.\n\n"
+		s += m.viewTwoFactorInput("Code")
+		s += m.viewTwoFactorMessages()
+		s += "\n" + renderFooter("Enter: Verify  •  ESC: Back to login")
+
+	case twoFactorModeStatus:
+		s += m.viewTwoFactorStatus()
+
+	case twoFactorModeEnroll:
+		if m.twoFactorModel.required {
+			s += errorStyle.Render("This server requires two-factor authentication for admin accounts.") + "\n"
+		}
+		s += "1. Scan this code with your authenticator app:\n\n"
+		for _, line := range strings.Split(strings.TrimRight(m.twoFactorModel.qrCode, "\n"), "\n") {
+			s += "  " + qrStyle.Render(line) + "\n"
+		}
+		if m.twoFactorModel.config != nil {
+			s += "\n   Or enter this key manually: " + statsStyle.Render(m.twoFactorModel.config.Secret) + "\n"
+		}
+		s += "\n2. Enter the 6-digit code it shows to finish:\n\n"
+		s += m.viewTwoFactorInput("Code")
+		s += m.viewTwoFactorMessages()
+		if m.twoFactorModel.required {
+			s += "\n" + renderFooter("Enter: Confirm  •  Ctrl+C: Quit")
+		} else {
+			s += "\n" + renderFooter("Enter: Confirm  •  ESC: Cancel")
+		}
+
+	case twoFactorModeConfirm:
+		if m.twoFactorModel.action == twoFactorActionDisable {
+			s += "Enter a current code to disable two-factor authentication.\n\n"
+		} else {
+			s += "Enter a current code to replace your backup codes.\n"
+			s += helpStyle.Render("Your old backup codes will stop working.") + "\n\n"
+		}
+		s += m.viewTwoFactorInput("Code")
+		s += m.viewTwoFactorMessages()
+		s += "\n" + renderFooter("Enter: Confirm  •  ESC: Cancel")
+
+	case twoFactorModeBackupCodes:
+		if m.twoFactorModel.message != "" {
+			s += successStyle.Render("✓ "+m.twoFactorModel.message) + "\n\n"
+		}
+		s += "Your backup codes (each works once):\n\n"
+		for i, code := range m.twoFactorModel.backupCodes {
+			s += fmt.Sprintf("  %2d. %s\n", i+1, statsStyle.Render(security.FormatBackupCode(code)))
+		}
+		s += "\n" + errorStyle.Render("Write these down now - they won't be shown again.") + "\n"
+		s += "\n" + renderFooter("Enter: Done")
+	}
+
+	return s
+}
+
+// viewTwoFactorStatus renders the status view opened from settings.
+func (m Model) viewTwoFactorStatus() string {
+	if m.twoFactorModel.statusError != "" {
+		return errorStyle.Render(m.twoFactorModel.statusError) + "\n\n" + renderFooter("ESC: Back")
+	}
+	if !m.twoFactorModel.loaded {
+		return helpStyle.Render("Loading...") + "\n"
+	}
+
+	var s string
+	if m.twoFactorModel.enabled {
+		s += "Status:       " + successStyle.Render("ENABLED") + "\n"
+		s += fmt.Sprintf("Backup codes: %s remaining\n\n", statsStyle.Render(fmt.Sprintf("%d", m.twoFactorModel.codesLeft)))
+	} else {
+		s += "Status:       " + helpStyle.Render("DISABLED") + "\n\n"
+		s += "Protect your account with a code from an authenticator app\n"
+		s += "(Google Authenticator, Authy, 1Password, ...) at every login.\n\n"
+	}
+
+	for i, option := range m.twoFactorStatusOptions() {
+		if i == m.twoFactorModel.cursor {
+			s += "> " + selectedMenuItemStyle.Render(option) + "\n"
+		} else {
+			s += "  " + option + "\n"
+		}
+	}
+
+	s += m.viewTwoFactorMessages()
+	s += "\n" + renderFooter("↑/↓: Select  •  Enter: Confirm  •  ESC: Back")
+	return s
+}
+
+// viewTwoFactorInput renders the code input field.
+func (m Model) viewTwoFactorInput(label string) string {
+	field := "[" + PadRight(m.twoFactorModel.input+"_", 16) + "]"
+	if m.twoFactorModel.busy {
+		field = "[ Verifying... ]"
+	}
+	return fmt.Sprintf("  %s: %s\n", label, HighlightStyle.Render(field))
+}
+
+// viewTwoFactorMessages renders the current error or success message.
+func (m Model) viewTwoFactorMessages() string {
+	if m.twoFactorModel.error != "" {
+		return errorStyle.Render(m.twoFactorModel.error) + "\n"
+	}
+	if m.twoFactorModel.message != "" {
+		return successStyle.Render("✓ "+m.twoFactorModel.message) + "\n"
+	}
+	return ""
+}
@@ -0,0 +1,157 @@
+// File: internal/tui/world.go
+// Project: Terminal Velocity
+// Description: Session integration with the server-wide world-state hub
+// Version: 1.5.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+//
+// Each SSH session shares one world.Hub with every other session. The hub owns
+// the chat, presence, faction, territory, diplomacy, trade, PvP and news
+// managers, so a global chat message or a new trade offer is visible to every
+// player.
+//
+// Update Flow:
+//   1. Player data loads and subscribeWorld() opens a hub subscription
+//   2. waitForWorldEvent() blocks in a tea.Cmd until the hub publishes an event
+//   3. Update() receives worldEventMsg, refreshes affected state, and waits again
+//   4. Close() releases the subscription and presence when the session ends
+//
+// A PvP battle update takes the player to the battle view, since both
+// players of a live battle must be able to act before the turn timer runs out.
+
+package tui
+
+import (
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/pvp"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/world"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+// worldEventMsg is sent when the world hub publishes an event for this player
+type worldEventMsg struct {
+	event world.Event
+}
+
+// attachWorld wires the shared managers from the hub into the model.
+//
+// A nil hub gets a private, unstarted hub so that models built without a
+// server (tests, tools) still have working managers.
+func (m *Model) attachWorld(hub *world.Hub) {
+	if hub == nil {
+		hub = world.NewHub()
+	}
+
+	m.worldHub = hub
+	m.chatManager = hub.Chat
+	m.presenceManager = hub.Presence
+	m.factionManager = hub.Factions
+	m.territoryManager = hub.Territory
+	m.diplomacyManager = hub.Diplomacy
+	m.tradeManager = hub.Trade
+	m.pvpManager = hub.PvP
+	m.newsManager = hub.News
+}
+
+// territoryFactionID returns the ID of the player's faction for territory
+// activity, or uuid.Nil if the player is not in a faction
+func (m *Model) territoryFactionID() uuid.UUID {
+	if m.factionManager == nil {
+		return uuid.Nil
+	}
+	faction, err := m.factionManager.GetPlayerFaction(m.playerID)
+	if err != nil {
+		return uuid.Nil
+	}
+	return faction.ID
+}
+
+// factionChatRecipients returns the players who receive the faction's chat,
+// including members of allied factions when a world hub is attached
+func (m *Model) factionChatRecipients(faction *models.PlayerFaction) []uuid.UUID {
+	if m.worldHub == nil {
+		return faction.Members
+	}
+	return m.worldHub.FactionChatRecipients(faction)
+}
+
+// subscribeWorld opens this session's hub subscription and starts listening.
+// Safe to call again after a player reload; the existing subscription is reused.
+func (m *Model) subscribeWorld() tea.Cmd {
+	if m.worldHub == nil {
+		return nil
+	}
+
+	if m.worldSub != nil {
+		if m.worldSub.PlayerID == m.playerID {
+			return nil
+		}
+		m.worldSub.Close()
+	}
+
+	m.worldSub = m.worldHub.Subscribe(m.playerID)
+	return waitForWorldEvent(m.worldSub)
+}
+
+// waitForWorldEvent returns a command that blocks until the next world event.
+// It returns nil once the subscription is closed, ending the listen loop.
+func waitForWorldEvent(sub *world.Subscription) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-sub.Events()
+		if !ok {
+			return nil
+		}
+		return worldEventMsg{event: event}
+	}
+}
+
+// handleWorldEvent refreshes any state derived from shared managers.
+//
+// Most screens read the managers directly in their view functions, so simply
+// receiving the message triggers a re-render with fresh data. Screens that
+// cache manager results in their sub-model are refreshed here.
+func (m Model) handleWorldEvent(msg worldEventMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg.event.Type {
+	case world.EventChatMessage:
+		if m.screen == ScreenChat {
+			m.chatModel.availableDMChats = m.chatManager.GetActiveDirectChats(m.playerID)
+		}
+	case world.EventPvPBattle:
+		view, ok := msg.event.Payload.(*pvp.BattleView)
+		inBattleView := m.screen == ScreenPvP && m.pvpModel.viewMode == pvpViewBattle
+		if ok && !view.Over && !inBattleView && m.player != nil {
+			m, cmd = m.openPvPBattle()
+		}
+	}
+
+	if m.worldSub == nil {
+		return m, cmd
+	}
+	return m, tea.Batch(cmd, waitForWorldEvent(m.worldSub))
+}
+
+// Close releases the session's shared-world resources.
+//
+// The server calls this after the BubbleTea program exits so that the player
+// stops receiving events and is shown as offline to everyone else. A
+// security session opened by the login screen is closed too, the game
+// session's final state is saved for resuming and mining stops.
+func (m Model) Close() {
+	m.closeGameSession()
+	if m.worldSub != nil {
+		m.worldSub.Close()
+	}
+	m.closePlayerUpdates()
+	// A session taken over by a newer login leaves presence to that login
+	if m.presenceManager != nil && m.player != nil && !m.sessionReplaced() {
+		m.presenceManager.Disconnect(m.playerID)
+	}
+	if !m.sessionReplaced() {
+		m.cancelMining("Disconnected")
+	}
+	if m.securityManager != nil && m.securitySession != uuid.Nil {
+		m.securityManager.OnLogout(m.securitySession)
+	}
+}
@@ -0,0 +1,440 @@
+// File: internal/world/hub.go
+// Project: Terminal Velocity
+// Description: Server-wide world-state hub owning shared multiplayer managers
+// Version: 1.3.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+// Package world provides the server-wide world-state hub.
+//
+// The hub owns every manager whose state must be shared between SSH sessions:
+// chat, presence, player factions, faction diplomacy, player-to-player trade,
+// PvP, territory and news. Exactly one Hub exists per server process. It is created and started
+// by the server, then injected into every session's TUI model.
+//
+// Update Delivery:
+// Sessions never poll each other's models. Instead, each session subscribes to
+// the hub and receives lightweight Event values whenever shared state changes
+// (a chat message arrives, a player comes online, a trade offer is updated,
+// etc.). The session then re-reads the relevant manager to render fresh data.
+//
+// Backpressure:
+// Every subscription has a bounded buffer. If a session falls behind, newer
+// events are dropped for that session only. Events are change signals rather
+// than state, so a dropped event is repaired by the next one.
+//
+// Diplomacy:
+// The hub connects diplomacy to the systems it affects. Faction war attacks
+// are allowed only between factions at war and outside safe zones, PvP
+// victories and territory conflicts add war score, allies help defend
+// contested systems, faction chat reaches allied factions, and market fees
+// in faction territory follow the trader's standing with the owner.
+//
+// PvP Battles:
+// Accepted challenges are fought in server-side battle rooms owned by the
+// PvP manager. Every battle change is published to both players, and a
+// player who goes offline forfeits their live battle.
+//
+// Lifecycle:
+//   - NewHub() creates the managers and wires their change callbacks
+//   - Start() seeds initial content and starts the maintenance worker, which
+//     also advances the territory simulation (upkeep, contests and sieges)
+//   - Stop() stops the workers and closes all subscriptions
+//
+// Thread Safety:
+// All Hub methods are safe for concurrent use. The managers themselves are
+// internally synchronized.
+package world
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/chat"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/diplomacy"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/factions"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/logger"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/news"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/presence"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/pvp"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/territory"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/trade"
+	"github.com/google/uuid"
+)
+
+var log = logger.WithComponent("World")
+
+var (
+	ErrNotAtWar = errors.New("your factions are not at war")
+	ErrSafeZone = errors.New("faction war combat is not allowed in a safe zone")
+)
+
+const (
+	// subscriptionBuffer is the number of pending events held per subscriber
+	subscriptionBuffer = 64
+
+	// maintenanceInterval is how often presence, offers and challenges are
+	// swept and the territory simulation is advanced
+	maintenanceInterval = 1 * time.Minute
+)
+
+// EventType identifies which part of the shared world changed
+type EventType string
+
+const (
+	EventChatMessage EventType = "chat_message" // Payload: *models.ChatMessage
+	EventPresence    EventType = "presence"     // Payload: PresenceChange
+	EventFaction     EventType = "faction"      // Payload: *models.PlayerFaction
+	EventTradeOffer  EventType = "trade_offer"  // Payload: *models.TradeOffer
+	EventPvP         EventType = "pvp"          // Payload: *models.PvPChallenge
+	EventPvPBattle   EventType = "pvp_battle"   // Payload: *pvp.BattleView
+	EventTerritory   EventType = "territory"    // Payload: *models.Territory
+	EventNews        EventType = "news"         // Payload: *models.NewsArticle
+	EventDiplomacy   EventType = "diplomacy"    // Payload: []uuid.UUID (factions involved)
+)
+
+// Event is a change notification delivered to subscribers
+type Event struct {
+	Type      EventType
+	PlayerIDs []uuid.UUID // Players the event concerns (nil = everyone)
+	Payload   interface{}
+	Timestamp time.Time
+}
+
+// Concerns reports whether the event is addressed to the given player
+func (e Event) Concerns(playerID uuid.UUID) bool {
+	if e.PlayerIDs == nil {
+		return true
+	}
+	for _, id := range e.PlayerIDs {
+		if id == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// PresenceChange is the payload of an EventPresence event
+type PresenceChange struct {
+	PlayerID uuid.UUID
+	Online   bool
+}
+
+// Hub owns the shared multiplayer managers and fans out their change events
+type Hub struct {
+	Chat      *chat.Manager
+	Presence  *presence.Manager
+	Factions  *factions.Manager
+	Trade     *trade.Manager
+	PvP       *pvp.Manager
+	Territory *territory.Manager
+	News      *news.Manager
+	Diplomacy *diplomacy.Manager
+
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	dropped     uint64
+	safeZones   map[uuid.UUID]bool // Systems where faction war combat is not allowed
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// NewHub creates a hub with fresh managers and wires their change callbacks
+func NewHub() *Hub {
+	return NewHubWithFactions(factions.NewManager())
+}
+
+// NewHubWithFactions creates a hub around an existing faction manager, such
+// as one backed by the database, and fresh instances of the other managers
+func NewHubWithFactions(factionManager *factions.Manager) *Hub {
+	h := &Hub{
+		Chat:        chat.NewManager(),
+		Presence:    presence.NewManager(),
+		Factions:    factionManager,
+		Trade:       trade.NewManager(),
+		PvP:         pvp.NewManager(),
+		Territory:   territory.NewManager(),
+		News:        news.NewManager(),
+		Diplomacy:   diplomacy.NewManager(nil, factionManager),
+		subscribers: make(map[*Subscription]struct{}),
+		safeZones:   make(map[uuid.UUID]bool),
+		stop:        make(chan struct{}),
+	}
+
+	h.Chat.SetMessageCallback(func(msg *models.ChatMessage, recipientIDs []uuid.UUID) {
+		h.publish(EventChatMessage, recipientIDs, msg)
+	})
+	h.Presence.SetPresenceChangedCallback(func(playerID uuid.UUID, online bool) {
+		h.publish(EventPresence, nil, PresenceChange{PlayerID: playerID, Online: online})
+		if !online {
+			if err := h.PvP.ForfeitBattle(playerID); err == nil {
+				log.Info("Player %s forfeited their PvP battle by disconnecting", playerID)
+			}
+		}
+	})
+	h.Factions.SetFactionChangedCallback(func(faction *models.PlayerFaction) {
+		h.publish(EventFaction, append([]uuid.UUID(nil), faction.Members...), faction)
+	})
+	h.Trade.SetOfferChangedCallback(func(offer *models.TradeOffer) {
+		h.publish(EventTradeOffer, []uuid.UUID{offer.InitiatorID, offer.RecipientID}, offer)
+	})
+	h.PvP.SetChallengeChangedCallback(func(challenge *models.PvPChallenge) {
+		h.publish(EventPvP, []uuid.UUID{challenge.ChallengerID, challenge.DefenderID}, challenge)
+	})
+	h.PvP.SetBattleChangedCallback(func(view *pvp.BattleView) {
+		h.publish(EventPvPBattle, view.PlayerIDs(), view)
+	})
+	h.PvP.SetWarPolicy(h.checkFactionWar)
+	h.PvP.SetCombatCompletedCallback(func(result *models.PvPCombatResult) {
+		h.Diplomacy.RecordPvPKill(h.playerFactionID(result.WinnerID), h.playerFactionID(result.LoserID))
+	})
+	h.Territory.SetFactions(factionManager)
+	h.Territory.SetAllyChecker(h.Diplomacy.Allied)
+	h.Territory.SetTerritoryChangedCallback(func(t *models.Territory) {
+		h.publish(EventTerritory, nil, t)
+	})
+	h.Territory.SetConflictResolvedCallback(func(t *models.Territory, winnerFactionID, loserFactionID uuid.UUID, captured bool) {
+		h.Diplomacy.RecordTerritoryConflict(winnerFactionID, loserFactionID, captured)
+	})
+	h.Diplomacy.SetDiplomacyChangedCallback(func(factionIDs []uuid.UUID) {
+		h.publish(EventDiplomacy, h.factionMembers(factionIDs), factionIDs)
+	})
+	h.News.SetArticleCallback(func(article *models.NewsArticle) {
+		h.publish(EventNews, nil, article)
+	})
+
+	return h
+}
+
+// Start seeds initial world content and begins the maintenance worker
+func (h *Hub) Start() {
+	h.News.GenerateInitialNews()
+	h.Diplomacy.Start()
+	go h.maintenanceWorker()
+	log.Info("World hub started")
+}
+
+// Stop stops the maintenance worker and closes every open subscription
+func (h *Hub) Stop() {
+	h.stopOnce.Do(func() {
+		close(h.stop)
+		h.Diplomacy.Stop()
+	})
+
+	h.mu.Lock()
+	for sub := range h.subscribers {
+		delete(h.subscribers, sub)
+		sub.closeEvents()
+	}
+	h.mu.Unlock()
+
+	log.Info("World hub stopped")
+}
+
+// SetSafeZones sets the systems where faction war combat is not allowed
+func (h *Hub) SetSafeZones(systemIDs []uuid.UUID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.safeZones = make(map[uuid.UUID]bool, len(systemIDs))
+	for _, systemID := range systemIDs {
+		h.safeZones[systemID] = true
+	}
+}
+
+// IsSafeZone reports whether faction war combat is forbidden in a system
+func (h *Hub) IsSafeZone(systemID uuid.UUID) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.safeZones[systemID]
+}
+
+// FactionChatRecipients returns the players who receive a faction's chat:
+// its own members and the members of allied factions
+func (h *Hub) FactionChatRecipients(faction *models.PlayerFaction) []uuid.UUID {
+	recipients := append([]uuid.UUID(nil), faction.Members...)
+	return append(recipients, h.factionMembers(h.Diplomacy.AlliedFactions(faction.ID))...)
+}
+
+// MarketFeeRate returns the market fee rate for a player trading in a
+// system, as a fraction of trade value. Only systems held by a faction
+// charge a fee; see diplomacy.Manager.MarketFeeRate.
+func (h *Hub) MarketFeeRate(playerID, systemID uuid.UUID) float64 {
+	held, err := h.Territory.GetTerritory(systemID)
+	if err != nil {
+		return 0
+	}
+	return h.Diplomacy.MarketFeeRate(h.playerFactionID(playerID), held.FactionID)
+}
+
+// checkFactionWar is the PvP war policy: a faction war attack needs both
+// players' factions at war and a system outside the safe zones
+func (h *Hub) checkFactionWar(attackerID, defenderID, systemID uuid.UUID) error {
+	if !h.Diplomacy.AtWar(h.playerFactionID(attackerID), h.playerFactionID(defenderID)) {
+		return ErrNotAtWar
+	}
+	if h.IsSafeZone(systemID) {
+		return ErrSafeZone
+	}
+	return nil
+}
+
+// playerFactionID returns the player's faction ID, or uuid.Nil
+func (h *Hub) playerFactionID(playerID uuid.UUID) uuid.UUID {
+	faction, err := h.Factions.GetPlayerFaction(playerID)
+	if err != nil || faction == nil {
+		return uuid.Nil
+	}
+	return faction.ID
+}
+
+// factionMembers returns the members of the given factions
+func (h *Hub) factionMembers(factionIDs []uuid.UUID) []uuid.UUID {
+	members := []uuid.UUID{}
+	for _, factionID := range factionIDs {
+		if faction, err := h.Factions.GetFaction(factionID); err == nil {
+			members = append(members, faction.Members...)
+		}
+	}
+	return members
+}
+
+// Subscribe registers a session for world events concerning the player.
+//
+// The player's chat history is created immediately so that messages sent
+// after subscribing are retained even before the chat screen is opened.
+// Callers must Close the subscription when the session ends.
+func (h *Hub) Subscribe(playerID uuid.UUID) *Subscription {
+	h.Chat.GetOrCreateHistory(playerID)
+
+	sub := &Subscription{
+		PlayerID: playerID,
+		hub:      h,
+		events:   make(chan Event, subscriptionBuffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	select {
+	case <-h.stop:
+		// Hub already stopped - hand back a closed subscription
+		sub.closeEvents()
+	default:
+		h.subscribers[sub] = struct{}{}
+	}
+
+	return sub
+}
+
+// SubscriberCount returns the number of open subscriptions
+func (h *Hub) SubscriberCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers)
+}
+
+// DroppedEvents returns how many events were discarded for slow subscribers
+func (h *Hub) DroppedEvents() uint64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.dropped
+}
+
+// publish fans an event out to every interested subscriber without blocking
+func (h *Hub) publish(eventType EventType, playerIDs []uuid.UUID, payload interface{}) {
+	event := Event{
+		Type:      eventType,
+		PlayerIDs: playerIDs,
+		Payload:   payload,
+		Timestamp: time.Now(),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		if !event.Concerns(sub.PlayerID) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			h.dropped++
+		}
+	}
+}
+
+// unsubscribe removes a subscription and closes its event channel
+func (h *Hub) unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, exists := h.subscribers[sub]; exists {
+		delete(h.subscribers, sub)
+		sub.closeEvents()
+	}
+}
+
+// maintenanceWorker periodically sweeps stale presence, offers and challenges
+func (h *Hub) maintenanceWorker() {
+	ticker := time.NewTicker(maintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			h.runMaintenance()
+		case <-h.stop:
+			return
+		}
+	}
+}
+
+// runMaintenance performs one maintenance sweep
+func (h *Hub) runMaintenance() {
+	h.Presence.UpdateIdleTimes()
+	h.Presence.CleanupStale()
+
+	expiredOffers := h.Trade.CleanupExpiredOffers()
+	expiredChallenges := h.PvP.CleanupExpiredChallenges()
+	expiredBounties := h.PvP.CleanupExpiredBounties()
+	h.News.Update()
+	h.Territory.Update(time.Now())
+
+	if expiredOffers+expiredChallenges+expiredBounties > 0 {
+		log.Debug("World maintenance: expired offers=%d, challenges=%d, bounties=%d",
+			expiredOffers, expiredChallenges, expiredBounties)
+	}
+}
+
+// Subscription is a single session's view of world events
+type Subscription struct {
+	PlayerID uuid.UUID
+
+	hub       *Hub
+	events    chan Event
+	closeOnce sync.Once
+}
+
+// Events returns the channel of pending events.
+// The channel is closed when the subscription or the hub is closed.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close unsubscribes from the hub. Safe to call more than once.
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// closeEvents closes the event channel exactly once.
+// Caller must hold the hub lock.
+func (s *Subscription) closeEvents() {
+	s.closeOnce.Do(func() {
+		close(s.events)
+	})
+}
@@ -0,0 +1,325 @@
+// File: internal/world/hub_test.go
+// Project: Terminal Velocity
+// Description: Tests for world hub event fan-out, subscription lifecycle, diplomacy and PvP battle wiring
+// Version: 1.2.0
+// Author: Joshua Ferguson
+// Created: 2025-11-16
+
+package world
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/models"
+	"github.com/JoshuaAFerguson/terminal-velocity/internal/pvp"
+	"github.com/google/uuid"
+)
+
+// receive waits briefly for the next event on a subscription
+func receive(t *testing.T, sub *Subscription) (Event, bool) {
+	t.Helper()
+	select {
+	case event, ok := <-sub.Events():
+		return event, ok
+	case <-time.After(time.Second):
+		return Event{}, false
+	}
+}
+
+// expectNoEvent asserts that nothing is pending on a subscription
+func expectNoEvent(t *testing.T, sub *Subscription) {
+	t.Helper()
+	select {
+	case event := <-sub.Events():
+		t.Fatalf("Expected no event, got %s", event.Type)
+	default:
+	}
+}
+
+// TestGlobalChatReachesAllSessions verifies that two sessions share one chat
+func TestGlobalChatReachesAllSessions(t *testing.T) {
+	hub := NewHub()
+	alice, bob := uuid.New(), uuid.New()
+
+	aliceSub := hub.Subscribe(alice)
+	bobSub := hub.Subscribe(bob)
+	defer aliceSub.Close()
+	defer bobSub.Close()
+
+	hub.Chat.SendGlobalMessage(alice, "alice", "hello")
+
+	for _, sub := range []*Subscription{aliceSub, bobSub} {
+		event, ok := receive(t, sub)
+		if !ok || event.Type != EventChatMessage {
+			t.Fatalf("Expected chat event for %s, got %+v", sub.PlayerID, event)
+		}
+	}
+
+	// Bob's history must contain the message without ever opening chat
+	if got := len(hub.Chat.GetRecentGlobal(10)); got != 1 {
+		t.Errorf("Expected 1 global message, got %d", got)
+	}
+}
+
+// TestDirectMessageOnlyReachesParticipants verifies per-player event filtering
+func TestDirectMessageOnlyReachesParticipants(t *testing.T) {
+	hub := NewHub()
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+
+	aliceSub := hub.Subscribe(alice)
+	bobSub := hub.Subscribe(bob)
+	carolSub := hub.Subscribe(carol)
+	defer aliceSub.Close()
+	defer bobSub.Close()
+	defer carolSub.Close()
+
+	hub.Chat.SendDirectMessage(alice, "alice", bob, "bob", "psst")
+
+	if _, ok := receive(t, aliceSub); !ok {
+		t.Error("Sender should receive direct message event")
+	}
+	if _, ok := receive(t, bobSub); !ok {
+		t.Error("Recipient should receive direct message event")
+	}
+	expectNoEvent(t, carolSub)
+}
+
+// TestTradeOfferNotifiesBothParties verifies trade events reach both players
+func TestTradeOfferNotifiesBothParties(t *testing.T) {
+	hub := NewHub()
+	alice, bob := uuid.New(), uuid.New()
+
+	bobSub := hub.Subscribe(bob)
+	defer bobSub.Close()
+
+	hub.Trade.CreateOffer(alice, "alice", bob, "bob", uuid.New(), uuid.New())
+
+	event, ok := receive(t, bobSub)
+	if !ok || event.Type != EventTradeOffer {
+		t.Fatalf("Expected trade offer event, got %+v", event)
+	}
+	if offers := hub.Trade.GetPendingOffers(bob); len(offers) != 1 {
+		t.Errorf("Expected 1 pending offer for recipient, got %d", len(offers))
+	}
+}
+
+// TestSlowSubscriberDropsEvents verifies publishing never blocks on a full buffer
+func TestSlowSubscriberDropsEvents(t *testing.T) {
+	hub := NewHub()
+	sub := hub.Subscribe(uuid.New())
+	defer sub.Close()
+
+	const sent = subscriptionBuffer + 10
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < sent; i++ {
+			hub.Chat.BroadcastSystemMessage("global", "tick")
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Publishing blocked on a slow subscriber")
+	}
+
+	if got := hub.DroppedEvents(); got != sent-subscriptionBuffer {
+		t.Errorf("Expected %d dropped events, got %d", sent-subscriptionBuffer, got)
+	}
+}
+
+// TestCloseAndStop verifies subscriptions are released on Close and on Stop
+func TestCloseAndStop(t *testing.T) {
+	hub := NewHub()
+	first := hub.Subscribe(uuid.New())
+	second := hub.Subscribe(uuid.New())
+
+	first.Close()
+	first.Close() // Idempotent
+	if _, ok := <-first.Events(); ok {
+		t.Error("Closed subscription channel should be closed")
+	}
+	if got := hub.SubscriberCount(); got != 1 {
+		t.Errorf("Expected 1 subscriber, got %d", got)
+	}
+
+	hub.Stop()
+	if _, ok := <-second.Events(); ok {
+		t.Error("Stop should close remaining subscriptions")
+	}
+
+	late := hub.Subscribe(uuid.New())
+	if _, ok := <-late.Events(); ok {
+		t.Error("Subscribing after Stop should return a closed subscription")
+	}
+	late.Close()
+}
+
+// TestMutedPlayerCannotChat verifies that the mute checker blocks sends
+func TestMutedPlayerCannotChat(t *testing.T) {
+	hub := NewHub()
+	alice, bob := uuid.New(), uuid.New()
+	hub.Chat.SetMuteChecker(func(playerID uuid.UUID) bool { return playerID == alice })
+
+	bobSub := hub.Subscribe(bob)
+	defer bobSub.Close()
+
+	if msg := hub.Chat.SendGlobalMessage(alice, "alice", "LOUD NOISES"); msg != nil {
+		t.Error("Muted player should not be able to send global chat")
+	}
+	if msg := hub.Chat.SendDirectMessage(alice, "alice", bob, "bob", "psst"); msg != nil {
+		t.Error("Muted player should not be able to send direct messages")
+	}
+	expectNoEvent(t, bobSub)
+
+	if msg := hub.Chat.SendGlobalMessage(bob, "bob", "hi"); msg == nil {
+		t.Error("Unmuted player should be able to chat")
+	}
+}
+
+// TestFactionWarCombat verifies that faction war PvP needs a war and a system
+// outside the safe zones, and that a win feeds the war score
+func TestFactionWarCombat(t *testing.T) {
+	hub := NewHub()
+	alice, bob := uuid.New(), uuid.New()
+	frontier, core := uuid.New(), uuid.New()
+	hub.SetSafeZones([]uuid.UUID{core})
+
+	red, err := hub.Factions.CreateFaction("Red", "RED", alice, models.AlignmentMercenary)
+	if err != nil {
+		t.Fatalf("CreateFaction failed: %v", err)
+	}
+	blue, err := hub.Factions.CreateFaction("Blue", "BLUE", bob, models.AlignmentMercenary)
+	if err != nil {
+		t.Fatalf("CreateFaction failed: %v", err)
+	}
+
+	attack := func(systemID uuid.UUID) (*models.PvPChallenge, error) {
+		return hub.PvP.CreateChallenge(alice, "alice", bob, "bob", models.ChallengeFactionWar, systemID, 0, "")
+	}
+	if _, err := attack(frontier); !errors.Is(err, ErrNotAtWar) {
+		t.Fatalf("Expected ErrNotAtWar before a war, got %v", err)
+	}
+
+	war, err := hub.Diplomacy.DeclareWar(context.Background(), "Red vs Blue", []uuid.UUID{red.ID}, []uuid.UUID{blue.ID})
+	if err != nil {
+		t.Fatalf("DeclareWar failed: %v", err)
+	}
+	if _, err := attack(core); !errors.Is(err, ErrSafeZone) {
+		t.Fatalf("Expected ErrSafeZone in a safe zone, got %v", err)
+	}
+
+	challenge, err := attack(frontier)
+	if err != nil {
+		t.Fatalf("Expected the attack to be allowed, got %v", err)
+	}
+	if challenge.Status != models.ChallengeActive {
+		t.Fatalf("Expected a faction war attack to start without consent, got %s", challenge.Status)
+	}
+	if _, err := hub.PvP.CompleteCombat(challenge.ID, alice, 0, 100, 50); err != nil {
+		t.Fatalf("CompleteCombat failed: %v", err)
+	}
+	if score := war.WarScore[red.ID]; score == 0 {
+		t.Error("Expected the win to add war score")
+	}
+}
+
+// TestAlliedFactionChat verifies that faction chat reaches allied factions
+func TestAlliedFactionChat(t *testing.T) {
+	hub := NewHub()
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+
+	red, err := hub.Factions.CreateFaction("Red", "RED", alice, models.AlignmentTrader)
+	if err != nil {
+		t.Fatalf("CreateFaction failed: %v", err)
+	}
+	blue, err := hub.Factions.CreateFaction("Blue", "BLUE", bob, models.AlignmentTrader)
+	if err != nil {
+		t.Fatalf("CreateFaction failed: %v", err)
+	}
+	if _, err := hub.Factions.CreateFaction("Green", "GRN", carol, models.AlignmentTrader); err != nil {
+		t.Fatalf("CreateFaction failed: %v", err)
+	}
+
+	if recipients := hub.FactionChatRecipients(red); len(recipients) != 1 {
+		t.Fatalf("Expected only Red's member before an alliance, got %d", len(recipients))
+	}
+	if _, err := hub.Diplomacy.FormAlliance(context.Background(), "Purple", "", red.ID, []uuid.UUID{blue.ID}); err != nil {
+		t.Fatalf("FormAlliance failed: %v", err)
+	}
+
+	recipients := hub.FactionChatRecipients(red)
+	if len(recipients) != 2 || recipients[1] != bob {
+		t.Errorf("Expected Red's chat to reach Blue's member, got %v", recipients)
+	}
+}
+
+// testPlayers is an in-memory pvp.Players for tests
+type testPlayers map[uuid.UUID]*models.Player
+
+func (p testPlayers) GetByID(ctx context.Context, id uuid.UUID) (*models.Player, error) {
+	return p[id], nil
+}
+
+// testShips is an in-memory pvp.Ships for tests
+type testShips map[uuid.UUID]*models.Ship
+
+func (s testShips) GetByID(ctx context.Context, id uuid.UUID) (*models.Ship, error) {
+	ship := *s[id]
+	return &ship, nil
+}
+
+// TestDisconnectForfeitsBattle verifies that both players receive battle
+// updates and that going offline forfeits a live battle
+func TestDisconnectForfeitsBattle(t *testing.T) {
+	hub := NewHub()
+	alice, bob := uuid.New(), uuid.New()
+	players, ships := testPlayers{}, testShips{}
+	for _, playerID := range []uuid.UUID{alice, bob} {
+		ship := &models.Ship{ID: uuid.New(), TypeID: "viper", Weapons: []string{"pulse_laser"}}
+		players[playerID] = &models.Player{ID: playerID, ShipID: ship.ID}
+		ships[ship.ID] = ship
+	}
+	hub.PvP.SetRepositories(players, ships)
+
+	hub.Presence.Connect(&models.Player{ID: alice, Username: "alice"}, nil)
+	hub.Presence.Connect(&models.Player{ID: bob, Username: "bob"}, nil)
+	aliceSub := hub.Subscribe(alice)
+	defer aliceSub.Close()
+
+	challenge, err := hub.PvP.CreateChallenge(alice, "alice", bob, "bob", models.ChallengeDuel, uuid.New(), 0, "")
+	if err != nil {
+		t.Fatalf("CreateChallenge failed: %v", err)
+	}
+	if err := hub.PvP.AcceptChallenge(challenge.ID, bob); err != nil {
+		t.Fatalf("AcceptChallenge failed: %v", err)
+	}
+
+	battleEvent := func() *pvp.BattleView {
+		for {
+			event, ok := receive(t, aliceSub)
+			if !ok {
+				t.Fatal("Expected a battle event")
+			}
+			if event.Type == EventPvPBattle {
+				return event.Payload.(*pvp.BattleView)
+			}
+		}
+	}
+	if view := battleEvent(); view.Over || view.ChallengeID != challenge.ID {
+		t.Fatalf("Expected the battle to start, got %+v", view)
+	}
+
+	hub.Presence.Disconnect(bob)
+	view := battleEvent()
+	if !view.Over || view.WinnerID != alice {
+		t.Fatalf("Expected alice to win when bob disconnects, got %+v", view)
+	}
+	if hub.PvP.GetStats(alice).Wins != 1 {
+		t.Error("Expected the forfeit to count as a win")
+	}
+}
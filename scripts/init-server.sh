@@ -2,7 +2,7 @@
 # File: scripts/init-server.sh
 # Project: Terminal Velocity
 # Description: Server initialization script - sets up database and universe
-# Version: 1.0.0
+# Version: 1.1.0
 # Author: Joshua Ferguson
 # Created: 2025-01-14
 
@@ -37,14 +37,14 @@ if ! command -v psql &> /dev/null; then
     exit 1
 fi
 
-# Check if genmap binary exists
-if [ ! -f "./genmap" ]; then
-    echo -e "${YELLOW}Building genmap tool...${NC}"
+# Check if the genmap and migrate binaries exist
+if [ ! -f "./genmap" ] || [ ! -f "./migrate" ]; then
+    echo -e "${YELLOW}Building genmap and migrate tools...${NC}"
     make build-tools || {
-        echo -e "${RED}Error: Failed to build genmap tool${NC}"
+        echo -e "${RED}Error: Failed to build tools${NC}"
         exit 1
     }
-    echo -e "${GREEN}✓ genmap tool built${NC}"
+    echo -e "${GREEN}✓ Tools built${NC}"
     echo ""
 fi
 
@@ -81,14 +81,10 @@ echo ""
 # Step 2: Initialize schema
 echo -e "${BLUE}Step 2: Schema Initialization${NC}"
 echo "----------------------------------------"
-echo "Running schema.sql..."
+echo "Applying migrations..."
 
-if [ ! -f "scripts/schema.sql" ]; then
-    echo -e "${RED}Error: scripts/schema.sql not found${NC}"
-    exit 1
-fi
-
-PGPASSWORD="${DB_PASSWORD}" psql -h "${DB_HOST}" -p "${DB_PORT}" -U "${DB_USER}" -d "${DB_NAME}" -f scripts/schema.sql
+DB_HOST="${DB_HOST}" DB_PORT="${DB_PORT}" DB_USER="${DB_USER}" DB_PASSWORD="${DB_PASSWORD}" DB_NAME="${DB_NAME}" \
+    ./migrate up
 
 echo -e "${GREEN}✓ Schema initialized${NC}"
 echo ""